@@ -18,6 +18,7 @@
 package aggregation
 
 import (
+	"math"
 	"strconv"
 
 	"github.com/lindb/lindb/aggregation/fields"
@@ -126,6 +127,11 @@ func (e *Expression) eval(parentFunc *stmt.CallExpr, expr stmt.Expr) []*collecti
 		return []*collections.FloatArray{values}
 	case *stmt.FieldExpr:
 		fieldName := ex.Name
+		if parentFunc != nil {
+			if values := e.gaugeAggValues(parentFunc.FuncType, field.Name(fieldName)); len(values) > 0 {
+				return values
+			}
+		}
 		fieldValues, ok := e.fieldStore[field.Name(fieldName)]
 		if !ok {
 			return nil
@@ -145,6 +151,7 @@ func (e *Expression) eval(parentFunc *stmt.CallExpr, expr stmt.Expr) []*collecti
 func (e *Expression) quantile(expr *stmt.CallExpr) []*collections.FloatArray {
 	var (
 		histogramFields = make(map[float64][]*collections.FloatArray)
+		sketchBins      = make(map[int32][]*collections.FloatArray)
 	)
 	if len(expr.Params) != 1 {
 		return nil
@@ -154,24 +161,114 @@ func (e *Expression) quantile(expr *stmt.CallExpr) []*collections.FloatArray {
 		return nil
 	}
 	for fieldName, df := range e.fieldStore {
-		if df.Type() == field.HistogramField {
+		switch df.Type() {
+		case field.HistogramField:
 			upperBound, err := metric.UpperBound(fieldName.String())
 			if err != nil {
 				continue
 			}
 			histogramFields[upperBound] = df.GetDefaultValues()
+		case field.SketchField:
+			index, err := metric.SketchBinIndex(fieldName.String())
+			if err != nil {
+				continue
+			}
+			sketchBins[index] = df.GetDefaultValues()
 		}
 	}
-	if len(histogramFields) == 0 {
+	var array *collections.FloatArray
+	switch {
+	case len(sketchBins) > 0:
+		// quantile sketch is more accurate than histogram
+		array, err = function.SketchQuantileCall(quantileValue, sketchBins)
+		if err == nil {
+			e.boundSketchQuantile(array)
+		}
+	case len(histogramFields) > 0:
+		array, err = function.QuantileCall(quantileValue, histogramFields)
+	default:
 		return nil
 	}
-	array, err := function.QuantileCall(quantileValue, histogramFields)
 	if err != nil {
 		return nil
 	}
 	return []*collections.FloatArray{array}
 }
 
+// gaugeAggValues returns the values of gauge field which function needs from its hidden aggregate fields,
+// avg needs sum and count. The slots without aggregates(data written before aggregates kept) fall back to
+// the value of gauge field, as avg of gauge value self. Returns nil if field is not gauge or function is not
+// answered by aggregates.
+func (e *Expression) gaugeAggValues(funcType function.FuncType, fieldName field.Name) []*collections.FloatArray {
+	gauge, ok := e.fieldStore[fieldName]
+	if !ok || gauge.Type() != field.GaugeField {
+		return nil
+	}
+	aggFields := field.GaugeAggFieldsByFunc(funcType)
+	if len(aggFields) == 0 {
+		return nil
+	}
+	result := make([]*collections.FloatArray, len(aggFields))
+	for idx, aggField := range aggFields {
+		result[idx] = collections.NewFloatArray(e.pointCount)
+		df, ok := e.fieldStore[aggField.Name(fieldName)]
+		if !ok {
+			continue
+		}
+		values := df.GetDefaultValues()
+		if len(values) == 0 || values[0] == nil {
+			continue
+		}
+		it := values[0].NewIterator()
+		for it.HasNext() {
+			pos, value := it.Next()
+			result[idx].SetValue(pos, value)
+		}
+	}
+	gaugeValues := gauge.GetValues(funcType)
+	if len(gaugeValues) == 0 || gaugeValues[0] == nil {
+		return result
+	}
+	it := gaugeValues[0].NewIterator()
+	for it.HasNext() {
+		pos, value := it.Next()
+		if result[0].HasValue(pos) {
+			continue
+		}
+		result[0].SetValue(pos, value)
+		if len(result) > 1 {
+			// count of gauge value for avg
+			result[1].SetValue(pos, 1)
+		}
+	}
+	return result
+}
+
+// boundSketchQuantile bounds the quantile of sketch by the min/max of sketch if exist,
+// because sketch only keeps relative accuracy, the quantile may be out of [min, max].
+func (e *Expression) boundSketchQuantile(array *collections.FloatArray) {
+	bound := func(fieldName field.Name, fn func(value, bound float64) float64) {
+		df, ok := e.fieldStore[fieldName]
+		if !ok {
+			return
+		}
+		values := df.GetDefaultValues()
+		if len(values) == 0 || values[0] == nil {
+			return
+		}
+		it := array.NewIterator()
+		for it.HasNext() {
+			pos, value := it.Next()
+			if pos < 0 || !values[0].HasValue(pos) {
+				continue
+			}
+			array.SetValue(pos, fn(value, values[0].GetValue(pos)))
+		}
+	}
+	bound(metric.HistogramMin, math.Max)
+	bound(metric.HistogramMax, math.Min)
+}
+
 // funcCall calls the function
 func (e *Expression) funcCall(expr *stmt.CallExpr) []*collections.FloatArray {
 	var params []*collections.FloatArray
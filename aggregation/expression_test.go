@@ -24,9 +24,12 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/series"
 	"github.com/lindb/lindb/series/field"
+	"github.com/lindb/lindb/series/metric"
 	"github.com/lindb/lindb/sql"
 	"github.com/lindb/lindb/sql/stmt"
 )
@@ -338,3 +341,98 @@ func TestExpression_NotSupport_Expr(t *testing.T) {
 	resultSet = expression.ResultSet()
 	assert.Equal(t, 0, len(resultSet))
 }
+
+func TestExpression_SketchQuantile(t *testing.T) {
+	timeRange := timeutil.TimeRange{Start: familyTime, End: familyTime + timeutil.OneHour}
+	interval := timeutil.Interval(timeutil.OneMinute)
+	newSeries := func(spec AggregatorSpec, value float64) []byte {
+		agg := NewSeriesAggregator(interval, 1, timeRange, spec)
+		fAgg, _ := agg.GetAggregator(familyTime)
+		fAgg.AggregateBySlot(5, value)
+		data, err := agg.ResultSet().MarshalBinary()
+		assert.NoError(t, err)
+		return data
+	}
+	newFieldSeries := func(fieldName field.Name, fieldType field.Type, funcType function.FuncType, value float64) []byte {
+		spec := NewAggregatorSpec(fieldName, fieldType)
+		spec.AddFunctionType(funcType)
+		return newSeries(spec, value)
+	}
+	fields := map[field.Name][]byte{
+		metric.SketchBinName(encoding.SketchBinIndex(10)):  newSeries(NewSketchBinAggregatorSpec(metric.SketchBinName(encoding.SketchBinIndex(10))), 10),
+		metric.SketchBinName(encoding.SketchBinIndex(100)): newSeries(NewSketchBinAggregatorSpec(metric.SketchBinName(encoding.SketchBinIndex(100))), 5),
+	}
+	eval := func(sql1 string) float64 {
+		q, err := sql.Parse(sql1)
+		assert.NoError(t, err)
+		expression := NewExpression(timeRange, timeutil.OneMinute, q.(*stmt.Query).SelectItems)
+		expression.Eval(series.NewGroupedIterator("host", fields))
+		for _, rs := range expression.ResultSet() {
+			return rs.GetValue(5)
+		}
+		return 0
+	}
+	// case 1: quantile of sketch
+	assert.InDelta(t, 10.0, eval("select quantile(0.5) from latency"), 0.2)
+	assert.InDelta(t, 100.0, eval("select quantile(0.99) from latency"), 2)
+	// case 2: quantile bounded by min/max of sketch
+	fields[metric.HistogramMin] = newFieldSeries(metric.HistogramMin, field.MinField, function.Min, 12)
+	fields[metric.HistogramMax] = newFieldSeries(metric.HistogramMax, field.MaxField, function.Max, 90)
+	assert.Equal(t, 12.0, eval("select quantile(0.5) from latency"))
+	assert.Equal(t, 90.0, eval("select quantile(0.99) from latency"))
+}
+
+func TestExpression_GaugeAggFields(t *testing.T) {
+	timeRange := timeutil.TimeRange{Start: familyTime, End: familyTime + timeutil.OneHour}
+	interval := timeutil.Interval(timeutil.OneMinute)
+	newSeries := func(fieldName field.Name, fieldType field.Type, values map[int]float64, funcTypes ...function.FuncType) []byte {
+		spec := NewAggregatorSpec(fieldName, fieldType)
+		for _, funcType := range funcTypes {
+			spec.AddFunctionType(funcType)
+		}
+		agg := NewSeriesAggregator(interval, 1, timeRange, spec)
+		fAgg, _ := agg.GetAggregator(familyTime)
+		for slot, value := range values {
+			fAgg.AggregateBySlot(slot, value)
+		}
+		data, err := agg.ResultSet().MarshalBinary()
+		assert.NoError(t, err)
+		return data
+	}
+	// slot 5 has aggregates of gauge, slot 6 only has gauge value(written before aggregates kept)
+	fields := map[field.Name][]byte{
+		"usage": newSeries("usage", field.GaugeField, map[int]float64{5: 10, 6: 20},
+			function.Max, function.Avg),
+	}
+	eval := func(sql1 string) *collections.FloatArray {
+		q, err := sql.Parse(sql1)
+		assert.NoError(t, err)
+		expression := NewExpression(timeRange, timeutil.OneMinute, q.(*stmt.Query).SelectItems)
+		expression.Eval(series.NewGroupedIterator("host", fields))
+		for _, rs := range expression.ResultSet() {
+			return rs
+		}
+		return nil
+	}
+	// case 1: no aggregates, avg of gauge value self
+	rs := eval("select avg(usage) from cpu")
+	assert.Equal(t, 10.0, rs.GetValue(5))
+	assert.Equal(t, 20.0, rs.GetValue(6))
+	// case 2: answered by aggregates, fall back to gauge value
+	maxField := field.GaugeAggFields[1].Name("usage")
+	sumField := field.GaugeAggFields[2].Name("usage")
+	countField := field.GaugeAggFields[3].Name("usage")
+	fields[maxField] = newSeries(maxField, field.MaxField, map[int]float64{5: 90}, function.Max)
+	fields[sumField] = newSeries(sumField, field.SumField, map[int]float64{5: 120}, function.Sum)
+	fields[countField] = newSeries(countField, field.SumField, map[int]float64{5: 3}, function.Sum)
+	rs = eval("select max(usage) from cpu")
+	assert.Equal(t, 90.0, rs.GetValue(5))
+	assert.Equal(t, 20.0, rs.GetValue(6))
+	rs = eval("select avg(usage) from cpu")
+	assert.Equal(t, 40.0, rs.GetValue(5))
+	assert.Equal(t, 20.0, rs.GetValue(6))
+	// case 3: gauge value without function
+	rs = eval("select usage from cpu")
+	assert.Equal(t, 10.0, rs.GetValue(5))
+	assert.Equal(t, 20.0, rs.GetValue(6))
+}
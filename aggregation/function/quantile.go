@@ -23,6 +23,7 @@ import (
 	"sort"
 
 	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/pkg/encoding"
 )
 
 type bucket struct {
@@ -123,3 +124,43 @@ func QuantileCall(q float64, histogramFields map[float64][]*collections.FloatArr
 
 	return targetFloatArray, nil
 }
+
+// SketchQuantileCall computes quantile from the counts of quantile sketch bins(bin index => counts),
+// bins of different series/interval are merged by sum, so the quantile keeps the relative accuracy of sketch.
+// 0 <= q <= 1
+func SketchQuantileCall(q float64, sketchBins map[int32][]*collections.FloatArray) (*collections.FloatArray, error) {
+	if q < 0 || q > 1 {
+		return nil, fmt.Errorf("SketchQuantileCall with illegal value: %f", q)
+	}
+	if len(sketchBins) == 0 {
+		return nil, fmt.Errorf("SketchQuantileCall without sketch bins")
+	}
+	capacity := 0
+	for _, arrays := range sketchBins {
+		if len(arrays) != 1 {
+			return nil, fmt.Errorf("SketchQuantileCall bins's floatArray count: %d not equals 1", len(arrays))
+		}
+		if capacity < arrays[0].Capacity() {
+			capacity = arrays[0].Capacity()
+		}
+	}
+	targetFloatArray := collections.NewFloatArray(capacity)
+	sketch := encoding.NewSketch()
+	for pos := 0; pos < capacity; pos++ {
+		sketch.Reset()
+		for index, arrays := range sketchBins {
+			if arrays[0].HasValue(pos) {
+				sketch.AddBin(index, arrays[0].GetValue(pos))
+			}
+		}
+		if sketch.Count() == 0 {
+			continue
+		}
+		v, err := sketch.Quantile(q)
+		if err != nil {
+			return nil, err
+		}
+		targetFloatArray.SetValue(pos, v)
+	}
+	return targetFloatArray, nil
+}
@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/pkg/encoding"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -162,3 +163,30 @@ func Test_QuantileCallBadCases(t *testing.T) {
 	_, err = QuantileCall(0.9, fields)
 	assert.Error(t, err)
 }
+
+func Test_SketchQuantileCall(t *testing.T) {
+	_, err := SketchQuantileCall(-1, nil)
+	assert.Error(t, err)
+	_, err = SketchQuantileCall(0.99, nil)
+	assert.Error(t, err)
+	_, err = SketchQuantileCall(0.99, map[int32][]*collections.FloatArray{1: nil})
+	assert.Error(t, err)
+
+	// two sketches(from different hosts) are merged by bin counts
+	sketch := encoding.NewSketch()
+	for i := 1; i <= 1000; i++ {
+		sketch.Add(float64(i))
+	}
+	bins := make(map[int32][]*collections.FloatArray)
+	sketch.Bins(func(index int32, count float64) {
+		array := collections.NewFloatArray(3)
+		array.SetValue(0, count)
+		array.SetValue(2, count*2)
+		bins[index] = []*collections.FloatArray{array}
+	})
+	array, err := SketchQuantileCall(0.999, bins)
+	assert.NoError(t, err)
+	assert.False(t, array.HasValue(1))
+	assert.InDelta(t, 999, array.GetValue(0), 999*encoding.SketchRelativeAccuracy)
+	assert.InDelta(t, 999, array.GetValue(2), 999*encoding.SketchRelativeAccuracy)
+}
@@ -20,6 +20,7 @@ package aggregation
 import (
 	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
 )
 
 //go:generate mockgen -source=./group_agg.go -destination=./group_agg_mock.go -package=aggregation
@@ -72,7 +73,13 @@ func (ga *groupingAggregator) Aggregate(it series.GroupedIterator) {
 			}
 		}
 		if sAgg == nil {
-			continue
+			// quantile sketch bins are not in aggregator specs, creates bin aggregator dynamically
+			if seriesIt.FieldType() != field.SketchField {
+				continue
+			}
+			sAgg = NewSeriesAggregator(ga.interval, ga.intervalRatio, ga.timeRange, NewSketchBinAggregatorSpec(fieldName))
+			seriesAgg = append(seriesAgg, sAgg)
+			ga.aggregates[it.Tags()] = seriesAgg
 		}
 		// 2. merge the field series data
 		for seriesIt.HasNext() {
@@ -17,6 +17,17 @@
 
 package aggregation
 
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+)
+
 //func TestGroupByAggregator_Aggregate(t *testing.T) {
 //	ctrl := gomock.NewController(t)
 //	defer ctrl.Finish()
@@ -102,3 +113,45 @@ package aggregation
 //rs = agg.ResultSet()
 //assert.Nil(t, rs)
 //}
+
+func TestGroupingAggregator_SketchBins(t *testing.T) {
+	interval := timeutil.Interval(timeutil.OneSecond * 10)
+	timeRange := timeutil.TimeRange{Start: 0, End: timeutil.OneHour}
+	sumSpec := NewAggregatorSpec("f1", field.SumField)
+	sumSpec.AddFunctionType(function.Sum)
+
+	binAgg := NewSeriesAggregator(interval, 1, timeRange, NewSketchBinAggregatorSpec("__sketch_10"))
+	agg, ok := binAgg.GetAggregator(0)
+	assert.True(t, ok)
+	agg.AggregateBySlot(1, 3)
+	data, err := binAgg.ResultSet().MarshalBinary()
+	assert.NoError(t, err)
+
+	f2Agg := NewSeriesAggregator(interval, 1, timeRange, NewAggregatorSpec("f2", field.SumField))
+	agg, _ = f2Agg.GetAggregator(0)
+	agg.AggregateBySlot(1, 3)
+	f2Data, err := f2Agg.ResultSet().MarshalBinary()
+	assert.NoError(t, err)
+
+	ga := NewGroupingAggregator(interval, 1, timeRange, AggregatorSpecs{sumSpec})
+	// sketch bin not in specs, aggregates dynamically
+	ga.Aggregate(series.NewGroupedIterator("host", map[field.Name][]byte{"__sketch_10": data}))
+	ga.Aggregate(series.NewGroupedIterator("host", map[field.Name][]byte{"__sketch_10": data}))
+	// other field not in specs, ignore it
+	ga.Aggregate(series.NewGroupedIterator("host", map[field.Name][]byte{"f2": f2Data}))
+
+	rs := ga.ResultSet()
+	assert.Len(t, rs, 1)
+	var binIt series.Iterator
+	for rs[0].HasNext() {
+		it := rs[0].Next()
+		if it.FieldName() == "__sketch_10" {
+			binIt = it
+		}
+	}
+	assert.NotNil(t, binIt)
+	assert.Equal(t, field.SketchField, binIt.FieldType())
+	assert.True(t, binIt.HasNext())
+	_, fieldIt := binIt.Next()
+	AssertFieldIt(t, fieldIt, map[int]float64{1: 6})
+}
@@ -50,6 +50,14 @@ func NewAggregatorSpec(fieldName field.Name, fieldType field.Type) AggregatorSpe
 	}
 }
 
+// NewSketchBinAggregatorSpec creates the aggregator spec of quantile sketch bin,
+// sketch bins are expanded from sketches when querying, so bin aggregator is created dynamically.
+func NewSketchBinAggregatorSpec(binName field.Name) AggregatorSpec {
+	spec := NewAggregatorSpec(binName, field.SketchField)
+	spec.AddFunctionType(function.Sum)
+	return spec
+}
+
 func (a *aggregatorSpec) GetFieldType() field.Type {
 	return a.fieldType
 }
@@ -38,3 +38,10 @@ func TestAggregatorSpec_AddFunctionType(t *testing.T) {
 	agg.AddFunctionType(function.Sum)
 	assert.Equal(t, 1, len(agg.Functions()))
 }
+
+func TestNewSketchBinAggregatorSpec(t *testing.T) {
+	agg := NewSketchBinAggregatorSpec("__sketch_10")
+	assert.Equal(t, field.Name("__sketch_10"), agg.FieldName())
+	assert.Equal(t, field.SketchField, agg.GetFieldType())
+	assert.Equal(t, map[function.FuncType]function.FuncType{function.Sum: function.Sum}, agg.Functions())
+}
@@ -120,6 +120,17 @@ func (d *DatabaseAPI) saveDataBase(database *models.Database) error {
 	if err := database.Option.Validate(); err != nil {
 		return err
 	}
+	if database.Limits != nil {
+		if err := database.Limits.Validate(); err != nil {
+			return err
+		}
+	}
+	// alter database, check if new option is compatible with the running database
+	if old, err := d.getByName(database.Name); err == nil {
+		if err := database.Option.ValidateAlter(old.Option); err != nil {
+			return err
+		}
+	}
 	data := encoding.JSONMarshal(database)
 
 	ctx, cancel := d.deps.WithTimeout()
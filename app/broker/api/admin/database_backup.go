@@ -0,0 +1,139 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package admin
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/lindb/lindb/app/broker/deps"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	httppkg "github.com/lindb/lindb/pkg/http"
+	"github.com/lindb/lindb/pkg/logger"
+)
+
+var (
+	// for testing
+	httpDo = http.DefaultClient.Do
+	// BackupDatabasePath represents database backup api path.
+	BackupDatabasePath = "/database/backup"
+	// RestoreDatabasePath represents database restore api path.
+	RestoreDatabasePath = "/database/restore"
+)
+
+// DatabaseBackupParam represents the param of database backup.
+type DatabaseBackupParam struct {
+	Cluster  string `json:"cluster" binding:"required"`
+	Database string `json:"database" binding:"required"`
+	Path     string `json:"path" binding:"required"` // backup path of each storage node
+}
+
+// DatabaseRestoreParam represents the param of database restore.
+type DatabaseRestoreParam struct {
+	Cluster  string           `json:"cluster" binding:"required"`
+	Database string           `json:"database" binding:"required"`
+	Path     string           `json:"path" binding:"required"` // backup path of each storage node
+	ShardIDs []models.ShardID `json:"shardIDs"`                // restore all shards in backup(all assigned shards if database exists) if empty
+}
+
+// DatabaseBackupAPI represents the database backup/restore by manual.
+type DatabaseBackupAPI struct {
+	deps *deps.HTTPDeps
+
+	logger *logger.Logger
+}
+
+// NewDatabaseBackupAPI create database backup api.
+func NewDatabaseBackupAPI(deps *deps.HTTPDeps) *DatabaseBackupAPI {
+	return &DatabaseBackupAPI{
+		deps:   deps,
+		logger: logger.GetLogger("broker", "DatabaseBackupAPI"),
+	}
+}
+
+// Register adds database backup/restore admin url route.
+func (api *DatabaseBackupAPI) Register(route gin.IRoutes) {
+	route.PUT(BackupDatabasePath, api.SubmitBackupTask)
+	route.PUT(RestoreDatabasePath, api.SubmitRestoreTask)
+}
+
+// SubmitBackupTask submits the task which backups database into path of all storage nodes.
+func (api *DatabaseBackupAPI) SubmitBackupTask(c *gin.Context) {
+	param := &DatabaseBackupParam{}
+	if err := c.ShouldBind(param); err != nil {
+		httppkg.Error(c, err)
+		return
+	}
+	if api.deps.Master.IsMaster() {
+		if err := api.deps.Master.BackupDatabase(param.Cluster, param.Database, param.Path); err != nil {
+			httppkg.Error(c, err)
+			return
+		}
+	} else if err := forwardToMaster(api.deps, api.logger, c, param); err != nil {
+		httppkg.Error(c, err)
+		return
+	}
+	httppkg.OK(c, "success")
+}
+
+// SubmitRestoreTask submits the task which restores database from backup path of all storage nodes.
+func (api *DatabaseBackupAPI) SubmitRestoreTask(c *gin.Context) {
+	param := &DatabaseRestoreParam{}
+	if err := c.ShouldBind(param); err != nil {
+		httppkg.Error(c, err)
+		return
+	}
+	if api.deps.Master.IsMaster() {
+		if err := api.deps.Master.RestoreDatabase(param.Cluster, param.Database, param.Path, param.ShardIDs); err != nil {
+			httppkg.Error(c, err)
+			return
+		}
+	} else if err := forwardToMaster(api.deps, api.logger, c, param); err != nil {
+		httppkg.Error(c, err)
+		return
+	}
+	httppkg.OK(c, "success")
+}
+
+// forwardToMaster forwards the request to master node if current node is not master.
+func forwardToMaster(deps *deps.HTTPDeps, log *logger.Logger, c *gin.Context, param interface{}) error {
+	masterNode := deps.Master.GetMaster().Node
+	req, err := http.NewRequest(http.MethodPut,
+		fmt.Sprintf("http://%s:%d%s", masterNode.HostIP, masterNode.HTTPPort, c.Request.URL.RequestURI()),
+		bytes.NewReader(encoding.JSONMarshal(param)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := httpDo(req)
+	if err != nil {
+		return err
+	}
+	if resp.Body != nil {
+		if err := resp.Body.Close(); err != nil {
+			log.Error("close http response body", logger.Error(err))
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("master handle error after forward")
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/app/broker/deps"
+	"github.com/lindb/lindb/coordinator"
+	"github.com/lindb/lindb/internal/mock"
+	"github.com/lindb/lindb/models"
+)
+
+func TestDatabaseBackupAPI_Backup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		httpDo = http.DefaultClient.Do
+		ctrl.Finish()
+	}()
+
+	master := coordinator.NewMockMaster(ctrl)
+	api := NewDatabaseBackupAPI(&deps.HTTPDeps{
+		Master: master,
+	})
+	r := gin.New()
+	api.Register(r)
+	body := `{"cluster":"test","database":"db","path":"/backup/db"}`
+
+	// no path
+	resp := mock.DoRequest(t, r, http.MethodPut, BackupDatabasePath, `{"cluster":"test","database":"db"}`)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// submit err
+	master.EXPECT().IsMaster().Return(true)
+	master.EXPECT().BackupDatabase("test", "db", "/backup/db").Return(fmt.Errorf("err"))
+	resp = mock.DoRequest(t, r, http.MethodPut, BackupDatabasePath, body)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// submit ok
+	master.EXPECT().IsMaster().Return(true)
+	master.EXPECT().BackupDatabase("test", "db", "/backup/db").Return(nil)
+	resp = mock.DoRequest(t, r, http.MethodPut, BackupDatabasePath, body)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	master.EXPECT().IsMaster().Return(false).AnyTimes()
+	master.EXPECT().GetMaster().Return(&models.Master{
+		Node: &models.StatelessNode{
+			HostIP:   "127.0.0.1",
+			HTTPPort: 12345,
+		},
+	}).AnyTimes()
+	// forward err
+	httpDo = func(req *http.Request) (*http.Response, error) {
+		return nil, fmt.Errorf("err")
+	}
+	resp = mock.DoRequest(t, r, http.MethodPut, BackupDatabasePath, body)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// master handle err
+	httpDo = func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusInternalServerError}, nil
+	}
+	resp = mock.DoRequest(t, r, http.MethodPut, BackupDatabasePath, body)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// forward ok, close body err
+	httpDo = func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "http://127.0.0.1:12345"+BackupDatabasePath, req.URL.String())
+		return &http.Response{StatusCode: http.StatusOK, Body: &mockIOReader{}}, nil
+	}
+	resp = mock.DoRequest(t, r, http.MethodPut, BackupDatabasePath, body)
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestDatabaseBackupAPI_Restore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		httpDo = http.DefaultClient.Do
+		ctrl.Finish()
+	}()
+
+	master := coordinator.NewMockMaster(ctrl)
+	api := NewDatabaseBackupAPI(&deps.HTTPDeps{
+		Master: master,
+	})
+	r := gin.New()
+	api.Register(r)
+	body := `{"cluster":"test","database":"db","path":"/backup/db","shardIDs":[1,2]}`
+
+	// no path
+	resp := mock.DoRequest(t, r, http.MethodPut, RestoreDatabasePath, `{"cluster":"test","database":"db"}`)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// submit err
+	master.EXPECT().IsMaster().Return(true)
+	master.EXPECT().RestoreDatabase("test", "db", "/backup/db", []models.ShardID{1, 2}).Return(fmt.Errorf("err"))
+	resp = mock.DoRequest(t, r, http.MethodPut, RestoreDatabasePath, body)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// submit ok
+	master.EXPECT().IsMaster().Return(true)
+	master.EXPECT().RestoreDatabase("test", "db", "/backup/db", []models.ShardID{1, 2}).Return(nil)
+	resp = mock.DoRequest(t, r, http.MethodPut, RestoreDatabasePath, body)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	// forward to master
+	master.EXPECT().IsMaster().Return(false)
+	master.EXPECT().GetMaster().Return(&models.Master{
+		Node: &models.StatelessNode{
+			HostIP:   "127.0.0.1",
+			HTTPPort: 12345,
+		},
+	})
+	httpDo = func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	}
+	resp = mock.DoRequest(t, r, http.MethodPut, RestoreDatabasePath, body)
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
@@ -0,0 +1,77 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/lindb/lindb/app/broker/deps"
+	httppkg "github.com/lindb/lindb/pkg/http"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/ltoml"
+)
+
+var (
+	// DropDatabasePath represents database drop api path.
+	DropDatabasePath = "/database/drop"
+)
+
+// DatabaseDropParam represents the param of database drop.
+type DatabaseDropParam struct {
+	Cluster    string         `json:"cluster" binding:"required"`
+	Database   string         `json:"database" binding:"required"`
+	PurgeDelay ltoml.Duration `json:"purgeDelay"` // keep dropped data in trash of storage nodes, remove directly if zero
+}
+
+// DatabaseDropAPI represents the database drop by manual.
+type DatabaseDropAPI struct {
+	deps *deps.HTTPDeps
+
+	logger *logger.Logger
+}
+
+// NewDatabaseDropAPI create database drop api.
+func NewDatabaseDropAPI(deps *deps.HTTPDeps) *DatabaseDropAPI {
+	return &DatabaseDropAPI{
+		deps:   deps,
+		logger: logger.GetLogger("broker", "DatabaseDropAPI"),
+	}
+}
+
+// Register adds database drop admin url route.
+func (api *DatabaseDropAPI) Register(route gin.IRoutes) {
+	route.PUT(DropDatabasePath, api.Drop)
+}
+
+// Drop deletes database config, then submits the task which drops database in all storage nodes.
+func (api *DatabaseDropAPI) Drop(c *gin.Context) {
+	param := &DatabaseDropParam{}
+	if err := c.ShouldBind(param); err != nil {
+		httppkg.Error(c, err)
+		return
+	}
+	if api.deps.Master.IsMaster() {
+		if err := api.deps.Master.DropDatabase(param.Cluster, param.Database, param.PurgeDelay.Duration()); err != nil {
+			httppkg.Error(c, err)
+			return
+		}
+	} else if err := forwardToMaster(api.deps, api.logger, c, param); err != nil {
+		httppkg.Error(c, err)
+		return
+	}
+	httppkg.OK(c, "success")
+}
@@ -0,0 +1,84 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/app/broker/deps"
+	"github.com/lindb/lindb/coordinator"
+	"github.com/lindb/lindb/internal/mock"
+	"github.com/lindb/lindb/models"
+)
+
+func TestDatabaseDropAPI_Drop(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		httpDo = http.DefaultClient.Do
+		ctrl.Finish()
+	}()
+
+	master := coordinator.NewMockMaster(ctrl)
+	api := NewDatabaseDropAPI(&deps.HTTPDeps{
+		Master: master,
+	})
+	r := gin.New()
+	api.Register(r)
+	body := `{"cluster":"test","database":"db","purgeDelay":"24h"}`
+
+	// no database
+	resp := mock.DoRequest(t, r, http.MethodPut, DropDatabasePath, `{"cluster":"test"}`)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// drop err
+	master.EXPECT().IsMaster().Return(true)
+	master.EXPECT().DropDatabase("test", "db", 24*time.Hour).Return(fmt.Errorf("err"))
+	resp = mock.DoRequest(t, r, http.MethodPut, DropDatabasePath, body)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// drop ok without purge delay
+	master.EXPECT().IsMaster().Return(true)
+	master.EXPECT().DropDatabase("test", "db", time.Duration(0)).Return(nil)
+	resp = mock.DoRequest(t, r, http.MethodPut, DropDatabasePath, `{"cluster":"test","database":"db"}`)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	master.EXPECT().IsMaster().Return(false).AnyTimes()
+	master.EXPECT().GetMaster().Return(&models.Master{
+		Node: &models.StatelessNode{
+			HostIP:   "127.0.0.1",
+			HTTPPort: 12345,
+		},
+	}).AnyTimes()
+	// forward err
+	httpDo = func(req *http.Request) (*http.Response, error) {
+		return nil, fmt.Errorf("err")
+	}
+	resp = mock.DoRequest(t, r, http.MethodPut, DropDatabasePath, body)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// forward ok
+	httpDo = func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "http://127.0.0.1:12345"+DropDatabasePath, req.URL.String())
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	}
+	resp = mock.DoRequest(t, r, http.MethodPut, DropDatabasePath, body)
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
@@ -0,0 +1,77 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/lindb/lindb/app/broker/deps"
+	"github.com/lindb/lindb/models"
+	httppkg "github.com/lindb/lindb/pkg/http"
+	"github.com/lindb/lindb/pkg/logger"
+)
+
+var (
+	// RebuildIndexPath represents series index rebuild api path.
+	RebuildIndexPath = "/database/index/rebuild"
+)
+
+// IndexRebuildParam represents the param of series index rebuild.
+type IndexRebuildParam struct {
+	Cluster  string           `json:"cluster" binding:"required"`
+	Database string           `json:"database" binding:"required"`
+	ShardIDs []models.ShardID `json:"shardIDs" binding:"required"`
+}
+
+// DatabaseIndexAPI represents the series index repair of database by manual.
+type DatabaseIndexAPI struct {
+	deps *deps.HTTPDeps
+
+	logger *logger.Logger
+}
+
+// NewDatabaseIndexAPI create database index api.
+func NewDatabaseIndexAPI(deps *deps.HTTPDeps) *DatabaseIndexAPI {
+	return &DatabaseIndexAPI{
+		deps:   deps,
+		logger: logger.GetLogger("broker", "DatabaseIndexAPI"),
+	}
+}
+
+// Register adds database index admin url route.
+func (api *DatabaseIndexAPI) Register(route gin.IRoutes) {
+	route.PUT(RebuildIndexPath, api.SubmitRebuildTask)
+}
+
+// SubmitRebuildTask submits the task which rebuilds series index of shards in all storage nodes.
+func (api *DatabaseIndexAPI) SubmitRebuildTask(c *gin.Context) {
+	param := &IndexRebuildParam{}
+	if err := c.ShouldBind(param); err != nil {
+		httppkg.Error(c, err)
+		return
+	}
+	if api.deps.Master.IsMaster() {
+		if err := api.deps.Master.RebuildIndex(param.Cluster, param.Database, param.ShardIDs); err != nil {
+			httppkg.Error(c, err)
+			return
+		}
+	} else if err := forwardToMaster(api.deps, api.logger, c, param); err != nil {
+		httppkg.Error(c, err)
+		return
+	}
+	httppkg.OK(c, "success")
+}
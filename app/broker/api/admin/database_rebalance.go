@@ -0,0 +1,108 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/lindb/lindb/app/broker/deps"
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	httppkg "github.com/lindb/lindb/pkg/http"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/ltoml"
+)
+
+var (
+	// RebalanceDatabasePath represents shard rebalance api path.
+	RebalanceDatabasePath = "/database/rebalance"
+)
+
+// DatabaseRebalanceParam represents the param of shard rebalance.
+type DatabaseRebalanceParam struct {
+	Cluster        string         `json:"cluster" binding:"required"`
+	Database       string         `json:"database" binding:"required"`
+	MaxConcurrency int            `json:"maxConcurrency"` // max num. of shards migrating concurrently
+	CatchUpWait    ltoml.Duration `json:"catchUpWait"`    // max wait for new replica catching up before switching leader
+}
+
+// DatabaseRebalanceAPI represents the shard rebalance of database by manual.
+type DatabaseRebalanceAPI struct {
+	deps *deps.HTTPDeps
+
+	logger *logger.Logger
+}
+
+// NewDatabaseRebalanceAPI create database rebalance api.
+func NewDatabaseRebalanceAPI(deps *deps.HTTPDeps) *DatabaseRebalanceAPI {
+	return &DatabaseRebalanceAPI{
+		deps:   deps,
+		logger: logger.GetLogger("broker", "DatabaseRebalanceAPI"),
+	}
+}
+
+// Register adds database rebalance admin url route.
+func (api *DatabaseRebalanceAPI) Register(route gin.IRoutes) {
+	route.PUT(RebalanceDatabasePath, api.Rebalance)
+	route.GET(RebalanceDatabasePath, api.GetProgress)
+}
+
+// Rebalance starts shard migrations which spread replicas/leaders evenly among live storage nodes.
+func (api *DatabaseRebalanceAPI) Rebalance(c *gin.Context) {
+	param := &DatabaseRebalanceParam{}
+	if err := c.ShouldBind(param); err != nil {
+		httppkg.Error(c, err)
+		return
+	}
+	if api.deps.Master.IsMaster() {
+		if err := api.deps.Master.RebalanceDatabase(param.Cluster, param.Database,
+			param.MaxConcurrency, param.CatchUpWait.Duration()); err != nil {
+			httppkg.Error(c, err)
+			return
+		}
+	} else if err := forwardToMaster(api.deps, api.logger, c, param); err != nil {
+		httppkg.Error(c, err)
+		return
+	}
+	httppkg.OK(c, "success")
+}
+
+// GetProgress returns the progress of shard rebalance by database name.
+func (api *DatabaseRebalanceAPI) GetProgress(c *gin.Context) {
+	var param struct {
+		Database string `form:"database" binding:"required"`
+	}
+	if err := c.ShouldBindQuery(&param); err != nil {
+		httppkg.Error(c, err)
+		return
+	}
+	ctx, cancel := api.deps.WithTimeout()
+	defer cancel()
+
+	data, err := api.deps.Repo.Get(ctx, constants.GetDatabaseRebalancePath(param.Database))
+	if err != nil {
+		httppkg.NotFound(c)
+		return
+	}
+	progress := &models.RebalanceProgress{}
+	if err := encoding.JSONUnmarshal(data, progress); err != nil {
+		httppkg.Error(c, err)
+		return
+	}
+	httppkg.OK(c, progress)
+}
@@ -0,0 +1,124 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package admin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/app/broker/deps"
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/coordinator"
+	"github.com/lindb/lindb/internal/mock"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/ltoml"
+	"github.com/lindb/lindb/pkg/state"
+)
+
+func TestDatabaseRebalanceAPI_Rebalance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		httpDo = http.DefaultClient.Do
+		ctrl.Finish()
+	}()
+
+	master := coordinator.NewMockMaster(ctrl)
+	api := NewDatabaseRebalanceAPI(&deps.HTTPDeps{
+		Master: master,
+	})
+	r := gin.New()
+	api.Register(r)
+	body := `{"cluster":"test","database":"db","maxConcurrency":2,"catchUpWait":"30s"}`
+
+	// no database
+	resp := mock.DoRequest(t, r, http.MethodPut, RebalanceDatabasePath, `{"cluster":"test"}`)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// rebalance err
+	master.EXPECT().IsMaster().Return(true)
+	master.EXPECT().RebalanceDatabase("test", "db", 2, 30*time.Second).Return(fmt.Errorf("err"))
+	resp = mock.DoRequest(t, r, http.MethodPut, RebalanceDatabasePath, body)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// rebalance ok
+	master.EXPECT().IsMaster().Return(true)
+	master.EXPECT().RebalanceDatabase("test", "db", 2, 30*time.Second).Return(nil)
+	resp = mock.DoRequest(t, r, http.MethodPut, RebalanceDatabasePath, body)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	master.EXPECT().IsMaster().Return(false).AnyTimes()
+	master.EXPECT().GetMaster().Return(&models.Master{
+		Node: &models.StatelessNode{
+			HostIP:   "127.0.0.1",
+			HTTPPort: 12345,
+		},
+	}).AnyTimes()
+	// forward err
+	httpDo = func(req *http.Request) (*http.Response, error) {
+		return nil, fmt.Errorf("err")
+	}
+	resp = mock.DoRequest(t, r, http.MethodPut, RebalanceDatabasePath, body)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// forward ok
+	httpDo = func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "http://127.0.0.1:12345"+RebalanceDatabasePath, req.URL.String())
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	}
+	resp = mock.DoRequest(t, r, http.MethodPut, RebalanceDatabasePath, body)
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestDatabaseRebalanceAPI_GetProgress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := state.NewMockRepository(ctrl)
+	api := NewDatabaseRebalanceAPI(&deps.HTTPDeps{
+		Ctx:       context.Background(),
+		Repo:      repo,
+		BrokerCfg: &config.Broker{BrokerBase: config.BrokerBase{HTTP: config.HTTP{ReadTimeout: ltoml.Duration(time.Second * 10)}}},
+	})
+	r := gin.New()
+	api.Register(r)
+
+	// no database
+	resp := mock.DoRequest(t, r, http.MethodGet, RebalanceDatabasePath, "")
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// progress not exist
+	repo.EXPECT().Get(gomock.Any(), constants.GetDatabaseRebalancePath("db")).Return(nil, state.ErrNotExist)
+	resp = mock.DoRequest(t, r, http.MethodGet, RebalanceDatabasePath+"?database=db", "")
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	// unmarshal progress err
+	repo.EXPECT().Get(gomock.Any(), constants.GetDatabaseRebalancePath("db")).Return([]byte("abc"), nil)
+	resp = mock.DoRequest(t, r, http.MethodGet, RebalanceDatabasePath+"?database=db", "")
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// get progress
+	progress := &models.RebalanceProgress{Database: "db", Migrations: []*models.ShardMigration{
+		{Kind: models.MoveReplica, ShardID: 1, From: 1, To: 2, State: models.MigrationRunning},
+	}}
+	repo.EXPECT().Get(gomock.Any(), constants.GetDatabaseRebalancePath("db")).Return(encoding.JSONMarshal(progress), nil)
+	resp = mock.DoRequest(t, r, http.MethodGet, RebalanceDatabasePath+"?database=db", "")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, string(encoding.JSONMarshal(progress)), resp.Body.String())
+}
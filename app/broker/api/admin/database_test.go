@@ -84,9 +84,21 @@ func TestDatabaseAPI_Save(t *testing.T) {
 	data := encoding.JSONMarshal(&database)
 	reps = mock.DoRequest(t, r, http.MethodPost, DatabasePath, string(data))
 	assert.Equal(t, http.StatusInternalServerError, reps.Code)
-	// put
+	// limits error
 	database.Option = option.DatabaseOption{Interval: "10s"}
+	database.Limits = &models.DatabaseLimits{WriteLimits: models.WriteLimits{RowsPerSecond: -1}}
+	data = encoding.JSONMarshal(&database)
+	reps = mock.DoRequest(t, r, http.MethodPost, DatabasePath, string(data))
+	assert.Equal(t, http.StatusInternalServerError, reps.Code)
+	// alter error, write interval cannot be changed
+	database.Limits = nil
 	data = encoding.JSONMarshal(&database)
+	repo.EXPECT().Get(gomock.Any(), gomock.Any()).
+		Return(encoding.JSONMarshal(&models.Database{Option: option.DatabaseOption{Interval: "20s"}}), nil)
+	reps = mock.DoRequest(t, r, http.MethodPost, DatabasePath, string(data))
+	assert.Equal(t, http.StatusInternalServerError, reps.Code)
+	// put
+	repo.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, state.ErrNotExist)
 	repo.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 	reps = mock.DoRequest(t, r, http.MethodPost, DatabasePath, string(data))
 	assert.Equal(t, http.StatusNoContent, reps.Code)
@@ -0,0 +1,122 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package admin
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/lindb/lindb/app/broker/deps"
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/http"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/state"
+)
+
+// ingestionRuleParam represents the query param of ingestion rule api.
+type ingestionRuleParam struct {
+	DatabaseName string `form:"db" binding:"required"`
+}
+
+// IngestionRuleAPI represents database ingestion relabel/drop rule admin rest api.
+type IngestionRuleAPI struct {
+	deps   *deps.HTTPDeps
+	logger *logger.Logger
+}
+
+// NewIngestionRuleAPI creates ingestion rule api instance.
+func NewIngestionRuleAPI(deps *deps.HTTPDeps) *IngestionRuleAPI {
+	return &IngestionRuleAPI{
+		deps:   deps,
+		logger: logger.GetLogger("broker", "IngestionRuleAPI"),
+	}
+}
+
+// Register adds ingestion rule admin url route.
+func (api *IngestionRuleAPI) Register(route gin.IRoutes) {
+	route.POST(constants.IngestionRulePath, api.Save)
+	route.GET(constants.IngestionRulePath, api.GetByDatabase)
+	route.DELETE(constants.IngestionRulePath, api.DeleteByDatabase)
+}
+
+// Save creates/replaces the ingestion rules of database.
+func (api *IngestionRuleAPI) Save(c *gin.Context) {
+	rules := &models.IngestionRules{}
+	if err := c.ShouldBind(&rules); err != nil {
+		http.Error(c, err)
+		return
+	}
+	if err := rules.Validate(); err != nil {
+		http.Error(c, err)
+		return
+	}
+	data := encoding.JSONMarshal(rules)
+
+	ctx, cancel := api.deps.WithTimeout()
+	defer cancel()
+	api.logger.Info("Saving ingestion rules", logger.String("rules", string(data)))
+	if err := api.deps.Repo.Put(ctx, constants.GetIngestionRulePath(rules.Database), data); err != nil {
+		http.Error(c, err)
+		return
+	}
+	http.NoContent(c)
+}
+
+// GetByDatabase gets the ingestion rules of database.
+func (api *IngestionRuleAPI) GetByDatabase(c *gin.Context) {
+	param := ingestionRuleParam{}
+	if err := c.ShouldBindQuery(&param); err != nil {
+		http.Error(c, err)
+		return
+	}
+	ctx, cancel := api.deps.WithTimeout()
+	defer cancel()
+	data, err := api.deps.Repo.Get(ctx, constants.GetIngestionRulePath(param.DatabaseName))
+	if err != nil {
+		if errors.Is(err, state.ErrNotExist) {
+			http.NotFound(c)
+			return
+		}
+		http.Error(c, err)
+		return
+	}
+	rules := &models.IngestionRules{}
+	if err := encoding.JSONUnmarshal(data, rules); err != nil {
+		http.Error(c, err)
+		return
+	}
+	http.OK(c, rules)
+}
+
+// DeleteByDatabase deletes the ingestion rules of database.
+func (api *IngestionRuleAPI) DeleteByDatabase(c *gin.Context) {
+	param := ingestionRuleParam{}
+	if err := c.ShouldBindQuery(&param); err != nil {
+		http.Error(c, err)
+		return
+	}
+	ctx, cancel := api.deps.WithTimeout()
+	defer cancel()
+	if err := api.deps.Repo.Delete(ctx, constants.GetIngestionRulePath(param.DatabaseName)); err != nil {
+		http.Error(c, err)
+		return
+	}
+	http.NoContent(c)
+}
@@ -0,0 +1,123 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package admin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/app/broker/deps"
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/internal/mock"
+	"github.com/lindb/lindb/pkg/ltoml"
+	"github.com/lindb/lindb/pkg/state"
+)
+
+func newIngestionRuleRouter(repo state.Repository) *gin.Engine {
+	r := gin.New()
+	api := NewIngestionRuleAPI(&deps.HTTPDeps{
+		Ctx:  context.Background(),
+		Repo: repo,
+		BrokerCfg: &config.Broker{BrokerBase: config.BrokerBase{
+			HTTP: config.HTTP{ReadTimeout: ltoml.Duration(time.Second * 10)},
+		}},
+	})
+	api.Register(r)
+	return r
+}
+
+func TestIngestionRuleAPI_Save(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := state.NewMockRepository(ctrl)
+	r := newIngestionRuleRouter(repo)
+
+	// bind error
+	resp := mock.DoRequest(t, r, http.MethodPost, constants.IngestionRulePath, "")
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// validate error
+	resp = mock.DoRequest(t, r, http.MethodPost, constants.IngestionRulePath,
+		`{"database":"db","rules":[{"action":"drop_tag"}]}`)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// put error
+	repo.EXPECT().Put(gomock.Any(), "/database/ingestion-rule/db", gomock.Any()).Return(fmt.Errorf("err"))
+	resp = mock.DoRequest(t, r, http.MethodPost, constants.IngestionRulePath,
+		`{"database":"db","rules":[{"action":"drop_metric","metric":"cpu"}]}`)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// put ok
+	repo.EXPECT().Put(gomock.Any(), "/database/ingestion-rule/db", gomock.Any()).Return(nil)
+	resp = mock.DoRequest(t, r, http.MethodPost, constants.IngestionRulePath,
+		`{"database":"db","rules":[{"action":"drop_metric","metric":"cpu"}]}`)
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+}
+
+func TestIngestionRuleAPI_GetByDatabase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := state.NewMockRepository(ctrl)
+	r := newIngestionRuleRouter(repo)
+
+	// param error
+	resp := mock.DoRequest(t, r, http.MethodGet, constants.IngestionRulePath, "")
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// not found
+	repo.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, state.ErrNotExist)
+	resp = mock.DoRequest(t, r, http.MethodGet, constants.IngestionRulePath+"?db=db", "")
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	// get rules err
+	repo.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("err"))
+	resp = mock.DoRequest(t, r, http.MethodGet, constants.IngestionRulePath+"?db=db", "")
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// bad content
+	repo.EXPECT().Get(gomock.Any(), gomock.Any()).Return([]byte("bad-data"), nil)
+	resp = mock.DoRequest(t, r, http.MethodGet, constants.IngestionRulePath+"?db=db", "")
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// get ok
+	repo.EXPECT().Get(gomock.Any(), gomock.Any()).Return([]byte(`{"database":"db"}`), nil)
+	resp = mock.DoRequest(t, r, http.MethodGet, constants.IngestionRulePath+"?db=db", "")
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestIngestionRuleAPI_DeleteByDatabase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := state.NewMockRepository(ctrl)
+	r := newIngestionRuleRouter(repo)
+
+	// param error
+	resp := mock.DoRequest(t, r, http.MethodDelete, constants.IngestionRulePath, "")
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// delete error
+	repo.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(fmt.Errorf("err"))
+	resp = mock.DoRequest(t, r, http.MethodDelete, constants.IngestionRulePath+"?db=db", "")
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// delete ok
+	repo.EXPECT().Delete(gomock.Any(), "/database/ingestion-rule/db").Return(nil)
+	resp = mock.DoRequest(t, r, http.MethodDelete, constants.IngestionRulePath+"?db=db", "")
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+}
@@ -0,0 +1,109 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/lindb/lindb/app/broker/deps"
+	"github.com/lindb/lindb/models"
+	httppkg "github.com/lindb/lindb/pkg/http"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/ltoml"
+)
+
+var (
+	// DrainStorageNodePath represents storage node drain api path.
+	DrainStorageNodePath = "/storage/node/drain"
+	// RecommissionStorageNodePath represents storage node recommission(cancel drain) api path.
+	RecommissionStorageNodePath = "/storage/node/recommission"
+)
+
+// StorageNodeDrainParam represents the param of storage node drain.
+type StorageNodeDrainParam struct {
+	Cluster         string         `json:"cluster" binding:"required"`
+	NodeID          models.NodeID  `json:"nodeId"`
+	MigrateReplicas bool           `json:"migrateReplicas"` // migrate replicas of node to other live nodes
+	Timeout         ltoml.Duration `json:"timeout"`         // max time waiting write ahead log acked by replicas
+}
+
+// StorageNodeRecommissionParam represents the param of storage node recommission.
+type StorageNodeRecommissionParam struct {
+	Cluster string        `json:"cluster" binding:"required"`
+	NodeID  models.NodeID `json:"nodeId"`
+}
+
+// StorageNodeDrainAPI represents the storage node drain by manual.
+type StorageNodeDrainAPI struct {
+	deps *deps.HTTPDeps
+
+	logger *logger.Logger
+}
+
+// NewStorageNodeDrainAPI create storage node drain api.
+func NewStorageNodeDrainAPI(deps *deps.HTTPDeps) *StorageNodeDrainAPI {
+	return &StorageNodeDrainAPI{
+		deps:   deps,
+		logger: logger.GetLogger("broker", "StorageNodeDrainAPI"),
+	}
+}
+
+// Register adds storage node drain admin url route.
+func (api *StorageNodeDrainAPI) Register(route gin.IRoutes) {
+	route.PUT(DrainStorageNodePath, api.Drain)
+	route.PUT(RecommissionStorageNodePath, api.Recommission)
+}
+
+// Drain moves leaders off the storage node, then drains it in background,
+// the node is marked decommissioned in storage state when drain finished.
+func (api *StorageNodeDrainAPI) Drain(c *gin.Context) {
+	param := &StorageNodeDrainParam{}
+	if err := c.ShouldBind(param); err != nil {
+		httppkg.Error(c, err)
+		return
+	}
+	if api.deps.Master.IsMaster() {
+		if err := api.deps.Master.DrainNode(param.Cluster, param.NodeID,
+			param.MigrateReplicas, param.Timeout.Duration()); err != nil {
+			httppkg.Error(c, err)
+			return
+		}
+	} else if err := forwardToMaster(api.deps, api.logger, c, param); err != nil {
+		httppkg.Error(c, err)
+		return
+	}
+	httppkg.OK(c, "success")
+}
+
+// Recommission cancels the running drain of storage node, removes its draining/decommissioned mark.
+func (api *StorageNodeDrainAPI) Recommission(c *gin.Context) {
+	param := &StorageNodeRecommissionParam{}
+	if err := c.ShouldBind(param); err != nil {
+		httppkg.Error(c, err)
+		return
+	}
+	if api.deps.Master.IsMaster() {
+		if err := api.deps.Master.RecommissionNode(param.Cluster, param.NodeID); err != nil {
+			httppkg.Error(c, err)
+			return
+		}
+	} else if err := forwardToMaster(api.deps, api.logger, c, param); err != nil {
+		httppkg.Error(c, err)
+		return
+	}
+	httppkg.OK(c, "success")
+}
@@ -0,0 +1,135 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/app/broker/deps"
+	"github.com/lindb/lindb/coordinator"
+	"github.com/lindb/lindb/internal/mock"
+	"github.com/lindb/lindb/models"
+)
+
+func TestStorageNodeDrainAPI_Drain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		httpDo = http.DefaultClient.Do
+		ctrl.Finish()
+	}()
+
+	master := coordinator.NewMockMaster(ctrl)
+	api := NewStorageNodeDrainAPI(&deps.HTTPDeps{
+		Master: master,
+	})
+	r := gin.New()
+	api.Register(r)
+	body := `{"cluster":"test","nodeId":1,"migrateReplicas":true,"timeout":"1m"}`
+
+	// no cluster
+	resp := mock.DoRequest(t, r, http.MethodPut, DrainStorageNodePath, `{"nodeId":1}`)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// drain err
+	master.EXPECT().IsMaster().Return(true)
+	master.EXPECT().DrainNode("test", models.NodeID(1), true, time.Minute).Return(fmt.Errorf("err"))
+	resp = mock.DoRequest(t, r, http.MethodPut, DrainStorageNodePath, body)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// drain ok
+	master.EXPECT().IsMaster().Return(true)
+	master.EXPECT().DrainNode("test", models.NodeID(1), false, time.Duration(0)).Return(nil)
+	resp = mock.DoRequest(t, r, http.MethodPut, DrainStorageNodePath, `{"cluster":"test","nodeId":1}`)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	master.EXPECT().IsMaster().Return(false).AnyTimes()
+	master.EXPECT().GetMaster().Return(&models.Master{
+		Node: &models.StatelessNode{
+			HostIP:   "127.0.0.1",
+			HTTPPort: 12345,
+		},
+	}).AnyTimes()
+	// forward err
+	httpDo = func(req *http.Request) (*http.Response, error) {
+		return nil, fmt.Errorf("err")
+	}
+	resp = mock.DoRequest(t, r, http.MethodPut, DrainStorageNodePath, body)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// forward ok
+	httpDo = func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "http://127.0.0.1:12345"+DrainStorageNodePath, req.URL.String())
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	}
+	resp = mock.DoRequest(t, r, http.MethodPut, DrainStorageNodePath, body)
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestStorageNodeDrainAPI_Recommission(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		httpDo = http.DefaultClient.Do
+		ctrl.Finish()
+	}()
+
+	master := coordinator.NewMockMaster(ctrl)
+	api := NewStorageNodeDrainAPI(&deps.HTTPDeps{
+		Master: master,
+	})
+	r := gin.New()
+	api.Register(r)
+	body := `{"cluster":"test","nodeId":1}`
+
+	// no cluster
+	resp := mock.DoRequest(t, r, http.MethodPut, RecommissionStorageNodePath, `{"nodeId":1}`)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// recommission err
+	master.EXPECT().IsMaster().Return(true)
+	master.EXPECT().RecommissionNode("test", models.NodeID(1)).Return(fmt.Errorf("err"))
+	resp = mock.DoRequest(t, r, http.MethodPut, RecommissionStorageNodePath, body)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// recommission ok
+	master.EXPECT().IsMaster().Return(true)
+	master.EXPECT().RecommissionNode("test", models.NodeID(1)).Return(nil)
+	resp = mock.DoRequest(t, r, http.MethodPut, RecommissionStorageNodePath, body)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	master.EXPECT().IsMaster().Return(false).AnyTimes()
+	master.EXPECT().GetMaster().Return(&models.Master{
+		Node: &models.StatelessNode{
+			HostIP:   "127.0.0.1",
+			HTTPPort: 12345,
+		},
+	}).AnyTimes()
+	// forward err
+	httpDo = func(req *http.Request) (*http.Response, error) {
+		return nil, fmt.Errorf("err")
+	}
+	resp = mock.DoRequest(t, r, http.MethodPut, RecommissionStorageNodePath, body)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// forward ok
+	httpDo = func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "http://127.0.0.1:12345"+RecommissionStorageNodePath, req.URL.String())
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	}
+	resp = mock.DoRequest(t, r, http.MethodPut, RecommissionStorageNodePath, body)
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
@@ -19,6 +19,7 @@ package ingest
 
 import (
 	"context"
+	"errors"
 	netHTTP "net/http"
 
 	"github.com/gin-gonic/gin"
@@ -26,6 +27,7 @@ import (
 	"github.com/lindb/lindb/app/broker/deps"
 	"github.com/lindb/lindb/constants"
 	ingestCommon "github.com/lindb/lindb/ingestion/common"
+	"github.com/lindb/lindb/ingestion/limit"
 	"github.com/lindb/lindb/pkg/http"
 	"github.com/lindb/lindb/series/metric"
 	"github.com/lindb/lindb/series/tag"
@@ -33,29 +35,57 @@ import (
 
 type parserFunc func(req *netHTTP.Request, enrichedTags tag.Tags, namespace string) (*metric.BrokerBatchRows, error)
 
+// defaultMaxRejections is the default number of rejections returned in write result.
+const defaultMaxRejections = 10
+
+// errAllRejected represents all metrics of write request are rejected.
+var errAllRejected = errors.New("all metrics rejected")
+
+// WriteResult represents the result of partial-success write request.
+type WriteResult struct {
+	Accepted   int                `json:"accepted"`
+	Rejected   int                `json:"rejected"`
+	Rejections []metric.Rejection `json:"rejections,omitempty"`
+}
+
 type commonWriter struct {
 	deps   *deps.HTTPDeps
 	parser parserFunc
 }
 
 func (cw *commonWriter) Write(c *gin.Context) {
-	if err := cw.deps.IngestLimiter.Do(func() error {
-		return cw.realWrite(c)
+	var result *WriteResult
+	if err := cw.deps.IngestLimiter.Do(func() (err error) {
+		result, err = cw.realWrite(c)
+		return err
 	}); err != nil {
-		http.Error(c, err)
+		switch {
+		case errors.Is(err, limit.ErrRateLimited):
+			http.TooManyRequests(c, err)
+		case errors.Is(err, errAllRejected):
+			http.BadRequest(c, result)
+		default:
+			http.Error(c, err)
+		}
+	} else if result != nil {
+		http.OK(c, result)
 	} else {
 		http.NoContent(c)
 	}
 }
 
-func (cw *commonWriter) realWrite(c *gin.Context) error {
+// realWrite writes metrics, returns write result if partial-success is required.
+// when partial-success is not required, returns write result with errAllRejected if all metrics are rejected.
+func (cw *commonWriter) realWrite(c *gin.Context) (*WriteResult, error) {
 	var param struct {
-		Database  string `form:"db" binding:"required"`
-		Namespace string `form:"ns"`
+		Database      string `form:"db" binding:"required"`
+		Namespace     string `form:"ns"`
+		Partial       bool   `form:"partial"`
+		MaxRejections int    `form:"max_rejections"`
 	}
 	err := c.ShouldBindQuery(&param)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	ctx, cancel := context.WithTimeout(context.Background(),
 		cw.deps.BrokerCfg.BrokerBase.Ingestion.IngestTimeout.Duration())
@@ -64,16 +94,49 @@ func (cw *commonWriter) realWrite(c *gin.Context) error {
 	if param.Namespace == "" {
 		param.Namespace = constants.DefaultNamespace
 	}
+	if param.MaxRejections <= 0 {
+		param.MaxRejections = defaultMaxRejections
+	}
 	enrichedTags, err := ingestCommon.ExtractEnrichTags(c.Request)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	metrics, err := cw.parser(c.Request, enrichedTags, param.Namespace)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if !param.Partial && metrics.Len() == 0 && metrics.RejectedCount() > 0 {
+		return newWriteResult(metrics, param.MaxRejections), errAllRejected
+	}
+	if relabeler, ok := cw.deps.StateMgr.GetRelabeler(param.Database); ok {
+		// apply ingestion rules of database before writing
+		relabeler.Apply(metrics)
+	}
+	// evict rows out of time range first, so that limiter only counts the rows will be written
+	cw.deps.CM.EvictOutOfTimeRange(param.Database, metrics)
+	if limiter, ok := cw.deps.StateMgr.GetLimiter(param.Database); ok {
+		if err := limiter.Allow(metrics); err != nil {
+			return nil, err
+		}
 	}
 	if err := cw.deps.CM.Write(ctx, param.Database, metrics); err != nil {
-		return err
+		return nil, err
+	}
+	if !param.Partial {
+		return nil, nil
+	}
+	return newWriteResult(metrics, param.MaxRejections), nil
+}
+
+// newWriteResult returns the write result of metrics, at most maxRejections rejections are returned.
+func newWriteResult(metrics *metric.BrokerBatchRows, maxRejections int) *WriteResult {
+	rejections := metrics.Rejections()
+	if len(rejections) > maxRejections {
+		rejections = rejections[:maxRejections]
+	}
+	return &WriteResult{
+		Accepted:   metrics.AcceptedCount(),
+		Rejected:   metrics.RejectedCount(),
+		Rejections: rejections,
 	}
-	return nil
 }
@@ -20,8 +20,10 @@ package ingest
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"testing"
 	"time"
 
@@ -31,9 +33,12 @@ import (
 
 	"github.com/lindb/lindb/app/broker/deps"
 	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/coordinator/broker"
 	"github.com/lindb/lindb/internal/concurrent"
 	"github.com/lindb/lindb/internal/linmetric"
 	"github.com/lindb/lindb/internal/mock"
+	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/pkg/ltoml"
 	"github.com/lindb/lindb/pkg/timeutil"
 	protoMetricsV1 "github.com/lindb/lindb/proto/gen/v1/metrics"
@@ -46,6 +51,10 @@ func Test_Flat_Write(t *testing.T) {
 	defer ctrl.Finish()
 
 	cm := replica.NewMockChannelManager(ctrl)
+	stateMgr := broker.NewMockStateManager(ctrl)
+	stateMgr.EXPECT().GetRelabeler(gomock.Any()).Return(nil, false).AnyTimes()
+	stateMgr.EXPECT().GetLimiter(gomock.Any()).Return(nil, false).AnyTimes()
+	cm.EXPECT().EvictOutOfTimeRange(gomock.Any(), gomock.Any()).AnyTimes()
 	api := NewFlatWriter(&deps.HTTPDeps{
 		BrokerCfg: &config.Broker{
 			BrokerBase: config.BrokerBase{
@@ -54,7 +63,8 @@ func Test_Flat_Write(t *testing.T) {
 				},
 			},
 		},
-		CM: cm,
+		CM:       cm,
+		StateMgr: stateMgr,
 		IngestLimiter: concurrent.NewLimiter(
 			context.TODO(),
 			32,
@@ -96,4 +106,43 @@ func Test_Flat_Write(t *testing.T) {
 	cm.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 	resp = mock.DoRequest(t, r, http.MethodPut, FlatWritePath+"?db=test&ns=ns4&enrich_tag=a=b", body)
 	assert.Equal(t, http.StatusNoContent, resp.Code)
+
+	// row is rejected with enriched tag, because of too many tags
+	var tags []*protoMetricsV1.KeyValue
+	for i := 0; i < constants.DefaultMaxTagKeysCount; i++ {
+		tags = append(tags, &protoMetricsV1.KeyValue{Key: strconv.Itoa(i), Value: strconv.Itoa(i)})
+	}
+	err = converter.ConvertTo(&protoMetricsV1.Metric{
+		Name:      "cpu",
+		Timestamp: timeutil.Now(),
+		Tags:      tags,
+		SimpleFields: []*protoMetricsV1.SimpleField{
+			{Name: "f1", Type: protoMetricsV1.SimpleFieldType_DELTA_SUM, Value: 1}},
+	}, &brokerRow)
+	assert.NoError(t, err)
+	buf.Reset()
+	_, _ = brokerRow.WriteTo(&buf)
+	badBody := buf.String()
+	rejection := metric.Rejection{Line: 2, Reason: fmt.Sprintf("too many tag pairs: %d", constants.DefaultMaxTagKeysCount+1)}
+
+	// partial success
+	cm.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	resp = mock.DoRequest(t, r, http.MethodPut,
+		FlatWritePath+"?db=test&enrich_tag=a=b&partial=true&max_rejections=1", body+badBody+badBody)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	result := WriteResult{}
+	assert.NoError(t, encoding.JSONUnmarshal(resp.Body.Bytes(), &result))
+	assert.Equal(t, WriteResult{Accepted: 1, Rejected: 2, Rejections: []metric.Rejection{rejection}}, result)
+
+	// all rows rejected
+	resp = mock.DoRequest(t, r, http.MethodPut, FlatWritePath+"?db=test&enrich_tag=a=b", badBody+badBody)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	result = WriteResult{}
+	assert.NoError(t, encoding.JSONUnmarshal(resp.Body.Bytes(), &result))
+	rejection.Line = 1
+	assert.Equal(t, WriteResult{
+		Accepted:   0,
+		Rejected:   2,
+		Rejections: []metric.Rejection{rejection, {Line: 2, Reason: rejection.Reason}},
+	}, result)
 }
@@ -18,11 +18,15 @@
 package ingest
 
 import (
+	netHTTP "net/http"
+
 	"github.com/gin-gonic/gin"
 
 	"github.com/lindb/lindb/app/broker/deps"
 	"github.com/lindb/lindb/ingestion/influx"
 	"github.com/lindb/lindb/pkg/http"
+	"github.com/lindb/lindb/series/metric"
+	"github.com/lindb/lindb/series/tag"
 )
 
 var (
@@ -37,12 +41,19 @@ type InfluxWriter struct {
 
 // NewInfluxWriter creates influx writer.
 func NewInfluxWriter(deps *deps.HTTPDeps) *InfluxWriter {
-	return &InfluxWriter{
+	iw := &InfluxWriter{
 		commonWriter: commonWriter{
-			deps:   deps,
-			parser: influx.Parse,
+			deps: deps,
 		},
 	}
+	iw.parser = iw.parse
+	return iw
+}
+
+// parse parses influxdb line protocol with string field policy of ingestion config.
+func (iw *InfluxWriter) parse(req *netHTTP.Request, enrichedTags tag.Tags, namespace string) (*metric.BrokerBatchRows, error) {
+	stringFieldPolicy := influx.ParseStringFieldPolicy(iw.deps.BrokerCfg.BrokerBase.Ingestion.InfluxStringField)
+	return influx.NewParser(stringFieldPolicy).Parse(req, enrichedTags, namespace)
 }
 
 // Register adds influx write url route.
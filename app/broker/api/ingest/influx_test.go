@@ -30,11 +30,18 @@ import (
 
 	"github.com/lindb/lindb/app/broker/deps"
 	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/coordinator/broker"
+	"github.com/lindb/lindb/ingestion/limit"
+	"github.com/lindb/lindb/ingestion/relabel"
 	"github.com/lindb/lindb/internal/concurrent"
 	"github.com/lindb/lindb/internal/linmetric"
 	"github.com/lindb/lindb/internal/mock"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/pkg/ltoml"
+	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/replica"
+	"github.com/lindb/lindb/series/metric"
 )
 
 func Test_Influx_Write(t *testing.T) {
@@ -42,6 +49,32 @@ func Test_Influx_Write(t *testing.T) {
 	defer ctrl.Finish()
 
 	cm := replica.NewMockChannelManager(ctrl)
+	stateMgr := broker.NewMockStateManager(ctrl)
+	relabeler, err := relabel.NewRelabeler(&models.IngestionRules{
+		Database: "relabel",
+		Rules:    []models.IngestionRule{{Action: models.DropMetric, Metric: "drop.*"}},
+	})
+	assert.NoError(t, err)
+	stateMgr.EXPECT().GetRelabeler(gomock.Any()).DoAndReturn(func(databaseName string) (*relabel.Relabeler, bool) {
+		if databaseName == "relabel" {
+			return relabeler, true
+		}
+		return nil, false
+	}).AnyTimes()
+	limiter := limit.NewLimiter("limit", &models.DatabaseLimits{WriteLimits: models.WriteLimits{RowsPerSecond: 1}})
+	stateMgr.EXPECT().GetLimiter(gomock.Any()).DoAndReturn(func(databaseName string) (*limit.Limiter, bool) {
+		if databaseName == "limit" {
+			return limiter, true
+		}
+		return nil, false
+	}).AnyTimes()
+	evict := false
+	cm.EXPECT().EvictOutOfTimeRange(gomock.Any(), gomock.Any()).
+		Do(func(_ string, rows *metric.BrokerBatchRows) {
+			if evict {
+				rows.EvictOutOfTimeRange(timeutil.OneHour, timeutil.OneHour)
+			}
+		}).AnyTimes()
 	api := NewInfluxWriter(&deps.HTTPDeps{
 		BrokerCfg: &config.Broker{
 			BrokerBase: config.BrokerBase{
@@ -50,7 +83,8 @@ func Test_Influx_Write(t *testing.T) {
 				},
 			},
 		},
-		CM: cm,
+		CM:       cm,
+		StateMgr: stateMgr,
 		IngestLimiter: concurrent.NewLimiter(
 			context.TODO(),
 			32,
@@ -71,13 +105,19 @@ func Test_Influx_Write(t *testing.T) {
 	resp = mock.DoRequest(t, r, http.MethodPut, InfluxWritePath+"?db=test&ns=ns2&enrich_tag=a", "")
 	assert.Equal(t, http.StatusInternalServerError, resp.Code)
 
-	// influx line format without timestamp
-	cm.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any()).Return(io.ErrClosedPipe)
+	// all lines rejected
 	resp = mock.DoRequest(t, r, http.MethodPut, InfluxWritePath+"?db=test&ns=ns3&enrich_tag=a=b", `
 # bad line
 a,v=c,d=f a=2 b=3 c=4
 `)
-	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	result := WriteResult{}
+	assert.NoError(t, encoding.JSONUnmarshal(resp.Body.Bytes(), &result))
+	assert.Equal(t, WriteResult{
+		Accepted:   0,
+		Rejected:   1,
+		Rejections: []metric.Rejection{{Line: 3, Reason: "bad_timestamp"}},
+	}, result)
 
 	// write error
 	cm.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any()).Return(io.ErrClosedPipe)
@@ -96,4 +136,73 @@ measurement,foo=bar value=12 1439587925
 measurement value=12 1439587925
 `)
 	assert.Equal(t, http.StatusNoContent, resp.Code)
+
+	// partial success
+	cm.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	resp = mock.DoRequest(t, r, http.MethodPut, InfluxWritePath+"?db=test&partial=true&max_rejections=1", `
+measurement,foo=bar value=12 1439587925
+measurement,foo value=12 1439587925
+measurement value=
+`)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	result = WriteResult{}
+	assert.NoError(t, encoding.JSONUnmarshal(resp.Body.Bytes(), &result))
+	assert.Equal(t, WriteResult{
+		Accepted:   1,
+		Rejected:   2,
+		Rejections: []metric.Rejection{{Line: 3, Reason: "bad_tags"}},
+	}, result)
+
+	// partial success, all lines rejected
+	cm.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	resp = mock.DoRequest(t, r, http.MethodPut, InfluxWritePath+"?db=test&partial=true", `measurement value=`)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	result = WriteResult{}
+	assert.NoError(t, encoding.JSONUnmarshal(resp.Body.Bytes(), &result))
+	assert.Equal(t, 0, result.Accepted)
+	assert.Equal(t, 1, result.Rejected)
+
+	// ingestion rules applied
+	cm.EXPECT().Write(gomock.Any(), "relabel", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, rows *metric.BrokerBatchRows) error {
+			assert.Equal(t, 1, rows.Len())
+			return nil
+		})
+	resp = mock.DoRequest(t, r, http.MethodPut, InfluxWritePath+"?db=relabel", `
+measurement value=12 1439587925
+dropped value=12 1439587925
+`)
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+
+	// rows out of time range are evicted before limiting
+	evict = true
+	cm.EXPECT().Write(gomock.Any(), "limit", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, rows *metric.BrokerBatchRows) error {
+			assert.Equal(t, 0, rows.AcceptedCount())
+			return nil
+		})
+	resp = mock.DoRequest(t, r, http.MethodPut, InfluxWritePath+"?db=limit&precision=s", `
+measurement value=12 1439587925
+measurement2 value=12 1439587925
+`)
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+	evict = false
+
+	// oversized batch is rejected even if window is empty
+	resp = mock.DoRequest(t, r, http.MethodPut, InfluxWritePath+"?db=limit", `
+measurement value=12 1439587925
+measurement2 value=12 1439587925
+`)
+	assert.Equal(t, http.StatusTooManyRequests, resp.Code)
+	cm.EXPECT().Write(gomock.Any(), "limit", gomock.Any()).Return(nil)
+	resp = mock.DoRequest(t, r, http.MethodPut, InfluxWritePath+"?db=limit", `
+measurement value=12 1439587925
+`)
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+
+	// rate limited
+	resp = mock.DoRequest(t, r, http.MethodPut, InfluxWritePath+"?db=limit", `
+measurement value=12 1439587925
+`)
+	assert.Equal(t, http.StatusTooManyRequests, resp.Code)
 }
@@ -30,12 +30,15 @@ import (
 
 	"github.com/lindb/lindb/app/broker/deps"
 	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/coordinator/broker"
 	"github.com/lindb/lindb/internal/concurrent"
 	"github.com/lindb/lindb/internal/linmetric"
 	"github.com/lindb/lindb/internal/mock"
+	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/pkg/ltoml"
 	protoMetricsV1 "github.com/lindb/lindb/proto/gen/v1/metrics"
 	"github.com/lindb/lindb/replica"
+	"github.com/lindb/lindb/series/metric"
 )
 
 func Test_NativeWriter(t *testing.T) {
@@ -43,6 +46,10 @@ func Test_NativeWriter(t *testing.T) {
 	defer ctrl.Finish()
 
 	cm := replica.NewMockChannelManager(ctrl)
+	stateMgr := broker.NewMockStateManager(ctrl)
+	stateMgr.EXPECT().GetRelabeler(gomock.Any()).Return(nil, false).AnyTimes()
+	stateMgr.EXPECT().GetLimiter(gomock.Any()).Return(nil, false).AnyTimes()
+	cm.EXPECT().EvictOutOfTimeRange(gomock.Any(), gomock.Any()).AnyTimes()
 	api := NewProtoWriter(&deps.HTTPDeps{
 		BrokerCfg: &config.Broker{
 			BrokerBase: config.BrokerBase{
@@ -51,7 +58,8 @@ func Test_NativeWriter(t *testing.T) {
 				},
 			},
 		},
-		CM: cm,
+		CM:       cm,
+		StateMgr: stateMgr,
 		IngestLimiter: concurrent.NewLimiter(
 			context.TODO(),
 			32,
@@ -92,4 +100,27 @@ func Test_NativeWriter(t *testing.T) {
 	resp = mock.DoRequest(t, r, http.MethodPost, ProtoWritePath+"?db=test&ns=ns4&enrich_tag=a=b", string(data))
 	assert.Equal(t, http.StatusInternalServerError, resp.Code)
 
+	// metric without fields is rejected
+	badMetric := &protoMetricsV1.Metric{Name: "2", Namespace: "ns"}
+	rejection := metric.Rejection{Line: 2, Reason: "bad metric proto, fields are empty"}
+
+	// partial success
+	cm.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	metricList = protoMetricsV1.MetricList{Metrics: []*protoMetricsV1.Metric{metricList.Metrics[0], badMetric, badMetric}}
+	data, _ = metricList.Marshal()
+	resp = mock.DoRequest(t, r, http.MethodPost, ProtoWritePath+"?db=test&partial=true&max_rejections=1", string(data))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	result := WriteResult{}
+	assert.NoError(t, encoding.JSONUnmarshal(resp.Body.Bytes(), &result))
+	assert.Equal(t, WriteResult{Accepted: 1, Rejected: 2, Rejections: []metric.Rejection{rejection}}, result)
+
+	// all metrics rejected
+	metricList = protoMetricsV1.MetricList{Metrics: []*protoMetricsV1.Metric{badMetric}}
+	data, _ = metricList.Marshal()
+	resp = mock.DoRequest(t, r, http.MethodPost, ProtoWritePath+"?db=test", string(data))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	result = WriteResult{}
+	assert.NoError(t, encoding.JSONUnmarshal(resp.Body.Bytes(), &result))
+	rejection.Line = 1
+	assert.Equal(t, WriteResult{Accepted: 0, Rejected: 1, Rejections: []metric.Rejection{rejection}}, result)
 }
@@ -147,21 +147,26 @@ func (d *MetadataAPI) suggest(c *gin.Context, database string, request *stmt.Met
 			}
 		}
 		// HistogramSum(sum), HistogramCount(sum), HistogramMin(min), HistogramMax(max) is visible
-		// __bucket_{id}(HistogramField) is not visible for api,
+		// __bucket_{id}(HistogramField) and __sketch(SketchField) are not visible for api,
 		// underlying histogram data is only restricted access by user via quantile function
 		// furthermore, we suggest some quantile functions for user in field names, such as quantile(0.99)
+		// __gauge_{agg}_{name} is not visible for api, it is only used for answering min/max/sum of gauge
 		var (
 			resultFields []models.Field
 			hasHistogram bool
 		)
 		for _, f := range result {
-			if f.Type != field.HistogramField {
+			if field.IsGaugeAggFieldName(f.Name) {
+				continue
+			}
+			switch f.Type {
+			case field.HistogramField, field.SketchField:
+				hasHistogram = true
+			default:
 				resultFields = append(resultFields, models.Field{
 					Name: string(f.Name),
 					Type: f.Type.String(),
 				})
-			} else {
-				hasHistogram = true
 			}
 		}
 		//
@@ -180,6 +180,17 @@ func TestMetadataAPI_SuggestCommon(t *testing.T) {
 	}))}, nil)
 	resp = mock.DoRequest(t, r, http.MethodGet, MetadataQueryPath+"?db=db&sql=show fields from cpu", "")
 	assert.Equal(t, http.StatusOK, resp.Code)
+
+	// gauge aggregates
+	metaDataQuery.EXPECT().WaitResponse().Return([]string{string(encoding.JSONMarshal(&[]field.Meta{
+		{Name: "usage", Type: field.GaugeField},
+		{Name: "__gauge_max_usage", Type: field.MaxField},
+		{Name: "__sketch", Type: field.SketchField},
+	}))}, nil)
+	resp = mock.DoRequest(t, r, http.MethodGet, MetadataQueryPath+"?db=db&sql=show fields from cpu", "")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.NotContains(t, resp.Body.String(), "__gauge_max_usage")
+	assert.NotContains(t, resp.Body.String(), "__sketch")
 }
 
 func Test_parseSQL(t *testing.T) {
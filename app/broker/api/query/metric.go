@@ -19,11 +19,14 @@ package query
 
 import (
 	"context"
+	"errors"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/lindb/lindb/app/broker/deps"
 	"github.com/lindb/lindb/pkg/http"
+	"github.com/lindb/lindb/sql"
+	"github.com/lindb/lindb/sql/stmt"
 )
 
 var (
@@ -45,6 +48,7 @@ func NewMetricAPI(deps *deps.HTTPDeps) *MetricAPI {
 // Register adds metric query url route.
 func (m *MetricAPI) Register(route gin.IRoutes) {
 	route.GET(MetricQueryPath, m.Search)
+	route.DELETE(MetricQueryPath, m.Delete)
 }
 
 // Search searches the metric data based on database and sql.
@@ -77,3 +81,38 @@ func (m *MetricAPI) searchWithLimit(c *gin.Context) error {
 	http.OK(c, resultSet)
 	return nil
 }
+
+// Delete deletes the series data based on database and delete sql,
+// such as: delete from cpu where host='1.1.1.1' and time>now()-1h.
+// delete is accepted after written into write ahead log of shard leaders, and applied by replicas asynchronously.
+func (m *MetricAPI) Delete(c *gin.Context) {
+	var param struct {
+		Database string `form:"db" binding:"required"`
+		SQL      string `form:"sql" binding:"required"`
+	}
+	err := c.ShouldBindQuery(&param)
+	if err != nil {
+		http.Error(c, err)
+		return
+	}
+	statement, err := sql.Parse(param.SQL)
+	if err != nil {
+		http.Error(c, err)
+		return
+	}
+	deleteStmt, ok := statement.(*stmt.Delete)
+	if !ok {
+		http.Error(c, errors.New("not delete statement"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.deps.BrokerCfg.Query.Timeout.Duration())
+	defer cancel()
+
+	deleteQuery := m.deps.QueryFactory.NewDeleteQuery(ctx, param.Database, deleteStmt)
+	if err := deleteQuery.WaitResponse(); err != nil {
+		http.Error(c, err)
+		return
+	}
+	http.NoContent(c)
+}
@@ -21,6 +21,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"testing"
 	"time"
 
@@ -100,3 +101,40 @@ func TestNewMetricAPI_Search_Err(t *testing.T) {
 	resp = mock.DoRequest(t, r, http.MethodGet, MetricQueryPath+"?db=test&sql=select f from cpu", "")
 	assert.Equal(t, http.StatusInternalServerError, resp.Code)
 }
+
+func TestMetricAPI_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	queryFactory := brokerQuery.NewMockFactory(ctrl)
+	deleteQuery := brokerQuery.NewMockDeleteQuery(ctrl)
+	api := NewMetricAPI(&deps.HTTPDeps{
+		BrokerCfg:    &config.Broker{Query: config.Query{Timeout: ltoml.Duration(time.Second)}},
+		QueryFactory: queryFactory,
+	})
+	r := gin.New()
+	api.Register(r)
+
+	// param error
+	resp := mock.DoRequest(t, r, http.MethodDelete, MetricQueryPath, "")
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// parse sql error
+	resp = mock.DoRequest(t, r, http.MethodDelete,
+		MetricQueryPath+"?db=test&sql="+url.QueryEscape("delete from"), "")
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// not delete statement
+	resp = mock.DoRequest(t, r, http.MethodDelete,
+		MetricQueryPath+"?db=test&sql="+url.QueryEscape("select f from cpu"), "")
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+
+	deleteSQL := url.QueryEscape("delete from cpu where host='1.1.1.1' and time>now()-1h")
+	queryFactory.EXPECT().NewDeleteQuery(gomock.Any(), "test", gomock.Any()).Return(deleteQuery).Times(2)
+	// delete error
+	deleteQuery.EXPECT().WaitResponse().Return(fmt.Errorf("err"))
+	resp = mock.DoRequest(t, r, http.MethodDelete, MetricQueryPath+"?db=test&sql="+deleteSQL, "")
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// delete ok
+	deleteQuery.EXPECT().WaitResponse().Return(nil)
+	resp = mock.DoRequest(t, r, http.MethodDelete, MetricQueryPath+"?db=test&sql="+deleteSQL, "")
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+}
@@ -33,9 +33,16 @@ type API struct {
 	master          *cluster.MasterAPI
 	database        *admin.DatabaseAPI
 	flusher         *admin.DatabaseFlusherAPI
+	backup          *admin.DatabaseBackupAPI
+	index           *admin.DatabaseIndexAPI
+	drop            *admin.DatabaseDropAPI
+	rebalance       *admin.DatabaseRebalanceAPI
+	drain           *admin.StorageNodeDrainAPI
 	storage         *admin.StorageClusterAPI
+	ingestionRule   *admin.IngestionRuleAPI
 	brokerState     *state.BrokerAPI
 	storageState    *state.StorageAPI
+	replicaState    *state.ReplicaAPI
 	influxIngestion *ingest.InfluxWriter
 	protoIngestion  *ingest.ProtoWriter
 	flatIngestion   *ingest.FlatWriter
@@ -49,9 +56,16 @@ func NewAPI(deps *deps.HTTPDeps) *API {
 		master:          cluster.NewMasterAPI(deps),
 		database:        admin.NewDatabaseAPI(deps),
 		flusher:         admin.NewDatabaseFlusherAPI(deps),
+		backup:          admin.NewDatabaseBackupAPI(deps),
+		index:           admin.NewDatabaseIndexAPI(deps),
+		drop:            admin.NewDatabaseDropAPI(deps),
+		rebalance:       admin.NewDatabaseRebalanceAPI(deps),
+		drain:           admin.NewStorageNodeDrainAPI(deps),
 		storage:         admin.NewStorageClusterAPI(deps),
+		ingestionRule:   admin.NewIngestionRuleAPI(deps),
 		brokerState:     state.NewBrokerAPI(deps),
 		storageState:    state.NewStorageAPI(deps),
+		replicaState:    state.NewReplicaAPI(deps),
 		influxIngestion: ingest.NewInfluxWriter(deps),
 		protoIngestion:  ingest.NewProtoWriter(deps),
 		flatIngestion:   ingest.NewFlatWriter(deps),
@@ -65,10 +79,17 @@ func (api *API) RegisterRouter(router *gin.RouterGroup) {
 	api.master.Register(router)
 	api.database.Register(router)
 	api.flusher.Register(router)
+	api.backup.Register(router)
+	api.index.Register(router)
+	api.drop.Register(router)
+	api.rebalance.Register(router)
+	api.drain.Register(router)
 	api.storage.Register(router)
+	api.ingestionRule.Register(router)
 
 	api.brokerState.Register(router)
 	api.storageState.Register(router)
+	api.replicaState.Register(router)
 
 	api.metadata.Register(router)
 	api.metric.Register(router)
@@ -0,0 +1,81 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package state
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/lindb/lindb/app/broker/deps"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/http"
+)
+
+var (
+	ReplicaStatePath = "/storage/cluster/replica/state"
+)
+
+// ReplicaAPI represents query replication state of storage cluster api from broker state machine.
+type ReplicaAPI struct {
+	deps *deps.HTTPDeps
+}
+
+// NewReplicaAPI creates the replication state api.
+func NewReplicaAPI(deps *deps.HTTPDeps) *ReplicaAPI {
+	return &ReplicaAPI{
+		deps: deps,
+	}
+}
+
+// Register adds replication state url route.
+func (s *ReplicaAPI) Register(route gin.IRoutes) {
+	route.GET(ReplicaStatePath, s.GetReplicaState)
+}
+
+// GetReplicaState returns the replication state(index/lag/ready etc.) reported by storage nodes,
+// filters replica peers by database if given.
+func (s *ReplicaAPI) GetReplicaState(c *gin.Context) {
+	var param struct {
+		ClusterName  string `form:"name" binding:"required"`
+		DatabaseName string `form:"db"`
+	}
+	err := c.ShouldBindQuery(&param)
+	if err != nil {
+		http.Error(c, err)
+		return
+	}
+	states, ok := s.deps.StateMgr.GetReplicaStates(param.ClusterName)
+	if !ok {
+		http.NotFound(c)
+		return
+	}
+	if param.DatabaseName == "" {
+		http.OK(c, states)
+		return
+	}
+	result := make([]models.NodeReplicaState, 0, len(states))
+	for _, state := range states {
+		var peers []models.ReplicaPeerState
+		for _, peer := range state.Peers {
+			if peer.Database == param.DatabaseName {
+				peers = append(peers, peer)
+			}
+		}
+		state.Peers = peers
+		result = append(result, state)
+	}
+	http.OK(c, result)
+}
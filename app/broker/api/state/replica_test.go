@@ -0,0 +1,82 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package state
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/app/broker/deps"
+	"github.com/lindb/lindb/coordinator/broker"
+	"github.com/lindb/lindb/internal/mock"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+)
+
+func TestReplicaAPI_GetReplicaState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stateMgr := broker.NewMockStateManager(ctrl)
+	api := NewReplicaAPI(&deps.HTTPDeps{
+		StateMgr: stateMgr,
+	})
+	r := gin.New()
+	api.Register(r)
+
+	// cluster name not input
+	resp := mock.DoRequest(t, r, http.MethodGet, ReplicaStatePath, "")
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// storage not found
+	stateMgr.EXPECT().GetReplicaStates("test").Return(nil, false)
+	resp = mock.DoRequest(t, r, http.MethodGet, ReplicaStatePath+"?name=test", "")
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+
+	peer := func(db string) models.ReplicaPeerState {
+		return models.ReplicaPeerState{ReplicaState: models.ReplicaState{Database: db, ShardID: 1, Leader: 1, Follower: 2}}
+	}
+	states := []models.NodeReplicaState{{
+		NodeID: 1,
+		Peers:  []models.ReplicaPeerState{peer("db1"), peer("db2")},
+	}, {
+		NodeID: 2,
+		Peers:  []models.ReplicaPeerState{peer("db2")},
+	}}
+	// all databases
+	stateMgr.EXPECT().GetReplicaStates("test").Return(states, true)
+	resp = mock.DoRequest(t, r, http.MethodGet, ReplicaStatePath+"?name=test", "")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var rs []models.NodeReplicaState
+	assert.NoError(t, encoding.JSONUnmarshal(resp.Body.Bytes(), &rs))
+	assert.Equal(t, states, rs)
+	// filter by database
+	stateMgr.EXPECT().GetReplicaStates("test").Return(states, true)
+	resp = mock.DoRequest(t, r, http.MethodGet, ReplicaStatePath+"?name=test&db=db1", "")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	rs = nil
+	assert.NoError(t, encoding.JSONUnmarshal(resp.Body.Bytes(), &rs))
+	assert.Equal(t, []models.NodeReplicaState{{
+		NodeID: 1,
+		Peers:  []models.ReplicaPeerState{peer("db1")},
+	}, {
+		NodeID: 2,
+	}}, rs)
+}
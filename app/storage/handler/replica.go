@@ -19,6 +19,7 @@ package handler
 
 import (
 	"context"
+	"fmt"
 	"io"
 
 	"google.golang.org/grpc/codes"
@@ -27,15 +28,24 @@ import (
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/fileutil"
 	"github.com/lindb/lindb/pkg/logger"
 	protoReplicaV1 "github.com/lindb/lindb/proto/gen/v1/replica"
 	"github.com/lindb/lindb/replica"
 	"github.com/lindb/lindb/rpc"
+	"github.com/lindb/lindb/tsdb"
+)
+
+// for testing
+var (
+	receiveSnapshot = replica.ReceiveSnapshot
+	removeDir       = fileutil.RemoveDir
 )
 
 // ReplicaHandler implements replica.ReplicaServiceServer interface for handling replica rpc request.
 type ReplicaHandler struct {
 	walMgr replica.WriteAheadLogManager
+	engine tsdb.Engine
 
 	logger *logger.Logger
 }
@@ -43,9 +53,11 @@ type ReplicaHandler struct {
 // NewReplicaHandler creates a replica handler.
 func NewReplicaHandler(
 	walMgr replica.WriteAheadLogManager,
+	engine tsdb.Engine,
 ) *ReplicaHandler {
 	return &ReplicaHandler{
 		walMgr: walMgr,
+		engine: engine,
 		logger: logger.GetLogger("storage", "ReplicaRPC"),
 	}
 }
@@ -122,6 +134,50 @@ func (r *ReplicaHandler) Replica(server protoReplicaV1.ReplicaService_ReplicaSer
 	}
 }
 
+// InstallSnapshot installs the shard snapshot from replica leader when the wal which replica needs has been removed,
+// rebuilds the write ahead log and shard with snapshot, then replicates the wal after snapshot index.
+func (r *ReplicaHandler) InstallSnapshot(server protoReplicaV1.ReplicaService_InstallSnapshotServer) error {
+	replicaState, err := r.getReplicaStateFromCtx(server.Context())
+	if err != nil {
+		r.logger.Error("get replica state err, when install snapshot", logger.Error(err))
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	snapshotPath := tsdb.ShardSnapshotPath(replicaState.Database, replicaState.ShardID,
+		fmt.Sprintf("%d_%d", replicaState.Leader, replicaState.Follower))
+	defer func() {
+		if err := removeDir(snapshotPath); err != nil {
+			r.logger.Warn("remove shard snapshot err", logger.String("path", snapshotPath), logger.Error(err))
+		}
+	}()
+	snapshotIdx, err := receiveSnapshot(server, snapshotPath)
+	if err != nil {
+		r.logger.Error("receive shard snapshot err", logger.Error(err))
+		return status.Error(codes.Internal, err.Error())
+	}
+	// drop the wal partition of shard only, because the shard of partition will be replaced
+	if err := r.walMgr.GetOrCreateLog(replicaState.Database).DropPartition(replicaState.ShardID); err != nil {
+		r.logger.Error("drop write ahead log err, when install snapshot", logger.Error(err))
+		return status.Error(codes.Internal, err.Error())
+	}
+	if err := r.engine.InstallShardSnapshot(snapshotPath, replicaState.ShardID); err != nil {
+		r.logger.Error("install shard snapshot err", logger.Error(err))
+		return status.Error(codes.Internal, err.Error())
+	}
+	p, err := r.getOrCreatePartition(replicaState.Database, replicaState.ShardID)
+	if err != nil {
+		r.logger.Error("get or create wal partition err, when install snapshot", logger.Error(err))
+		return status.Error(codes.Internal, err.Error())
+	}
+	p.ResetReplicaIndex(snapshotIdx + 1)
+	r.logger.Info("install shard snapshot successfully",
+		logger.String("database", replicaState.Database),
+		logger.Any("shardID", replicaState.ShardID),
+		logger.Int64("snapshotIdx", snapshotIdx))
+	return server.SendAndClose(&protoReplicaV1.SnapshotResponse{
+		AckIndex: p.ReplicaAckIndex(),
+	})
+}
+
 // getReplicaStateFromCtx gets replica relationship metadata from rpc context.
 func (r *ReplicaHandler) getReplicaStateFromCtx(ctx context.Context) (replicatorState models.ReplicaState, err error) {
 	replicaStateData, err := rpc.GetStringFromContext(ctx, constants.RPCMetaReplicaState)
@@ -28,8 +28,11 @@ import (
 	"google.golang.org/grpc/metadata"
 
 	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/fileutil"
 	protoReplicaV1 "github.com/lindb/lindb/proto/gen/v1/replica"
 	"github.com/lindb/lindb/replica"
+	"github.com/lindb/lindb/tsdb"
 )
 
 func TestReplicaHandler_Replica(t *testing.T) {
@@ -40,7 +43,7 @@ func TestReplicaHandler_Replica(t *testing.T) {
 
 	walMgr := replica.NewMockWriteAheadLogManager(ctrl)
 	replicaServer := protoReplicaV1.NewMockReplicaService_ReplicaServer(ctrl)
-	r := NewReplicaHandler(walMgr)
+	r := NewReplicaHandler(walMgr, nil)
 
 	// case 5: create partition err
 	ctx := metadata.NewIncomingContext(context.TODO(),
@@ -87,3 +90,62 @@ func TestReplicaHandler_Replica(t *testing.T) {
 	err = r.Replica(replicaServer)
 	assert.NoError(t, err)
 }
+
+func TestReplicaHandler_InstallSnapshot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		receiveSnapshot = replica.ReceiveSnapshot
+		removeDir = fileutil.RemoveDir
+		ctrl.Finish()
+	}()
+	receiveSnapshot = func(_ protoReplicaV1.ReplicaService_InstallSnapshotServer, _ string) (int64, error) {
+		return 10, nil
+	}
+
+	walMgr := replica.NewMockWriteAheadLogManager(ctrl)
+	engine := tsdb.NewMockEngine(ctrl)
+	server := protoReplicaV1.NewMockReplicaService_InstallSnapshotServer(ctrl)
+	r := NewReplicaHandler(walMgr, engine)
+
+	// case 1: replica state not found
+	server.EXPECT().Context().Return(context.TODO())
+	assert.Error(t, r.InstallSnapshot(server))
+
+	ctx := metadata.NewIncomingContext(context.TODO(),
+		metadata.Pairs(
+			constants.RPCMetaReplicaState, `{"database":"test-db","shardId":1,"leader":2,"follower":3}`,
+		))
+	server.EXPECT().Context().Return(ctx).AnyTimes()
+	wal := replica.NewMockWriteAheadLog(ctrl)
+	walMgr.EXPECT().GetOrCreateLog("test-db").Return(wal).AnyTimes()
+	// case 2: receive snapshot err
+	receiveSnapshot = func(_ protoReplicaV1.ReplicaService_InstallSnapshotServer, _ string) (int64, error) {
+		return 0, fmt.Errorf("err")
+	}
+	removeDir = func(path string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, r.InstallSnapshot(server))
+	receiveSnapshot = func(_ protoReplicaV1.ReplicaService_InstallSnapshotServer, _ string) (int64, error) {
+		return 10, nil
+	}
+	removeDir = fileutil.RemoveDir
+	// case 3: drop wal partition err
+	wal.EXPECT().DropPartition(models.ShardID(1)).Return(fmt.Errorf("err"))
+	assert.Error(t, r.InstallSnapshot(server))
+	wal.EXPECT().DropPartition(models.ShardID(1)).Return(nil).AnyTimes()
+	// case 4: install snapshot err
+	engine.EXPECT().InstallShardSnapshot(gomock.Any(), models.ShardID(1)).Return(fmt.Errorf("err"))
+	assert.Error(t, r.InstallSnapshot(server))
+	engine.EXPECT().InstallShardSnapshot(gomock.Any(), models.ShardID(1)).Return(nil).AnyTimes()
+	// case 5: create partition err
+	wal.EXPECT().GetOrCreatePartition(models.ShardID(1)).Return(nil, fmt.Errorf("err"))
+	assert.Error(t, r.InstallSnapshot(server))
+	// case 6: install snapshot successfully
+	p := replica.NewMockPartition(ctrl)
+	wal.EXPECT().GetOrCreatePartition(models.ShardID(1)).Return(p, nil)
+	p.EXPECT().ResetReplicaIndex(int64(11))
+	p.EXPECT().ReplicaAckIndex().Return(int64(10))
+	server.EXPECT().SendAndClose(&protoReplicaV1.SnapshotResponse{AckIndex: 10}).Return(nil)
+	assert.NoError(t, r.InstallSnapshot(server))
+}
@@ -94,6 +94,7 @@ type runtime struct {
 	taskExecutor *storage.TaskExecutor
 	factory      factory
 	engine       tsdb.Engine
+	walMgr       replica.WriteAheadLogManager
 	rpcHandler   *rpcHandler
 	httpServer   *http.Server
 	queryPool    concurrent.Pool
@@ -151,7 +152,9 @@ func (r *runtime) Run() error {
 		hostName = "unknown"
 	}
 	r.node = &models.StatefulNode{
-		ID: models.NodeID(r.config.StorageBase.Indicator),
+		ID:   models.NodeID(r.config.StorageBase.Indicator),
+		Zone: r.config.StorageBase.Zone,
+		Rack: r.config.StorageBase.Rack,
 		StatelessNode: models.StatelessNode{
 			HostIP:     ip,
 			GRPCPort:   r.config.StorageBase.GRPC.Port,
@@ -176,6 +179,12 @@ func (r *runtime) Run() error {
 		r.state = server.Failed
 		return err
 	}
+	// drop the databases which are dropped when storage node is offline before serving
+	if err := storage.DropDroppedDatabases(r.ctx, r.repo, r.engine, r.walMgr); err != nil {
+		r.log.Error("drop dropped databases failure", logger.Error(err))
+		r.state = server.Failed
+		return err
+	}
 
 	// Use Leader election mechanism to ensure the uniqueness of stateful node id
 	if err := r.MustRegisterStateFulNode(); err != nil {
@@ -189,8 +198,10 @@ func (r *runtime) Run() error {
 		return fmt.Errorf("start state machines error: %s", err)
 	}
 
-	r.taskExecutor = storage.NewTaskExecutor(r.ctx, r.node, r.repo, r.engine)
+	r.taskExecutor = storage.NewTaskExecutor(r.ctx, r.node, r.repo, r.engine, r.walMgr, r.walMgr)
 	r.taskExecutor.Run()
+	// start report replication state of write ahead log
+	storage.NewReplicaStateReporter(r.ctx, r.node, r.repo, r.walMgr).Run()
 
 	// start system collector
 	r.systemCollector()
@@ -377,24 +388,26 @@ func (r *runtime) startTCPServer() {
 
 // bindRPCHandlers binds rpc handlers, registers task into grpc server
 func (r *runtime) bindRPCHandlers() {
-	//FIXME: (stone1100) need close
-	leafTaskProcessor := storageQuery.NewLeafTaskProcessor(
-		r.node,
-		r.engine,
-		r.factory.taskServer,
-	)
-
 	//TODO modify
-	walMgr := replica.NewWriteAheadLogManager(
+	r.walMgr = replica.NewWriteAheadLogManager(
 		r.ctx,
 		r.config.StorageBase.WAL,
 		r.node.ID, r.engine,
 		rpc.NewClientStreamFactory(r.node),
 		r.stateMgr,
+		// delete record of write ahead log is applied by local replicator
+		storageQuery.NewDeleteHandler(r.engine),
+	)
+	//FIXME: (stone1100) need close
+	leafTaskProcessor := storageQuery.NewLeafTaskProcessor(
+		r.node,
+		r.engine,
+		r.walMgr,
+		r.factory.taskServer,
 	)
 	r.rpcHandler = &rpcHandler{
-		replica: handler.NewReplicaHandler(walMgr),
-		write:   handler.NewWriteHandler(walMgr),
+		replica: handler.NewReplicaHandler(r.walMgr, r.engine),
+		write:   handler.NewWriteHandler(r.walMgr),
 		task: query.NewTaskHandler(
 			r.config.Query,
 			r.factory.taskServer,
@@ -0,0 +1,99 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lindb/lindb/app/broker/api/admin"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+)
+
+var restoreShardIDs []int
+
+var backupDatabaseCmd = &cobra.Command{
+	Use:   "database-backup [cluster] [database] [path]",
+	Short: "Backups a database into the path of all storage nodes",
+	Args:  cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return putToBroker(admin.BackupDatabasePath, &admin.DatabaseBackupParam{
+			Cluster:  args[0],
+			Database: args[1],
+			Path:     args[2],
+		})
+	},
+}
+
+var restoreDatabaseCmd = &cobra.Command{
+	Use:   "database-restore [cluster] [database] [path]",
+	Short: "Restores a database from the backup path of all storage nodes",
+	Args:  cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var shardIDs []models.ShardID
+		for _, shardID := range restoreShardIDs {
+			shardIDs = append(shardIDs, models.ShardID(shardID))
+		}
+		return putToBroker(admin.RestoreDatabasePath, &admin.DatabaseRestoreParam{
+			Cluster:  args[0],
+			Database: args[1],
+			Path:     args[2],
+			ShardIDs: shardIDs,
+		})
+	},
+}
+
+func init() {
+	restoreDatabaseCmd.Flags().IntSliceVar(
+		&restoreShardIDs, "shards", nil, "shard ids to restore, restore all shards in backup(all assigned shards if database exists) if empty")
+}
+
+// putToBroker submits the param to broker admin api using http put
+func putToBroker(apiPath string, param interface{}) error {
+	u, err := url.Parse(cliBrokerEndpoint)
+	if err != nil {
+		return err
+	}
+	u.Path = path.Join(u.Path, "/api/", apiPath)
+	req, err := http.NewRequest(http.MethodPut, u.String(), bytes.NewReader(encoding.JSONMarshal(param)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json;charset=UTF-8")
+	client := http.Client{Timeout: time.Second * 10}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	body, _ := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("broker response status: %d, body: %s", resp.StatusCode, body)
+	}
+	fmt.Println("submit task successfully")
+	return nil
+}
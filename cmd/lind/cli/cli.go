@@ -43,6 +43,12 @@ func NewCLICmd() *cobra.Command {
 		listDatabaseCmd,
 		getDatabaseCmd,
 		deleteDatabaseCmd,
+		backupDatabaseCmd,
+		restoreDatabaseCmd,
+		rebuildIndexCmd,
+		rebalanceDatabaseCmd,
+		drainStorageNodeCmd,
+		recommissionStorageNodeCmd,
 		addUserCmd,
 		listUserCmd,
 		getUserCmd,
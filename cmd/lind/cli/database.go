@@ -19,7 +19,9 @@ package cli
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/lindb/lindb/app/broker/api/admin"
 	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/internal/bootstrap"
 	"github.com/lindb/lindb/models"
@@ -89,10 +91,22 @@ var getDatabaseCmd = &cobra.Command{
 	},
 }
 
+var dropPurgeDelay time.Duration
+
 var deleteDatabaseCmd = &cobra.Command{
-	Use:   "database-delete",
-	Short: "Deletes a database",
-	Run: func(cmd *cobra.Command, args []string) {
-		// todo: @codingcrush
+	Use:   "database-delete [cluster] [database]",
+	Short: "Deletes a database, storage nodes purge dropped data(except write ahead log) after purge delay",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return putToBroker(admin.DropDatabasePath, &admin.DatabaseDropParam{
+			Cluster:    args[0],
+			Database:   args[1],
+			PurgeDelay: ltoml.Duration(dropPurgeDelay),
+		})
 	},
 }
+
+func init() {
+	deleteDatabaseCmd.Flags().DurationVar(&dropPurgeDelay, "purge-delay", 0,
+		"delay of purging dropped data in trash(write ahead log is always removed directly), remove directly if zero")
+}
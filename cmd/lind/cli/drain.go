@@ -0,0 +1,74 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package cli
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lindb/lindb/app/broker/api/admin"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/ltoml"
+)
+
+var (
+	drainMigrateReplicas bool
+	drainTimeout         time.Duration
+)
+
+var drainStorageNodeCmd = &cobra.Command{
+	Use:   "storage-node-drain [cluster] [node id]",
+	Short: "Moves leaders and optional replicas off storage node, then marks it decommissioned",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		nodeID, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return err
+		}
+		return putToBroker(admin.DrainStorageNodePath, &admin.StorageNodeDrainParam{
+			Cluster:         args[0],
+			NodeID:          models.NodeID(nodeID),
+			MigrateReplicas: drainMigrateReplicas,
+			Timeout:         ltoml.Duration(drainTimeout),
+		})
+	},
+}
+
+var recommissionStorageNodeCmd = &cobra.Command{
+	Use:   "storage-node-recommission [cluster] [node id]",
+	Short: "Cancels the running drain of storage node, removes its draining/decommissioned mark",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		nodeID, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return err
+		}
+		return putToBroker(admin.RecommissionStorageNodePath, &admin.StorageNodeRecommissionParam{
+			Cluster: args[0],
+			NodeID:  models.NodeID(nodeID),
+		})
+	},
+}
+
+func init() {
+	drainStorageNodeCmd.Flags().BoolVar(&drainMigrateReplicas, "migrate-replicas", false,
+		"migrate replicas of node to other live storage nodes")
+	drainStorageNodeCmd.Flags().DurationVar(&drainTimeout, "timeout", 5*time.Minute,
+		"max time waiting write ahead log of node acked by replicas")
+}
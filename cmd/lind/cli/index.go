@@ -0,0 +1,48 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/lindb/lindb/app/broker/api/admin"
+	"github.com/lindb/lindb/models"
+)
+
+var rebuildShardIDs []int
+
+var rebuildIndexCmd = &cobra.Command{
+	Use:   "database-index-rebuild [cluster] [database]",
+	Short: "Rebuilds the series index of shards from data families and forward index in all storage nodes",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var shardIDs []models.ShardID
+		for _, shardID := range rebuildShardIDs {
+			shardIDs = append(shardIDs, models.ShardID(shardID))
+		}
+		return putToBroker(admin.RebuildIndexPath, &admin.IndexRebuildParam{
+			Cluster:  args[0],
+			Database: args[1],
+			ShardIDs: shardIDs,
+		})
+	},
+}
+
+func init() {
+	rebuildIndexCmd.Flags().IntSliceVar(&rebuildShardIDs, "shards", nil, "shard ids to rebuild index")
+	_ = rebuildIndexCmd.MarkFlagRequired("shards")
+}
@@ -0,0 +1,52 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package cli
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lindb/lindb/app/broker/api/admin"
+	"github.com/lindb/lindb/pkg/ltoml"
+)
+
+var (
+	rebalanceMaxConcurrency int
+	rebalanceCatchUpWait    time.Duration
+)
+
+var rebalanceDatabaseCmd = &cobra.Command{
+	Use:   "database-rebalance [cluster] [database]",
+	Short: "Migrates shards of database for spreading replicas and leaders evenly among live storage nodes",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return putToBroker(admin.RebalanceDatabasePath, &admin.DatabaseRebalanceParam{
+			Cluster:        args[0],
+			Database:       args[1],
+			MaxConcurrency: rebalanceMaxConcurrency,
+			CatchUpWait:    ltoml.Duration(rebalanceCatchUpWait),
+		})
+	},
+}
+
+func init() {
+	rebalanceDatabaseCmd.Flags().IntVar(&rebalanceMaxConcurrency, "max-concurrency", 1,
+		"max num. of shards migrating concurrently")
+	rebalanceDatabaseCmd.Flags().DurationVar(&rebalanceCatchUpWait, "catch-up-wait", time.Minute,
+		"max wait for new replica catching up through replica wal before switching leader, migration fails if timeout")
+}
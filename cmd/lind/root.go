@@ -19,6 +19,7 @@ package lind
 
 import (
 	"github.com/lindb/lindb/cmd/lind/cli"
+	"github.com/lindb/lindb/cmd/lind/tool"
 
 	"github.com/spf13/cobra"
 )
@@ -53,5 +54,6 @@ func init() {
 		newBrokerCmd(),
 		newStandaloneCmd(),
 		cli.NewCLICmd(),
+		tool.NewToolCmd(),
 	)
 }
@@ -0,0 +1,203 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package tool
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/lindb/roaring"
+	"github.com/spf13/cobra"
+
+	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/kv/table"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/series/field"
+	"github.com/lindb/lindb/tsdb/tblstore/metricsdata"
+	"github.com/lindb/lindb/tsdb/tblstore/tagindex"
+)
+
+// newMetricDataCmd returns the command which decodes metric data blocks of metric id from data family.
+func newMetricDataCmd() *cobra.Command {
+	var (
+		baseTime int64
+		interval time.Duration
+	)
+	cmd := &cobra.Command{
+		Use:   "metric-data [store path] [family] [metric id]",
+		Short: "Decodes the metric data blocks of metric id into points from all sst files of data family",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			metricID, err := strconv.ParseUint(args[2], 10, 32)
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			return forEachBlock(args[0], args[1], uint32(metricID), isMetricDataFamily,
+				func(_ kv.MergerType, path string, block []byte) error {
+					_, _ = fmt.Fprintf(out, "file: %s\n", path)
+					return printMetricBlock(out, block, baseTime, interval)
+				})
+		},
+	}
+	cmd.Flags().Int64Var(&baseTime, "base-time", 0,
+		"base timestamp(ms) of data family, if set prints timestamp of each point instead of slot")
+	cmd.Flags().DurationVar(&interval, "interval", 10*time.Second, "write interval of database")
+	return cmd
+}
+
+// newTagIndexCmd returns the command which prints forward/inverted index entries of tag key from index family.
+func newTagIndexCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "tag-index [store path] [family] [tag key id]",
+		Short: "Prints forward/inverted index entries of tag key from all sst files of index family",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tagKeyID, err := strconv.ParseUint(args[2], 10, 32)
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			return forEachBlock(args[0], args[1], uint32(tagKeyID), isTagIndexFamily,
+				func(merger kv.MergerType, path string, block []byte) error {
+					_, _ = fmt.Fprintf(out, "file: %s\n", path)
+					return printTagIndexBlock(out, merger, block)
+				})
+		},
+	}
+}
+
+// isMetricDataFamily checks if the family stores metric data by merger of family.
+func isMetricDataFamily(merger kv.MergerType) bool {
+	return merger == metricsdata.MetricDataMerger
+}
+
+// isTagIndexFamily checks if the family stores forward/inverted index by merger of family.
+func isTagIndexFamily(merger kv.MergerType) bool {
+	return merger == tagindex.SeriesForwardMerger || merger == tagindex.SeriesInvertedMerger
+}
+
+// forEachBlock finds the value block of key from all live files of family, then invokes fn for each block.
+func forEachBlock(storePath, familyName string, key uint32, accept func(merger kv.MergerType) bool,
+	fn func(merger kv.MergerType, path string, block []byte) error,
+) error {
+	manifest, err := kv.LoadStoreManifest(storePath)
+	if err != nil {
+		return err
+	}
+	var family *kv.FamilyManifest
+	for _, f := range manifest.Families {
+		if f.Option.Name == familyName {
+			family = f
+			break
+		}
+	}
+	if family == nil {
+		return fmt.Errorf("family: %s not exist in store: %s", familyName, storePath)
+	}
+	merger := kv.MergerType(family.Option.Merger)
+	if !accept(merger) {
+		return fmt.Errorf("family: %s with merger: %s not supported", familyName, merger)
+	}
+	for _, files := range family.Levels {
+		for _, file := range files {
+			if key < file.GetMinKey() || key > file.GetMaxKey() {
+				continue
+			}
+			path := family.FilePath(manifest.Path, file.GetFileNumber())
+			if err := readBlock(path, key, func(block []byte) error {
+				return fn(merger, path, block)
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// readBlock reads the value block of key from sst file, then invokes fn if key exists.
+func readBlock(path string, key uint32, fn func(block []byte) error) error {
+	reader, err := table.NewReader(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+	block, err := reader.Get(key)
+	if errors.Is(err, table.ErrKeyNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return fn(block)
+}
+
+// printMetricBlock prints the tombstones or points of metric block.
+func printMetricBlock(out io.Writer, block []byte, baseTime int64, interval time.Duration) error {
+	if metricsdata.IsTombstones(block) {
+		tombstones, err := metricsdata.DecodeTombstones(nil, block)
+		if err != nil {
+			return err
+		}
+		for _, tombstone := range tombstones {
+			_, _ = fmt.Fprintf(out, "  tombstone slot: [%d,%d], series ids: %v\n",
+				tombstone.SlotRange.Start, tombstone.SlotRange.End, tombstone.SeriesIDs.ToArray())
+		}
+		return nil
+	}
+	if err := metricsdata.VerifyChecksum(block); err != nil {
+		_, _ = fmt.Fprintf(out, "  WARN: %s\n", err)
+	}
+	return metricsdata.DecodeMetricBlock(block, func(seriesID uint32, fieldMeta field.Meta, slot uint16, value float64) {
+		if baseTime > 0 {
+			timestamp := baseTime + int64(slot)*interval.Milliseconds()
+			_, _ = fmt.Fprintf(out, "  series: %d, field: %d(%s), time: %s, value: %v\n",
+				seriesID, fieldMeta.ID, fieldMeta.Type, timeutil.FormatTimestamp(timestamp, "2006-01-02 15:04:05.000"), value)
+			return
+		}
+		_, _ = fmt.Fprintf(out, "  series: %d, field: %d(%s), slot: %d, value: %v\n",
+			seriesID, fieldMeta.ID, fieldMeta.Type, slot, value)
+	})
+}
+
+// printTagIndexBlock prints the series tombstone or forward/inverted index entries of tag key.
+func printTagIndexBlock(out io.Writer, merger kv.MergerType, block []byte) error {
+	if tagindex.IsSeriesTombstone(block) {
+		seriesIDs, err := tagindex.DecodeSeriesTombstone(block)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(out, "  series tombstone: %v\n", seriesIDs.ToArray())
+		return nil
+	}
+	if err := tagindex.VerifyChecksum(block); err != nil {
+		_, _ = fmt.Fprintf(out, "  WARN: %s\n", err)
+	}
+	if merger == tagindex.SeriesInvertedMerger {
+		return tagindex.DecodeInvertedIndex(block, func(tagValueID uint32, seriesIDs *roaring.Bitmap) {
+			_, _ = fmt.Fprintf(out, "  tag value: %d => series: %v\n", tagValueID, seriesIDs.ToArray())
+		})
+	}
+	return tagindex.DecodeForwardIndex(block, func(seriesID, tagValueID uint32) {
+		_, _ = fmt.Fprintf(out, "  series: %d => tag value: %d\n", seriesID, tagValueID)
+	})
+}
@@ -0,0 +1,76 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package tool
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/replica"
+	"github.com/lindb/lindb/tsdb"
+)
+
+// newIndexRebuildCmd returns the command which rebuilds the series index of shard from
+// data families, forward index and un-acked rows in replica write ahead log,
+// then reports the series which have data, but cannot be resolved.
+func newIndexRebuildCmd() *cobra.Command {
+	var cfgPath string
+	cmd := &cobra.Command{
+		Use:   "index-rebuild [database] [shard id]",
+		Short: "Rebuilds the series index of shard from data families and replica write ahead log",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			shardID, err := strconv.Atoi(args[1])
+			if err != nil {
+				return fmt.Errorf("invalid shard id: %s", args[1])
+			}
+			cfg := config.Storage{}
+			if err := config.LoadAndSetStorageConfig(cfgPath, "", &cfg); err != nil {
+				return err
+			}
+			engine, err := tsdb.NewEngine()
+			if err != nil {
+				return err
+			}
+			defer engine.Close()
+
+			shard, ok := engine.GetShard(args[0], models.ShardID(shardID))
+			if !ok {
+				return fmt.Errorf("shard not found, database: %s, shard: %d", args[0], shardID)
+			}
+			report, err := shard.RebuildIndex(
+				replica.NewWriteAheadLogRowSource(cfg.StorageBase.WAL, args[0], models.ShardID(shardID)))
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			_, _ = fmt.Fprintf(out, "%s\n", encoding.JSONMarshal(report))
+			for metricID, seriesIDs := range report.UnresolvedSeries {
+				_, _ = fmt.Fprintf(out, "UNRESOLVED metric: %d, series: %s\n", metricID, seriesIDs.String())
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&cfgPath, "config", "", "storage config file path of the stopped node")
+	_ = cmd.MarkFlagRequired("config")
+	return cmd
+}
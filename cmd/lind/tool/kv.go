@@ -0,0 +1,150 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package tool
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/kv/table"
+	"github.com/lindb/lindb/tsdb/tblstore/metricsdata"
+	"github.com/lindb/lindb/tsdb/tblstore/tagindex"
+)
+
+// newKVManifestCmd returns the command which dumps the version manifest of kv store.
+func newKVManifestCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "kv-manifest [store path]",
+		Short: "Dumps the version manifest, levels and sst files(key range/size) of kv store",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manifest, err := kv.LoadStoreManifest(args[0])
+			if err != nil {
+				return err
+			}
+			printManifest(cmd.OutOrStdout(), manifest)
+			return nil
+		},
+	}
+}
+
+// newKVVerifyCmd returns the command which verifies all live sst files of kv store.
+func newKVVerifyCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "kv-verify [store path]",
+		Short: "Verifies footer, compression and crc32 checksum of each block for all sst files of kv store",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manifest, err := kv.LoadStoreManifest(args[0])
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			failures := 0
+			for _, family := range manifest.Families {
+				for _, files := range family.Levels {
+					for _, file := range files {
+						path := family.FilePath(manifest.Path, file.GetFileNumber())
+						errs := verifyFile(path, file.GetFileSize(), kv.MergerType(family.Option.Merger))
+						if len(errs) == 0 {
+							_, _ = fmt.Fprintf(out, "OK      %s\n", path)
+							continue
+						}
+						failures++
+						for _, e := range errs {
+							_, _ = fmt.Fprintf(out, "CORRUPT %s: %s\n", path, e)
+						}
+					}
+				}
+			}
+			if failures > 0 {
+				return fmt.Errorf("found %d corrupted file(s)", failures)
+			}
+			return nil
+		},
+	}
+}
+
+// printManifest prints the families/levels/files of store manifest.
+func printManifest(out io.Writer, manifest *kv.StoreManifest) {
+	_, _ = fmt.Fprintf(out, "store: %s, levels: %d, next file number: %d\n",
+		manifest.Path, manifest.Option.Levels, manifest.NextFileNumber)
+	for _, family := range manifest.Families {
+		_, _ = fmt.Fprintf(out, "family: %s, id: %d, merger: %s, compression: %s\n",
+			family.Option.Name, family.Option.ID, family.Option.Merger, family.Option.Compression)
+		for level, files := range family.Levels {
+			_, _ = fmt.Fprintf(out, "  level %d: %d file(s)\n", level, len(files))
+			for _, file := range files {
+				_, _ = fmt.Fprintf(out, "    file: %d, min key: %d, max key: %d, size: %d\n",
+					file.GetFileNumber(), file.GetMinKey(), file.GetMaxKey(), file.GetFileSize())
+			}
+		}
+		for fileNumber, interval := range family.RollupFiles {
+			_, _ = fmt.Fprintf(out, "  rollup file: %d, target interval: %d\n", fileNumber, interval)
+		}
+		for familyID, fileNumbers := range family.ReferenceFiles {
+			_, _ = fmt.Fprintf(out, "  reference files of family %d: %v\n", familyID, fileNumbers)
+		}
+	}
+}
+
+// verifyFile verifies the sst file, returns all errors found.
+func verifyFile(path string, expectSize uint32, merger kv.MergerType) (errs []error) {
+	reader, err := table.NewReader(path)
+	if err != nil {
+		return []error{err}
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+	if stat, err := os.Stat(path); err != nil {
+		errs = append(errs, err)
+	} else if stat.Size() != int64(expectSize) {
+		errs = append(errs, fmt.Errorf("file size mismatch, expect: %d, actual: %d", expectSize, stat.Size()))
+	}
+	verifyBlock := blockVerifier(merger)
+	it := reader.Iterator()
+	for it.HasNext() {
+		key := it.Key()
+		block, err := reader.Get(key)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("read block of key: %d error: %s", key, err))
+			continue
+		}
+		if err := verifyBlock(block); err != nil {
+			errs = append(errs, fmt.Errorf("verify block of key: %d error: %s", key, err))
+		}
+	}
+	return errs
+}
+
+// blockVerifier returns the checksum verifier of value block by merger of family.
+func blockVerifier(merger kv.MergerType) func(block []byte) error {
+	switch merger {
+	case metricsdata.MetricDataMerger:
+		return metricsdata.VerifyChecksum
+	case tagindex.SeriesForwardMerger, tagindex.SeriesInvertedMerger:
+		return tagindex.VerifyChecksum
+	default:
+		// block without checksum
+		return func(block []byte) error { return nil }
+	}
+}
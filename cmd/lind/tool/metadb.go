@@ -0,0 +1,91 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package tool
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/spf13/cobra"
+	"go.etcd.io/bbolt"
+)
+
+// newMetaDBCmd returns the command which dumps all buckets of bbolt db file(e.g. metadb/indexdb),
+// then verifies the consistency of db file.
+func newMetaDBCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "metadb [bbolt db file]",
+		Short: "Dumps all buckets/keys of bbolt db file(e.g. meta.db), then checks the consistency of db file",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := bbolt.Open(args[0], 0600, &bbolt.Options{Timeout: 1 * time.Second, ReadOnly: true})
+			if err != nil {
+				return err
+			}
+			defer func() {
+				_ = db.Close()
+			}()
+			out := cmd.OutOrStdout()
+			return db.View(func(tx *bbolt.Tx) error {
+				if err := tx.ForEach(func(name []byte, b *bbolt.Bucket) error {
+					return dumpBucket(out, name, b, 0)
+				}); err != nil {
+					return err
+				}
+				failures := 0
+				for err := range tx.Check() {
+					failures++
+					_, _ = fmt.Fprintf(out, "CORRUPT %s\n", err)
+				}
+				if failures > 0 {
+					return fmt.Errorf("found %d error(s) when checking db file: %s", failures, args[0])
+				}
+				_, _ = fmt.Fprintf(out, "OK      %s\n", args[0])
+				return nil
+			})
+		},
+	}
+}
+
+// dumpBucket prints the keys/values and nested buckets of bucket recursively.
+func dumpBucket(out io.Writer, name []byte, b *bbolt.Bucket, depth int) error {
+	indent := strings.Repeat("  ", depth)
+	_, _ = fmt.Fprintf(out, "%sbucket: %s, sequence: %d\n", indent, formatBytes(name), b.Sequence())
+	return b.ForEach(func(k, v []byte) error {
+		if v == nil {
+			// nested bucket
+			return dumpBucket(out, k, b.Bucket(k), depth+1)
+		}
+		_, _ = fmt.Fprintf(out, "%s  %s => %s\n", indent, formatBytes(k), formatBytes(v))
+		return nil
+	})
+}
+
+// formatBytes returns the string if all characters are printable, else returns hex string.
+func formatBytes(data []byte) string {
+	str := string(data)
+	for _, r := range str {
+		if !unicode.IsPrint(r) {
+			return "0x" + hex.EncodeToString(data)
+		}
+	}
+	return str
+}
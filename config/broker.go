@@ -56,8 +56,9 @@ read-timeout = "%s"`,
 }
 
 type Ingestion struct {
-	MaxConcurrency int            `toml:"max-write-concurrency"`
-	IngestTimeout  ltoml.Duration `toml:"ingest-timeout"`
+	MaxConcurrency    int            `toml:"max-write-concurrency"`
+	IngestTimeout     ltoml.Duration `toml:"ingest-timeout"`
+	InfluxStringField string         `toml:"influx-string-field"`
 }
 
 func (i *Ingestion) TOML() string {
@@ -69,9 +70,14 @@ func (i *Ingestion) TOML() string {
 max-concurrency = %d
 ## maximum duration before timeout for server ingesting metrics
 ## Default: 5s
-ingest-timeout = "%s"`,
+ingest-timeout = "%s"
+## how to handle string fields of influxdb line protocol, fields in LinDB are float only.
+## drop: drops string fields, tag: stores string fields as tags
+## Default: drop
+influx-string-field = "%s"`,
 		i.MaxConcurrency,
-		i.IngestTimeout.Duration().String())
+		i.IngestTimeout.Duration().String(),
+		i.InfluxStringField)
 }
 
 // User represents user model
@@ -148,8 +154,9 @@ func NewDefaultBrokerBase() *BrokerBase {
 			WriteTimeout: ltoml.Duration(time.Second * 5),
 		},
 		Ingestion: Ingestion{
-			MaxConcurrency: runtime.GOMAXPROCS(-1) * 2,
-			IngestTimeout:  ltoml.Duration(time.Second * 5),
+			MaxConcurrency:    runtime.GOMAXPROCS(-1) * 2,
+			IngestTimeout:     ltoml.Duration(time.Second * 5),
+			InfluxStringField: "drop",
 		},
 		Write: Write{
 			BatchTimeout:   ltoml.Duration(time.Second * 2),
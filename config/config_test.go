@@ -19,7 +19,9 @@ package config
 
 import (
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -68,6 +70,12 @@ func Test_NewConfig(t *testing.T) {
 	assert.Equal(t, storageCfg.StorageBase, *NewDefaultStorageBase())
 	assert.Equal(t, storageCfg.Logging, *NewDefaultLogging())
 	assert.Equal(t, storageCfg.Monitor, *NewDefaultMonitor())
+	// block cache disabled
+	assert.Nil(t, ltoml.WriteConfig(storageCfgPath,
+		strings.Replace(NewDefaultStorageTOML(), `block-cache-size = "256 MiB"`, `block-cache-size = "0 B"`, 1)))
+	storageCfg = Storage{}
+	assert.Nil(t, LoadAndSetStorageConfig(storageCfgPath, "storage.toml", &storageCfg))
+	assert.Zero(t, storageCfg.StorageBase.TSDB.GetBlockCacheSize())
 
 	// validate standalone config
 	standaloneCfgPath := filepath.Join(testPath, "standalone.toml")
@@ -157,6 +165,42 @@ func Test_checkStorageBaseCfg(t *testing.T) {
 	assert.NotZero(t, storageCfg4.TSDB.FlushConcurrency)
 	assert.NotZero(t, storageCfg4.TSDB.MaxSeriesIDsNumber)
 	assert.NotZero(t, storageCfg4.TSDB.MaxTagKeysNumber)
+	assert.Equal(t, NewDefaultStorageBase().TSDB.GetBlockCacheSize(), storageCfg4.TSDB.GetBlockCacheSize())
+	// block cache disabled explicitly
+	disabled := ltoml.Size(0)
+	storageCfg4.TSDB.BlockCacheSize = &disabled
+	assert.NoError(t, checkStorageBaseCfg(storageCfg4))
+	assert.Zero(t, storageCfg4.TSDB.GetBlockCacheSize())
+
+	// storage tiers
+	storageCfg4.TSDB.Tiers = []StorageTier{{Dir: "/tmp/lindb-cold", Age: ltoml.Duration(time.Hour)}}
+	assert.NoError(t, checkStorageBaseCfg(storageCfg4))
+	storageCfg4.TSDB.Tiers = []StorageTier{{Age: ltoml.Duration(time.Hour)}}
+	assert.Error(t, checkStorageBaseCfg(storageCfg4))
+	storageCfg4.TSDB.Tiers = []StorageTier{{Dir: "/tmp/lindb-cold"}}
+	assert.Error(t, checkStorageBaseCfg(storageCfg4))
+	storageCfg4.TSDB.Tiers = []StorageTier{
+		{Dir: "/tmp/lindb-cold", Age: ltoml.Duration(time.Hour)},
+		{Dir: "/tmp/lindb-archive", Age: ltoml.Duration(time.Hour)},
+	}
+	assert.Error(t, checkStorageBaseCfg(storageCfg4))
+}
+
+func TestTSDB_TiersTOML(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+	storageCfg := NewDefaultStorageBase()
+	storageCfg.TSDB.Tiers = []StorageTier{
+		{Dir: "/tmp/lindb-cold", Age: ltoml.Duration(time.Hour * 24 * 7)},
+		{Dir: "/tmp/lindb-archive", Age: ltoml.Duration(time.Hour * 24 * 30)},
+	}
+	assert.NoError(t, fileutil.MkDirIfNotExist(testPath))
+	cfgPath := filepath.Join(testPath, "storage-tiers.toml")
+	assert.Nil(t, ltoml.WriteConfig(cfgPath, storageCfg.TOML()))
+	cfg := Storage{}
+	assert.Nil(t, ltoml.DecodeToml(cfgPath, &cfg))
+	assert.Equal(t, storageCfg.TSDB.Tiers, cfg.StorageBase.TSDB.Tiers)
 }
 
 func Test_checkCoordinatorCfg(t *testing.T) {
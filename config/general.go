@@ -117,8 +117,9 @@ connect-timeout = "%s"`,
 
 // StorageCluster represents config of storage cluster
 type StorageCluster struct {
-	Name   string    `json:"name" binding:"required"`
-	Config RepoState `json:"config"`
+	Name        string    `json:"name" binding:"required"`
+	Config      RepoState `json:"config"`
+	PrimaryZone string    `json:"primaryZone,omitempty"` // leaders of shards are preferred in primary zone
 }
 
 // Query represents query rpc config
@@ -22,6 +22,7 @@ import (
 	"math"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/lindb/lindb/pkg/ltoml"
@@ -39,6 +40,15 @@ type TSDB struct {
 	FlushConcurrency         int            `toml:"flush-concurrency"`
 	MaxSeriesIDsNumber       int            `toml:"max-seriesIDs"`
 	MaxTagKeysNumber         int            `toml:"max-tagKeys"`
+	BlockCacheSize           *ltoml.Size    `toml:"block-cache-size"`
+	Tiers                    []StorageTier  `toml:"tiers"`
+}
+
+// StorageTier represents a cold storage tier of time series data,
+// the segments are moved into the dir of tier once all data of segment is older than the age.
+type StorageTier struct {
+	Dir string         `toml:"dir"`
+	Age ltoml.Duration `toml:"age"`
 }
 
 func (t *TSDB) TOML() string {
@@ -82,7 +92,23 @@ flush-concurrency = %d
 max-seriesIDs = %d
 ## Limit for tagKeys
 ## Default: 32
-max-tagKeys = %d`,
+max-tagKeys = %d
+
+## Block cache configuration
+##
+## The memory budget of decoded value blocks cache, which is shared by all databases and shards.
+## Set 0 to disable the block cache.
+## Default: 256 MiB
+block-cache-size = "%s"
+
+## Storage tiers configuration
+##
+## Ordered list of cold storage tiers(sorted by age), the segments are moved
+## from the dir above into the dir of tier once all data of segment is older than the age of tier.
+## Example: moves the segments older than 7 days into HDD
+## [[storage.tsdb.tiers]]
+## dir = "/hdd/lindb/storage/data"
+## age = "168h"%s`,
 		t.Dir,
 		t.MaxMemDBSize.String(),
 		t.MaxMemDBTotalSize.String(),
@@ -93,15 +119,39 @@ max-tagKeys = %d`,
 		t.FlushConcurrency,
 		t.MaxSeriesIDsNumber,
 		t.MaxTagKeysNumber,
+		t.GetBlockCacheSize().String(),
+		t.tiersTOML(),
 	)
 }
 
+// tiersTOML returns the toml config string of storage tiers
+// GetBlockCacheSize returns the memory budget of block cache, 0 means block cache disabled.
+func (t *TSDB) GetBlockCacheSize() ltoml.Size {
+	if t.BlockCacheSize == nil {
+		return 0
+	}
+	return *t.BlockCacheSize
+}
+
+func (t *TSDB) tiersTOML() string {
+	var sb strings.Builder
+	for _, tier := range t.Tiers {
+		sb.WriteString(fmt.Sprintf(`
+[[storage.tsdb.tiers]]
+dir = "%s"
+age = "%s"`, tier.Dir, tier.Age.String()))
+	}
+	return sb.String()
+}
+
 // StorageBase represents a storage configuration
 type StorageBase struct {
-	Indicator int  `toml:"indicator"` // Indicator is unique id under current storage cluster.
-	GRPC      GRPC `toml:"grpc"`
-	TSDB      TSDB `toml:"tsdb"`
-	WAL       WAL  `toml:"wal"`
+	Indicator int    `toml:"indicator"` // Indicator is unique id under current storage cluster.
+	Zone      string `toml:"zone"`      // Zone is the availability zone label of failure domain
+	Rack      string `toml:"rack"`      // Rack is the rack label of failure domain
+	GRPC      GRPC   `toml:"grpc"`
+	TSDB      TSDB   `toml:"tsdb"`
+	WAL       WAL    `toml:"wal"`
 }
 
 // TOML returns StorageBase's toml config string
@@ -111,6 +161,11 @@ func (s *StorageBase) TOML() string {
 ## Indicator is a unique id for identifing each storage node
 ## Make sure indicator on each node is different
 indicator = %d
+## Failure domain labels of storage node, replicas of shard are spread across zones/racks,
+## leaders are preferred in the primary zone of storage cluster.
+## Default: empty, no failure domain
+zone = "%s"
+rack = "%s"
 
 [storage.grpc]%s
 
@@ -118,6 +173,8 @@ indicator = %d
 
 [storage.tsdb]%s`,
 		s.Indicator,
+		s.Zone,
+		s.Rack,
 		s.GRPC.TOML(),
 		s.WAL.TOML(),
 		s.TSDB.TOML(),
@@ -129,6 +186,9 @@ type WAL struct {
 	Dir                string         `toml:"dir"`
 	DataSizeLimit      int64          `toml:"data-size-limit"`
 	RemoveTaskInterval ltoml.Duration `toml:"remove-task-interval"`
+	// ResetLostReplica resets the append index of follower which cannot be caught up by shard snapshot,
+	// data between follower's ack index and leader's smallest ack index will be lost.
+	ResetLostReplica bool `toml:"reset-lost-replica"`
 }
 
 func (rc *WAL) GetDataSizeLimit() int64 {
@@ -149,10 +209,15 @@ dir = "%s"
 ## file is created. It defaults to 512 megabytes, available size is in [1MB, 1GB]
 data-size-limit = %d
 ## interval for how often a new segment will be created
-remove-task-interval = "%s"`,
+remove-task-interval = "%s"
+## reset-lost-replica resets the follower which cannot be caught up by shard snapshot,
+## the wal data which follower needs has been removed will be lost.
+## Only enable it explicitly after confirming the data loss, default false(retry catching up by snapshot)
+reset-lost-replica = %t`,
 		rc.Dir,
 		rc.DataSizeLimit,
 		rc.RemoveTaskInterval.String(),
+		rc.ResetLostReplica,
 	)
 }
 
@@ -167,6 +232,7 @@ type Storage struct {
 
 // NewDefaultStorageBase returns a new default StorageBase struct
 func NewDefaultStorageBase() *StorageBase {
+	blockCacheSize := ltoml.Size(256 * 1024 * 1024)
 	return &StorageBase{
 		Indicator: 1,
 		GRPC: GRPC{
@@ -190,6 +256,7 @@ func NewDefaultStorageBase() *StorageBase {
 			FlushConcurrency:         int(math.Ceil(float64(runtime.GOMAXPROCS(-1)) / 2)),
 			MaxSeriesIDsNumber:       200000,
 			MaxTagKeysNumber:         32,
+			BlockCacheSize:           &blockCacheSize,
 		},
 	}
 }
@@ -245,6 +312,21 @@ func checkTSDBCfg(tsdbCfg *TSDB) error {
 	if tsdbCfg.MaxTagKeysNumber <= 0 {
 		tsdbCfg.MaxTagKeysNumber = defaultStorageCfg.TSDB.MaxTagKeysNumber
 	}
+	if tsdbCfg.BlockCacheSize == nil {
+		// block cache is disabled only if size is set to 0 explicitly
+		tsdbCfg.BlockCacheSize = defaultStorageCfg.TSDB.BlockCacheSize
+	}
+	for idx, tier := range tsdbCfg.Tiers {
+		if tier.Dir == "" {
+			return fmt.Errorf("dir of storage tier cannot be empty")
+		}
+		if tier.Age <= 0 {
+			return fmt.Errorf("age of storage tier must > 0")
+		}
+		if idx > 0 && tier.Age <= tsdbCfg.Tiers[idx-1].Age {
+			return fmt.Errorf("storage tiers must be sorted by age")
+		}
+	}
 	return nil
 }
 
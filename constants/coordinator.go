@@ -30,6 +30,10 @@ const (
 	// StateNodesPath represents the state of node that node will report runtime status
 	//TODO need remove
 	StateNodesPath = "/state/nodes"
+	// ReplicaStatePath represents the replication state of write ahead log that storage node will report
+	ReplicaStatePath = "/state/replica"
+	// DatabaseDroppedPath represents the dropped databases which storage node will drop when it starts.
+	DatabaseDroppedPath = "/database/dropped"
 )
 
 // defines broker level constants will be used in broker.
@@ -40,16 +44,34 @@ const (
 	DatabaseConfigPath = "/database/config"
 	// ShardAssigmentPath represents database shard assignment.
 	ShardAssigmentPath = "/database/assign"
+	// IngestionRulePath represents database ingestion rules(relabel/drop) applied in broker.
+	IngestionRulePath = "/database/ingestion-rule"
+	// DatabaseRebalancePath represents progress of database's shard rebalance.
+	DatabaseRebalancePath = "/database/rebalance"
 	// StorageConfigPath represents storage cluster's config.
 	StorageConfigPath = "/storage/config"
 	// StorageStatePath represents storage cluster's state.
 	StorageStatePath = "/storage/state"
+	// StorageDrainPath represents the running drains of storage nodes.
+	StorageDrainPath = "/storage/drain"
 )
 
 // defines all task kinds
 const (
 	// FlushDatabase represents task kind which is flush memory database for storage node
 	FlushDatabase task.Kind = "flush-database"
+	// BackupDatabase represents task kind which is backup database for storage node
+	BackupDatabase task.Kind = "backup-database"
+	// RestoreDatabase represents task kind which is restore database from backup for storage node
+	RestoreDatabase task.Kind = "restore-database"
+	// RebuildIndex represents task kind which is rebuild series index of shards for storage node
+	RebuildIndex task.Kind = "rebuild-index"
+	// DropDatabase represents task kind which is drop database(shards/write ahead log) for storage node
+	DropDatabase task.Kind = "drop-database"
+	// DropShard represents task kind which is drop shards(data/write ahead log) of database for storage node
+	DropShard task.Kind = "drop-shard"
+	// DrainNode represents task kind which is wait write ahead log replicated before node decommissioned
+	DrainNode task.Kind = "drain-node"
 )
 
 // GetStorageClusterConfigPath returns path which storing config of storage cluster
@@ -61,6 +83,16 @@ func GetStorageStatePath(name string) string {
 	return fmt.Sprintf("%s/%s", StorageStatePath, name)
 }
 
+// GetStorageDrainPath returns path which storing the running drains of storage cluster
+func GetStorageDrainPath(name string) string {
+	return fmt.Sprintf("%s/%s/", StorageDrainPath, name)
+}
+
+// GetNodeDrainPath returns path which storing the running drain of storage node
+func GetNodeDrainPath(name string, node string) string {
+	return fmt.Sprintf("%s/%s/%s", StorageDrainPath, name, node)
+}
+
 // GetDatabaseConfigPath returns path which storing config of database
 func GetDatabaseConfigPath(name string) string {
 	return fmt.Sprintf("%s/%s", DatabaseConfigPath, name)
@@ -71,6 +103,21 @@ func GetDatabaseAssignPath(name string) string {
 	return fmt.Sprintf("%s/%s", ShardAssigmentPath, name)
 }
 
+// GetDatabaseDroppedPath returns path which storing drop param of dropped database
+func GetDatabaseDroppedPath(name string) string {
+	return fmt.Sprintf("%s/%s", DatabaseDroppedPath, name)
+}
+
+// GetIngestionRulePath returns path which storing ingestion rules of database
+func GetIngestionRulePath(name string) string {
+	return fmt.Sprintf("%s/%s", IngestionRulePath, name)
+}
+
+// GetDatabaseRebalancePath returns path which storing shard rebalance progress of database
+func GetDatabaseRebalancePath(name string) string {
+	return fmt.Sprintf("%s/%s", DatabaseRebalancePath, name)
+}
+
 // GetLiveNodePath returns live node register path.
 func GetLiveNodePath(node string) string {
 	return fmt.Sprintf("%s/%s", LiveNodesPath, node)
@@ -80,3 +127,8 @@ func GetLiveNodePath(node string) string {
 func GetNodeMonitoringStatPath(node string) string {
 	return fmt.Sprintf("%s/%s", StateNodesPath, node)
 }
+
+// GetReplicaStatePath returns the path which storing replication state of storage node
+func GetReplicaStatePath(node string) string {
+	return fmt.Sprintf("%s/%s", ReplicaStatePath, node)
+}
@@ -27,6 +27,18 @@ func TestGetDatabaseAssignPath(t *testing.T) {
 	assert.Equal(t, ShardAssigmentPath+"/name", GetDatabaseAssignPath("name"))
 }
 
+func TestGetIngestionRulePath(t *testing.T) {
+	assert.Equal(t, IngestionRulePath+"/name", GetIngestionRulePath("name"))
+}
+
+func TestGetDatabaseRebalancePath(t *testing.T) {
+	assert.Equal(t, DatabaseRebalancePath+"/name", GetDatabaseRebalancePath("name"))
+}
+
+func TestGetDatabaseDroppedPath(t *testing.T) {
+	assert.Equal(t, DatabaseDroppedPath+"/name", GetDatabaseDroppedPath("name"))
+}
+
 func TestGetDatabaseConfigPath(t *testing.T) {
 	assert.Equal(t, DatabaseConfigPath+"/name", GetDatabaseConfigPath("name"))
 }
@@ -40,6 +52,15 @@ func TestGetStorageClusterConfigPath(t *testing.T) {
 
 }
 
+func TestGetStorageDrainPath(t *testing.T) {
+	assert.Equal(t, StorageDrainPath+"/name/", GetStorageDrainPath("name"))
+	assert.Equal(t, StorageDrainPath+"/name/1", GetNodeDrainPath("name", "1"))
+}
+
 func TestGetNodeMonitoringStatPath(t *testing.T) {
 	assert.Equal(t, StateNodesPath+"/1.1.1.1:port", GetNodeMonitoringStatPath("1.1.1.1:port"))
 }
+
+func TestGetReplicaStatePath(t *testing.T) {
+	assert.Equal(t, ReplicaStatePath+"/1", GetReplicaStatePath("1"))
+}
@@ -49,6 +49,10 @@ var (
 
 	// ErrDataFileCorruption represents data in tsdb's file is corrupted
 	ErrDataFileCorruption = errors.New("data corruption")
+	// ErrSegmentExpired represents segment is out of data retention, cannot be created again.
+	ErrSegmentExpired = errors.New("segment is expired")
+	// ErrMetadataConflict represents the metadata cannot be merged, because same name has different id or id is used.
+	ErrMetadataConflict = errors.New("metadata conflict")
 
 	ErrInfluxLineTooLong = errors.New("influx line is too long")
 
@@ -63,4 +67,14 @@ var (
 	ErrNoStorageCluster = errors.New("storage cluster not exist")
 	// ErrStatefulNodeExist represents stateful node already register.
 	ErrStatefulNodeExist = errors.New("stateful node already register")
+	// ErrRebalanceRunning represents shard rebalance of database is running.
+	ErrRebalanceRunning = errors.New("shard rebalance of database is running")
+	// ErrNodeNotAlive represents storage node is not alive.
+	ErrNodeNotAlive = errors.New("storage node is not alive")
+	// ErrDrainRunning represents drain of storage node is running.
+	ErrDrainRunning = errors.New("drain of storage node is running")
+	// ErrNodeNotDrained represents storage node is neither draining nor decommissioned.
+	ErrNodeNotDrained = errors.New("storage node is neither draining nor decommissioned")
+	// ErrReplicaNotCaughtUp represents new replica cannot catch up with leader in time.
+	ErrReplicaNotCaughtUp = errors.New("replica not caught up with leader")
 )
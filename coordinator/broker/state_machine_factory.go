@@ -73,6 +73,13 @@ func (f *stateMachineFactory) Start() (err error) {
 	}
 	f.stateMachines = append(f.stateMachines, sm)
 
+	f.logger.Debug("starting IngestionRuleStateMachine")
+	sm, err = f.createIngestionRuleStateMachine()
+	if err != nil {
+		return err
+	}
+	f.stateMachines = append(f.stateMachines, sm)
+
 	f.logger.Info("started BrokerStateMachines")
 	return nil
 }
@@ -126,6 +133,19 @@ func (f *stateMachineFactory) createStorageStatusStateMachine() (discovery.State
 	)
 }
 
+// createIngestionRuleStateMachine creates database ingestion rule state machine.
+func (f *stateMachineFactory) createIngestionRuleStateMachine() (discovery.StateMachine, error) {
+	return discovery.NewStateMachineFn(
+		f.ctx,
+		discovery.IngestionRuleStateMachine,
+		f.discoveryFactory,
+		constants.IngestionRulePath,
+		true,
+		f.onIngestionRuleChanged,
+		f.onIngestionRuleDeletion,
+	)
+}
+
 // onDatabaseConfigChanged triggers when database config modified(create/update)
 func (f *stateMachineFactory) onDatabaseConfigChanged(key string, data []byte) {
 	f.stateMgr.EmitEvent(&discovery.Event{
@@ -176,3 +196,20 @@ func (f *stateMachineFactory) onStorageDeletion(key string) {
 		Key:  key,
 	})
 }
+
+// onIngestionRuleChanged triggers when ingestion rules of database modified(create/update).
+func (f *stateMachineFactory) onIngestionRuleChanged(key string, data []byte) {
+	f.stateMgr.EmitEvent(&discovery.Event{
+		Type:  discovery.IngestionRuleChanged,
+		Key:   key,
+		Value: data,
+	})
+}
+
+// onIngestionRuleDeletion triggers when ingestion rules of database is deletion.
+func (f *stateMachineFactory) onIngestionRuleDeletion(key string) {
+	f.stateMgr.EmitEvent(&discovery.Event{
+		Type: discovery.IngestionRuleDeletion,
+		Key:  key,
+	})
+}
@@ -53,10 +53,18 @@ func TestStateMachineFactory_Start(t *testing.T) {
 	discovery1.EXPECT().Discovery(gomock.Any()).Return(fmt.Errorf("err"))
 	err = fct.Start()
 	assert.Error(t, err)
+	//ingestion rule sm err
+	discovery1.EXPECT().Discovery(gomock.Any()).Return(nil)
+	discovery1.EXPECT().Discovery(gomock.Any()).Return(nil)
+	discovery1.EXPECT().Discovery(gomock.Any()).Return(nil)
+	discovery1.EXPECT().Discovery(gomock.Any()).Return(fmt.Errorf("err"))
+	err = fct.Start()
+	assert.Error(t, err)
 	// all state machines are ok
 	discovery1.EXPECT().Discovery(gomock.Any()).Return(nil)
 	discovery1.EXPECT().Discovery(gomock.Any()).Return(nil)
 	discovery1.EXPECT().Discovery(gomock.Any()).Return(nil)
+	discovery1.EXPECT().Discovery(gomock.Any()).Return(nil)
 	err = fct.Start()
 	assert.NoError(t, err)
 }
@@ -135,3 +143,23 @@ func TestStateMachineFactory_OnStorage(t *testing.T) {
 	})
 	fct1.onStorageStateChange("/key", []byte("value"))
 }
+
+func TestStateMachineFactory_OnIngestionRule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stateMgr := NewMockStateManager(ctrl)
+	fct := NewStateMachineFactory(context.TODO(), nil, stateMgr)
+	fct1 := fct.(*stateMachineFactory)
+	stateMgr.EXPECT().EmitEvent(&discovery.Event{
+		Type: discovery.IngestionRuleDeletion,
+		Key:  "/key",
+	})
+	fct1.onIngestionRuleDeletion("/key")
+	stateMgr.EXPECT().EmitEvent(&discovery.Event{
+		Type:  discovery.IngestionRuleChanged,
+		Key:   "/key",
+		Value: []byte("value"),
+	})
+	fct1.onIngestionRuleChanged("/key", []byte("value"))
+}
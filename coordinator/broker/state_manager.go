@@ -19,11 +19,15 @@ package broker
 
 import (
 	"context"
+	"fmt"
 	"path/filepath"
+	"reflect"
 	"sync"
 
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/coordinator/discovery"
+	"github.com/lindb/lindb/ingestion/limit"
+	"github.com/lindb/lindb/ingestion/relabel"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/pkg/logger"
@@ -43,10 +47,20 @@ type StateManager interface {
 	GetLiveNodes() []models.StatelessNode
 	// GetDatabaseCfg returns the database config by name.
 	GetDatabaseCfg(databaseName string) (models.Database, bool)
+	// GetRelabeler returns the relabeler compiled by ingestion rules of database.
+	GetRelabeler(databaseName string) (*relabel.Relabeler, bool)
+	// GetLimiter returns the write limiter of database.
+	GetLimiter(databaseName string) (*limit.Limiter, bool)
 	// GetQueryableReplicas returns the queryable replicas，
 	// and chooses the leader replica if the shard has multi-replica.
 	// returns storage node => shard id list
 	GetQueryableReplicas(databaseName string) (map[string][]models.ShardID, error)
+	// GetShardLeaders returns the leader replicas of database's shards, which are used for deleting data,
+	// returns error if any shard is not online or any leader is not alive.
+	// returns storage node => shard state list
+	GetShardLeaders(databaseName string) (map[string][]models.ShardState, error)
+	// GetReplicaStates returns the replication state reported by storage nodes of storage cluster.
+	GetReplicaStates(storageName string) ([]models.NodeReplicaState, bool)
 }
 
 // stateManager implements StateManager.
@@ -59,6 +73,8 @@ type stateManager struct {
 	storages    map[string]*models.StorageState // storage state
 	databases   map[string]models.Database      // database config
 	nodes       map[string]models.StatelessNode // broker live nodes
+	relabelers  map[string]*relabel.Relabeler   // database ingestion rules
+	limiters    map[string]*limit.Limiter       // database write limits
 
 	// connection manager
 	connectionManager rpc.ConnectionManager
@@ -90,6 +106,8 @@ func NewStateManager(
 		storages:          make(map[string]*models.StorageState),
 		databases:         make(map[string]models.Database),
 		nodes:             make(map[string]models.StatelessNode),
+		relabelers:        make(map[string]*relabel.Relabeler),
+		limiters:          make(map[string]*limit.Limiter),
 		events:            make(chan *discovery.Event, 10),
 		logger:            logger.GetLogger("broker", "StateManager"),
 	}
@@ -149,6 +167,10 @@ func (m *stateManager) processEvent(event *discovery.Event) {
 		m.onStorageStateChange(event.Key, event.Value)
 	case discovery.StorageDeletion:
 		m.onStorageDelete(event.Key)
+	case discovery.IngestionRuleChanged:
+		m.onIngestionRuleChange(event.Key, event.Value)
+	case discovery.IngestionRuleDeletion:
+		m.onIngestionRuleDelete(event.Key)
 	}
 }
 
@@ -169,7 +191,22 @@ func (m *stateManager) onDatabaseCfgChange(key string, data []byte) {
 		return
 	}
 
+	oldCfg, ok := m.databases[cfg.Name]
 	m.databases[cfg.Name] = cfg
+
+	if ok && !reflect.DeepEqual(oldCfg.Option, cfg.Option) {
+		// apply changed option(e.g. ahead/behind) to the existing write channel
+		m.cm.AlterDatabaseOption(cfg.Name, cfg.Option)
+	}
+	if ok && reflect.DeepEqual(oldCfg.Limits, cfg.Limits) {
+		// keep the quota usage of limiter if limits not changed
+		return
+	}
+	if cfg.Limits == nil || cfg.Limits.IsEmpty() {
+		delete(m.limiters, cfg.Name)
+		return
+	}
+	m.limiters[cfg.Name] = limit.NewLimiter(cfg.Name, cfg.Limits)
 }
 
 // onDatabaseCfgDelete triggers when database is deletion.
@@ -180,8 +217,41 @@ func (m *stateManager) onDatabaseCfgDelete(key string) {
 	_, databaseName := filepath.Split(key)
 
 	delete(m.databases, databaseName)
+	delete(m.limiters, databaseName)
+	delete(m.relabelers, databaseName)
+
+	// stop write channel of dropped database
+	m.cm.DropDatabase(databaseName)
+}
+
+// onIngestionRuleChange triggers when ingestion rules of database create/modify,
+// compiles the rules and replaces the relabeler of database.
+func (m *stateManager) onIngestionRuleChange(key string, data []byte) {
+	m.logger.Info("ingestion rules are modified",
+		logger.String("key", key),
+		logger.String("data", string(data)))
+
+	rules := &models.IngestionRules{}
+	if err := encoding.JSONUnmarshal(data, rules); err != nil {
+		m.logger.Error("ingestion rules modified but unmarshal error", logger.Error(err))
+		return
+	}
+	relabeler, err := relabel.NewRelabeler(rules)
+	if err != nil {
+		m.logger.Error("ingestion rules modified but compile error", logger.Error(err))
+		return
+	}
+	m.relabelers[rules.Database] = relabeler
+}
+
+// onIngestionRuleDelete triggers when ingestion rules of database is deletion.
+func (m *stateManager) onIngestionRuleDelete(key string) {
+	m.logger.Info("ingestion rules deleted",
+		logger.String("key", key))
+
+	_, databaseName := filepath.Split(key)
 
-	//TODO remove database channel
+	delete(m.relabelers, databaseName)
 }
 
 // onNodeStartup triggers when broker node online.
@@ -311,16 +381,101 @@ func (m *stateManager) GetDatabaseCfg(databaseName string) (models.Database, boo
 	return database, ok
 }
 
+// GetRelabeler returns the relabeler compiled by ingestion rules of database.
+func (m *stateManager) GetRelabeler(databaseName string) (*relabel.Relabeler, bool) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	relabeler, ok := m.relabelers[databaseName]
+	return relabeler, ok
+}
+
+// GetLimiter returns the write limiter of database.
+func (m *stateManager) GetLimiter(databaseName string) (*limit.Limiter, bool) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	limiter, ok := m.limiters[databaseName]
+	return limiter, ok
+}
+
+// GetReplicaStates returns the replication state reported by storage nodes of storage cluster.
+func (m *stateManager) GetReplicaStates(storageName string) ([]models.NodeReplicaState, bool) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	storageState, ok := m.storages[storageName]
+	if !ok {
+		return nil, false
+	}
+	states := make([]models.NodeReplicaState, len(storageState.ReplicaStates))
+	copy(states, storageState.ReplicaStates)
+	return states, true
+}
+
 // GetQueryableReplicas returns the queryable replicas, else return detail error msg.::x
 // returns storage node => shard id list
 func (m *stateManager) GetQueryableReplicas(databaseName string) (map[string][]models.ShardID, error) {
 	m.mutex.RLock()
 	defer m.mutex.RUnlock()
 
+	database, liveNodes, shards, err := m.getShardStates(databaseName)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string][]models.ShardID)
+	for shardID, shardState := range shards {
+		if shardState.State == models.OnlineShard {
+			node := liveNodes[shardState.Leader]
+			nodeID := node.Indicator()
+			result[nodeID] = append(result[nodeID], shardID)
+		} else {
+			m.logger.Warn("shard is not online ignore it, maybe query data will be lost",
+				logger.String("storage", database.Storage),
+				logger.String("database", databaseName),
+				logger.Any("shard", shardState.ID))
+		}
+	}
+	return result, nil
+}
+
+// GetShardLeaders returns the leader replicas of database's shards, else return detail error msg.
+func (m *stateManager) GetShardLeaders(databaseName string) (map[string][]models.ShardState, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	_, liveNodes, shards, err := m.getShardStates(databaseName)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string][]models.ShardState)
+	for shardID, shardState := range shards {
+		if shardState.State != models.OnlineShard {
+			return nil, fmt.Errorf("shard[%d] of database[%s] is not online", shardID, databaseName)
+		}
+		node, ok := liveNodes[shardState.Leader]
+		if !ok {
+			return nil, fmt.Errorf("leader[%d] of shard[%d] of database[%s] is not alive",
+				shardState.Leader, shardID, databaseName)
+		}
+		nodeID := node.Indicator()
+		result[nodeID] = append(result[nodeID], shardState)
+	}
+	return result, nil
+}
+
+// getShardStates returns the live nodes and shard states of database, else return detail error msg.
+// NOTICE: must be called with read lock.
+func (m *stateManager) getShardStates(databaseName string) (
+	database models.Database,
+	liveNodes map[models.NodeID]models.StatefulNode,
+	shards map[models.ShardID]models.ShardState,
+	err error,
+) {
 	// 1. check database if exist
 	database, ok := m.databases[databaseName]
 	if !ok {
-		return nil, constants.ErrDatabaseNotFound
+		return database, nil, nil, constants.ErrDatabaseNotFound
 	}
 
 	// 2. check shards if exist
@@ -329,38 +484,24 @@ func (m *stateManager) GetQueryableReplicas(databaseName string) (map[string][]m
 		m.logger.Warn("database not run on any storage",
 			logger.String("storage", database.Storage),
 			logger.String("database", databaseName))
-		return nil, constants.ErrNoStorageCluster
+		return database, nil, nil, constants.ErrNoStorageCluster
 	}
 	// check if has live nodes
-	liveNodes := storageState.LiveNodes
+	liveNodes = storageState.LiveNodes
 	if len(liveNodes) == 0 {
 		m.logger.Warn("there is no live node for this storage",
 			logger.String("storage", database.Storage),
 			logger.String("database", databaseName))
-		return nil, constants.ErrNoLiveNode
+		return database, nil, nil, constants.ErrNoLiveNode
 	}
-	shards := storageState.ShardStates[databaseName]
+	shards = storageState.ShardStates[databaseName]
 	if len(shards) == 0 {
 		m.logger.Warn("there is no shard for this database",
 			logger.String("storage", database.Storage),
 			logger.String("database", databaseName))
-		return nil, constants.ErrShardNotFound
+		return database, nil, nil, constants.ErrShardNotFound
 	}
-
-	result := make(map[string][]models.ShardID)
-	for shardID, shardState := range shards {
-		if shardState.State == models.OnlineShard {
-			node := liveNodes[shardState.Leader]
-			nodeID := node.Indicator()
-			result[nodeID] = append(result[nodeID], shardID)
-		} else {
-			m.logger.Warn("shard is not online ignore it, maybe query data will be lost",
-				logger.String("storage", database.Storage),
-				logger.String("database", databaseName),
-				logger.Any("shard", shardState.ID))
-		}
-	}
-	return result, nil
+	return database, liveNodes, shards, nil
 }
 
 // buildShardAssign builds the data write channel and related shard state.
@@ -30,6 +30,7 @@ import (
 	"github.com/lindb/lindb/coordinator/discovery"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/option"
 	"github.com/lindb/lindb/replica"
 	"github.com/lindb/lindb/rpc"
 )
@@ -51,7 +52,12 @@ func TestStateManager_Handle_Event_Panic(t *testing.T) {
 }
 
 func TestStateManager_DatabaseConfig(t *testing.T) {
-	mgr := NewStateManager(context.TODO(), models.StatelessNode{}, nil, nil, nil)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cm := replica.NewMockChannelManager(ctrl)
+	cm.EXPECT().DropDatabase(gomock.Any()).AnyTimes()
+	mgr := NewStateManager(context.TODO(), models.StatelessNode{}, nil, nil, cm)
 	// case 1: unmarshal database config err
 	mgr.EmitEvent(&discovery.Event{
 		Type:  discovery.DatabaseConfigChanged,
@@ -92,6 +98,156 @@ func TestStateManager_DatabaseConfig(t *testing.T) {
 	mgr.Close()
 }
 
+func TestStateManager_DatabaseLimits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cm := replica.NewMockChannelManager(ctrl)
+	cm.EXPECT().DropDatabase(gomock.Any()).AnyTimes()
+	mgr := NewStateManager(context.TODO(), models.StatelessNode{}, nil, nil, cm)
+	// case 1: no limits
+	mgr.EmitEvent(&discovery.Event{
+		Type:  discovery.DatabaseConfigChanged,
+		Key:   "/test",
+		Value: []byte(`{"name":"test"}`),
+	})
+	time.Sleep(100 * time.Millisecond) // wait
+	_, ok := mgr.GetLimiter("test")
+	assert.False(t, ok)
+	// case 2: create limiter
+	mgr.EmitEvent(&discovery.Event{
+		Type:  discovery.DatabaseConfigChanged,
+		Key:   "/test",
+		Value: []byte(`{"name":"test","limits":{"rowsPerSecond":10}}`),
+	})
+	time.Sleep(100 * time.Millisecond) // wait
+	limiter, ok := mgr.GetLimiter("test")
+	assert.True(t, ok)
+	// case 3: limits not changed, keep limiter
+	mgr.EmitEvent(&discovery.Event{
+		Type:  discovery.DatabaseConfigChanged,
+		Key:   "/test",
+		Value: []byte(`{"name":"test","numOfShard":3,"limits":{"rowsPerSecond":10}}`),
+	})
+	time.Sleep(100 * time.Millisecond) // wait
+	limiter2, ok := mgr.GetLimiter("test")
+	assert.True(t, ok)
+	assert.Equal(t, limiter, limiter2)
+	// case 4: limits removed
+	mgr.EmitEvent(&discovery.Event{
+		Type:  discovery.DatabaseConfigChanged,
+		Key:   "/test",
+		Value: []byte(`{"name":"test","limits":{}}`),
+	})
+	time.Sleep(100 * time.Millisecond) // wait
+	_, ok = mgr.GetLimiter("test")
+	assert.False(t, ok)
+	// case 5: remove database config
+	mgr.EmitEvent(&discovery.Event{
+		Type:  discovery.DatabaseConfigChanged,
+		Key:   "/test",
+		Value: []byte(`{"name":"test","limits":{"rowsPerSecond":10}}`),
+	})
+	mgr.EmitEvent(&discovery.Event{
+		Type: discovery.DatabaseConfigDeletion,
+		Key:  "/test",
+	})
+	time.Sleep(100 * time.Millisecond) // wait
+	_, ok = mgr.GetLimiter("test")
+	assert.False(t, ok)
+
+	mgr.Close()
+}
+
+func TestStateManager_DatabaseOption(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cm := replica.NewMockChannelManager(ctrl)
+	mgr := NewStateManager(context.TODO(), models.StatelessNode{}, nil, nil, cm)
+	mgr.EmitEvent(&discovery.Event{
+		Type:  discovery.DatabaseConfigChanged,
+		Key:   "/test",
+		Value: []byte(`{"name":"test","option":{"interval":"10s"}}`),
+	})
+	// case 1: option not changed
+	mgr.EmitEvent(&discovery.Event{
+		Type:  discovery.DatabaseConfigChanged,
+		Key:   "/test",
+		Value: []byte(`{"name":"test","numOfShard":3,"option":{"interval":"10s"}}`),
+	})
+	// case 2: alter option of write channel
+	cm.EXPECT().AlterDatabaseOption("test", option.DatabaseOption{Interval: "10s", Behind: "2h"})
+	mgr.EmitEvent(&discovery.Event{
+		Type:  discovery.DatabaseConfigChanged,
+		Key:   "/test",
+		Value: []byte(`{"name":"test","option":{"interval":"10s","behind":"2h"}}`),
+	})
+	time.Sleep(100 * time.Millisecond) // wait
+
+	cm.EXPECT().Close().AnyTimes()
+	mgr.Close()
+}
+
+func TestStateManager_IngestionRule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cm := replica.NewMockChannelManager(ctrl)
+	cm.EXPECT().DropDatabase(gomock.Any()).AnyTimes()
+	mgr := NewStateManager(context.TODO(), models.StatelessNode{}, nil, nil, cm)
+	// case 1: unmarshal ingestion rules err
+	mgr.EmitEvent(&discovery.Event{
+		Type:  discovery.IngestionRuleChanged,
+		Key:   "/test",
+		Value: []byte("221"),
+	})
+	// case 2: compile ingestion rules err
+	mgr.EmitEvent(&discovery.Event{
+		Type:  discovery.IngestionRuleChanged,
+		Key:   "/test",
+		Value: []byte(`{"database":"test","rules":[{"action":"drop_metric","metric":"("}]}`),
+	})
+	time.Sleep(100 * time.Millisecond) // wait
+	_, ok := mgr.GetRelabeler("test")
+	assert.False(t, ok)
+	// case 3: cache relabeler
+	mgr.EmitEvent(&discovery.Event{
+		Type:  discovery.IngestionRuleChanged,
+		Key:   "/test",
+		Value: []byte(`{"database":"test","rules":[{"action":"drop_metric","metric":"cpu"}]}`),
+	})
+	time.Sleep(time.Second) // wait
+	relabeler, ok := mgr.GetRelabeler("test")
+	assert.True(t, ok)
+	assert.NotNil(t, relabeler)
+
+	// case 4: remove ingestion rules
+	mgr.EmitEvent(&discovery.Event{
+		Type: discovery.IngestionRuleDeletion,
+		Key:  "/test",
+	})
+	time.Sleep(time.Second) // wait
+	_, ok = mgr.GetRelabeler("test")
+	assert.False(t, ok)
+
+	// case 5: remove relabeler when database config deleted
+	mgr.EmitEvent(&discovery.Event{
+		Type:  discovery.IngestionRuleChanged,
+		Key:   "/test",
+		Value: []byte(`{"database":"test","rules":[{"action":"drop_metric","metric":"cpu"}]}`),
+	})
+	mgr.EmitEvent(&discovery.Event{
+		Type: discovery.DatabaseConfigDeletion,
+		Key:  "/test",
+	})
+	time.Sleep(100 * time.Millisecond) // wait
+	_, ok = mgr.GetRelabeler("test")
+	assert.False(t, ok)
+
+	mgr.Close()
+}
+
 func TestStateManager_Node(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -287,4 +443,55 @@ func TestStateManager_ShardState(t *testing.T) {
 	replicas, err = mgr.GetQueryableReplicas("db")
 	assert.NoError(t, err)
 	assert.Len(t, replicas, 1)
+
+}
+
+func TestStateManager_GetShardLeaders(t *testing.T) {
+	mgr := NewStateManager(context.TODO(), models.StatelessNode{}, nil, nil, nil)
+	mgr1 := mgr.(*stateManager)
+	mgr1.databases = map[string]models.Database{"db": {Storage: "test"}}
+	storageState := models.NewStorageState("test")
+	storageState.LiveNodes[1] = models.StatefulNode{StatelessNode: models.StatelessNode{HostIP: "1.1.1.1", GRPCPort: 9000}}
+	storageState.ShardStates["db"] = map[models.ShardID]models.ShardState{1: {ID: 1}}
+	mgr1.storages["test"] = storageState
+
+	// db not exist
+	leaders, err := mgr.GetShardLeaders("test_db")
+	assert.Equal(t, err, constants.ErrDatabaseNotFound)
+	assert.Empty(t, leaders)
+	// shard not online
+	leaders, err = mgr.GetShardLeaders("db")
+	assert.Error(t, err)
+	assert.Empty(t, leaders)
+	// leader not alive
+	storageState.ShardStates["db"][1] = models.ShardState{
+		ID: 1, State: models.OnlineShard, Leader: 2, Replica: models.Replica{Replicas: []models.NodeID{1, 2}},
+	}
+	leaders, err = mgr.GetShardLeaders("db")
+	assert.Error(t, err)
+	assert.Empty(t, leaders)
+	// follower not alive, delete is replicated by write ahead log of leader
+	shardState := models.ShardState{
+		ID: 1, State: models.OnlineShard, Leader: 1, Replica: models.Replica{Replicas: []models.NodeID{1, 2}},
+	}
+	storageState.ShardStates["db"][1] = shardState
+	leaders, err = mgr.GetShardLeaders("db")
+	assert.NoError(t, err)
+	assert.Equal(t, []models.ShardState{shardState}, leaders["1.1.1.1:9000"])
+}
+
+func TestStateManager_GetReplicaStates(t *testing.T) {
+	mgr := NewStateManager(context.TODO(), models.StatelessNode{}, nil, nil, nil)
+	states, ok := mgr.GetReplicaStates("test")
+	assert.False(t, ok)
+	assert.Empty(t, states)
+
+	replicaStates := []models.NodeReplicaState{{NodeID: 1, ReportTime: 10}}
+	mgr1 := mgr.(*stateManager)
+	mgr1.mutex.Lock()
+	mgr1.storages["test"] = &models.StorageState{Name: "test", ReplicaStates: replicaStates}
+	mgr1.mutex.Unlock()
+	states, ok = mgr.GetReplicaStates("test")
+	assert.True(t, ok)
+	assert.Equal(t, replicaStates, states)
 }
@@ -29,6 +29,10 @@ const (
 	StorageStateChanged
 	StorageDeletion
 	StorageConfigChanged
+	IngestionRuleChanged
+	IngestionRuleDeletion
+	ReplicaStateChanged
+	ReplicaStateDeletion
 )
 
 // Event represents discovery state change event.
@@ -42,6 +42,8 @@ const (
 	StorageStatusStateMachine
 	StorageConfigStateMachine
 	StorageNodeStateMachine
+	IngestionRuleStateMachine
+	ReplicaStateStateMachine
 )
 
 // String returns state machine type desc.
@@ -59,6 +61,10 @@ func (st StateMachineType) String() string {
 		return "StorageConfigStateMachine"
 	case StorageNodeStateMachine:
 		return "StorageNodeStateMachine"
+	case IngestionRuleStateMachine:
+		return "IngestionRuleStateMachine"
+	case ReplicaStateStateMachine:
+		return "ReplicaStateStateMachine"
 	default:
 		return "Unknown"
 	}
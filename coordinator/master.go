@@ -21,6 +21,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/coordinator/discovery"
@@ -64,6 +65,27 @@ type Master interface {
 	Stop()
 	// FlushDatabase submits the coordinator task for flushing memory database by cluster and database name
 	FlushDatabase(cluster string, databaseName string) error
+	// BackupDatabase submits the coordinator task for backup database by cluster and database name
+	BackupDatabase(cluster string, databaseName string, path string) error
+	// RestoreDatabase submits the coordinator task for restoring database from backup path by cluster,
+	// the restored shards of live database are registered in shard assignment.
+	RestoreDatabase(cluster string, databaseName string, path string, shardIDs []models.ShardID) error
+	// RebuildIndex submits the coordinator task for rebuilding series index of shards by cluster
+	RebuildIndex(cluster string, databaseName string, shardIDs []models.ShardID) error
+	// DropDatabase submits the coordinator task for dropping database by cluster, then deletes database config
+	// and ingestion rules, storage nodes remove write ahead log directly, and purge the data in trash
+	// after purge delay if it > 0.
+	// NOTE: dropped database cannot be undeleted, data in trash is only kept for manual recovery.
+	DropDatabase(cluster string, databaseName string, purgeDelay time.Duration) error
+	// RebalanceDatabase starts shard migrations which spread replicas/leaders of database evenly
+	// among live storage nodes by cluster, the progress is saved in state repo.
+	RebalanceDatabase(cluster string, databaseName string, maxConcurrency int, catchUpWait time.Duration) error
+	// DrainNode moves leaders off the storage node by cluster, waits its write ahead log acked by replicas,
+	// migrates its replicas if need, then marks it decommissioned in storage state.
+	DrainNode(cluster string, nodeID models.NodeID, migrateReplicas bool, timeout time.Duration) error
+	// RecommissionNode cancels the running drain of storage node by cluster,
+	// removes its draining/decommissioned mark in storage state.
+	RecommissionNode(cluster string, nodeID models.NodeID) error
 }
 
 // master implements master interface
@@ -180,3 +202,118 @@ func (m *master) FlushDatabase(cluster string, databaseName string) error {
 	}
 	return nil
 }
+
+// BackupDatabase submits the coordinator task for backup database by cluster and database name
+func (m *master) BackupDatabase(cluster string, databaseName string, path string) error {
+	if m.IsMaster() {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+
+		storage := m.stateMgr.GetStorageCluster(cluster)
+		if storage == nil {
+			return constants.ErrNoStorageCluster
+		}
+		return storage.BackupDatabase(databaseName, path)
+	}
+	return nil
+}
+
+// RestoreDatabase submits the coordinator task for restoring database from backup path by cluster,
+// the restored shards of live database are registered in shard assignment.
+func (m *master) RestoreDatabase(cluster string, databaseName string, path string, shardIDs []models.ShardID) error {
+	if m.IsMaster() {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+
+		return m.stateMgr.RestoreDatabase(cluster, databaseName, path, shardIDs)
+	}
+	return nil
+}
+
+// RebuildIndex submits the coordinator task for rebuilding series index of shards by cluster
+func (m *master) RebuildIndex(cluster string, databaseName string, shardIDs []models.ShardID) error {
+	if m.IsMaster() {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+
+		storage := m.stateMgr.GetStorageCluster(cluster)
+		if storage == nil {
+			return constants.ErrNoStorageCluster
+		}
+		return storage.RebuildIndex(databaseName, shardIDs)
+	}
+	return nil
+}
+
+// DropDatabase submits the coordinator task for dropping database by cluster, then deletes database config
+// and ingestion rules, storage nodes remove write ahead log directly, and purge the data in trash
+// after purge delay if it > 0.
+func (m *master) DropDatabase(cluster string, databaseName string, purgeDelay time.Duration) error {
+	if m.IsMaster() {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+
+		storage := m.stateMgr.GetStorageCluster(cluster)
+		if storage == nil {
+			return constants.ErrNoStorageCluster
+		}
+		// persist the dropped database before deleting database config,
+		// so the storage nodes which are offline can drop it when they start.
+		if err := storage.DropDatabase(databaseName, purgeDelay); err != nil {
+			return err
+		}
+		if err := m.cfg.Repo.Delete(m.ctx, constants.GetDatabaseConfigPath(databaseName)); err != nil {
+			return err
+		}
+		return m.cfg.Repo.Delete(m.ctx, constants.GetIngestionRulePath(databaseName))
+	}
+	return nil
+}
+
+// RebalanceDatabase starts shard migrations which spread replicas/leaders of database evenly
+// among live storage nodes by cluster, the progress is saved in state repo.
+func (m *master) RebalanceDatabase(cluster string, databaseName string,
+	maxConcurrency int, catchUpWait time.Duration,
+) error {
+	if m.IsMaster() {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+
+		storage := m.stateMgr.GetStorageCluster(cluster)
+		if storage == nil {
+			return constants.ErrNoStorageCluster
+		}
+		return m.stateMgr.RebalanceDatabase(databaseName, masterpkg.RebalanceOption{
+			MaxConcurrency: maxConcurrency,
+			CatchUpWait:    catchUpWait,
+		})
+	}
+	return nil
+}
+
+// DrainNode moves leaders off the storage node by cluster, waits its write ahead log acked by replicas,
+// migrates its replicas if need, then marks it decommissioned in storage state.
+func (m *master) DrainNode(cluster string, nodeID models.NodeID, migrateReplicas bool, timeout time.Duration) error {
+	if m.IsMaster() {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+
+		return m.stateMgr.DrainNode(cluster, nodeID, masterpkg.DrainOption{
+			MigrateReplicas: migrateReplicas,
+			Timeout:         timeout,
+		})
+	}
+	return nil
+}
+
+// RecommissionNode cancels the running drain of storage node by cluster,
+// removes its draining/decommissioned mark in storage state.
+func (m *master) RecommissionNode(cluster string, nodeID models.NodeID) error {
+	if m.IsMaster() {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+
+		return m.stateMgr.RecommissionNode(cluster, nodeID)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package master
+
+import (
+	"sort"
+
+	"github.com/lindb/lindb/models"
+)
+
+// failureDomains represents the failure domain labels(zone/rack) of storage nodes.
+type failureDomains struct {
+	zones      map[models.NodeID]string
+	racks      map[models.NodeID]string
+	numOfZones int
+	numOfRacks int
+}
+
+// newFailureDomains creates the failure domains of storage nodes, node without labels is in the default domain.
+func newFailureDomains(nodeIDs []models.NodeID, nodes map[models.NodeID]*models.StatefulNode) *failureDomains {
+	d := &failureDomains{
+		zones: make(map[models.NodeID]string),
+		racks: make(map[models.NodeID]string),
+	}
+	zones := make(map[string]struct{})
+	racks := make(map[string]struct{})
+	for _, nodeID := range nodeIDs {
+		var zone, rack string
+		if node, ok := nodes[nodeID]; ok && node != nil {
+			zone = node.Zone
+			// rack name is unique in zone
+			rack = node.Zone + "/" + node.Rack
+		}
+		d.zones[nodeID] = zone
+		d.racks[nodeID] = rack
+		zones[zone] = struct{}{}
+		racks[rack] = struct{}{}
+	}
+	d.numOfZones = len(zones)
+	d.numOfRacks = len(racks)
+	return d
+}
+
+// alternateNodes reorders nodes so that adjacent nodes are in different zones,
+// nodes in the same zone are alternated by rack, the order is kept if nodes without labels.
+func (d *failureDomains) alternateNodes(nodeIDs []models.NodeID) []models.NodeID {
+	var zoneNodes [][]models.NodeID
+	for _, nodesInZone := range groupNodes(nodeIDs, d.zones) {
+		zoneNodes = append(zoneNodes, interleaveNodes(groupNodes(nodesInZone, d.racks)))
+	}
+	return interleaveNodes(zoneNodes)
+}
+
+// newUsage returns the usage of failure domains for a shard, starts with the leader.
+func (d *failureDomains) newUsage(leader models.NodeID) *domainUsage {
+	u := &domainUsage{
+		domains: d,
+		zones:   make(map[string]struct{}),
+		racks:   make(map[string]struct{}),
+	}
+	u.use(leader)
+	return u
+}
+
+// replicasUsage returns the usage of failure domains for the replicas of a shard except the replica at skip index,
+// the replica on node which not in failure domains(offline node) is ignored.
+func (d *failureDomains) replicasUsage(replicas []models.NodeID, skip int) *domainUsage {
+	u := &domainUsage{
+		domains: d,
+		zones:   make(map[string]struct{}),
+		racks:   make(map[string]struct{}),
+	}
+	for idx, nodeID := range replicas {
+		if _, ok := d.zones[nodeID]; !ok || idx == skip {
+			continue
+		}
+		u.use(nodeID)
+	}
+	return u
+}
+
+// domainUsage represents the zones/racks used by replicas of a shard.
+type domainUsage struct {
+	domains *failureDomains
+	zones   map[string]struct{}
+	racks   map[string]struct{}
+}
+
+// isUsed checks if the zone/rack of node is used by the replicas of shard.
+func (u *domainUsage) isUsed(nodeID models.NodeID) bool {
+	if _, ok := u.zones[u.domains.zones[nodeID]]; ok && u.domains.numOfZones > 1 {
+		return true
+	}
+	if _, ok := u.racks[u.domains.racks[nodeID]]; ok && u.domains.numOfRacks > 1 {
+		return true
+	}
+	return false
+}
+
+// use marks the zone/rack of node used, resets the usage if all zones/racks are used.
+func (u *domainUsage) use(nodeID models.NodeID) {
+	u.zones[u.domains.zones[nodeID]] = struct{}{}
+	if len(u.zones) >= u.domains.numOfZones {
+		u.zones = make(map[string]struct{})
+	}
+	u.racks[u.domains.racks[nodeID]] = struct{}{}
+	if len(u.racks) >= u.domains.numOfRacks {
+		u.racks = make(map[string]struct{})
+	}
+}
+
+// groupNodes groups nodes by label, groups are sorted by label, the order of nodes in group is kept.
+func groupNodes(nodeIDs []models.NodeID, labels map[models.NodeID]string) [][]models.NodeID {
+	var keys []string
+	groups := make(map[string][]models.NodeID)
+	for _, nodeID := range nodeIDs {
+		label := labels[nodeID]
+		if _, ok := groups[label]; !ok {
+			keys = append(keys, label)
+		}
+		groups[label] = append(groups[label], nodeID)
+	}
+	sort.Strings(keys)
+	var result [][]models.NodeID
+	for _, key := range keys {
+		result = append(result, groups[key])
+	}
+	return result
+}
+
+// interleaveNodes picks node from each group by round-robin.
+func interleaveNodes(groups [][]models.NodeID) []models.NodeID {
+	var result []models.NodeID
+	for i := 0; ; i++ {
+		picked := false
+		for _, group := range groups {
+			if i < len(group) {
+				result = append(result, group[i])
+				picked = true
+			}
+		}
+		if !picked {
+			return result
+		}
+	}
+}
@@ -0,0 +1,377 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package master
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/coordinator/task"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/ltoml"
+	"github.com/lindb/lindb/pkg/state"
+	"github.com/lindb/lindb/pkg/timeutil"
+)
+
+const defaultDrainTimeout = 5 * time.Minute
+
+// for testing
+var (
+	drainCheckInterval = time.Second
+	// drainTaskTimeoutMargin is the extra time waiting storage node reports drain task result after timeout
+	drainTaskTimeoutMargin = time.Minute
+)
+
+// errDrainTaskFailure represents write ahead log of draining node not acked by replicas.
+var errDrainTaskFailure = errors.New("write ahead log of draining node not acked by replicas")
+
+// DrainOption represents the options of storage node drain.
+type DrainOption struct {
+	MigrateReplicas bool          // migrate replicas of draining node to other live nodes
+	Timeout         time.Duration // max time waiting write ahead log of draining node acked by replicas
+}
+
+// nodeDrainTaskName returns the coordinator task name of node drain.
+func nodeDrainTaskName(nodeID models.NodeID) string {
+	return strconv.Itoa(int(nodeID))
+}
+
+// drainKey returns the key of running drain.
+func drainKey(storageName string, nodeID models.NodeID) string {
+	return fmt.Sprintf("%s/%d", storageName, nodeID)
+}
+
+// nodeDrainPath returns the path which storing the running drain of storage node.
+func nodeDrainPath(storageName string, nodeID models.NodeID) string {
+	return constants.GetNodeDrainPath(storageName, strconv.Itoa(int(nodeID)))
+}
+
+// DrainNode drains storage node gracefully:
+// 1) marks node draining, moves leaders of shards to other replicas, brokers stop writing to it;
+// 2) waits write ahead log of node acked by replicas;
+// 3) migrates replicas of node to other live nodes if need;
+// 4) marks node decommissioned, node can be stopped safely.
+// step 2~4 run in background, the drain state is saved in storage state.
+func (m *stateManager) DrainNode(storageName string, nodeID models.NodeID, opt DrainOption) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	cluster, ok := m.storages[storageName]
+	if !ok {
+		return constants.ErrNoStorageCluster
+	}
+	key := drainKey(storageName, nodeID)
+	if _, ok := m.drains[key]; ok {
+		return constants.ErrDrainRunning
+	}
+	s := cluster.GetState()
+	if _, ok := s.LiveNodes[nodeID]; !ok {
+		return constants.ErrNodeNotAlive
+	}
+	if opt.Timeout <= 0 {
+		opt.Timeout = defaultDrainTimeout
+	}
+	// mark node draining, then move leaders off it
+	s.NodeDraining(nodeID)
+	m.electLeadersOnNode(s, nodeID)
+	m.syncState(s)
+
+	if err := cluster.DrainNode(nodeID, opt.Timeout); err != nil {
+		return err
+	}
+	// persist the drain, new master resumes it if master changed
+	drain := &models.NodeDrain{
+		NodeID:          nodeID,
+		MigrateReplicas: opt.MigrateReplicas,
+		Timeout:         ltoml.Duration(opt.Timeout),
+		StartTime:       timeutil.Now(),
+	}
+	if err := m.masterRepo.Put(m.ctx, nodeDrainPath(storageName, nodeID), encoding.JSONMarshal(drain)); err != nil {
+		return err
+	}
+	m.startDrain(cluster, nodeID, opt)
+	m.logger.Info("start drain storage node",
+		logger.String("storage", storageName),
+		logger.Any("node", nodeID),
+		logger.Any("option", opt))
+	return nil
+}
+
+// RecommissionNode cancels the running drain of storage node(the replica migration which is running is not rolled back),
+// removes its draining/decommissioned mark, then the node can hold new leaders/replicas again.
+func (m *stateManager) RecommissionNode(storageName string, nodeID models.NodeID) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	cluster, ok := m.storages[storageName]
+	if !ok {
+		return constants.ErrNoStorageCluster
+	}
+	s := cluster.GetState()
+	if !s.IsDraining(nodeID) && !s.IsDecommissioned(nodeID) {
+		return constants.ErrNodeNotDrained
+	}
+	key := drainKey(storageName, nodeID)
+	if cancel, ok := m.drains[key]; ok {
+		cancel()
+		delete(m.drains, key)
+	}
+	if err := m.masterRepo.Delete(m.ctx, nodeDrainPath(storageName, nodeID)); err != nil {
+		return err
+	}
+	s.NodeRecommissioned(nodeID)
+	m.syncState(s)
+	m.logger.Info("storage node is recommissioned",
+		logger.String("storage", storageName),
+		logger.Any("node", nodeID))
+	return nil
+}
+
+// startDrain drains storage node in background, the drain can be canceled by recommissioning node.
+func (m *stateManager) startDrain(cluster StorageCluster, nodeID models.NodeID, opt DrainOption) {
+	key := drainKey(cluster.GetState().Name, nodeID)
+	ctx, cancel := context.WithCancel(m.ctx)
+	m.drains[key] = cancel
+	go func() {
+		m.drainNode(ctx, cluster, nodeID, opt)
+
+		m.mutex.Lock()
+		// canceled drain is removed by canceler
+		if ctx.Err() == nil {
+			delete(m.drains, key)
+		}
+		m.mutex.Unlock()
+		cancel()
+	}()
+}
+
+// resumeDrains restores the draining/decommissioned marks of storage nodes from the storage state persisted
+// by previous master, then resumes the running drains.
+func (m *stateManager) resumeDrains(cluster StorageCluster) {
+	s := cluster.GetState()
+	data, err := m.masterRepo.Get(m.ctx, constants.GetStorageStatePath(s.Name))
+	if err != nil {
+		if !errors.Is(err, state.ErrNotExist) {
+			m.logger.Error("get storage state failure, cannot resume drains",
+				logger.String("storage", s.Name), logger.Error(err))
+		}
+		return
+	}
+	persisted := &models.StorageState{}
+	if err := encoding.JSONUnmarshal(data, persisted); err != nil {
+		m.logger.Error("unmarshal storage state failure, cannot resume drains",
+			logger.String("storage", s.Name), logger.Error(err))
+		return
+	}
+	s.DrainingNodes = persisted.DrainingNodes
+	s.DecommissionedNodes = persisted.DecommissionedNodes
+
+	kvs, err := m.masterRepo.List(m.ctx, constants.GetStorageDrainPath(s.Name))
+	if err != nil {
+		m.logger.Error("list drains of storage failure, cannot resume drains",
+			logger.String("storage", s.Name), logger.Error(err))
+		return
+	}
+	for _, kv := range kvs {
+		drain := &models.NodeDrain{}
+		if err := encoding.JSONUnmarshal(kv.Value, drain); err != nil {
+			m.logger.Warn("unmarshal drain of storage node failure, skip it",
+				logger.String("storage", s.Name), logger.String("key", kv.Key), logger.Error(err))
+			continue
+		}
+		if !s.IsDraining(drain.NodeID) {
+			continue
+		}
+		if _, ok := m.drains[drainKey(s.Name, drain.NodeID)]; ok {
+			continue
+		}
+		m.startDrain(cluster, drain.NodeID, DrainOption{
+			MigrateReplicas: drain.MigrateReplicas,
+			Timeout:         drain.Timeout.Duration(),
+		})
+		m.logger.Info("resume drain storage node",
+			logger.String("storage", s.Name),
+			logger.Any("drain", drain))
+	}
+}
+
+// cancelDrains cancels the running drains of storage cluster.
+func (m *stateManager) cancelDrains(storageName string) {
+	prefix := storageName + "/"
+	for key, cancel := range m.drains {
+		if strings.HasPrefix(key, prefix) {
+			cancel()
+			delete(m.drains, key)
+		}
+	}
+}
+
+// drainNode waits write ahead log of node acked, migrates replicas if need, then marks node decommissioned.
+func (m *stateManager) drainNode(ctx context.Context, cluster StorageCluster, nodeID models.NodeID, opt DrainOption) {
+	if err := m.waitNodeDrained(ctx, cluster, nodeID, opt.Timeout); err != nil {
+		m.logger.Error("wait write ahead log of draining node acked failure, keep node draining",
+			logger.Any("node", nodeID), logger.Error(err))
+		return
+	}
+	if opt.MigrateReplicas {
+		if err := m.migrateNodeReplicas(ctx, cluster, nodeID); err != nil {
+			m.logger.Error("migrate replicas of draining node failure, keep node draining",
+				logger.Any("node", nodeID), logger.Error(err))
+			return
+		}
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if ctx.Err() != nil {
+		// drain is canceled
+		return
+	}
+	s := cluster.GetState()
+	if err := m.masterRepo.Delete(m.ctx, nodeDrainPath(s.Name, nodeID)); err != nil {
+		m.logger.Warn("remove drain of decommissioned node failure",
+			logger.String("storage", s.Name), logger.Any("node", nodeID), logger.Error(err))
+	}
+	s.NodeDecommissioned(nodeID)
+	m.syncState(s)
+	m.logger.Info("storage node is decommissioned",
+		logger.String("storage", s.Name),
+		logger.Any("node", nodeID))
+}
+
+// waitNodeDrained waits the drain task finished by storage node.
+func (m *stateManager) waitNodeDrained(ctx context.Context, cluster StorageCluster, nodeID models.NodeID, timeout time.Duration) error {
+	deadline := time.NewTimer(timeout + drainTaskTimeoutMargin)
+	defer deadline.Stop()
+	ticker := time.NewTicker(drainCheckInterval)
+	defer ticker.Stop()
+
+	taskName := nodeDrainTaskName(nodeID)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline.C:
+			return errDrainTaskFailure
+		case <-ticker.C:
+			taskState, err := cluster.GetTaskState(constants.DrainNode, taskName)
+			if err != nil {
+				m.logger.Warn("get drain task state error", logger.Any("node", nodeID), logger.Error(err))
+				continue
+			}
+			switch taskState {
+			case task.StateDoneOK:
+				return nil
+			case task.StateDoneErr:
+				return errDrainTaskFailure
+			}
+		}
+	}
+}
+
+// migrateNodeReplicas moves replicas of node to other live nodes database by database,
+// the progress is saved as shard rebalance progress of database.
+func (m *stateManager) migrateNodeReplicas(ctx context.Context, cluster StorageCluster, nodeID models.NodeID) error {
+	m.mutex.Lock()
+	replicas := cluster.GetState().ReplicasOnNode(nodeID)
+	m.mutex.Unlock()
+
+	for databaseName := range replicas {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		job, err := m.newNodeMigrationJob(cluster, databaseName, nodeID)
+		if err != nil {
+			return err
+		}
+		job.run()
+
+		m.mutex.Lock()
+		delete(m.rebalances, databaseName)
+		m.mutex.Unlock()
+
+		if _, failed := job.progress.Stats(); failed > 0 {
+			return fmt.Errorf("%d replica migrations of database[%s] failed", failed, databaseName)
+		}
+	}
+	return nil
+}
+
+// newNodeMigrationJob plans the replica migrations which move replicas off node for database.
+func (m *stateManager) newNodeMigrationJob(cluster StorageCluster,
+	databaseName string, nodeID models.NodeID,
+) (*rebalanceJob, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, ok := m.rebalances[databaseName]; ok {
+		return nil, constants.ErrRebalanceRunning
+	}
+	shardAssign, err := m.GetShardAssign(databaseName)
+	if err != nil {
+		return nil, err
+	}
+	// draining/decommissioned nodes are excluded
+	liveNodes, err := cluster.GetLiveNodes()
+	if err != nil {
+		return nil, err
+	}
+	var nodeIDs []models.NodeID
+	nodes := make(map[models.NodeID]*models.StatefulNode)
+	for idx := range liveNodes {
+		node := liveNodes[idx]
+		nodeIDs = append(nodeIDs, node.ID)
+		nodes[node.ID] = &node
+	}
+	_, migrations := RebalanceShardAssignment(nodeIDs, nodes, shardAssign)
+	var moves []*models.ShardMigration
+	for _, migration := range migrations {
+		if migration.Kind == models.MoveReplica && migration.From == nodeID {
+			moves = append(moves, migration)
+		}
+	}
+	numOfReplicas := 0
+	for _, replica := range shardAssign.Shards {
+		if replica.Contain(nodeID) {
+			numOfReplicas++
+		}
+	}
+	if len(moves) < numOfReplicas {
+		return nil, fmt.Errorf("not enough live nodes to hold %d replicas of database[%s]", numOfReplicas, databaseName)
+	}
+	job := newRebalanceJob(m.ctx, m.masterRepo, m.updateShardReplica, m.getReplicaPeerState, m.dropShardReplica, &models.RebalanceProgress{
+		Database:   databaseName,
+		Storage:    cluster.GetState().Name,
+		StartTime:  timeutil.Now(),
+		Migrations: moves,
+	}, RebalanceOption{})
+	job.saveProgress()
+	m.rebalances[databaseName] = job
+	m.logger.Info("start migrate replicas of draining node",
+		logger.String("database", databaseName),
+		logger.Any("node", nodeID),
+		logger.Int("migrations", len(moves)))
+	return job, nil
+}
@@ -0,0 +1,269 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package master
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/coordinator/task"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/state"
+)
+
+func TestStateManager_DrainNode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storage := NewMockStorageCluster(ctrl)
+	mgr := NewStateManager(context.TODO(), nil, nil, nil)
+	mgr1 := mgr.(*stateManager)
+	// case 1: storage not exist
+	err := mgr.DrainNode("test", 1, DrainOption{})
+	assert.Equal(t, constants.ErrNoStorageCluster, err)
+	// case 2: drain is running
+	mgr1.storages["test"] = storage
+	mgr1.drains[drainKey("test", 1)] = func() {}
+	err = mgr.DrainNode("test", 1, DrainOption{})
+	assert.Equal(t, constants.ErrDrainRunning, err)
+	// case 3: node not alive
+	storage.EXPECT().GetState().Return(&models.StorageState{Name: "test"})
+	err = mgr.DrainNode("test", 2, DrainOption{})
+	assert.Equal(t, constants.ErrNodeNotAlive, err)
+}
+
+func TestStateManager_RecommissionNode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := state.NewMockRepository(ctrl)
+	storage := NewMockStorageCluster(ctrl)
+	mgr := NewStateManager(context.TODO(), repo, nil, nil)
+	mgr1 := mgr.(*stateManager)
+	// case 1: storage not exist
+	err := mgr.RecommissionNode("test", 1)
+	assert.Equal(t, constants.ErrNoStorageCluster, err)
+	// case 2: node not drained
+	mgr1.storages["test"] = storage
+	s := &models.StorageState{Name: "test", DrainingNodes: []models.NodeID{1}, DecommissionedNodes: []models.NodeID{2}}
+	storage.EXPECT().GetState().Return(s).AnyTimes()
+	err = mgr.RecommissionNode("test", 3)
+	assert.Equal(t, constants.ErrNodeNotDrained, err)
+	// case 3: remove drain err
+	repo.EXPECT().Delete(gomock.Any(), constants.GetNodeDrainPath("test", "1")).Return(fmt.Errorf("err"))
+	err = mgr.RecommissionNode("test", 1)
+	assert.Error(t, err)
+	assert.True(t, s.IsDraining(1))
+	// case 4: cancel running drain
+	ctx, cancel := context.WithCancel(context.TODO())
+	mgr1.drains[drainKey("test", 1)] = cancel
+	repo.EXPECT().Delete(gomock.Any(), constants.GetNodeDrainPath("test", "1")).Return(nil)
+	repo.EXPECT().Put(gomock.Any(), constants.GetStorageStatePath("test"), gomock.Any()).Return(nil)
+	err = mgr.RecommissionNode("test", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, context.Canceled, ctx.Err())
+	assert.Empty(t, mgr1.drains)
+	assert.False(t, s.IsDraining(1))
+	// case 5: recommission decommissioned node
+	repo.EXPECT().Delete(gomock.Any(), constants.GetNodeDrainPath("test", "2")).Return(nil)
+	repo.EXPECT().Put(gomock.Any(), constants.GetStorageStatePath("test"), gomock.Any()).Return(nil)
+	err = mgr.RecommissionNode("test", 2)
+	assert.NoError(t, err)
+	assert.Empty(t, s.UnavailableNodes())
+}
+
+func TestStateManager_resumeDrains(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := state.NewMockRepository(ctrl)
+	storage := NewMockStorageCluster(ctrl)
+	mgr := NewStateManager(context.TODO(), repo, nil, nil)
+	mgr1 := mgr.(*stateManager)
+	s := &models.StorageState{Name: "test"}
+	storage.EXPECT().GetState().Return(s).AnyTimes()
+	storage.EXPECT().GetTaskState(constants.DrainNode, gomock.Any()).Return(task.StateRunning, nil).AnyTimes()
+	// case 1: storage state not exist
+	repo.EXPECT().Get(gomock.Any(), constants.GetStorageStatePath("test")).Return(nil, state.ErrNotExist)
+	mgr1.resumeDrains(storage)
+	// case 2: get storage state err
+	repo.EXPECT().Get(gomock.Any(), constants.GetStorageStatePath("test")).Return(nil, fmt.Errorf("err"))
+	mgr1.resumeDrains(storage)
+	// case 3: unmarshal storage state err
+	repo.EXPECT().Get(gomock.Any(), constants.GetStorageStatePath("test")).Return([]byte{1, 2, 3}, nil)
+	mgr1.resumeDrains(storage)
+	assert.Empty(t, s.UnavailableNodes())
+	// case 4: list drains err
+	persisted := []byte(`{"name":"test","drainingNodes":[1,2],"decommissionedNodes":[3]}`)
+	repo.EXPECT().Get(gomock.Any(), constants.GetStorageStatePath("test")).Return(persisted, nil).AnyTimes()
+	repo.EXPECT().List(gomock.Any(), constants.GetStorageDrainPath("test")).Return(nil, fmt.Errorf("err"))
+	mgr1.resumeDrains(storage)
+	assert.True(t, s.IsDraining(1))
+	assert.True(t, s.IsDecommissioned(3))
+	// case 5: resume drains of draining nodes
+	repo.EXPECT().List(gomock.Any(), constants.GetStorageDrainPath("test")).Return([]state.KeyValue{
+		{Key: "err", Value: []byte{1, 2, 3}},
+		{Key: "1", Value: []byte(`{"nodeId":1,"timeout":"1m"}`)},
+		{Key: "3", Value: []byte(`{"nodeId":3,"timeout":"1m"}`)},
+	}, nil).Times(2)
+	mgr1.mutex.Lock()
+	mgr1.resumeDrains(storage)
+	assert.Len(t, mgr1.drains, 1)
+	_, ok := mgr1.drains[drainKey("test", 1)]
+	assert.True(t, ok)
+	// case 6: drain is running
+	mgr1.resumeDrains(storage)
+	assert.Len(t, mgr1.drains, 1)
+	// case 7: cancel drains when storage unregistered
+	mgr1.cancelDrains("test")
+	assert.Empty(t, mgr1.drains)
+	mgr1.mutex.Unlock()
+	// wait canceled drain exit
+	time.Sleep(100 * time.Millisecond)
+	mgr1.mutex.Lock()
+	assert.Empty(t, mgr1.drains)
+	mgr1.mutex.Unlock()
+}
+
+func TestStateManager_drainNode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		drainCheckInterval = time.Second
+		ctrl.Finish()
+	}()
+	drainCheckInterval = time.Millisecond
+
+	repo := state.NewMockRepository(ctrl)
+	storage := NewMockStorageCluster(ctrl)
+	mgr := NewStateManager(context.TODO(), repo, nil, nil)
+	mgr1 := mgr.(*stateManager)
+	s := &models.StorageState{Name: "test", DrainingNodes: []models.NodeID{1}}
+	storage.EXPECT().GetState().Return(s).AnyTimes()
+	// case 1: drain task failure, keep node draining
+	gomock.InOrder(
+		storage.EXPECT().GetTaskState(constants.DrainNode, "1").Return(task.StateCreated, fmt.Errorf("err")),
+		storage.EXPECT().GetTaskState(constants.DrainNode, "1").Return(task.StateRunning, nil),
+		storage.EXPECT().GetTaskState(constants.DrainNode, "1").Return(task.StateDoneErr, nil),
+	)
+	mgr1.drainNode(context.TODO(), storage, 1, DrainOption{Timeout: time.Minute})
+	assert.True(t, s.IsDraining(1))
+	// case 2: migrate replicas failure, keep node draining
+	s.ShardAssignments = map[string]*models.ShardAssignment{"test": {
+		Name:   "test",
+		Shards: map[models.ShardID]*models.Replica{0: {Replicas: []models.NodeID{1}}},
+	}}
+	storage.EXPECT().GetTaskState(constants.DrainNode, "1").Return(task.StateDoneOK, nil)
+	repo.EXPECT().Get(gomock.Any(), constants.GetDatabaseAssignPath("test")).Return(nil, fmt.Errorf("err"))
+	mgr1.drainNode(context.TODO(), storage, 1, DrainOption{Timeout: time.Minute, MigrateReplicas: true})
+	assert.True(t, s.IsDraining(1))
+	// case 3: drain canceled after write ahead log acked
+	s.ShardAssignments = nil
+	ctx, cancel := context.WithCancel(context.TODO())
+	storage.EXPECT().GetTaskState(constants.DrainNode, "1").DoAndReturn(func(_ task.Kind, _ string) (task.State, error) {
+		cancel()
+		return task.StateDoneOK, nil
+	})
+	mgr1.drainNode(ctx, storage, 1, DrainOption{Timeout: time.Minute})
+	assert.True(t, s.IsDraining(1))
+	// case 4: node decommissioned
+	storage.EXPECT().GetTaskState(constants.DrainNode, "1").Return(task.StateDoneOK, nil)
+	repo.EXPECT().Delete(gomock.Any(), constants.GetNodeDrainPath("test", "1")).Return(fmt.Errorf("err"))
+	repo.EXPECT().Put(gomock.Any(), constants.GetStorageStatePath("test"), gomock.Any()).Return(nil)
+	mgr1.drainNode(context.TODO(), storage, 1, DrainOption{Timeout: time.Minute, MigrateReplicas: true})
+	assert.False(t, s.IsDraining(1))
+	assert.True(t, s.IsDecommissioned(1))
+}
+
+func TestStateManager_waitNodeDrained(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		drainCheckInterval = time.Second
+		drainTaskTimeoutMargin = time.Minute
+		ctrl.Finish()
+	}()
+	drainCheckInterval = time.Millisecond
+	drainTaskTimeoutMargin = 0
+
+	storage := NewMockStorageCluster(ctrl)
+	storage.EXPECT().GetTaskState(constants.DrainNode, "1").Return(task.StateRunning, nil).AnyTimes()
+	ctx, cancel := context.WithCancel(context.TODO())
+	mgr := NewStateManager(context.TODO(), nil, nil, nil)
+	mgr1 := mgr.(*stateManager)
+	// case 1: timeout
+	err := mgr1.waitNodeDrained(ctx, storage, 1, 10*time.Millisecond)
+	assert.Equal(t, errDrainTaskFailure, err)
+	// case 2: ctx canceled
+	cancel()
+	err = mgr1.waitNodeDrained(ctx, storage, 1, time.Minute)
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestStateManager_migrateNodeReplicas(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := state.NewMockRepository(ctrl)
+	storage := NewMockStorageCluster(ctrl)
+	mgr := NewStateManager(context.TODO(), repo, nil, nil)
+	mgr1 := mgr.(*stateManager)
+	storage.EXPECT().GetState().Return(&models.StorageState{
+		Name: "test",
+		ShardAssignments: map[string]*models.ShardAssignment{"test": {
+			Name:   "test",
+			Shards: map[models.ShardID]*models.Replica{0: {Replicas: []models.NodeID{1, 2}}},
+		}},
+	}).AnyTimes()
+	shardAssign := []byte(`{"name":"test","shards":{"0":{"replicas":[1,2]},"1":{"replicas":[1,2]}}}`)
+	// case 1: drain canceled
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	err := mgr1.migrateNodeReplicas(ctx, storage, 1)
+	assert.Equal(t, context.Canceled, err)
+	// case 2: rebalance is running
+	mgr1.rebalances["test"] = &rebalanceJob{}
+	err = mgr1.migrateNodeReplicas(context.TODO(), storage, 1)
+	assert.Equal(t, constants.ErrRebalanceRunning, err)
+	delete(mgr1.rebalances, "test")
+	// case 3: get shard assignment err
+	repo.EXPECT().Get(gomock.Any(), constants.GetDatabaseAssignPath("test")).Return(nil, fmt.Errorf("err"))
+	err = mgr1.migrateNodeReplicas(context.TODO(), storage, 1)
+	assert.Error(t, err)
+	// case 4: get live nodes err
+	repo.EXPECT().Get(gomock.Any(), constants.GetDatabaseAssignPath("test")).Return(shardAssign, nil)
+	storage.EXPECT().GetLiveNodes().Return(nil, fmt.Errorf("err"))
+	err = mgr1.migrateNodeReplicas(context.TODO(), storage, 1)
+	assert.Error(t, err)
+	// case 5: not enough live nodes
+	repo.EXPECT().Get(gomock.Any(), constants.GetDatabaseAssignPath("test")).Return(shardAssign, nil)
+	storage.EXPECT().GetLiveNodes().Return([]models.StatefulNode{{ID: 2}}, nil)
+	err = mgr1.migrateNodeReplicas(context.TODO(), storage, 1)
+	assert.Error(t, err)
+	// case 6: migration failure
+	repo.EXPECT().Get(gomock.Any(), constants.GetDatabaseAssignPath("test")).Return(shardAssign, nil)
+	storage.EXPECT().GetLiveNodes().Return([]models.StatefulNode{{ID: 2}, {ID: 3}, {ID: 4}}, nil)
+	repo.EXPECT().Put(gomock.Any(), constants.GetDatabaseRebalancePath("test"), gomock.Any()).Return(nil).AnyTimes()
+	repo.EXPECT().Get(gomock.Any(), constants.GetDatabaseAssignPath("test")).Return(nil, fmt.Errorf("err")).AnyTimes()
+	err = mgr1.migrateNodeReplicas(context.TODO(), storage, 1)
+	assert.Error(t, err)
+	assert.Empty(t, mgr1.rebalances)
+}
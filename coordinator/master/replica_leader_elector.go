@@ -24,10 +24,16 @@ import (
 
 //go:generate mockgen -source=./replica_leader_elector.go -destination=./replica_leader_elector_mock.go -package=master
 
+// ReplicaLeaderElector represents the leader elector for shard's replicas.
 type ReplicaLeaderElector interface {
+	// ElectLeader elects the leader from live replicas of shard,
+	// prefers the replicas which not in exclude nodes(draining node etc.),
+	// then prefers the replicas in primary zone if it is not empty.
 	ElectLeader(shardAssignment *models.ShardAssignment,
 		liveNodes map[models.NodeID]models.StatefulNode,
 		shardID models.ShardID,
+		excludeNodes []models.NodeID,
+		primaryZone string,
 	) (leader models.NodeID, err error)
 }
 
@@ -41,6 +47,8 @@ func newReplicaLeaderElector() ReplicaLeaderElector {
 func (r *replicaLeaderElector) ElectLeader(shardAssignment *models.ShardAssignment,
 	liveNodes map[models.NodeID]models.StatefulNode,
 	shardID models.ShardID,
+	excludeNodes []models.NodeID,
+	primaryZone string,
 ) (leader models.NodeID, err error) {
 	replicas, ok := shardAssignment.Shards[shardID]
 	if !ok {
@@ -61,7 +69,32 @@ func (r *replicaLeaderElector) ElectLeader(shardAssignment *models.ShardAssignme
 		err = constants.ErrNoLiveReplica
 		return
 	}
-	//elect leader from live replicas
+	//elect leader from live replicas, prefer the replica not excluded and in primary zone
+	if primaryZone != "" {
+		for _, replica := range liveReplicaNodes.Replicas {
+			if !isExcluded(excludeNodes, replica) && liveNodes[replica].Zone == primaryZone {
+				leader = replica
+				return
+			}
+		}
+	}
+	for _, replica := range liveReplicaNodes.Replicas {
+		if !isExcluded(excludeNodes, replica) {
+			leader = replica
+			return
+		}
+	}
+	// all live replicas are excluded, keep shard online
 	leader = liveReplicaNodes.Replicas[0]
 	return
 }
+
+// isExcluded checks if the node is in exclude nodes.
+func isExcluded(excludeNodes []models.NodeID, nodeID models.NodeID) bool {
+	for _, node := range excludeNodes {
+		if node == nodeID {
+			return true
+		}
+	}
+	return false
+}
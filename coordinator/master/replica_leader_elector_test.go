@@ -0,0 +1,68 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package master
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/models"
+)
+
+func TestReplicaLeaderElector_ElectLeader(t *testing.T) {
+	elector := newReplicaLeaderElector()
+	shardAssignment := &models.ShardAssignment{
+		Name:   "test",
+		Shards: map[models.ShardID]*models.Replica{1: {Replicas: []models.NodeID{1, 2, 3}}},
+	}
+	liveNodes := map[models.NodeID]models.StatefulNode{1: {ID: 1}, 2: {ID: 2}, 3: {ID: 3}}
+
+	leader, err := elector.ElectLeader(shardAssignment, liveNodes, 2, nil, "")
+	assert.Equal(t, constants.ErrShardNotFound, err)
+	assert.Equal(t, models.NodeID(0), leader)
+
+	leader, err = elector.ElectLeader(shardAssignment, map[models.NodeID]models.StatefulNode{4: {ID: 4}}, 1, nil, "")
+	assert.Equal(t, constants.ErrNoLiveReplica, err)
+	assert.Equal(t, models.NodeID(0), leader)
+
+	leader, err = elector.ElectLeader(shardAssignment, liveNodes, 1, nil, "")
+	assert.NoError(t, err)
+	assert.Equal(t, models.NodeID(1), leader)
+	// skip exclude node
+	leader, err = elector.ElectLeader(shardAssignment, liveNodes, 1, []models.NodeID{1}, "")
+	assert.NoError(t, err)
+	assert.Equal(t, models.NodeID(2), leader)
+	// all live replicas are excluded
+	leader, err = elector.ElectLeader(shardAssignment, liveNodes, 1, []models.NodeID{1, 2, 3}, "")
+	assert.NoError(t, err)
+	assert.Equal(t, models.NodeID(1), leader)
+
+	liveNodes = map[models.NodeID]models.StatefulNode{1: {ID: 1, Zone: "z1"}, 2: {ID: 2, Zone: "z2"}, 3: {ID: 3, Zone: "z2"}}
+	// prefer replica in primary zone
+	leader, err = elector.ElectLeader(shardAssignment, liveNodes, 1, nil, "z2")
+	assert.NoError(t, err)
+	assert.Equal(t, models.NodeID(2), leader)
+	leader, err = elector.ElectLeader(shardAssignment, liveNodes, 1, []models.NodeID{2}, "z2")
+	assert.NoError(t, err)
+	assert.Equal(t, models.NodeID(3), leader)
+	// no live replica in primary zone
+	leader, err = elector.ElectLeader(shardAssignment, liveNodes, 1, nil, "z3")
+	assert.NoError(t, err)
+	assert.Equal(t, models.NodeID(1), leader)
+}
@@ -45,7 +45,13 @@ import (
 // s8		s9		s5		s6		s7		(2st replica)
 // s3		s4		s0		s1		s2		(3st replica)
 // s7		s8		s9		s5		s6		(3st replica)
-func ShardAssignment(storageNodeIDs []models.NodeID, cfg *models.Database, fixedStartIndex int, startShardID models.ShardID) (*models.ShardAssignment, error) {
+//
+// If storage nodes have failure domain labels(zone/rack), the node list is reordered so that adjacent nodes are in
+// different zones/racks, and the remaining replicas of each shard skip the nodes whose zone/rack is already used
+// by the shard until all zones/racks are used, so the replicas of shard are spread across failure domains.
+func ShardAssignment(storageNodeIDs []models.NodeID, nodes map[models.NodeID]*models.StatefulNode,
+	cfg *models.Database, fixedStartIndex int, startShardID models.ShardID,
+) (*models.ShardAssignment, error) {
 	numOfShard := cfg.NumOfShard
 	replicaFactor := cfg.ReplicaFactor
 	if numOfShard <= 0 {
@@ -61,12 +67,13 @@ func ShardAssignment(storageNodeIDs []models.NodeID, cfg *models.Database, fixed
 	}
 
 	shardAssignment := models.NewShardAssignment(cfg.Name)
-	assignReplicasToStorageNodes(storageNodeIDs, numOfShard, replicaFactor, fixedStartIndex, startShardID, shardAssignment)
+	assignReplicasToStorageNodes(storageNodeIDs, nodes, numOfShard, replicaFactor, fixedStartIndex, startShardID, shardAssignment)
 
 	return shardAssignment, nil
 }
 
-func ModifyShardAssignment(storageNodeIDs []models.NodeID, cfg *models.Database, shardAssignment *models.ShardAssignment,
+func ModifyShardAssignment(storageNodeIDs []models.NodeID, nodes map[models.NodeID]*models.StatefulNode,
+	cfg *models.Database, shardAssignment *models.ShardAssignment,
 	fixedStartIndex int, startShardID models.ShardID) error {
 	numOfShard := cfg.NumOfShard - len(shardAssignment.Shards)
 	replicaFactor := cfg.ReplicaFactor
@@ -81,16 +88,18 @@ func ModifyShardAssignment(storageNodeIDs []models.NodeID, cfg *models.Database,
 			cfg.Name)
 	}
 
-	assignReplicasToStorageNodes(storageNodeIDs, numOfShard, replicaFactor, fixedStartIndex, startShardID, shardAssignment)
+	assignReplicasToStorageNodes(storageNodeIDs, nodes, numOfShard, replicaFactor, fixedStartIndex, startShardID, shardAssignment)
 
 	return nil
 }
 
 // assignReplicasToStorageNodes assigns replica list for storage storageCluster
 // which database's each shard based on selected node list in storageCluster.
-func assignReplicasToStorageNodes(storageNodeIDs []models.NodeID,
+func assignReplicasToStorageNodes(storageNodeIDs []models.NodeID, nodes map[models.NodeID]*models.StatefulNode,
 	numOfShard, replicaFactor, fixedStartIndex int, startShardID models.ShardID,
 	shardAssignment *models.ShardAssignment) {
+	domains := newFailureDomains(storageNodeIDs, nodes)
+	storageNodeIDs = domains.alternateNodes(storageNodeIDs)
 	numOfNode := len(storageNodeIDs)
 
 	// init start index/shift/current shard
@@ -116,16 +125,28 @@ func assignReplicasToStorageNodes(storageNodeIDs []models.NodeID,
 		leader := storageNodeIDs[firstReplicaIndex]
 		shardAssignment.AddReplica(currentShardID, leader)
 
-		// assign other replica
-		for j := 0; j < replicaFactor-1; j++ {
+		// assign other replica, skip the node which failure domain is used by shard
+		used := domains.newUsage(leader)
+		replicas := 1
+		for j, misses := 0, 0; replicas < replicaFactor; j++ {
 			idx := replicaIndex(firstReplicaIndex, nextReplicaShift, j, numOfNode)
-			shardAssignment.AddReplica(currentShardID, storageNodeIDs[idx])
+			nodeID := storageNodeIDs[idx]
+			if shardAssignment.Shards[currentShardID].Contain(nodeID) {
+				continue
+			}
+			if misses < numOfNode && used.isUsed(nodeID) {
+				misses++
+				continue
+			}
+			shardAssignment.AddReplica(currentShardID, nodeID)
+			used.use(nodeID)
+			replicas++
+			misses = 0
 		}
 
 		// do next shard assign
 		currentShardID++
 	}
-
 }
 
 // replicaIndex calculates replica index based on first replica index and shift
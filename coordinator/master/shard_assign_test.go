@@ -28,7 +28,7 @@ import (
 func TestShardAssign(t *testing.T) {
 	storageNodeIDs := []models.NodeID{0, 1, 2, 3, 4}
 
-	_, err1 := ShardAssignment(storageNodeIDs,
+	_, err1 := ShardAssignment(storageNodeIDs, nil,
 		&models.Database{
 			Name:          "test",
 			NumOfShard:    0,
@@ -36,7 +36,7 @@ func TestShardAssign(t *testing.T) {
 		}, -1, -1)
 	assert.NotNil(t, err1)
 
-	_, err1 = ShardAssignment(storageNodeIDs,
+	_, err1 = ShardAssignment(storageNodeIDs, nil,
 		&models.Database{
 			Name:          "test",
 			NumOfShard:    3,
@@ -44,7 +44,7 @@ func TestShardAssign(t *testing.T) {
 		}, -1, -1)
 	assert.NotNil(t, err1)
 
-	_, err2 := ShardAssignment(storageNodeIDs,
+	_, err2 := ShardAssignment(storageNodeIDs, nil,
 		&models.Database{
 			Name:          "test",
 			NumOfShard:    10,
@@ -52,7 +52,7 @@ func TestShardAssign(t *testing.T) {
 		}, -1, -1)
 	assert.NotNil(t, err2)
 
-	shardAssignment, _ := ShardAssignment(storageNodeIDs,
+	shardAssignment, _ := ShardAssignment(storageNodeIDs, nil,
 		&models.Database{
 			Name:          "test",
 			NumOfShard:    10,
@@ -61,6 +61,86 @@ func TestShardAssign(t *testing.T) {
 	checkShardAssignResult(shardAssignment, t)
 }
 
+func TestShardAssign_FailureDomain(t *testing.T) {
+	storageNodeIDs := []models.NodeID{1, 2, 3, 4, 5, 6}
+	nodes := map[models.NodeID]*models.StatefulNode{
+		1: {Zone: "z1", Rack: "r1"},
+		2: {Zone: "z1", Rack: "r2"},
+		3: {Zone: "z2", Rack: "r1"},
+		4: {Zone: "z2", Rack: "r2"},
+		5: {Zone: "z3", Rack: "r1"},
+		6: {Zone: "z3", Rack: "r2"},
+	}
+	shardAssignment, err := ShardAssignment(storageNodeIDs, nodes,
+		&models.Database{
+			Name:          "test",
+			NumOfShard:    12,
+			ReplicaFactor: 3,
+		}, -1, -1)
+	assert.NoError(t, err)
+	assert.Len(t, shardAssignment.Shards, 12)
+	for _, replica := range shardAssignment.Shards {
+		assert.Len(t, replica.Replicas, 3)
+		zones := make(map[string]struct{})
+		for _, nodeID := range replica.Replicas {
+			zones[nodes[nodeID].Zone] = struct{}{}
+		}
+		assert.Len(t, zones, 3)
+	}
+
+	// zones less than replica factor, spread replicas by rack
+	nodes = map[models.NodeID]*models.StatefulNode{
+		1: {Zone: "z1", Rack: "r1"},
+		2: {Zone: "z1", Rack: "r1"},
+		3: {Zone: "z1", Rack: "r2"},
+		4: {Zone: "z2", Rack: "r1"},
+		5: {Zone: "z2", Rack: "r1"},
+		6: {Zone: "z2", Rack: "r2"},
+	}
+	shardAssignment, err = ShardAssignment(storageNodeIDs, nodes,
+		&models.Database{
+			Name:          "test",
+			NumOfShard:    12,
+			ReplicaFactor: 4,
+		}, -1, -1)
+	assert.NoError(t, err)
+	for _, replica := range shardAssignment.Shards {
+		assert.Len(t, replica.Replicas, 4)
+		racks := make(map[string]struct{})
+		for _, nodeID := range replica.Replicas {
+			racks[nodes[nodeID].Zone+"/"+nodes[nodeID].Rack] = struct{}{}
+		}
+		assert.Len(t, racks, 4)
+	}
+
+	// one zone only, replicas are assigned to the same zone
+	shardAssignment, err = ShardAssignment([]models.NodeID{1, 2, 3}, nodes,
+		&models.Database{
+			Name:          "test",
+			NumOfShard:    3,
+			ReplicaFactor: 3,
+		}, -1, -1)
+	assert.NoError(t, err)
+	for _, replica := range shardAssignment.Shards {
+		assert.Len(t, replica.Replicas, 3)
+	}
+}
+
+func TestFailureDomains_alternateNodes(t *testing.T) {
+	storageNodeIDs := []models.NodeID{1, 2, 3, 4, 5}
+	// without labels, keep the order
+	assert.Equal(t, storageNodeIDs, newFailureDomains(storageNodeIDs, nil).alternateNodes(storageNodeIDs))
+
+	nodes := map[models.NodeID]*models.StatefulNode{
+		1: {Zone: "z1", Rack: "r1"},
+		2: {Zone: "z1", Rack: "r1"},
+		3: {Zone: "z1", Rack: "r2"},
+		4: {Zone: "z2", Rack: "r1"},
+	}
+	assert.Equal(t, []models.NodeID{5, 1, 4, 3, 2},
+		newFailureDomains(storageNodeIDs, nodes).alternateNodes(storageNodeIDs))
+}
+
 func checkShardAssignResult(shardAssignment *models.ShardAssignment, t *testing.T) {
 	assert.Equal(t, 10, len(shardAssignment.Shards))
 	var nodes = make(map[models.NodeID]map[models.ShardID]models.ShardID)
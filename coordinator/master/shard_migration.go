@@ -0,0 +1,288 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package master
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/state"
+	"github.com/lindb/lindb/pkg/timeutil"
+)
+
+const (
+	defaultCatchUpWait   = time.Minute
+	catchUpCheckInterval = time.Second
+)
+
+// RebalanceOption represents the throttle of shard migrations.
+type RebalanceOption struct {
+	MaxConcurrency int           // max num. of shards migrating concurrently, default 1
+	CatchUpWait    time.Duration // max wait for new replica catching up through replica wal before switching leader
+}
+
+// updateShardReplicaFn updates replica list of shard, then saves shard assignment.
+type updateShardReplicaFn func(databaseName string, shardID models.ShardID, update func(replica *models.Replica) error) error
+
+// replicaPeerStateFn returns the replication state of shard from leader to follower reported by leader.
+type replicaPeerStateFn func(storageName, databaseName string, shardID models.ShardID, follower models.NodeID) (
+	peer models.ReplicaPeerState, reportTime int64, ok bool)
+
+// dropShardReplicaFn submits the task dropping data/write ahead log of shard's replica in storage node.
+type dropShardReplicaFn func(storageName, databaseName string, shardID models.ShardID, nodeID models.NodeID) error
+
+// rebalanceJob runs shard migrations of database, migrations of the same shard run in order.
+type rebalanceJob struct {
+	ctx                context.Context
+	repo               state.Repository
+	updateShardReplica updateShardReplicaFn
+	replicaPeerState   replicaPeerStateFn
+	dropShardReplica   dropShardReplicaFn
+	progress           *models.RebalanceProgress
+	opt                RebalanceOption
+	checkInterval      time.Duration
+
+	mutex  sync.Mutex
+	logger *logger.Logger
+}
+
+// newRebalanceJob creates the shard rebalance job.
+func newRebalanceJob(ctx context.Context, repo state.Repository,
+	updateShardReplica updateShardReplicaFn, replicaPeerState replicaPeerStateFn, dropShardReplica dropShardReplicaFn,
+	progress *models.RebalanceProgress, opt RebalanceOption,
+) *rebalanceJob {
+	if opt.MaxConcurrency <= 0 {
+		opt.MaxConcurrency = 1
+	}
+	if opt.CatchUpWait <= 0 {
+		opt.CatchUpWait = defaultCatchUpWait
+	}
+	return &rebalanceJob{
+		ctx:                ctx,
+		repo:               repo,
+		updateShardReplica: updateShardReplica,
+		replicaPeerState:   replicaPeerState,
+		dropShardReplica:   dropShardReplica,
+		progress:           progress,
+		opt:                opt,
+		checkInterval:      catchUpCheckInterval,
+		logger:             logger.GetLogger("master", "RebalanceJob"),
+	}
+}
+
+// run runs all migrations with max concurrency, then saves the final progress.
+func (j *rebalanceJob) run() {
+	// group migrations by shard, keep the order(move replica => transfer leader)
+	var shardIDs []models.ShardID
+	shards := make(map[models.ShardID][]*models.ShardMigration)
+	for _, migration := range j.progress.Migrations {
+		if _, ok := shards[migration.ShardID]; !ok {
+			shardIDs = append(shardIDs, migration.ShardID)
+		}
+		shards[migration.ShardID] = append(shards[migration.ShardID], migration)
+	}
+	migrationsCh := make(chan []*models.ShardMigration)
+	var wait sync.WaitGroup
+	wait.Add(j.opt.MaxConcurrency)
+	for i := 0; i < j.opt.MaxConcurrency; i++ {
+		go func() {
+			defer wait.Done()
+			for migrations := range migrationsCh {
+				for _, migration := range migrations {
+					if !j.migrate(migration) {
+						break
+					}
+				}
+			}
+		}()
+	}
+	for _, shardID := range shardIDs {
+		migrationsCh <- shards[shardID]
+	}
+	close(migrationsCh)
+	wait.Wait()
+
+	j.mutex.Lock()
+	for _, migration := range j.progress.Migrations {
+		if migration.State == models.MigrationPending {
+			migration.State = models.MigrationFailed
+			migration.ErrMsg = "previous migration of shard failed"
+		}
+	}
+	j.progress.EndTime = timeutil.Now()
+	done, failed := j.progress.Stats()
+	j.mutex.Unlock()
+
+	j.saveProgress()
+	j.logger.Info("shard rebalance completed",
+		logger.String("database", j.progress.Database),
+		logger.Int("done", done),
+		logger.Int("failed", failed))
+}
+
+// migrate runs the shard migration, returns if migration is done.
+func (j *rebalanceJob) migrate(migration *models.ShardMigration) bool {
+	j.setState(migration, models.MigrationRunning, nil)
+
+	var err error
+	switch migration.Kind {
+	case models.MoveReplica:
+		err = j.moveReplica(migration)
+	case models.TransferLeader:
+		err = j.transferLeader(migration.ShardID, migration.To)
+	default:
+		err = fmt.Errorf("unknown migration kind: %s", migration.Kind)
+	}
+	if err != nil {
+		j.logger.Error("migrate shard failure",
+			logger.String("database", j.progress.Database),
+			logger.Any("migration", migration),
+			logger.Error(err))
+		j.setState(migration, models.MigrationFailed, err)
+		return false
+	}
+	j.setState(migration, models.MigrationDone, nil)
+	return true
+}
+
+// moveReplica moves replica of shard: add new replica => catch up => switch leader => remove old replica
+// => drop data of old replica, new replica is removed if it cannot catch up with leader.
+func (j *rebalanceJob) moveReplica(migration *models.ShardMigration) error {
+	database := j.progress.Database
+	// 1. add new replica, storage node creates shard, leader replicates wal to new replica
+	if err := j.updateShardReplica(database, migration.ShardID, func(replica *models.Replica) error {
+		if indexOf(replica.Replicas, migration.From) < 0 {
+			return constants.ErrReplicaNotFound
+		}
+		if !replica.Contain(migration.To) {
+			replica.Replicas = append(replica.Replicas, migration.To)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	// 2. wait new replica catching up through replica wal
+	if err := j.waitCatchUp(migration); err != nil {
+		if rollbackErr := j.removeReplica(migration.ShardID, migration.To); rollbackErr != nil {
+			j.logger.Error("remove new replica failure, when new replica cannot catch up",
+				logger.String("database", database),
+				logger.Any("migration", migration),
+				logger.Error(rollbackErr))
+		}
+		return err
+	}
+	// 3. new replica takes the position of old replica, switch leader if old replica is leader
+	if err := j.updateShardReplica(database, migration.ShardID, func(replica *models.Replica) error {
+		from := indexOf(replica.Replicas, migration.From)
+		to := indexOf(replica.Replicas, migration.To)
+		if from < 0 || to < 0 {
+			return constants.ErrReplicaNotFound
+		}
+		replica.Replicas[from], replica.Replicas[to] = replica.Replicas[to], replica.Replicas[from]
+		return nil
+	}); err != nil {
+		return err
+	}
+	// 4. remove old replica
+	if err := j.removeReplica(migration.ShardID, migration.From); err != nil {
+		return err
+	}
+	// 5. old node drops the shard data and write ahead log which are not used any more
+	return j.dropShardReplica(j.progress.Storage, database, migration.ShardID, migration.From)
+}
+
+// waitCatchUp waits until the ack index of new replica reaches the append index of leader,
+// returns constants.ErrReplicaNotCaughtUp if new replica cannot catch up in time.
+func (j *rebalanceJob) waitCatchUp(migration *models.ShardMigration) error {
+	// the replication state reported before adding new replica is stale
+	startTime := timeutil.Now()
+	timeout := time.NewTimer(j.opt.CatchUpWait)
+	defer timeout.Stop()
+	ticker := time.NewTicker(j.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		peer, reportTime, ok := j.replicaPeerState(j.progress.Storage, j.progress.Database, migration.ShardID, migration.To)
+		// append index is the next index for appending message
+		if ok && reportTime >= startTime && peer.Ready && peer.AckIndex >= peer.AppendIndex-1 {
+			return nil
+		}
+		select {
+		case <-ticker.C:
+		case <-timeout.C:
+			return fmt.Errorf("%w, shard: %d, replica: %d", constants.ErrReplicaNotCaughtUp, migration.ShardID, migration.To)
+		case <-j.ctx.Done():
+			return j.ctx.Err()
+		}
+	}
+}
+
+// removeReplica removes the replica of shard.
+func (j *rebalanceJob) removeReplica(shardID models.ShardID, nodeID models.NodeID) error {
+	return j.updateShardReplica(j.progress.Database, shardID, func(replica *models.Replica) error {
+		idx := indexOf(replica.Replicas, nodeID)
+		if idx < 0 {
+			return nil
+		}
+		replica.Replicas = append(replica.Replicas[:idx], replica.Replicas[idx+1:]...)
+		return nil
+	})
+}
+
+// transferLeader transfers leader of shard to the replica.
+func (j *rebalanceJob) transferLeader(shardID models.ShardID, leader models.NodeID) error {
+	return j.updateShardReplica(j.progress.Database, shardID, func(replica *models.Replica) error {
+		idx := indexOf(replica.Replicas, leader)
+		if idx < 0 {
+			return constants.ErrReplicaNotFound
+		}
+		replica.Replicas[0], replica.Replicas[idx] = replica.Replicas[idx], replica.Replicas[0]
+		return nil
+	})
+}
+
+// setState sets the state of migration, then saves the progress.
+func (j *rebalanceJob) setState(migration *models.ShardMigration, state models.MigrationState, err error) {
+	j.mutex.Lock()
+	migration.State = state
+	if err != nil {
+		migration.ErrMsg = err.Error()
+	}
+	j.mutex.Unlock()
+
+	j.saveProgress()
+}
+
+// saveProgress saves the progress of rebalance into state repo.
+func (j *rebalanceJob) saveProgress() {
+	j.mutex.Lock()
+	data := encoding.JSONMarshal(j.progress)
+	j.mutex.Unlock()
+
+	if err := j.repo.Put(j.ctx,
+		constants.GetDatabaseRebalancePath(j.progress.Database), data); err != nil {
+		j.logger.Error("save shard rebalance progress failure",
+			logger.String("database", j.progress.Database),
+			logger.Error(err))
+	}
+}
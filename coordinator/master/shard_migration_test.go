@@ -0,0 +1,167 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package master
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/state"
+	"github.com/lindb/lindb/pkg/timeutil"
+)
+
+func TestRebalanceJob_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := state.NewMockRepository(ctrl)
+	repo.EXPECT().Put(gomock.Any(), constants.GetDatabaseRebalancePath("test"), gomock.Any()).
+		Return(fmt.Errorf("err")).AnyTimes()
+	shardAssign := models.NewShardAssignment("test")
+	shardAssign.AddReplica(0, 1)
+	shardAssign.AddReplica(0, 2)
+	shardAssign.AddReplica(1, 1)
+	shardAssign.AddReplica(1, 2)
+	var lock sync.Mutex
+	update := func(databaseName string, shardID models.ShardID, fn func(replica *models.Replica) error) error {
+		lock.Lock()
+		defer lock.Unlock()
+		replica, ok := shardAssign.Shards[shardID]
+		if !ok {
+			return constants.ErrShardNotFound
+		}
+		return fn(replica)
+	}
+	progress := &models.RebalanceProgress{
+		Database: "test",
+		Migrations: []*models.ShardMigration{
+			{Kind: models.MoveReplica, ShardID: 0, From: 1, To: 3, State: models.MigrationPending},
+			{Kind: models.TransferLeader, ShardID: 1, From: 1, To: 2, State: models.MigrationPending},
+			{Kind: models.MoveReplica, ShardID: 2, From: 1, To: 3, State: models.MigrationPending},
+			{Kind: models.TransferLeader, ShardID: 2, From: 1, To: 3, State: models.MigrationPending},
+			{Kind: models.TransferLeader, ShardID: 1, From: 2, To: 4, State: models.MigrationPending},
+			{Kind: "unknown", ShardID: 3, State: models.MigrationPending},
+		},
+	}
+	job := newRebalanceJob(context.TODO(), repo, update, caughtUp, dropShard, progress,
+		RebalanceOption{MaxConcurrency: 2, CatchUpWait: time.Millisecond})
+	job.run()
+
+	assert.Equal(t, []models.NodeID{3, 2}, shardAssign.Shards[0].Replicas)
+	assert.Equal(t, []models.NodeID{2, 1}, shardAssign.Shards[1].Replicas)
+	assert.True(t, progress.Done())
+	assert.True(t, progress.EndTime > 0)
+	done, failed := progress.Stats()
+	assert.Equal(t, 2, done)
+	assert.Equal(t, 4, failed)
+	assert.Equal(t, constants.ErrShardNotFound.Error(), progress.Migrations[2].ErrMsg)
+	assert.Equal(t, constants.ErrReplicaNotFound.Error(), progress.Migrations[4].ErrMsg)
+}
+
+func TestRebalanceJob_MoveReplica(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := state.NewMockRepository(ctrl)
+	repo.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	replica := &models.Replica{Replicas: []models.NodeID{1, 2}}
+	var steps [][]models.NodeID
+	update := func(databaseName string, shardID models.ShardID, fn func(replica *models.Replica) error) error {
+		if err := fn(replica); err != nil {
+			return err
+		}
+		steps = append(steps, append([]models.NodeID{}, replica.Replicas...))
+		return nil
+	}
+	var droppedNodes []models.NodeID
+	var dropErr error
+	drop := func(storageName, databaseName string, shardID models.ShardID, nodeID models.NodeID) error {
+		droppedNodes = append(droppedNodes, nodeID)
+		return dropErr
+	}
+	job := newRebalanceJob(context.TODO(), repo, update, caughtUp, drop, &models.RebalanceProgress{Database: "test"},
+		RebalanceOption{CatchUpWait: time.Second})
+	// case 1: add replica => switch leader => remove old replica => drop shard in old node
+	err := job.moveReplica(&models.ShardMigration{ShardID: 1, From: 1, To: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]models.NodeID{{1, 2, 3}, {3, 2, 1}, {3, 2}}, steps)
+	assert.Equal(t, []models.NodeID{1}, droppedNodes)
+	// case 2: old replica not exist
+	err = job.moveReplica(&models.ShardMigration{ShardID: 1, From: 1, To: 4})
+	assert.Equal(t, constants.ErrReplicaNotFound, err)
+	// case 3: submit drop shard task err
+	steps = nil
+	dropErr = constants.ErrNodeNotAlive
+	err = job.moveReplica(&models.ShardMigration{ShardID: 1, From: 2, To: 1})
+	assert.Equal(t, constants.ErrNodeNotAlive, err)
+	assert.Equal(t, [][]models.NodeID{{3, 2, 1}, {3, 1, 2}, {3, 1}}, steps)
+	assert.Equal(t, []models.NodeID{1, 2}, droppedNodes)
+	// reset replicas for following cases
+	replica.Replicas = []models.NodeID{3, 2}
+	droppedNodes = nil
+	// case 4: new replica cannot catch up, remove new replica
+	steps = nil
+	var peerState models.ReplicaPeerState
+	var reportTime int64
+	notCaughtUp := func(_, _ string, _ models.ShardID, _ models.NodeID) (models.ReplicaPeerState, int64, bool) {
+		return peerState, reportTime, true
+	}
+	job = newRebalanceJob(context.TODO(), repo, update, notCaughtUp, drop, &models.RebalanceProgress{Database: "test"},
+		RebalanceOption{CatchUpWait: 10 * time.Millisecond})
+	job.checkInterval = time.Millisecond
+	peerState = models.ReplicaPeerState{AppendIndex: 10, AckIndex: 5, Ready: true}
+	reportTime = timeutil.Now() + timeutil.OneHour
+	err = job.moveReplica(&models.ShardMigration{ShardID: 1, From: 3, To: 4})
+	assert.True(t, errors.Is(err, constants.ErrReplicaNotCaughtUp))
+	assert.Equal(t, [][]models.NodeID{{3, 2, 4}, {3, 2}}, steps)
+	// case 5: replica state reported before adding new replica is stale
+	steps = nil
+	peerState = models.ReplicaPeerState{AppendIndex: 10, AckIndex: 9, Ready: true}
+	reportTime = 0
+	err = job.moveReplica(&models.ShardMigration{ShardID: 1, From: 3, To: 4})
+	assert.True(t, errors.Is(err, constants.ErrReplicaNotCaughtUp))
+	assert.Equal(t, [][]models.NodeID{{3, 2, 4}, {3, 2}}, steps)
+	// case 6: context canceled when waiting catch up
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	job = newRebalanceJob(ctx, repo, update, notCaughtUp, drop, &models.RebalanceProgress{Database: "test"}, RebalanceOption{})
+	assert.Equal(t, 1, job.opt.MaxConcurrency)
+	assert.Equal(t, defaultCatchUpWait, job.opt.CatchUpWait)
+	err = job.moveReplica(&models.ShardMigration{ShardID: 1, From: 3, To: 4})
+	assert.Equal(t, context.Canceled, err)
+	// shard data of old replica is kept if migration failed
+	assert.Empty(t, droppedNodes)
+}
+
+// dropShard submits the task dropping shard in storage node successfully.
+func dropShard(_, _ string, _ models.ShardID, _ models.NodeID) error {
+	return nil
+}
+
+// caughtUp returns the replication state which new replica has caught up with leader.
+func caughtUp(_, _ string, _ models.ShardID, _ models.NodeID) (models.ReplicaPeerState, int64, bool) {
+	return models.ReplicaPeerState{AppendIndex: 10, AckIndex: 9, Ready: true}, timeutil.Now(), true
+}
@@ -0,0 +1,254 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package master
+
+import (
+	"sort"
+
+	"github.com/lindb/lindb/models"
+)
+
+// RebalanceShardAssignment plans the target shard assignment which spreads replicas and leaders(first replica)
+// evenly among storage nodes, returns the target assignment and the migrations for moving current assignment to it.
+//
+// The plan tries to move as few replicas as possible, and keeps replicas of shard in different zones/racks like shard assignment:
+// 1. Move the replicas on nodes which not in storage node list(offline/draining node) to the least loaded node,
+//    prefer the node which failure domain is not used by other replicas of shard.
+// 2. Move the replica which failure domain is used by other replicas of shard to the least loaded node with unused failure domain.
+// 3. Move replica from the most loaded node to the least loaded node with unused failure domain until the gap of them <= 1.
+// 4. Transfer leader from the node with most leaders to the replica with least leaders until the gap of them <= 1.
+// The replica is replaced in place, so the leader is switched to new node if moving the leader replica.
+func RebalanceShardAssignment(
+	storageNodeIDs []models.NodeID,
+	nodes map[models.NodeID]*models.StatefulNode,
+	shardAssignment *models.ShardAssignment,
+) (*models.ShardAssignment, []*models.ShardMigration) {
+	target := models.NewShardAssignment(shardAssignment.Name)
+	var shardIDs []models.ShardID
+	for shardID, replica := range shardAssignment.Shards {
+		shardIDs = append(shardIDs, shardID)
+		target.Shards[shardID] = &models.Replica{Replicas: append([]models.NodeID{}, replica.Replicas...)}
+	}
+	sort.Slice(shardIDs, func(i, j int) bool { return shardIDs[i] < shardIDs[j] })
+	nodeIDs := append([]models.NodeID{}, storageNodeIDs...)
+	sort.Slice(nodeIDs, func(i, j int) bool { return nodeIDs[i] < nodeIDs[j] })
+	if len(nodeIDs) == 0 {
+		return target, nil
+	}
+
+	domains := newFailureDomains(nodeIDs, nodes)
+	replicas := newNodeCounter(nodeIDs)
+	for _, shardID := range shardIDs {
+		for _, nodeID := range target.Shards[shardID].Replicas {
+			replicas.inc(nodeID)
+		}
+	}
+	// 1. move replicas out of the nodes not in storage node list
+	for _, shardID := range shardIDs {
+		replica := target.Shards[shardID]
+		for idx, nodeID := range replica.Replicas {
+			if replicas.contains(nodeID) {
+				continue
+			}
+			used := domains.replicasUsage(replica.Replicas, idx)
+			to, ok := replicas.least(func(id models.NodeID) bool { return !replica.Contain(id) && !used.isUsed(id) })
+			if !ok {
+				to, ok = replicas.least(func(id models.NodeID) bool { return !replica.Contain(id) })
+			}
+			if !ok {
+				continue
+			}
+			replica.Replicas[idx] = to
+			replicas.inc(to)
+		}
+	}
+	// 2. move replicas out of the failure domains used by other replicas of shard
+	for _, shardID := range shardIDs {
+		replica := target.Shards[shardID]
+		for idx, nodeID := range replica.Replicas {
+			used := domains.replicasUsage(replica.Replicas, idx)
+			if !replicas.contains(nodeID) || !used.isUsed(nodeID) {
+				continue
+			}
+			to, ok := replicas.least(func(id models.NodeID) bool { return !replica.Contain(id) && !used.isUsed(id) })
+			if !ok {
+				continue
+			}
+			replica.Replicas[idx] = to
+			replicas.dec(nodeID)
+			replicas.inc(to)
+		}
+	}
+	// 3. move replicas from the most loaded node to the least loaded node
+	for {
+		from := replicas.most()
+		moved := false
+		for _, shardID := range shardIDs {
+			replica := target.Shards[shardID]
+			idx := indexOf(replica.Replicas, from)
+			if idx < 0 {
+				continue
+			}
+			used := domains.replicasUsage(replica.Replicas, idx)
+			to, ok := replicas.least(func(id models.NodeID) bool { return !replica.Contain(id) && !used.isUsed(id) })
+			if !ok || replicas.count[from]-replicas.count[to] <= 1 {
+				continue
+			}
+			replica.Replicas[idx] = to
+			replicas.dec(from)
+			replicas.inc(to)
+			moved = true
+			break
+		}
+		if !moved {
+			break
+		}
+	}
+	var migrations []*models.ShardMigration
+	leaders := make(map[models.ShardID]models.NodeID)
+	for _, shardID := range shardIDs {
+		from := shardAssignment.Shards[shardID].Replicas
+		to := target.Shards[shardID].Replicas
+		for idx := range from {
+			if from[idx] != to[idx] {
+				migrations = append(migrations, &models.ShardMigration{
+					Kind:    models.MoveReplica,
+					ShardID: shardID,
+					From:    from[idx],
+					To:      to[idx],
+					State:   models.MigrationPending,
+				})
+			}
+		}
+		if len(to) > 0 {
+			leaders[shardID] = to[0]
+		}
+	}
+	// 4. transfer leaders from the node with most leaders to the node with least leaders
+	leaderCounter := newNodeCounter(nodeIDs)
+	for _, shardID := range shardIDs {
+		if leader, ok := leaders[shardID]; ok {
+			leaderCounter.inc(leader)
+		}
+	}
+	for {
+		from := leaderCounter.most()
+		transferred := false
+		for _, shardID := range shardIDs {
+			replica := target.Shards[shardID]
+			if len(replica.Replicas) == 0 || replica.Replicas[0] != from {
+				continue
+			}
+			to, ok := leaderCounter.least(func(id models.NodeID) bool { return replica.Contain(id) })
+			if !ok || leaderCounter.count[from]-leaderCounter.count[to] <= 1 {
+				continue
+			}
+			idx := indexOf(replica.Replicas, to)
+			replica.Replicas[0], replica.Replicas[idx] = replica.Replicas[idx], replica.Replicas[0]
+			leaderCounter.dec(from)
+			leaderCounter.inc(to)
+			transferred = true
+			break
+		}
+		if !transferred {
+			break
+		}
+	}
+	for _, shardID := range shardIDs {
+		replica := target.Shards[shardID]
+		if leader, ok := leaders[shardID]; ok && replica.Replicas[0] != leader {
+			migrations = append(migrations, &models.ShardMigration{
+				Kind:    models.TransferLeader,
+				ShardID: shardID,
+				From:    leader,
+				To:      replica.Replicas[0],
+				State:   models.MigrationPending,
+			})
+		}
+	}
+	return target, migrations
+}
+
+// nodeCounter counts replicas/leaders for each storage node.
+type nodeCounter struct {
+	nodeIDs []models.NodeID
+	count   map[models.NodeID]int
+}
+
+// newNodeCounter creates the counter for storage node list(sorted).
+func newNodeCounter(nodeIDs []models.NodeID) *nodeCounter {
+	c := &nodeCounter{
+		nodeIDs: nodeIDs,
+		count:   make(map[models.NodeID]int),
+	}
+	for _, nodeID := range nodeIDs {
+		c.count[nodeID] = 0
+	}
+	return c
+}
+
+func (c *nodeCounter) contains(nodeID models.NodeID) bool {
+	_, ok := c.count[nodeID]
+	return ok
+}
+
+func (c *nodeCounter) inc(nodeID models.NodeID) {
+	if c.contains(nodeID) {
+		c.count[nodeID]++
+	}
+}
+
+func (c *nodeCounter) dec(nodeID models.NodeID) {
+	if c.contains(nodeID) {
+		c.count[nodeID]--
+	}
+}
+
+// most returns the node which has the most count.
+func (c *nodeCounter) most() models.NodeID {
+	most := c.nodeIDs[0]
+	for _, nodeID := range c.nodeIDs {
+		if c.count[nodeID] > c.count[most] {
+			most = nodeID
+		}
+	}
+	return most
+}
+
+// least returns the node which has the least count and matches the filter.
+func (c *nodeCounter) least(filter func(nodeID models.NodeID) bool) (least models.NodeID, ok bool) {
+	for _, nodeID := range c.nodeIDs {
+		if !filter(nodeID) {
+			continue
+		}
+		if !ok || c.count[nodeID] < c.count[least] {
+			least = nodeID
+			ok = true
+		}
+	}
+	return
+}
+
+// indexOf returns the index of node in replica list, returns -1 if not exist.
+func indexOf(replicas []models.NodeID, nodeID models.NodeID) int {
+	for idx, id := range replicas {
+		if id == nodeID {
+			return idx
+		}
+	}
+	return -1
+}
@@ -0,0 +1,132 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package master
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/models"
+)
+
+func TestRebalanceShardAssignment(t *testing.T) {
+	shardAssign, err := ShardAssignment([]models.NodeID{1, 2, 3}, nil, &models.Database{
+		Name:          "test",
+		NumOfShard:    6,
+		ReplicaFactor: 2,
+	}, 0, 0)
+	assert.NoError(t, err)
+
+	// case 1: no storage node
+	_, migrations := RebalanceShardAssignment(nil, nil, shardAssign)
+	assert.Empty(t, migrations)
+	// case 2: already balanced
+	target, migrations := RebalanceShardAssignment([]models.NodeID{3, 2, 1}, nil, shardAssign)
+	assert.Empty(t, migrations)
+	assert.Equal(t, shardAssign, target)
+	// case 3: add new storage node
+	target, migrations = RebalanceShardAssignment([]models.NodeID{1, 2, 3, 4}, nil, shardAssign)
+	assert.NotEmpty(t, migrations)
+	checkRebalanceResult(t, target, []models.NodeID{1, 2, 3, 4}, 3)
+	for _, migration := range migrations {
+		assert.Equal(t, models.MigrationPending, migration.State)
+		if migration.Kind == models.MoveReplica {
+			assert.True(t, shardAssign.Shards[migration.ShardID].Contain(migration.From))
+			assert.True(t, target.Shards[migration.ShardID].Contain(migration.To))
+		}
+	}
+	// current assignment not changed
+	checkRebalanceResult(t, shardAssign, []models.NodeID{1, 2, 3}, 4)
+	// case 4: move replicas out of node 3
+	target, migrations = RebalanceShardAssignment([]models.NodeID{1, 2, 4}, nil, shardAssign)
+	assert.NotEmpty(t, migrations)
+	checkRebalanceResult(t, target, []models.NodeID{1, 2, 4}, 4)
+}
+
+func TestRebalanceShardAssignment_Leader(t *testing.T) {
+	shardAssign := models.NewShardAssignment("test")
+	for shardID := models.ShardID(0); shardID < 4; shardID++ {
+		shardAssign.AddReplica(shardID, 1)
+		shardAssign.AddReplica(shardID, 2)
+	}
+	target, migrations := RebalanceShardAssignment([]models.NodeID{1, 2}, nil, shardAssign)
+	assert.Len(t, migrations, 2)
+	for _, migration := range migrations {
+		assert.Equal(t, models.TransferLeader, migration.Kind)
+		assert.Equal(t, models.NodeID(1), migration.From)
+		assert.Equal(t, models.NodeID(2), migration.To)
+	}
+	checkRebalanceResult(t, target, []models.NodeID{1, 2}, 4)
+}
+
+func TestRebalanceShardAssignment_FailureDomain(t *testing.T) {
+	nodes := map[models.NodeID]*models.StatefulNode{
+		1: {ID: 1, Zone: "a"},
+		2: {ID: 2, Zone: "a"},
+		3: {ID: 3, Zone: "b"},
+		4: {ID: 4, Zone: "b"},
+		5: {ID: 5, Zone: "b"},
+	}
+	shardAssign := models.NewShardAssignment("test")
+	for shardID, replicas := range [][]models.NodeID{{1, 2}, {3, 4}, {2, 1}, {4, 3}} {
+		for _, nodeID := range replicas {
+			shardAssign.AddReplica(models.ShardID(shardID), nodeID)
+		}
+	}
+	checkZones := func(target *models.ShardAssignment) {
+		for _, replica := range target.Shards {
+			assert.NotEqual(t, nodes[replica.Replicas[0]].Zone, nodes[replica.Replicas[1]].Zone)
+		}
+	}
+	// case 1: move replicas out of the same zone
+	target, migrations := RebalanceShardAssignment([]models.NodeID{1, 2, 3, 4}, nodes, shardAssign)
+	assert.NotEmpty(t, migrations)
+	checkZones(target)
+	checkRebalanceResult(t, target, []models.NodeID{1, 2, 3, 4}, 2)
+	// case 2: move replicas out of node 4, prefer node in unused zone
+	target, _ = RebalanceShardAssignment([]models.NodeID{1, 2, 3, 5}, nodes, target)
+	checkZones(target)
+	checkRebalanceResult(t, target, []models.NodeID{1, 2, 3, 5}, 2)
+	// case 3: nodes without labels, same as no failure domain
+	target, migrations = RebalanceShardAssignment([]models.NodeID{1, 2, 3, 4}, nil, shardAssign)
+	assert.Empty(t, migrations)
+	assert.Equal(t, shardAssign, target)
+}
+
+// checkRebalanceResult checks replicas and leaders spread evenly among storage nodes.
+func checkRebalanceResult(t *testing.T, shardAssign *models.ShardAssignment, nodeIDs []models.NodeID, replicas int) {
+	replicaCount := make(map[models.NodeID]int)
+	leaderCount := make(map[models.NodeID]int)
+	for _, replica := range shardAssign.Shards {
+		nodes := make(map[models.NodeID]struct{})
+		for _, nodeID := range replica.Replicas {
+			nodes[nodeID] = struct{}{}
+			replicaCount[nodeID]++
+		}
+		assert.Len(t, nodes, len(replica.Replicas))
+		leaderCount[replica.Replicas[0]]++
+	}
+	assert.Len(t, replicaCount, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		assert.Equal(t, replicas, replicaCount[nodeID])
+	}
+	numOfLeaders := len(shardAssign.Shards) / len(nodeIDs)
+	for _, nodeID := range nodeIDs {
+		assert.True(t, leaderCount[nodeID] >= numOfLeaders && leaderCount[nodeID] <= numOfLeaders+1)
+	}
+}
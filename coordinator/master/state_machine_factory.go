@@ -183,3 +183,31 @@ func (f *StateMachineFactory) createStorageNodeStateMachine(storageName string,
 		},
 	)
 }
+
+// createReplicaStateStateMachine creates replication state machine of storage nodes.
+func (f *StateMachineFactory) createReplicaStateStateMachine(storageName string,
+	discoveryFactory discovery.Factory,
+) (discovery.StateMachine, error) {
+	return discovery.NewStateMachine(
+		f.ctx,
+		discovery.ReplicaStateStateMachine,
+		discoveryFactory,
+		constants.ReplicaStatePath,
+		true,
+		func(key string, data []byte) {
+			f.stateMgr.EmitEvent(&discovery.Event{
+				Type:       discovery.ReplicaStateChanged,
+				Key:        key,
+				Value:      data,
+				Attributes: map[string]string{storageNameKey: storageName},
+			})
+		},
+		func(key string) {
+			f.stateMgr.EmitEvent(&discovery.Event{
+				Type:       discovery.ReplicaStateDeletion,
+				Key:        key,
+				Attributes: map[string]string{storageNameKey: storageName},
+			})
+		},
+	)
+}
@@ -192,3 +192,34 @@ func TestStateMachineFactory_StorageNode(t *testing.T) {
 	})
 	sm.OnDelete("/test")
 }
+
+func TestStateMachineFactory_ReplicaState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stateMgr := NewMockStateManager(ctrl)
+	discoveryFct := discovery.NewMockFactory(ctrl)
+	discovery1 := discovery.NewMockDiscovery(ctrl)
+	discoveryFct.EXPECT().CreateDiscovery(gomock.Any(), gomock.Any()).Return(discovery1)
+	discovery1.EXPECT().Discovery(gomock.Any()).Return(nil)
+	fct := NewStateMachineFactory(context.TODO(), discoveryFct, stateMgr)
+
+	sm, err := fct.createReplicaStateStateMachine("test", discoveryFct)
+	assert.NoError(t, err)
+	assert.NotNil(t, sm)
+
+	stateMgr.EXPECT().EmitEvent(&discovery.Event{
+		Type:       discovery.ReplicaStateChanged,
+		Key:        "/test",
+		Value:      []byte("value"),
+		Attributes: map[string]string{storageNameKey: "test"},
+	})
+	sm.OnCreate("/test", []byte("value"))
+
+	stateMgr.EXPECT().EmitEvent(&discovery.Event{
+		Type:       discovery.ReplicaStateDeletion,
+		Key:        "/test",
+		Attributes: map[string]string{storageNameKey: "test"},
+	})
+	sm.OnDelete("/test")
+}
@@ -20,7 +20,9 @@ package master
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"sync"
 	"time"
@@ -36,6 +38,7 @@ import (
 	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/pkg/ltoml"
 	"github.com/lindb/lindb/pkg/state"
+	"github.com/lindb/lindb/pkg/timeutil"
 )
 
 //go:generate mockgen -source=./state_manager.go -destination=./state_manager_mock.go -package=master
@@ -51,6 +54,19 @@ type StateManager interface {
 
 	// GetStorageCluster returns cluster controller for maintain the metadata of storage cluster.
 	GetStorageCluster(name string) StorageCluster
+	// RebalanceDatabase plans shard migrations which spread replicas/leaders evenly among live storage nodes,
+	// then runs the migrations in background, the progress is saved in state repo.
+	RebalanceDatabase(databaseName string, opt RebalanceOption) error
+	// DrainNode moves leaders off the storage node, waits its write ahead log acked by replicas,
+	// migrates its replicas if need, then marks it decommissioned, runs in background.
+	DrainNode(storageName string, nodeID models.NodeID, opt DrainOption) error
+	// RecommissionNode cancels the running drain of storage node, removes its draining/decommissioned mark,
+	// then the node can hold new leaders/replicas again.
+	RecommissionNode(storageName string, nodeID models.NodeID) error
+	// RestoreDatabase submits the coordinator task for restoring database from backup path,
+	// the shards of live database are restored in the nodes which hold their replicas,
+	// the restored shards which are not assigned are registered in shard assignment of database.
+	RestoreDatabase(storageName, databaseName, path string, shardIDs []models.ShardID) error
 }
 
 // stateManager implements StateManager.
@@ -70,8 +86,10 @@ type stateManager struct {
 		repoFactory state.RepositoryFactory,
 		controllerFactory task.ControllerFactory) (cluster StorageCluster, err error)
 
-	storages  map[string]StorageCluster
-	databases map[string]models.Database
+	storages   map[string]StorageCluster
+	databases  map[string]models.Database
+	rebalances map[string]*rebalanceJob
+	drains     map[string]context.CancelFunc // storage/node => cancel of running drain
 
 	events chan *discovery.Event
 
@@ -97,6 +115,8 @@ func NewStateManager(
 		controllerFactory:   controllerFactory,
 		storages:            make(map[string]StorageCluster),
 		databases:           make(map[string]models.Database),
+		rebalances:          make(map[string]*rebalanceJob),
+		drains:              make(map[string]context.CancelFunc),
 		elector:             newReplicaLeaderElector(),
 		events:              make(chan *discovery.Event, 10),
 		running:             atomic.NewBool(true),
@@ -160,6 +180,10 @@ func (m *stateManager) processEvent(event *discovery.Event) {
 		m.onStorageNodeStartup(event.Attributes[storageNameKey], event.Key, event.Value)
 	case discovery.NodeFailure:
 		m.onStorageNodeFailure(event.Attributes[storageNameKey], event.Key)
+	case discovery.ReplicaStateChanged:
+		m.onReplicaStateChange(event.Attributes[storageNameKey], event.Key, event.Value)
+	case discovery.ReplicaStateDeletion:
+		m.onReplicaStateDelete(event.Attributes[storageNameKey], event.Key)
 	}
 }
 
@@ -186,14 +210,48 @@ func (m *stateManager) onDatabaseCfgChange(key string, data []byte) {
 		return
 	}
 
+	if old, ok := m.databases[cfg.Name]; ok {
+		// alter database, reject option which is incompatible with running shards
+		if err := cfg.Option.ValidateAlter(old.Option); err != nil {
+			m.logger.Error("database option is changed, but incompatible with old option",
+				logger.String("database", cfg.Name),
+				logger.Error(err))
+			return
+		}
+	}
+
 	m.databases[cfg.Name] = cfg
 
 	m.shardAssignment(cfg)
 }
 
-// onDatabaseCfgDelete triggers when database config is deletion.
-func (m *stateManager) onDatabaseCfgDelete(_ string) {
-	panic("need impl")
+// onDatabaseCfgDelete triggers when database config is deletion,
+// removes shard assignment and shard states of the database.
+func (m *stateManager) onDatabaseCfgDelete(key string) {
+	m.logger.Info("database config is deleted, remove shard assignment",
+		logger.String("key", key))
+
+	_, databaseName := filepath.Split(key)
+	databaseCfg, ok := m.databases[databaseName]
+	delete(m.databases, databaseName)
+
+	if err := m.masterRepo.Delete(m.ctx, constants.GetDatabaseAssignPath(databaseName)); err != nil {
+		m.logger.Error("remove shard assignment of database error",
+			logger.String("database", databaseName),
+			logger.Error(err))
+	}
+	if !ok {
+		return
+	}
+	storage, ok := m.storages[databaseCfg.Storage]
+	if !ok {
+		return
+	}
+	s := storage.GetState()
+	delete(s.ShardAssignments, databaseName)
+	delete(s.ShardStates, databaseName)
+
+	m.syncState(s)
 }
 
 // onShardAssignmentChange triggers when shard assignment modify.
@@ -216,7 +274,7 @@ func (m *stateManager) onShardAssignmentChange(key string, data []byte) {
 	liveNodes := s.LiveNodes
 	shardStates := make(map[models.ShardID]models.ShardState)
 	for shardID, replicas := range shardAssignment.Shards {
-		leader, err := m.elector.ElectLeader(shardAssignment, liveNodes, shardID)
+		leader, err := m.elector.ElectLeader(shardAssignment, liveNodes, shardID, s.UnavailableNodes(), s.PrimaryZone)
 		shardState := models.ShardState{ID: shardID, Replica: *replicas}
 		if err != nil {
 			shardState.State = models.OfflineShard
@@ -283,6 +341,10 @@ func (m *stateManager) onStorageNodeStartup(storageName string, key string, data
 	s := cluster.GetState()
 
 	s.NodeOnline(node)
+	if s.IsDecommissioned(node.ID) {
+		// decommissioned node restarts after maintenance, can hold shards again
+		s.NodeRecommissioned(node.ID)
+	}
 
 	m.onNodeStartup(s, node)
 
@@ -306,6 +368,52 @@ func (m *stateManager) onStorageNodeFailure(storageName string, key string) {
 	s := cluster.GetState()
 
 	m.onNodeFailure(s, models.NodeID(id))
+	s.RemoveReplicaState(models.NodeID(id))
+
+	m.syncState(s)
+}
+
+// onReplicaStateChange triggers when storage node reports the replication state of write ahead log.
+func (m *stateManager) onReplicaStateChange(storageName string, key string, data []byte) {
+	nodeState := models.NodeReplicaState{}
+	if err := encoding.JSONUnmarshal(data, &nodeState); err != nil {
+		m.logger.Error("replica state of storage node is changed but unmarshal error",
+			logger.String("storage", storageName),
+			logger.String("key", key),
+			logger.Error(err))
+		return
+	}
+	cluster, ok := m.storages[storageName]
+	if !ok {
+		m.logger.Warn("replica state of storage node is changed but storage cluster not found",
+			logger.String("storage", storageName),
+			logger.String("key", key))
+		return
+	}
+	s := cluster.GetState()
+	if _, ok := s.LiveNodes[nodeState.NodeID]; !ok {
+		// ignore the stale state reported by offline node
+		return
+	}
+	s.UpdateReplicaState(nodeState)
+
+	m.syncState(s)
+}
+
+// onReplicaStateDelete triggers when the replication state of storage node is deletion.
+func (m *stateManager) onReplicaStateDelete(storageName string, key string) {
+	_, nodeIDStr := filepath.Split(key)
+	id, err := strconv.ParseInt(nodeIDStr, 10, 64)
+	if err != nil {
+		m.logger.Error("parse node id of replica state err", logger.String("key", key), logger.Error(err))
+		return
+	}
+	cluster, ok := m.storages[storageName]
+	if !ok {
+		return
+	}
+	s := cluster.GetState()
+	s.RemoveReplicaState(models.NodeID(id))
 
 	m.syncState(s)
 }
@@ -327,6 +435,8 @@ func (m *stateManager) register(cfg config.StorageCluster) error {
 		return err
 	}
 	m.storages[cfg.Name] = cluster
+	// resume the drains of storage nodes which are running when master changed
+	m.resumeDrains(cluster)
 	// start storage cluster state machine.
 	go func() {
 		// need start storage cluster state machine in background,
@@ -350,6 +460,7 @@ func (m *stateManager) unRegister(name string) {
 	if ok {
 		// need cleanup storage cluster resource
 		cluster.Close()
+		m.cancelDrains(name)
 
 		delete(m.storages, name)
 
@@ -424,6 +535,14 @@ func (m *stateManager) shardAssignment(databaseCfg models.Database) {
 				logger.Error(err))
 			return
 		}
+		// push database option to storage nodes, let existing shards apply option changes
+		if err := cluster.SaveDatabaseAssignment(shardAssign, databaseCfg.Option); err != nil {
+			m.logger.Error("push database option to storage cluster error",
+				logger.String("storage", databaseCfg.Storage),
+				logger.Any("database", databaseCfg.Name),
+				logger.Error(err))
+			return
+		}
 	}
 }
 
@@ -448,6 +567,11 @@ func (m *stateManager) onNodeStartup(state *models.StorageState, node models.Sta
 
 func (m *stateManager) onNodeFailure(state *models.StorageState, nodeID models.NodeID) {
 	// 1. find all leaders on failure node, need do leader elect
+	m.electLeadersOnNode(state, nodeID)
+}
+
+// electLeadersOnNode elects new leaders for the shards which leader is the node(offline/draining).
+func (m *stateManager) electLeadersOnNode(state *models.StorageState, nodeID models.NodeID) {
 	leadersOnOfflineNode := state.LeadersOnNode(nodeID)
 
 	liveNodes := state.LiveNodes
@@ -455,7 +579,7 @@ func (m *stateManager) onNodeFailure(state *models.StorageState, nodeID models.N
 		shardAssignment := state.ShardAssignments[db]
 		shardStates := state.ShardStates[db]
 		for _, shardID := range shards {
-			leader, err := m.elector.ElectLeader(shardAssignment, liveNodes, shardID)
+			leader, err := m.elector.ElectLeader(shardAssignment, liveNodes, shardID, state.UnavailableNodes(), state.PrimaryZone)
 			shardState := shardStates[shardID]
 			if err != nil {
 				shardState.State = models.OfflineShard
@@ -517,7 +641,7 @@ func (m *stateManager) createShardAssignment(
 	}
 
 	// generate shard assignment based on node ids and config
-	shardAssign, err := ShardAssignment(nodeIDs, cfg, fixedStartIndex, startShardID)
+	shardAssign, err := ShardAssignment(nodeIDs, nodes, cfg, fixedStartIndex, startShardID)
 	if err != nil {
 		return nil, err
 	}
@@ -565,7 +689,7 @@ func (m *stateManager) modifyShardAssignment(
 
 		// generate shard assignment based on node ids and config
 		//TODO check start shard id
-		err = ModifyShardAssignment(nodeIDs, cfg, shardAssign, -1, models.ShardID(len(shardAssign.Shards)))
+		err = ModifyShardAssignment(nodeIDs, nodes, cfg, shardAssign, -1, models.ShardID(len(shardAssign.Shards)))
 		if err != nil {
 			return err
 		}
@@ -600,6 +724,194 @@ func (m *stateManager) GetShardAssign(databaseName string) (*models.ShardAssignm
 	return shardAssign, nil
 }
 
+// RebalanceDatabase plans shard migrations which spread replicas/leaders evenly among live storage nodes,
+// then runs the migrations in background, the progress is saved in state repo.
+func (m *stateManager) RebalanceDatabase(databaseName string, opt RebalanceOption) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, ok := m.rebalances[databaseName]; ok {
+		return constants.ErrRebalanceRunning
+	}
+	databaseCfg, ok := m.databases[databaseName]
+	if !ok {
+		return constants.ErrDatabaseNotFound
+	}
+	cluster, ok := m.storages[databaseCfg.Storage]
+	if !ok {
+		return constants.ErrNoStorageCluster
+	}
+	shardAssign, err := m.GetShardAssign(databaseName)
+	if err != nil {
+		return err
+	}
+	liveNodes, err := cluster.GetLiveNodes()
+	if err != nil {
+		return err
+	}
+	var nodeIDs []models.NodeID
+	nodes := make(map[models.NodeID]*models.StatefulNode)
+	for idx := range liveNodes {
+		node := liveNodes[idx]
+		nodeIDs = append(nodeIDs, node.ID)
+		nodes[node.ID] = &node
+	}
+	_, migrations := RebalanceShardAssignment(nodeIDs, nodes, shardAssign)
+	job := newRebalanceJob(m.ctx, m.masterRepo, m.updateShardReplica, m.getReplicaPeerState, m.dropShardReplica, &models.RebalanceProgress{
+		Database:   databaseName,
+		Storage:    databaseCfg.Storage,
+		StartTime:  timeutil.Now(),
+		Migrations: migrations,
+	}, opt)
+	if len(migrations) == 0 {
+		job.progress.EndTime = job.progress.StartTime
+		job.saveProgress()
+		m.logger.Info("shard assignment is balanced, no migration",
+			logger.String("database", databaseName))
+		return nil
+	}
+	job.saveProgress()
+	m.rebalances[databaseName] = job
+	m.logger.Info("start shard rebalance",
+		logger.String("database", databaseName),
+		logger.Int("migrations", len(migrations)))
+	go func() {
+		job.run()
+
+		m.mutex.Lock()
+		delete(m.rebalances, databaseName)
+		m.mutex.Unlock()
+	}()
+	return nil
+}
+
+// RestoreDatabase submits the coordinator task for restoring database from backup path,
+// the shards of live database are restored in the nodes which hold their replicas(all assigned shards if not given),
+// the restored shards which are not assigned are registered in shard assignment of database.
+func (m *stateManager) RestoreDatabase(storageName, databaseName, path string, shardIDs []models.ShardID) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	cluster, ok := m.storages[storageName]
+	if !ok {
+		return constants.ErrNoStorageCluster
+	}
+	databaseCfg, ok := m.databases[databaseName]
+	if !ok || databaseCfg.Storage != storageName {
+		// database not created, restores it from backup in all live nodes
+		return cluster.RestoreDatabase(databaseName, path, shardIDs)
+	}
+	shardAssign, err := m.GetShardAssign(databaseName)
+	if err != nil {
+		return err
+	}
+	if len(shardIDs) == 0 {
+		for shardID := range shardAssign.Shards {
+			shardIDs = append(shardIDs, shardID)
+		}
+		sort.Slice(shardIDs, func(i, j int) bool { return shardIDs[i] < shardIDs[j] })
+	}
+	liveNodes, err := cluster.GetLiveNodes()
+	if err != nil {
+		return err
+	}
+	var nodeIDs []models.NodeID
+	nodes := make(map[models.NodeID]*models.StatefulNode)
+	for idx := range liveNodes {
+		node := liveNodes[idx]
+		nodeIDs = append(nodeIDs, node.ID)
+		nodes[node.ID] = &node
+	}
+	assigned := false
+	nodeShards := make(map[models.NodeID][]models.ShardID)
+	for _, shardID := range shardIDs {
+		if _, ok := shardAssign.Shards[shardID]; !ok {
+			if databaseCfg.ReplicaFactor <= 0 || databaseCfg.ReplicaFactor > len(nodeIDs) {
+				return fmt.Errorf("assign restored shard[%d] of database[%s] error, because replica factor > num. of storage nodes",
+					shardID, databaseName)
+			}
+			assignReplicasToStorageNodes(nodeIDs, nodes, 1, databaseCfg.ReplicaFactor, -1, shardID, shardAssign)
+			assigned = true
+		}
+		for _, nodeID := range shardAssign.Shards[shardID].Replicas {
+			nodeShards[nodeID] = append(nodeShards[nodeID], shardID)
+		}
+	}
+	if err := cluster.RestoreShards(databaseName, path, nodeShards); err != nil {
+		return err
+	}
+	if !assigned {
+		return nil
+	}
+	m.logger.Info("register restored shards in shard assignment",
+		logger.String("database", databaseName),
+		logger.Any("shardAssign", shardAssign))
+	if err := m.masterRepo.Put(m.ctx, constants.GetDatabaseAssignPath(databaseName), encoding.JSONMarshal(shardAssign)); err != nil {
+		return err
+	}
+	return cluster.SaveDatabaseAssignment(shardAssign, databaseCfg.Option)
+}
+
+// updateShardReplica updates replica list of shard, then saves shard assignment into master/storage repo.
+func (m *stateManager) updateShardReplica(databaseName string, shardID models.ShardID,
+	update func(replica *models.Replica) error,
+) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	databaseCfg, ok := m.databases[databaseName]
+	if !ok {
+		return constants.ErrDatabaseNotFound
+	}
+	cluster, ok := m.storages[databaseCfg.Storage]
+	if !ok {
+		return constants.ErrNoStorageCluster
+	}
+	shardAssign, err := m.GetShardAssign(databaseName)
+	if err != nil {
+		return err
+	}
+	replica, ok := shardAssign.Shards[shardID]
+	if !ok {
+		return constants.ErrShardNotFound
+	}
+	if err := update(replica); err != nil {
+		return err
+	}
+	if err := m.masterRepo.Put(m.ctx, constants.GetDatabaseAssignPath(databaseName), encoding.JSONMarshal(shardAssign)); err != nil {
+		return err
+	}
+	return cluster.SaveDatabaseAssignment(shardAssign, databaseCfg.Option)
+}
+
+// getReplicaPeerState returns the replication state of shard from leader to follower reported by leader.
+func (m *stateManager) getReplicaPeerState(storageName, databaseName string,
+	shardID models.ShardID, follower models.NodeID,
+) (peer models.ReplicaPeerState, reportTime int64, ok bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	cluster, ok := m.storages[storageName]
+	if !ok {
+		return models.ReplicaPeerState{}, 0, false
+	}
+	return cluster.GetState().GetReplicaPeerState(databaseName, shardID, follower)
+}
+
+// dropShardReplica submits the task dropping data/write ahead log of shard's replica in storage node.
+func (m *stateManager) dropShardReplica(storageName, databaseName string,
+	shardID models.ShardID, nodeID models.NodeID,
+) error {
+	m.mutex.Lock()
+	cluster, ok := m.storages[storageName]
+	m.mutex.Unlock()
+
+	if !ok {
+		return constants.ErrNoStorageCluster
+	}
+	return cluster.DropShards(databaseName, nodeID, []models.ShardID{shardID})
+}
+
 // GetStorageCluster returns cluster controller for maintain the metadata of storage cluster.
 func (m *stateManager) GetStorageCluster(name string) (cluster StorageCluster) {
 	m.mutex.Lock()
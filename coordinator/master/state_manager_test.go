@@ -27,6 +27,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/coordinator/discovery"
 	"github.com/lindb/lindb/coordinator/task"
 	"github.com/lindb/lindb/models"
@@ -68,12 +69,268 @@ func TestStateManager_NotRunning(t *testing.T) {
 	mgr.Close()
 }
 
+func TestStateManager_AlterDatabaseOption(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := state.NewMockRepository(ctrl)
+	storage := NewMockStorageCluster(ctrl)
+	mgr := NewStateManager(context.TODO(), repo, nil, nil)
+	mgr1 := mgr.(*stateManager)
+	mgr1.storages["test"] = storage
+	cfg := models.Database{
+		Name:    "test",
+		Storage: "test",
+		Option:  option.DatabaseOption{Interval: "10s", Rollup: []string{"5m"}},
+	}
+	mgr1.databases["test"] = cfg
+	shardAssign := []byte(`{"name":"test"}`)
+
+	// case 1: incompatible option, keep old option
+	newCfg := cfg
+	newCfg.Option = option.DatabaseOption{Interval: "20s"}
+	mgr1.onDatabaseCfgChange("/database/test", encoding.JSONMarshal(&newCfg))
+	assert.Equal(t, cfg, mgr1.databases["test"])
+	// case 2: push option err
+	newCfg.Option = option.DatabaseOption{Interval: "10s", Rollup: []string{"5m", "1h"}, Behind: "2h"}
+	repo.EXPECT().Get(gomock.Any(), gomock.Any()).Return(shardAssign, nil)
+	repo.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	storage.EXPECT().SaveDatabaseAssignment(gomock.Any(), newCfg.Option).Return(fmt.Errorf("err"))
+	mgr1.onDatabaseCfgChange("/database/test", encoding.JSONMarshal(&newCfg))
+	// case 3: push option successfully
+	repo.EXPECT().Get(gomock.Any(), gomock.Any()).Return(shardAssign, nil)
+	repo.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	storage.EXPECT().SaveDatabaseAssignment(gomock.Any(), newCfg.Option).Return(nil)
+	mgr1.onDatabaseCfgChange("/database/test", encoding.JSONMarshal(&newCfg))
+	assert.Equal(t, newCfg.Option, mgr1.databases["test"].Option)
+}
+
+func TestStateManager_DropDatabase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := state.NewMockRepository(ctrl)
+	storage := NewMockStorageCluster(ctrl)
+	mgr := NewStateManager(context.TODO(), repo, nil, nil)
+	mgr1 := mgr.(*stateManager)
+	mgr1.storages["test"] = storage
+	// case 1: database not exist, remove shard assignment err
+	repo.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(fmt.Errorf("err"))
+	mgr1.onDatabaseCfgDelete("/database/config/test")
+	// case 2: storage not exist
+	mgr1.databases["test"] = models.Database{Name: "test", Storage: "not-exist"}
+	repo.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+	mgr1.onDatabaseCfgDelete("/database/config/test")
+	_, ok := mgr1.databases["test"]
+	assert.False(t, ok)
+	// case 3: remove shard states of database
+	mgr1.databases["test"] = models.Database{Name: "test", Storage: "test"}
+	storage.EXPECT().GetState().Return(&models.StorageState{Name: "test"})
+	repo.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+	repo.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	mgr1.onDatabaseCfgDelete("/database/config/test")
+}
+
+func TestStateManager_RebalanceDatabase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := state.NewMockRepository(ctrl)
+	storage := NewMockStorageCluster(ctrl)
+	mgr := NewStateManager(context.TODO(), repo, nil, nil)
+	mgr1 := mgr.(*stateManager)
+	opt := RebalanceOption{CatchUpWait: time.Millisecond}
+	// case 1: database not exist
+	err := mgr.RebalanceDatabase("test", opt)
+	assert.Equal(t, constants.ErrDatabaseNotFound, err)
+	// case 2: storage not exist
+	mgr1.databases["test"] = models.Database{Name: "test", Storage: "test"}
+	err = mgr.RebalanceDatabase("test", opt)
+	assert.Equal(t, constants.ErrNoStorageCluster, err)
+	// case 3: get shard assignment err
+	mgr1.storages["test"] = storage
+	repo.EXPECT().Get(gomock.Any(), constants.GetDatabaseAssignPath("test")).Return(nil, fmt.Errorf("err"))
+	err = mgr.RebalanceDatabase("test", opt)
+	assert.Error(t, err)
+	// case 4: get live nodes err
+	shardAssign := []byte(`{"name":"test","shards":{"0":{"replicas":[1]},"1":{"replicas":[1]}}}`)
+	repo.EXPECT().Get(gomock.Any(), constants.GetDatabaseAssignPath("test")).Return(shardAssign, nil)
+	storage.EXPECT().GetLiveNodes().Return(nil, fmt.Errorf("err"))
+	err = mgr.RebalanceDatabase("test", opt)
+	assert.Error(t, err)
+	// case 5: shard assignment is balanced
+	repo.EXPECT().Get(gomock.Any(), constants.GetDatabaseAssignPath("test")).Return(shardAssign, nil)
+	storage.EXPECT().GetLiveNodes().Return([]models.StatefulNode{{ID: 1}}, nil)
+	repo.EXPECT().Put(gomock.Any(), constants.GetDatabaseRebalancePath("test"), gomock.Any()).Return(nil)
+	err = mgr.RebalanceDatabase("test", opt)
+	assert.NoError(t, err)
+	// case 6: run migrations in background, migration failure
+	repo.EXPECT().Get(gomock.Any(), constants.GetDatabaseAssignPath("test")).Return(shardAssign, nil)
+	storage.EXPECT().GetLiveNodes().Return([]models.StatefulNode{{ID: 1}, {ID: 2}}, nil)
+	repo.EXPECT().Put(gomock.Any(), constants.GetDatabaseRebalancePath("test"), gomock.Any()).Return(nil).AnyTimes()
+	running := make(chan struct{})
+	repo.EXPECT().Get(gomock.Any(), constants.GetDatabaseAssignPath("test")).
+		DoAndReturn(func(_ context.Context, _ string) ([]byte, error) {
+			<-running
+			return nil, fmt.Errorf("err")
+		})
+	err = mgr.RebalanceDatabase("test", opt)
+	assert.NoError(t, err)
+	// case 7: rebalance is running
+	err = mgr.RebalanceDatabase("test", opt)
+	assert.Equal(t, constants.ErrRebalanceRunning, err)
+	close(running)
+	time.Sleep(100 * time.Millisecond)
+	mgr1.mutex.Lock()
+	assert.Empty(t, mgr1.rebalances)
+	mgr1.mutex.Unlock()
+}
+
+func TestStateManager_RestoreDatabase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := state.NewMockRepository(ctrl)
+	storage := NewMockStorageCluster(ctrl)
+	mgr := NewStateManager(context.TODO(), repo, nil, nil)
+	mgr1 := mgr.(*stateManager)
+	// case 1: storage not exist
+	err := mgr.RestoreDatabase("test", "test", "/backup", nil)
+	assert.Equal(t, constants.ErrNoStorageCluster, err)
+	// case 2: database not exist, restore it in all live nodes
+	mgr1.storages["test"] = storage
+	storage.EXPECT().RestoreDatabase("test", "/backup", []models.ShardID{1}).Return(nil)
+	err = mgr.RestoreDatabase("test", "test", "/backup", []models.ShardID{1})
+	assert.NoError(t, err)
+	// case 3: get shard assignment err
+	mgr1.databases["test"] = models.Database{Name: "test", Storage: "test", ReplicaFactor: 2}
+	repo.EXPECT().Get(gomock.Any(), constants.GetDatabaseAssignPath("test")).Return(nil, fmt.Errorf("err"))
+	err = mgr.RestoreDatabase("test", "test", "/backup", nil)
+	assert.Error(t, err)
+	// case 4: get live nodes err
+	shardAssign := []byte(`{"name":"test","shards":{"0":{"replicas":[1,2]},"1":{"replicas":[2,3]}}}`)
+	repo.EXPECT().Get(gomock.Any(), constants.GetDatabaseAssignPath("test")).Return(shardAssign, nil).AnyTimes()
+	storage.EXPECT().GetLiveNodes().Return(nil, fmt.Errorf("err"))
+	err = mgr.RestoreDatabase("test", "test", "/backup", nil)
+	assert.Error(t, err)
+	// case 5: restore assigned shards in replica nodes
+	liveNodes := []models.StatefulNode{{ID: 1}, {ID: 2}, {ID: 3}}
+	storage.EXPECT().GetLiveNodes().Return(liveNodes, nil).AnyTimes()
+	storage.EXPECT().RestoreShards("test", "/backup", map[models.NodeID][]models.ShardID{
+		1: {0}, 2: {0, 1}, 3: {1},
+	}).Return(nil)
+	err = mgr.RestoreDatabase("test", "test", "/backup", nil)
+	assert.NoError(t, err)
+	// case 6: restore shards err
+	storage.EXPECT().RestoreShards(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("err"))
+	err = mgr.RestoreDatabase("test", "test", "/backup", []models.ShardID{1})
+	assert.Error(t, err)
+	// case 7: replica factor > num. of live nodes
+	mgr1.databases["test"] = models.Database{Name: "test", Storage: "test", ReplicaFactor: 4}
+	err = mgr.RestoreDatabase("test", "test", "/backup", []models.ShardID{5})
+	assert.Error(t, err)
+	// case 8: register restored shard which is not assigned
+	mgr1.databases["test"] = models.Database{Name: "test", Storage: "test", ReplicaFactor: 2}
+	var nodeShards map[models.NodeID][]models.ShardID
+	storage.EXPECT().RestoreShards("test", "/backup", gomock.Any()).
+		DoAndReturn(func(_, _ string, shards map[models.NodeID][]models.ShardID) error {
+			nodeShards = shards
+			return nil
+		}).Times(2)
+	repo.EXPECT().Put(gomock.Any(), constants.GetDatabaseAssignPath("test"), gomock.Any()).Return(fmt.Errorf("err"))
+	err = mgr.RestoreDatabase("test", "test", "/backup", []models.ShardID{5})
+	assert.Error(t, err)
+	assert.Len(t, nodeShards, 2)
+	repo.EXPECT().Put(gomock.Any(), constants.GetDatabaseAssignPath("test"), gomock.Any()).Return(nil)
+	storage.EXPECT().SaveDatabaseAssignment(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(assign *models.ShardAssignment, _ option.DatabaseOption) error {
+			assert.Len(t, assign.Shards, 3)
+			assert.Len(t, assign.Shards[5].Replicas, 2)
+			return nil
+		})
+	err = mgr.RestoreDatabase("test", "test", "/backup", []models.ShardID{5})
+	assert.NoError(t, err)
+}
+
+func TestStateManager_getReplicaPeerState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storage := NewMockStorageCluster(ctrl)
+	mgr := NewStateManager(context.TODO(), nil, nil, nil)
+	mgr1 := mgr.(*stateManager)
+	// case 1: storage not exist
+	_, _, ok := mgr1.getReplicaPeerState("test", "db", 1, 2)
+	assert.False(t, ok)
+	// case 2: get replica state from storage state
+	mgr1.storages["test"] = storage
+	storageState := models.NewStorageState("test")
+	storageState.UpdateReplicaState(models.NodeReplicaState{NodeID: 1, ReportTime: 10, Peers: []models.ReplicaPeerState{{
+		ReplicaState: models.ReplicaState{Database: "db", ShardID: 1, Leader: 1, Follower: 2},
+		AckIndex:     5,
+	}}})
+	storage.EXPECT().GetState().Return(storageState)
+	peer, reportTime, ok := mgr1.getReplicaPeerState("test", "db", 1, 2)
+	assert.True(t, ok)
+	assert.Equal(t, int64(5), peer.AckIndex)
+	assert.Equal(t, int64(10), reportTime)
+}
+
+func TestStateManager_dropShardReplica(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storage := NewMockStorageCluster(ctrl)
+	mgr := NewStateManager(context.TODO(), nil, nil, nil)
+	mgr1 := mgr.(*stateManager)
+	// case 1: storage not exist
+	err := mgr1.dropShardReplica("test", "db", 1, 2)
+	assert.Equal(t, constants.ErrNoStorageCluster, err)
+	// case 2: submit drop shard task
+	mgr1.storages["test"] = storage
+	storage.EXPECT().DropShards("db", models.NodeID(2), []models.ShardID{1}).Return(nil)
+	err = mgr1.dropShardReplica("test", "db", 1, 2)
+	assert.NoError(t, err)
+}
+
+func TestStateManager_updateShardReplica(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := state.NewMockRepository(ctrl)
+	storage := NewMockStorageCluster(ctrl)
+	mgr := NewStateManager(context.TODO(), repo, nil, nil)
+	mgr1 := mgr.(*stateManager)
+	update := func(replica *models.Replica) error { return fmt.Errorf("err") }
+	// case 1: database not exist
+	err := mgr1.updateShardReplica("test", 0, update)
+	assert.Equal(t, constants.ErrDatabaseNotFound, err)
+	// case 2: storage not exist
+	mgr1.databases["test"] = models.Database{Name: "test", Storage: "test"}
+	err = mgr1.updateShardReplica("test", 0, update)
+	assert.Equal(t, constants.ErrNoStorageCluster, err)
+	// case 3: get shard assignment err
+	mgr1.storages["test"] = storage
+	repo.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("err"))
+	err = mgr1.updateShardReplica("test", 0, update)
+	assert.Error(t, err)
+	// case 4: shard not exist
+	repo.EXPECT().Get(gomock.Any(), gomock.Any()).Return([]byte(`{"name":"test","shards":{"1":{"replicas":[1]}}}`), nil).Times(2)
+	err = mgr1.updateShardReplica("test", 0, update)
+	assert.Equal(t, constants.ErrShardNotFound, err)
+	// case 5: update replica err
+	err = mgr1.updateShardReplica("test", 1, update)
+	assert.Error(t, err)
+}
+
 func TestStateManager_StorageCfg(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer func() {
 		ctrl.Finish()
 	}()
-	mgr := NewStateManager(context.TODO(), nil, nil, nil)
+	repo := state.NewMockRepository(ctrl)
+	repo.EXPECT().Get(gomock.Any(), constants.GetStorageStatePath("test")).Return(nil, state.ErrNotExist).AnyTimes()
+	mgr := NewStateManager(context.TODO(), repo, nil, nil)
 	mgr1 := mgr.(*stateManager)
 	//mgr1 := mgr.(*stateManager)
 	// case 1: unmarshal cfg err
@@ -104,6 +361,7 @@ func TestStateManager_StorageCfg(t *testing.T) {
 	time.Sleep(100 * time.Millisecond)
 	// case 4: start storage err
 	storage1 := NewMockStorageCluster(ctrl)
+	storage1.EXPECT().GetState().Return(&models.StorageState{Name: "test"}).AnyTimes()
 	mgr1.mutex.Lock()
 	mgr1.newStorageClusterFn = func(ctx context.Context, cfg config.StorageCluster,
 		stateMgr StateManager, repoFactory state.RepositoryFactory,
@@ -165,6 +423,8 @@ func TestStateManager_DatabaseCfg(t *testing.T) {
 	}
 	mgr1.mutex.Unlock()
 
+	repo.EXPECT().Get(gomock.Any(), constants.GetStorageStatePath("test")).Return(nil, state.ErrNotExist)
+	storage1.EXPECT().GetState().Return(&models.StorageState{Name: "test"})
 	storage1.EXPECT().Start().Return(nil)
 	mgr.EmitEvent(&discovery.Event{
 		Type:  discovery.StorageConfigChanged,
@@ -265,8 +525,8 @@ func TestStateManager_ShardAssignment(t *testing.T) {
 		Shards: map[models.ShardID]*models.Replica{1: {Replicas: []models.NodeID{2, 3}}, 2: {Replicas: []models.NodeID{2, 3}}},
 	})
 	storage.EXPECT().GetState().Return(models.NewStorageState("test"))
-	elector.EXPECT().ElectLeader(gomock.Any(), gomock.Any(), gomock.Any()).Return(models.NodeID(2), nil)
-	elector.EXPECT().ElectLeader(gomock.Any(), gomock.Any(), gomock.Any()).Return(models.NodeID(0), fmt.Errorf("err"))
+	elector.EXPECT().ElectLeader(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(models.NodeID(2), nil)
+	elector.EXPECT().ElectLeader(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(models.NodeID(0), fmt.Errorf("err"))
 	repo.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("err"))
 	mgr.EmitEvent(&discovery.Event{
 		Type:  discovery.ShardAssignmentChanged,
@@ -275,8 +535,8 @@ func TestStateManager_ShardAssignment(t *testing.T) {
 	})
 	// case 2: put state err
 	storage.EXPECT().GetState().Return(models.NewStorageState("test"))
-	elector.EXPECT().ElectLeader(gomock.Any(), gomock.Any(), gomock.Any()).Return(models.NodeID(2), nil)
-	elector.EXPECT().ElectLeader(gomock.Any(), gomock.Any(), gomock.Any()).Return(models.NodeID(0), fmt.Errorf("err"))
+	elector.EXPECT().ElectLeader(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(models.NodeID(2), nil)
+	elector.EXPECT().ElectLeader(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(models.NodeID(0), fmt.Errorf("err"))
 	repo.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 	mgr.EmitEvent(&discovery.Event{
 		Type:  discovery.ShardAssignmentChanged,
@@ -515,3 +775,81 @@ func TestStateManager_StorageNodeFailure(t *testing.T) {
 	time.Sleep(300 * time.Millisecond)
 	mgr.Close()
 }
+
+func TestStateManager_ReplicaState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := state.NewMockRepository(ctrl)
+	repo.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	storage := NewMockStorageCluster(ctrl)
+	storage.EXPECT().Close().AnyTimes()
+	storageState := &models.StorageState{
+		Name:      "test",
+		LiveNodes: map[models.NodeID]models.StatefulNode{1: {ID: 1}},
+	}
+	storage.EXPECT().GetState().Return(storageState).AnyTimes()
+	mgr := NewStateManager(context.TODO(), repo, nil, nil)
+	mgr1 := mgr.(*stateManager)
+	mgr1.mutex.Lock()
+	mgr1.storages["test"] = storage
+	mgr1.mutex.Unlock()
+	// case 1: unmarshal err
+	mgr.EmitEvent(&discovery.Event{
+		Type:       discovery.ReplicaStateChanged,
+		Key:        "/state/replica/1",
+		Value:      []byte("dd"),
+		Attributes: map[string]string{storageNameKey: "test"},
+	})
+	// case 2: storage not found
+	mgr.EmitEvent(&discovery.Event{
+		Type:       discovery.ReplicaStateChanged,
+		Key:        "/state/replica/1",
+		Value:      []byte(`{"nodeId":1}`),
+		Attributes: map[string]string{storageNameKey: "test2"},
+	})
+	// case 3: node is offline
+	mgr.EmitEvent(&discovery.Event{
+		Type:       discovery.ReplicaStateChanged,
+		Key:        "/state/replica/2",
+		Value:      []byte(`{"nodeId":2}`),
+		Attributes: map[string]string{storageNameKey: "test"},
+	})
+	// case 4: update replica state
+	mgr.EmitEvent(&discovery.Event{
+		Type:       discovery.ReplicaStateChanged,
+		Key:        "/state/replica/1",
+		Value:      []byte(`{"nodeId":1,"reportTime":10,"peers":[{"database":"db","shardId":1,"leader":1,"follower":2,"lag":3}]}`),
+		Attributes: map[string]string{storageNameKey: "test"},
+	})
+	time.Sleep(100 * time.Millisecond)
+	mgr1.mutex.Lock()
+	assert.Len(t, storageState.ReplicaStates, 1)
+	assert.Equal(t, models.NodeID(1), storageState.ReplicaStates[0].NodeID)
+	assert.Equal(t, int64(3), storageState.ReplicaStates[0].Peers[0].Lag)
+	mgr1.mutex.Unlock()
+
+	// case 5: parse node id err when deletion
+	mgr.EmitEvent(&discovery.Event{
+		Type:       discovery.ReplicaStateDeletion,
+		Key:        "/state/replica/test_1",
+		Attributes: map[string]string{storageNameKey: "test"},
+	})
+	// case 6: storage not found when deletion
+	mgr.EmitEvent(&discovery.Event{
+		Type:       discovery.ReplicaStateDeletion,
+		Key:        "/state/replica/1",
+		Attributes: map[string]string{storageNameKey: "test2"},
+	})
+	// case 7: remove replica state
+	mgr.EmitEvent(&discovery.Event{
+		Type:       discovery.ReplicaStateDeletion,
+		Key:        "/state/replica/1",
+		Attributes: map[string]string{storageNameKey: "test"},
+	})
+	time.Sleep(100 * time.Millisecond)
+	mgr1.mutex.Lock()
+	assert.Empty(t, storageState.ReplicaStates)
+	mgr1.mutex.Unlock()
+	mgr.Close()
+}
@@ -20,6 +20,8 @@ package master
 import (
 	"context"
 	"encoding/json"
+	"strconv"
+	"time"
 
 	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/constants"
@@ -28,6 +30,7 @@ import (
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/ltoml"
 	"github.com/lindb/lindb/pkg/option"
 	"github.com/lindb/lindb/pkg/state"
 )
@@ -41,9 +44,28 @@ import (
 type StorageCluster interface {
 	Start() error
 	GetState() *models.StorageState
+	// GetLiveNodes returns the live nodes which can hold new shard replicas,
+	// draining/decommissioned nodes are excluded.
 	GetLiveNodes() ([]models.StatefulNode, error)
 	// FlushDatabase submits the coordinator task for flushing memory database by name
 	FlushDatabase(databaseName string) error
+	// BackupDatabase submits the coordinator task for backup database into path of all storage nodes
+	BackupDatabase(databaseName string, path string) error
+	// RestoreDatabase submits the coordinator task for restoring database from path of all storage nodes
+	RestoreDatabase(databaseName string, path string, shardIDs []models.ShardID) error
+	// RestoreShards submits the coordinator task for restoring shards of database from path of given storage nodes
+	RestoreShards(databaseName string, path string, nodeShards map[models.NodeID][]models.ShardID) error
+	// RebuildIndex submits the coordinator task for rebuilding series index of shards in all storage nodes
+	RebuildIndex(databaseName string, shardIDs []models.ShardID) error
+	// DropDatabase persists the dropped database and removes database assignment in storage state repo,
+	// then submits the coordinator task for dropping database in all live storage nodes
+	DropDatabase(databaseName string, purgeDelay time.Duration) error
+	// DropShards submits the coordinator task for dropping shards(data/write ahead log) of database in storage node
+	DropShards(databaseName string, nodeID models.NodeID, shardIDs []models.ShardID) error
+	// DrainNode submits the coordinator task for waiting write ahead log of node acked by replicas
+	DrainNode(nodeID models.NodeID, timeout time.Duration) error
+	// GetTaskState returns the state of coordinator task by kind and name
+	GetTaskState(kind task.Kind, name string) (task.State, error)
 	// SaveDatabaseAssignment saves database assignment in storage state repo.
 	SaveDatabaseAssignment(
 		shardAssign *models.ShardAssignment,
@@ -69,8 +91,9 @@ type storageCluster struct {
 	storageRepo    state.Repository
 	stateMgr       StateManager
 
-	state *models.StorageState
-	sm    discovery.StateMachine
+	state     *models.StorageState
+	sm        discovery.StateMachine
+	replicaSM discovery.StateMachine
 
 	logger *logger.Logger
 }
@@ -96,13 +119,15 @@ func newStorageCluster(ctx context.Context,
 
 	log := logger.GetLogger("coordinator", "Storage")
 
+	storageState := models.NewStorageState(cfg.Name)
+	storageState.PrimaryZone = cfg.PrimaryZone
 	cluster = &storageCluster{
 		ctx:            ctx,
 		cfg:            cfg,
 		taskController: controllerFactory.CreateController(ctx, storageRepo),
 		storageRepo:    storageRepo,
 		stateMgr:       stateMgr,
-		state:          models.NewStorageState(cfg.Name),
+		state:          storageState,
 		logger:         log,
 	}
 
@@ -111,12 +136,18 @@ func newStorageCluster(ctx context.Context,
 }
 
 func (c *storageCluster) Start() error {
-	sm, err := c.stateMgr.GetStateMachineFactory().
-		createStorageNodeStateMachine(c.cfg.Name, discovery.NewFactory(c.storageRepo))
+	stateMachineFct := c.stateMgr.GetStateMachineFactory()
+	discoveryFct := discovery.NewFactory(c.storageRepo)
+	sm, err := stateMachineFct.createStorageNodeStateMachine(c.cfg.Name, discoveryFct)
 	if err != nil {
 		return err
 	}
 	c.sm = sm
+	replicaSM, err := stateMachineFct.createReplicaStateStateMachine(c.cfg.Name, discoveryFct)
+	if err != nil {
+		return err
+	}
+	c.replicaSM = replicaSM
 
 	c.logger.Info("start storage cluster successfully", logger.String("storage", c.cfg.Name))
 	return nil
@@ -137,6 +168,9 @@ func (c *storageCluster) GetLiveNodes() (rs []models.StatefulNode, err error) {
 		if err := json.Unmarshal(kv.Value, &node); err != nil {
 			return nil, err
 		}
+		if c.state.IsDraining(node.ID) || c.state.IsDecommissioned(node.ID) {
+			continue
+		}
 		rs = append(rs, node)
 	}
 	return rs, nil
@@ -165,12 +199,194 @@ func (c *storageCluster) FlushDatabase(databaseName string) error {
 	return nil
 }
 
+// BackupDatabase submits the coordinator task for backup database into path of all storage nodes
+func (c *storageCluster) BackupDatabase(databaseName string, path string) error {
+	var params []task.ControllerTaskParam
+	taskParam := &models.DatabaseBackupTask{DatabaseName: databaseName, Path: path}
+	for _, node := range c.state.LiveNodes {
+		params = append(params, task.ControllerTaskParam{
+			NodeID: node.Indicator(),
+			Params: taskParam,
+		})
+	}
+	if err := c.SubmitTask(constants.BackupDatabase, databaseName, params); err != nil {
+		return err
+	}
+	c.logger.Info("submit backup database task",
+		logger.String("storage", c.cfg.Name),
+		logger.String("database", databaseName),
+		logger.String("path", path))
+	return nil
+}
+
+// RestoreDatabase submits the coordinator task for restoring database from path of all storage nodes
+func (c *storageCluster) RestoreDatabase(databaseName string, path string, shardIDs []models.ShardID) error {
+	var params []task.ControllerTaskParam
+	taskParam := &models.DatabaseRestoreTask{DatabaseName: databaseName, Path: path, ShardIDs: shardIDs}
+	for _, node := range c.state.LiveNodes {
+		params = append(params, task.ControllerTaskParam{
+			NodeID: node.Indicator(),
+			Params: taskParam,
+		})
+	}
+	if err := c.SubmitTask(constants.RestoreDatabase, databaseName, params); err != nil {
+		return err
+	}
+	c.logger.Info("submit restore database task",
+		logger.String("storage", c.cfg.Name),
+		logger.String("database", databaseName),
+		logger.String("path", path))
+	return nil
+}
+
+// RestoreShards submits the coordinator task for restoring shards of database from path of given storage nodes,
+// the nodes which are not alive are skipped.
+func (c *storageCluster) RestoreShards(databaseName string, path string, nodeShards map[models.NodeID][]models.ShardID) error {
+	var params []task.ControllerTaskParam
+	for nodeID, shardIDs := range nodeShards {
+		node, ok := c.state.LiveNodes[nodeID]
+		if !ok {
+			c.logger.Warn("skip restoring shards in storage node which is not alive",
+				logger.String("storage", c.cfg.Name),
+				logger.String("database", databaseName),
+				logger.Any("node", nodeID),
+				logger.Any("shards", shardIDs))
+			continue
+		}
+		params = append(params, task.ControllerTaskParam{
+			NodeID: node.Indicator(),
+			Params: &models.DatabaseRestoreTask{DatabaseName: databaseName, Path: path, ShardIDs: shardIDs},
+		})
+	}
+	if len(params) == 0 {
+		return constants.ErrNoLiveNode
+	}
+	if err := c.SubmitTask(constants.RestoreDatabase, databaseName, params); err != nil {
+		return err
+	}
+	c.logger.Info("submit restore shards task",
+		logger.String("storage", c.cfg.Name),
+		logger.String("database", databaseName),
+		logger.String("path", path),
+		logger.Any("nodeShards", nodeShards))
+	return nil
+}
+
+// RebuildIndex submits the coordinator task for rebuilding series index of shards in all storage nodes
+func (c *storageCluster) RebuildIndex(databaseName string, shardIDs []models.ShardID) error {
+	var params []task.ControllerTaskParam
+	taskParam := &models.IndexRebuildTask{DatabaseName: databaseName, ShardIDs: shardIDs}
+	for _, node := range c.state.LiveNodes {
+		params = append(params, task.ControllerTaskParam{
+			NodeID: node.Indicator(),
+			Params: taskParam,
+		})
+	}
+	if err := c.SubmitTask(constants.RebuildIndex, databaseName, params); err != nil {
+		return err
+	}
+	c.logger.Info("submit rebuild index task",
+		logger.String("storage", c.cfg.Name),
+		logger.String("database", databaseName),
+		logger.Any("shards", shardIDs))
+	return nil
+}
+
+// DropDatabase persists the dropped database in storage state repo(storage nodes which are offline
+// drop it when they start), removes database assignment, then submits the coordinator task for
+// dropping database in all live storage nodes.
+func (c *storageCluster) DropDatabase(databaseName string, purgeDelay time.Duration) error {
+	taskParam := &models.DatabaseDropTask{DatabaseName: databaseName, PurgeDelay: ltoml.Duration(purgeDelay)}
+	if err := c.storageRepo.Put(c.ctx, constants.GetDatabaseDroppedPath(databaseName), encoding.JSONMarshal(taskParam)); err != nil {
+		return err
+	}
+	if err := c.storageRepo.Delete(c.ctx, constants.GetDatabaseAssignPath(databaseName)); err != nil {
+		return err
+	}
+	var params []task.ControllerTaskParam
+	for _, node := range c.state.LiveNodes {
+		params = append(params, task.ControllerTaskParam{
+			NodeID: node.Indicator(),
+			Params: taskParam,
+		})
+	}
+	if err := c.SubmitTask(constants.DropDatabase, databaseName, params); err != nil {
+		return err
+	}
+	c.logger.Info("submit drop database task",
+		logger.String("storage", c.cfg.Name),
+		logger.String("database", databaseName),
+		logger.String("purgeDelay", purgeDelay.String()))
+	return nil
+}
+
+// DropShards submits the coordinator task for dropping shards(data/write ahead log) of database in storage node,
+// such as the replicas of shards are moved to other nodes.
+func (c *storageCluster) DropShards(databaseName string, nodeID models.NodeID, shardIDs []models.ShardID) error {
+	node, ok := c.state.LiveNodes[nodeID]
+	if !ok {
+		return constants.ErrNodeNotAlive
+	}
+	params := []task.ControllerTaskParam{{
+		NodeID: node.Indicator(),
+		Params: &models.ShardDropTask{DatabaseName: databaseName, ShardIDs: shardIDs},
+	}}
+	if err := c.SubmitTask(constants.DropShard, shardDropTaskName(databaseName, nodeID, shardIDs), params); err != nil {
+		return err
+	}
+	c.logger.Info("submit drop shards task",
+		logger.String("storage", c.cfg.Name),
+		logger.String("database", databaseName),
+		logger.Any("node", nodeID),
+		logger.Any("shards", shardIDs))
+	return nil
+}
+
+// shardDropTaskName returns the name of shard drop task, shards of the same database
+// may be dropped concurrently in storage node.
+func shardDropTaskName(databaseName string, nodeID models.NodeID, shardIDs []models.ShardID) string {
+	name := databaseName + "-" + strconv.Itoa(int(nodeID))
+	for _, shardID := range shardIDs {
+		name += "-" + strconv.Itoa(int(shardID))
+	}
+	return name
+}
+
+// DrainNode submits the coordinator task for waiting write ahead log of node acked by replicas
+func (c *storageCluster) DrainNode(nodeID models.NodeID, timeout time.Duration) error {
+	node, ok := c.state.LiveNodes[nodeID]
+	if !ok {
+		return constants.ErrNodeNotAlive
+	}
+	params := []task.ControllerTaskParam{{
+		NodeID: node.Indicator(),
+		Params: &models.NodeDrainTask{NodeID: nodeID, Timeout: ltoml.Duration(timeout)},
+	}}
+	if err := c.SubmitTask(constants.DrainNode, nodeDrainTaskName(nodeID), params); err != nil {
+		return err
+	}
+	c.logger.Info("submit drain node task",
+		logger.String("storage", c.cfg.Name),
+		logger.Any("node", nodeID),
+		logger.String("timeout", timeout.String()))
+	return nil
+}
+
+// GetTaskState returns the state of coordinator task by kind and name
+func (c *storageCluster) GetTaskState(kind task.Kind, name string) (task.State, error) {
+	return c.taskController.GetState(kind, name)
+}
+
 // SaveDatabaseAssignment saves database assignment in storage state repo.
 func (c *storageCluster) SaveDatabaseAssignment(
 	shardAssign *models.ShardAssignment,
 	databaseOption option.DatabaseOption,
 ) error {
 	//TODO timeout ctx
+	// database is created again after dropped, storage nodes need not drop it any more
+	if err := c.storageRepo.Delete(c.ctx, constants.GetDatabaseDroppedPath(shardAssign.Name)); err != nil {
+		return err
+	}
 	data := encoding.JSONMarshal(&models.DatabaseAssignment{
 		ShardAssignment: shardAssign,
 		Option:          databaseOption,
@@ -205,6 +421,12 @@ func (c *storageCluster) Close() {
 				logger.String("storage", c.cfg.Name), logger.Error(err), logger.Stack())
 		}
 	}
+	if c.replicaSM != nil {
+		if err := c.replicaSM.Close(); err != nil {
+			c.logger.Error("close replica state machine of storage cluster",
+				logger.String("storage", c.cfg.Name), logger.Error(err), logger.Stack())
+		}
+	}
 	if err := c.storageRepo.Close(); err != nil {
 		c.logger.Error("close state repo of storage cluster",
 			logger.String("storage", c.cfg.Name), logger.Error(err), logger.Stack())
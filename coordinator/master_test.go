@@ -197,6 +197,112 @@ func TestMaster_FlushDatabase(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestMaster_BackupRestoreDatabase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	eventCh := make(chan *state.Event)
+
+	repo := state.NewMockRepository(ctrl)
+	repo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	repo.EXPECT().Watch(gomock.Any(), gomock.Any(), true).Return(eventCh).AnyTimes()
+	repo.EXPECT().Elect(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(true, nil, nil).AnyTimes()
+	discoveryFactory := discovery.NewMockFactory(ctrl)
+	discovery1 := discovery.NewMockDiscovery(ctrl)
+	discovery1.EXPECT().Discovery(gomock.Any()).Return(nil).AnyTimes()
+	discovery1.EXPECT().Close().AnyTimes()
+	discoveryFactory.EXPECT().CreateDiscovery(gomock.Any(), gomock.Any()).Return(discovery1).AnyTimes()
+
+	node1 := models.StatelessNode{HostIP: "1.1.1.1", GRPCPort: 8000}
+	master1 := NewMaster(&MasterCfg{
+		Ctx:              context.TODO(),
+		Repo:             repo,
+		Node:             &node1,
+		TTL:              1,
+		DiscoveryFactory: discoveryFactory,
+	})
+	err := master1.BackupDatabase("test", "test", "/backup")
+	assert.NoError(t, err)
+	err = master1.RestoreDatabase("test", "test", "/backup", nil)
+	assert.NoError(t, err)
+	err = master1.RebuildIndex("test", "test", nil)
+	assert.NoError(t, err)
+	err = master1.DropDatabase("test", "test", 0)
+	assert.NoError(t, err)
+	err = master1.RebalanceDatabase("test", "test", 1, time.Minute)
+	assert.NoError(t, err)
+	err = master1.DrainNode("test", 1, true, time.Minute)
+	assert.NoError(t, err)
+	err = master1.RecommissionNode("test", 1)
+	assert.NoError(t, err)
+
+	master1.Start()
+	data := encoding.JSONMarshal(&models.Master{Node: &node1})
+	sendEvent(eventCh, &state.Event{
+		Type: state.EventTypeModify,
+		KeyValues: []state.EventKeyValue{
+			{Key: constants.MasterPath, Value: data},
+		},
+	})
+	assert.True(t, master1.IsMaster())
+	err = master1.BackupDatabase("test", "test", "/backup")
+	assert.Error(t, err)
+	err = master1.RestoreDatabase("test", "test", "/backup", nil)
+	assert.Error(t, err)
+	err = master1.RebuildIndex("test", "test", nil)
+	assert.Error(t, err)
+	err = master1.DropDatabase("test", "test", 0)
+	assert.Error(t, err)
+	err = master1.RebalanceDatabase("test", "test", 1, time.Minute)
+	assert.Error(t, err)
+	err = master1.DrainNode("test", 1, true, time.Minute)
+	assert.Equal(t, constants.ErrNoStorageCluster, err)
+	err = master1.RecommissionNode("test", 1)
+	assert.Equal(t, constants.ErrNoStorageCluster, err)
+
+	m1 := master1.(*master)
+	m1.mutex.Lock()
+	statMgr := masterpkg.NewMockStateManager(ctrl)
+	m1.stateMgr = statMgr
+	m1.mutex.Unlock()
+
+	cluster1 := masterpkg.NewMockStorageCluster(ctrl)
+	statMgr.EXPECT().GetStorageCluster(gomock.Any()).Return(cluster1).Times(6)
+	cluster1.EXPECT().BackupDatabase("test", "/backup").Return(nil)
+	err = master1.BackupDatabase("test", "test", "/backup")
+	assert.NoError(t, err)
+	statMgr.EXPECT().RestoreDatabase("test", "test", "/backup", []models.ShardID{1}).Return(nil)
+	err = master1.RestoreDatabase("test", "test", "/backup", []models.ShardID{1})
+	assert.NoError(t, err)
+	cluster1.EXPECT().RebuildIndex("test", []models.ShardID{1}).Return(nil)
+	err = master1.RebuildIndex("test", "test", []models.ShardID{1})
+	assert.NoError(t, err)
+	cluster1.EXPECT().DropDatabase("test", time.Hour).Return(fmt.Errorf("err"))
+	err = master1.DropDatabase("test", "test", time.Hour)
+	assert.Error(t, err)
+	cluster1.EXPECT().DropDatabase("test", time.Hour).Return(nil)
+	repo.EXPECT().Delete(gomock.Any(), constants.GetDatabaseConfigPath("test")).Return(fmt.Errorf("err"))
+	err = master1.DropDatabase("test", "test", time.Hour)
+	assert.Error(t, err)
+	cluster1.EXPECT().DropDatabase("test", time.Hour).Return(nil)
+	repo.EXPECT().Delete(gomock.Any(), constants.GetDatabaseConfigPath("test")).Return(nil)
+	repo.EXPECT().Delete(gomock.Any(), constants.GetIngestionRulePath("test")).Return(nil)
+	err = master1.DropDatabase("test", "test", time.Hour)
+	assert.NoError(t, err)
+	statMgr.EXPECT().RebalanceDatabase("test", masterpkg.RebalanceOption{MaxConcurrency: 2, CatchUpWait: time.Minute}).
+		Return(nil)
+	err = master1.RebalanceDatabase("test", "test", 2, time.Minute)
+	assert.NoError(t, err)
+	statMgr.EXPECT().DrainNode("test", models.NodeID(1), masterpkg.DrainOption{MigrateReplicas: true, Timeout: time.Minute}).
+		Return(nil)
+	err = master1.DrainNode("test", 1, true, time.Minute)
+	assert.NoError(t, err)
+	statMgr.EXPECT().RecommissionNode("test", models.NodeID(1)).Return(nil)
+	err = master1.RecommissionNode("test", 1)
+	assert.NoError(t, err)
+}
+
 func sendEvent(eventCh chan *state.Event, event *state.Event) {
 	eventCh <- event
 	time.Sleep(10 * time.Millisecond)
@@ -0,0 +1,93 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/coordinator/task"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/tsdb"
+)
+
+// databaseBackupProcessor represents backup database into local path of storage node
+type databaseBackupProcessor struct {
+	engine tsdb.Engine
+}
+
+// newDatabaseBackupProcessor returns database backup processor instance
+func newDatabaseBackupProcessor(engine tsdb.Engine) task.Processor {
+	return &databaseBackupProcessor{
+		engine: engine,
+	}
+}
+
+func (p *databaseBackupProcessor) Kind() task.Kind             { return constants.BackupDatabase }
+func (p *databaseBackupProcessor) RetryCount() int             { return 0 }
+func (p *databaseBackupProcessor) RetryBackOff() time.Duration { return 0 }
+func (p *databaseBackupProcessor) Concurrency() int            { return 1 }
+
+// Process backups all shards and metadata of database into backup path
+func (p *databaseBackupProcessor) Process(ctx context.Context, task task.Task) error {
+	param := models.DatabaseBackupTask{}
+	if err := encoding.JSONUnmarshal(task.Params, &param); err != nil {
+		return err
+	}
+	err := p.engine.BackupDatabase(param.DatabaseName, param.Path)
+	logger.GetLogger("coordinator", "StorageBackupDBProcessor").
+		Info("process backup database task",
+			logger.String("params", string(task.Params)),
+			logger.Any("result", err == nil),
+		)
+	return err
+}
+
+// databaseRestoreProcessor represents restore database from backup path of storage node
+type databaseRestoreProcessor struct {
+	engine tsdb.Engine
+}
+
+// newDatabaseRestoreProcessor returns database restore processor instance
+func newDatabaseRestoreProcessor(engine tsdb.Engine) task.Processor {
+	return &databaseRestoreProcessor{
+		engine: engine,
+	}
+}
+
+func (p *databaseRestoreProcessor) Kind() task.Kind             { return constants.RestoreDatabase }
+func (p *databaseRestoreProcessor) RetryCount() int             { return 0 }
+func (p *databaseRestoreProcessor) RetryBackOff() time.Duration { return 0 }
+func (p *databaseRestoreProcessor) Concurrency() int            { return 1 }
+
+// Process rebuilds database from backup path based on backup manifest
+func (p *databaseRestoreProcessor) Process(ctx context.Context, task task.Task) error {
+	param := models.DatabaseRestoreTask{}
+	if err := encoding.JSONUnmarshal(task.Params, &param); err != nil {
+		return err
+	}
+	err := p.engine.RestoreDatabase(param.Path, param.ShardIDs...)
+	logger.GetLogger("coordinator", "StorageRestoreDBProcessor").
+		Info("process restore database task",
+			logger.String("params", string(task.Params)),
+			logger.Any("result", err == nil),
+		)
+	return err
+}
@@ -0,0 +1,103 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/coordinator/task"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/state"
+	"github.com/lindb/lindb/tsdb"
+)
+
+//go:generate mockgen -source=./database_drop_task.go -destination=./database_drop_task_mock.go -package=storage
+
+// WriteAheadLogDropper represents drop write ahead log of database/shard,
+// implemented by replica's write ahead log manager.
+type WriteAheadLogDropper interface {
+	// DropLog closes and removes write ahead log of database.
+	DropLog(database string) error
+	// DropPartition closes and removes write ahead log of database's shard.
+	DropPartition(database string, shardID models.ShardID) error
+}
+
+// databaseDropProcessor represents drop database(write ahead log/shards/metadata) in storage node
+type databaseDropProcessor struct {
+	engine     tsdb.Engine
+	walDropper WriteAheadLogDropper
+	logger     *logger.Logger
+}
+
+// newDatabaseDropProcessor returns database drop processor instance
+func newDatabaseDropProcessor(engine tsdb.Engine, walDropper WriteAheadLogDropper) task.Processor {
+	return &databaseDropProcessor{
+		engine:     engine,
+		walDropper: walDropper,
+		logger:     logger.GetLogger("coordinator", "StorageDropDBProcessor"),
+	}
+}
+
+func (p *databaseDropProcessor) Kind() task.Kind             { return constants.DropDatabase }
+func (p *databaseDropProcessor) RetryCount() int             { return 0 }
+func (p *databaseDropProcessor) RetryBackOff() time.Duration { return 0 }
+func (p *databaseDropProcessor) Concurrency() int            { return 1 }
+
+// Process stops replication by removing write ahead log first, then drops the data of database,
+// data is purged from trash after purge delay, but write ahead log is removed directly(no undelete).
+func (p *databaseDropProcessor) Process(_ context.Context, task task.Task) error {
+	param := models.DatabaseDropTask{}
+	if err := encoding.JSONUnmarshal(task.Params, &param); err != nil {
+		return err
+	}
+	if err := p.walDropper.DropLog(param.DatabaseName); err != nil {
+		return err
+	}
+	if err := p.engine.DropDatabase(param.DatabaseName, param.PurgeDelay.Duration()); err != nil {
+		return err
+	}
+	p.logger.Info("process drop database task successfully",
+		logger.String("params", string(task.Params)))
+	return nil
+}
+
+// DropDroppedDatabases drops the local databases which are dropped when storage node is offline,
+// the dropped databases are persisted in storage state repo by master.
+func DropDroppedDatabases(ctx context.Context, repo state.Repository, engine tsdb.Engine, walDropper WriteAheadLogDropper) error {
+	kvs, err := repo.List(ctx, constants.DatabaseDroppedPath)
+	if err != nil {
+		return err
+	}
+	p := newDatabaseDropProcessor(engine, walDropper)
+	for _, kv := range kvs {
+		param := models.DatabaseDropTask{}
+		if err := encoding.JSONUnmarshal(kv.Value, &param); err != nil {
+			return err
+		}
+		if _, ok := engine.GetDatabase(param.DatabaseName); !ok {
+			continue
+		}
+		if err := p.Process(ctx, task.Task{Params: kv.Value}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package storage
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/coordinator/task"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/ltoml"
+	"github.com/lindb/lindb/pkg/state"
+	"github.com/lindb/lindb/tsdb"
+)
+
+func TestDatabaseDropProcessor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	engine := tsdb.NewMockEngine(ctrl)
+	walDropper := NewMockWriteAheadLogDropper(ctrl)
+	processor := newDatabaseDropProcessor(engine, walDropper)
+	assert.Equal(t, 1, processor.Concurrency())
+	assert.Equal(t, time.Duration(0), processor.RetryBackOff())
+	assert.Equal(t, 0, processor.RetryCount())
+	assert.Equal(t, constants.DropDatabase, processor.Kind())
+
+	// case 1: unmarshal param err
+	err := processor.Process(context.TODO(), task.Task{Params: []byte{1, 1, 1}})
+	assert.Error(t, err)
+	param := encoding.JSONMarshal(&models.DatabaseDropTask{DatabaseName: "test", PurgeDelay: ltoml.Duration(time.Hour)})
+	// case 2: drop write ahead log err
+	walDropper.EXPECT().DropLog("test").Return(fmt.Errorf("err"))
+	err = processor.Process(context.TODO(), task.Task{Params: param})
+	assert.Error(t, err)
+	// case 3: drop database err
+	walDropper.EXPECT().DropLog("test").Return(nil)
+	engine.EXPECT().DropDatabase("test", time.Hour).Return(fmt.Errorf("err"))
+	err = processor.Process(context.TODO(), task.Task{Params: param})
+	assert.Error(t, err)
+	// case 4: drop database successfully
+	walDropper.EXPECT().DropLog("test").Return(nil)
+	engine.EXPECT().DropDatabase("test", time.Hour).Return(nil)
+	err = processor.Process(context.TODO(), task.Task{Params: param})
+	assert.NoError(t, err)
+}
+
+func TestDropDroppedDatabases(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := state.NewMockRepository(ctrl)
+	engine := tsdb.NewMockEngine(ctrl)
+	walDropper := NewMockWriteAheadLogDropper(ctrl)
+	param := encoding.JSONMarshal(&models.DatabaseDropTask{DatabaseName: "test", PurgeDelay: ltoml.Duration(time.Hour)})
+	// case 1: list dropped databases err
+	repo.EXPECT().List(gomock.Any(), constants.DatabaseDroppedPath).Return(nil, fmt.Errorf("err"))
+	err := DropDroppedDatabases(context.TODO(), repo, engine, walDropper)
+	assert.Error(t, err)
+	// case 2: unmarshal param err
+	repo.EXPECT().List(gomock.Any(), gomock.Any()).Return([]state.KeyValue{{Value: []byte{1, 1, 1}}}, nil)
+	err = DropDroppedDatabases(context.TODO(), repo, engine, walDropper)
+	assert.Error(t, err)
+	// case 3: database not exist in local
+	repo.EXPECT().List(gomock.Any(), gomock.Any()).Return([]state.KeyValue{{Value: param}}, nil)
+	engine.EXPECT().GetDatabase("test").Return(nil, false)
+	err = DropDroppedDatabases(context.TODO(), repo, engine, walDropper)
+	assert.NoError(t, err)
+	// case 4: drop database err
+	repo.EXPECT().List(gomock.Any(), gomock.Any()).Return([]state.KeyValue{{Value: param}}, nil)
+	engine.EXPECT().GetDatabase("test").Return(nil, true)
+	walDropper.EXPECT().DropLog("test").Return(fmt.Errorf("err"))
+	err = DropDroppedDatabases(context.TODO(), repo, engine, walDropper)
+	assert.Error(t, err)
+	// case 5: drop database successfully
+	repo.EXPECT().List(gomock.Any(), gomock.Any()).Return([]state.KeyValue{{Value: param}}, nil)
+	engine.EXPECT().GetDatabase("test").Return(nil, true)
+	walDropper.EXPECT().DropLog("test").Return(nil)
+	engine.EXPECT().DropDatabase("test", time.Hour).Return(nil)
+	err = DropDroppedDatabases(context.TODO(), repo, engine, walDropper)
+	assert.NoError(t, err)
+}
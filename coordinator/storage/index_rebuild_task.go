@@ -0,0 +1,79 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/coordinator/task"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/tsdb"
+)
+
+// indexRebuildProcessor represents rebuild series index of shards in storage node,
+// NOTICE: write ahead log is held by running replicas, so only data families and forward index are used,
+// using lind tool to rebuild index from write ahead log offline.
+type indexRebuildProcessor struct {
+	engine tsdb.Engine
+	logger *logger.Logger
+}
+
+// newIndexRebuildProcessor returns series index rebuild processor instance
+func newIndexRebuildProcessor(engine tsdb.Engine) task.Processor {
+	return &indexRebuildProcessor{
+		engine: engine,
+		logger: logger.GetLogger("coordinator", "StorageIndexRebuildProcessor"),
+	}
+}
+
+func (p *indexRebuildProcessor) Kind() task.Kind             { return constants.RebuildIndex }
+func (p *indexRebuildProcessor) RetryCount() int             { return 0 }
+func (p *indexRebuildProcessor) RetryBackOff() time.Duration { return 0 }
+func (p *indexRebuildProcessor) Concurrency() int            { return 1 }
+
+// Process rebuilds series index of shards which exist in current storage node
+func (p *indexRebuildProcessor) Process(ctx context.Context, task task.Task) error {
+	param := models.IndexRebuildTask{}
+	if err := encoding.JSONUnmarshal(task.Params, &param); err != nil {
+		return err
+	}
+	for _, shardID := range param.ShardIDs {
+		shard, ok := p.engine.GetShard(param.DatabaseName, shardID)
+		if !ok {
+			// shard not in current node
+			continue
+		}
+		report, err := shard.RebuildIndex(nil)
+		if err != nil {
+			p.logger.Error("rebuild index of shard failure",
+				logger.String("database", param.DatabaseName),
+				logger.Any("shardID", shardID),
+				logger.Error(err))
+			return err
+		}
+		p.logger.Info("rebuild index of shard completed",
+			logger.String("database", param.DatabaseName),
+			logger.Any("shardID", shardID),
+			logger.Int("restoredSeries", report.RestoredSeries),
+			logger.Int("unresolvedSeries", report.NumOfUnresolvedSeries()))
+	}
+	return nil
+}
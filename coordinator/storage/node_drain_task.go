@@ -0,0 +1,91 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/coordinator/task"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/logger"
+)
+
+//go:generate mockgen -source=./node_drain_task.go -destination=./node_drain_task_mock.go -package=storage
+
+// for testing
+var (
+	replicatedCheckInterval = time.Second
+)
+
+// errWaitReplicatedTimeout represents write ahead log not acked by replicas before timeout.
+var errWaitReplicatedTimeout = errors.New("wait write ahead log replicated timeout")
+
+// WriteAheadLogChecker represents check replication of write ahead log,
+// implemented by replica's write ahead log manager.
+type WriteAheadLogChecker interface {
+	// IsReplicated returns if all write ahead logs are acked by replicas.
+	IsReplicated() bool
+}
+
+// nodeDrainProcessor represents wait write ahead log of draining node acked by replicas,
+// leadership of draining node is moved by master before task submitted.
+type nodeDrainProcessor struct {
+	walChecker WriteAheadLogChecker
+	logger     *logger.Logger
+}
+
+// newNodeDrainProcessor returns node drain processor instance
+func newNodeDrainProcessor(walChecker WriteAheadLogChecker) task.Processor {
+	return &nodeDrainProcessor{
+		walChecker: walChecker,
+		logger:     logger.GetLogger("coordinator", "StorageNodeDrainProcessor"),
+	}
+}
+
+func (p *nodeDrainProcessor) Kind() task.Kind             { return constants.DrainNode }
+func (p *nodeDrainProcessor) RetryCount() int             { return 0 }
+func (p *nodeDrainProcessor) RetryBackOff() time.Duration { return 0 }
+func (p *nodeDrainProcessor) Concurrency() int            { return 1 }
+
+// Process waits until all write ahead logs are acked by replicas, returns err if timeout.
+func (p *nodeDrainProcessor) Process(ctx context.Context, task task.Task) error {
+	param := models.NodeDrainTask{}
+	if err := encoding.JSONUnmarshal(task.Params, &param); err != nil {
+		return err
+	}
+	timeout := time.NewTimer(param.Timeout.Duration())
+	defer timeout.Stop()
+	ticker := time.NewTicker(replicatedCheckInterval)
+	defer ticker.Stop()
+
+	for !p.walChecker.IsReplicated() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout.C:
+			return errWaitReplicatedTimeout
+		case <-ticker.C:
+		}
+	}
+	p.logger.Info("process drain node task successfully",
+		logger.String("params", string(task.Params)))
+	return nil
+}
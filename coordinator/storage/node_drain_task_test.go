@@ -0,0 +1,73 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/coordinator/task"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/ltoml"
+)
+
+func TestNodeDrainProcessor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		replicatedCheckInterval = time.Second
+		ctrl.Finish()
+	}()
+	replicatedCheckInterval = 10 * time.Millisecond
+
+	walChecker := NewMockWriteAheadLogChecker(ctrl)
+	processor := newNodeDrainProcessor(walChecker)
+	assert.Equal(t, 1, processor.Concurrency())
+	assert.Equal(t, time.Duration(0), processor.RetryBackOff())
+	assert.Equal(t, 0, processor.RetryCount())
+	assert.Equal(t, constants.DrainNode, processor.Kind())
+
+	// case 1: unmarshal param err
+	err := processor.Process(context.TODO(), task.Task{Params: []byte{1, 1, 1}})
+	assert.Error(t, err)
+	param := encoding.JSONMarshal(&models.NodeDrainTask{NodeID: 1, Timeout: ltoml.Duration(50 * time.Millisecond)})
+	// case 2: wait replicated timeout
+	walChecker.EXPECT().IsReplicated().Return(false).AnyTimes()
+	err = processor.Process(context.TODO(), task.Task{Params: param})
+	assert.Equal(t, errWaitReplicatedTimeout, err)
+	// case 3: ctx canceled
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	err = processor.Process(ctx, task.Task{Params: param})
+	assert.Equal(t, context.Canceled, err)
+
+	// case 4: replicated after retry
+	walChecker = NewMockWriteAheadLogChecker(ctrl)
+	processor = newNodeDrainProcessor(walChecker)
+	gomock.InOrder(
+		walChecker.EXPECT().IsReplicated().Return(false),
+		walChecker.EXPECT().IsReplicated().Return(true),
+	)
+	param = encoding.JSONMarshal(&models.NodeDrainTask{NodeID: 1, Timeout: ltoml.Duration(time.Minute)})
+	err = processor.Process(context.TODO(), task.Task{Params: param})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,140 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package storage
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/internal/linmetric"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/state"
+	"github.com/lindb/lindb/pkg/timeutil"
+)
+
+//go:generate mockgen -source=./replica_state_reporter.go -destination=./replica_state_reporter_mock.go -package=storage
+
+// for testing
+var (
+	replicaStateReportInterval = 30 * time.Second
+)
+
+var (
+	replicaStateScope          = linmetric.NewScope("lindb.storage.replica")
+	replicaAppendIndexVec      = replicaStateScope.NewGaugeVec("append_index", "db", "shard", "leader", "follower")
+	replicaAckIndexVec         = replicaStateScope.NewGaugeVec("ack_index", "db", "shard", "leader", "follower")
+	replicaLagVec              = replicaStateScope.NewGaugeVec("lag", "db", "shard", "leader", "follower")
+	replicaLagBytesVec         = replicaStateScope.NewGaugeVec("lag_bytes", "db", "shard", "leader", "follower")
+	replicaLastReplicaTimeVec  = replicaStateScope.NewGaugeVec("last_replica_time", "db", "shard", "leader", "follower")
+	replicaReadyVec            = replicaStateScope.NewGaugeVec("ready", "db", "shard", "leader", "follower")
+	replicaStateReportFailures = replicaStateScope.NewCounter("report_failures")
+)
+
+// ReplicaStateGetter represents get replication state of write ahead log,
+// implemented by replica's write ahead log manager.
+type ReplicaStateGetter interface {
+	// ReplicaState returns the replication state of all replica peers.
+	ReplicaState() []models.ReplicaPeerState
+}
+
+// ReplicaStateReporter reports the replication state of storage node into state repo periodically,
+// master merges the state of all storage nodes into storage cluster state.
+type ReplicaStateReporter struct {
+	ctx    context.Context
+	node   *models.StatefulNode
+	repo   state.Repository
+	getter ReplicaStateGetter
+
+	logger *logger.Logger
+}
+
+// NewReplicaStateReporter creates a replication state reporter.
+func NewReplicaStateReporter(ctx context.Context,
+	node *models.StatefulNode,
+	repo state.Repository,
+	getter ReplicaStateGetter,
+) *ReplicaStateReporter {
+	return &ReplicaStateReporter{
+		ctx:    ctx,
+		node:   node,
+		repo:   repo,
+		getter: getter,
+		logger: logger.GetLogger("coordinator", "ReplicaStateReporter"),
+	}
+}
+
+// Run reports the replication state periodically in background, until context done.
+func (r *ReplicaStateReporter) Run() {
+	go func() {
+		ticker := time.NewTicker(replicaStateReportInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				r.report()
+			case <-r.ctx.Done():
+				r.logger.Info("replica state reporter is stopped")
+				return
+			}
+		}
+	}()
+	r.logger.Info("replica state reporter started")
+}
+
+// report records the replication state into metrics, then puts it into state repo.
+func (r *ReplicaStateReporter) report() {
+	peers := r.getter.ReplicaState()
+	for idx := range peers {
+		recordReplicaState(&peers[idx])
+	}
+	data := encoding.JSONMarshal(&models.NodeReplicaState{
+		NodeID:     r.node.ID,
+		ReportTime: timeutil.Now(),
+		Peers:      peers,
+	})
+	ctx, cancel := context.WithTimeout(r.ctx, 5*time.Second)
+	defer cancel()
+	if err := r.repo.Put(ctx, constants.GetReplicaStatePath(strconv.Itoa(int(r.node.ID))), data); err != nil {
+		replicaStateReportFailures.Incr()
+		r.logger.Warn("report replica state err", logger.Error(err))
+	}
+}
+
+// recordReplicaState records the replication state of replica peer into metrics.
+func recordReplicaState(peer *models.ReplicaPeerState) {
+	tags := []string{
+		peer.Database,
+		peer.ShardID.String(),
+		strconv.Itoa(int(peer.Leader)),
+		strconv.Itoa(int(peer.Follower)),
+	}
+	replicaAppendIndexVec.WithTagValues(tags...).Update(float64(peer.AppendIndex))
+	replicaAckIndexVec.WithTagValues(tags...).Update(float64(peer.AckIndex))
+	replicaLagVec.WithTagValues(tags...).Update(float64(peer.Lag))
+	replicaLagBytesVec.WithTagValues(tags...).Update(float64(peer.LagBytes))
+	replicaLastReplicaTimeVec.WithTagValues(tags...).Update(float64(peer.LastReplicaTime))
+	ready := 0.0
+	if peer.Ready {
+		ready = 1
+	}
+	replicaReadyVec.WithTagValues(tags...).Update(ready)
+}
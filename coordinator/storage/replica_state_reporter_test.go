@@ -0,0 +1,89 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package storage
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/state"
+)
+
+func TestReplicaStateReporter_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		replicaStateReportInterval = 30 * time.Second
+		ctrl.Finish()
+	}()
+	replicaStateReportInterval = 10 * time.Millisecond
+
+	repo := state.NewMockRepository(ctrl)
+	getter := NewMockReplicaStateGetter(ctrl)
+	ctx, cancel := context.WithCancel(context.TODO())
+	reporter := NewReplicaStateReporter(ctx, &models.StatefulNode{ID: 1}, repo, getter)
+	getter.EXPECT().ReplicaState().Return(nil).AnyTimes()
+	repo.EXPECT().Put(gomock.Any(), constants.GetReplicaStatePath("1"), gomock.Any()).Return(nil).AnyTimes()
+	reporter.Run()
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	time.Sleep(20 * time.Millisecond)
+}
+
+func TestReplicaStateReporter_report(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := state.NewMockRepository(ctrl)
+	getter := NewMockReplicaStateGetter(ctrl)
+	reporter := NewReplicaStateReporter(context.TODO(), &models.StatefulNode{ID: 1}, repo, getter)
+	peers := []models.ReplicaPeerState{{
+		ReplicaState: models.ReplicaState{Database: "test", ShardID: 1, Leader: 1, Follower: 2},
+		AppendIndex:  10,
+		AckIndex:     5,
+		Lag:          4,
+		LagBytes:     100,
+		Ready:        true,
+	}}
+	// case 1: put err
+	getter.EXPECT().ReplicaState().Return(peers)
+	repo.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("err"))
+	reporter.report()
+	// case 2: put ok
+	getter.EXPECT().ReplicaState().Return(peers)
+	repo.EXPECT().Put(gomock.Any(), constants.GetReplicaStatePath("1"), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, data []byte) error {
+			nodeState := models.NodeReplicaState{}
+			assert.NoError(t, encoding.JSONUnmarshal(data, &nodeState))
+			assert.Equal(t, models.NodeID(1), nodeState.NodeID)
+			assert.NotZero(t, nodeState.ReportTime)
+			assert.Equal(t, peers, nodeState.Peers)
+			return nil
+		})
+	reporter.report()
+	tags := []string{"test", "1", "1", "2"}
+	assert.Equal(t, float64(4), replicaLagVec.WithTagValues(tags...).Get())
+	assert.Equal(t, float64(100), replicaLagBytesVec.WithTagValues(tags...).Get())
+	assert.Equal(t, float64(1), replicaReadyVec.WithTagValues(tags...).Get())
+}
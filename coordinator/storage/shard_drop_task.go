@@ -0,0 +1,74 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/coordinator/task"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/tsdb"
+)
+
+// shardDropProcessor represents drop shards(write ahead log/data) of database in storage node,
+// such as the replica of shard is moved to other node.
+type shardDropProcessor struct {
+	engine     tsdb.Engine
+	walDropper WriteAheadLogDropper
+	logger     *logger.Logger
+}
+
+// newShardDropProcessor returns shard drop processor instance
+func newShardDropProcessor(engine tsdb.Engine, walDropper WriteAheadLogDropper) task.Processor {
+	return &shardDropProcessor{
+		engine:     engine,
+		walDropper: walDropper,
+		logger:     logger.GetLogger("coordinator", "StorageDropShardProcessor"),
+	}
+}
+
+func (p *shardDropProcessor) Kind() task.Kind             { return constants.DropShard }
+func (p *shardDropProcessor) RetryCount() int             { return 0 }
+func (p *shardDropProcessor) RetryBackOff() time.Duration { return 0 }
+func (p *shardDropProcessor) Concurrency() int            { return 1 }
+
+// Process stops replication by removing write ahead log of shard first, then drops the data of shard.
+func (p *shardDropProcessor) Process(_ context.Context, task task.Task) error {
+	param := models.ShardDropTask{}
+	if err := encoding.JSONUnmarshal(task.Params, &param); err != nil {
+		return err
+	}
+	db, ok := p.engine.GetDatabase(param.DatabaseName)
+	for _, shardID := range param.ShardIDs {
+		if err := p.walDropper.DropPartition(param.DatabaseName, shardID); err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		if err := db.DropShard(shardID); err != nil {
+			return err
+		}
+	}
+	p.logger.Info("process drop shard task successfully",
+		logger.String("params", string(task.Params)))
+	return nil
+}
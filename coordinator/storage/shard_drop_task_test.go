@@ -0,0 +1,72 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package storage
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/coordinator/task"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/tsdb"
+)
+
+func TestShardDropProcessor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	engine := tsdb.NewMockEngine(ctrl)
+	walDropper := NewMockWriteAheadLogDropper(ctrl)
+	processor := newShardDropProcessor(engine, walDropper)
+	assert.Equal(t, 1, processor.Concurrency())
+	assert.Equal(t, time.Duration(0), processor.RetryBackOff())
+	assert.Equal(t, 0, processor.RetryCount())
+	assert.Equal(t, constants.DropShard, processor.Kind())
+
+	// case 1: unmarshal param err
+	err := processor.Process(context.TODO(), task.Task{Params: []byte{1, 1, 1}})
+	assert.Error(t, err)
+	param := encoding.JSONMarshal(&models.ShardDropTask{DatabaseName: "test", ShardIDs: []models.ShardID{1}})
+	// case 2: drop write ahead log err
+	engine.EXPECT().GetDatabase("test").Return(nil, false)
+	walDropper.EXPECT().DropPartition("test", models.ShardID(1)).Return(fmt.Errorf("err"))
+	err = processor.Process(context.TODO(), task.Task{Params: param})
+	assert.Error(t, err)
+	// case 3: database not exist, only drop write ahead log
+	engine.EXPECT().GetDatabase("test").Return(nil, false)
+	walDropper.EXPECT().DropPartition("test", models.ShardID(1)).Return(nil)
+	err = processor.Process(context.TODO(), task.Task{Params: param})
+	assert.NoError(t, err)
+	// case 4: drop shard err
+	db := tsdb.NewMockDatabase(ctrl)
+	engine.EXPECT().GetDatabase("test").Return(db, true).AnyTimes()
+	walDropper.EXPECT().DropPartition("test", models.ShardID(1)).Return(nil).AnyTimes()
+	db.EXPECT().DropShard(models.ShardID(1)).Return(fmt.Errorf("err"))
+	err = processor.Process(context.TODO(), task.Task{Params: param})
+	assert.Error(t, err)
+	// case 5: drop shard successfully
+	db.EXPECT().DropShard(models.ShardID(1)).Return(nil)
+	err = processor.Process(context.TODO(), task.Task{Params: param})
+	assert.NoError(t, err)
+}
@@ -43,10 +43,18 @@ func NewTaskExecutor(ctx context.Context,
 	node *models.StatefulNode,
 	repo state.Repository,
 	engine tsdb.Engine,
+	walDropper WriteAheadLogDropper,
+	walChecker WriteAheadLogChecker,
 ) *TaskExecutor {
 	executor := task.NewExecutor(ctx, node, repo)
 	// register task processor
 	executor.Register(newDatabaseFlushProcessor(engine))
+	executor.Register(newDatabaseBackupProcessor(engine))
+	executor.Register(newDatabaseRestoreProcessor(engine))
+	executor.Register(newIndexRebuildProcessor(engine))
+	executor.Register(newDatabaseDropProcessor(engine, walDropper))
+	executor.Register(newShardDropProcessor(engine, walDropper))
+	executor.Register(newNodeDrainProcessor(walChecker))
 	return &TaskExecutor{
 		ctx:      ctx,
 		repo:     repo,
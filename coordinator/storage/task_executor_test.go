@@ -38,7 +38,7 @@ func TestTaskExecutor(t *testing.T) {
 	repo := state.NewMockRepository(ctrl)
 	exec := NewTaskExecutor(context.TODO(), &models.StatefulNode{
 		StatelessNode: models.StatelessNode{HostIP: "1.1.1.1", GRPCPort: 5000},
-	}, repo, engine)
+	}, repo, engine, NewMockWriteAheadLogDropper(ctrl), NewMockWriteAheadLogChecker(ctrl))
 	assert.NotNil(t, exec)
 
 	repo.EXPECT().WatchPrefix(gomock.Any(), gomock.Any(), true).Return(nil)
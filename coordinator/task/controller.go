@@ -84,6 +84,8 @@ type ControllerTaskParam struct {
 type Controller interface {
 	// Submit submits a task with params
 	Submit(kind Kind, name string, params []ControllerTaskParam) error
+	// GetState returns the state of tasks submitted by kind and name
+	GetState(kind Kind, name string) (State, error)
 	// Close closes controller, then releases the resource
 	Close() error
 	// taskKey returns the key of task
@@ -148,6 +150,20 @@ func (c *controller) Submit(kind Kind, name string, params []ControllerTaskParam
 	return nil
 }
 
+// GetState returns the state of tasks submitted by kind and name,
+// StateDoneOK/StateDoneErr means all tasks are executed by related nodes.
+func (c *controller) GetState(kind Kind, name string) (State, error) {
+	data, err := c.repo.Get(c.ctx, c.statusKey(kind, name))
+	if err != nil {
+		return StateCreated, err
+	}
+	grp := groupedTasks{}
+	if err := encoding.JSONUnmarshal(data, &grp); err != nil {
+		return StateCreated, err
+	}
+	return grp.State, nil
+}
+
 // Close shutdowns task controller
 func (c *controller) Close() error {
 	if atomic.CompareAndSwapInt32(&c.closed, 0, 1) {
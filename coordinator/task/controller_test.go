@@ -70,6 +70,18 @@ func TestController_Submit(t *testing.T) {
 	})
 	assert.NotNil(t, err)
 
+	repo.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("err"))
+	_, err = controller.GetState(kindDummy, "wtf-1019-07-05--1")
+	assert.Error(t, err)
+	repo.EXPECT().Get(gomock.Any(), gomock.Any()).Return([]byte{1, 2, 3}, nil)
+	_, err = controller.GetState(kindDummy, "wtf-1019-07-05--1")
+	assert.Error(t, err)
+	repo.EXPECT().Get(gomock.Any(), gomock.Any()).
+		Return(encoding.JSONMarshal(&groupedTasks{State: StateDoneOK}), nil)
+	st, err := controller.GetState(kindDummy, "wtf-1019-07-05--1")
+	assert.NoError(t, err)
+	assert.Equal(t, StateDoneOK, st)
+
 	assert.Equal(t, taskCoordinatorKey+"/executor/node/kinds/k/names/name",
 		controller.taskKey("k", "name", "node"))
 	assert.Equal(t, taskCoordinatorKey+"/status/kinds/k/names/name",
@@ -21,6 +21,7 @@ import (
 	"github.com/lindb/lindb/aggregation"
 	"github.com/lindb/lindb/internal/concurrent"
 	"github.com/lindb/lindb/pkg/timeutil"
+	protoCommonV1 "github.com/lindb/lindb/proto/gen/v1/common"
 	"github.com/lindb/lindb/series"
 )
 
@@ -45,6 +46,8 @@ type StorageQueryFlow interface {
 	Reduce(tags string, it series.GroupedIterator)
 	// ReduceTagValues reduces the group by tag values.
 	ReduceTagValues(tagKeyIndex int, tagValues map[uint32]string)
+	// ReduceExemplars reduces the exemplars which link the field values to traces.
+	ReduceExemplars(exemplars []*protoCommonV1.Exemplar)
 	// Complete completes the query flow with error.
 	Complete(err error)
 }
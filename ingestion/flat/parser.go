@@ -86,7 +86,8 @@ func Parse(req *http.Request, enrichedTags tag.Tags, namespace string) (*metric.
 		flatCorruptedDataCounter.Incr()
 		return nil, err
 	}
-	if batch.Len() == 0 {
+	// no metrics in payload, if all metrics are rejected, returns batch with rejections
+	if batch.Len() == 0 && batch.RejectedCount() == 0 {
 		return nil, fmt.Errorf("empty metrics")
 	}
 	flatUnmarshalMetricCounter.Add(float64(batch.Len()))
@@ -31,6 +31,7 @@ const defaultPayloadBlock = 64 * 1024
 //
 // HasNext() bool (skip empty lines)
 // Next() []byte
+// LineNumber() int
 // Error() error
 // Reset()
 type ChunkReader struct {
@@ -40,6 +41,7 @@ type ChunkReader struct {
 	readAt       int
 	endAt        int
 	section      []byte
+	lineNumber   int
 	error        error
 }
 
@@ -61,6 +63,7 @@ func (cr *ChunkReader) Reset(r io.Reader) {
 	cr.reader = r
 	cr.readAt, cr.endAt = 0, 0
 	cr.section = nil
+	cr.lineNumber = 0
 	cr.error = nil
 }
 
@@ -80,6 +83,7 @@ func (cr *ChunkReader) HasNext() bool {
 		switch delimiterAt {
 		case 0: // empty line, move right
 			cr.readAt++
+			cr.lineNumber++
 			continue
 		case -1: // do not exist
 			cr.moveTailToHead()
@@ -97,6 +101,7 @@ func (cr *ChunkReader) HasNext() bool {
 				// got a line, but without delimiter
 				if cr.readAt < cr.endAt {
 					cr.readAt = cr.endAt
+					cr.lineNumber++
 					return true
 					// exhausted
 				} else if cr.readAt >= cr.endAt {
@@ -109,6 +114,7 @@ func (cr *ChunkReader) HasNext() bool {
 		default: // got line
 			cr.section = cr.payloadBlock[cr.readAt : cr.readAt+delimiterAt]
 			cr.readAt += delimiterAt + 1
+			cr.lineNumber++
 			return true
 		}
 	}
@@ -122,6 +128,11 @@ func (cr *ChunkReader) Next() []byte {
 	return cr.section
 }
 
+// LineNumber returns the line number of current section, starts with 1, empty lines are counted.
+func (cr *ChunkReader) LineNumber() int {
+	return cr.lineNumber
+}
+
 func (cr *ChunkReader) Error() error {
 	return cr.error
 }
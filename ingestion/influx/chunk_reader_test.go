@@ -35,16 +35,20 @@ a3,location=us-midwest temperature=100 1465839830100400200`
 func assertReadAll(t *testing.T, cr *ChunkReader) {
 	assert.True(t, cr.HasNext())
 	assert.Equal(t, "# comment", string(cr.Next()))
+	assert.Equal(t, 2, cr.LineNumber())
 	assert.Nil(t, cr.Error())
 
 	assert.True(t, cr.HasNext())
 	assert.Equal(t, "a1,location=us-midwest temperature=82 1465839830100400200", string(cr.Next()))
+	assert.Equal(t, 3, cr.LineNumber())
 
 	assert.True(t, cr.HasNext())
 	assert.Equal(t, "a2,location=us-midwest temperature=1 1465839830100400200", string(cr.Next()))
+	assert.Equal(t, 5, cr.LineNumber())
 
 	assert.True(t, cr.HasNext())
 	assert.Equal(t, "a3,location=us-midwest temperature=100 1465839830100400200", string(cr.Next()))
+	assert.Equal(t, 7, cr.LineNumber())
 
 	assert.False(t, cr.HasNext())
 	assert.NotNil(t, cr.Error())
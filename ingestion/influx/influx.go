@@ -34,9 +34,29 @@ var (
 	influxLogger = logger.GetLogger("ingestion", "InfluxDB")
 )
 
-// Parse parses influxdb line protocol data to LinDB pb prometheus.
-// https://docs.influxdata.com/influxdb/v2.0/write-data/developer-tools/api/#example-api-write-request
+var defaultParser = NewParser(DropStringField)
+
+// Parser parses influxdb line protocol data with typed fields,
+// integer/unsigned/float fields are stored as float, boolean fields are stored as 0/1,
+// string fields are handled by StringFieldPolicy.
+type Parser struct {
+	stringFieldPolicy StringFieldPolicy
+}
+
+// NewParser creates influxdb line protocol parser with string field policy.
+func NewParser(stringFieldPolicy StringFieldPolicy) *Parser {
+	return &Parser{stringFieldPolicy: stringFieldPolicy}
+}
+
+// Parse parses influxdb line protocol data to LinDB pb prometheus with default parser.
 func Parse(req *http.Request, enrichedTags tag.Tags, namespace string) (*metric.BrokerBatchRows, error) {
+	return defaultParser.Parse(req, enrichedTags, namespace)
+}
+
+// Parse parses influxdb line protocol data to LinDB pb prometheus,
+// bad lines are rejected with line number in batch, other lines of the batch are kept.
+// https://docs.influxdata.com/influxdb/v2.0/write-data/developer-tools/api/#example-api-write-request
+func (p *Parser) Parse(req *http.Request, enrichedTags tag.Tags, namespace string) (*metric.BrokerBatchRows, error) {
 	qry := req.URL.Query()
 	var reader = req.Body
 	if strings.EqualFold(req.Header.Get("Content-Encoding"), "gzip") {
@@ -69,10 +89,12 @@ func Parse(req *http.Request, enrichedTags tag.Tags, namespace string) (*metric.
 		if bytes.HasPrefix(nextLine, []byte{'#'}) {
 			continue
 		}
-		if err := parseInfluxLine(rowBuilder, nextLine, namespace, multiplier); err != nil {
+		lineNumber := cr.LineNumber()
+		if err := parseInfluxLine(rowBuilder, nextLine, namespace, multiplier, p.stringFieldPolicy); err != nil {
 			influxLogger.Warn("ingest error",
 				logger.String("line", string(nextLine)),
-				logger.Error(err))
+				logger.Error(&LineError{Line: lineNumber, Err: err}))
+			batch.Reject(lineNumber, err)
 			droppedMetricsCounter.Incr()
 			continue
 		}
@@ -82,7 +104,7 @@ func Parse(req *http.Request, enrichedTags tag.Tags, namespace string) (*metric.
 				return nil, err
 			}
 		}
-		if err := batch.TryAppend(rowBuilder.BuildTo); err != nil {
+		if err := batch.TryAppendLine(lineNumber, rowBuilder.BuildTo); err != nil {
 			droppedMetricsCounter.Incr()
 			continue
 		}
@@ -97,15 +119,16 @@ func Parse(req *http.Request, enrichedTags tag.Tags, namespace string) (*metric.
 }
 
 // getPrecisionMultiplier returns a multiplier for the precision specified.
+// both influxdb 1.x(n/u/ms/s/m/h) and 2.x(ns/us/ms/s) precisions are supported.
 // https://docs.influxdata.com/influxdb/v2.0/api/#operation/PostWrite
 // timestamp in lindb is milliseconds
 // when multiplier > 0, real_timestamp = timestamp * multiplier
 // when multiplier < 0, real_timestamp = timestamp / (-1 * multiplier)
 func getPrecisionMultiplier(precision string) int64 {
 	switch strings.ToLower(precision) {
-	case "ns":
+	case "ns", "n":
 		return -1e6
-	case "us":
+	case "us", "u", "µs", "µ":
 		return -1e3
 	case "ms":
 		return 1
@@ -93,9 +93,26 @@ measurement value=12 1439587925
 
 func Test_getPrecisionMultiplier(t *testing.T) {
 	assert.Equal(t, int64(-1000000), getPrecisionMultiplier("ns"))
+	assert.Equal(t, int64(-1000000), getPrecisionMultiplier("n"))
+	assert.Equal(t, int64(-1000), getPrecisionMultiplier("u"))
 	assert.Equal(t, int64(-1000), getPrecisionMultiplier("us"))
 	assert.Equal(t, int64(1), getPrecisionMultiplier("ms"))
 	assert.Equal(t, int64(1000), getPrecisionMultiplier("s"))
 	assert.Equal(t, int64(60000), getPrecisionMultiplier("m"))
 	assert.Equal(t, int64(3600000), getPrecisionMultiplier("h"))
 }
+
+func Test_Parser_StringFieldAsTag(t *testing.T) {
+	const body = `
+measurement,foo=bar value=12,msg="hello world" 1439587925
+measurement msg="only string" 1439587925
+`
+	req, err := http.NewRequest(http.MethodPut, "?precision=s", strings.NewReader(body))
+	assert.NoError(t, err)
+	batch, err := NewParser(StringFieldAsTag).Parse(req, nil, "ns")
+	assert.NoError(t, err)
+	assert.Len(t, batch.Rows(), 1)
+	m := batch.Rows()[0].Metric()
+	assert.Equal(t, int64(1439587925000), m.Timestamp())
+	assert.Equal(t, 2, m.KeyValuesLength())
+}
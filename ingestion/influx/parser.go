@@ -20,7 +20,10 @@ package influx
 import (
 	"bytes"
 	"errors"
+	"fmt"
+	"math"
 	"strconv"
+	"strings"
 
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/internal/linmetric"
@@ -29,6 +32,7 @@ import (
 	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/proto/gen/v1/flatMetricsV1"
 	"github.com/lindb/lindb/series/metric"
+	"github.com/lindb/lindb/series/tag"
 )
 
 var (
@@ -37,8 +41,58 @@ var (
 	ErrBadTags           = errors.New("bad_tags")
 	ErrBadFields         = errors.New("bad_fields")
 	ErrBadTimestamp      = errors.New("bad_timestamp")
+	// errStringFieldDropped represents a string field is dropped by DropStringField policy.
+	errStringFieldDropped = errors.New("string_field_dropped")
 )
 
+// StringFieldPolicy defines how string fields of line protocol are handled,
+// LinDB only stores float values in fields.
+type StringFieldPolicy int
+
+const (
+	// DropStringField drops string fields, other fields of the line are kept.
+	DropStringField StringFieldPolicy = iota
+	// StringFieldAsTag stores string field as a tag, field name as tag key, string as tag value,
+	// string field is dropped if the tag key exists.
+	StringFieldAsTag
+)
+
+// ParseStringFieldPolicy returns the string field policy by name, drop is default.
+func ParseStringFieldPolicy(policy string) StringFieldPolicy {
+	switch strings.ToLower(policy) {
+	case "tag":
+		return StringFieldAsTag
+	default:
+		return DropStringField
+	}
+}
+
+// String returns the name of string field policy.
+func (p StringFieldPolicy) String() string {
+	switch p {
+	case StringFieldAsTag:
+		return "tag"
+	default:
+		return "drop"
+	}
+}
+
+// LineError represents the parse error of a single line in the payload.
+type LineError struct {
+	Line int   // line number, starts with 1
+	Err  error // parse error
+}
+
+// Error returns the error message with line number.
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Err)
+}
+
+// Unwrap returns the original parse error.
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
 var (
 	influxIngestionScope       = linmetric.NewScope("lindb.ingestion.influx")
 	influxCorruptedDataCounter = influxIngestionScope.NewCounter("data_corrupted_count")
@@ -57,6 +111,7 @@ func parseInfluxLine(
 	content []byte,
 	namespace string,
 	multiplier int64,
+	stringFieldPolicy StringFieldPolicy,
 ) error {
 	// skip comment line
 	if bytes.HasPrefix(content, []byte{'#'}) {
@@ -68,7 +123,7 @@ func parseInfluxLine(
 	// parse metric-name
 	metricEndAt, err := scanMetricName(content, escaped)
 	if err != nil {
-		return nil
+		return err
 	}
 	builder.AddMetricName(unescapeMetricName(content[:metricEndAt]))
 
@@ -88,15 +143,27 @@ func parseInfluxLine(
 	}
 
 	// parse fields
-	fieldsEndAt, err := scanFieldLine(content, tagsEndAt+1, escaped)
+	fieldsEndAt, err := scanFieldLine(content, tagsEndAt+1)
 	if err != nil {
 		return err
 	}
-	fields, err := parseFields(content, tagsEndAt+1, fieldsEndAt, escaped)
+	fields, stringTags, err := parseFields(content, tagsEndAt+1, fieldsEndAt, stringFieldPolicy)
 	// return error only if fields are empty, just drop fields not supported in lindb like string.
 	if err != nil && len(fields) == 0 {
 		return err
 	}
+	for idx := range stringTags {
+		tagKey := string(stringTags[idx].Key)
+		if _, ok := tags[tagKey]; ok {
+			// tag key exists(tag or string field converted before), drop string field
+			droppedFieldsCounter.Incr()
+			continue
+		}
+		tags[tagKey] = string(stringTags[idx].Value)
+		if err := builder.AddTag(stringTags[idx].Key, stringTags[idx].Value); err != nil {
+			return err
+		}
+	}
 	for idx := range fields {
 		if err := builder.AddSimpleField(fields[idx].Name, fields[idx].Type, fields[idx].Value); err != nil {
 			return err
@@ -143,16 +210,39 @@ func walkToUnescapedChar(buf []byte, char byte, startAt int, isEscaped bool) int
 	}
 }
 
+// walkToUnquotedChar returns first position of given char before endAt,
+// which is neither escaped nor inside a double-quoted string field value.
+// a=1,b="x,y" c -> 3
+// a="x y" c -> 7
+func walkToUnquotedChar(buf []byte, char byte, startAt, endAt int) int {
+	quoted := false
+	for i := startAt; i < endAt; i++ {
+		switch buf[i] {
+		case '\\':
+			// skip escaped char
+			i++
+		case '"':
+			quoted = !quoted
+		case char:
+			if !quoted {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
 // scanMetricName examines the metric-name part of a Point, and returns the end position
 func scanMetricName(buf []byte, isEscaped bool) (endAt int, err error) {
 	// unescaped comma;
 	commaAt := walkToUnescapedChar(buf, ',', 0, isEscaped)
+	whiteSpaceAt := walkToUnescapedChar(buf, ' ', 0, isEscaped)
 	switch {
 	case commaAt == 0:
 		return -1, ErrMissingMetricName
-	case commaAt < 0:
+	case commaAt < 0 || (whiteSpaceAt >= 0 && whiteSpaceAt < commaAt):
 		// cpu value=1, no comma
-		whiteSpaceAt := walkToUnescapedChar(buf, ' ', 0, isEscaped)
+		// cpu value=1,count=2, comma in fields
 		switch {
 		case whiteSpaceAt > 0:
 			return whiteSpaceAt, nil
@@ -218,9 +308,10 @@ WalkBeforeComma:
 	}
 }
 
-// scanFieldLine returns the end position of fields
-func scanFieldLine(buf []byte, startAt int, isEscaped bool) (endAt int, err error) {
-	endAt = walkToUnescapedChar(buf, ' ', startAt, isEscaped)
+// scanFieldLine returns the end position of fields,
+// whitespace in string field value does not end the field line.
+func scanFieldLine(buf []byte, startAt int) (endAt int, err error) {
+	endAt = walkToUnquotedChar(buf, ' ', startAt, len(buf))
 	switch {
 	case endAt < 0:
 		// case: no timestamp
@@ -243,25 +334,26 @@ type flatSimpleField struct {
 	Value float64
 }
 
+// parseFields parses the field line, returns the float fields and string fields converted to tags.
 func parseFields(
 	buf []byte,
 	startAt int,
 	endAt int,
-	isEscaped bool,
-) (fields []flatSimpleField, err error) {
+	stringFieldPolicy StringFieldPolicy,
+) (fields []flatSimpleField, stringTags tag.Tags, err error) {
 WalkBeforeComma:
 	{
 		if startAt >= endAt-1 {
 			if len(fields) == 0 {
-				return fields, ErrBadFields
+				return fields, stringTags, ErrBadFields
 			}
-			return fields, nil
+			return fields, stringTags, nil
 		}
-		commaAt := walkToUnescapedChar(buf, ',', startAt, isEscaped)
+		commaAt := walkToUnquotedChar(buf, ',', startAt, endAt)
 		// '=' does not exist
-		equalAt := walkToUnescapedChar(buf, '=', startAt, isEscaped)
+		equalAt := walkToUnescapedChar(buf, '=', startAt, true)
 		if equalAt <= startAt || equalAt+1 >= endAt {
-			return fields, ErrBadFields
+			return fields, stringTags, ErrBadFields
 		}
 		boundaryAt := endAt
 		if commaAt > 0 && commaAt <= endAt {
@@ -269,13 +361,25 @@ WalkBeforeComma:
 		}
 		// move to next field pair
 		if equalAt+1 >= boundaryAt {
-			return fields, ErrBadFields
+			return fields, stringTags, ErrBadFields
+		}
+		key, value := buf[startAt:equalAt], buf[equalAt+1:boundaryAt]
+		if isStringFieldValue(value) {
+			var stringTag *tag.Tag
+			stringTag, err = parseStringField(key, value, stringFieldPolicy)
+			if err == nil {
+				stringTags = append(stringTags, *stringTag)
+			} else {
+				droppedFieldsCounter.Incr()
+			}
+			startAt = boundaryAt + 1
+			goto WalkBeforeComma
 		}
 		// move to next field pair
 		var (
 			parsedFields []flatSimpleField
 		)
-		parsedFields, err = parseField(buf[startAt:equalAt], buf[equalAt+1:boundaryAt])
+		parsedFields, err = parseField(key, value)
 		if err == nil {
 			fields = append(fields, parsedFields...)
 		} else {
@@ -299,12 +403,18 @@ func parseField(key, value []byte) ([]flatSimpleField, error) {
 	}
 	tail := value[len(value)-1]
 	switch tail {
-	case 'i', 'I', 'u', 'U': // is int or unsigned
+	case 'i', 'I': // is int, stored as float
 		v, err := strconv.ParseInt(strutil.ByteSlice2String(value[0:len(value)-1]), 10, 64)
 		if err != nil {
 			return nil, ErrBadFields
 		}
 		return toLinGaugeAndSumField(unescapedKey, float64(v)), nil
+	case 'u', 'U': // is unsigned, stored as float
+		v, err := strconv.ParseUint(strutil.ByteSlice2String(value[0:len(value)-1]), 10, 64)
+		if err != nil {
+			return nil, ErrBadFields
+		}
+		return toLinGaugeAndSumField(unescapedKey, float64(v)), nil
 	case 't', 'T': // boolean true
 		if len(value) == 1 {
 			return []flatSimpleField{{
@@ -342,7 +452,7 @@ func parseField(key, value []byte) ([]flatSimpleField, error) {
 			}}, nil
 		default:
 			v, err := strconv.ParseFloat(lf, 64)
-			if err != nil {
+			if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
 				return nil, ErrBadFields
 			}
 			return toLinGaugeAndSumField(unescapedKey, v), nil
@@ -350,6 +460,31 @@ func parseField(key, value []byte) ([]flatSimpleField, error) {
 	}
 }
 
+// isStringFieldValue checks if field value is a double-quoted string.
+func isStringFieldValue(value []byte) bool {
+	return len(value) > 0 && value[0] == '"'
+}
+
+// parseStringField parses string field, converts it into tag or drops it based on policy.
+func parseStringField(key, value []byte, stringFieldPolicy StringFieldPolicy) (*tag.Tag, error) {
+	if len(value) < 2 || value[len(value)-1] != '"' {
+		return nil, ErrBadFields
+	}
+	unescapedKey := unescapeTag(key)
+	if len(bytes.TrimSpace(unescapedKey)) == 0 {
+		return nil, ErrBadFields
+	}
+	if stringFieldPolicy != StringFieldAsTag {
+		return nil, errStringFieldDropped
+	}
+	unescapedValue := unescapeStringField(value[1 : len(value)-1])
+	if len(unescapedValue) == 0 {
+		return nil, ErrBadFields
+	}
+	t := tag.NewTag(unescapedKey, unescapedValue)
+	return &t, nil
+}
+
 func toLinGaugeAndSumField(key []byte, value float64) []flatSimpleField {
 	switch {
 	case bytes.HasSuffix(key, []byte("gauge")):
@@ -459,6 +594,21 @@ func unescapeMetricName(in []byte) []byte {
 	return in
 }
 
+// unescapeStringField unescapes double quote and backslash in string field value.
+func unescapeStringField(in []byte) []byte {
+	if bytes.IndexByte(in, '\\') == -1 {
+		return in
+	}
+	out := make([]byte, 0, len(in))
+	for i := 0; i < len(in); i++ {
+		if in[i] == '\\' && i+1 < len(in) && (in[i+1] == '"' || in[i+1] == '\\') {
+			i++
+		}
+		out = append(out, in[i])
+	}
+	return out
+}
+
 func unescapeTag(in []byte) []byte {
 	if bytes.IndexByte(in, '\\') == -1 {
 		return in
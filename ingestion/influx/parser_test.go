@@ -18,6 +18,7 @@
 package influx
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -40,7 +41,7 @@ func Test_tooManyTags(t *testing.T) {
 		tagPair = append(tagPair, fmt.Sprintf("%s=%s", v, v))
 	}
 	line := fmt.Sprintf("mmm,%s x=1,y=2 1465839830100400200", strings.Join(tagPair, ","))
-	err := parseInfluxLine(builder, []byte(line), "ns", -1e6)
+	err := parseInfluxLine(builder, []byte(line), "ns", -1e6, DropStringField)
 	assert.NoError(t, err)
 	_, err = builder.Build()
 	assert.Error(t, err)
@@ -50,7 +51,7 @@ func Test_noTags_noTimestamp(t *testing.T) {
 	builder, releaseFunc := metric.NewRowBuilder()
 	defer releaseFunc(builder)
 
-	err := parseInfluxLine(builder, []byte("cpu value=1"), "ns2", -1e6)
+	err := parseInfluxLine(builder, []byte("cpu value=1"), "ns2", -1e6, DropStringField)
 	assert.Nil(t, err)
 	var row metric.BrokerRow
 	err = builder.BuildTo(&row)
@@ -75,7 +76,7 @@ func Test_badTimestamp(t *testing.T) {
 	}
 	for _, line := range lines {
 		builder.Reset()
-		err := parseInfluxLine(builder, []byte(line), "ns3", 1)
+		err := parseInfluxLine(builder, []byte(line), "ns3", 1, DropStringField)
 		assert.Equal(t, ErrBadTimestamp, err)
 	}
 }
@@ -100,7 +101,7 @@ func Test_tags(t *testing.T) {
 	}
 	for _, example := range examples {
 		builder.Reset()
-		err := parseInfluxLine(builder, []byte(example.Line), "ns", 1e6)
+		err := parseInfluxLine(builder, []byte(example.Line), "ns", 1e6, DropStringField)
 		assert.Nil(t, err)
 		var br metric.BrokerRow
 		assert.NoError(t, builder.BuildTo(&br))
@@ -129,7 +130,7 @@ func Test_InvalidLine(t *testing.T) {
 	}
 	for _, example := range examples {
 		builder.Reset()
-		err := parseInfluxLine(builder, []byte(example.Line), "ns", 1e6)
+		err := parseInfluxLine(builder, []byte(example.Line), "ns", 1e6, DropStringField)
 		if err == nil {
 			_, err = builder.Build()
 		}
@@ -147,6 +148,7 @@ func Test_metricName(t *testing.T) {
 	}{
 		{`cpu,tag0=v0 value=1 111`, "cpu"},
 		{`cpu value=1 222`, "cpu"},
+		{`cpu value=1,count=2 222`, "cpu"},
 		{`cpu\  value=1`, "cpu "},
 		{`cpu\ a,    tag0=v0 value=1`, "cpu a"},
 		{`cpu\,a, tag0=v0 value=1`, "cpu,a"},
@@ -155,7 +157,7 @@ func Test_metricName(t *testing.T) {
 	}
 	for _, example := range examples {
 		builder.Reset()
-		err := parseInfluxLine(builder, []byte(example.Line), "ns", 1e6)
+		err := parseInfluxLine(builder, []byte(example.Line), "ns", 1e6, DropStringField)
 		assert.NoError(t, err)
 		var row metric.BrokerRow
 		assert.NoError(t, builder.BuildTo(&row))
@@ -184,7 +186,7 @@ func Test_missingTagValues(t *testing.T) {
 	}
 	for _, example := range examples {
 		builder.Reset()
-		err := parseInfluxLine(builder, []byte(example.Line), "ns", -1e6)
+		err := parseInfluxLine(builder, []byte(example.Line), "ns", -1e6, DropStringField)
 		assert.Equal(t, example.Err, err)
 	}
 }
@@ -205,7 +207,7 @@ func Test_missingFieldNames(t *testing.T) {
 	}
 	for _, example := range examples {
 		builder.Reset()
-		err := parseInfluxLine(builder, []byte(example.Line), "ns", 1e6)
+		err := parseInfluxLine(builder, []byte(example.Line), "ns", 1e6, DropStringField)
 		assert.Equal(t, example.Err, err)
 		if example.FieldCount == 0 {
 			assert.Error(t, err)
@@ -401,7 +403,7 @@ func Test_parseUnescapedMetric(t *testing.T) {
 
 	for _, example := range examples {
 		builder.Reset()
-		err := parseInfluxLine(builder, []byte(example.Line), "ns", -1e6)
+		err := parseInfluxLine(builder, []byte(example.Line), "ns", -1e6, DropStringField)
 		assert.Nil(t, err)
 		var row metric.BrokerRow
 		assert.NoError(t, builder.BuildTo(&row))
@@ -448,7 +450,7 @@ func Test_parseBadFields(t *testing.T) {
 	defer releaseFunc(builder)
 	for _, line := range lines {
 		builder.Reset()
-		err := parseInfluxLine(builder, []byte(line), "ns", 1e6)
+		err := parseInfluxLine(builder, []byte(line), "ns", 1e6, DropStringField)
 		assert.Equal(t, ErrBadFields, err)
 	}
 }
@@ -463,3 +465,122 @@ func Test_parseTimestamp(t *testing.T) {
 	assert.InDelta(t, timestamp, timestamp2MilliSeconds(timestamp/1000/3600), float64(1000*3600))
 
 }
+
+func Test_parseTypedFields(t *testing.T) {
+	builder, releaseFunc := metric.NewRowBuilder()
+	defer releaseFunc(builder)
+
+	examples := []struct {
+		Line   string
+		Policy StringFieldPolicy
+		Tags   map[string]string
+		Fields []flatSimpleField
+	}{
+		// unsigned
+		{`cpu value_gauge=18446744073709551615u`,
+			DropStringField,
+			map[string]string{},
+			[]flatSimpleField{
+				{Name: []byte("value_gauge"), Type: flatMetricsV1.SimpleFieldTypeGauge, Value: 18446744073709551615},
+			},
+		},
+		// integer, boolean and string fields
+		{`cpu count_sum=-3i,up=TRUE,msg="a b,c=d" 1465839830100400200`,
+			DropStringField,
+			map[string]string{},
+			[]flatSimpleField{
+				{Name: []byte("count_sum"), Type: flatMetricsV1.SimpleFieldTypeDeltaSum, Value: -3},
+				{Name: []byte("up"), Type: flatMetricsV1.SimpleFieldTypeGauge, Value: 1},
+			},
+		},
+		// string field as tag
+		{`cpu,host=a up=f,msg="a \"b\",c=d",empty="" 1465839830100400200`,
+			StringFieldAsTag,
+			map[string]string{"host": "a", "msg": `a "b",c=d`},
+			[]flatSimpleField{
+				{Name: []byte("up"), Type: flatMetricsV1.SimpleFieldTypeGauge, Value: 0},
+			},
+		},
+	}
+	for _, example := range examples {
+		builder.Reset()
+		err := parseInfluxLine(builder, []byte(example.Line), "ns", -1e6, example.Policy)
+		assert.NoError(t, err, example.Line)
+		var row metric.BrokerRow
+		assert.NoError(t, builder.BuildTo(&row))
+		m := row.Metric()
+		var mp = make(map[string]string)
+		var kv flatMetricsV1.KeyValue
+		for i := 0; i < m.KeyValuesLength(); i++ {
+			m.KeyValues(&kv, i)
+			mp[string(kv.Key())] = string(kv.Value())
+		}
+		assert.Equal(t, example.Tags, mp, example.Line)
+		var realFields []flatSimpleField
+		var sf flatMetricsV1.SimpleField
+		for i := 0; i < m.SimpleFieldsLength(); i++ {
+			m.SimpleFields(&sf, i)
+			realFields = append(realFields, flatSimpleField{Name: sf.Name(), Type: sf.Type(), Value: sf.Value()})
+		}
+		assert.EqualValues(t, example.Fields, realFields, example.Line)
+	}
+
+	// bad typed fields
+	lines := []string{
+		`cpu value=-1u`,
+		`cpu value=1.5i`,
+		`cpu value=NaN`,
+		`cpu value=Inf`,
+		`cpu value="abc`,
+		`cpu value="abc"`,
+	}
+	for _, line := range lines {
+		builder.Reset()
+		err := parseInfluxLine(builder, []byte(line), "ns", 1e6, StringFieldAsTag)
+		assert.Equal(t, ErrBadFields, err, line)
+	}
+}
+
+func Test_StringFieldPolicy(t *testing.T) {
+	assert.Equal(t, StringFieldAsTag, ParseStringFieldPolicy("TAG"))
+	assert.Equal(t, DropStringField, ParseStringFieldPolicy("drop"))
+	assert.Equal(t, DropStringField, ParseStringFieldPolicy("unknown"))
+	assert.Equal(t, "tag", StringFieldAsTag.String())
+	assert.Equal(t, "drop", DropStringField.String())
+
+	err := &LineError{Line: 3, Err: ErrBadFields}
+	assert.Equal(t, "line 3: bad_fields", err.Error())
+	assert.True(t, errors.Is(err, ErrBadFields))
+}
+
+func Test_stringFieldAsTag_collision(t *testing.T) {
+	builder, releaseFunc := metric.NewRowBuilder()
+	defer releaseFunc(builder)
+
+	examples := []struct {
+		Line string
+		Tags map[string]string
+	}{
+		{`cpu,host=a value=1,msg="hello"`, map[string]string{"host": "a", "msg": "hello"}},
+		// string field collides with tag key
+		{`cpu,host=a value=1,host="b"`, map[string]string{"host": "a"}},
+		// string field collides with string field converted before
+		{`cpu,host=a value=1,msg="hello",msg="world"`, map[string]string{"host": "a", "msg": "hello"}},
+	}
+	for _, example := range examples {
+		builder.Reset()
+		err := parseInfluxLine(builder, []byte(example.Line), "ns", 1e6, StringFieldAsTag)
+		assert.NoError(t, err)
+		var br metric.BrokerRow
+		assert.NoError(t, builder.BuildTo(&br))
+		m := br.Metric()
+		assert.Equal(t, len(example.Tags), m.KeyValuesLength())
+		var mp = make(map[string]string)
+		var kv flatMetricsV1.KeyValue
+		for i := 0; i < m.KeyValuesLength(); i++ {
+			m.KeyValues(&kv, i)
+			mp[string(kv.Key())] = string(kv.Value())
+		}
+		assert.EqualValues(t, example.Tags, mp)
+	}
+}
@@ -0,0 +1,322 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package limit
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/cespare/xxhash/v2"
+
+	"github.com/lindb/lindb/internal/linmetric"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/fasttime"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/proto/gen/v1/flatMetricsV1"
+	"github.com/lindb/lindb/series/metric"
+)
+
+// ErrRateLimited represents the write request exceeds the write limits.
+var ErrRateLimited = errors.New("write rate limit exceeded")
+
+const (
+	// maxKnownSeries is the max number of series hashes tracked in one generation for each database.
+	maxKnownSeries = 1 << 20
+	// knownSeriesTTL is the duration of one generation, series not seen in
+	// last two generations are treated as new.
+	knownSeriesTTL = timeutil.OneHour
+	// knownSeriesWarmUp is the duration after limiter created in which new series limit is not enforced,
+	// because all series are unknown after broker restarted.
+	knownSeriesWarmUp = 5 * timeutil.OneMinute
+)
+
+var (
+	limitScope              = linmetric.NewScope("lindb.ingestion.limit")
+	throttledRequestCounter = limitScope.NewCounterVec("throttled_requests", "db", "ns", "type")
+	throttledRowCounter     = limitScope.NewCounterVec("throttled_rows", "db")
+	allowedRowCounter       = limitScope.NewCounterVec("allowed_rows", "db")
+	allowedBytesCounter     = limitScope.NewCounterVec("allowed_bytes", "db")
+	newSeriesCounter        = limitScope.NewCounterVec("new_series", "db")
+)
+
+// for testing
+var nowFunc = fasttime.UnixMilliseconds
+
+// usage represents the resource usage of a write request.
+type usage struct {
+	rows      int64
+	bytes     int64
+	newSeries int64
+}
+
+// window represents a fixed time window counter.
+type window struct {
+	limit    int64 // no limit if limit <= 0
+	interval int64 // window size in milliseconds
+	start    int64
+	used     int64
+}
+
+// exceeded checks if the window overflows after acquiring n.
+func (w *window) exceeded(now, n int64) bool {
+	if w.limit <= 0 {
+		return false
+	}
+	used := w.used
+	if now-w.start >= w.interval {
+		used = 0
+	}
+	return used+n > w.limit
+}
+
+// acquire adds n to the window, rolls the window if expired.
+func (w *window) acquire(now, n int64) {
+	if w.limit <= 0 {
+		return
+	}
+	if now-w.start >= w.interval {
+		w.start = now - now%w.interval
+		w.used = 0
+	}
+	w.used += n
+}
+
+// quota represents the rows/bytes/new series windows of database or namespace.
+type quota struct {
+	rows      window
+	bytes     window
+	newSeries window
+}
+
+func newQuota(limits models.WriteLimits) *quota {
+	return &quota{
+		rows:      window{limit: limits.RowsPerSecond, interval: timeutil.OneSecond},
+		bytes:     window{limit: limits.BytesPerSecond, interval: timeutil.OneSecond},
+		newSeries: window{limit: limits.NewSeriesPerMinute, interval: timeutil.OneMinute},
+	}
+}
+
+// exceeded returns the limit type which is exceeded, returns empty if all limits are satisfied.
+func (q *quota) exceeded(now int64, u *usage) string {
+	switch {
+	case q.rows.exceeded(now, u.rows):
+		return "rows"
+	case q.bytes.exceeded(now, u.bytes):
+		return "bytes"
+	case q.newSeries.exceeded(now, u.newSeries):
+		return "new_series"
+	default:
+		return ""
+	}
+}
+
+func (q *quota) acquire(now int64, u *usage) {
+	q.rows.acquire(now, u.rows)
+	q.bytes.acquire(now, u.bytes)
+	q.newSeries.acquire(now, u.newSeries)
+}
+
+// seriesTracker tracks the series hashes seen recently with two generations,
+// which bounds the memory by time instead of dropping all known series at once.
+type seriesTracker struct {
+	current   map[uint64]struct{}
+	previous  map[uint64]struct{}
+	rotateAt  int64
+	warmUntil int64
+}
+
+func newSeriesTracker(now int64) *seriesTracker {
+	return &seriesTracker{
+		current:   make(map[uint64]struct{}),
+		previous:  make(map[uint64]struct{}),
+		rotateAt:  now + knownSeriesTTL,
+		warmUntil: now + knownSeriesWarmUp,
+	}
+}
+
+// track marks the series as seen, returns true if series is new.
+func (t *seriesTracker) track(hash uint64) bool {
+	if _, ok := t.current[hash]; ok {
+		return false
+	}
+	t.current[hash] = struct{}{}
+	if _, ok := t.previous[hash]; ok {
+		delete(t.previous, hash)
+		return false
+	}
+	return true
+}
+
+// untrack removes the new series of throttled request.
+func (t *seriesTracker) untrack(hashes []uint64) {
+	for _, hash := range hashes {
+		delete(t.current, hash)
+	}
+}
+
+// warmedUp checks if the tracker has seen the active series after created.
+func (t *seriesTracker) warmedUp(now int64) bool {
+	return now >= t.warmUntil
+}
+
+// rotate drops the series not seen in last two generations.
+func (t *seriesTracker) rotate(now int64) {
+	if now < t.rotateAt && len(t.current) <= maxKnownSeries {
+		return
+	}
+	t.previous = t.current
+	t.current = make(map[uint64]struct{})
+	t.rotateAt = now + knownSeriesTTL
+}
+
+// Limiter enforces the write limits of database and its namespaces on a broker node.
+type Limiter struct {
+	database     string
+	trackSeries  bool
+	quota        *quota
+	namespaces   map[string]*quota
+	knownSeries  *seriesTracker
+	mutex        sync.Mutex
+	requestUsage map[string]*usage // namespace => usage of limited namespaces, reused under mutex
+	hashBuf      []byte
+
+	statistics struct {
+		throttledRows *linmetric.BoundCounter
+		allowedRows   *linmetric.BoundCounter
+		allowedBytes  *linmetric.BoundCounter
+		newSeries     *linmetric.BoundCounter
+	}
+}
+
+// NewLimiter creates the write limiter of database.
+func NewLimiter(database string, limits *models.DatabaseLimits) *Limiter {
+	l := &Limiter{
+		database:     database,
+		quota:        newQuota(limits.WriteLimits),
+		namespaces:   make(map[string]*quota),
+		knownSeries:  newSeriesTracker(nowFunc()),
+		requestUsage: make(map[string]*usage),
+	}
+	l.trackSeries = limits.NewSeriesPerMinute > 0
+	for _, nsLimits := range limits.Namespaces {
+		l.namespaces[nsLimits.Namespace] = newQuota(nsLimits.WriteLimits)
+		l.requestUsage[nsLimits.Namespace] = &usage{}
+		if nsLimits.NewSeriesPerMinute > 0 {
+			l.trackSeries = true
+		}
+	}
+	l.statistics.throttledRows = throttledRowCounter.WithTagValues(database)
+	l.statistics.allowedRows = allowedRowCounter.WithTagValues(database)
+	l.statistics.allowedBytes = allowedBytesCounter.WithTagValues(database)
+	l.statistics.newSeries = newSeriesCounter.WithTagValues(database)
+	return l
+}
+
+// Allow checks if the rows of batch can be written, returns ErrRateLimited if any limit is exceeded.
+// Quota is only consumed when the whole batch is allowed.
+func (l *Limiter) Allow(batch *metric.BrokerBatchRows) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	for _, u := range l.requestUsage {
+		*u = usage{}
+	}
+	var (
+		total       usage
+		newSeries   []uint64
+		now         = nowFunc()
+		limitSeries = l.knownSeries.warmedUp(now)
+	)
+	rows := batch.Rows()
+	for idx := range rows {
+		row := &rows[idx]
+		if row.IsOutOfTimeRange {
+			continue
+		}
+		m := row.Metric()
+		// only tracks the usage of limited namespaces
+		u, limited := l.requestUsage[string(m.Namespace())]
+		size := int64(row.Size())
+		if limited {
+			u.rows++
+			u.bytes += size
+		}
+		total.rows++
+		total.bytes += size
+		if !l.trackSeries {
+			continue
+		}
+		// mark as known temporarily, rollback if request is throttled
+		if hash := l.seriesHash(&m); l.knownSeries.track(hash) {
+			newSeries = append(newSeries, hash)
+			if limitSeries {
+				total.newSeries++
+				if limited {
+					u.newSeries++
+				}
+			}
+		}
+	}
+
+	if limitType := l.quota.exceeded(now, &total); limitType != "" {
+		return l.throttle(newSeries, total.rows, "", limitType)
+	}
+	for ns, u := range l.requestUsage {
+		if u.rows > 0 {
+			if limitType := l.namespaces[ns].exceeded(now, u); limitType != "" {
+				return l.throttle(newSeries, total.rows, ns, limitType)
+			}
+		}
+	}
+
+	l.quota.acquire(now, &total)
+	for ns, u := range l.requestUsage {
+		l.namespaces[ns].acquire(now, u)
+	}
+	l.knownSeries.rotate(now)
+	l.statistics.allowedRows.Add(float64(total.rows))
+	l.statistics.allowedBytes.Add(float64(total.bytes))
+	l.statistics.newSeries.Add(float64(len(newSeries)))
+	return nil
+}
+
+// seriesHash returns the hash of namespace + metric name + tags,
+// because the hash of flat metric only includes the tags.
+func (l *Limiter) seriesHash(m *flatMetricsV1.Metric) uint64 {
+	l.hashBuf = append(l.hashBuf[:0], m.Namespace()...)
+	l.hashBuf = append(l.hashBuf, 0)
+	l.hashBuf = append(l.hashBuf, m.Name()...)
+	l.hashBuf = append(l.hashBuf, 0)
+	var tagsHash [8]byte
+	binary.LittleEndian.PutUint64(tagsHash[:], m.Hash())
+	l.hashBuf = append(l.hashBuf, tagsHash[:]...)
+	return xxhash.Sum64(l.hashBuf)
+}
+
+// throttle rollbacks the new series of request, then returns rate limited error.
+func (l *Limiter) throttle(newSeries []uint64, rows int64, namespace, limitType string) error {
+	l.knownSeries.untrack(newSeries)
+	l.statistics.throttledRows.Add(float64(rows))
+	if namespace == "" {
+		throttledRequestCounter.WithTagValues(l.database, "*", limitType).Incr()
+		return fmt.Errorf("%w: %s of database[%s]", ErrRateLimited, limitType, l.database)
+	}
+	throttledRequestCounter.WithTagValues(l.database, namespace, limitType).Incr()
+	return fmt.Errorf("%w: %s of database[%s] namespace[%s]", ErrRateLimited, limitType, l.database, namespace)
+}
@@ -0,0 +1,173 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package limit
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/fasttime"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/proto/gen/v1/flatMetricsV1"
+	"github.com/lindb/lindb/series/metric"
+)
+
+func buildBatch(t *testing.T, namespace string, hosts ...string) *metric.BrokerBatchRows {
+	batch := metric.NewBrokerBatchRows()
+	builder, releaseFunc := metric.NewRowBuilder()
+	defer releaseFunc(builder)
+
+	for _, host := range hosts {
+		builder.Reset()
+		builder.AddNameSpace([]byte(namespace))
+		builder.AddMetricName([]byte("cpu"))
+		assert.NoError(t, builder.AddTag([]byte("host"), []byte(host)))
+		assert.NoError(t, builder.AddSimpleField([]byte("f1"), flatMetricsV1.SimpleFieldTypeDeltaSum, 1))
+		assert.NoError(t, batch.TryAppend(builder.BuildTo))
+	}
+	return batch
+}
+
+func mockNow(now *int64) func() {
+	nowFunc = func() int64 { return *now }
+	return func() { nowFunc = fasttime.UnixMilliseconds }
+}
+
+func TestLimiter_Rows(t *testing.T) {
+	now := int64(1000)
+	defer mockNow(&now)()
+
+	l := NewLimiter("db", &models.DatabaseLimits{WriteLimits: models.WriteLimits{RowsPerSecond: 3}})
+	assert.NoError(t, l.Allow(buildBatch(t, "ns", "a", "b")))
+	err := l.Allow(buildBatch(t, "ns", "a", "b"))
+	assert.True(t, errors.Is(err, ErrRateLimited))
+	// throttled request doesn't consume quota
+	assert.NoError(t, l.Allow(buildBatch(t, "ns", "a")))
+	assert.Error(t, l.Allow(buildBatch(t, "ns", "a")))
+	// next window
+	now += 1000
+	assert.NoError(t, l.Allow(buildBatch(t, "ns", "a", "b", "c")))
+}
+
+func TestLimiter_Bytes(t *testing.T) {
+	now := int64(1000)
+	defer mockNow(&now)()
+
+	batch := buildBatch(t, "ns", "a")
+	size := int64(batch.Rows()[0].Size())
+	l := NewLimiter("db", &models.DatabaseLimits{WriteLimits: models.WriteLimits{BytesPerSecond: size}})
+	assert.NoError(t, l.Allow(batch))
+	assert.Error(t, l.Allow(buildBatch(t, "ns", "a")))
+	// out of time range rows are not counted
+	batch = buildBatch(t, "ns", "a")
+	batch.Rows()[0].IsOutOfTimeRange = true
+	assert.NoError(t, l.Allow(batch))
+}
+
+func TestLimiter_NewSeries(t *testing.T) {
+	now := int64(1000)
+	defer mockNow(&now)()
+
+	l := NewLimiter("db", &models.DatabaseLimits{WriteLimits: models.WriteLimits{NewSeriesPerMinute: 2}})
+	// new series limit is not enforced when warming up
+	assert.NoError(t, l.Allow(buildBatch(t, "ns", "x", "y", "z")))
+	now += knownSeriesWarmUp
+	assert.NoError(t, l.Allow(buildBatch(t, "ns", "a", "b", "a")))
+	// known series
+	assert.NoError(t, l.Allow(buildBatch(t, "ns", "a", "b")))
+	err := l.Allow(buildBatch(t, "ns", "c"))
+	assert.True(t, errors.Is(err, ErrRateLimited))
+	// throttled series are not known
+	now += 60 * 1000
+	assert.NoError(t, l.Allow(buildBatch(t, "ns", "c", "d")))
+	assert.Error(t, l.Allow(buildBatch(t, "ns", "e")))
+}
+
+func TestLimiter_Namespace(t *testing.T) {
+	now := int64(1000)
+	defer mockNow(&now)()
+
+	l := NewLimiter("db", &models.DatabaseLimits{
+		Namespaces: []models.NamespaceLimits{
+			{Namespace: "ns1", WriteLimits: models.WriteLimits{RowsPerSecond: 1}},
+			{Namespace: "ns2", WriteLimits: models.WriteLimits{NewSeriesPerMinute: 1}},
+		},
+	})
+	assert.NoError(t, l.Allow(buildBatch(t, "ns1", "a")))
+	err := l.Allow(buildBatch(t, "ns1", "a"))
+	assert.True(t, errors.Is(err, ErrRateLimited))
+	assert.Equal(t, fmt.Sprintf("%s: rows of database[db] namespace[ns1]", ErrRateLimited), err.Error())
+	now += knownSeriesWarmUp
+	assert.NoError(t, l.Allow(buildBatch(t, "ns2", "a")))
+	assert.Error(t, l.Allow(buildBatch(t, "ns2", "b")))
+	// no limit namespace
+	assert.NoError(t, l.Allow(buildBatch(t, "ns3", "a", "b", "c")))
+	// usage of no limit namespace isn't tracked
+	assert.Len(t, l.requestUsage, 2)
+}
+
+func TestLimiter_LargeBatch(t *testing.T) {
+	now := int64(1000)
+	defer mockNow(&now)()
+
+	l := NewLimiter("db", &models.DatabaseLimits{WriteLimits: models.WriteLimits{RowsPerSecond: 2}})
+	// batch larger than limit is rejected even if window is empty
+	err := l.Allow(buildBatch(t, "ns", "a", "b", "c"))
+	assert.True(t, errors.Is(err, ErrRateLimited))
+	// rejected batch doesn't consume quota
+	assert.NoError(t, l.Allow(buildBatch(t, "ns", "a", "b")))
+	now += 1000
+	assert.Error(t, l.Allow(buildBatch(t, "ns", "a", "b", "c")))
+	// bytes limit
+	batch := buildBatch(t, "ns", "a")
+	size := int64(batch.Rows()[0].Size())
+	l = NewLimiter("db", &models.DatabaseLimits{WriteLimits: models.WriteLimits{BytesPerSecond: size}})
+	err = l.Allow(buildBatch(t, "ns", "a", "b"))
+	assert.True(t, errors.Is(err, ErrRateLimited))
+	assert.NoError(t, l.Allow(batch))
+}
+
+func TestLimiter_RotateKnownSeries(t *testing.T) {
+	now := int64(1000)
+	defer mockNow(&now)()
+
+	l := NewLimiter("db", &models.DatabaseLimits{WriteLimits: models.WriteLimits{NewSeriesPerMinute: 1}})
+	now += knownSeriesWarmUp
+	assert.NoError(t, l.Allow(buildBatch(t, "ns", "a")))
+	// series seen in previous generation is still known
+	now += knownSeriesTTL
+	assert.NoError(t, l.Allow(buildBatch(t, "ns", "b")))
+	assert.NoError(t, l.Allow(buildBatch(t, "ns", "a")))
+	// series not seen in last two generations is new
+	now += knownSeriesTTL
+	assert.NoError(t, l.Allow(buildBatch(t, "ns", "c")))
+	now += knownSeriesTTL
+	assert.NoError(t, l.Allow(buildBatch(t, "ns", "d")))
+	assert.Error(t, l.Allow(buildBatch(t, "ns", "b")))
+	// rotate if too many series
+	for i := 0; i <= maxKnownSeries; i++ {
+		l.knownSeries.current[uint64(i)] = struct{}{}
+	}
+	now += timeutil.OneMinute
+	assert.NoError(t, l.Allow(buildBatch(t, "ns", "d")))
+	assert.Empty(t, l.knownSeries.current)
+	assert.Len(t, l.knownSeries.previous, maxKnownSeries+2)
+}
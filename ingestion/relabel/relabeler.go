@@ -0,0 +1,295 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package relabel
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+
+	"github.com/lindb/lindb/internal/linmetric"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/proto/gen/v1/flatMetricsV1"
+	"github.com/lindb/lindb/series/metric"
+)
+
+var (
+	relabelScope            = linmetric.NewScope("lindb.ingestion.relabel")
+	droppedMetricsCounter   = relabelScope.NewCounterVec("dropped_metrics", "db")
+	relabeledMetricsCounter = relabelScope.NewCounterVec("relabeled_metrics", "db")
+	relabelFailuresCounter  = relabelScope.NewCounterVec("relabel_failures", "db")
+)
+
+var relabelLogger = logger.GetLogger("ingestion", "Relabel")
+
+// rule represents the compiled ingestion rule.
+type rule struct {
+	models.IngestionRule
+	metric *regexp.Regexp // nil if rule applies for all metrics
+	regex  *regexp.Regexp
+}
+
+// matchMetric checks if the rule applies for the metric name.
+func (r *rule) matchMetric(metricName []byte) bool {
+	return r.metric == nil || r.metric.Match(metricName)
+}
+
+type tagKV struct {
+	key   []byte
+	value []byte
+}
+
+// Relabeler applies the ingestion rules of a database to broker rows before writing,
+// which drops metrics/tags/fields, renames tag keys, rewrites tag values and adds static tags.
+type Relabeler struct {
+	database string
+	rules    []*rule
+
+	statistics struct {
+		droppedMetrics   *linmetric.BoundCounter
+		relabeledMetrics *linmetric.BoundCounter
+		relabelFailures  *linmetric.BoundCounter
+	}
+}
+
+// NewRelabeler compiles the ingestion rules of database, returns error if rules are invalid.
+func NewRelabeler(rules *models.IngestionRules) (*Relabeler, error) {
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+	r := &Relabeler{database: rules.Database}
+	for idx := range rules.Rules {
+		compiled := &rule{IngestionRule: rules.Rules[idx]}
+		if compiled.Metric != "" {
+			compiled.metric, _ = models.CompileAnchoredRegex(compiled.Metric)
+		}
+		if compiled.Regex != "" {
+			compiled.regex, _ = models.CompileAnchoredRegex(compiled.Regex)
+		}
+		r.rules = append(r.rules, compiled)
+	}
+	r.statistics.droppedMetrics = droppedMetricsCounter.WithTagValues(rules.Database)
+	r.statistics.relabeledMetrics = relabeledMetricsCounter.WithTagValues(rules.Database)
+	r.statistics.relabelFailures = relabelFailuresCounter.WithTagValues(rules.Database)
+	return r, nil
+}
+
+// Apply applies the rules to all rows of batch, returns the number of dropped rows.
+func (r *Relabeler) Apply(batch *metric.BrokerBatchRows) (dropped int) {
+	if len(r.rules) == 0 || batch == nil {
+		return 0
+	}
+	builder, releaseFunc := metric.NewRowBuilder()
+	defer releaseFunc(builder)
+
+	var rules []*rule
+	dropped = batch.Retain(func(row *metric.BrokerRow) bool {
+		m := row.Metric()
+		rules = rules[:0]
+		for _, rule := range r.rules {
+			if !rule.matchMetric(m.Name()) {
+				continue
+			}
+			if rule.Action == models.DropMetric {
+				return false
+			}
+			rules = append(rules, rule)
+		}
+		if len(rules) == 0 {
+			return true
+		}
+		changed, keep, err := r.relabel(builder, &m, rules)
+		if err == nil && changed && keep {
+			err = builder.BuildTo(row)
+		}
+		if err != nil {
+			r.statistics.relabelFailures.Incr()
+			relabelLogger.Warn("relabel metric failure, drop it",
+				logger.String("database", r.database),
+				logger.String("metric", string(m.Name())),
+				logger.Error(err))
+			return false
+		}
+		if changed && keep {
+			r.statistics.relabeledMetrics.Incr()
+		}
+		return keep
+	})
+	r.statistics.droppedMetrics.Add(float64(dropped))
+	return dropped
+}
+
+// relabel applies the rules to metric, then puts it into row builder if metric is changed,
+// returns keep=false if all fields are dropped.
+func (r *Relabeler) relabel(
+	builder *metric.RowBuilder,
+	m *flatMetricsV1.Metric,
+	rules []*rule,
+) (changed, keep bool, err error) {
+	var (
+		tags         []tagKV
+		fields       []int
+		kv           flatMetricsV1.KeyValue
+		flatExemplar flatMetricsV1.Exemplar
+		exemplar     metric.Exemplar
+	)
+	for idx := 0; idx < m.KeyValuesLength(); idx++ {
+		if m.KeyValues(&kv, idx) {
+			tags = append(tags, tagKV{key: kv.Key(), value: kv.Value()})
+		}
+	}
+	var sf flatMetricsV1.SimpleField
+	for idx := 0; idx < m.SimpleFieldsLength(); idx++ {
+		fields = append(fields, idx)
+	}
+	for _, rule := range rules {
+		switch rule.Action {
+		case models.DropTag:
+			kept := tags[:0]
+			for _, t := range tags {
+				if !rule.regex.Match(t.key) {
+					kept = append(kept, t)
+				}
+			}
+			changed = changed || len(kept) != len(tags)
+			tags = kept
+		case models.RenameTag:
+			if idx := indexOfTag(tags, rule.TagKey); idx >= 0 {
+				tags = removeTag(tags, rule.TargetTagKey)
+				idx = indexOfTag(tags, rule.TagKey)
+				tags[idx].key = []byte(rule.TargetTagKey)
+				changed = true
+			}
+		case models.ReplaceTagValue:
+			if idx := indexOfTag(tags, rule.TagKey); idx >= 0 && rule.regex.Match(tags[idx].value) {
+				value := rule.regex.ReplaceAll(tags[idx].value, []byte(rule.Replacement))
+				if len(value) == 0 {
+					tags = removeTag(tags, rule.TagKey)
+				} else {
+					tags[idx].value = value
+				}
+				changed = true
+			}
+		case models.AddTag:
+			if idx := indexOfTag(tags, rule.TagKey); idx >= 0 {
+				tags[idx].value = []byte(rule.TagValue)
+			} else {
+				tags = append(tags, tagKV{key: []byte(rule.TagKey), value: []byte(rule.TagValue)})
+			}
+			changed = true
+		case models.DropField:
+			kept := fields[:0]
+			for _, fieldIdx := range fields {
+				if m.SimpleFields(&sf, fieldIdx) && !rule.regex.Match(sf.Name()) {
+					kept = append(kept, fieldIdx)
+				}
+			}
+			changed = changed || len(kept) != len(fields)
+			fields = kept
+		}
+	}
+	if !changed {
+		return false, true, nil
+	}
+	var compound flatMetricsV1.CompoundField
+	hasCompound := m.CompoundField(&compound) != nil && (compound.ValuesLength() > 0 || compound.SketchLength() > 0)
+	if len(fields) == 0 && !hasCompound {
+		return true, false, nil
+	}
+
+	builder.Reset()
+	builder.AddNameSpace(m.Namespace())
+	builder.AddMetricName(m.Name())
+	builder.AddTimestamp(m.Timestamp())
+	for _, t := range tags {
+		if err := builder.AddTag(t.key, t.value); err != nil {
+			return true, false, err
+		}
+	}
+	for _, fieldIdx := range fields {
+		m.SimpleFields(&sf, fieldIdx)
+		if err := builder.AddSimpleField(sf.Name(), sf.Type(), sf.Value()); err != nil {
+			return true, false, err
+		}
+		for idx := 0; idx < sf.ExemplarsLength(); idx++ {
+			if sf.Exemplars(&flatExemplar, idx) {
+				exemplar.FromFlat(&flatExemplar)
+				if err := builder.AddSimpleFieldExemplar(exemplar.TraceID, exemplar.SpanID, exemplar.Duration); err != nil {
+					return true, false, err
+				}
+			}
+		}
+	}
+	if hasCompound {
+		if err := addCompoundField(builder, &compound); err != nil {
+			return true, false, err
+		}
+		for idx := 0; idx < compound.ExemplarsLength(); idx++ {
+			if compound.Exemplars(&flatExemplar, idx) {
+				exemplar.FromFlat(&flatExemplar)
+				if err := builder.AddCompoundFieldExemplar(exemplar.TraceID, exemplar.SpanID, exemplar.Duration); err != nil {
+					return true, false, err
+				}
+			}
+		}
+	}
+	return true, true, nil
+}
+
+// addCompoundField adds the compound field of origin metric into row builder.
+func addCompoundField(builder *metric.RowBuilder, compound *flatMetricsV1.CompoundField) error {
+	if sketch := compound.SketchBytes(); len(sketch) > 0 {
+		if err := builder.AddCompoundFieldSketch(sketch); err != nil {
+			return err
+		}
+		return builder.AddCompoundFieldMMSC(compound.Min(), compound.Max(), compound.Sum(), compound.Count())
+	}
+	num := compound.ValuesLength()
+	if compound.ExplicitBoundsLength() != num {
+		return fmt.Errorf("compound values's length: %d != explicit-bounds's length: %d",
+			num, compound.ExplicitBoundsLength())
+	}
+	values := make([]float64, num)
+	bounds := make([]float64, num)
+	for idx := 0; idx < num; idx++ {
+		values[idx] = compound.Values(idx)
+		bounds[idx] = compound.ExplicitBounds(idx)
+	}
+	if err := builder.AddCompoundFieldData(values, bounds); err != nil {
+		return err
+	}
+	return builder.AddCompoundFieldMMSC(compound.Min(), compound.Max(), compound.Sum(), compound.Count())
+}
+
+// indexOfTag returns the index of tag key, returns -1 if not exist.
+func indexOfTag(tags []tagKV, key string) int {
+	for idx := range tags {
+		if bytes.Equal(tags[idx].key, []byte(key)) {
+			return idx
+		}
+	}
+	return -1
+}
+
+// removeTag removes the tag key if exist.
+func removeTag(tags []tagKV, key string) []tagKV {
+	if idx := indexOfTag(tags, key); idx >= 0 {
+		return append(tags[:idx], tags[idx+1:]...)
+	}
+	return tags
+}
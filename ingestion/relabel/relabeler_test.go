@@ -0,0 +1,171 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package relabel
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/proto/gen/v1/flatMetricsV1"
+	"github.com/lindb/lindb/series/metric"
+)
+
+func buildBatch(t *testing.T) *metric.BrokerBatchRows {
+	batch := metric.NewBrokerBatchRows()
+	builder, releaseFunc := metric.NewRowBuilder()
+	defer releaseFunc(builder)
+
+	appendRow := func(name string, tags map[string]string, fields ...string) {
+		builder.Reset()
+		builder.AddMetricName([]byte(name))
+		for k, v := range tags {
+			assert.NoError(t, builder.AddTag([]byte(k), []byte(v)))
+		}
+		for _, f := range fields {
+			assert.NoError(t, builder.AddSimpleField([]byte(f), flatMetricsV1.SimpleFieldTypeDeltaSum, 1))
+			assert.NoError(t, builder.AddSimpleFieldExemplar([]byte("trace-"+f), []byte("span"), 1))
+		}
+		if len(fields) == 0 {
+			assert.NoError(t, builder.AddCompoundFieldData([]float64{1, 2}, []float64{1, math.Inf(1)}))
+			assert.NoError(t, builder.AddCompoundFieldMMSC(1, 2, 3, 3))
+			assert.NoError(t, builder.AddCompoundFieldExemplar([]byte("trace-histogram"), nil, 2))
+		}
+		assert.NoError(t, batch.TryAppend(builder.BuildTo))
+	}
+	appendRow("debug_metric", map[string]string{"host": "a"}, "f1")
+	appendRow("cpu", map[string]string{"host": "a", "request_id": "123", "pod": "web-7f9c"}, "f1", "f2")
+	appendRow("memory", map[string]string{"host": "b"}, "f2")
+	appendRow("latency", map[string]string{"host": "c", "pod": "api-1a2b"})
+	appendRow("disk", map[string]string{"ip": "1.1.1.1"}, "f1")
+	return batch
+}
+
+func tagsOf(row *metric.BrokerRow) map[string]string {
+	m := row.Metric()
+	result := make(map[string]string)
+	var kv flatMetricsV1.KeyValue
+	for i := 0; i < m.KeyValuesLength(); i++ {
+		m.KeyValues(&kv, i)
+		result[string(kv.Key())] = string(kv.Value())
+	}
+	return result
+}
+
+func fieldsOf(row *metric.BrokerRow) []string {
+	m := row.Metric()
+	var result []string
+	var sf flatMetricsV1.SimpleField
+	for i := 0; i < m.SimpleFieldsLength(); i++ {
+		m.SimpleFields(&sf, i)
+		result = append(result, string(sf.Name()))
+	}
+	return result
+}
+
+func TestNewRelabeler(t *testing.T) {
+	_, err := NewRelabeler(&models.IngestionRules{})
+	assert.Error(t, err)
+
+	r, err := NewRelabeler(&models.IngestionRules{Database: "db"})
+	assert.NoError(t, err)
+	assert.Zero(t, r.Apply(buildBatch(t)))
+}
+
+func TestRelabeler_Apply(t *testing.T) {
+	r, err := NewRelabeler(&models.IngestionRules{
+		Database: "db",
+		Rules: []models.IngestionRule{
+			{Action: models.DropMetric, Metric: "debug_.*"},
+			{Action: models.DropTag, Regex: "request_id"},
+			{Action: models.ReplaceTagValue, TagKey: "pod", Regex: "(.*)-[a-z0-9]+", Replacement: "$1"},
+			{Action: models.AddTag, Metric: "cpu|latency", TagKey: "env", TagValue: "prod"},
+			{Action: models.RenameTag, TagKey: "ip", TargetTagKey: "host"},
+			{Action: models.DropField, Metric: "cpu|memory", Regex: "f2"},
+		},
+	})
+	assert.NoError(t, err)
+	batch := buildBatch(t)
+	// drop debug_metric and memory(all fields dropped)
+	assert.Equal(t, 2, r.Apply(batch))
+	assert.Equal(t, 3, batch.Len())
+
+	rows := make(map[string]*metric.BrokerRow)
+	for idx := range batch.Rows() {
+		row := &batch.Rows()[idx]
+		m := row.Metric()
+		rows[string(m.Name())] = row
+	}
+	assert.Equal(t, map[string]string{"host": "a", "pod": "web", "env": "prod"}, tagsOf(rows["cpu"]))
+	assert.Equal(t, []string{"f1"}, fieldsOf(rows["cpu"]))
+	assert.Equal(t, map[string]string{"host": "c", "pod": "api", "env": "prod"}, tagsOf(rows["latency"]))
+	latency := rows["latency"].Metric()
+	var compound flatMetricsV1.CompoundField
+	assert.NotNil(t, latency.CompoundField(&compound))
+	assert.Equal(t, 2, compound.ValuesLength())
+	assert.Equal(t, float64(3), compound.Sum())
+	// exemplars are retained after relabel
+	var (
+		flatExemplar flatMetricsV1.Exemplar
+		e            metric.Exemplar
+		sf           flatMetricsV1.SimpleField
+	)
+	assert.Equal(t, 1, compound.ExemplarsLength())
+	assert.True(t, compound.Exemplars(&flatExemplar, 0))
+	e.FromFlat(&flatExemplar)
+	assert.Equal(t, "trace-histogram", string(e.TraceID))
+	cpu := rows["cpu"].Metric()
+	assert.True(t, cpu.SimpleFields(&sf, 0))
+	assert.Equal(t, 1, sf.ExemplarsLength())
+	assert.True(t, sf.Exemplars(&flatExemplar, 0))
+	e.FromFlat(&flatExemplar)
+	assert.Equal(t, metric.Exemplar{TraceID: []byte("trace-f1"), SpanID: []byte("span"), Duration: 1}, e)
+	assert.Equal(t, map[string]string{"host": "1.1.1.1"}, tagsOf(rows["disk"]))
+}
+
+func TestRelabeler_Sketch(t *testing.T) {
+	r, err := NewRelabeler(&models.IngestionRules{
+		Database: "db",
+		Rules:    []models.IngestionRule{{Action: models.AddTag, TagKey: "env", TagValue: "prod"}},
+	})
+	assert.NoError(t, err)
+	sketch := encoding.NewSketch()
+	sketch.Add(1)
+	sketch.Add(100)
+	batch := metric.NewBrokerBatchRows()
+	builder, releaseFunc := metric.NewRowBuilder()
+	defer releaseFunc(builder)
+	builder.AddMetricName([]byte("latency"))
+	assert.NoError(t, builder.AddCompoundFieldSketch(sketch.MarshalBinary()))
+	assert.NoError(t, builder.AddCompoundFieldMMSC(1, 100, 101, 2))
+	assert.NoError(t, batch.TryAppend(builder.BuildTo))
+
+	assert.Zero(t, r.Apply(batch))
+	assert.Equal(t, 1, batch.Len())
+	row := &batch.Rows()[0]
+	assert.Equal(t, map[string]string{"env": "prod"}, tagsOf(row))
+	m := row.Metric()
+	var compound flatMetricsV1.CompoundField
+	assert.NotNil(t, m.CompoundField(&compound))
+	assert.Equal(t, sketch.MarshalBinary(), compound.SketchBytes())
+	assert.Equal(t, float64(1), compound.Min())
+	assert.Equal(t, float64(100), compound.Max())
+	assert.Equal(t, float64(2), compound.Count())
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/lindb/lindb/kv/table"
 	"github.com/lindb/lindb/kv/version"
 	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/timeutil"
 )
 
 var (
@@ -109,13 +110,12 @@ func (c *compactJob) mergeCompaction() (err error) {
 		return err
 	}
 	// if merge success install compaction results into manifest
-	c.installCompactionResults()
-	return nil
+	return c.installCompactionResults()
 }
 
 // doMerge merges the input files based on merger interface which need use implements
 func (c *compactJob) doMerge() error {
-	it, err := c.makeInputIterator()
+	it, fileNumbers, err := c.makeInputIterator()
 	if err != nil {
 		return err
 	}
@@ -126,30 +126,46 @@ func (c *compactJob) doMerge() error {
 	if c.rollup != nil {
 		merger.Init(map[string]interface{}{RollupContext: c.rollup})
 	}
+	versionedMerger, versioned := merger.(VersionedMerger)
+	merge := func(key uint32, files []table.FileNumber, values [][]byte) error {
+		if versioned {
+			return versionedMerger.MergeVersioned(key, files, values)
+		}
+		return merger.Merge(key, values)
+	}
 
 	var needMerge [][]byte
+	var needMergeFiles []table.FileNumber
 	var previousKey uint32
 	start := true
 	for it.HasNext() {
 		key := it.Key()
-		value := it.Value()
+		value, err := it.Value()
+		if err != nil {
+			// abort compaction, input files are kept
+			return err
+		}
+		fileNumber := fileNumbers[it.Source()]
 		switch {
 		case start || key == previousKey:
 			// if start or same keys, append to need merge slice
 			needMerge = append(needMerge, value)
+			needMergeFiles = append(needMergeFiles, fileNumber)
 			start = false
 		case key != previousKey:
 			//FIXME stone1100 merge data maybe is one block
 
 			// 1. if new key != previous key do merge logic based on user define
-			if err := merger.Merge(previousKey, needMerge); err != nil {
+			if err := merge(previousKey, needMergeFiles, needMerge); err != nil {
 				return err
 			}
 			// 2. prepare next merge loop
 			// init value for next loop
 			needMerge = needMerge[:0]
+			needMergeFiles = needMergeFiles[:0]
 			// add value to need merge slice
 			needMerge = append(needMerge, value)
+			needMergeFiles = append(needMergeFiles, fileNumber)
 		}
 		// set previous merge key
 		previousKey = key
@@ -157,7 +173,7 @@ func (c *compactJob) doMerge() error {
 
 	// if has pending merge values after iterator, need do merge
 	if len(needMerge) > 0 {
-		if err := merger.Merge(previousKey, needMerge); err != nil {
+		if err := merge(previousKey, needMergeFiles, needMerge); err != nil {
 			return err
 		}
 	}
@@ -173,34 +189,49 @@ func (c *compactJob) doMerge() error {
 // installCompactionResults installs compactions results.
 // 1. mark input files is deletion which compaction job picked.
 // 2. add output files to up level.
-// 3. commit edit log for manifest.
-func (c *compactJob) installCompactionResults() {
+// 3. if rollup job, output files need rollup into next target interval of family.
+// 4. commit edit log for manifest.
+func (c *compactJob) installCompactionResults() error {
 	// marks compaction input files for deletion
 	c.state.compaction.MarkInputDeletes()
 	// adds compaction outputs
 	level := c.state.compaction.GetLevel()
+	var rollupIntervals []timeutil.Interval
+	if c.state.compaction.IsRollup() {
+		rollupIntervals = c.family.getRollupIntervals()
+	}
 	for _, output := range c.state.outputs {
 		c.state.compaction.AddFile(level+1, output)
+		for _, interval := range rollupIntervals {
+			c.state.compaction.AddRollupFile(output.GetFileNumber(), interval)
+		}
 	}
-	c.family.commitEditLog(c.state.compaction.GetEditLog())
+	if !c.family.commitEditLog(c.state.compaction.GetEditLog()) {
+		// source files of rollup job are kept for next rollup job
+		return fmt.Errorf("commit edit log failure")
+	}
+	return nil
 }
 
-// makeInputIterator makes a merged iterator by compaction pick input files
-func (c *compactJob) makeInputIterator() (table.Iterator, error) {
+// makeInputIterator makes a merged iterator by compaction pick input files,
+// returns the file number of each input iterator also.
+func (c *compactJob) makeInputIterator() (table.MergedIterator, []table.FileNumber, error) {
 	var its []table.Iterator
+	var fileNumbers []table.FileNumber
 	for which := 0; which < 2; which++ {
 		files := c.state.compaction.GetInputs()[which]
 		if len(files) > 0 {
 			for _, fileMeta := range files {
 				reader, err := c.state.snapshot.GetReader(fileMeta.GetFileNumber())
 				if err != nil {
-					return nil, err
+					return nil, nil, err
 				}
 				its = append(its, reader.Iterator())
+				fileNumbers = append(fileNumbers, fileMeta.GetFileNumber())
 			}
 		}
 	}
-	return table.NewMergedIterator(its), nil
+	return table.NewMergedIterator(its), fileNumbers, nil
 }
 
 // openCompactionOutputFile opens a new compaction store build, and adds the file number into pending output
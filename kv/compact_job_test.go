@@ -136,6 +136,40 @@ func TestCompactJob_merge_compact_merge_fail(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestCompactJob_merge_compact_read_value_fail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	snapshot := version.NewMockSnapshot(ctrl)
+	reader := table.NewMockReader(ctrl)
+	it := table.NewMockIterator(ctrl)
+	gomock.InOrder(
+		it.EXPECT().HasNext().Return(true),
+		it.EXPECT().Key().Return(uint32(1)),
+		it.EXPECT().Value().Return(nil, fmt.Errorf("err")),
+		it.EXPECT().HasNext().Return(false),
+	)
+	gomock.InOrder(
+		reader.EXPECT().Iterator().Return(it),
+		reader.EXPECT().Iterator().Return(generateIterator(ctrl, map[uint32][]byte{})),
+	)
+	snapshot.EXPECT().GetReader(gomock.Any()).Return(reader, nil).MaxTimes(2)
+	// no value merged if read value failure
+	merge := NewMockMerger(ctrl)
+	family := generateMockFamily(ctrl, func(flusher Flusher) (Merger, error) {
+		return merge, nil
+	})
+	family.EXPECT().familyInfo().Return("family").AnyTimes()
+	f1 := version.NewFileMeta(1, 1, 10, 100)
+	f4 := version.NewFileMeta(4, 30, 100, 100)
+	compaction := version.NewCompaction(1, 0, []*version.FileMeta{f1}, []*version.FileMeta{f4})
+	state := newCompactionState(1000, snapshot, compaction)
+	compactJob := newCompactJob(family, state, nil)
+	err := compactJob.Run()
+	assert.Error(t, err)
+	assert.Empty(t, state.outputs)
+}
+
 func TestCompactJob_merge_doMerge_fail(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -357,6 +391,65 @@ func TestCompactJob_merge_compact(t *testing.T) {
 	assert.Equal(t, version.CreateNewFile(1, newFile), logs[4])
 }
 
+// mockVersionedMerger records the file numbers of values for same key
+type mockVersionedMerger struct {
+	mockAppendMerger
+	fileNumbers map[uint32][]table.FileNumber
+}
+
+func (m *mockVersionedMerger) MergeVersioned(key uint32, fileNumbers []table.FileNumber, values [][]byte) error {
+	m.fileNumbers[key] = append([]table.FileNumber(nil), fileNumbers...)
+	return m.Merge(key, values)
+}
+
+func TestCompactJob_merge_versioned(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	snapshot := version.NewMockSnapshot(ctrl)
+	reader1 := table.NewMockReader(ctrl)
+	reader2 := table.NewMockReader(ctrl)
+	reader1.EXPECT().Iterator().Return(generateIterator(ctrl, map[uint32][]byte{
+		1:  []byte("value1"),
+		10: []byte("value10"),
+	}))
+	reader2.EXPECT().Iterator().Return(generateIterator(ctrl, map[uint32][]byte{
+		10: []byte("value10"),
+		30: []byte("value30"),
+	}))
+	snapshot.EXPECT().GetReader(table.FileNumber(1)).Return(reader1, nil)
+	snapshot.EXPECT().GetReader(table.FileNumber(3)).Return(reader2, nil)
+	merger := &mockVersionedMerger{fileNumbers: make(map[uint32][]table.FileNumber)}
+	family := generateMockFamily(ctrl, func(flusher Flusher) (Merger, error) {
+		merger.flusher = flusher
+		return merger, nil
+	})
+	family.EXPECT().familyInfo().Return("family").AnyTimes()
+	f1 := version.NewFileMeta(1, 1, 10, 100)
+	f3 := version.NewFileMeta(3, 10, 30, 100)
+	compaction := version.NewCompaction(1, 0, []*version.FileMeta{f1}, []*version.FileMeta{f3})
+	state := newCompactionState(10000000, snapshot, compaction)
+	compactJob := newCompactJob(family, state, nil)
+	builder := table.NewMockBuilder(ctrl)
+	family.EXPECT().newTableBuilder().Return(builder, nil)
+	family.EXPECT().addPendingOutput(table.FileNumber(5))
+	family.EXPECT().removePendingOutput(table.FileNumber(5))
+	builder.EXPECT().FileNumber().Return(table.FileNumber(5)).AnyTimes()
+	builder.EXPECT().Add(gomock.Any(), gomock.Any()).Return(nil).Times(3)
+	builder.EXPECT().Size().Return(uint32(10)).AnyTimes()
+	builder.EXPECT().Count().Return(uint64(3))
+	builder.EXPECT().Close().Return(nil)
+	builder.EXPECT().MinKey().Return(uint32(1))
+	builder.EXPECT().MaxKey().Return(uint32(30))
+	err := compactJob.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, []table.FileNumber{1}, merger.fileNumbers[1])
+	fileNumbers := merger.fileNumbers[10]
+	sort.Slice(fileNumbers, func(i, j int) bool { return fileNumbers[i] < fileNumbers[j] })
+	assert.Equal(t, []table.FileNumber{1, 3}, fileNumbers)
+	assert.Equal(t, []table.FileNumber{3}, merger.fileNumbers[30])
+}
+
 func generateMockFamily(ctrl *gomock.Controller, merger NewMerger) *MockFamily {
 	family := NewMockFamily(ctrl)
 	family.EXPECT().getNewMerger().Return(merger).AnyTimes()
@@ -379,7 +472,7 @@ func generateIterator(ctrl *gomock.Controller, values map[uint32][]byte) table.I
 		calls = append(calls,
 			it1.EXPECT().HasNext().Return(true),
 			it1.EXPECT().Key().Return(key),
-			it1.EXPECT().Value().Return(values[key]))
+			it1.EXPECT().Value().Return(values[key], nil))
 	}
 	calls = append(calls, it1.EXPECT().HasNext().Return(false))
 
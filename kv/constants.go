@@ -17,13 +17,19 @@
 
 package kv
 
-import "github.com/lindb/lindb/pkg/logger"
+import (
+	"time"
+
+	"github.com/lindb/lindb/pkg/logger"
+)
 
 const dummy = ""
 const RollupContext = "RollupContext"
 const defaultMaxFileSize = uint32(256 * 1024 * 1024)
 const defaultCompactThreshold = 4
 const defaultRollupThreshold = 3
+const fenceCompactionInterval = 10 * time.Millisecond
 
 var defaultCompactCheckInterval = 60
+var defaultRollupCheckInterval = 60
 var kvLogger = logger.GetLogger("kv", "Store")
@@ -21,13 +21,16 @@ import (
 	"fmt"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"go.uber.org/atomic"
 
+	"github.com/lindb/lindb/internal/linmetric"
 	"github.com/lindb/lindb/kv/table"
 	"github.com/lindb/lindb/kv/version"
 	"github.com/lindb/lindb/pkg/fileutil"
 	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/timeutil"
 )
 
 //go:generate mockgen -source ./family.go -destination=./family_mock.go -package kv
@@ -38,6 +41,16 @@ var (
 	removeDirFunc     = fileutil.RemoveDir
 )
 
+var (
+	familyScope = linmetric.NewScope("lindb.kv.family")
+	// compression ratio of family type = table_bytes / raw_bytes
+	rawBytesVec   = familyScope.NewCounterVec("raw_bytes", "type")
+	tableBytesVec = familyScope.NewCounterVec("table_bytes", "type")
+	// lookup files which key range overlaps, and saved lookups of files which key set not contains the key
+	lookupFilesVec      = familyScope.NewCounterVec("lookup_files", "type")
+	lookupSavedFilesVec = familyScope.NewCounterVec("lookup_saved_files", "type")
+)
+
 // Family implements column family for data isolation each family.
 type Family interface {
 	// ID return family's id
@@ -48,6 +61,11 @@ type Family interface {
 	NewFlusher() Flusher
 	// GetSnapshot returns current version's snapshot
 	GetSnapshot() version.Snapshot
+	// InUse returns if family's versions are still referenced by search/compact/rollup
+	InUse() bool
+	// FenceCompaction waits the running compaction/rollup jobs completed, then blocks new compaction/rollup jobs
+	// until the returned release function is invoked.
+	FenceCompaction() (release func())
 	// familyInfo return family info
 	familyInfo() string
 
@@ -67,8 +85,14 @@ type Family interface {
 	addPendingOutput(fileNumber table.FileNumber)
 	// removePendingOutput removes pending output file after compact or flush
 	removePendingOutput(fileNumber table.FileNumber)
+	// needRollup returns if need rollup source family data
+	needRollup() bool
+	// rollup does rollup job, merges the data of family into target family
+	rollup()
 	// doRollupWork does rollup job, merge source family data to target family
 	doRollupWork(sourceFamily Family, rollup Rollup, sourceFiles []table.FileNumber) (err error)
+	// getRollupIntervals returns the target intervals which the new files of family need rollup into
+	getRollupIntervals() []timeutil.Interval
 
 	// deleteObsoleteFiles deletes obsolete files
 	deleteObsoleteFiles()
@@ -83,12 +107,15 @@ type family struct {
 	merger        NewMerger
 	familyVersion version.FamilyVersion
 	maxFileSize   uint32
+	compression   table.CompressionType
 
 	pendingOutputs    sync.Map
 	newCompactJobFunc func(family Family, state *compactionState, rollup Rollup) CompactJob
 
 	rolluping  atomic.Bool
 	compacting atomic.Bool
+	// rollupWriting protects writing rollup data of source family into this family
+	rollupWriting sync.Mutex
 }
 
 // newFamily creates new family or open existed family.
@@ -110,6 +137,10 @@ func newFamily(store Store, option FamilyOption) (Family, error) {
 	if option.MaxFileSize > 0 {
 		maxFileSize = option.MaxFileSize
 	}
+	compression, err := table.ParseCompressionType(option.Compression)
+	if err != nil {
+		return nil, err
+	}
 
 	f := &family{
 		familyPath:        familyPath,
@@ -118,6 +149,7 @@ func newFamily(store Store, option FamilyOption) (Family, error) {
 		option:            option,
 		merger:            merger,
 		maxFileSize:       maxFileSize,
+		compression:       compression,
 		newCompactJobFunc: newCompactJobFunc,
 		familyVersion:     store.createFamilyVersion(name, version.FamilyID(option.ID)),
 	}
@@ -143,7 +175,33 @@ func (f *family) NewFlusher() Flusher {
 
 // GetSnapshot returns current version's snapshot
 func (f *family) GetSnapshot() version.Snapshot {
-	return f.familyVersion.GetSnapshot()
+	return &familySnapshot{
+		Snapshot:         f.familyVersion.GetSnapshot(),
+		lookupFiles:      lookupFilesVec.WithTagValues(f.option.Merger),
+		lookupSavedFiles: lookupSavedFilesVec.WithTagValues(f.option.Merger),
+	}
+}
+
+// InUse returns if family's versions are still referenced by search/compact/rollup
+func (f *family) InUse() bool {
+	return f.familyVersion.NumOfRef() > 0
+}
+
+// FenceCompaction waits the running compaction/rollup jobs completed, then blocks new compaction/rollup jobs
+// until the returned release function is invoked.
+func (f *family) FenceCompaction() (release func()) {
+	for !f.compacting.CAS(false, true) {
+		time.Sleep(fenceCompactionInterval)
+	}
+	for !f.rolluping.CAS(false, true) {
+		time.Sleep(fenceCompactionInterval)
+	}
+	f.rollupWriting.Lock()
+	return func() {
+		f.rollupWriting.Unlock()
+		f.rolluping.Store(false)
+		f.compacting.Store(false)
+	}
 }
 
 // familyInfo return family info
@@ -155,7 +213,15 @@ func (f *family) familyInfo() string {
 func (f *family) newTableBuilder() (table.Builder, error) {
 	fileNumber := f.store.nextFileNumber()
 	fileName := filepath.Join(f.familyPath, version.Table(fileNumber))
-	return table.NewStoreBuilder(fileNumber, fileName)
+	builder, err := table.NewStoreBuilder(fileNumber, fileName, f.compression)
+	if err != nil {
+		return nil, err
+	}
+	return &familyTableBuilder{
+		Builder:    builder,
+		rawBytes:   rawBytesVec.WithTagValues(f.option.Merger),
+		tableBytes: tableBytesVec.WithTagValues(f.option.Merger),
+	}, nil
 }
 
 // commitEditLog persists edit logs into manifest file.
@@ -308,3 +374,53 @@ func (f *family) deleteObsoleteFiles() {
 		}
 	}
 }
+
+// familyTableBuilder records the compression statistics of family after building table
+type familyTableBuilder struct {
+	table.Builder
+
+	rawBytes   *linmetric.BoundCounter
+	tableBytes *linmetric.BoundCounter
+}
+
+// Close closes the table builder, then records the size of raw values and table file
+func (b *familyTableBuilder) Close() error {
+	if err := b.Builder.Close(); err != nil {
+		return err
+	}
+	b.rawBytes.Add(float64(b.Builder.RawSize()))
+	b.tableBytes.Add(float64(b.Builder.Size()))
+	return nil
+}
+
+// familySnapshot skips the files which key set of table footer not contains the key when finding readers,
+// then records the lookup statistics of family.
+type familySnapshot struct {
+	version.Snapshot
+
+	lookupFiles      *linmetric.BoundCounter
+	lookupSavedFiles *linmetric.BoundCounter
+}
+
+// FindReaders finds all files which key range overlaps, then skips the files which key set not contains the key,
+// so that the caller need not probe the value of files without key.
+func (s *familySnapshot) FindReaders(key uint32) ([]table.Reader, error) {
+	files := s.GetCurrent().FindFiles(key)
+	s.lookupFiles.Add(float64(len(files)))
+	var readers []table.Reader
+	for _, fileMeta := range files {
+		reader, err := s.GetReader(fileMeta.GetFileNumber())
+		if err != nil {
+			return nil, err
+		}
+		if reader == nil {
+			continue
+		}
+		if !reader.Contains(key) {
+			s.lookupSavedFiles.Incr()
+			continue
+		}
+		readers = append(readers, reader)
+	}
+	return readers, nil
+}
@@ -18,6 +18,8 @@
 package kv
 
 import (
+	"sort"
+
 	"github.com/lindb/lindb/kv/table"
 	"github.com/lindb/lindb/kv/version"
 	"github.com/lindb/lindb/pkg/logger"
@@ -34,8 +36,13 @@ type Rollup interface {
 	IntervalRatio() uint16
 	// CalcSlot calculates the target slot based on source timestamp
 	CalcSlot(timestamp int64) uint16
-	// GetTargetFamily returns the target family based on source family name
+	// TruncateTimestamp returns the start time of target slot which source timestamp belongs to
+	TruncateTimestamp(timestamp int64) int64
+	// GetTargetFamily returns the target family based on source family name, returns nil if cannot get it
 	GetTargetFamily(sourceFamilyName string) Family
+	// ForFamily returns the rollup of source family, because the slot of source data is based on family time,
+	// returns nil if the data of source family need not rollup.
+	ForFamily(sourceFamilyName string) Rollup
 }
 
 // needRollup returns if need rollup source family data
@@ -87,20 +94,29 @@ func (f *family) rollup() {
 
 		// do rollup job in target family
 		rollup, ok := f.store.getRollup(interval)
-		if !ok {
+		if ok {
+			rollup = rollup.ForFamily(f.name)
+		}
+		if rollup == nil {
 			kvLogger.Warn("skip rollup because cannot get target rollup",
 				logger.String("family", f.familyInfo()),
 				logger.Int64("interval", interval.Int64()))
 			return
 		}
-		editLog := version.NewEditLog(f.ID())
 		targetFamily := rollup.GetTargetFamily(f.name)
+		if targetFamily == nil {
+			kvLogger.Warn("skip rollup because cannot get target family",
+				logger.String("family", f.familyInfo()),
+				logger.Int64("interval", interval.Int64()))
+			return
+		}
+		editLog := version.NewEditLog(f.ID())
 
 		if err := targetFamily.doRollupWork(f, rollup, sourceFiles); err != nil {
 			kvLogger.Error("do rollup work fail",
 				logger.String("family", f.familyInfo()),
 				logger.Int64("interval", interval.Int64()),
-				logger.Any("files", sourceFiles))
+				logger.Any("files", sourceFiles), logger.Error(err))
 			return
 		}
 
@@ -114,6 +130,11 @@ func (f *family) rollup() {
 	}
 }
 
+// getRollupIntervals returns the target intervals which the new files of family need rollup into
+func (f *family) getRollupIntervals() []timeutil.Interval {
+	return f.store.getRollupIntervals(f.name)
+}
+
 // doRollupWork does rollup work in target family,
 // 1. reads data from source family
 // 2. merge these
@@ -122,10 +143,16 @@ func (f *family) doRollupWork(sourceFamily Family, rollup Rollup, sourceFiles []
 	if len(sourceFiles) == 0 {
 		return
 	}
+	// blocks the fence of target family(e.g. moving segment) until rollup data written
+	f.rollupWriting.Lock()
+	defer f.rollupWriting.Unlock()
+
 	targetFiles := make(map[table.FileNumber]struct{})
 	for _, file := range sourceFiles {
 		targetFiles[file] = struct{}{}
 	}
+	// the reference files which source family already removed from rollup files, need not be kept
+	var staleFiles []table.FileNumber
 	referenceFiles := f.familyVersion.GetLiveReferenceFiles()
 	files, ok := referenceFiles[sourceFamily.ID()]
 	if ok {
@@ -138,6 +165,8 @@ func (f *family) doRollupWork(sourceFamily Family, rollup Rollup, sourceFiles []
 					logger.String("source", sourceFamily.familyInfo()),
 					logger.String("target", f.familyInfo()),
 				)
+			} else {
+				staleFiles = append(staleFiles, file)
 			}
 		}
 	}
@@ -145,12 +174,20 @@ func (f *family) doRollupWork(sourceFamily Family, rollup Rollup, sourceFiles []
 		// if no target files, return it
 		return
 	}
+	inputs := make([]table.FileNumber, 0, len(targetFiles))
+	for file := range targetFiles {
+		inputs = append(inputs, file)
+	}
+	sort.Slice(inputs, func(i, j int) bool { return inputs[i] < inputs[j] })
 
 	snapshot := sourceFamily.GetSnapshot()
 	defer func() {
 		snapshot.Close()
 	}()
-	compaction := version.NewCompaction(f.ID(), -1, nil, nil)
+	compaction := version.NewRollupCompaction(f.ID(), sourceFamily.ID(), inputs)
+	for _, file := range staleFiles {
+		compaction.DeleteReferenceFile(sourceFamily.ID(), file)
+	}
 
 	compactionState := newCompactionState(f.maxFileSize, snapshot, compaction)
 	compactJob := newCompactJobFunc(f, compactionState, rollup)
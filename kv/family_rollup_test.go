@@ -93,15 +93,24 @@ func TestFamily_rollup(t *testing.T) {
 	fv.EXPECT().GetLiveRollupFiles().Return(map[table.FileNumber]timeutil.Interval{10: 10}).MaxTimes(2)
 	store.EXPECT().getRollup(timeutil.Interval(10)).Return(nil, false)
 	f2.rollup()
-	// case 4: do rollup err
+	// case 4: family need not rollup
 	fv.EXPECT().GetLiveRollupFiles().Return(map[table.FileNumber]timeutil.Interval{10: 10}).MaxTimes(2)
 	rollup := NewMockRollup(ctrl)
+	store.EXPECT().getRollup(timeutil.Interval(10)).Return(rollup, true).AnyTimes()
+	rollup.EXPECT().ForFamily(gomock.Any()).Return(nil)
+	f2.rollup()
+	// case 5: target family not found
+	fv.EXPECT().GetLiveRollupFiles().Return(map[table.FileNumber]timeutil.Interval{10: 10}).MaxTimes(2)
+	rollup.EXPECT().ForFamily(gomock.Any()).Return(rollup).AnyTimes()
+	rollup.EXPECT().GetTargetFamily(gomock.Any()).Return(nil)
+	f2.rollup()
+	// case 6: do rollup err
+	fv.EXPECT().GetLiveRollupFiles().Return(map[table.FileNumber]timeutil.Interval{10: 10}).MaxTimes(2)
 	tf := NewMockFamily(ctrl)
 	rollup.EXPECT().GetTargetFamily(gomock.Any()).Return(tf).AnyTimes()
-	store.EXPECT().getRollup(timeutil.Interval(10)).Return(rollup, true).AnyTimes()
 	tf.EXPECT().doRollupWork(f2, rollup, []table.FileNumber{10}).Return(fmt.Errorf("err"))
 	f2.rollup()
-	// case 5: rollup success
+	// case 7: rollup success
 	fv.EXPECT().GetLiveRollupFiles().Return(map[table.FileNumber]timeutil.Interval{10: 10}).MaxTimes(2)
 	tf.EXPECT().doRollupWork(f2, rollup, []table.FileNumber{10}).Return(nil)
 	store.EXPECT().commitFamilyEditLog(gomock.Any(), gomock.Any()).Return(nil)
@@ -132,18 +141,23 @@ func TestFamily_doRollupWork(t *testing.T) {
 	sf.EXPECT().familyInfo().Return("family").AnyTimes()
 	err = f2.doRollupWork(sf, nil, []table.FileNumber{10, 20, 30})
 	assert.NoError(t, err)
-	// case 3: rollup source files
-	fv.EXPECT().GetLiveReferenceFiles().Return(map[version.FamilyID][]table.FileNumber{10: {10, 30}}).AnyTimes()
+	// case 3: rollup source files, skip rollup files, delete stale reference files
+	fv.EXPECT().GetLiveReferenceFiles().Return(map[version.FamilyID][]table.FileNumber{10: {5, 10, 30}}).AnyTimes()
 	snapshot := version.NewMockSnapshot(ctrl)
 	snapshot.EXPECT().Close().AnyTimes()
 	sf.EXPECT().GetSnapshot().Return(snapshot).AnyTimes()
-	err = f2.doRollupWork(sf, nil, []table.FileNumber{10, 20, 30})
-	assert.NoError(t, err)
-	// case 4: rollup job err
 	compactJob := NewMockCompactJob(ctrl)
 	newCompactJobFunc = func(family Family, state *compactionState, rollup Rollup) CompactJob {
+		assert.True(t, state.compaction.IsRollup())
+		assert.Len(t, state.compaction.GetInputs()[0], 1)
+		assert.Equal(t, table.FileNumber(20), state.compaction.GetInputs()[0][0].GetFileNumber())
+		assert.Len(t, state.compaction.GetEditLog().GetLogs(), 1)
 		return compactJob
 	}
+	compactJob.EXPECT().Run().Return(nil)
+	err = f2.doRollupWork(sf, nil, []table.FileNumber{10, 20, 30})
+	assert.NoError(t, err)
+	// case 4: rollup job err
 	compactJob.EXPECT().Run().Return(fmt.Errorf("err"))
 	err = f2.doRollupWork(sf, nil, []table.FileNumber{10, 20, 30})
 	assert.Error(t, err)
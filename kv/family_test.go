@@ -56,7 +56,11 @@ func TestFamily_New(t *testing.T) {
 	f, err = newFamily(store, FamilyOption{Merger: "mockMerger_not_exist"})
 	assert.Error(t, err)
 	assert.Nil(t, f)
-	// case 3: create family success
+	// case 3: create family err, compression not exist
+	f, err = newFamily(store, FamilyOption{Merger: "mockMerger", Compression: "lz4"})
+	assert.Error(t, err)
+	assert.Nil(t, f)
+	// case 4: create family success
 	vs := version.NewMockFamilyVersion(ctrl)
 	store.EXPECT().createFamilyVersion(gomock.Any(), gomock.Any()).Return(vs)
 	f, err = newFamily(store, FamilyOption{Merger: "mockMerger", ID: 10, Name: "f", MaxFileSize: 10})
@@ -90,8 +94,16 @@ func TestFamily_Data_Write_Read(t *testing.T) {
 	_ = flusher.Add(10, []byte("test10"))
 	commitErr := flusher.Commit()
 	assert.Nil(t, commitErr)
+	// key range overlaps, but key set not contains the key
+	flusher = f.NewFlusher()
+	_ = flusher.Add(5, []byte("test5"))
+	_ = flusher.Add(20, []byte("test20"))
+	assert.NoError(t, flusher.Commit())
 
+	assert.False(t, f.InUse())
 	snapshot := f.GetSnapshot()
+	assert.True(t, f.InUse())
+	assert.Len(t, snapshot.GetCurrent().FindFiles(10), 2)
 	readers, _ := snapshot.FindReaders(10)
 	assert.Equal(t, 1, len(readers))
 	value, _ := readers[0].Get(1)
@@ -99,6 +111,7 @@ func TestFamily_Data_Write_Read(t *testing.T) {
 	value, _ = readers[0].Get(10)
 	assert.Equal(t, []byte("test10"), value)
 	snapshot.Close()
+	assert.False(t, f.InUse())
 }
 
 func TestFamily_commitEditLog(t *testing.T) {
@@ -196,6 +209,62 @@ func TestFamily_compact(t *testing.T) {
 	time.Sleep(200 * time.Millisecond)
 }
 
+func TestFamily_FenceCompaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testKVPath)
+		ctrl.Finish()
+	}()
+	store := NewMockStore(ctrl)
+	store.EXPECT().Option().Return(DefaultStoreOption(testKVPath)).AnyTimes()
+	store.EXPECT().createFamilyVersion(gomock.Any(), gomock.Any()).Return(version.NewMockFamilyVersion(ctrl))
+	f, err := newFamily(store, FamilyOption{Merger: "mockMerger"})
+	assert.NoError(t, err)
+	f1 := f.(*family)
+
+	// case 1: compaction job is blocked by fence
+	release := f.FenceCompaction()
+	assert.False(t, f1.needCompact())
+	f.compact()
+	release()
+	assert.False(t, f1.compacting.Load())
+	// case 2: fence waits running compaction job
+	f1.compacting.Store(true)
+	fenced := make(chan struct{})
+	go func() {
+		release := f.FenceCompaction()
+		close(fenced)
+		release()
+	}()
+	select {
+	case <-fenced:
+		assert.Fail(t, "fence should wait running compaction job")
+	case <-time.After(50 * time.Millisecond):
+	}
+	f1.compacting.Store(false)
+	<-fenced
+	// case 3: fence waits running rollup job
+	f1.rolluping.Store(true)
+	fenced = make(chan struct{})
+	go func() {
+		release := f.FenceCompaction()
+		close(fenced)
+		release()
+	}()
+	select {
+	case <-fenced:
+		assert.Fail(t, "fence should wait running rollup job")
+	case <-time.After(50 * time.Millisecond):
+	}
+	f1.rolluping.Store(false)
+	<-fenced
+	// case 4: rollup job is blocked by fence
+	release = f.FenceCompaction()
+	assert.False(t, f1.needRollup())
+	release()
+	assert.False(t, f1.rolluping.Load())
+}
+
 func TestFamily_compact_background(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer func() {
@@ -276,3 +345,52 @@ func TestFamily_deleteObsoleteFiles(t *testing.T) {
 	}
 	f1.deleteObsoleteFiles()
 }
+
+func TestFamily_Snapshot_FindReaders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	snapshot := version.NewMockSnapshot(ctrl)
+	v := version.NewMockVersion(ctrl)
+	snapshot.EXPECT().GetCurrent().Return(v).AnyTimes()
+	fs := &familySnapshot{
+		Snapshot:         snapshot,
+		lookupFiles:      lookupFilesVec.WithTagValues("test"),
+		lookupSavedFiles: lookupSavedFilesVec.WithTagValues("test"),
+	}
+	files := func() []*version.FileMeta {
+		return []*version.FileMeta{
+			version.NewFileMeta(1, 1, 20, 100),
+			version.NewFileMeta(2, 1, 20, 100),
+			version.NewFileMeta(3, 1, 20, 100),
+		}
+	}
+	// case 1: get reader err
+	v.EXPECT().FindFiles(uint32(10)).Return(files())
+	snapshot.EXPECT().GetReader(table.FileNumber(1)).Return(nil, fmt.Errorf("err"))
+	readers, err := fs.FindReaders(10)
+	assert.Error(t, err)
+	assert.Nil(t, readers)
+	// case 2: skip files without key
+	r1 := table.NewMockReader(ctrl)
+	r2 := table.NewMockReader(ctrl)
+	r3 := table.NewMockReader(ctrl)
+	r1.EXPECT().Contains(uint32(10)).Return(true)
+	r2.EXPECT().Contains(uint32(10)).Return(false)
+	r3.EXPECT().Contains(uint32(10)).Return(true)
+	v.EXPECT().FindFiles(uint32(10)).Return(files())
+	snapshot.EXPECT().GetReader(table.FileNumber(1)).Return(r1, nil)
+	snapshot.EXPECT().GetReader(table.FileNumber(2)).Return(r2, nil)
+	snapshot.EXPECT().GetReader(table.FileNumber(3)).Return(r3, nil)
+	readers, err = fs.FindReaders(10)
+	assert.NoError(t, err)
+	assert.Equal(t, []table.Reader{r1, r3}, readers)
+	assert.Equal(t, float64(6), fs.lookupFiles.Get())
+	assert.Equal(t, float64(1), fs.lookupSavedFiles.Get())
+	// case 3: reader not found
+	v.EXPECT().FindFiles(uint32(1)).Return(files()[:1])
+	snapshot.EXPECT().GetReader(table.FileNumber(1)).Return(nil, nil)
+	readers, err = fs.FindReaders(1)
+	assert.NoError(t, err)
+	assert.Empty(t, readers)
+}
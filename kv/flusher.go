@@ -101,6 +101,10 @@ func (sf *storeFlusher) Commit() (err error) {
 
 		fileMeta := version.NewFileMeta(builder.FileNumber(), builder.MinKey(), builder.MaxKey(), builder.Size())
 		sf.editLog.Add(version.CreateNewFile(0, fileMeta))
+		// new file need rollup into target intervals if family has rollup relation
+		for _, interval := range sf.family.getRollupIntervals() {
+			sf.editLog.Add(version.CreateNewRollupFile(fileMeta.GetFileNumber(), interval))
+		}
 	}
 
 	if flag := sf.family.commitEditLog(sf.editLog); !flag {
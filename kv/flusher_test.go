@@ -26,6 +26,7 @@ import (
 
 	"github.com/lindb/lindb/kv/table"
 	"github.com/lindb/lindb/kv/version"
+	"github.com/lindb/lindb/pkg/timeutil"
 )
 
 func TestFlusher_Add(t *testing.T) {
@@ -114,6 +115,7 @@ func TestStoreFlusher_Commit(t *testing.T) {
 		builder.EXPECT().MinKey().Return(uint32(1)),
 		builder.EXPECT().MaxKey().Return(uint32(10)),
 		builder.EXPECT().Size().Return(uint32(100)),
+		family.EXPECT().getRollupIntervals().Return(nil),
 		family.EXPECT().commitEditLog(gomock.Any()).Return(false),
 		builder.EXPECT().FileNumber().Return(table.FileNumber(10)),
 		family.EXPECT().removePendingOutput(table.FileNumber(10)),
@@ -131,7 +133,12 @@ func TestStoreFlusher_Commit(t *testing.T) {
 		builder.EXPECT().MinKey().Return(uint32(1)),
 		builder.EXPECT().MaxKey().Return(uint32(10)),
 		builder.EXPECT().Size().Return(uint32(100)),
-		family.EXPECT().commitEditLog(gomock.Any()).Return(true),
+		family.EXPECT().getRollupIntervals().Return([]timeutil.Interval{10 * 1000}),
+		family.EXPECT().commitEditLog(gomock.Any()).DoAndReturn(func(editLog version.EditLog) bool {
+			// new file + rollup file
+			assert.Len(t, editLog.GetLogs(), 2)
+			return true
+		}),
 		builder.EXPECT().FileNumber().Return(table.FileNumber(10)),
 		family.EXPECT().removePendingOutput(table.FileNumber(10)),
 	)
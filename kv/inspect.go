@@ -0,0 +1,91 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package kv
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/lindb/lindb/kv/table"
+	"github.com/lindb/lindb/kv/version"
+	"github.com/lindb/lindb/pkg/timeutil"
+)
+
+// FamilyManifest represents the live files of family decoded from store manifest.
+type FamilyManifest struct {
+	Option         FamilyOption
+	Levels         [][]*version.FileMeta                   // live files of each level
+	RollupFiles    map[table.FileNumber]timeutil.Interval   // rollup files waiting for rollup job
+	ReferenceFiles map[version.FamilyID][]table.FileNumber // files referenced by rollup target family
+}
+
+// FilePath returns the file path of family by file number.
+func (f *FamilyManifest) FilePath(storePath string, fileNumber table.FileNumber) string {
+	return filepath.Join(storePath, f.Option.Name, version.Table(fileNumber))
+}
+
+// StoreManifest represents the store option and the live files of all families.
+type StoreManifest struct {
+	Path           string
+	Option         StoreOption
+	NextFileNumber table.FileNumber
+	Families       []*FamilyManifest // sorted by family id
+}
+
+// LoadStoreManifest loads the manifest of store from path without modifying any file,
+// store must be closed(e.g. node stopped), used for inspecting store offline.
+func LoadStoreManifest(path string) (*StoreManifest, error) {
+	info := &storeInfo{}
+	if err := decodeTomlFunc(filepath.Join(path, version.Options), info); err != nil {
+		return nil, fmt.Errorf("load store info error:%s", err)
+	}
+	vs := newVersionSetFunc(path, nil, info.StoreOption.Levels)
+	defer func() {
+		_ = vs.Destroy()
+	}()
+	for familyName, familyOption := range info.Families {
+		vs.CreateFamilyVersion(familyName, version.FamilyID(familyOption.ID))
+	}
+	if err := vs.Load(); err != nil {
+		return nil, fmt.Errorf("load store manifest error:%s", err)
+	}
+	manifest := &StoreManifest{
+		Path:           path,
+		Option:         info.StoreOption,
+		NextFileNumber: vs.NextFileNumber(),
+	}
+	for familyName, familyOption := range info.Families {
+		snapshot := vs.GetFamilyVersion(familyName).GetSnapshot()
+		current := snapshot.GetCurrent()
+		familyManifest := &FamilyManifest{
+			Option:         familyOption,
+			Levels:         make([][]*version.FileMeta, info.StoreOption.Levels),
+			RollupFiles:    current.GetRollupFiles(),
+			ReferenceFiles: current.GetReferenceFiles(),
+		}
+		for level := 0; level < info.StoreOption.Levels; level++ {
+			familyManifest.Levels[level] = current.GetFiles(level)
+		}
+		snapshot.Close()
+		manifest.Families = append(manifest.Families, familyManifest)
+	}
+	sort.Slice(manifest.Families, func(i, j int) bool {
+		return manifest.Families[i].Option.ID < manifest.Families[j].Option.ID
+	})
+	return manifest, nil
+}
@@ -0,0 +1,87 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package kv
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/kv/table"
+	"github.com/lindb/lindb/pkg/fileutil"
+	"github.com/lindb/lindb/pkg/ltoml"
+)
+
+func TestLoadStoreManifest(t *testing.T) {
+	storePath := filepath.Join(testKVPath, "inspect")
+	defer func() {
+		decodeTomlFunc = ltoml.DecodeToml
+		_ = fileutil.RemoveDir(testKVPath)
+	}()
+	// case 1: store not exist
+	manifest, err := LoadStoreManifest(storePath)
+	assert.Error(t, err)
+	assert.Nil(t, manifest)
+
+	kv, err := NewStore("test_kv", DefaultStoreOption(storePath))
+	assert.NoError(t, err)
+	f, err := kv.CreateFamily("f", FamilyOption{Merger: mergerStr})
+	assert.NoError(t, err)
+	flusher := f.NewFlusher()
+	assert.NoError(t, flusher.Add(1, []byte("test")))
+	assert.NoError(t, flusher.Add(10, []byte("test10")))
+	assert.NoError(t, flusher.Commit())
+	_, err = kv.CreateFamily("empty", FamilyOption{Merger: mergerStr})
+	assert.NoError(t, err)
+	assert.NoError(t, kv.Close())
+
+	// case 2: load manifest
+	manifest, err = LoadStoreManifest(storePath)
+	assert.NoError(t, err)
+	assert.Equal(t, storePath, manifest.Path)
+	assert.Len(t, manifest.Families, 2)
+	family := manifest.Families[0]
+	assert.Equal(t, "f", family.Option.Name)
+	assert.Len(t, family.Levels, manifest.Option.Levels)
+	assert.Len(t, family.Levels[0], 1)
+	file := family.Levels[0][0]
+	assert.Equal(t, uint32(1), file.GetMinKey())
+	assert.Equal(t, uint32(10), file.GetMaxKey())
+	reader, err := table.NewReader(family.FilePath(storePath, file.GetFileNumber()))
+	assert.NoError(t, err)
+	value, err := reader.Get(10)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("test10"), value)
+	assert.NoError(t, reader.Close())
+	assert.Equal(t, "empty", manifest.Families[1].Option.Name)
+	assert.Empty(t, manifest.Families[1].Levels[0])
+
+	// case 3: current file not exist
+	assert.NoError(t, fileutil.RemoveFile(filepath.Join(storePath, "CURRENT")))
+	manifest, err = LoadStoreManifest(storePath)
+	assert.Error(t, err)
+	assert.Nil(t, manifest)
+	// case 4: decode store info err
+	decodeTomlFunc = func(fileName string, v interface{}) error {
+		return fmt.Errorf("err")
+	}
+	manifest, err = LoadStoreManifest(storePath)
+	assert.Error(t, err)
+	assert.Nil(t, manifest)
+}
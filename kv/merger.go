@@ -17,6 +17,10 @@
 
 package kv
 
+import (
+	"github.com/lindb/lindb/kv/table"
+)
+
 //go:generate mockgen -source ./merger.go -destination=./merger_mock.go -package kv
 
 // MergerType represents the merger type
@@ -46,3 +50,11 @@ type Merger interface {
 	// return err if failure
 	Merge(key uint32, values [][]byte) error
 }
+
+// VersionedMerger represents the merger which needs the file number of each value when merging,
+// value from newer file has larger file number(e.g. purges the values deleted by versioned tombstone).
+// Compaction job merges values by MergeVersioned if merger implements it.
+type VersionedMerger interface {
+	// MergeVersioned merges values for same key, fileNumbers[i] is the file number which values[i] comes from
+	MergeVersioned(key uint32, fileNumbers []table.FileNumber, values [][]byte) error
+}
@@ -25,6 +25,7 @@ type FamilyOption struct {
 	RollupThreshold  int    `toml:"rollupThreshold"`  // level 0 rollup threshold
 	Merger           string `toml:"merger"`           // merger which need implement Merger interface
 	MaxFileSize      uint32 `toml:"maxFileSize"`      // max file size
+	Compression      string `toml:"compression"`      // compression of values: none(default), snappy, zstd
 }
 
 // StoreOption defines config item for store level
@@ -47,6 +47,7 @@ var (
 	mkDirFunc         = fileutil.MkDir
 	removeFunc        = os.Remove
 	newFileLockFunc   = lockers.NewFileLock
+	linkOrCopyFunc    = fileutil.LinkOrCopyFile
 )
 
 // Store is kv store, supporting column family, but is different from other LSM implementation.
@@ -62,6 +63,9 @@ type Store interface {
 	Option() StoreOption
 	// RegisterRollup registers the rollup source/target relation
 	RegisterRollup(interval timeutil.Interval, rollup Rollup)
+	// Backup creates a point-in-time copy of store into target path,
+	// the copy can be opened as a store directly.
+	Backup(targetPath string) error
 	// Close closes store, then release some resource
 	Close() error
 
@@ -76,6 +80,8 @@ type Store interface {
 	evictFamilyFile(name string, fileNumber table.FileNumber)
 	// getRollup returns the rollup relation by interval
 	getRollup(interval timeutil.Interval) (Rollup, bool)
+	// getRollupIntervals returns the target intervals which the data of family need rollup into
+	getRollupIntervals(familyName string) []timeutil.Interval
 }
 
 // store implements Store interface
@@ -179,8 +185,9 @@ func NewStore(name string, option StoreOption) (s Store, err error) {
 		return nil, fmt.Errorf("recover store version set error:%s", err)
 	}
 
-	// schedule compact job
+	// schedule compact/rollup job
 	store1.scheduleCompactJob()
+	store1.scheduleRollupJob()
 	return store1, nil
 }
 
@@ -263,6 +270,49 @@ func (s *store) RegisterRollup(interval timeutil.Interval, rollup Rollup) {
 	s.rollupRelations[interval] = rollup
 }
 
+// Backup creates a point-in-time copy of store into target path,
+// the copy can be opened as a store directly.
+// 1. pins current version of all families, and writes the manifest of pinned versions;
+// 2. hard links(or copies) the table files of pinned versions, table file is immutable;
+// 3. writes store info(OPTIONS), after pinning versions, so all families of manifest are included.
+func (s *store) Backup(targetPath string) error {
+	if err := mkDirFunc(targetPath); err != nil {
+		return fmt.Errorf("create backup path error:%s", err)
+	}
+	snapshots, err := s.versions.Checkpoint(targetPath)
+	if err != nil {
+		return fmt.Errorf("checkpoint store version set error:%s", err)
+	}
+	defer func() {
+		for _, snapshot := range snapshots {
+			snapshot.Close()
+		}
+	}()
+	for familyName, snapshot := range snapshots {
+		familyPath := filepath.Join(targetPath, familyName)
+		if err := mkDirFunc(familyPath); err != nil {
+			return fmt.Errorf("create backup family path error:%s", err)
+		}
+		for _, file := range snapshot.GetCurrent().GetAllFiles() {
+			fileName := version.Table(file.GetFileNumber())
+			if err := linkOrCopyFunc(
+				filepath.Join(s.option.Path, familyName, fileName),
+				filepath.Join(familyPath, fileName)); err != nil {
+				return fmt.Errorf("backup table file[%s] of family[%s] error:%s", fileName, familyName, err)
+			}
+		}
+	}
+	s.rwMutex.RLock()
+	defer s.rwMutex.RUnlock()
+	infoPath := filepath.Join(targetPath, version.Options)
+	if err := encodeTomlFunc(infoPath, s.storeInfo); err != nil {
+		return fmt.Errorf("write store info to file[%s] error:%s", infoPath, err)
+	}
+	kvLogger.Info("backup store successfully",
+		logger.String("store", s.option.Path), logger.String("target", targetPath))
+	return nil
+}
+
 // Close closes store, then release some resource
 func (s *store) Close() error {
 	//FIXME stone1100 need if has background job doing(family compact/flush etc.)
@@ -284,10 +334,27 @@ func (s *store) evictFamilyFile(name string, fileNumber table.FileNumber) {
 
 // getRollup returns the rollup relation by interval
 func (s *store) getRollup(interval timeutil.Interval) (Rollup, bool) {
+	s.rwMutex.RLock()
+	defer s.rwMutex.RUnlock()
+
 	rollup, ok := s.rollupRelations[interval]
 	return rollup, ok
 }
 
+// getRollupIntervals returns the target intervals which the data of family need rollup into
+func (s *store) getRollupIntervals(familyName string) []timeutil.Interval {
+	s.rwMutex.RLock()
+	defer s.rwMutex.RUnlock()
+
+	var intervals []timeutil.Interval
+	for interval, rollup := range s.rollupRelations {
+		if rollup.ForFamily(familyName) != nil {
+			intervals = append(intervals, interval)
+		}
+	}
+	return intervals
+}
+
 // createFamilyVersion creates family version using family name and family id,
 // if family version exist, return exist one
 func (s *store) createFamilyVersion(name string, familyID version.FamilyID) version.FamilyVersion {
@@ -351,6 +418,41 @@ func (s *store) compact() {
 	}
 }
 
+// scheduleRollupJob schedules a rollup background job
+func (s *store) scheduleRollupJob() {
+	interval := defaultRollupCheckInterval
+	if s.option.RollupCheckInterval > 0 {
+		interval = s.option.RollupCheckInterval
+	}
+	ticker := time.NewTicker(time.Duration(interval) * time.Second)
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				s.rollup()
+			case <-s.ctx.Done():
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+}
+
+// rollup checks if family need do rollup, if need, does rollup job
+func (s *store) rollup() {
+	s.rwMutex.RLock()
+	families := make([]Family, 0, len(s.families))
+	for _, family := range s.families {
+		families = append(families, family)
+	}
+	s.rwMutex.RUnlock()
+	for _, family := range families {
+		if family.needRollup() {
+			family.rollup()
+		}
+	}
+}
+
 // deleteFamilyObsoleteFiles deletes the all families obsolete files when init kv store
 func (s *store) deleteFamilyObsoleteFiles() {
 	for _, family := range s.families {
@@ -18,6 +18,7 @@
 package kv
 
 import (
+	"bytes"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -33,6 +34,7 @@ import (
 	"github.com/lindb/lindb/pkg/fileutil"
 	"github.com/lindb/lindb/pkg/lockers"
 	"github.com/lindb/lindb/pkg/ltoml"
+	"github.com/lindb/lindb/pkg/timeutil"
 )
 
 var testKVPath = "./test_data"
@@ -188,6 +190,99 @@ func TestStore_CreateFamily(t *testing.T) {
 	assert.Equal(t, "f", names[0])
 }
 
+func TestStore_CreateFamily_Compression(t *testing.T) {
+	option := DefaultStoreOption(testKVPath)
+	defer func() {
+		_ = fileutil.RemoveDir(testKVPath)
+	}()
+
+	kv, err := NewStore("test_kv", option)
+	assert.NoError(t, err)
+	defer func() {
+		_ = kv.Close()
+	}()
+	f, err := kv.CreateFamily("f", FamilyOption{Merger: mergerStr, Compression: "zstd"})
+	assert.NoError(t, err)
+	assert.Equal(t, "zstd", kv.(*store).storeInfo.Families["f"].Compression)
+	flusher := f.NewFlusher()
+	assert.NoError(t, flusher.Add(1, []byte("test")))
+	assert.NoError(t, flusher.Add(10, bytes.Repeat([]byte("test10"), 100)))
+	assert.NoError(t, flusher.Commit())
+
+	snapshot := f.GetSnapshot()
+	defer snapshot.Close()
+	readers, err := snapshot.FindReaders(10)
+	assert.NoError(t, err)
+	assert.Len(t, readers, 1)
+	value, _ := readers[0].Get(1)
+	assert.Equal(t, []byte("test"), value)
+	value, _ = readers[0].Get(10)
+	assert.Equal(t, bytes.Repeat([]byte("test10"), 100), value)
+}
+
+func TestStore_Backup(t *testing.T) {
+	backupPath := filepath.Join(testKVPath, "backup")
+	defer func() {
+		linkOrCopyFunc = fileutil.LinkOrCopyFile
+		encodeTomlFunc = ltoml.EncodeToml
+		mkDirFunc = fileutil.MkDir
+		_ = fileutil.RemoveDir(testKVPath)
+		_ = fileutil.RemoveDir(backupPath)
+	}()
+	kv, err := NewStore("test_kv", DefaultStoreOption(filepath.Join(testKVPath, "store")))
+	assert.NoError(t, err)
+	f, err := kv.CreateFamily("f", FamilyOption{Merger: mergerStr})
+	assert.NoError(t, err)
+	flusher := f.NewFlusher()
+	assert.NoError(t, flusher.Add(1, []byte("test")))
+	assert.NoError(t, flusher.Commit())
+	_, err = kv.CreateFamily("empty", FamilyOption{Merger: mergerStr})
+	assert.NoError(t, err)
+
+	assert.NoError(t, kv.Backup(backupPath))
+	// data written after backup not included
+	flusher = f.NewFlusher()
+	assert.NoError(t, flusher.Add(2, []byte("test2")))
+	assert.NoError(t, flusher.Commit())
+	assert.NoError(t, kv.Close())
+
+	backup, err := NewStore("backup_kv", DefaultStoreOption(backupPath))
+	assert.NoError(t, err)
+	assert.Len(t, backup.ListFamilyNames(), 2)
+	snapshot := backup.GetFamily("f").GetSnapshot()
+	readers, err := snapshot.FindReaders(1)
+	assert.NoError(t, err)
+	assert.Len(t, readers, 1)
+	value, _ := readers[0].Get(1)
+	assert.Equal(t, []byte("test"), value)
+	readers, err = snapshot.FindReaders(2)
+	assert.NoError(t, err)
+	assert.Empty(t, readers)
+	snapshot.Close()
+	assert.NoError(t, backup.Close())
+
+	kv, err = NewStore("test_kv", DefaultStoreOption(filepath.Join(testKVPath, "store")))
+	assert.NoError(t, err)
+	defer func() {
+		_ = kv.Close()
+	}()
+	// case: write store info err
+	encodeTomlFunc = func(fileName string, v interface{}) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, kv.Backup(filepath.Join(testKVPath, "backup1")))
+	// case: link file err
+	linkOrCopyFunc = func(src, dst string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, kv.Backup(filepath.Join(testKVPath, "backup2")))
+	// case: mkdir err
+	mkDirFunc = func(path string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, kv.Backup(filepath.Join(testKVPath, "backup3")))
+}
+
 func TestStore_deleteObsoleteFiles(t *testing.T) {
 	option := DefaultStoreOption(testKVPath)
 	defer func() {
@@ -296,3 +391,53 @@ func TestStore_RegisterRollup(t *testing.T) {
 	assert.True(t, ok)
 	assert.Equal(t, rollup, rollup2)
 }
+
+func TestStore_Rollup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testKVPath)
+		ctrl.Finish()
+	}()
+	option := DefaultStoreOption(filepath.Join(testKVPath, "source"))
+	option.RollupCheckInterval = 1
+	source, err := NewStore("source_kv", option)
+	assert.NoError(t, err)
+	defer func() {
+		_ = source.Close()
+	}()
+	target, err := NewStore("target_kv", DefaultStoreOption(filepath.Join(testKVPath, "target")))
+	assert.NoError(t, err)
+	defer func() {
+		_ = target.Close()
+	}()
+	sf, err := source.CreateFamily("f", FamilyOption{RollupThreshold: 2, Merger: mergerStr})
+	assert.NoError(t, err)
+	tf, err := target.CreateFamily("f", FamilyOption{Merger: mergerStr})
+	assert.NoError(t, err)
+
+	rollup := NewMockRollup(ctrl)
+	rollup.EXPECT().ForFamily("f").Return(rollup).AnyTimes()
+	rollup.EXPECT().GetTargetFamily("f").Return(tf).AnyTimes()
+	source.RegisterRollup(timeutil.Interval(5*timeutil.OneMinute), rollup)
+	assert.Equal(t, []timeutil.Interval{timeutil.Interval(5 * timeutil.OneMinute)}, source.(*store).getRollupIntervals("f"))
+
+	for i := 0; i < 2; i++ {
+		flusher := sf.NewFlusher()
+		_ = flusher.Add(1, []byte("test"))
+		assert.NoError(t, flusher.Commit())
+	}
+	assert.Eventually(t, func() bool {
+		snapshot := tf.GetSnapshot()
+		defer snapshot.Close()
+		readers, err := snapshot.FindReaders(1)
+		if err != nil || len(readers) != 1 {
+			return false
+		}
+		value, _ := readers[0].Get(1)
+		return string(value) == "testtest"
+	}, 5*time.Second, 100*time.Millisecond)
+	// rolled up files are removed from rollup files of source family
+	assert.Eventually(t, func() bool {
+		return !sf.needRollup()
+	}, 5*time.Second, 100*time.Millisecond)
+}
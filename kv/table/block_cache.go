@@ -0,0 +1,182 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"container/list"
+	"sync"
+
+	"go.uber.org/atomic"
+
+	"github.com/lindb/lindb/internal/linmetric"
+)
+
+// blockEntryOverhead is the estimated memory overhead of each cached block(list element, map entry etc.)
+const blockEntryOverhead = 96
+
+var (
+	blockCacheScope      = linmetric.NewScope("lindb.kv.table.block_cache")
+	blockCacheHits       = blockCacheScope.NewCounter("hits")
+	blockCacheMisses     = blockCacheScope.NewCounter("misses")
+	blockCacheEvicts     = blockCacheScope.NewCounter("evicts")
+	blockCacheSizeGauge  = blockCacheScope.NewGauge("size")
+	blockCacheBlockGauge = blockCacheScope.NewGauge("blocks")
+)
+
+var (
+	// sharedBlockCache is the block cache shared by all table readers of families and shards, disabled by default
+	sharedBlockCache = newBlockCache(0)
+	// readerIDSequence generates the unique id of table reader for building block cache key,
+	// so that the cached blocks of other reader will never be hit even if file number is reused.
+	readerIDSequence atomic.Uint64
+)
+
+// SetBlockCacheSize sets the memory budget of block cache which is shared by all table readers,
+// 0 disables the block cache.
+func SetBlockCacheSize(size int64) {
+	sharedBlockCache.setCapacity(size)
+}
+
+// blockKey represents the key of cached block
+type blockKey struct {
+	readerID uint64
+	idx      int
+}
+
+// blockEntry represents the cached block
+type blockEntry struct {
+	key   blockKey
+	block []byte
+}
+
+// blockCache caches the decoded value blocks based on LRU with memory budget
+type blockCache struct {
+	capacity atomic.Int64 // read without lock for checking if cache enabled
+	size     int64
+	ll       *list.List
+	items    map[uint64]map[int]*list.Element // reader id => block idx => cached block
+	mutex    sync.Mutex
+}
+
+// newBlockCache creates a block cache with memory budget
+func newBlockCache(capacity int64) *blockCache {
+	c := &blockCache{
+		ll:    list.New(),
+		items: make(map[uint64]map[int]*list.Element),
+	}
+	c.capacity.Store(capacity)
+	return c
+}
+
+// setCapacity sets the memory budget, evicts blocks if exceed the new budget
+func (c *blockCache) setCapacity(capacity int64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if capacity < 0 {
+		capacity = 0
+	}
+	c.capacity.Store(capacity)
+	c.evict()
+}
+
+// enabled checks if block cache is enabled
+func (c *blockCache) enabled() bool {
+	return c.capacity.Load() > 0
+}
+
+// get returns the cached block, then marks it as most recently used
+func (c *blockCache) get(key blockKey) ([]byte, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if elem, ok := c.items[key.readerID][key.idx]; ok {
+		c.ll.MoveToFront(elem)
+		blockCacheHits.Incr()
+		return elem.Value.(*blockEntry).block, true
+	}
+	blockCacheMisses.Incr()
+	return nil, false
+}
+
+// put caches the block, evicts least recently used blocks if exceed the memory budget,
+// the block which is larger than the budget will not be cached.
+func (c *blockCache) put(key blockKey, block []byte) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	blockSize := int64(len(block) + blockEntryOverhead)
+	if blockSize > c.capacity.Load() {
+		return
+	}
+	blocks, ok := c.items[key.readerID]
+	if !ok {
+		blocks = make(map[int]*list.Element)
+		c.items[key.readerID] = blocks
+	}
+	if elem, ok := blocks[key.idx]; ok {
+		c.ll.MoveToFront(elem)
+		return
+	}
+	blocks[key.idx] = c.ll.PushFront(&blockEntry{key: key, block: block})
+	c.size += blockSize
+	c.evict()
+}
+
+// purge removes all cached blocks of reader, invoked when reader closed.
+func (c *blockCache) purge(readerID uint64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	blocks, ok := c.items[readerID]
+	if !ok {
+		return
+	}
+	delete(c.items, readerID)
+	for _, elem := range blocks {
+		entry := c.ll.Remove(elem).(*blockEntry)
+		c.size -= int64(len(entry.block) + blockEntryOverhead)
+	}
+	c.updateGauges()
+}
+
+// evict removes the least recently used blocks until the size is not larger than the memory budget
+func (c *blockCache) evict() {
+	capacity := c.capacity.Load()
+	for c.size > capacity {
+		elem := c.ll.Back()
+		if elem == nil {
+			break
+		}
+		entry := c.ll.Remove(elem).(*blockEntry)
+		blocks := c.items[entry.key.readerID]
+		delete(blocks, entry.key.idx)
+		if len(blocks) == 0 {
+			delete(c.items, entry.key.readerID)
+		}
+		c.size -= int64(len(entry.block) + blockEntryOverhead)
+		blockCacheEvicts.Incr()
+	}
+	c.updateGauges()
+}
+
+// updateGauges updates the size/blocks gauges of block cache.
+func (c *blockCache) updateGauges() {
+	blockCacheSizeGauge.Update(float64(c.size))
+	blockCacheBlockGauge.Update(float64(c.ll.Len()))
+}
@@ -0,0 +1,118 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockCache(t *testing.T) {
+	c := newBlockCache(0)
+	// case 1: disabled
+	assert.False(t, c.enabled())
+	c.put(blockKey{readerID: 1, idx: 1}, []byte("test"))
+	_, ok := c.get(blockKey{readerID: 1, idx: 1})
+	assert.False(t, ok)
+	// case 2: put/get
+	c.setCapacity(2 * (blockEntryOverhead + 4))
+	assert.True(t, c.enabled())
+	c.put(blockKey{readerID: 1, idx: 1}, []byte("1111"))
+	c.put(blockKey{readerID: 1, idx: 2}, []byte("2222"))
+	c.put(blockKey{readerID: 1, idx: 2}, []byte("2222"))
+	block, ok := c.get(blockKey{readerID: 1, idx: 1})
+	assert.True(t, ok)
+	assert.Equal(t, []byte("1111"), block)
+	// case 3: evict least recently used block
+	c.put(blockKey{readerID: 2, idx: 1}, []byte("3333"))
+	_, ok = c.get(blockKey{readerID: 1, idx: 2})
+	assert.False(t, ok)
+	_, ok = c.get(blockKey{readerID: 1, idx: 1})
+	assert.True(t, ok)
+	_, ok = c.get(blockKey{readerID: 2, idx: 1})
+	assert.True(t, ok)
+	// case 4: block larger than budget
+	c.put(blockKey{readerID: 3, idx: 1}, bytes.Repeat([]byte("1"), 1024))
+	_, ok = c.get(blockKey{readerID: 3, idx: 1})
+	assert.False(t, ok)
+	// case 5: shrink budget
+	c.setCapacity(blockEntryOverhead + 4)
+	assert.Equal(t, 1, c.ll.Len())
+	c.setCapacity(-1)
+	assert.Equal(t, 0, c.ll.Len())
+	assert.Empty(t, c.items)
+	assert.Zero(t, c.size)
+	// case 6: purge blocks of reader
+	c.setCapacity(3 * (blockEntryOverhead + 4))
+	c.put(blockKey{readerID: 1, idx: 1}, []byte("1111"))
+	c.put(blockKey{readerID: 1, idx: 2}, []byte("2222"))
+	c.put(blockKey{readerID: 2, idx: 1}, []byte("3333"))
+	c.purge(1)
+	c.purge(3)
+	_, ok = c.get(blockKey{readerID: 1, idx: 1})
+	assert.False(t, ok)
+	_, ok = c.get(blockKey{readerID: 2, idx: 1})
+	assert.True(t, ok)
+	assert.Equal(t, 1, c.ll.Len())
+	assert.Equal(t, int64(blockEntryOverhead+4), c.size)
+}
+
+func TestStoreMMapReader_BlockCache(t *testing.T) {
+	SetBlockCacheSize(1024 * 1024)
+	defer SetBlockCacheSize(0)
+
+	for _, compression := range []CompressionType{NoCompression, SnappyCompression} {
+		path := filepath.Join(t.TempDir(), "000010.sst")
+		builder, err := NewStoreBuilder(10, path, compression)
+		assert.NoError(t, err)
+		assert.NoError(t, builder.Add(1, []byte("test")))
+		assert.NoError(t, builder.Add(10, bytes.Repeat([]byte("test10"), 100)))
+		assert.NoError(t, builder.Close())
+
+		r, err := newMMapStoreReader(path)
+		assert.NoError(t, err)
+		reader := r.(*storeMMapReader)
+		// iterator does not fill block cache
+		it := r.Iterator()
+		assert.True(t, it.HasNext())
+		assert.Equal(t, uint32(1), it.Key())
+		value, err := it.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("test"), value)
+		_, ok := sharedBlockCache.get(blockKey{readerID: reader.id, idx: 0})
+		assert.False(t, ok)
+		// get fills block cache
+		for i := 0; i < 2; i++ {
+			value, err := r.Get(10)
+			assert.NoError(t, err)
+			assert.Equal(t, bytes.Repeat([]byte("test10"), 100), value)
+		}
+		block, ok := sharedBlockCache.get(blockKey{readerID: reader.id, idx: 1})
+		assert.True(t, ok)
+		assert.Equal(t, bytes.Repeat([]byte("test10"), 100), block)
+		_, err = reader.getCachedBlock(10)
+		assert.Error(t, err)
+		// cached blocks are purged when reader closed
+		assert.NoError(t, r.Close())
+		_, ok = sharedBlockCache.get(blockKey{readerID: reader.id, idx: 1})
+		assert.False(t, ok)
+	}
+}
@@ -18,6 +18,7 @@
 package table
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
 	"hash"
@@ -83,6 +84,8 @@ type Builder interface {
 	MaxKey() uint32
 	// Size returns the length of store file
 	Size() uint32
+	// RawSize returns the total length of values before compressing
+	RawSize() uint32
 	// Count returns the number of k/v pairs contained in the store
 	Count() uint64
 	// Abandon abandons current store build for some reason
@@ -114,10 +117,12 @@ type StreamWriter interface {
 
 // storeBuilder builds store file
 type storeBuilder struct {
-	fileNumber FileNumber
-	fileName   string
-	writer     bufioutil.BufioWriter
-	offset     *encoding.FixedOffsetEncoder
+	fileNumber  FileNumber
+	fileName    string
+	writer      bufioutil.BufioWriter
+	offset      *encoding.FixedOffsetEncoder
+	compression CompressionType
+	rawSize     uint32
 
 	// see paper of roaring bitmap: https://arxiv.org/pdf/1603.06549.pdf
 	keys   *roaring.Bitmap
@@ -127,19 +132,21 @@ type storeBuilder struct {
 	first bool
 }
 
-// NewStoreBuilder creates store builder instance for building store file
-func NewStoreBuilder(fileNumber FileNumber, fileName string) (Builder, error) {
+// NewStoreBuilder creates store builder instance for building store file,
+// the values will be compressed by given compression type.
+func NewStoreBuilder(fileNumber FileNumber, fileName string, compression CompressionType) (Builder, error) {
 	writer, err := newBufioWriterFunc(fileName)
 	if err != nil {
 		return nil, fmt.Errorf("create file write for store builder error:%s", err)
 	}
 	return &storeBuilder{
-		fileNumber: fileNumber,
-		fileName:   fileName,
-		keys:       roaring.New(),
-		writer:     writer,
-		first:      true,
-		offset:     encoding.NewFixedOffsetEncoder(true),
+		fileNumber:  fileNumber,
+		fileName:    fileName,
+		keys:        roaring.New(),
+		writer:      writer,
+		first:       true,
+		offset:      encoding.NewFixedOffsetEncoder(true),
+		compression: compression,
 	}, nil
 }
 
@@ -183,15 +190,24 @@ func (b *storeBuilder) Add(key uint32, value []byte) error {
 
 	// get write offset
 	offset := b.writer.Size()
-	if _, err := b.writer.Write(value); err != nil {
+	if _, err := b.writer.Write(b.encode(value)); err != nil {
 		return fmt.Errorf("write data into store file error:%s", err)
 	}
 	getBuilderStatistics().AddKeys.Incr()
 	getBuilderStatistics().AddBytes.Add(float64(len(value)))
+	b.rawSize += uint32(len(value))
 	b.afterWrite(key, int(offset))
 	return nil
 }
 
+// encode compresses the value if compression enabled
+func (b *storeBuilder) encode(value []byte) []byte {
+	if b.compression == NoCompression {
+		return value
+	}
+	return b.compression.compress(value)
+}
+
 // MinKey returns min key in store
 func (b *storeBuilder) MinKey() uint32 {
 	return b.minKey
@@ -207,6 +223,11 @@ func (b *storeBuilder) Size() uint32 {
 	return uint32(b.writer.Size())
 }
 
+// RawSize returns the total length of values before compressing
+func (b *storeBuilder) RawSize() uint32 {
+	return b.rawSize
+}
+
 // Count returns the number of k/v pairs contained in the store
 func (b *storeBuilder) Count() uint64 {
 	return b.keys.GetCardinality()
@@ -238,18 +259,34 @@ func (b *storeBuilder) Close() error {
 		return err
 	}
 
-	// for file footer for offsets/keys index, length=1+4+4+8
-	var buf [17]byte
-	binary.LittleEndian.PutUint32(buf[:4], uint32(posOfOffset))
-	binary.LittleEndian.PutUint32(buf[4:8], uint32(posOfKeys))
-	buf[8] = version0
-	binary.LittleEndian.PutUint64(buf[9:], magicNumberOffsetFile)
-	if _, err = b.writer.Write(buf[:]); err != nil {
+	if _, err = b.writer.Write(b.footer(uint32(posOfOffset), uint32(posOfKeys))); err != nil {
 		return err
 	}
 	return b.writer.Close()
 }
 
+// footer returns the file footer for offsets/keys index,
+// keeps version0 layout without compression, so that the file can be read by old version.
+func (b *storeBuilder) footer(posOfOffset, posOfKeys uint32) []byte {
+	if b.compression == NoCompression {
+		// length=4+4+1+8
+		var buf [sstFileFooterSize]byte
+		binary.LittleEndian.PutUint32(buf[:4], posOfOffset)
+		binary.LittleEndian.PutUint32(buf[4:8], posOfKeys)
+		buf[8] = version0
+		binary.LittleEndian.PutUint64(buf[9:], magicNumberOffsetFile)
+		return buf[:]
+	}
+	// length=4+4+1+1+8
+	var buf [sstFileFooterSizeV1]byte
+	binary.LittleEndian.PutUint32(buf[:4], posOfOffset)
+	binary.LittleEndian.PutUint32(buf[4:8], posOfKeys)
+	buf[8] = byte(b.compression)
+	buf[9] = version1
+	binary.LittleEndian.PutUint64(buf[10:], magicNumberOffsetFile)
+	return buf[:]
+}
+
 func (b *storeBuilder) StreamWriter() StreamWriter {
 	return newStreamWriter(b)
 }
@@ -269,6 +306,7 @@ type streamWriter struct {
 	offset  int64
 	badKey  bool
 	crc32   hash.Hash32
+	buf     bytes.Buffer // buffer of value for compressing
 }
 
 func (sw *streamWriter) Prepare(key uint32) {
@@ -277,13 +315,23 @@ func (sw *streamWriter) Prepare(key uint32) {
 	sw.key = key
 	sw.size = 0
 	sw.crc32.Reset()
+	sw.buf.Reset()
 }
 
 func (sw *streamWriter) Write(data []byte) (int, error) {
 	if sw.badKey {
 		return 0, nil
 	}
-	n, err := sw.builder.writer.Write(data)
+	var (
+		n   int
+		err error
+	)
+	if sw.builder.compression == NoCompression {
+		n, err = sw.builder.writer.Write(data)
+	} else {
+		// compresses the whole value when committing
+		n, err = sw.buf.Write(data)
+	}
 	_, _ = sw.crc32.Write(data)
 	if err == nil {
 		sw.size += uint32(n)
@@ -304,6 +352,12 @@ func (sw *streamWriter) Commit() error {
 	if sw.badKey {
 		return nil
 	}
+	if sw.builder.compression != NoCompression {
+		if _, err := sw.builder.writer.Write(sw.builder.encode(sw.buf.Bytes())); err != nil {
+			return fmt.Errorf("write data into store file error:%s", err)
+		}
+	}
+	sw.builder.rawSize += sw.size
 	sw.builder.afterWrite(sw.key, int(sw.offset))
 	// preventing committing twice
 	sw.badKey = true
@@ -52,7 +52,7 @@ func TestStoreBuilder_magicNumber(t *testing.T) {
 
 func TestStoreBuilder_BuildStore(t *testing.T) {
 	_ = fileutil.MkDirIfNotExist(testKVPath)
-	var builder, err = NewStoreBuilder(10, testKVPath+"/000010.sst")
+	var builder, err = NewStoreBuilder(10, testKVPath+"/000010.sst", NoCompression)
 	defer func() {
 		_ = os.RemoveAll(testKVPath)
 		_ = builder.Close()
@@ -90,7 +90,7 @@ func TestStoreBuilder_Build_Err(t *testing.T) {
 	newBufioWriterFunc = func(fileName string) (bufioutil.BufioWriter, error) {
 		return writer, nil
 	}
-	builder, err := NewStoreBuilder(10, testKVPath+"/000200.sst")
+	builder, err := NewStoreBuilder(10, testKVPath+"/000200.sst", NoCompression)
 	assert.NoError(t, err)
 	writer.EXPECT().Size().Return(int64(10)).AnyTimes()
 
@@ -130,7 +130,7 @@ func TestStoreBuilder_Build_Err(t *testing.T) {
 	newBufioWriterFunc = func(fileName string) (bufioutil.BufioWriter, error) {
 		return nil, fmt.Errorf("err")
 	}
-	builder, err = NewStoreBuilder(10, testKVPath+"/000200.sst")
+	builder, err = NewStoreBuilder(10, testKVPath+"/000200.sst", NoCompression)
 	assert.Error(t, err)
 	assert.Nil(t, builder)
 }
@@ -140,7 +140,7 @@ func TestStoreBuilder_Abandon(t *testing.T) {
 	defer func() {
 		_ = os.RemoveAll(testKVPath)
 	}()
-	builder, err := NewStoreBuilder(10, testKVPath+"/000010.sst")
+	builder, err := NewStoreBuilder(10, testKVPath+"/000010.sst", NoCompression)
 	assert.NoError(t, err)
 	_ = builder.Add(1, []byte("test"))
 	err = builder.Abandon()
@@ -148,7 +148,7 @@ func TestStoreBuilder_Abandon(t *testing.T) {
 }
 
 func Test_Builder_Stream_Writer(t *testing.T) {
-	var builder, err = NewStoreBuilder(10, filepath.Join(t.TempDir(), "/000010.sst"))
+	var builder, err = NewStoreBuilder(10, filepath.Join(t.TempDir(), "/000010.sst"), NoCompression)
 	defer func() {
 		_ = builder.Close()
 	}()
@@ -174,7 +174,7 @@ func Test_Builder_Stream_Writer(t *testing.T) {
 }
 
 func Test_StreamWriter_CheckSum32(t *testing.T) {
-	var builder, _ = NewStoreBuilder(10, filepath.Join(t.TempDir(), "/000011.sst"))
+	var builder, _ = NewStoreBuilder(10, filepath.Join(t.TempDir(), "/000011.sst"), NoCompression)
 	defer func() {
 		_ = builder.Close()
 	}()
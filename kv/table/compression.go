@@ -0,0 +1,114 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"fmt"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// CompressionType represents the compression algorithm of the values in sst file
+type CompressionType uint8
+
+// Defines all compression types of sst file
+const (
+	NoCompression CompressionType = iota
+	SnappyCompression
+	ZstdCompression
+)
+
+const (
+	// block flags, the first byte of each value if compression enabled
+	rawBlock        byte = 0
+	compressedBlock byte = 1
+)
+
+var (
+	// zstd encoder/decoder without concurrent stream are safe for EncodeAll/DecodeAll concurrently
+	zstdEncoder, _ = zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+	zstdDecoder, _ = zstd.NewReader(nil, zstd.WithDecoderConcurrency(1))
+)
+
+// ParseCompressionType returns the compression type by name, empty name means no compression
+func ParseCompressionType(name string) (CompressionType, error) {
+	switch name {
+	case "", "none":
+		return NoCompression, nil
+	case "snappy":
+		return SnappyCompression, nil
+	case "zstd":
+		return ZstdCompression, nil
+	default:
+		return NoCompression, fmt.Errorf("unknown compression type: %s", name)
+	}
+}
+
+// String returns the name of compression type
+func (t CompressionType) String() string {
+	switch t {
+	case NoCompression:
+		return "none"
+	case SnappyCompression:
+		return "snappy"
+	case ZstdCompression:
+		return "zstd"
+	default:
+		return "unknown"
+	}
+}
+
+// compress compresses the value into block(flag + data), if compressed data is not smaller, keeps raw data
+func (t CompressionType) compress(value []byte) []byte {
+	var block []byte
+	switch t {
+	case SnappyCompression:
+		buf := make([]byte, 1+snappy.MaxEncodedLen(len(value)))
+		block = buf[:1+len(snappy.Encode(buf[1:], value))]
+	case ZstdCompression:
+		block = zstdEncoder.EncodeAll(value, []byte{0})
+	}
+	if len(block) == 0 || len(block) > len(value) {
+		block = make([]byte, len(value)+1)
+		block[0] = rawBlock
+		copy(block[1:], value)
+		return block
+	}
+	block[0] = compressedBlock
+	return block
+}
+
+// decompress decompresses the block(flag + data) which written by compress
+func (t CompressionType) decompress(block []byte) ([]byte, error) {
+	if len(block) == 0 {
+		return nil, fmt.Errorf("empty block with compression: %s", t)
+	}
+	data := block[1:]
+	if block[0] == rawBlock {
+		return data, nil
+	}
+	switch t {
+	case SnappyCompression:
+		return snappy.Decode(nil, data)
+	case ZstdCompression:
+		return zstdDecoder.DecodeAll(data, nil)
+	default:
+		return nil, fmt.Errorf("unknown compression type: %d", t)
+	}
+}
@@ -0,0 +1,64 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCompressionType(t *testing.T) {
+	for _, c := range []CompressionType{NoCompression, SnappyCompression, ZstdCompression} {
+		compression, err := ParseCompressionType(c.String())
+		assert.NoError(t, err)
+		assert.Equal(t, c, compression)
+	}
+	compression, err := ParseCompressionType("")
+	assert.NoError(t, err)
+	assert.Equal(t, NoCompression, compression)
+	_, err = ParseCompressionType("lz4")
+	assert.Error(t, err)
+	assert.Equal(t, "unknown", CompressionType(10).String())
+}
+
+func TestCompressionType_compress(t *testing.T) {
+	value := bytes.Repeat([]byte("compression"), 100)
+	for _, c := range []CompressionType{SnappyCompression, ZstdCompression} {
+		block := c.compress(value)
+		assert.Equal(t, compressedBlock, block[0])
+		assert.Less(t, len(block), len(value))
+		data, err := c.decompress(block)
+		assert.NoError(t, err)
+		assert.Equal(t, value, data)
+		// keep raw data if compressed data is not smaller
+		block = c.compress([]byte{1})
+		assert.Equal(t, []byte{rawBlock, 1}, block)
+		data, err = c.decompress(block)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{1}, data)
+		// corrupted data
+		_, err = c.decompress([]byte{compressedBlock, 1, 2, 3})
+		assert.Error(t, err)
+		_, err = c.decompress(nil)
+		assert.Error(t, err)
+	}
+	_, err := CompressionType(10).decompress([]byte{compressedBlock, 1, 2, 3})
+	assert.Error(t, err)
+}
@@ -30,14 +30,23 @@ var (
 const (
 	// magic-number in the footer of sst file
 	magicNumberOffsetFile uint64 = 0x69632d656d656c65
-	// current file layout version
+	// file layout version without compression
 	version0 = 0
+	// file layout version with compression type in footer
+	version1 = 1
 
 	sstFileFooterSize = 4 + // posOfOffset(4)
 		4 + // posOfKeys(4)
 		1 + // version(1)
 		8 // magicNumber(8)
 	magicNumberAtFooter = 9
+	sstFileFooterSizeV1 = 4 + // posOfOffset(4)
+		4 + // posOfKeys(4)
+		1 + // compression(1)
+		1 + // version(1)
+		8 // magicNumber(8)
+	// version is always before magic-number, so that the version can be read before decoding footer
+	versionAtTail = 9
 )
 
 var tableLogger = logger.GetLogger("kv", "Table")
@@ -30,8 +30,9 @@ type Iterator interface {
 	HasNext() bool
 	// Key returns the key of the current key/value pair
 	Key() uint32
-	// Value returns the value of the current key/value pair
-	Value() []byte
+	// Value returns the value of the current key/value pair,
+	// returns err if read value failure(e.g. block corrupted).
+	Value() ([]byte, error)
 }
 
 /////////////
@@ -39,17 +40,27 @@ type Iterator interface {
 // reference:(https://golang.org/src/container/heap/example_pq_test.go)
 ////////////
 
+// MergedIterator iterates over some iterators in key order,
+// and knows which iterator the current key/value pair comes from.
+type MergedIterator interface {
+	Iterator
+	// Source returns the index of iterator(in given iterators) which current key/value pair comes from
+	Source() int
+}
+
 // mergedIterator iterates over some iterator in key order
 type mergedIterator struct {
 	its []Iterator
 	pq  priorityQueue
 
-	curKey   uint32
-	curValue []byte
+	curKey    uint32
+	curValue  []byte
+	curErr    error
+	curSource int
 }
 
 // NewMergedIterator create merged iterator for multi iterators
-func NewMergedIterator(its []Iterator) Iterator {
+func NewMergedIterator(its []Iterator) MergedIterator {
 	it := &mergedIterator{
 		its: its,
 	}
@@ -60,13 +71,17 @@ func NewMergedIterator(its []Iterator) Iterator {
 // initQueue initializes the priority queue
 func (m *mergedIterator) initQueue() {
 	i := 0
-	for _, it := range m.its {
+	for source, it := range m.its {
 		if it.HasNext() {
+			key := it.Key()
+			value, err := it.Value()
 			m.pq = append(m.pq, &item{
-				it:    it,
-				key:   it.Key(),
-				value: it.Value(),
-				index: i,
+				it:     it,
+				key:    key,
+				value:  value,
+				err:    err,
+				source: source,
+				index:  i,
 			})
 			i++
 		}
@@ -86,12 +101,14 @@ func (m *mergedIterator) HasNext() bool {
 		item := val.(*item)
 		m.curKey = item.key
 		m.curValue = item.value
+		m.curErr = item.err
+		m.curSource = item.source
 
 		// if it has value, push back queue and adjust priority
 		it := item.it
 		if it.HasNext() {
 			item.key = it.Key()
-			item.value = it.Value()
+			item.value, item.err = it.Value()
 			m.pq.Push(item)
 			m.pq.update(item)
 		}
@@ -105,17 +122,25 @@ func (m *mergedIterator) Key() uint32 {
 	return m.curKey
 }
 
-// Value returns the value of the current key/value pair
-func (m *mergedIterator) Value() []byte {
-	return m.curValue
+// Value returns the value of the current key/value pair,
+// returns the err of iterator which current key/value pair comes from if read value failure.
+func (m *mergedIterator) Value() ([]byte, error) {
+	return m.curValue, m.curErr
+}
+
+// Source returns the index of iterator(in given iterators) which current key/value pair comes from
+func (m *mergedIterator) Source() int {
+	return m.curSource
 }
 
 // item represents an item under priority queue, using key as priority.
 type item struct {
 	it Iterator
 
-	key   uint32
-	value []byte
+	key    uint32
+	value  []byte
+	err    error
+	source int // index of iterator in merged iterators
 
 	index int
 }
@@ -18,6 +18,7 @@
 package table
 
 import (
+	"fmt"
 	"sort"
 	"testing"
 
@@ -53,7 +54,9 @@ func TestMergedIterator(t *testing.T) {
 	i := 0
 	for mergedIt.HasNext() {
 		assert.Equal(t, keys[i], mergedIt.Key())
-		assert.Equal(t, expects[keys[i]], mergedIt.Value())
+		value, err := mergedIt.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, expects[keys[i]], value)
 		i++
 	}
 	assert.Equal(t, len(keys), i)
@@ -66,12 +69,15 @@ func TestMergedIterator(t *testing.T) {
 	it2 = NewMockIterator(ctrl)
 	// only one iterator has value
 	it2.EXPECT().HasNext().Return(false)
-	mergedIt = NewMergedIterator([]Iterator{it1, it2})
+	mergedIt = NewMergedIterator([]Iterator{it2, it1})
 	keys = []uint32{10, 100, 1000}
 	i = 0
 	for mergedIt.HasNext() {
 		assert.Equal(t, keys[i], mergedIt.Key())
-		assert.Equal(t, expects[keys[i]], mergedIt.Value())
+		value, err := mergedIt.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, expects[keys[i]], value)
+		assert.Equal(t, 1, mergedIt.Source())
 		i++
 	}
 	assert.Equal(t, len(keys), i)
@@ -105,7 +111,9 @@ func TestMergedIterator(t *testing.T) {
 	i = 0
 	for mergedIt.HasNext() {
 		assert.Equal(t, keys[i], mergedIt.Key())
-		assert.Equal(t, expects[keys[i]], mergedIt.Value())
+		value, err := mergedIt.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, expects[keys[i]], value)
 		i++
 	}
 	assert.Equal(t, len(keys), i)
@@ -147,12 +155,44 @@ func TestMergedIterator_complex(t *testing.T) {
 	i := 0
 	for mergedIt.HasNext() {
 		assert.Equal(t, keys[i], mergedIt.Key())
-		assert.Equal(t, expects[keys[i]], mergedIt.Value())
+		value, err := mergedIt.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, expects[keys[i]], value)
 		i++
 	}
 	assert.Equal(t, len(keys), i)
 }
 
+func TestMergedIterator_Value_Err(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	it1 := NewMockIterator(ctrl)
+	gomock.InOrder(
+		it1.EXPECT().HasNext().Return(true),
+		it1.EXPECT().Key().Return(uint32(1)),
+		it1.EXPECT().Value().Return([]byte("value1"), nil),
+		it1.EXPECT().HasNext().Return(true),
+		it1.EXPECT().Key().Return(uint32(10)),
+		it1.EXPECT().Value().Return(nil, fmt.Errorf("err")),
+		it1.EXPECT().HasNext().Return(false),
+	)
+	it2 := generateIterator(ctrl, map[uint32][]byte{5: []byte("value5")})
+	mergedIt := NewMergedIterator([]Iterator{it1, it2})
+	// err is returned with the key/value pair which read failure
+	for _, key := range []uint32{1, 5} {
+		assert.True(t, mergedIt.HasNext())
+		assert.Equal(t, key, mergedIt.Key())
+		_, err := mergedIt.Value()
+		assert.NoError(t, err)
+	}
+	assert.True(t, mergedIt.HasNext())
+	assert.Equal(t, uint32(10), mergedIt.Key())
+	_, err := mergedIt.Value()
+	assert.Error(t, err)
+	assert.False(t, mergedIt.HasNext())
+}
+
 func generateIterator(ctrl *gomock.Controller, values map[uint32][]byte) *MockIterator {
 	it1 := NewMockIterator(ctrl)
 	var keys []uint32
@@ -167,7 +207,7 @@ func generateIterator(ctrl *gomock.Controller, values map[uint32][]byte) *MockIt
 		calls = append(calls,
 			it1.EXPECT().HasNext().Return(true),
 			it1.EXPECT().Key().Return(key),
-			it1.EXPECT().Value().Return(values[key]))
+			it1.EXPECT().Value().Return(values[key], nil))
 	}
 	calls = append(calls, it1.EXPECT().HasNext().Return(false))
 
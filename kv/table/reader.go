@@ -74,6 +74,8 @@ type readerStatistics struct {
 type Reader interface {
 	// Path returns the file path
 	Path() string
+	// Contains checks if the key exists in store file by the key set of footer
+	Contains(key uint32) bool
 	// Get returns value for giving key,
 	// if key not exist, return nil, ErrKeyNotExist
 	Get(key uint32) ([]byte, error)
@@ -85,11 +87,19 @@ type Reader interface {
 
 // storeMMapReader represents mmap store file reader
 type storeMMapReader struct {
+	id           uint64                       // unique id of reader for caching blocks
 	path         string                       // path of sst-file
 	fullBlock    []byte                       // mmaped file content
 	entriesBlock []byte                       // mmaped file content without footer
 	keys         *roaring.Bitmap              // bitmap of keys
 	offsets      *encoding.FixedOffsetDecoder // offset of values
+	compression  CompressionType              // compression type of values
+}
+
+// NewReader creates the reader of store file without table cache,
+// used for reading store file directly(e.g. inspection tool).
+func NewReader(path string) (Reader, error) {
+	return newMMapStoreReader(path)
 }
 
 // newMMapStoreReader creates mmap store file reader
@@ -118,6 +128,7 @@ func newMMapStoreReader(path string) (r Reader, err error) {
 		return
 	}
 	reader := &storeMMapReader{
+		id:        readerIDSequence.Inc(),
 		path:      path,
 		fullBlock: data,
 		keys:      roaring.New(),
@@ -138,6 +149,17 @@ func (r *storeMMapReader) initialize() error {
 	if uint64Func(r.fullBlock[footerStart+magicNumberAtFooter:]) != magicNumberOffsetFile {
 		return fmt.Errorf("verify magic-number of sstfile:%s failure", r.path)
 	}
+	switch version := r.fullBlock[len(r.fullBlock)-versionAtTail]; version {
+	case version0:
+	case version1:
+		footerStart = len(r.fullBlock) - sstFileFooterSizeV1
+		if footerStart < 0 {
+			return fmt.Errorf("length of sstfile:%s length is too short", r.path)
+		}
+		r.compression = CompressionType(r.fullBlock[footerStart+8])
+	default:
+		return fmt.Errorf("unknown version: %d of sstfile:%s", version, r.path)
+	}
 	posOfOffset := int(binary.LittleEndian.Uint32(r.fullBlock[footerStart : footerStart+4]))
 	posOfKeys := int(binary.LittleEndian.Uint32(r.fullBlock[footerStart+4 : footerStart+8]))
 	if !sort.IntsAreSorted([]int{
@@ -169,6 +191,11 @@ func (r *storeMMapReader) Path() string {
 	return r.path
 }
 
+// Contains checks if the key exists in store file by the key set of footer
+func (r *storeMMapReader) Contains(key uint32) bool {
+	return r.keys.Contains(key)
+}
+
 // Get return value for key, if not exist return nil,false
 func (r *storeMMapReader) Get(key uint32) ([]byte, error) {
 	if !r.keys.Contains(key) {
@@ -176,11 +203,35 @@ func (r *storeMMapReader) Get(key uint32) ([]byte, error) {
 	}
 	// bitmap data's index from 1, so idx= get index - 1
 	idx := r.keys.Rank(key)
-	return r.getBlock(int(idx) - 1)
+	return r.getCachedBlock(int(idx) - 1)
+}
+
+// getCachedBlock returns the block from block cache, if not exist reads it from file then caches it
+func (r *storeMMapReader) getCachedBlock(idx int) ([]byte, error) {
+	if !sharedBlockCache.enabled() {
+		return r.getBlock(idx)
+	}
+	key := blockKey{readerID: r.id, idx: idx}
+	if block, ok := sharedBlockCache.get(key); ok {
+		return block, nil
+	}
+	block, err := r.getBlock(idx)
+	if err != nil {
+		return nil, err
+	}
+	if r.compression == NoCompression {
+		// copy the block from mmap file, so that the cached block is not affected by os page cache
+		block = append([]byte(nil), block...)
+	}
+	sharedBlockCache.put(key, block)
+	return block, nil
 }
 
 func (r *storeMMapReader) getBlock(idx int) ([]byte, error) {
 	block, err := r.offsets.GetBlock(idx, r.entriesBlock)
+	if err == nil && r.compression != NoCompression {
+		block, err = r.compression.decompress(block)
+	}
 	if err == nil {
 		getReaderStatistics().getCounts.Incr()
 		getReaderStatistics().getBytes.Add(float64(len(block)))
@@ -197,6 +248,8 @@ func (r *storeMMapReader) Iterator() Iterator {
 
 // Close store reader, release resource
 func (r *storeMMapReader) Close() error {
+	// cached blocks of reader will never be hit after closed(file maybe deleted after compaction)
+	sharedBlockCache.purge(r.id)
 	r.entriesBlock = nil
 	err := fileutil.Unmap(r.fullBlock)
 	if err == nil {
@@ -235,9 +288,13 @@ func (it *storeMMapIterator) Key() uint32 {
 	return key
 }
 
-// Value returns the value of the current key/value pair
-func (it *storeMMapIterator) Value() []byte {
-	block, _ := it.reader.getBlock(it.idx)
+// Value returns the value of the current key/value pair,
+// reads the block from file directly without filling block cache(e.g. compaction reads all blocks once).
+func (it *storeMMapIterator) Value() ([]byte, error) {
+	block, err := it.reader.getBlock(it.idx)
 	it.idx++
-	return block
+	if err != nil {
+		return nil, fmt.Errorf("read block: %d of file: %s, error: %w", it.idx-1, it.reader.path, err)
+	}
+	return block, nil
 }
@@ -18,9 +18,12 @@
 package table
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/lindb/roaring"
@@ -70,7 +73,7 @@ func TestStoreMMapReader_readBytes_Err(t *testing.T) {
 		encoding.BitmapUnmarshal = bitmapUnmarshal
 		_ = os.RemoveAll(testKVPath)
 	}()
-	builder, err := NewStoreBuilder(10, testKVPath+"/000010.sst")
+	builder, err := NewStoreBuilder(10, testKVPath+"/000010.sst", NoCompression)
 	assert.NoError(t, err)
 
 	_ = builder.Add(1, []byte("test"))
@@ -121,7 +124,7 @@ func TestReader(t *testing.T) {
 		_ = os.RemoveAll(testKVPath)
 	}()
 
-	builder, err := NewStoreBuilder(10, testKVPath+"/000010.sst")
+	builder, err := NewStoreBuilder(10, testKVPath+"/000010.sst", NoCompression)
 	assert.NoError(t, err)
 
 	_ = builder.Add(1, []byte("test"))
@@ -145,6 +148,8 @@ func TestReader(t *testing.T) {
 	defer func() {
 		_ = reader.Close()
 	}()
+	assert.True(t, reader.Contains(10))
+	assert.False(t, reader.Contains(5))
 	value, err := reader.Get(100)
 	assert.Error(t, err)
 	assert.Nil(t, value)
@@ -157,6 +162,16 @@ func TestReader(t *testing.T) {
 	_ = reader.Close()
 	cache.Evict("", "000010.sst")
 	_ = cache.Close()
+
+	// read file directly without cache
+	fileReader, err := NewReader(testKVPath + "/000010.sst")
+	assert.NoError(t, err)
+	value, _ = fileReader.Get(10)
+	assert.Equal(t, []byte("test10"), value)
+	assert.NoError(t, fileReader.Close())
+	fileReader, err = NewReader(testKVPath + "/000011.sst")
+	assert.Error(t, err)
+	assert.Nil(t, fileReader)
 }
 
 func TestStoreIterator(t *testing.T) {
@@ -164,7 +179,7 @@ func TestStoreIterator(t *testing.T) {
 	defer func() {
 		_ = os.RemoveAll(testKVPath)
 	}()
-	builder, err := NewStoreBuilder(10, testKVPath+"/000010.sst")
+	builder, err := NewStoreBuilder(10, testKVPath+"/000010.sst", NoCompression)
 	assert.NoError(t, err)
 
 	_ = builder.Add(1, []byte("test"))
@@ -183,11 +198,92 @@ func TestStoreIterator(t *testing.T) {
 	it := reader.Iterator()
 	assert.True(t, it.HasNext())
 	assert.Equal(t, uint32(1), it.Key())
-	assert.Equal(t, []byte("test"), it.Value())
+	value, err := it.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("test"), value)
 
 	assert.True(t, it.HasNext())
 	assert.Equal(t, uint32(10), it.Key())
-	assert.Equal(t, []byte("test10"), it.Value())
+	value, err = it.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("test10"), value)
 
 	assert.False(t, it.HasNext())
 }
+
+func TestStoreMMapReader_Compression(t *testing.T) {
+	for _, compression := range []CompressionType{SnappyCompression, ZstdCompression} {
+		path := filepath.Join(t.TempDir(), "000010.sst")
+		builder, err := NewStoreBuilder(10, path, compression)
+		assert.NoError(t, err)
+		value := bytes.Repeat([]byte("test10"), 100)
+		assert.NoError(t, builder.Add(1, []byte("test")))
+		assert.NoError(t, builder.Add(10, value))
+		writer := builder.StreamWriter()
+		writer.Prepare(20)
+		_, _ = writer.Write(value[:300])
+		_, _ = writer.Write(value[300:])
+		assert.NoError(t, writer.Commit())
+		assert.Equal(t, uint32(4+600+600), builder.RawSize())
+		assert.NoError(t, builder.Close())
+		assert.Less(t, builder.Size(), builder.RawSize())
+
+		r, err := newMMapStoreReader(path)
+		assert.NoError(t, err)
+		v, err := r.Get(1)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("test"), v)
+		v, err = r.Get(10)
+		assert.NoError(t, err)
+		assert.Equal(t, value, v)
+		it := r.Iterator()
+		for _, key := range []uint32{1, 10, 20} {
+			assert.True(t, it.HasNext())
+			assert.Equal(t, key, it.Key())
+			v, err = it.Value()
+			assert.NoError(t, err)
+			assert.NotEmpty(t, v)
+		}
+		assert.NoError(t, r.Close())
+	}
+}
+
+func TestStoreMMapIterator_Value_Err(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "000010.sst")
+	builder, err := NewStoreBuilder(10, path, SnappyCompression)
+	assert.NoError(t, err)
+	assert.NoError(t, builder.Add(1, bytes.Repeat([]byte("test"), 100)))
+	assert.NoError(t, builder.Close())
+	// corrupt the compressed block
+	data, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	for i := 0; i < 5; i++ {
+		data[i] = 0xff
+	}
+	assert.NoError(t, ioutil.WriteFile(path, data, 0644))
+
+	r, err := newMMapStoreReader(path)
+	assert.NoError(t, err)
+	it := r.Iterator()
+	assert.True(t, it.HasNext())
+	assert.Equal(t, uint32(1), it.Key())
+	value, err := it.Value()
+	assert.Error(t, err)
+	assert.Nil(t, value)
+	assert.NoError(t, r.Close())
+}
+
+func TestStoreMMapReader_unknown_version(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "000010.sst")
+	builder, err := NewStoreBuilder(10, path, NoCompression)
+	assert.NoError(t, err)
+	assert.NoError(t, builder.Add(1, []byte("test")))
+	assert.NoError(t, builder.Close())
+	data, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	data[len(data)-versionAtTail] = 100
+	assert.NoError(t, ioutil.WriteFile(path, data, 0644))
+	r, err := newMMapStoreReader(path)
+	assert.Error(t, err)
+	assert.Nil(t, r)
+}
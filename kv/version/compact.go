@@ -17,7 +17,10 @@
 
 package version
 
-import "github.com/lindb/lindb/kv/table"
+import (
+	"github.com/lindb/lindb/kv/table"
+	"github.com/lindb/lindb/pkg/timeutil"
+)
 
 // Compaction represents the compaction job context
 type Compaction struct {
@@ -27,6 +30,10 @@ type Compaction struct {
 	levelInputs   []*FileMeta
 	levelUpInputs []*FileMeta
 
+	// rollup compaction merges the files of source family into target family
+	rollup         bool
+	sourceFamilyID FamilyID
+
 	editLog EditLog
 }
 
@@ -41,11 +48,29 @@ func NewCompaction(familyID FamilyID, level int, levelInputs, levelUpInputs []*F
 	}
 }
 
+// NewRollupCompaction creates a rollup job context, which merges the files of source family
+// into level0 of target family, only file number of input file is used for reading source file.
+func NewRollupCompaction(familyID, sourceFamilyID FamilyID, sourceFiles []table.FileNumber) *Compaction {
+	levelInputs := make([]*FileMeta, len(sourceFiles))
+	for idx, fileNumber := range sourceFiles {
+		levelInputs[idx] = NewFileMeta(fileNumber, 0, 0, 0)
+	}
+	c := NewCompaction(familyID, -1, levelInputs, nil)
+	c.rollup = true
+	c.sourceFamilyID = sourceFamilyID
+	return c
+}
+
 // IsTrivialMove returns a trivial compaction that can be implemented by just
 // moving a single input file to the next level (no merging or splitting).
 // returns true: can just moving file to the next level
 func (c *Compaction) IsTrivialMove() bool {
-	return len(c.levelInputs) == 1 && len(c.levelUpInputs) == 0
+	return !c.rollup && len(c.levelInputs) == 1 && len(c.levelUpInputs) == 0
+}
+
+// IsRollup returns if the compaction is rollup job
+func (c *Compaction) IsRollup() bool {
+	return c.rollup
 }
 
 // GetLevelFiles returns low level files
@@ -63,8 +88,26 @@ func (c *Compaction) AddFile(level int, file *FileMeta) {
 	c.editLog.Add(CreateNewFile(int32(level), file))
 }
 
+// AddRollupFile adds a new file which need rollup into target interval
+func (c *Compaction) AddRollupFile(fileNumber table.FileNumber, interval timeutil.Interval) {
+	c.editLog.Add(CreateNewRollupFile(fileNumber, interval))
+}
+
+// DeleteReferenceFile deletes a reference file of source family which need not be kept
+func (c *Compaction) DeleteReferenceFile(sourceFamilyID FamilyID, fileNumber table.FileNumber) {
+	c.editLog.Add(CreateDeleteReferenceFile(sourceFamilyID, fileNumber))
+}
+
 // MarkInputDeletes marks all inputs of this compaction as deletion, adds log to version edit.
+// For rollup job, the inputs belong to source family, so marks them as reference files of source family,
+// then the files cannot be rolled up again before source family removes them from rollup files.
 func (c *Compaction) MarkInputDeletes() {
+	if c.rollup {
+		for _, input := range c.levelInputs {
+			c.editLog.Add(CreateNewReferenceFile(c.sourceFamilyID, input.fileNumber))
+		}
+		return
+	}
 	for _, input := range c.levelInputs {
 		c.editLog.Add(NewDeleteFile(int32(c.level), input.fileNumber))
 	}
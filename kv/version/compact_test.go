@@ -21,6 +21,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/kv/table"
+	"github.com/lindb/lindb/pkg/timeutil"
 )
 
 func TestCompaction(t *testing.T) {
@@ -48,3 +51,21 @@ func TestCompaction(t *testing.T) {
 	compaction.DeleteFile(0, 2)
 	assert.False(t, compaction.GetEditLog().IsEmpty())
 }
+
+func TestCompaction_Rollup(t *testing.T) {
+	compaction := NewRollupCompaction(1, 2, []table.FileNumber{3})
+	assert.True(t, compaction.IsRollup())
+	assert.False(t, compaction.IsTrivialMove())
+	assert.Equal(t, -1, compaction.GetLevel())
+	assert.Equal(t, table.FileNumber(3), compaction.GetLevelFiles()[0].GetFileNumber())
+	compaction.DeleteReferenceFile(2, 1)
+	compaction.MarkInputDeletes()
+	compaction.AddFile(0, &FileMeta{fileNumber: 6, minKey: 10, maxKey: 1001})
+	compaction.AddRollupFile(6, timeutil.Interval(timeutil.OneHour))
+	assert.Equal(t, []Log{
+		CreateDeleteReferenceFile(2, 1),
+		CreateNewReferenceFile(2, 3),
+		CreateNewFile(0, &FileMeta{fileNumber: 6, minKey: 10, maxKey: 1001}),
+		CreateNewRollupFile(6, timeutil.Interval(timeutil.OneHour)),
+	}, compaction.GetEditLog().GetLogs())
+}
@@ -51,6 +51,8 @@ type FamilyVersion interface {
 	GetLiveRollupFiles() map[table.FileNumber]timeutil.Interval
 	// GetLiveReferenceFiles returns all rollup reference files
 	GetLiveReferenceFiles() map[FamilyID][]table.FileNumber
+	// NumOfRef returns the number of reference of all active versions
+	NumOfRef() int32
 	// removeVersion removes version from active versions
 	removeVersion(v Version)
 	// appendVersion swaps family's current version, then releases previous version
@@ -137,6 +139,17 @@ func (fv *familyVersion) GetLiveReferenceFiles() map[FamilyID][]table.FileNumber
 	return fv.current.GetReferenceFiles()
 }
 
+// NumOfRef returns the number of reference of all active versions
+func (fv *familyVersion) NumOfRef() int32 {
+	fv.mutex.RLock()
+	defer fv.mutex.RUnlock()
+	var ref int32
+	for _, v := range fv.activeVersions {
+		ref += v.NumOfRef()
+	}
+	return ref
+}
+
 // removeVersion removes version from active versions,
 // cannot remove current version from active versions.
 func (fv *familyVersion) removeVersion(v Version) {
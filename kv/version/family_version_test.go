@@ -65,6 +65,7 @@ func TestFamilyVersion(t *testing.T) {
 	snapshot2 := familyVersion1.GetSnapshot()
 	assert.Equal(t, 2, len(fv.activeVersions), "version list !=2")
 	assert.Equal(t, version2, snapshot2.GetCurrent(), "get wrong current version")
+	assert.Equal(t, int32(2), familyVersion1.NumOfRef())
 	assert.Equal(t, 3, len(familyVersion1.GetAllActiveFiles()), "file list != 3")
 
 	// delete file1
@@ -99,6 +100,7 @@ func TestFamilyVersion(t *testing.T) {
 	version1 = snapshot2.GetCurrent()
 	version2 = version1.Clone()
 	snapshot2.Close()
+	assert.Equal(t, int32(0), familyVersion1.NumOfRef())
 
 	familyVersion1.appendVersion(version2)
 	fv = familyVersion1.(*familyVersion)
@@ -218,6 +218,15 @@ func (v *version) Clone() Version {
 			newVersion.AddFile(level, file)
 		}
 	}
+	// rollup metadata need be kept in new version, otherwise files not rollup will be lost
+	for fileNumber, interval := range v.GetRollupFiles() {
+		newVersion.AddRollupFile(fileNumber, interval)
+	}
+	for familyID, fileNumbers := range v.GetReferenceFiles() {
+		for _, fileNumber := range fileNumbers {
+			newVersion.AddReferenceFile(familyID, fileNumber)
+		}
+	}
 	return newVersion
 }
 
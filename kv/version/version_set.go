@@ -48,6 +48,9 @@ var (
 type StoreVersionSet interface {
 	// Recover recover version set if exist, recover been invoked when kv store init.
 	Recover() error
+	// Load loads version set from current manifest file without initializing journal writer,
+	// never modifies any file, used for reading store offline(e.g. inspection tool).
+	Load() error
 	// Destroy closes version set, release resource, such as journal writer etc.
 	Destroy() error
 	// NextFileNumber generates next file number
@@ -61,6 +64,9 @@ type StoreVersionSet interface {
 	CreateFamilyVersion(family string, familyID FamilyID) FamilyVersion
 	// GetFamilyVersion returns family version if exist, else return nil
 	GetFamilyVersion(family string) FamilyVersion
+	// Checkpoint pins current version of all families, then persists the manifest of pinned versions into target path,
+	// returns the snapshots of pinned versions(family name => snapshot), invoker must close those snapshots.
+	Checkpoint(targetPath string) (map[string]Snapshot, error)
 
 	// newVersionID generates new version id
 	newVersionID() int64
@@ -200,6 +206,46 @@ func (vs *storeVersionSet) GetFamilyVersion(family string) FamilyVersion {
 	return nil
 }
 
+// Checkpoint pins current version of all families, then persists the manifest of pinned versions into target path,
+// returns the snapshots of pinned versions(family name => snapshot), invoker must close those snapshots.
+func (vs *storeVersionSet) Checkpoint(targetPath string) (snapshots map[string]Snapshot, err error) {
+	// hold lock for pinning versions of all families at the same point(no edit log committing)
+	vs.mutex.RLock()
+	snapshots = make(map[string]Snapshot, len(vs.familyVersions))
+	var editLogs []EditLog
+	for familyID, familyName := range vs.familyIDs {
+		snapshot := vs.familyVersions[familyName].GetSnapshot()
+		snapshots[familyName] = snapshot
+		editLogs = append(editLogs, vs.createVersionEditLog(familyID, snapshot.GetCurrent()))
+	}
+	editLogs = append(editLogs, vs.createStoreSnapshot())
+	manifestFileName := ManifestFileName(table.FileNumber(vs.manifestFileNumber.Load()))
+	vs.mutex.RUnlock()
+
+	defer func() {
+		if err != nil {
+			for _, snapshot := range snapshots {
+				snapshot.Close()
+			}
+		}
+	}()
+	writer, err := newBufferWriterFunc(filepath.Join(targetPath, manifestFileName))
+	if err != nil {
+		return nil, err
+	}
+	err = vs.persistEditLogs(writer, editLogs)
+	if closeErr := writer.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = writeCurrentFile(targetPath, manifestFileName); err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
+
 // Recover recover version set if exist, recover been invoked when kv store init.
 // Initialize if version file not exists, else recover old data then init journal writer.
 func (vs *storeVersionSet) Recover() error {
@@ -221,6 +267,12 @@ func (vs *storeVersionSet) Recover() error {
 	return nil
 }
 
+// Load loads version set from current manifest file without initializing journal writer,
+// never modifies any file, used for reading store offline(e.g. inspection tool).
+func (vs *storeVersionSet) Load() error {
+	return vs.recover()
+}
+
 // recover does recover logic, read journal wal record and recover it
 func (vs *storeVersionSet) recover() error {
 	manifestFileName, err := vs.readManifestFileName()
@@ -341,13 +393,18 @@ func (vs *storeVersionSet) newVersionID() int64 {
 
 // setCurrent writes manifest file name into CURRENT file
 func (vs *storeVersionSet) setCurrent(manifestFile string) error {
-	current := vs.getCurrentPath()
-	tmp := fmt.Sprintf("%s.%s", current, TmpSuffix)
+	return writeCurrentFile(vs.storePath, manifestFile)
+}
+
+// writeCurrentFile writes manifest file name into CURRENT file under store path
+func writeCurrentFile(storePath, manifestFile string) error {
+	currentPath := filepath.Join(storePath, current())
+	tmp := fmt.Sprintf("%s.%s", currentPath, TmpSuffix)
 	// write manifest file name into current file
 	if err := writeFileFunc(tmp, []byte(manifestFile), 0666); err != nil {
 		return fmt.Errorf("write manifest file name into current tmp file error:%s", err)
 	}
-	if err := renameFunc(tmp, current); err != nil {
+	if err := renameFunc(tmp, currentPath); err != nil {
 		return fmt.Errorf("rename current tmp file name to current error:%s", err)
 	}
 	return nil
@@ -378,11 +435,16 @@ func (vs *storeVersionSet) createSnapshot() (editLogs []EditLog) {
 
 // createFamilySnapshot creates snapshot of edit log for family level
 func (vs *storeVersionSet) createFamilySnapshot(familyID FamilyID, familyVersion FamilyVersion) EditLog {
-	editLog := NewEditLog(familyID)
 	// save current version all active files
 	snapshot := familyVersion.GetSnapshot()
 	defer snapshot.Close()
-	levels := snapshot.GetCurrent().Levels()
+	return vs.createVersionEditLog(familyID, snapshot.GetCurrent())
+}
+
+// createVersionEditLog creates edit log which includes all active files of given version
+func (vs *storeVersionSet) createVersionEditLog(familyID FamilyID, version Version) EditLog {
+	editLog := NewEditLog(familyID)
+	levels := version.Levels()
 	for numOfLevel, level := range levels {
 		files := level.getFiles()
 		for _, file := range files {
@@ -391,6 +453,15 @@ func (vs *storeVersionSet) createFamilySnapshot(familyID FamilyID, familyVersion
 			editLog.Add(newFile)
 		}
 	}
+	// rollup files/reference files of version
+	for fileNumber, interval := range version.GetRollupFiles() {
+		editLog.Add(CreateNewRollupFile(fileNumber, interval))
+	}
+	for sourceFamilyID, fileNumbers := range version.GetReferenceFiles() {
+		for _, fileNumber := range fileNumbers {
+			editLog.Add(CreateNewReferenceFile(sourceFamilyID, fileNumber))
+		}
+	}
 	return editLog
 }
 
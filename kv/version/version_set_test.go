@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -29,6 +30,7 @@ import (
 	"github.com/lindb/lindb/kv/table"
 	"github.com/lindb/lindb/pkg/bufioutil"
 	"github.com/lindb/lindb/pkg/fileutil"
+	"github.com/lindb/lindb/pkg/timeutil"
 )
 
 var vsTestPath = "test_data"
@@ -206,6 +208,8 @@ func TestCommitFamilyEditLog(t *testing.T) {
 	nf := nFile.(*newFile)
 	editLog.Add(nFile)
 	editLog.Add(NewDeleteFile(1, 123))
+	editLog.Add(CreateNewRollupFile(12, 10))
+	editLog.Add(CreateNewReferenceFile(10, 5))
 	err = vs.CommitFamilyEditLog("f", editLog)
 	assert.Nil(t, err, "commit family edit log error")
 
@@ -222,6 +226,8 @@ func TestCommitFamilyEditLog(t *testing.T) {
 		snapshot := familyVersion.GetSnapshot()
 		vs1 := vs.(*storeVersionSet)
 		assert.Equal(t, nf.file, snapshot.GetCurrent().GetAllFiles()[0], "cannot recover family version data")
+		assert.Equal(t, map[table.FileNumber]timeutil.Interval{12: 10}, snapshot.GetCurrent().GetRollupFiles())
+		assert.Equal(t, map[FamilyID][]table.FileNumber{10: {5}}, snapshot.GetCurrent().GetReferenceFiles())
 		assert.Equal(t, int64(3+i), vs1.nextFileNumber.Load(), "recover file number error")
 		snapshot.Close()
 
@@ -306,3 +312,99 @@ func destroyVersionTestData() {
 		fmt.Println("delete test path error")
 	}
 }
+
+func TestStoreVersionSet_Load(t *testing.T) {
+	initVersionSetTestData()
+	ctrl := gomock.NewController(t)
+	defer func() {
+		destroyVersionTestData()
+		ctrl.Finish()
+	}()
+	cache := table.NewMockCache(ctrl)
+	vs := NewStoreVersionSet(vsTestPath, cache, 2)
+	// case 1: current file not exist
+	assert.Error(t, vs.Load())
+	familyID := FamilyID(1)
+	vs.CreateFamilyVersion("f", familyID)
+	assert.NoError(t, vs.Recover())
+	editLog := NewEditLog(familyID)
+	editLog.Add(CreateNewFile(0, NewFileMeta(12, 1, 100, 2014)))
+	assert.NoError(t, vs.CommitFamilyEditLog("f", editLog))
+	manifestFileNumber := vs.ManifestFileNumber()
+	_ = vs.Destroy()
+	files, err := ioutil.ReadDir(vsTestPath)
+	assert.NoError(t, err)
+
+	// case 2: load version set without writing new manifest
+	vs = NewStoreVersionSet(vsTestPath, cache, 2)
+	vs.CreateFamilyVersion("f", familyID)
+	assert.NoError(t, vs.Load())
+	assert.Equal(t, manifestFileNumber, vs.ManifestFileNumber())
+	snapshot := vs.GetFamilyVersion("f").GetSnapshot()
+	assert.Equal(t, []*FileMeta{NewFileMeta(12, 1, 100, 2014)}, snapshot.GetCurrent().GetAllFiles())
+	snapshot.Close()
+	_ = vs.Destroy()
+	files2, err := ioutil.ReadDir(vsTestPath)
+	assert.NoError(t, err)
+	assert.Equal(t, len(files), len(files2))
+}
+
+func TestStoreVersionSet_Checkpoint(t *testing.T) {
+	initVersionSetTestData()
+	ctrl := gomock.NewController(t)
+	defer func() {
+		destroyVersionTestData()
+		newBufferWriterFunc = bufioutil.NewBufioEntryWriter
+		writeFileFunc = ioutil.WriteFile
+		ctrl.Finish()
+	}()
+	cache := table.NewMockCache(ctrl)
+	vs := NewStoreVersionSet(vsTestPath, cache, 2)
+	familyID := FamilyID(1)
+	vs.CreateFamilyVersion("f", familyID)
+	assert.NoError(t, vs.Recover())
+	editLog := NewEditLog(familyID)
+	nFile := CreateNewFile(0, NewFileMeta(12, 1, 100, 2014))
+	editLog.Add(nFile)
+	assert.NoError(t, vs.CommitFamilyEditLog("f", editLog))
+
+	checkpointPath := filepath.Join(vsTestPath, "checkpoint")
+	assert.NoError(t, fileutil.MkDirIfNotExist(checkpointPath))
+	snapshots, err := vs.Checkpoint(checkpointPath)
+	assert.NoError(t, err)
+	assert.Len(t, snapshots, 1)
+	// pinned version not changed after committing new edit log
+	editLog = NewEditLog(familyID)
+	editLog.Add(NewDeleteFile(0, 12))
+	assert.NoError(t, vs.CommitFamilyEditLog("f", editLog))
+	assert.Len(t, snapshots["f"].GetCurrent().GetAllFiles(), 1)
+	snapshots["f"].Close()
+	_ = vs.Destroy()
+
+	// recover version set from checkpoint
+	vs = NewStoreVersionSet(checkpointPath, cache, 2)
+	vs.CreateFamilyVersion("f", familyID)
+	assert.NoError(t, vs.Recover())
+	snapshot := vs.GetFamilyVersion("f").GetSnapshot()
+	assert.Equal(t, []*FileMeta{NewFileMeta(12, 1, 100, 2014)}, snapshot.GetCurrent().GetAllFiles())
+	snapshot.Close()
+	_ = vs.Destroy()
+
+	// case: create manifest err
+	newBufferWriterFunc = func(fileName string) (bufioutil.BufioWriter, error) {
+		return nil, fmt.Errorf("err")
+	}
+	vs = NewStoreVersionSet(vsTestPath, cache, 2)
+	vs.CreateFamilyVersion("f", familyID)
+	snapshots, err = vs.Checkpoint(checkpointPath)
+	assert.Error(t, err)
+	assert.Nil(t, snapshots)
+	// case: write current err
+	newBufferWriterFunc = bufioutil.NewBufioEntryWriter
+	writeFileFunc = func(filename string, data []byte, perm os.FileMode) error {
+		return fmt.Errorf("err")
+	}
+	snapshots, err = vs.Checkpoint(checkpointPath)
+	assert.Error(t, err)
+	assert.Nil(t, snapshots)
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/kv/table"
+	"github.com/lindb/lindb/pkg/timeutil"
 )
 
 func TestVersion_New(t *testing.T) {
@@ -87,10 +88,14 @@ func TestVersion_Files(t *testing.T) {
 	assert.Equal(t, 1, v.NumberOfFilesInLevel(0))
 	assert.Equal(t, 1, v.NumberOfFilesInLevel(1))
 
+	v.AddRollupFile(1, 10)
+	v.AddReferenceFile(10, 1)
 	vs.EXPECT().newVersionID().Return(int64(2))
 	v2 := v.Clone()
 	assert.Equal(t, 1, v2.NumberOfFilesInLevel(0))
 	assert.Equal(t, 1, v2.NumberOfFilesInLevel(1))
+	assert.Equal(t, map[table.FileNumber]timeutil.Interval{1: 10}, v2.GetRollupFiles())
+	assert.Equal(t, map[FamilyID][]table.FileNumber{10: {1}}, v2.GetReferenceFiles())
 
 	assert.Nil(t, v.GetFiles(-1))
 	assert.Nil(t, v.GetFiles(3))
@@ -37,9 +37,74 @@ type Database struct {
 	NumOfShard    int                   `json:"numOfShard"`              // num. of shard
 	ReplicaFactor int                   `json:"replicaFactor"`           // replica refactor
 	Option        option.DatabaseOption `json:"option"`                  // time series database option
+	Limits        *DatabaseLimits       `json:"limits,omitempty"`        // write limits of database/namespace
 	Desc          string                `json:"desc,omitempty"`
 }
 
+// WriteLimits represents the write rate limits, no limit if value <= 0.
+type WriteLimits struct {
+	RowsPerSecond      int64 `json:"rowsPerSecond,omitempty"`
+	BytesPerSecond     int64 `json:"bytesPerSecond,omitempty"`
+	NewSeriesPerMinute int64 `json:"newSeriesPerMinute,omitempty"`
+}
+
+// IsEmpty returns if there is no limit.
+func (l WriteLimits) IsEmpty() bool {
+	return l.RowsPerSecond <= 0 && l.BytesPerSecond <= 0 && l.NewSeriesPerMinute <= 0
+}
+
+// Validate checks if the write limits are valid.
+func (l WriteLimits) Validate() error {
+	if l.RowsPerSecond < 0 || l.BytesPerSecond < 0 || l.NewSeriesPerMinute < 0 {
+		return fmt.Errorf("write limit cannot be negative")
+	}
+	return nil
+}
+
+// NamespaceLimits represents the write limits of namespace.
+type NamespaceLimits struct {
+	Namespace string `json:"namespace"`
+	WriteLimits
+}
+
+// DatabaseLimits represents the write limits of database and its namespaces,
+// limits are enforced by each broker node.
+type DatabaseLimits struct {
+	WriteLimits                   // limits of whole database
+	Namespaces  []NamespaceLimits `json:"namespaces,omitempty"` // limits of each namespace
+}
+
+// IsEmpty returns if there is no limit of database and its namespaces.
+func (l *DatabaseLimits) IsEmpty() bool {
+	for _, limits := range l.Namespaces {
+		if !limits.IsEmpty() {
+			return false
+		}
+	}
+	return l.WriteLimits.IsEmpty()
+}
+
+// Validate checks if the database limits are valid.
+func (l *DatabaseLimits) Validate() error {
+	if err := l.WriteLimits.Validate(); err != nil {
+		return err
+	}
+	namespaces := make(map[string]struct{})
+	for _, limits := range l.Namespaces {
+		if limits.Namespace == "" {
+			return fmt.Errorf("namespace of write limits cannot be empty")
+		}
+		if _, ok := namespaces[limits.Namespace]; ok {
+			return fmt.Errorf("duplicate write limits of namespace[%s]", limits.Namespace)
+		}
+		namespaces[limits.Namespace] = struct{}{}
+		if err := limits.Validate(); err != nil {
+			return fmt.Errorf("namespace[%s]: %w", limits.Namespace, err)
+		}
+	}
+	return nil
+}
+
 // String returns the database's description.
 func (db Database) String() string {
 	result := "create database " + db.Name + " with "
@@ -44,3 +44,26 @@ func TestDatabase_String(t *testing.T) {
 	}
 	assert.Equal(t, "create database test with shard 10, replica 1, interval 10s", database.String())
 }
+
+func TestDatabaseLimits_Validate(t *testing.T) {
+	limits := &DatabaseLimits{}
+	assert.NoError(t, limits.Validate())
+	assert.True(t, limits.IsEmpty())
+
+	limits.RowsPerSecond = -1
+	assert.Error(t, limits.Validate())
+
+	limits.RowsPerSecond = 10
+	assert.False(t, limits.IsEmpty())
+	limits.Namespaces = []NamespaceLimits{{Namespace: "ns", WriteLimits: WriteLimits{BytesPerSecond: -1}}}
+	assert.Error(t, limits.Validate())
+	limits.Namespaces = []NamespaceLimits{{WriteLimits: WriteLimits{BytesPerSecond: 1}}}
+	assert.Error(t, limits.Validate())
+	limits.Namespaces = []NamespaceLimits{{Namespace: "ns"}, {Namespace: "ns"}}
+	assert.Error(t, limits.Validate())
+
+	limits.Namespaces = []NamespaceLimits{{Namespace: "ns", WriteLimits: WriteLimits{NewSeriesPerMinute: 10}}}
+	assert.NoError(t, limits.Validate())
+	limits.RowsPerSecond = 0
+	assert.False(t, limits.IsEmpty())
+}
@@ -0,0 +1,119 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package models
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// RuleAction represents the action of ingestion rule.
+type RuleAction string
+
+const (
+	// DropMetric drops the metric whose name matches Metric regex.
+	DropMetric RuleAction = "drop_metric"
+	// DropTag drops the tags whose key matches Regex.
+	DropTag RuleAction = "drop_tag"
+	// RenameTag renames tag key from TagKey to TargetTagKey.
+	RenameTag RuleAction = "rename_tag"
+	// ReplaceTagValue rewrites value of TagKey with Replacement if value matches Regex,
+	// capture groups of Regex can be used in Replacement, like $1.
+	ReplaceTagValue RuleAction = "replace_tag_value"
+	// AddTag adds static tag TagKey=TagValue, overwrites it if tag exists.
+	AddTag RuleAction = "add_tag"
+	// DropField drops the fields whose name matches Regex.
+	DropField RuleAction = "drop_field"
+)
+
+// IngestionRule defines a relabel/drop rule applied in broker before writing,
+// all regexes are fully anchored.
+type IngestionRule struct {
+	Action       RuleAction `json:"action" binding:"required"`
+	Metric       string     `json:"metric,omitempty"` // metric name regex, rule applies for all metrics if empty
+	TagKey       string     `json:"tagKey,omitempty"`
+	TargetTagKey string     `json:"targetTagKey,omitempty"`
+	TagValue     string     `json:"tagValue,omitempty"`
+	Regex        string     `json:"regex,omitempty"`
+	Replacement  string     `json:"replacement,omitempty"`
+}
+
+// Validate checks if the rule is valid for its action.
+func (r *IngestionRule) Validate() error {
+	if r.Metric != "" {
+		if _, err := CompileAnchoredRegex(r.Metric); err != nil {
+			return fmt.Errorf("bad metric regex of rule: %w", err)
+		}
+	}
+	switch r.Action {
+	case DropMetric:
+		if r.Metric == "" {
+			return fmt.Errorf("metric regex cannot be empty for action: %s", r.Action)
+		}
+	case DropTag, DropField:
+		if r.Regex == "" {
+			return fmt.Errorf("regex cannot be empty for action: %s", r.Action)
+		}
+	case RenameTag:
+		if r.TagKey == "" || r.TargetTagKey == "" {
+			return fmt.Errorf("tag key/target tag key cannot be empty for action: %s", r.Action)
+		}
+		if r.TagKey == r.TargetTagKey {
+			return fmt.Errorf("tag key cannot be same as target tag key for action: %s", r.Action)
+		}
+	case ReplaceTagValue:
+		if r.TagKey == "" || r.Regex == "" {
+			return fmt.Errorf("tag key/regex cannot be empty for action: %s", r.Action)
+		}
+	case AddTag:
+		if r.TagKey == "" || r.TagValue == "" {
+			return fmt.Errorf("tag key/tag value cannot be empty for action: %s", r.Action)
+		}
+	default:
+		return fmt.Errorf("unknown action of rule: %s", r.Action)
+	}
+	if r.Regex != "" {
+		if _, err := CompileAnchoredRegex(r.Regex); err != nil {
+			return fmt.Errorf("bad regex of rule: %w", err)
+		}
+	}
+	return nil
+}
+
+// IngestionRules defines the ingestion rules of database, rules are applied in order.
+type IngestionRules struct {
+	Database string          `json:"database" binding:"required"`
+	Rules    []IngestionRule `json:"rules"`
+}
+
+// Validate checks if all rules are valid.
+func (r *IngestionRules) Validate() error {
+	if r.Database == "" {
+		return fmt.Errorf("database name cannot be empty")
+	}
+	for idx := range r.Rules {
+		if err := r.Rules[idx].Validate(); err != nil {
+			return fmt.Errorf("rule[%d]: %w", idx, err)
+		}
+	}
+	return nil
+}
+
+// CompileAnchoredRegex compiles the regex which matches the whole string.
+func CompileAnchoredRegex(expr string) (*regexp.Regexp, error) {
+	return regexp.Compile("^(?:" + expr + ")$")
+}
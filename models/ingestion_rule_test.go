@@ -0,0 +1,64 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIngestionRules_Validate(t *testing.T) {
+	assert.Error(t, (&IngestionRules{}).Validate())
+	assert.NoError(t, (&IngestionRules{Database: "db"}).Validate())
+
+	cases := []struct {
+		rule  IngestionRule
+		valid bool
+	}{
+		{IngestionRule{Action: "unknown"}, false},
+		{IngestionRule{Action: DropMetric}, false},
+		{IngestionRule{Action: DropMetric, Metric: "("}, false},
+		{IngestionRule{Action: DropMetric, Metric: "cpu.*"}, true},
+		{IngestionRule{Action: DropTag}, false},
+		{IngestionRule{Action: DropTag, Regex: "("}, false},
+		{IngestionRule{Action: DropTag, Regex: "request_id|pod_.*"}, true},
+		{IngestionRule{Action: DropField, Regex: "f1"}, true},
+		{IngestionRule{Action: RenameTag, TagKey: "host"}, false},
+		{IngestionRule{Action: RenameTag, TagKey: "host", TargetTagKey: "ip"}, true},
+		{IngestionRule{Action: RenameTag, TagKey: "host", TargetTagKey: "host"}, false},
+		{IngestionRule{Action: ReplaceTagValue, TagKey: "pod"}, false},
+		{IngestionRule{Action: ReplaceTagValue, TagKey: "pod", Regex: "(.*)-[a-z0-9]+", Replacement: "$1"}, true},
+		{IngestionRule{Action: AddTag, TagKey: "env"}, false},
+		{IngestionRule{Action: AddTag, TagKey: "env", TagValue: "prod"}, true},
+	}
+	for _, c := range cases {
+		err := (&IngestionRules{Database: "db", Rules: []IngestionRule{c.rule}}).Validate()
+		if c.valid {
+			assert.NoError(t, err, c.rule)
+		} else {
+			assert.Error(t, err, c.rule)
+		}
+	}
+}
+
+func TestCompileAnchoredRegex(t *testing.T) {
+	r, err := CompileAnchoredRegex("a|b")
+	assert.NoError(t, err)
+	assert.True(t, r.MatchString("a"))
+	assert.False(t, r.MatchString("ab"))
+}
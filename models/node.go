@@ -36,7 +36,9 @@ type Node interface {
 type StatefulNode struct {
 	StatelessNode
 
-	ID NodeID `json:"id"`
+	ID   NodeID `json:"id"`
+	Zone string `json:"zone,omitempty"` // availability zone label of failure domain
+	Rack string `json:"rack,omitempty"` // rack label of failure domain
 }
 
 // StatelessNode represents stateless node basic info.
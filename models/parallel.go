@@ -64,4 +64,7 @@ type Leaf struct {
 
 	Receivers []StatelessNode `json:"receivers"`
 	ShardIDs  []ShardID       `json:"shardIDs"`
+	// ShardStates are the states of shards which leader is this leaf node,
+	// used for writing delete record into write ahead log of shard.
+	ShardStates []ShardState `json:"shardStates,omitempty"`
 }
@@ -0,0 +1,80 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package models
+
+// MigrationKind represents the kind of shard migration.
+type MigrationKind string
+
+const (
+	// MoveReplica moves the replica of shard from one storage node to another,
+	// add new replica => catch up => switch leader => remove old replica.
+	MoveReplica MigrationKind = "MoveReplica"
+	// TransferLeader transfers the leader of shard to another replica.
+	TransferLeader MigrationKind = "TransferLeader"
+)
+
+// MigrationState represents the state of shard migration.
+type MigrationState string
+
+const (
+	MigrationPending MigrationState = "Pending"
+	MigrationRunning MigrationState = "Running"
+	MigrationDone    MigrationState = "Done"
+	MigrationFailed  MigrationState = "Failed"
+)
+
+// ShardMigration represents a migration of shard's replica/leader between storage nodes.
+type ShardMigration struct {
+	Kind    MigrationKind  `json:"kind"`
+	ShardID ShardID        `json:"shardId"`
+	From    NodeID         `json:"from"`
+	To      NodeID         `json:"to"`
+	State   MigrationState `json:"state"`
+	ErrMsg  string         `json:"errMsg,omitempty"`
+}
+
+// RebalanceProgress represents the progress of shard rebalance for database.
+type RebalanceProgress struct {
+	Database   string            `json:"database"`
+	Storage    string            `json:"storage"`
+	StartTime  int64             `json:"startTime"`
+	EndTime    int64             `json:"endTime"`
+	Migrations []*ShardMigration `json:"migrations"`
+}
+
+// Done returns if all migrations are finished(done or failed).
+func (p *RebalanceProgress) Done() bool {
+	for _, m := range p.Migrations {
+		if m.State == MigrationPending || m.State == MigrationRunning {
+			return false
+		}
+	}
+	return true
+}
+
+// Stats returns the num. of done and failed migrations.
+func (p *RebalanceProgress) Stats() (done, failed int) {
+	for _, m := range p.Migrations {
+		switch m.State {
+		case MigrationDone:
+			done++
+		case MigrationFailed:
+			failed++
+		}
+	}
+	return
+}
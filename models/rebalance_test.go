@@ -0,0 +1,38 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRebalanceProgress(t *testing.T) {
+	p := &RebalanceProgress{Migrations: []*ShardMigration{
+		{Kind: MoveReplica, State: MigrationDone},
+		{Kind: TransferLeader, State: MigrationRunning},
+		{Kind: MoveReplica, State: MigrationFailed},
+	}}
+	assert.False(t, p.Done())
+	done, failed := p.Stats()
+	assert.Equal(t, 1, done)
+	assert.Equal(t, 1, failed)
+
+	p.Migrations[1].State = MigrationDone
+	assert.True(t, p.Done())
+}
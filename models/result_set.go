@@ -29,6 +29,7 @@ type ResultSet struct {
 	EndTime    int64       `json:"endTime,omitempty"`
 	Interval   int64       `json:"interval,omitempty"`
 	Series     []*Series   `json:"series,omitempty"`
+	Exemplars  []*Exemplar `json:"exemplars,omitempty"`
 	Stats      *QueryStats `json:"stats,omitempty"`
 }
 
@@ -79,3 +80,12 @@ func NewPoints() *Points {
 func (p *Points) AddPoint(timestamp int64, value float64) {
 	p.Points[timestamp] = value
 }
+
+// Exemplar represents a sampled trace which links the field value to trace.
+type Exemplar struct {
+	Field     string `json:"field"`
+	Timestamp int64  `json:"timestamp"`
+	TraceID   string `json:"traceID"`          // hex encoding
+	SpanID    string `json:"spanID,omitempty"` // hex encoding
+	Duration  int64  `json:"duration"`
+}
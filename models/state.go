@@ -19,6 +19,7 @@ package models
 
 import (
 	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/ltoml"
 )
 
 type ShardStateType int
@@ -41,6 +42,28 @@ type ReplicaState struct {
 	Follower NodeID  `json:"follower"`
 }
 
+// ReplicaPeerState represents the replication state of a replica peer(leader->follower).
+// local replica peer: leader == follower or follower is current node,
+// remote replica peer: follower is not current node.
+type ReplicaPeerState struct {
+	ReplicaState
+
+	AppendIndex     int64 `json:"appendIndex"`     // next index for appending message into write ahead log
+	ReplicaIndex    int64 `json:"replicaIndex"`    // next index for replicating to follower
+	AckIndex        int64 `json:"ackIndex"`        // index acked by follower
+	Lag             int64 `json:"lag"`             // number of messages which are not replicated
+	LagBytes        int64 `json:"lagBytes"`        // approximate bytes of messages which are not replicated
+	LastReplicaTime int64 `json:"lastReplicaTime"` // timestamp of last successful replication
+	Ready           bool  `json:"ready"`           // if replicator is ready for replicating
+}
+
+// NodeReplicaState represents the replication state of all replica peers on a storage node.
+type NodeReplicaState struct {
+	NodeID     NodeID             `json:"nodeId"`
+	ReportTime int64              `json:"reportTime"`
+	Peers      []ReplicaPeerState `json:"peers"`
+}
+
 // ShardState represents current state of shard.
 type ShardState struct {
 	ID      ShardID        `json:"id"`
@@ -59,13 +82,27 @@ type FamilyState struct {
 // StorageState represents storage cluster state.
 // NOTICE: it is not safe for concurrent use. //TODO need concurrent safe????
 type StorageState struct {
-	Name string `json:"name"`
+	Name        string `json:"name"`
+	PrimaryZone string `json:"primaryZone,omitempty"` // leaders of shards are preferred in primary zone
 
 	LiveNodes map[NodeID]StatefulNode `json:"liveNodes"`
 
 	//TODO remove??
 	ShardAssignments map[string]*ShardAssignment       `json:"shardAssignments"` // database's name => shard assignment
 	ShardStates      map[string]map[ShardID]ShardState `json:"shardStates"`      // database's name => shard state
+
+	DrainingNodes       []NodeID `json:"drainingNodes,omitempty"`       // nodes which are moving leadership/data away
+	DecommissionedNodes []NodeID `json:"decommissionedNodes,omitempty"` // nodes which are drained, can be stopped safely
+
+	ReplicaStates []NodeReplicaState `json:"replicaStates,omitempty"` // replication state reported by storage nodes
+}
+
+// NodeDrain represents the running drain of storage node, persisted for resuming drain when master changed.
+type NodeDrain struct {
+	NodeID          NodeID         `json:"nodeId"`
+	MigrateReplicas bool           `json:"migrateReplicas"` // migrate replicas of node to other live nodes
+	Timeout         ltoml.Duration `json:"timeout"`         // max time waiting write ahead log acked by replicas
+	StartTime       int64          `json:"startTime"`
 }
 
 // NewStorageState creates storage cluster state
@@ -113,8 +150,107 @@ func (s *StorageState) NodeOffline(nodeID NodeID) {
 	delete(s.LiveNodes, nodeID)
 }
 
+// NodeDraining marks the node as draining, new leaders/replicas will not be assigned to it.
+func (s *StorageState) NodeDraining(nodeID NodeID) {
+	s.DecommissionedNodes = removeNode(s.DecommissionedNodes, nodeID)
+	if !s.IsDraining(nodeID) {
+		s.DrainingNodes = append(s.DrainingNodes, nodeID)
+	}
+}
+
+// NodeDecommissioned marks the node as decommissioned after it is drained.
+func (s *StorageState) NodeDecommissioned(nodeID NodeID) {
+	s.DrainingNodes = removeNode(s.DrainingNodes, nodeID)
+	if !s.IsDecommissioned(nodeID) {
+		s.DecommissionedNodes = append(s.DecommissionedNodes, nodeID)
+	}
+}
+
+// NodeRecommissioned removes the draining/decommissioned mark of the node.
+func (s *StorageState) NodeRecommissioned(nodeID NodeID) {
+	s.DrainingNodes = removeNode(s.DrainingNodes, nodeID)
+	s.DecommissionedNodes = removeNode(s.DecommissionedNodes, nodeID)
+}
+
+// IsDraining returns if the node is draining.
+func (s *StorageState) IsDraining(nodeID NodeID) bool {
+	return containsNode(s.DrainingNodes, nodeID)
+}
+
+// IsDecommissioned returns if the node is decommissioned.
+func (s *StorageState) IsDecommissioned(nodeID NodeID) bool {
+	return containsNode(s.DecommissionedNodes, nodeID)
+}
+
+// UnavailableNodes returns the nodes which are draining or decommissioned.
+func (s *StorageState) UnavailableNodes() []NodeID {
+	var nodes []NodeID
+	nodes = append(nodes, s.DrainingNodes...)
+	nodes = append(nodes, s.DecommissionedNodes...)
+	return nodes
+}
+
+// UpdateReplicaState sets the replication state reported by storage node.
+func (s *StorageState) UpdateReplicaState(state NodeReplicaState) {
+	for idx := range s.ReplicaStates {
+		if s.ReplicaStates[idx].NodeID == state.NodeID {
+			s.ReplicaStates[idx] = state
+			return
+		}
+	}
+	s.ReplicaStates = append(s.ReplicaStates, state)
+}
+
+// RemoveReplicaState removes the replication state reported by storage node.
+func (s *StorageState) RemoveReplicaState(nodeID NodeID) {
+	var states []NodeReplicaState
+	for _, state := range s.ReplicaStates {
+		if state.NodeID != nodeID {
+			states = append(states, state)
+		}
+	}
+	s.ReplicaStates = states
+}
+
+// GetReplicaPeerState returns the replication state of shard from leader to follower which is reported by leader,
+// returns the report time of leader and if the state exists.
+func (s *StorageState) GetReplicaPeerState(database string, shardID ShardID, follower NodeID) (
+	peer ReplicaPeerState, reportTime int64, ok bool,
+) {
+	for _, state := range s.ReplicaStates {
+		for _, p := range state.Peers {
+			if p.Database == database && p.ShardID == shardID &&
+				p.Leader == state.NodeID && p.Leader != follower && p.Follower == follower {
+				return p, state.ReportTime, true
+			}
+		}
+	}
+	return ReplicaPeerState{}, 0, false
+}
+
 // Stringer returns a human readable string
 func (s *StorageState) String() string {
 	content := encoding.JSONMarshal(s)
 	return string(content)
 }
+
+// containsNode checks if node list contains the node.
+func containsNode(nodes []NodeID, nodeID NodeID) bool {
+	for _, node := range nodes {
+		if node == nodeID {
+			return true
+		}
+	}
+	return false
+}
+
+// removeNode removes the node from node list.
+func removeNode(nodes []NodeID, nodeID NodeID) []NodeID {
+	var result []NodeID
+	for _, node := range nodes {
+		if node != nodeID {
+			result = append(result, node)
+		}
+	}
+	return result
+}
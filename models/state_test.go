@@ -21,6 +21,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/encoding"
 )
 
 func TestStorageState(t *testing.T) {
@@ -55,3 +57,72 @@ func TestStorageState(t *testing.T) {
 	assert.Len(t, rs1, 1)
 	assert.Equal(t, rs1["test"], []ShardID{1})
 }
+
+func TestStorageState_Drain(t *testing.T) {
+	storageState := NewStorageState("test")
+	storageState.NodeDraining(1)
+	storageState.NodeDraining(1)
+	assert.True(t, storageState.IsDraining(1))
+	assert.False(t, storageState.IsDecommissioned(1))
+	assert.Equal(t, []NodeID{1}, storageState.UnavailableNodes())
+
+	storageState.NodeDecommissioned(1)
+	storageState.NodeDecommissioned(1)
+	assert.False(t, storageState.IsDraining(1))
+	assert.True(t, storageState.IsDecommissioned(1))
+	assert.Equal(t, []NodeID{1}, storageState.UnavailableNodes())
+
+	storageState.NodeRecommissioned(1)
+	assert.False(t, storageState.IsDraining(1))
+	assert.False(t, storageState.IsDecommissioned(1))
+	assert.Empty(t, storageState.UnavailableNodes())
+}
+
+func TestStorageState_ReplicaState(t *testing.T) {
+	storageState := NewStorageState("test")
+	storageState.UpdateReplicaState(NodeReplicaState{NodeID: 1, ReportTime: 10})
+	storageState.UpdateReplicaState(NodeReplicaState{NodeID: 2, ReportTime: 10})
+	storageState.UpdateReplicaState(NodeReplicaState{NodeID: 1, ReportTime: 20})
+	assert.Equal(t, []NodeReplicaState{{NodeID: 1, ReportTime: 20}, {NodeID: 2, ReportTime: 10}},
+		storageState.ReplicaStates)
+
+	storageState.RemoveReplicaState(1)
+	assert.Equal(t, []NodeReplicaState{{NodeID: 2, ReportTime: 10}}, storageState.ReplicaStates)
+	storageState.RemoveReplicaState(2)
+	assert.Empty(t, storageState.ReplicaStates)
+
+	state := NodeReplicaState{NodeID: 1, Peers: []ReplicaPeerState{{
+		ReplicaState: ReplicaState{Database: "db", ShardID: 1, Leader: 1, Follower: 2},
+		AppendIndex:  10,
+		AckIndex:     5,
+		Lag:          4,
+		Ready:        true,
+	}}}
+	data := encoding.JSONMarshal(&state)
+	assert.Contains(t, string(data), `"database":"db"`)
+	state1 := NodeReplicaState{}
+	assert.NoError(t, encoding.JSONUnmarshal(data, &state1))
+	assert.Equal(t, state, state1)
+}
+
+func TestStorageState_GetReplicaPeerState(t *testing.T) {
+	storageState := NewStorageState("test")
+	peer := ReplicaPeerState{
+		ReplicaState: ReplicaState{Database: "db", ShardID: 1, Leader: 1, Follower: 2},
+		AppendIndex:  10,
+		AckIndex:     9,
+	}
+	storageState.UpdateReplicaState(NodeReplicaState{NodeID: 2, ReportTime: 10, Peers: []ReplicaPeerState{{
+		ReplicaState: ReplicaState{Database: "db", ShardID: 1, Leader: 1, Follower: 2},
+	}}})
+	storageState.UpdateReplicaState(NodeReplicaState{NodeID: 1, ReportTime: 20, Peers: []ReplicaPeerState{peer}})
+	// state reported by leader
+	p, reportTime, ok := storageState.GetReplicaPeerState("db", 1, 2)
+	assert.True(t, ok)
+	assert.Equal(t, peer, p)
+	assert.Equal(t, int64(20), reportTime)
+	_, _, ok = storageState.GetReplicaPeerState("db", 1, 3)
+	assert.False(t, ok)
+	_, _, ok = storageState.GetReplicaPeerState("db", 2, 2)
+	assert.False(t, ok)
+}
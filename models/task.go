@@ -19,6 +19,7 @@ package models
 
 import (
 	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/ltoml"
 	"github.com/lindb/lindb/pkg/option"
 )
 
@@ -43,3 +44,70 @@ type DatabaseFlushTask struct {
 func (t DatabaseFlushTask) Bytes() []byte {
 	return encoding.JSONMarshal(t)
 }
+
+// DatabaseBackupTask represents the database backup task's param
+type DatabaseBackupTask struct {
+	DatabaseName string `json:"databaseName"` // database's name
+	Path         string `json:"path"`         // backup path of storage node
+}
+
+// Bytes returns the database backup task's binary data using json
+func (t DatabaseBackupTask) Bytes() []byte {
+	return encoding.JSONMarshal(t)
+}
+
+// DatabaseRestoreTask represents the database restore task's param
+type DatabaseRestoreTask struct {
+	DatabaseName string    `json:"databaseName"` // database's name
+	Path         string    `json:"path"`         // backup path of storage node
+	ShardIDs     []ShardID `json:"shardIDs"`     // shard ids need to restore, restore all shards if empty
+}
+
+// Bytes returns the database restore task's binary data using json
+func (t DatabaseRestoreTask) Bytes() []byte {
+	return encoding.JSONMarshal(t)
+}
+
+// IndexRebuildTask represents the series index rebuild task's param
+type IndexRebuildTask struct {
+	DatabaseName string    `json:"databaseName"` // database's name
+	ShardIDs     []ShardID `json:"shardIDs"`     // shard ids need to rebuild index
+}
+
+// Bytes returns the series index rebuild task's binary data using json
+func (t IndexRebuildTask) Bytes() []byte {
+	return encoding.JSONMarshal(t)
+}
+
+// DatabaseDropTask represents the database drop task's param
+type DatabaseDropTask struct {
+	DatabaseName string         `json:"databaseName"` // database's name
+	PurgeDelay   ltoml.Duration `json:"purgeDelay"`   // keep dropped data in trash before purged, remove directly if zero
+}
+
+// Bytes returns the database drop task's binary data using json
+func (t DatabaseDropTask) Bytes() []byte {
+	return encoding.JSONMarshal(t)
+}
+
+// ShardDropTask represents the shard drop task's param
+type ShardDropTask struct {
+	DatabaseName string    `json:"databaseName"` // database's name
+	ShardIDs     []ShardID `json:"shardIDs"`     // shard ids need to drop
+}
+
+// Bytes returns the shard drop task's binary data using json
+func (t ShardDropTask) Bytes() []byte {
+	return encoding.JSONMarshal(t)
+}
+
+// NodeDrainTask represents the storage node drain task's param
+type NodeDrainTask struct {
+	NodeID  NodeID         `json:"nodeId"`  // draining node's id
+	Timeout ltoml.Duration `json:"timeout"` // max time waiting write ahead log acked by replicas
+}
+
+// Bytes returns the node drain task's binary data using json
+func (t NodeDrainTask) Bytes() []byte {
+	return encoding.JSONMarshal(t)
+}
@@ -19,10 +19,12 @@ package models
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/ltoml"
 	"github.com/lindb/lindb/pkg/option"
 )
 
@@ -47,3 +49,70 @@ func TestDatabaseFlushTask_Bytes(t *testing.T) {
 	_ = encoding.JSONUnmarshal(data, &task1)
 	assert.Equal(t, task, task1)
 }
+
+func TestDatabaseBackupTask_Bytes(t *testing.T) {
+	task := DatabaseBackupTask{
+		DatabaseName: "test",
+		Path:         "/backup/test",
+	}
+	data := task.Bytes()
+	task1 := DatabaseBackupTask{}
+	_ = encoding.JSONUnmarshal(data, &task1)
+	assert.Equal(t, task, task1)
+}
+
+func TestDatabaseRestoreTask_Bytes(t *testing.T) {
+	task := DatabaseRestoreTask{
+		DatabaseName: "test",
+		Path:         "/backup/test",
+		ShardIDs:     []ShardID{1, 2},
+	}
+	data := task.Bytes()
+	task1 := DatabaseRestoreTask{}
+	_ = encoding.JSONUnmarshal(data, &task1)
+	assert.Equal(t, task, task1)
+}
+
+func TestIndexRebuildTask_Bytes(t *testing.T) {
+	task := IndexRebuildTask{
+		DatabaseName: "test",
+		ShardIDs:     []ShardID{1, 2},
+	}
+	data := task.Bytes()
+	task1 := IndexRebuildTask{}
+	_ = encoding.JSONUnmarshal(data, &task1)
+	assert.Equal(t, task, task1)
+}
+
+func TestDatabaseDropTask_Bytes(t *testing.T) {
+	task := DatabaseDropTask{
+		DatabaseName: "test",
+		PurgeDelay:   ltoml.Duration(time.Hour),
+	}
+	data := task.Bytes()
+	task1 := DatabaseDropTask{}
+	_ = encoding.JSONUnmarshal(data, &task1)
+	assert.Equal(t, task, task1)
+}
+
+func TestShardDropTask_Bytes(t *testing.T) {
+	task := ShardDropTask{
+		DatabaseName: "test",
+		ShardIDs:     []ShardID{1, 2},
+	}
+	data := task.Bytes()
+	task1 := ShardDropTask{}
+	_ = encoding.JSONUnmarshal(data, &task1)
+	assert.Equal(t, task, task1)
+}
+
+func TestNodeDrainTask_Bytes(t *testing.T) {
+	task := NodeDrainTask{
+		NodeID:  1,
+		Timeout: ltoml.Duration(time.Minute),
+	}
+	data := task.Bytes()
+	task1 := NodeDrainTask{}
+	_ = encoding.JSONUnmarshal(data, &task1)
+	assert.Equal(t, task, task1)
+}
@@ -0,0 +1,215 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package encoding
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/lindb/lindb/pkg/stream"
+)
+
+const (
+	sketchVersion = 1
+	// SketchRelativeAccuracy represents the relative accuracy of quantile value computed by sketch.
+	SketchRelativeAccuracy = 0.02
+	// SketchMaxBins represents the max bins kept by sketch, the lowest bins are collapsed if exceed,
+	// so that the high quantiles(p99, p99.9) are always accurate.
+	SketchMaxBins = 128
+	// SketchZeroBinIndex represents the bin index of values which are too small(or <= 0).
+	SketchZeroBinIndex = math.MinInt32
+	// sketchMinValue represents the min value which can be indexed by sketch.
+	sketchMinValue = 1e-9
+)
+
+var (
+	sketchGamma    = (1 + SketchRelativeAccuracy) / (1 - SketchRelativeAccuracy)
+	sketchLogGamma = math.Log(sketchGamma)
+)
+
+var errSketchVersion = errors.New("unknown sketch version")
+
+// Sketch represents the mergeable quantile sketch(based on DDSketch),
+// values are counted in logarithmic bins, so any quantile has bounded relative error,
+// and sketches of different series/time can be merged by adding the count of same bin.
+type Sketch struct {
+	bins  map[int32]float64
+	count float64
+}
+
+// NewSketch creates an empty sketch.
+func NewSketch() *Sketch {
+	return &Sketch{bins: make(map[int32]float64)}
+}
+
+// SketchBinIndex returns the bin index of value.
+func SketchBinIndex(value float64) int32 {
+	if value < sketchMinValue {
+		return SketchZeroBinIndex
+	}
+	return int32(math.Ceil(math.Log(value) / sketchLogGamma))
+}
+
+// SketchBinValue returns the representative value of bin index, the value is within relative accuracy
+// for all values in this bin.
+func SketchBinValue(index int32) float64 {
+	if index == SketchZeroBinIndex {
+		return 0
+	}
+	return 2 * math.Pow(sketchGamma, float64(index)) / (1 + sketchGamma)
+}
+
+// Add adds a value into sketch.
+func (s *Sketch) Add(value float64) {
+	s.AddBin(SketchBinIndex(value), 1)
+}
+
+// AddBin adds the count of bin into sketch.
+func (s *Sketch) AddBin(index int32, count float64) {
+	if count <= 0 {
+		return
+	}
+	s.bins[index] += count
+	s.count += count
+	s.collapse()
+}
+
+// Merge merges other sketch into current sketch.
+func (s *Sketch) Merge(other *Sketch) {
+	for index, count := range other.bins {
+		s.bins[index] += count
+	}
+	s.count += other.count
+	s.collapse()
+}
+
+// Count returns the count of values added.
+func (s *Sketch) Count() float64 {
+	return s.count
+}
+
+// BinsLen returns the number of bins.
+func (s *Sketch) BinsLen() int {
+	return len(s.bins)
+}
+
+// Bins walks all bins ordered by bin index.
+func (s *Sketch) Bins(fn func(index int32, count float64)) {
+	for _, index := range s.sortedIndexes() {
+		fn(index, s.bins[index])
+	}
+}
+
+// Quantile returns the quantile value, returns 0 if sketch is empty.
+func (s *Sketch) Quantile(q float64) (float64, error) {
+	if q < 0 || q > 1 {
+		return 0, fmt.Errorf("quantile with illegal value: %f", q)
+	}
+	if s.count == 0 {
+		return 0, nil
+	}
+	rank := q * (s.count - 1)
+	indexes := s.sortedIndexes()
+	var cumulative float64
+	for _, index := range indexes {
+		cumulative += s.bins[index]
+		if cumulative > rank {
+			return SketchBinValue(index), nil
+		}
+	}
+	return SketchBinValue(indexes[len(indexes)-1]), nil
+}
+
+// Reset resets the sketch for reusing.
+func (s *Sketch) Reset() {
+	for index := range s.bins {
+		delete(s.bins, index)
+	}
+	s.count = 0
+}
+
+// MarshalBinary marshals sketch into binary.
+// format: version(1 byte) + bins len(uvarint) + [bin index delta(varint) + count(uvarint)]...
+func (s *Sketch) MarshalBinary() []byte {
+	writer := stream.NewBufferWriter(nil)
+	writer.PutByte(sketchVersion)
+	writer.PutUvarint64(uint64(len(s.bins)))
+	var prev int64
+	for _, index := range s.sortedIndexes() {
+		writer.PutVarint64(int64(index) - prev)
+		writer.PutUvarint64(uint64(math.Round(s.bins[index])))
+		prev = int64(index)
+	}
+	data, _ := writer.Bytes()
+	return data
+}
+
+// UnmarshalBinary unmarshals sketch from binary, the sketch is reset before reading.
+func (s *Sketch) UnmarshalBinary(data []byte) error {
+	s.Reset()
+	if len(data) == 0 {
+		return nil
+	}
+	reader := stream.NewReader(data)
+	if version := reader.ReadByte(); version != sketchVersion {
+		return fmt.Errorf("%w: %d", errSketchVersion, version)
+	}
+	binsLen := reader.ReadUvarint64()
+	var index int64
+	for i := uint64(0); i < binsLen && reader.Error() == nil; i++ {
+		index += reader.ReadVarint64()
+		count := reader.ReadUvarint64()
+		if index < math.MinInt32 || index > math.MaxInt32 {
+			return fmt.Errorf("sketch bin index: %d out of range", index)
+		}
+		s.bins[int32(index)] += float64(count)
+		s.count += float64(count)
+	}
+	if err := reader.Error(); err != nil {
+		return err
+	}
+	if !reader.Empty() {
+		return fmt.Errorf("sketch has %d bytes unread", len(reader.UnreadSlice()))
+	}
+	s.collapse()
+	return nil
+}
+
+// collapse merges the lowest bins if the number of bins exceeds max bins.
+func (s *Sketch) collapse() {
+	if len(s.bins) <= SketchMaxBins {
+		return
+	}
+	indexes := s.sortedIndexes()
+	collapsed := len(indexes) - SketchMaxBins
+	target := indexes[collapsed]
+	for _, index := range indexes[:collapsed] {
+		s.bins[target] += s.bins[index]
+		delete(s.bins, index)
+	}
+}
+
+func (s *Sketch) sortedIndexes() []int32 {
+	indexes := make([]int32, 0, len(s.bins))
+	for index := range s.bins {
+		indexes = append(indexes, index)
+	}
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+	return indexes
+}
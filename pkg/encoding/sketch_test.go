@@ -0,0 +1,129 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package encoding
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSketch_Quantile(t *testing.T) {
+	s := NewSketch()
+	v, err := s.Quantile(0.99)
+	assert.NoError(t, err)
+	assert.Zero(t, v)
+	_, err = s.Quantile(1.1)
+	assert.Error(t, err)
+
+	for i := 1; i <= 10000; i++ {
+		s.Add(float64(i))
+	}
+	assert.Equal(t, 10000.0, s.Count())
+	for _, q := range []float64{0.5, 0.9, 0.99, 0.999, 1} {
+		v, err = s.Quantile(q)
+		assert.NoError(t, err)
+		expect := q * 10000
+		assert.InDelta(t, expect, v, expect*SketchRelativeAccuracy+1)
+	}
+	// lowest bins are collapsed
+	assert.Equal(t, SketchMaxBins, s.BinsLen())
+	v, err = s.Quantile(0)
+	assert.NoError(t, err)
+	assert.True(t, v < 100)
+}
+
+func TestSketch_Zero(t *testing.T) {
+	s := NewSketch()
+	s.Add(0)
+	s.Add(-1)
+	s.Add(10)
+	assert.Equal(t, 2, s.BinsLen())
+	v, err := s.Quantile(0.5)
+	assert.NoError(t, err)
+	assert.Zero(t, v)
+	assert.Zero(t, SketchBinValue(SketchZeroBinIndex))
+	// ignore empty bin
+	s.AddBin(1, 0)
+	assert.Equal(t, 2, s.BinsLen())
+}
+
+func TestSketch_Merge(t *testing.T) {
+	s1 := NewSketch()
+	s2 := NewSketch()
+	all := NewSketch()
+	for i := 1; i <= 1000; i++ {
+		s1.Add(float64(i))
+		s2.Add(float64(i * 10))
+		all.Add(float64(i))
+		all.Add(float64(i * 10))
+	}
+	s1.Merge(s2)
+	assert.Equal(t, all.Count(), s1.Count())
+	for _, q := range []float64{0.5, 0.99, 0.999} {
+		v1, _ := s1.Quantile(q)
+		v2, _ := all.Quantile(q)
+		assert.Equal(t, v2, v1)
+	}
+}
+
+func TestSketch_Collapse(t *testing.T) {
+	s := NewSketch()
+	for i := 0; i < 1000; i++ {
+		s.Add(math.Pow(1.1, float64(i)))
+	}
+	assert.Equal(t, SketchMaxBins, s.BinsLen())
+	assert.Equal(t, 1000.0, s.Count())
+	max := math.Pow(1.1, 999)
+	v, err := s.Quantile(1)
+	assert.NoError(t, err)
+	assert.InDelta(t, max, v, max*SketchRelativeAccuracy)
+}
+
+func TestSketch_MarshalBinary(t *testing.T) {
+	s := NewSketch()
+	s.Add(0)
+	for i := 1; i <= 100; i++ {
+		s.Add(float64(i))
+	}
+	data := s.MarshalBinary()
+	s2 := NewSketch()
+	s2.Add(1)
+	assert.NoError(t, s2.UnmarshalBinary(data))
+	assert.Equal(t, s.Count(), s2.Count())
+	var indexes []int32
+	s2.Bins(func(index int32, count float64) {
+		indexes = append(indexes, index)
+	})
+	assert.Equal(t, int32(SketchZeroBinIndex), indexes[0])
+	assert.Equal(t, s.BinsLen(), len(indexes))
+	v1, _ := s.Quantile(0.9)
+	v2, _ := s2.Quantile(0.9)
+	assert.Equal(t, v1, v2)
+
+	// empty data
+	assert.NoError(t, s2.UnmarshalBinary(nil))
+	assert.Zero(t, s2.Count())
+	// unknown version
+	assert.Error(t, s2.UnmarshalBinary([]byte{9, 0}))
+	// corrupt data
+	assert.Error(t, s2.UnmarshalBinary(data[:len(data)-1]))
+	assert.Error(t, s2.UnmarshalBinary(append(data, 1)))
+	// index out of range
+	assert.Error(t, s2.UnmarshalBinary([]byte{sketchVersion, 2, 1, 1, 0xfe, 0xff, 0xff, 0xff, 0x1f, 1}))
+}
@@ -18,6 +18,7 @@
 package fileutil
 
 import (
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -27,6 +28,7 @@ var (
 	mkdirAllFunc  = os.MkdirAll
 	removeAllFunc = os.RemoveAll
 	removeFunc    = os.Remove
+	linkFunc      = os.Link
 )
 
 // MkDirIfNotExist creates given dir if it not exist
@@ -100,3 +102,55 @@ func GetExistPath(path string) string {
 	}
 	return GetExistPath(dir)
 }
+
+// CopyFile copies the content of src file into dst file, dst file will be truncated if exist.
+func CopyFile(src, dst string) (err error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = in.Close()
+	}()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if e := out.Close(); e != nil && err == nil {
+			err = e
+		}
+	}()
+	if _, err = io.Copy(out, in); err != nil {
+		return err
+	}
+	return out.Sync()
+}
+
+// LinkOrCopyFile creates dst file as hard link of src file,
+// copies the file if hard link not supported(such as cross devices).
+// NOTE: only immutable file can be linked, because link shares the content.
+func LinkOrCopyFile(src, dst string) error {
+	if err := linkFunc(src, dst); err == nil {
+		return nil
+	}
+	return CopyFile(src, dst)
+}
+
+// CopyDir copies all files of src dir into dst dir recursively.
+func CopyDir(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if info.IsDir() {
+			return MkDirIfNotExist(target)
+		}
+		return CopyFile(path, target)
+	})
+}
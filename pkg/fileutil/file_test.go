@@ -19,6 +19,7 @@ package fileutil
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"testing"
@@ -121,3 +122,51 @@ func TestRemoveFile(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, files, 1)
 }
+
+func TestCopyFile(t *testing.T) {
+	_ = MkDirIfNotExist(testPath)
+
+	defer func() {
+		_ = RemoveDir(testPath)
+		linkFunc = os.Link
+	}()
+	src := filepath.Join(testPath, "src")
+	assert.NoError(t, ioutil.WriteFile(src, []byte("data"), 0644))
+	// src not exist
+	assert.Error(t, CopyFile(filepath.Join(testPath, "not_exist"), filepath.Join(testPath, "dst")))
+	// dst dir not exist
+	assert.Error(t, CopyFile(src, filepath.Join(testPath, "not_exist", "dst")))
+	assert.NoError(t, CopyFile(src, filepath.Join(testPath, "dst")))
+	data, err := ioutil.ReadFile(filepath.Join(testPath, "dst"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("data"), data)
+	// hard link
+	assert.NoError(t, LinkOrCopyFile(src, filepath.Join(testPath, "link")))
+	data, err = ioutil.ReadFile(filepath.Join(testPath, "link"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("data"), data)
+	// copy if link failure
+	linkFunc = func(oldname, newname string) error {
+		return fmt.Errorf("err")
+	}
+	assert.NoError(t, LinkOrCopyFile(src, filepath.Join(testPath, "copy")))
+	data, err = ioutil.ReadFile(filepath.Join(testPath, "copy"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("data"), data)
+}
+
+func TestCopyDir(t *testing.T) {
+	defer func() {
+		_ = RemoveDir(testPath)
+	}()
+	src := filepath.Join(testPath, "src")
+	_ = MkDirIfNotExist(filepath.Join(src, "a", "b"))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(src, "a", "b", "file"), []byte("data"), 0644))
+	dst := filepath.Join(testPath, "dst")
+	assert.NoError(t, CopyDir(src, dst))
+	data, err := ioutil.ReadFile(filepath.Join(dst, "a", "b", "file"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("data"), data)
+	// src not exist
+	assert.Error(t, CopyDir(filepath.Join(testPath, "not_exist"), dst))
+}
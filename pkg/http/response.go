@@ -40,12 +40,23 @@ func NotFound(c *gin.Context) {
 	response(c, http.StatusNotFound, nil)
 }
 
+// BadRequest responses content and set the http status code 400.
+func BadRequest(c *gin.Context, content interface{}) {
+	response(c, http.StatusBadRequest, content)
+}
+
 // Error responses error message and set the http status code 500.
 func Error(c *gin.Context, err error) {
 	_ = c.Error(err)
 	response(c, http.StatusInternalServerError, err.Error())
 }
 
+// TooManyRequests responses error message and set the http status code 429.
+func TooManyRequests(c *gin.Context, err error) {
+	_ = c.Error(err)
+	response(c, http.StatusTooManyRequests, err.Error())
+}
+
 // response responses json body for http restful api
 func response(c *gin.Context, httpCode int, content interface{}) {
 	c.JSON(httpCode, content)
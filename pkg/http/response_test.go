@@ -58,3 +58,11 @@ func TestError(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, resp.Code)
 	assert.Equal(t, `"err"`, resp.Body.String())
 }
+
+func TestTooManyRequests(t *testing.T) {
+	resp := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(resp)
+	TooManyRequests(c, fmt.Errorf("err"))
+	assert.Equal(t, http.StatusTooManyRequests, resp.Code)
+	assert.Equal(t, `"err"`, resp.Body.String())
+}
@@ -29,14 +29,34 @@ type DatabaseOption struct {
 	// rollup intervals(like seconds->minute->hour->day)
 	Rollup []string `toml:"rollup" json:"rollup,omitempty"`
 
+	// data retention of write interval, keeps data forever if empty(like 14d)
+	TTL string `toml:"ttl" json:"ttl,omitempty"`
+	// data retention of each rollup interval, same order as rollup, keeps data forever if empty(like 90d)
+	RollupTTL []string `toml:"rollupTTL" json:"rollupTTL,omitempty"`
+
 	// auto create namespace
 	AutoCreateNS bool `toml:"autoCreateNS" json:"autoCreateNS,omitempty"`
+	// keeps hidden min/max/sum/count fields for gauge, so that rollup data can answer min/max/sum
+	GaugeRollupAggregates bool `toml:"gaugeRollupAggregates" json:"gaugeRollupAggregates,omitempty"`
 
 	Behind string `toml:"behind" json:"behind,omitempty"` // allowed timestamp write behind
 	Ahead  string `toml:"ahead" json:"ahead,omitempty"`   // allowed timestamp write ahead
 
 	Index FlusherOption `toml:"index" json:"index,omitempty"` // index flusher option
 	Data  FlusherOption `toml:"data" json:"data,omitempty"`   // data flusher data
+
+	// compression of kv families, only applied to the families created after changed
+	Compression CompressionOption `toml:"compression" json:"compression,omitempty"`
+}
+
+// CompressionOption represents the compression(none/snappy/zstd) of each kind of kv family,
+// no compression if empty, operators opt in compression of each kind of family.
+type CompressionOption struct {
+	Data     string `toml:"data" json:"data,omitempty"`         // metric data family
+	Index    string `toml:"index" json:"index,omitempty"`       // series forward/inverted index family
+	Meta     string `toml:"meta" json:"meta,omitempty"`         // tag metadata family
+	Exemplar string `toml:"exemplar" json:"exemplar,omitempty"` // exemplar family
+	Sketch   string `toml:"sketch" json:"sketch,omitempty"`     // quantile sketch family
 }
 
 // FlusherOption represents a flusher configuration for index and memory db
@@ -61,6 +81,15 @@ func (e DatabaseOption) Validate() error {
 	if err := validateInterval(e.Behind, false); err != nil {
 		return err
 	}
+	if err := e.Compression.Validate(); err != nil {
+		return err
+	}
+	if len(e.RollupTTL) > len(e.Rollup) {
+		return fmt.Errorf("rollup ttl cannot be more than rollup intervals")
+	}
+	if err := e.validateTTLs(); err != nil {
+		return err
+	}
 	var interval timeutil.Interval
 	_ = interval.ValueOf(e.Interval)
 	for _, intervalStr := range e.Rollup {
@@ -73,6 +102,113 @@ func (e DatabaseOption) Validate() error {
 	return nil
 }
 
+// Validate validates the compression of each kind of family if valid
+func (c CompressionOption) Validate() error {
+	for _, compression := range []string{c.Data, c.Index, c.Meta, c.Exemplar, c.Sketch} {
+		switch compression {
+		case "", "none", "snappy", "zstd":
+		default:
+			return fmt.Errorf("unknown compression: %s", compression)
+		}
+	}
+	return nil
+}
+
+// ValidateAlter validates if the option can replace the old option of a running database,
+// write interval cannot be changed and rollup intervals can only be appended.
+func (e DatabaseOption) ValidateAlter(old DatabaseOption) error {
+	// invalid ttl cannot be applied to running database, otherwise data is kept forever
+	if err := e.validateTTLs(); err != nil {
+		return err
+	}
+	if err := e.Compression.Validate(); err != nil {
+		return err
+	}
+	same, err := sameInterval(e.Interval, old.Interval)
+	if err != nil {
+		return err
+	}
+	if !same {
+		return fmt.Errorf("write interval cannot be changed, old: %s, new: %s", old.Interval, e.Interval)
+	}
+	if len(e.Rollup) < len(old.Rollup) {
+		return fmt.Errorf("rollup interval cannot be removed")
+	}
+	for idx, interval := range old.Rollup {
+		same, err := sameInterval(e.Rollup[idx], interval)
+		if err != nil {
+			return err
+		}
+		if !same {
+			return fmt.Errorf("rollup interval cannot be changed, old: %s, new: %s", interval, e.Rollup[idx])
+		}
+	}
+	// data is rolled up from smaller interval into larger one, the appended interval must be the largest,
+	// otherwise the rollup relation of exist intervals will be changed.
+	var last timeutil.Interval
+	if err := last.ValueOf(old.Interval); err != nil {
+		return err
+	}
+	if len(old.Rollup) > 0 {
+		_ = last.ValueOf(old.Rollup[len(old.Rollup)-1])
+	}
+	for _, intervalStr := range e.Rollup[len(old.Rollup):] {
+		var interval timeutil.Interval
+		if err := interval.ValueOf(intervalStr); err != nil {
+			return err
+		}
+		if interval.Int64() <= last.Int64() {
+			return fmt.Errorf("appended rollup interval must be large than exist intervals, new: %s", intervalStr)
+		}
+		last = interval
+	}
+	return nil
+}
+
+// sameInterval checks if two interval strings represent the same interval, returns err if interval string is invalid
+func sameInterval(a, b string) (bool, error) {
+	var intervalA, intervalB timeutil.Interval
+	if err := intervalA.ValueOf(a); err != nil {
+		return false, err
+	}
+	if err := intervalB.ValueOf(b); err != nil {
+		return false, err
+	}
+	return intervalA == intervalB, nil
+}
+
+// validateTTLs checks ttl of write interval and rollup intervals if valid
+func (e DatabaseOption) validateTTLs() error {
+	if err := validateInterval(e.Behind, false); err != nil {
+		return err
+	}
+	var behind timeutil.Interval
+	_ = behind.ValueOf(e.Behind)
+	// data written behind cannot be expired directly, so ttl of each interval must be large than behind
+	for _, ttl := range append([]string{e.TTL}, e.RollupTTL...) {
+		if err := validateTTL(ttl, behind); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateTTL checks ttl string if valid, empty ttl means keeping data forever
+func validateTTL(ttlStr string, behind timeutil.Interval) error {
+	if err := validateInterval(ttlStr, false); err != nil {
+		return err
+	}
+	if ttlStr == "" {
+		return nil
+	}
+	var ttl timeutil.Interval
+	_ = ttl.ValueOf(ttlStr)
+	if ttl.Int64() <= behind.Int64() {
+		return fmt.Errorf("ttl must be large than behind, ttl: %s", ttlStr)
+	}
+	return nil
+}
+
 // validateInterval checks interval string if valid
 func validateInterval(intervalStr string, require bool) error {
 	if !require && intervalStr == "" {
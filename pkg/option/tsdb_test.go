@@ -44,4 +44,41 @@ func Test_DatabaseOption_Validate(t *testing.T) {
 	assert.NotNil(t, databaseOption.Validate())
 	databaseOption = DatabaseOption{Interval: "10s", Rollup: []string{"20s", "1m", "1h"}, Behind: "10h", Ahead: "1h"}
 	assert.Nil(t, databaseOption.Validate())
+	databaseOption = DatabaseOption{Interval: "10s", Compression: CompressionOption{Data: "lz4"}}
+	assert.NotNil(t, databaseOption.Validate())
+	databaseOption = DatabaseOption{Interval: "10s", Compression: CompressionOption{Data: "none", Index: "zstd", Sketch: "snappy"}}
+	assert.Nil(t, databaseOption.Validate())
+	databaseOption = DatabaseOption{Interval: "10s", TTL: "aa"}
+	assert.NotNil(t, databaseOption.Validate())
+	databaseOption = DatabaseOption{Interval: "10s", TTL: "1h", Behind: "1h"}
+	assert.NotNil(t, databaseOption.Validate())
+	databaseOption = DatabaseOption{Interval: "10s", Rollup: []string{"5m"}, RollupTTL: []string{"90d", "2y"}}
+	assert.NotNil(t, databaseOption.Validate())
+	databaseOption = DatabaseOption{Interval: "10s", Rollup: []string{"5m", "1h"}, RollupTTL: []string{"90d", "aa"}}
+	assert.NotNil(t, databaseOption.Validate())
+	databaseOption = DatabaseOption{Interval: "10s", Rollup: []string{"5m", "1h"},
+		TTL: "14d", RollupTTL: []string{"30m", "2y"}, Behind: "1h"}
+	assert.NotNil(t, databaseOption.Validate())
+	databaseOption = DatabaseOption{Interval: "10s", Rollup: []string{"5m", "1h"},
+		TTL: "14d", RollupTTL: []string{"", "2y"}, Behind: "1h"}
+	assert.Nil(t, databaseOption.Validate())
+}
+
+func Test_DatabaseOption_ValidateAlter(t *testing.T) {
+	old := DatabaseOption{Interval: "10s", Rollup: []string{"5m"}}
+	assert.NotNil(t, DatabaseOption{Interval: "aa"}.ValidateAlter(old))
+	assert.NotNil(t, DatabaseOption{Interval: "20s", Rollup: []string{"5m"}}.ValidateAlter(old))
+	assert.NotNil(t, DatabaseOption{Interval: "10s"}.ValidateAlter(old))
+	assert.NotNil(t, DatabaseOption{Interval: "10s", Rollup: []string{"10m"}}.ValidateAlter(old))
+	assert.NotNil(t, DatabaseOption{Interval: "10s", Rollup: []string{"bb"}}.ValidateAlter(old))
+	assert.NotNil(t, DatabaseOption{Interval: "10s"}.ValidateAlter(DatabaseOption{Interval: "cc"}))
+	assert.NotNil(t, DatabaseOption{Interval: "10s", Rollup: []string{"5m"}, TTL: "aa"}.ValidateAlter(old))
+	assert.NotNil(t, DatabaseOption{Interval: "10s", Rollup: []string{"5m"}, RollupTTL: []string{"bb"}}.ValidateAlter(old))
+	assert.NotNil(t, DatabaseOption{Interval: "10s", Rollup: []string{"5m"}, TTL: "1h", Behind: "2h"}.ValidateAlter(old))
+	assert.NotNil(t, DatabaseOption{Interval: "10s", Rollup: []string{"5m"}, Compression: CompressionOption{Meta: "lz4"}}.ValidateAlter(old))
+	assert.Nil(t, DatabaseOption{Interval: "10s", Rollup: []string{"5m"}, Behind: "2h"}.ValidateAlter(old))
+	assert.Nil(t, DatabaseOption{Interval: "10s", Rollup: []string{"300s", "1h"}}.ValidateAlter(old))
+	assert.NotNil(t, DatabaseOption{Interval: "10s", Rollup: []string{"5m", "1m"}}.ValidateAlter(old))
+	assert.NotNil(t, DatabaseOption{Interval: "10s", Rollup: []string{"5m", "1h", "30m"}}.ValidateAlter(old))
+	assert.NotNil(t, DatabaseOption{Interval: "10s", Rollup: []string{"5m", "cc"}}.ValidateAlter(old))
 }
@@ -57,6 +57,8 @@ type FanOutQueue interface {
 	HeadSeq() int64
 	// TailSeq returns the tailSeq which is the smallest seq among all the fanOut tailSeq.
 	TailSeq() int64
+	// DataSize returns the approximate data size in bytes of messages after the seq.
+	DataSize(seq int64) int64
 	//SetAppendSeq sets append seq(head/tail seq)
 	SetAppendSeq(seq int64)
 	// Close persists Seq meta, FanOut seq meta, release resources.
@@ -161,6 +163,11 @@ func (fq *fanOutQueue) TailSeq() int64 {
 	return fq.queue.TailSeq()
 }
 
+// DataSize returns the approximate data size in bytes of messages after the seq.
+func (fq *fanOutQueue) DataSize(seq int64) int64 {
+	return fq.queue.DataSize(seq)
+}
+
 // SetAppendSeq sets append seq(head/tail) underlying queue
 func (fq *fanOutQueue) SetAppendSeq(seq int64) {
 	fq.lock4map.RLock()
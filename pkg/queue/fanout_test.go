@@ -424,6 +424,7 @@ func TestFanOutQueue_SetAppendSeq(t *testing.T) {
 	// consumer group 1
 	consumeMsg(t, fq, "f1", 100)
 	f2, _ := fq.GetOrCreateFanOut("f2")
+	assert.Equal(t, int64(len("msg-99")), fq.DataSize(98))
 	// set new append seq
 	fq.SetAppendSeq(200)
 	// put msg
@@ -57,6 +57,8 @@ type Queue interface {
 	Get(sequence int64) (message []byte, err error)
 	// Size returns the total size of message.
 	Size() int64
+	// DataSize returns the approximate data size in bytes of messages after the sequence.
+	DataSize(sequence int64) int64
 	// IsEmpty returns if queue is empty
 	IsEmpty() bool
 	// HeadSeq returns the head seq which stands for the latest read barrier.
@@ -254,6 +256,35 @@ func (q *queue) Size() int64 {
 	return q.HeadSeq() - q.TailSeq()
 }
 
+// DataSize returns the approximate data size in bytes of messages after the sequence,
+// the unused tail of each full data page is also counted.
+func (q *queue) DataSize(sequence int64) int64 {
+	q.rwMutex.RLock()
+	defer q.rwMutex.RUnlock()
+
+	if sequence < q.TailSeq() {
+		// messages before tail sequence maybe removed
+		sequence = q.TailSeq()
+	}
+	sequence++
+	if sequence > q.HeadSeq() {
+		return 0
+	}
+	indexPage, ok := q.indexPageFct.GetPage(sequence / indexItemsPerPage)
+	if !ok {
+		return 0
+	}
+	indexOffset := int((sequence % indexItemsPerPage) * indexItemLength)
+	dataPageID := int64(indexPage.ReadUint64(indexOffset + queueDataPageIndexOffset))
+	messageOffset := int64(indexPage.ReadUint32(indexOffset + messageOffsetOffset))
+
+	size := (q.dataPageIndex-dataPageID)*dataPageSize + int64(q.messageOffset) - messageOffset
+	if size < 0 {
+		return 0
+	}
+	return size
+}
+
 // HeadSeq returns the head seq which stands for the latest read barrier.
 // New message is appended at head seq.
 func (q *queue) HeadSeq() int64 {
@@ -136,6 +136,33 @@ func TestQueue_Ack(t *testing.T) {
 	q.Close()
 }
 
+func TestQueue_DataSize(t *testing.T) {
+	dir := path.Join(testPath, "queue")
+
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+
+	q, err := NewQueue(dir, 1024, time.Minute)
+	assert.NoError(t, err)
+	// case 1: empty queue
+	assert.Equal(t, int64(0), q.DataSize(-1))
+	for i := 0; i < 3; i++ {
+		err = q.Put([]byte("123"))
+		assert.NoError(t, err)
+	}
+	// case 2: size after sequence
+	assert.Equal(t, int64(9), q.DataSize(-1))
+	assert.Equal(t, int64(6), q.DataSize(0))
+	assert.Equal(t, int64(3), q.DataSize(1))
+	assert.Equal(t, int64(0), q.DataSize(2))
+	assert.Equal(t, int64(0), q.DataSize(10))
+	// case 3: sequence less than tail sequence
+	q.Ack(0)
+	assert.Equal(t, int64(6), q.DataSize(-1))
+	q.Close()
+}
+
 func TestQueue_new_err(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	dir := path.Join(testPath, "queue")
@@ -76,16 +76,19 @@ type RequestType int32
 const (
 	RequestType_Data     RequestType = 0
 	RequestType_Metadata RequestType = 1
+	RequestType_Delete   RequestType = 2
 )
 
 var RequestType_name = map[int32]string{
 	0: "Data",
 	1: "Metadata",
+	2: "Delete",
 }
 
 var RequestType_value = map[string]int32{
 	"Data":     0,
 	"Metadata": 1,
+	"Delete":   2,
 }
 
 func (x RequestType) String() string {
@@ -273,6 +276,7 @@ func (m *TaskResponse) GetStats() []byte {
 type TimeSeriesList struct {
 	TimeSeriesList       []*TimeSeries     `protobuf:"bytes,1,rep,name=timeSeriesList,proto3" json:"timeSeriesList,omitempty"`
 	FieldAggSpecs        []*AggregatorSpec `protobuf:"bytes,2,rep,name=fieldAggSpecs,proto3" json:"fieldAggSpecs,omitempty"`
+	Exemplars            []*Exemplar       `protobuf:"bytes,3,rep,name=exemplars,proto3" json:"exemplars,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
 	XXX_unrecognized     []byte            `json:"-"`
 	XXX_sizecache        int32             `json:"-"`
@@ -325,6 +329,92 @@ func (m *TimeSeriesList) GetFieldAggSpecs() []*AggregatorSpec {
 	return nil
 }
 
+func (m *TimeSeriesList) GetExemplars() []*Exemplar {
+	if m != nil {
+		return m.Exemplars
+	}
+	return nil
+}
+
+type Exemplar struct {
+	Field                string   `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Timestamp            int64    `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	TraceID              []byte   `protobuf:"bytes,3,opt,name=traceID,proto3" json:"traceID,omitempty"`
+	SpanID               []byte   `protobuf:"bytes,4,opt,name=spanID,proto3" json:"spanID,omitempty"`
+	Duration             int64    `protobuf:"varint,5,opt,name=duration,proto3" json:"duration,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Exemplar) Reset()         { *m = Exemplar{} }
+func (m *Exemplar) String() string { return proto.CompactTextString(m) }
+func (*Exemplar) ProtoMessage()    {}
+func (*Exemplar) Descriptor() ([]byte, []int) {
+	return fileDescriptor_555bd8c177793206, []int{3}
+}
+func (m *Exemplar) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Exemplar) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Exemplar.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Exemplar) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Exemplar.Merge(m, src)
+}
+func (m *Exemplar) XXX_Size() int {
+	return m.Size()
+}
+func (m *Exemplar) XXX_DiscardUnknown() {
+	xxx_messageInfo_Exemplar.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Exemplar proto.InternalMessageInfo
+
+func (m *Exemplar) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
+}
+
+func (m *Exemplar) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *Exemplar) GetTraceID() []byte {
+	if m != nil {
+		return m.TraceID
+	}
+	return nil
+}
+
+func (m *Exemplar) GetSpanID() []byte {
+	if m != nil {
+		return m.SpanID
+	}
+	return nil
+}
+
+func (m *Exemplar) GetDuration() int64 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
 type TimeSeries struct {
 	Tags                 string            `protobuf:"bytes,1,opt,name=tags,proto3" json:"tags,omitempty"`
 	Fields               map[string][]byte `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
@@ -337,7 +427,7 @@ func (m *TimeSeries) Reset()         { *m = TimeSeries{} }
 func (m *TimeSeries) String() string { return proto.CompactTextString(m) }
 func (*TimeSeries) ProtoMessage()    {}
 func (*TimeSeries) Descriptor() ([]byte, []int) {
-	return fileDescriptor_555bd8c177793206, []int{3}
+	return fileDescriptor_555bd8c177793206, []int{4}
 }
 func (m *TimeSeries) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -393,7 +483,7 @@ func (m *AggregatorSpec) Reset()         { *m = AggregatorSpec{} }
 func (m *AggregatorSpec) String() string { return proto.CompactTextString(m) }
 func (*AggregatorSpec) ProtoMessage()    {}
 func (*AggregatorSpec) Descriptor() ([]byte, []int) {
-	return fileDescriptor_555bd8c177793206, []int{4}
+	return fileDescriptor_555bd8c177793206, []int{5}
 }
 func (m *AggregatorSpec) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -449,6 +539,7 @@ func init() {
 	proto.RegisterType((*TaskRequest)(nil), "protoCommonV1.TaskRequest")
 	proto.RegisterType((*TaskResponse)(nil), "protoCommonV1.TaskResponse")
 	proto.RegisterType((*TimeSeriesList)(nil), "protoCommonV1.TimeSeriesList")
+	proto.RegisterType((*Exemplar)(nil), "protoCommonV1.Exemplar")
 	proto.RegisterType((*TimeSeries)(nil), "protoCommonV1.TimeSeries")
 	proto.RegisterMapType((map[string][]byte)(nil), "protoCommonV1.TimeSeries.FieldsEntry")
 	proto.RegisterType((*AggregatorSpec)(nil), "protoCommonV1.AggregatorSpec")
@@ -457,43 +548,48 @@ func init() {
 func init() { proto.RegisterFile("common.proto", fileDescriptor_555bd8c177793206) }
 
 var fileDescriptor_555bd8c177793206 = []byte{
-	// 564 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0x4d, 0x6f, 0xd3, 0x40,
-	0x10, 0xcd, 0xc6, 0x69, 0x9a, 0x8e, 0x9d, 0xc8, 0x5a, 0x21, 0x30, 0x01, 0xa2, 0xc8, 0x52, 0x25,
-	0xab, 0x48, 0x11, 0xb4, 0x17, 0x40, 0x70, 0x28, 0x2d, 0x1f, 0x15, 0x6d, 0x40, 0xdb, 0x50, 0xce,
-	0x8b, 0x3d, 0x35, 0x56, 0xfd, 0x85, 0x77, 0x5b, 0xc9, 0xff, 0xa4, 0xe2, 0x17, 0x71, 0xe4, 0xc2,
-	0x85, 0x13, 0x2a, 0x7f, 0x04, 0xed, 0xda, 0x69, 0xe2, 0xa8, 0x1c, 0x38, 0x79, 0xe7, 0xcd, 0xbc,
-	0x37, 0xf3, 0xd6, 0xb3, 0x60, 0xf9, 0x59, 0x92, 0x64, 0xe9, 0x24, 0x2f, 0x32, 0x99, 0xd1, 0xbe,
-	0xfe, 0xec, 0x69, 0xe8, 0xe4, 0xb1, 0xfb, 0x8b, 0x80, 0x39, 0xe3, 0xe2, 0x8c, 0xe1, 0xd7, 0x73,
-	0x14, 0x92, 0xba, 0x60, 0xe5, 0xbc, 0xc0, 0x54, 0x2a, 0xf0, 0x60, 0xdf, 0x21, 0x63, 0xe2, 0x6d,
-	0xb0, 0x06, 0x46, 0x1f, 0x42, 0x47, 0x96, 0x39, 0x3a, 0xed, 0x31, 0xf1, 0x06, 0xdb, 0x77, 0x26,
-	0x0d, 0xc5, 0x89, 0x2a, 0x9a, 0x95, 0x39, 0x32, 0x5d, 0x44, 0x9f, 0x83, 0x59, 0x54, 0xda, 0x0a,
-	0x74, 0x0c, 0xcd, 0x19, 0xae, 0x70, 0xd8, 0xa2, 0x82, 0x2d, 0x97, 0xeb, 0x71, 0xbe, 0x94, 0x22,
-	0xf2, 0x79, 0xfc, 0x21, 0xe6, 0xa9, 0xd3, 0x19, 0x13, 0xcf, 0x62, 0x0d, 0x8c, 0x3a, 0xb0, 0x9e,
-	0xf3, 0x32, 0xce, 0x78, 0xe0, 0xac, 0xe9, 0xf4, 0x3c, 0x74, 0x7f, 0x12, 0xb0, 0x2a, 0x73, 0x22,
-	0xcf, 0x52, 0x81, 0xf4, 0x36, 0x74, 0xe5, 0xb2, 0xaf, 0x3a, 0xfa, 0x3f, 0x47, 0xf7, 0x61, 0xc3,
-	0xcf, 0x92, 0x3c, 0x46, 0x89, 0x81, 0xf6, 0xd3, 0x63, 0x0b, 0x40, 0xb5, 0xc0, 0xa2, 0x38, 0x12,
-	0xa1, 0x9e, 0x75, 0x83, 0xd5, 0x11, 0x1d, 0x42, 0x4f, 0x60, 0x1a, 0xcc, 0xa2, 0x04, 0xf5, 0x98,
-	0x06, 0xbb, 0x8e, 0x97, 0x1d, 0x74, 0x1b, 0x0e, 0xe8, 0x2d, 0x58, 0x13, 0x92, 0x4b, 0xe1, 0xac,
-	0x6b, 0xbc, 0x0a, 0xdc, 0x4b, 0x02, 0x03, 0x45, 0x3c, 0xc6, 0x22, 0x42, 0x71, 0x18, 0x09, 0x49,
-	0x77, 0x61, 0x20, 0x1b, 0x88, 0x43, 0xc6, 0x86, 0x67, 0x6e, 0xdf, 0x5d, 0xf5, 0x72, 0x5d, 0xc4,
-	0x56, 0x08, 0x74, 0x0f, 0xfa, 0xa7, 0x11, 0xc6, 0xc1, 0x6e, 0x18, 0x1e, 0xe7, 0xe8, 0x0b, 0xa7,
-	0xad, 0x15, 0x1e, 0xac, 0x28, 0xec, 0x86, 0x61, 0x81, 0x21, 0x97, 0x59, 0xa1, 0xaa, 0x58, 0x93,
-	0xe3, 0x7e, 0x23, 0x00, 0x8b, 0x1e, 0x94, 0x42, 0x47, 0xf2, 0x50, 0xd4, 0xd7, 0xad, 0xcf, 0xf4,
-	0x05, 0x74, 0x35, 0x67, 0xde, 0x60, 0xf3, 0x9f, 0x23, 0x4e, 0x5e, 0xeb, 0xba, 0x57, 0xa9, 0x2c,
-	0x4a, 0x56, 0x93, 0x86, 0x4f, 0xc1, 0x5c, 0x82, 0xa9, 0x0d, 0xc6, 0x19, 0x96, 0x75, 0x03, 0x75,
-	0x54, 0x77, 0x76, 0xc1, 0xe3, 0xf3, 0xea, 0x6f, 0x5a, 0xac, 0x0a, 0x9e, 0xb5, 0x9f, 0x10, 0x37,
-	0x87, 0x41, 0x73, 0x7a, 0xf5, 0x2f, 0xb5, 0xec, 0x94, 0x27, 0x58, 0x6b, 0x2c, 0x80, 0xeb, 0xec,
-	0x6c, 0xbe, 0x1b, 0x7d, 0xb6, 0x00, 0xd4, 0x6e, 0x9e, 0x9e, 0xa7, 0xbe, 0x3a, 0xeb, 0x0b, 0x37,
-	0xc6, 0x86, 0xd7, 0x67, 0x0d, 0x6c, 0x6b, 0x07, 0x7a, 0xf3, 0xed, 0xa1, 0x26, 0xac, 0x7f, 0x9c,
-	0xbe, 0x9b, 0xbe, 0xff, 0x34, 0xb5, 0x5b, 0xd4, 0x06, 0xeb, 0x20, 0x95, 0x58, 0x24, 0x18, 0x44,
-	0x5c, 0xa2, 0x4d, 0x68, 0x0f, 0x3a, 0x87, 0xc8, 0x4f, 0xed, 0xf6, 0xd6, 0x26, 0x98, 0x4b, 0x0f,
-	0x42, 0x25, 0xf6, 0xb9, 0xe4, 0x76, 0x8b, 0x5a, 0xd0, 0x3b, 0x42, 0xc9, 0x03, 0x15, 0x91, 0xed,
-	0x93, 0xea, 0xe5, 0x1e, 0x63, 0x71, 0x11, 0xf9, 0x48, 0xdf, 0x40, 0xf7, 0x2d, 0x4f, 0x83, 0x18,
-	0xe9, 0xf0, 0x86, 0xfd, 0xad, 0x05, 0x87, 0xf7, 0x6e, 0xcc, 0x55, 0xcf, 0xc3, 0x6d, 0x79, 0xe4,
-	0x11, 0x79, 0x69, 0x7f, 0xbf, 0x1a, 0x91, 0x1f, 0x57, 0x23, 0xf2, 0xfb, 0x6a, 0x44, 0x2e, 0xff,
-	0x8c, 0x5a, 0x9f, 0xbb, 0x9a, 0xb3, 0xf3, 0x37, 0x00, 0x00, 0xff, 0xff, 0xdc, 0xb3, 0x4d, 0x15,
-	0x4a, 0x04, 0x00, 0x00,
+	// 650 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0xdd, 0x6e, 0x12, 0x41,
+	0x14, 0x66, 0x80, 0x52, 0x38, 0x2c, 0x64, 0x33, 0x31, 0xba, 0xa2, 0x12, 0xb2, 0x89, 0x09, 0xa9,
+	0x09, 0xb1, 0x6d, 0x4c, 0xd4, 0xe8, 0x45, 0x2d, 0x55, 0x89, 0x2d, 0x9a, 0x29, 0xd6, 0xeb, 0x91,
+	0x3d, 0xc5, 0x4d, 0xf7, 0xcf, 0x99, 0xa1, 0x91, 0x77, 0xf0, 0x01, 0x8c, 0x4f, 0xe4, 0x9d, 0xde,
+	0x78, 0xe3, 0x95, 0xa9, 0x2f, 0x62, 0x66, 0x76, 0xb7, 0xb0, 0xa4, 0xde, 0x78, 0xc5, 0x7c, 0xdf,
+	0x39, 0xe7, 0xdb, 0xf3, 0x0b, 0x58, 0xd3, 0x38, 0x0c, 0xe3, 0x68, 0x90, 0x88, 0x58, 0xc5, 0xb4,
+	0x65, 0x7e, 0xf6, 0x0d, 0x75, 0xb2, 0xed, 0xfe, 0x22, 0xd0, 0x9c, 0x70, 0x79, 0xc6, 0xf0, 0xe3,
+	0x1c, 0xa5, 0xa2, 0x2e, 0x58, 0x09, 0x17, 0x18, 0x29, 0x4d, 0x8e, 0x86, 0x0e, 0xe9, 0x91, 0x7e,
+	0x83, 0x15, 0x38, 0x7a, 0x0f, 0xaa, 0x6a, 0x91, 0xa0, 0x53, 0xee, 0x91, 0x7e, 0x7b, 0xe7, 0xc6,
+	0xa0, 0xa0, 0x38, 0xd0, 0x4e, 0x93, 0x45, 0x82, 0xcc, 0x38, 0xd1, 0x27, 0xd0, 0x14, 0xa9, 0xb6,
+	0x26, 0x9d, 0x8a, 0x89, 0xe9, 0xac, 0xc5, 0xb0, 0xa5, 0x07, 0x5b, 0x75, 0x37, 0xe9, 0x7c, 0x58,
+	0x48, 0x7f, 0xca, 0x83, 0x37, 0x01, 0x8f, 0x9c, 0x6a, 0x8f, 0xf4, 0x2d, 0x56, 0xe0, 0xa8, 0x03,
+	0x9b, 0x09, 0x5f, 0x04, 0x31, 0xf7, 0x9c, 0x0d, 0x63, 0xce, 0xa1, 0xfb, 0x93, 0x80, 0x95, 0x16,
+	0x27, 0x93, 0x38, 0x92, 0x48, 0xaf, 0x43, 0x4d, 0xad, 0xd6, 0x55, 0x53, 0xff, 0x51, 0xd1, 0x6d,
+	0x68, 0x4c, 0xe3, 0x30, 0x09, 0x50, 0xa1, 0x67, 0xea, 0xa9, 0xb3, 0x25, 0xa1, 0x3f, 0x81, 0x42,
+	0x1c, 0xc9, 0x99, 0xc9, 0xb5, 0xc1, 0x32, 0x44, 0x3b, 0x50, 0x97, 0x18, 0x79, 0x13, 0x3f, 0x44,
+	0x93, 0x66, 0x85, 0x5d, 0xe2, 0xd5, 0x0a, 0x6a, 0x85, 0x0a, 0xe8, 0x35, 0xd8, 0x90, 0x8a, 0x2b,
+	0xe9, 0x6c, 0x1a, 0x3e, 0x05, 0xee, 0x77, 0x02, 0x6d, 0x1d, 0x78, 0x8c, 0xc2, 0x47, 0x79, 0xe8,
+	0x4b, 0x45, 0xf7, 0xa0, 0xad, 0x0a, 0x8c, 0x43, 0x7a, 0x95, 0x7e, 0x73, 0xe7, 0xe6, 0x7a, 0x2d,
+	0x97, 0x4e, 0x6c, 0x2d, 0x80, 0xee, 0x43, 0xeb, 0xd4, 0xc7, 0xc0, 0xdb, 0x9b, 0xcd, 0x8e, 0x13,
+	0x9c, 0x4a, 0xa7, 0x6c, 0x14, 0xee, 0xac, 0x29, 0xec, 0xcd, 0x66, 0x02, 0x67, 0x5c, 0xc5, 0x42,
+	0x7b, 0xb1, 0x62, 0x0c, 0x7d, 0x00, 0x0d, 0xfc, 0x84, 0x61, 0x12, 0x70, 0x21, 0x9d, 0x8a, 0x11,
+	0x58, 0x6f, 0xe7, 0x41, 0x66, 0x67, 0x4b, 0x4f, 0xf7, 0x33, 0x81, 0x7a, 0xce, 0xeb, 0xa2, 0x8d,
+	0x68, 0x36, 0xa4, 0x14, 0xe8, 0xb6, 0xeb, 0x84, 0xa5, 0xe2, 0x61, 0x62, 0x06, 0x55, 0x61, 0x4b,
+	0x42, 0xb7, 0x50, 0x09, 0x3e, 0xc5, 0xd1, 0xd0, 0x8c, 0xc4, 0x62, 0x39, 0xd4, 0x03, 0x91, 0x09,
+	0x8f, 0x46, 0xc3, 0x6c, 0x79, 0x32, 0xa4, 0x07, 0xe2, 0xcd, 0x05, 0x57, 0x7e, 0x1c, 0xe5, 0x03,
+	0xc9, 0xb1, 0xfb, 0x95, 0x00, 0x2c, 0x3b, 0x45, 0x29, 0x54, 0x15, 0x9f, 0xc9, 0x2c, 0x1f, 0xf3,
+	0xa6, 0x4f, 0xa1, 0x66, 0xf2, 0xca, 0xdb, 0x74, 0xf7, 0x9f, 0x8d, 0x1e, 0x3c, 0x37, 0x7e, 0x07,
+	0x91, 0x12, 0x0b, 0x96, 0x05, 0x75, 0x1e, 0x41, 0x73, 0x85, 0xa6, 0x36, 0x54, 0xce, 0x70, 0x91,
+	0x7d, 0x40, 0x3f, 0x75, 0x13, 0xce, 0x79, 0x30, 0x4f, 0x77, 0xd2, 0x62, 0x29, 0x78, 0x5c, 0x7e,
+	0x48, 0xdc, 0x04, 0xda, 0xc5, 0x19, 0xe8, 0xd6, 0x18, 0xd9, 0x31, 0x0f, 0x31, 0xd3, 0x58, 0x12,
+	0x97, 0xd6, 0x49, 0xbe, 0xe1, 0x2d, 0xb6, 0x24, 0xf4, 0x85, 0x9d, 0xce, 0xa3, 0xa9, 0x7e, 0x9b,
+	0xb5, 0xd1, 0x33, 0x6b, 0xb1, 0x02, 0xb7, 0xb5, 0x0b, 0xf5, 0xfc, 0x06, 0x68, 0x13, 0x36, 0xdf,
+	0x8e, 0x5f, 0x8d, 0x5f, 0xbf, 0x1b, 0xdb, 0x25, 0x6a, 0x83, 0x35, 0x8a, 0x14, 0x8a, 0x10, 0x3d,
+	0x9f, 0x2b, 0xb4, 0x09, 0xad, 0x43, 0xf5, 0x10, 0xf9, 0xa9, 0x5d, 0xde, 0xda, 0x86, 0xe6, 0xca,
+	0x59, 0x6b, 0xc3, 0x90, 0x2b, 0x6e, 0x97, 0xa8, 0x05, 0xf5, 0x23, 0x54, 0xdc, 0xd3, 0x88, 0x50,
+	0x80, 0xda, 0x10, 0xf5, 0xe9, 0xd8, 0xe5, 0x9d, 0x93, 0xf4, 0xbf, 0xe8, 0x18, 0xc5, 0xb9, 0x3f,
+	0x45, 0xfa, 0x02, 0x6a, 0x2f, 0x79, 0xe4, 0x05, 0x48, 0x3b, 0x57, 0x5c, 0x64, 0x26, 0xde, 0xb9,
+	0x75, 0xa5, 0x2d, 0x3d, 0x78, 0xb7, 0xd4, 0x27, 0xf7, 0xc9, 0x33, 0xfb, 0xdb, 0x45, 0x97, 0xfc,
+	0xb8, 0xe8, 0x92, 0xdf, 0x17, 0x5d, 0xf2, 0xe5, 0x4f, 0xb7, 0xf4, 0xbe, 0x66, 0x62, 0x76, 0xff,
+	0x0e, 0x00, 0x80, 0x8d, 0xd6, 0xca, 0x1c, 0x05, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -765,6 +861,20 @@ func (m *TimeSeriesList) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.Exemplars) > 0 {
+		for iNdEx := len(m.Exemplars) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Exemplars[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintCommon(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
 	if len(m.FieldAggSpecs) > 0 {
 		for iNdEx := len(m.FieldAggSpecs) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -796,6 +906,64 @@ func (m *TimeSeriesList) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *Exemplar) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Exemplar) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Exemplar) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Duration != 0 {
+		i = encodeVarintCommon(dAtA, i, uint64(m.Duration))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.SpanID) > 0 {
+		i -= len(m.SpanID)
+		copy(dAtA[i:], m.SpanID)
+		i = encodeVarintCommon(dAtA, i, uint64(len(m.SpanID)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.TraceID) > 0 {
+		i -= len(m.TraceID)
+		copy(dAtA[i:], m.TraceID)
+		i = encodeVarintCommon(dAtA, i, uint64(len(m.TraceID)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Timestamp != 0 {
+		i = encodeVarintCommon(dAtA, i, uint64(m.Timestamp))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Field) > 0 {
+		i -= len(m.Field)
+		copy(dAtA[i:], m.Field)
+		i = encodeVarintCommon(dAtA, i, uint64(len(m.Field)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *TimeSeries) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1004,6 +1172,42 @@ func (m *TimeSeriesList) Size() (n int) {
 			n += 1 + l + sovCommon(uint64(l))
 		}
 	}
+	if len(m.Exemplars) > 0 {
+		for _, e := range m.Exemplars {
+			l = e.Size()
+			n += 1 + l + sovCommon(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Exemplar) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Field)
+	if l > 0 {
+		n += 1 + l + sovCommon(uint64(l))
+	}
+	if m.Timestamp != 0 {
+		n += 1 + sovCommon(uint64(m.Timestamp))
+	}
+	l = len(m.TraceID)
+	if l > 0 {
+		n += 1 + l + sovCommon(uint64(l))
+	}
+	l = len(m.SpanID)
+	if l > 0 {
+		n += 1 + l + sovCommon(uint64(l))
+	}
+	if m.Duration != 0 {
+		n += 1 + sovCommon(uint64(m.Duration))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1603,6 +1807,232 @@ func (m *TimeSeriesList) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Exemplars", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCommon
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Exemplars = append(m.Exemplars, &Exemplar{})
+			if err := m.Exemplars[len(m.Exemplars)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCommon(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCommon
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCommon
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Exemplar) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCommon
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Exemplar: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Exemplar: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Field", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCommon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Field = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TraceID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCommon
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TraceID = append(m.TraceID[:0], dAtA[iNdEx:postIndex]...)
+			if m.TraceID == nil {
+				m.TraceID = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SpanID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCommon
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SpanID = append(m.SpanID[:0], dAtA[iNdEx:postIndex]...)
+			if m.SpanID == nil {
+				m.SpanID = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Duration", wireType)
+			}
+			m.Duration = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Duration |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCommon(dAtA[iNdEx:])
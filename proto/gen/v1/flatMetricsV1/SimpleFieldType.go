@@ -29,22 +29,26 @@ const (
 	SimpleFieldTypeDeltaSum    SimpleFieldType = 2
 	SimpleFieldTypeMin         SimpleFieldType = 3
 	SimpleFieldTypeMax         SimpleFieldType = 4
+	// monotonically increasing total, converted to delta sum per series by storage
+	SimpleFieldTypeCumulativeSum SimpleFieldType = 5
 )
 
 var EnumNamesSimpleFieldType = map[SimpleFieldType]string{
-	SimpleFieldTypeUnSpecified: "UnSpecified",
-	SimpleFieldTypeGauge:       "Gauge",
-	SimpleFieldTypeDeltaSum:    "DeltaSum",
-	SimpleFieldTypeMin:         "Min",
-	SimpleFieldTypeMax:         "Max",
+	SimpleFieldTypeUnSpecified:   "UnSpecified",
+	SimpleFieldTypeGauge:         "Gauge",
+	SimpleFieldTypeDeltaSum:      "DeltaSum",
+	SimpleFieldTypeMin:           "Min",
+	SimpleFieldTypeMax:           "Max",
+	SimpleFieldTypeCumulativeSum: "CumulativeSum",
 }
 
 var EnumValuesSimpleFieldType = map[string]SimpleFieldType{
-	"UnSpecified": SimpleFieldTypeUnSpecified,
-	"Gauge":       SimpleFieldTypeGauge,
-	"DeltaSum":    SimpleFieldTypeDeltaSum,
-	"Min":         SimpleFieldTypeMin,
-	"Max":         SimpleFieldTypeMax,
+	"UnSpecified":   SimpleFieldTypeUnSpecified,
+	"Gauge":         SimpleFieldTypeGauge,
+	"DeltaSum":      SimpleFieldTypeDeltaSum,
+	"Min":           SimpleFieldTypeMin,
+	"Max":           SimpleFieldTypeMax,
+	"CumulativeSum": SimpleFieldTypeCumulativeSum,
 }
 
 func (v SimpleFieldType) String() string {
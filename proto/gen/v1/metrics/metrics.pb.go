@@ -48,6 +48,8 @@ const (
 	SimpleFieldType_DELTA_SUM          SimpleFieldType = 2
 	SimpleFieldType_Min                SimpleFieldType = 3
 	SimpleFieldType_Max                SimpleFieldType = 4
+	// monotonically increasing total, converted to delta sum per series by storage
+	SimpleFieldType_CUMULATIVE_SUM SimpleFieldType = 5
 )
 
 var SimpleFieldType_name = map[int32]string{
@@ -56,6 +58,7 @@ var SimpleFieldType_name = map[int32]string{
 	2: "DELTA_SUM",
 	3: "Min",
 	4: "Max",
+	5: "CUMULATIVE_SUM",
 }
 
 var SimpleFieldType_value = map[string]int32{
@@ -64,6 +67,7 @@ var SimpleFieldType_value = map[string]int32{
 	"DELTA_SUM":          2,
 	"Min":                3,
 	"Max":                4,
+	"CUMULATIVE_SUM":     5,
 }
 
 func (x SimpleFieldType) String() string {
@@ -348,11 +352,13 @@ type CompoundField struct {
 	// Histogram buckets are inclusive of their upper boundary, except the last
 	// bucket where the boundary is at infinity. This format is intentionally
 	// compatible with the OpenMetrics histogram definition.
-	ExplicitBounds       []float64 `protobuf:"fixed64,6,rep,packed,name=explicit_bounds,json=explicitBounds,proto3" json:"explicit_bounds,omitempty"`
-	Values               []float64 `protobuf:"fixed64,7,rep,packed,name=values,proto3" json:"values,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+	ExplicitBounds []float64 `protobuf:"fixed64,6,rep,packed,name=explicit_bounds,json=explicitBounds,proto3" json:"explicit_bounds,omitempty"`
+	Values         []float64 `protobuf:"fixed64,7,rep,packed,name=values,proto3" json:"values,omitempty"`
+	// binary of mergeable quantile sketch, used for sketch field instead of explicit bounds/values.
+	Sketch               []byte   `protobuf:"bytes,8,opt,name=sketch,proto3" json:"sketch,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *CompoundField) Reset()         { *m = CompoundField{} }
@@ -437,6 +443,13 @@ func (m *CompoundField) GetValues() []float64 {
 	return nil
 }
 
+func (m *CompoundField) GetSketch() []byte {
+	if m != nil {
+		return m.Sketch
+	}
+	return nil
+}
+
 // KeyValue is a key-value pair that is used to store tag/label attributes
 type KeyValue struct {
 	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
@@ -575,43 +588,44 @@ func init() {
 func init() { proto.RegisterFile("metrics.proto", fileDescriptor_6039342a2ba47b72) }
 
 var fileDescriptor_6039342a2ba47b72 = []byte{
-	// 569 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x52, 0xcd, 0x6e, 0xd3, 0x4c,
-	0x14, 0xed, 0xc4, 0x4e, 0x1c, 0xdf, 0x34, 0xa9, 0x35, 0xfa, 0x94, 0x6f, 0xa0, 0x10, 0xac, 0x6c,
-	0xb0, 0x10, 0x8a, 0x20, 0x95, 0x58, 0x22, 0xda, 0xc6, 0x2d, 0x11, 0x09, 0xaa, 0x26, 0x4d, 0x17,
-	0x6c, 0xac, 0xa9, 0x3d, 0x10, 0x8b, 0xf8, 0x47, 0x19, 0x07, 0x25, 0x6f, 0xc2, 0x03, 0xb0, 0xe3,
-	0x45, 0x58, 0xb2, 0xe0, 0x01, 0x50, 0x78, 0x11, 0x34, 0x63, 0xa7, 0xf9, 0x11, 0x42, 0xac, 0xe6,
-	0x9e, 0x73, 0xcf, 0xdc, 0xb9, 0xf7, 0xdc, 0x81, 0x7a, 0xc4, 0xb3, 0x59, 0xe8, 0x8b, 0x4e, 0x3a,
-	0x4b, 0xb2, 0x04, 0x37, 0xd4, 0x31, 0xcc, 0xb9, 0x9b, 0xe7, 0xed, 0x97, 0x00, 0x39, 0x18, 0x84,
-	0x22, 0xc3, 0xcf, 0xc0, 0x28, 0xe4, 0xa4, 0x64, 0x6b, 0x4e, 0xad, 0xdb, 0xec, 0xec, 0xea, 0x3b,
-	0x79, 0x44, 0xd7, 0xb2, 0xf6, 0xd7, 0x12, 0x54, 0x72, 0x0e, 0x3f, 0x00, 0x33, 0x66, 0x11, 0x17,
-	0x29, 0xf3, 0x39, 0x41, 0x36, 0x72, 0x4c, 0xba, 0x21, 0x30, 0x06, 0x5d, 0x02, 0x52, 0x52, 0x09,
-	0x15, 0xcb, 0x1b, 0x59, 0x18, 0x71, 0x91, 0xb1, 0x28, 0x25, 0x9a, 0x8d, 0x1c, 0x8d, 0x6e, 0x08,
-	0xfc, 0x14, 0xf4, 0x8c, 0x7d, 0x10, 0x44, 0x57, 0x9d, 0x90, 0xfd, 0x4e, 0xde, 0xf0, 0xe5, 0x0d,
-	0x9b, 0xce, 0x39, 0x55, 0x2a, 0x7c, 0x0c, 0xa6, 0x3c, 0xbd, 0x09, 0x13, 0x13, 0x52, 0xb6, 0x91,
-	0xa3, 0xd3, 0xaa, 0x24, 0x5e, 0x33, 0x31, 0xc1, 0xaf, 0xa0, 0x2e, 0xc2, 0x28, 0x9d, 0x72, 0xef,
-	0x7d, 0xc8, 0xa7, 0x81, 0x20, 0x15, 0x55, 0xf3, 0x78, 0xbf, 0xe6, 0x48, 0x89, 0x2e, 0xa4, 0x86,
-	0x1e, 0x8a, 0x0d, 0x10, 0xb8, 0x07, 0x0d, 0x3f, 0x89, 0xd2, 0x64, 0x1e, 0x07, 0x79, 0x0d, 0x62,
-	0xd8, 0xc8, 0xa9, 0x75, 0x1f, 0xee, 0x97, 0x38, 0x2f, 0x54, 0x79, 0x91, 0xba, 0xbf, 0x0d, 0xdb,
-	0x5f, 0x10, 0xd4, 0xb6, 0xde, 0xb8, 0x33, 0x05, 0x6d, 0x99, 0x72, 0x02, 0x7a, 0xb6, 0x4c, 0x73,
-	0xa3, 0x1a, 0xdd, 0x47, 0x7f, 0x69, 0xf1, 0x7a, 0x99, 0xca, 0xe9, 0x97, 0x29, 0xc7, 0x2f, 0xc0,
-	0xe4, 0x0b, 0x1e, 0xa5, 0x53, 0x36, 0x13, 0x44, 0xfb, 0xb3, 0x61, 0x6e, 0x21, 0xa0, 0x1b, 0x29,
-	0xfe, 0x0f, 0xca, 0x9f, 0xa4, 0x89, 0x44, 0xb7, 0x91, 0x83, 0x68, 0x0e, 0xda, 0x3f, 0x10, 0xd4,
-	0x77, 0xe6, 0xd8, 0xad, 0x8f, 0xfe, 0xbd, 0xbe, 0x05, 0x5a, 0x14, 0xc6, 0x6a, 0x16, 0x44, 0x65,
-	0xa8, 0x18, 0xb6, 0x50, 0xdb, 0x96, 0x0c, 0x5b, 0x48, 0x46, 0xcc, 0xa3, 0xa2, 0x03, 0x19, 0xca,
-	0xae, 0xfc, 0x64, 0x1e, 0x67, 0x6a, 0x8f, 0x88, 0xe6, 0x00, 0x3f, 0x86, 0x23, 0xbe, 0x48, 0xa7,
-	0xa1, 0x1f, 0x66, 0xde, 0xad, 0x6c, 0x2d, 0x5f, 0x23, 0xa2, 0x8d, 0x35, 0x7d, 0xa6, 0x58, 0xdc,
-	0x84, 0x8a, 0x9a, 0x43, 0x10, 0x43, 0xe5, 0x0b, 0xd4, 0xee, 0x42, 0x75, 0xfd, 0x69, 0xe4, 0xa3,
-	0x1f, 0xf9, 0xb2, 0x30, 0x5e, 0x86, 0x1b, 0x2b, 0xf2, 0x1f, 0x5a, 0x58, 0xf1, 0x0e, 0xaa, 0xeb,
-	0xb9, 0xf0, 0xff, 0x60, 0x88, 0x94, 0xc5, 0x5e, 0x18, 0xa8, 0x7b, 0x87, 0xb4, 0x22, 0x61, 0x3f,
-	0xc0, 0xf7, 0xa0, 0x9a, 0xcd, 0x98, 0xcf, 0x65, 0xa6, 0xa4, 0x32, 0x86, 0xc2, 0xfd, 0x00, 0xdf,
-	0x87, 0x6a, 0x30, 0x9f, 0xb1, 0x2c, 0x4c, 0xe2, 0xe2, 0x87, 0xdf, 0xe1, 0x27, 0x63, 0x38, 0xda,
-	0xdb, 0x26, 0x6e, 0x02, 0x1e, 0xf5, 0x87, 0x57, 0x03, 0xd7, 0x1b, 0xbf, 0x1d, 0x5d, 0xb9, 0xe7,
-	0xfd, 0x8b, 0xbe, 0xdb, 0xb3, 0x0e, 0xb0, 0x09, 0xe5, 0xcb, 0xd3, 0xf1, 0xa5, 0x6b, 0x21, 0x5c,
-	0x07, 0xb3, 0xe7, 0x0e, 0xae, 0x4f, 0xbd, 0xd1, 0x78, 0x68, 0x95, 0xb0, 0x01, 0xda, 0x30, 0x8c,
-	0x2d, 0x4d, 0x05, 0x6c, 0x61, 0xe9, 0x67, 0xd6, 0xb7, 0x55, 0x0b, 0x7d, 0x5f, 0xb5, 0xd0, 0xcf,
-	0x55, 0x0b, 0x7d, 0xfe, 0xd5, 0x3a, 0xb8, 0xad, 0xa8, 0x4d, 0x9d, 0xfc, 0x0e, 0x00, 0x00, 0xff,
-	0xff, 0x50, 0xfd, 0x5e, 0xe6, 0x0c, 0x04, 0x00, 0x00,
+	// 590 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x52, 0xcb, 0x6e, 0xd3, 0x40,
+	0x14, 0xed, 0xc4, 0xce, 0xeb, 0xb6, 0x49, 0xad, 0x11, 0x2a, 0x03, 0x85, 0x60, 0x65, 0x83, 0x85,
+	0x50, 0x05, 0xa9, 0xc4, 0x12, 0xd1, 0x87, 0x5b, 0x22, 0x12, 0x54, 0x4d, 0x9a, 0x2e, 0xd8, 0x44,
+	0x53, 0x7b, 0x20, 0xa3, 0xc6, 0xf6, 0x28, 0x33, 0x41, 0xc9, 0x9f, 0xf0, 0x01, 0xec, 0xf8, 0x11,
+	0x96, 0x7c, 0x02, 0x2a, 0x5b, 0x3e, 0x02, 0xcd, 0xd8, 0x69, 0xda, 0x08, 0x21, 0x56, 0xbe, 0xe7,
+	0xdc, 0x93, 0x93, 0x7b, 0xcf, 0x1d, 0x68, 0x24, 0x5c, 0x4f, 0x45, 0xa4, 0xf6, 0xe4, 0x34, 0xd3,
+	0x19, 0x6e, 0xda, 0x4f, 0x3f, 0xe7, 0x2e, 0x5e, 0xb6, 0x5f, 0x03, 0xe4, 0xa0, 0x27, 0x94, 0xc6,
+	0x2f, 0xa0, 0x5a, 0xc8, 0x49, 0xc9, 0x77, 0x82, 0xcd, 0xce, 0xce, 0xde, 0x5d, 0xfd, 0x5e, 0x5e,
+	0xd1, 0xa5, 0xac, 0xfd, 0xad, 0x04, 0x95, 0x9c, 0xc3, 0x8f, 0xa0, 0x9e, 0xb2, 0x84, 0x2b, 0xc9,
+	0x22, 0x4e, 0x90, 0x8f, 0x82, 0x3a, 0x5d, 0x11, 0x18, 0x83, 0x6b, 0x00, 0x29, 0xd9, 0x86, 0xad,
+	0xcd, 0x2f, 0xb4, 0x48, 0xb8, 0xd2, 0x2c, 0x91, 0xc4, 0xf1, 0x51, 0xe0, 0xd0, 0x15, 0x81, 0x9f,
+	0x83, 0xab, 0xd9, 0x27, 0x45, 0x5c, 0x3b, 0x09, 0x59, 0x9f, 0xe4, 0x1d, 0x5f, 0x5c, 0xb0, 0xc9,
+	0x8c, 0x53, 0xab, 0xc2, 0xbb, 0x50, 0x37, 0xdf, 0xd1, 0x98, 0xa9, 0x31, 0x29, 0xfb, 0x28, 0x70,
+	0x69, 0xcd, 0x10, 0x6f, 0x99, 0x1a, 0xe3, 0x37, 0xd0, 0x50, 0x22, 0x91, 0x13, 0x3e, 0xfa, 0x28,
+	0xf8, 0x24, 0x56, 0xa4, 0x62, 0x3d, 0x77, 0xd7, 0x3d, 0x07, 0x56, 0x74, 0x62, 0x34, 0x74, 0x4b,
+	0xad, 0x80, 0xc2, 0xc7, 0xd0, 0x8c, 0xb2, 0x44, 0x66, 0xb3, 0x34, 0xce, 0x3d, 0x48, 0xd5, 0x47,
+	0xc1, 0x66, 0xe7, 0xf1, 0xba, 0xc5, 0x51, 0xa1, 0xca, 0x4d, 0x1a, 0xd1, 0x6d, 0xd8, 0xfe, 0x8a,
+	0x60, 0xf3, 0xd6, 0x7f, 0xdc, 0x84, 0x82, 0x6e, 0x85, 0xb2, 0x0f, 0xae, 0x5e, 0xc8, 0x3c, 0xa8,
+	0x66, 0xe7, 0xc9, 0x3f, 0x46, 0x3c, 0x5f, 0x48, 0xb3, 0xfd, 0x42, 0x72, 0xfc, 0x0a, 0xea, 0x7c,
+	0xce, 0x13, 0x39, 0x61, 0x53, 0x45, 0x9c, 0xbf, 0x07, 0x16, 0x16, 0x02, 0xba, 0x92, 0xe2, 0x7b,
+	0x50, 0xfe, 0x6c, 0x42, 0x24, 0xae, 0x8f, 0x02, 0x44, 0x73, 0xd0, 0xfe, 0x8d, 0xa0, 0x71, 0x67,
+	0x8f, 0xbb, 0xfe, 0xe8, 0xff, 0xfd, 0x3d, 0x70, 0x12, 0x91, 0xda, 0x5d, 0x10, 0x35, 0xa5, 0x65,
+	0xd8, 0x9c, 0x38, 0x05, 0xc3, 0xe6, 0x86, 0x51, 0xb3, 0xa4, 0x98, 0xc0, 0x94, 0x66, 0xaa, 0x28,
+	0x9b, 0xa5, 0xda, 0xde, 0x11, 0xd1, 0x1c, 0xe0, 0xa7, 0xb0, 0xcd, 0xe7, 0x72, 0x22, 0x22, 0xa1,
+	0x47, 0x97, 0x66, 0xb4, 0xfc, 0x8c, 0x88, 0x36, 0x97, 0xf4, 0xa1, 0x65, 0xf1, 0x0e, 0x54, 0xec,
+	0x1e, 0x8a, 0x54, 0x6d, 0xbf, 0x40, 0x86, 0x57, 0x57, 0x5c, 0x47, 0x63, 0x52, 0xf3, 0x51, 0xb0,
+	0x45, 0x0b, 0xd4, 0xee, 0x40, 0x6d, 0xf9, 0x98, 0xcc, 0x30, 0x57, 0x7c, 0x51, 0x1c, 0xc4, 0x94,
+	0xab, 0x88, 0xf2, 0x97, 0x5b, 0x44, 0xf4, 0x01, 0x6a, 0xcb, 0x7d, 0xf1, 0x7d, 0xa8, 0x2a, 0xc9,
+	0xd2, 0x91, 0x88, 0x09, 0x2a, 0x8c, 0x25, 0x4b, 0xbb, 0x31, 0x7e, 0x00, 0x35, 0x3d, 0x65, 0x11,
+	0x37, 0x9d, 0x92, 0xed, 0x54, 0x2d, 0xee, 0xc6, 0xf8, 0x21, 0xd4, 0xe2, 0xd9, 0x94, 0x69, 0x91,
+	0xa5, 0xc5, 0xcb, 0xbf, 0xc1, 0xcf, 0x04, 0x6c, 0xaf, 0x5d, 0x19, 0xef, 0x00, 0x1e, 0x74, 0xfb,
+	0x67, 0xbd, 0x70, 0x34, 0x7c, 0x3f, 0x38, 0x0b, 0x8f, 0xba, 0x27, 0xdd, 0xf0, 0xd8, 0xdb, 0xc0,
+	0x75, 0x28, 0x9f, 0x1e, 0x0c, 0x4f, 0x43, 0x0f, 0xe1, 0x06, 0xd4, 0x8f, 0xc3, 0xde, 0xf9, 0xc1,
+	0x68, 0x30, 0xec, 0x7b, 0x25, 0x5c, 0x05, 0xa7, 0x2f, 0x52, 0xcf, 0xb1, 0x05, 0x9b, 0x7b, 0x2e,
+	0xc6, 0xd0, 0x3c, 0x1a, 0xf6, 0x87, 0xbd, 0x83, 0xf3, 0xee, 0x45, 0x68, 0x55, 0xe5, 0x43, 0xef,
+	0xfb, 0x75, 0x0b, 0xfd, 0xb8, 0x6e, 0xa1, 0x9f, 0xd7, 0x2d, 0xf4, 0xe5, 0x57, 0x6b, 0xe3, 0xb2,
+	0x62, 0xaf, 0xba, 0xff, 0x67, 0x00, 0xb8, 0xc6, 0xf7, 0xf5, 0x38, 0x04, 0x00, 0x00,
 }
 
 func (m *MetricList) Marshal() (dAtA []byte, err error) {
@@ -829,6 +843,13 @@ func (m *CompoundField) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.Sketch) > 0 {
+		i -= len(m.Sketch)
+		copy(dAtA[i:], m.Sketch)
+		i = encodeVarintMetrics(dAtA, i, uint64(len(m.Sketch)))
+		i--
+		dAtA[i] = 0x42
+	}
 	if len(m.Values) > 0 {
 		for iNdEx := len(m.Values) - 1; iNdEx >= 0; iNdEx-- {
 			f2 := math.Float64bits(float64(m.Values[iNdEx]))
@@ -1106,6 +1127,10 @@ func (m *CompoundField) Size() (n int) {
 	if len(m.Values) > 0 {
 		n += 1 + sovMetrics(uint64(len(m.Values)*8)) + len(m.Values)*8
 	}
+	l = len(m.Sketch)
+	if l > 0 {
+		n += 1 + l + sovMetrics(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1874,6 +1899,40 @@ func (m *CompoundField) Unmarshal(dAtA []byte) error {
 			} else {
 				return fmt.Errorf("proto: wrong wireType = %d for field Values", wireType)
 			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sketch", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetrics
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMetrics
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetrics
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sketch = append(m.Sketch[:0], dAtA[iNdEx:postIndex]...)
+			if m.Sketch == nil {
+				m.Sketch = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMetrics(dAtA[iNdEx:])
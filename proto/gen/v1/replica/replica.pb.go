@@ -413,6 +413,124 @@ func (m *ReplicaResponse) GetErr() string {
 	return ""
 }
 
+type SnapshotRequest struct {
+	SnapshotIndex        int64    `protobuf:"varint,1,opt,name=snapshotIndex,proto3" json:"snapshotIndex,omitempty"`
+	Path                 string   `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Dir                  bool     `protobuf:"varint,3,opt,name=dir,proto3" json:"dir,omitempty"`
+	Data                 []byte   `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotRequest) Reset()         { *m = SnapshotRequest{} }
+func (m *SnapshotRequest) String() string { return proto.CompactTextString(m) }
+func (*SnapshotRequest) ProtoMessage()    {}
+func (*SnapshotRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1e84aa831fb48ea1, []int{6}
+}
+func (m *SnapshotRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SnapshotRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SnapshotRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SnapshotRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotRequest.Merge(m, src)
+}
+func (m *SnapshotRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SnapshotRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotRequest proto.InternalMessageInfo
+
+func (m *SnapshotRequest) GetSnapshotIndex() int64 {
+	if m != nil {
+		return m.SnapshotIndex
+	}
+	return 0
+}
+
+func (m *SnapshotRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *SnapshotRequest) GetDir() bool {
+	if m != nil {
+		return m.Dir
+	}
+	return false
+}
+
+func (m *SnapshotRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type SnapshotResponse struct {
+	AckIndex             int64    `protobuf:"varint,1,opt,name=ackIndex,proto3" json:"ackIndex,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotResponse) Reset()         { *m = SnapshotResponse{} }
+func (m *SnapshotResponse) String() string { return proto.CompactTextString(m) }
+func (*SnapshotResponse) ProtoMessage()    {}
+func (*SnapshotResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1e84aa831fb48ea1, []int{7}
+}
+func (m *SnapshotResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SnapshotResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SnapshotResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SnapshotResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotResponse.Merge(m, src)
+}
+func (m *SnapshotResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *SnapshotResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotResponse proto.InternalMessageInfo
+
+func (m *SnapshotResponse) GetAckIndex() int64 {
+	if m != nil {
+		return m.AckIndex
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*ResetIndexRequest)(nil), "protoReplicaV1.ResetIndexRequest")
 	proto.RegisterType((*ResetIndexResponse)(nil), "protoReplicaV1.ResetIndexResponse")
@@ -420,35 +538,42 @@ func init() {
 	proto.RegisterType((*GetReplicaAckIndexResponse)(nil), "protoReplicaV1.GetReplicaAckIndexResponse")
 	proto.RegisterType((*ReplicaRequest)(nil), "protoReplicaV1.ReplicaRequest")
 	proto.RegisterType((*ReplicaResponse)(nil), "protoReplicaV1.ReplicaResponse")
+	proto.RegisterType((*SnapshotRequest)(nil), "protoReplicaV1.SnapshotRequest")
+	proto.RegisterType((*SnapshotResponse)(nil), "protoReplicaV1.SnapshotResponse")
 }
 
 func init() { proto.RegisterFile("replica.proto", fileDescriptor_1e84aa831fb48ea1) }
 
 var fileDescriptor_1e84aa831fb48ea1 = []byte{
-	// 364 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x92, 0xbf, 0x4e, 0xf3, 0x30,
-	0x14, 0xc5, 0xeb, 0xf6, 0x4b, 0xfb, 0x71, 0x29, 0xa5, 0x58, 0x15, 0x32, 0x19, 0x42, 0xf0, 0x14,
-	0x18, 0x2a, 0xfe, 0x2c, 0xac, 0xb0, 0x20, 0x24, 0x86, 0xca, 0x20, 0x76, 0x37, 0xb9, 0x12, 0x15,
-	0x6d, 0x13, 0x9c, 0x80, 0xd8, 0x78, 0x0d, 0x76, 0x5e, 0x86, 0x11, 0xde, 0x00, 0x95, 0x17, 0x41,
-	0x75, 0xdc, 0x34, 0x25, 0xb4, 0xea, 0x00, 0x53, 0x72, 0x8f, 0x7d, 0xee, 0xfd, 0xd9, 0x3e, 0xb0,
-	0xa6, 0x30, 0xea, 0xf7, 0x7c, 0xd9, 0x8e, 0x54, 0x98, 0x84, 0xb4, 0xa1, 0x3f, 0x22, 0xd5, 0xae,
-	0x0f, 0xf8, 0x13, 0x6c, 0x08, 0x8c, 0x31, 0x39, 0x1f, 0x06, 0xf8, 0x28, 0xf0, 0xee, 0x1e, 0xe3,
-	0x84, 0xda, 0xf0, 0x3f, 0x90, 0x89, 0xec, 0xca, 0x18, 0x19, 0x71, 0x89, 0xb7, 0x22, 0xb2, 0x9a,
-	0xb6, 0xc0, 0x8a, 0x6f, 0xa4, 0x0a, 0x58, 0xd9, 0x25, 0x9e, 0x25, 0xd2, 0x82, 0x6e, 0x42, 0xb5,
-	0x8f, 0x32, 0x40, 0xc5, 0x2a, 0x5a, 0x36, 0x15, 0x75, 0x61, 0x55, 0x46, 0x11, 0x0e, 0x03, 0xdd,
-	0x9f, 0xfd, 0x73, 0x89, 0x57, 0x11, 0x79, 0x89, 0xb7, 0x80, 0xe6, 0x01, 0xe2, 0x28, 0x1c, 0xc6,
-	0xc8, 0x11, 0xb6, 0xce, 0x30, 0x31, 0x98, 0x27, 0xfe, 0xed, 0xdf, 0xe0, 0xf1, 0x63, 0xb0, 0x7f,
-	0x1a, 0x93, 0x42, 0x8c, 0xe7, 0x48, 0xa3, 0x31, 0x4b, 0x93, 0x67, 0x35, 0xbf, 0x80, 0x86, 0xb1,
-	0x4d, 0xa8, 0x38, 0xd4, 0xcd, 0x55, 0xe7, 0xcf, 0x3a, 0xa3, 0x8d, 0x39, 0x14, 0xfa, 0xa1, 0x0a,
-	0x74, 0xbf, 0xba, 0x30, 0x15, 0x7f, 0x27, 0xb0, 0x9e, 0xb5, 0x9b, 0x4e, 0xff, 0xa5, 0x47, 0x58,
-	0x86, 0x6c, 0xc1, 0x59, 0x53, 0x7f, 0x4a, 0x75, 0xd5, 0x1b, 0x20, 0xab, 0x4e, 0xfc, 0x53, 0x8d,
-	0x36, 0xa1, 0x82, 0x4a, 0xb1, 0x9a, 0x06, 0x1d, 0xff, 0x1e, 0xbe, 0x94, 0xb3, 0x2b, 0xba, 0x44,
-	0xf5, 0xd0, 0xf3, 0x91, 0x76, 0xc0, 0xd2, 0x6f, 0x4d, 0x77, 0xda, 0xb3, 0x31, 0x6c, 0x17, 0x32,
-	0x68, 0xf3, 0x45, 0x5b, 0x4c, 0x4a, 0x4a, 0x74, 0x00, 0xb4, 0xf8, 0x80, 0x74, 0xf7, 0xbb, 0x77,
-	0x6e, 0x96, 0xec, 0xbd, 0x65, 0xb6, 0x66, 0xe3, 0x3a, 0x50, 0x33, 0x8b, 0xd4, 0x29, 0xf2, 0xe5,
-	0xe3, 0x60, 0x6f, 0xcf, 0x5d, 0x9f, 0x74, 0xf3, 0xc8, 0x3e, 0x39, 0x6d, 0xbe, 0x8e, 0x1c, 0xf2,
-	0x36, 0x72, 0xc8, 0xc7, 0xc8, 0x21, 0xcf, 0x9f, 0x4e, 0xa9, 0x5b, 0xd5, 0xbe, 0xa3, 0xaf, 0x00,
-	0x00, 0x00, 0xff, 0xff, 0xd8, 0xd8, 0xbe, 0xb5, 0xb9, 0x03, 0x00, 0x00,
+	// 447 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x53, 0xbd, 0x6e, 0xd4, 0x40,
+	0x10, 0xce, 0xe6, 0x72, 0x97, 0x64, 0xb8, 0xe4, 0x8e, 0x51, 0x84, 0x8c, 0x0b, 0x63, 0x56, 0x14,
+	0x07, 0xc5, 0x89, 0x9f, 0x86, 0x16, 0x1a, 0x14, 0x89, 0x22, 0xda, 0x20, 0x44, 0xbb, 0xb1, 0x47,
+	0x3a, 0x0b, 0xc7, 0x76, 0x76, 0x17, 0x44, 0xc7, 0x6b, 0xf0, 0x48, 0x94, 0xf0, 0x06, 0xe8, 0x78,
+	0x0e, 0x24, 0xe4, 0xf5, 0xfa, 0x2f, 0xce, 0x45, 0x57, 0x40, 0xe5, 0x99, 0xcf, 0x33, 0xdf, 0x7c,
+	0xf3, 0xb3, 0x70, 0xa4, 0xa8, 0x48, 0x93, 0x48, 0x2e, 0x0b, 0x95, 0x9b, 0x1c, 0x8f, 0xed, 0x47,
+	0x54, 0xd8, 0xfb, 0x67, 0xfc, 0x2b, 0xdc, 0x15, 0xa4, 0xc9, 0x9c, 0x66, 0x31, 0x7d, 0x11, 0x74,
+	0xf5, 0x89, 0xb4, 0x41, 0x1f, 0x0e, 0x62, 0x69, 0xe4, 0x85, 0xd4, 0xe4, 0xb1, 0x90, 0x2d, 0x0e,
+	0x45, 0xe3, 0xe3, 0x09, 0x8c, 0xf5, 0x4a, 0xaa, 0xd8, 0xdb, 0x0d, 0xd9, 0x62, 0x2c, 0x2a, 0x07,
+	0xef, 0xc1, 0x24, 0x25, 0x19, 0x93, 0xf2, 0x46, 0x16, 0x76, 0x1e, 0x86, 0x70, 0x47, 0x16, 0x05,
+	0x65, 0xb1, 0xe5, 0xf7, 0xf6, 0x42, 0xb6, 0x18, 0x89, 0x2e, 0xc4, 0x4f, 0x00, 0xbb, 0x02, 0x74,
+	0x91, 0x67, 0x9a, 0x38, 0xc1, 0xfd, 0x37, 0x64, 0x9c, 0xcc, 0x57, 0xd1, 0xc7, 0xff, 0x23, 0x8f,
+	0xbf, 0x04, 0xff, 0xa6, 0x32, 0x95, 0x88, 0xb2, 0x8e, 0x74, 0x98, 0x37, 0xb6, 0xca, 0x1b, 0x9f,
+	0xbf, 0x85, 0x63, 0x97, 0x56, 0xab, 0xe2, 0x30, 0x75, 0xa3, 0xee, 0xf6, 0xda, 0xc3, 0x4a, 0x1d,
+	0x8a, 0xa2, 0x5c, 0xc5, 0x96, 0x6f, 0x2a, 0x9c, 0xc7, 0x7f, 0x32, 0x98, 0x35, 0x74, 0x6d, 0xf5,
+	0x7f, 0xb4, 0x84, 0x6d, 0x94, 0xdd, 0xd2, 0x6b, 0x95, 0x5f, 0xa9, 0x7a, 0x97, 0x5c, 0x92, 0x37,
+	0xa9, 0xf3, 0x5b, 0x0c, 0xe7, 0x30, 0x22, 0xa5, 0xbc, 0x7d, 0x2b, 0xb4, 0x34, 0xf9, 0x15, 0xcc,
+	0xce, 0x33, 0x59, 0xe8, 0x55, 0x6e, 0xea, 0x11, 0x3d, 0x82, 0x23, 0xed, 0xa0, 0xaa, 0x12, 0xb3,
+	0x4c, 0x7d, 0x10, 0x11, 0xf6, 0x0a, 0x69, 0x56, 0xb6, 0xb7, 0x43, 0x61, 0xed, 0x92, 0x3e, 0x4e,
+	0xaa, 0xbe, 0x0e, 0x44, 0x69, 0x96, 0x51, 0xe5, 0x38, 0x6c, 0x33, 0x53, 0x61, 0x6d, 0xbe, 0x84,
+	0x79, 0x5b, 0xf2, 0x86, 0x25, 0xb2, 0x7e, 0x63, 0xcf, 0xff, 0xec, 0x36, 0x5b, 0x3c, 0x27, 0xf5,
+	0x39, 0x89, 0x08, 0xcf, 0x60, 0x6c, 0xcf, 0x11, 0x1f, 0x2e, 0xfb, 0x2f, 0x65, 0x39, 0x78, 0x26,
+	0x3e, 0xbf, 0x2d, 0xc4, 0x1d, 0xf2, 0x0e, 0x5e, 0x02, 0x0e, 0x6f, 0x0c, 0x1f, 0x5f, 0xcf, 0xdd,
+	0x78, 0xee, 0xfe, 0x93, 0x6d, 0x42, 0x9b, 0x72, 0x67, 0xb0, 0xef, 0x7e, 0x62, 0x30, 0xd4, 0xd7,
+	0xbd, 0x58, 0xff, 0xc1, 0xc6, 0xff, 0x35, 0xdb, 0x82, 0x3d, 0x65, 0xf8, 0x01, 0x66, 0xa7, 0x99,
+	0x36, 0x32, 0x4d, 0xeb, 0xe1, 0xe2, 0x20, 0xf3, 0xda, 0xa6, 0xfd, 0x70, 0x73, 0x40, 0xcb, 0xfd,
+	0x7a, 0xfe, 0x7d, 0x1d, 0xb0, 0x1f, 0xeb, 0x80, 0xfd, 0x5a, 0x07, 0xec, 0xdb, 0xef, 0x60, 0xe7,
+	0x62, 0x62, 0xd3, 0x5e, 0xfc, 0x1d, 0x00, 0xc2, 0xe6, 0x22, 0x38, 0xb6, 0x04, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -466,6 +591,7 @@ type ReplicaServiceClient interface {
 	Reset(ctx context.Context, in *ResetIndexRequest, opts ...grpc.CallOption) (*ResetIndexResponse, error)
 	GetReplicaAckIndex(ctx context.Context, in *GetReplicaAckIndexRequest, opts ...grpc.CallOption) (*GetReplicaAckIndexResponse, error)
 	Replica(ctx context.Context, opts ...grpc.CallOption) (ReplicaService_ReplicaClient, error)
+	InstallSnapshot(ctx context.Context, opts ...grpc.CallOption) (ReplicaService_InstallSnapshotClient, error)
 }
 
 type replicaServiceClient struct {
@@ -525,11 +651,46 @@ func (x *replicaServiceReplicaClient) Recv() (*ReplicaResponse, error) {
 	return m, nil
 }
 
+func (c *replicaServiceClient) InstallSnapshot(ctx context.Context, opts ...grpc.CallOption) (ReplicaService_InstallSnapshotClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ReplicaService_serviceDesc.Streams[1], "/protoReplicaV1.ReplicaService/InstallSnapshot", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &replicaServiceInstallSnapshotClient{stream}
+	return x, nil
+}
+
+type ReplicaService_InstallSnapshotClient interface {
+	Send(*SnapshotRequest) error
+	CloseAndRecv() (*SnapshotResponse, error)
+	grpc.ClientStream
+}
+
+type replicaServiceInstallSnapshotClient struct {
+	grpc.ClientStream
+}
+
+func (x *replicaServiceInstallSnapshotClient) Send(m *SnapshotRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *replicaServiceInstallSnapshotClient) CloseAndRecv() (*SnapshotResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(SnapshotResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ReplicaServiceServer is the server API for ReplicaService service.
 type ReplicaServiceServer interface {
 	Reset(context.Context, *ResetIndexRequest) (*ResetIndexResponse, error)
 	GetReplicaAckIndex(context.Context, *GetReplicaAckIndexRequest) (*GetReplicaAckIndexResponse, error)
 	Replica(ReplicaService_ReplicaServer) error
+	InstallSnapshot(ReplicaService_InstallSnapshotServer) error
 }
 
 // UnimplementedReplicaServiceServer can be embedded to have forward compatible implementations.
@@ -545,6 +706,9 @@ func (*UnimplementedReplicaServiceServer) GetReplicaAckIndex(ctx context.Context
 func (*UnimplementedReplicaServiceServer) Replica(srv ReplicaService_ReplicaServer) error {
 	return status.Errorf(codes.Unimplemented, "method Replica not implemented")
 }
+func (*UnimplementedReplicaServiceServer) InstallSnapshot(srv ReplicaService_InstallSnapshotServer) error {
+	return status.Errorf(codes.Unimplemented, "method InstallSnapshot not implemented")
+}
 
 func RegisterReplicaServiceServer(s *grpc.Server, srv ReplicaServiceServer) {
 	s.RegisterService(&_ReplicaService_serviceDesc, srv)
@@ -612,6 +776,32 @@ func (x *replicaServiceReplicaServer) Recv() (*ReplicaRequest, error) {
 	return m, nil
 }
 
+func _ReplicaService_InstallSnapshot_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ReplicaServiceServer).InstallSnapshot(&replicaServiceInstallSnapshotServer{stream})
+}
+
+type ReplicaService_InstallSnapshotServer interface {
+	SendAndClose(*SnapshotResponse) error
+	Recv() (*SnapshotRequest, error)
+	grpc.ServerStream
+}
+
+type replicaServiceInstallSnapshotServer struct {
+	grpc.ServerStream
+}
+
+func (x *replicaServiceInstallSnapshotServer) SendAndClose(m *SnapshotResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *replicaServiceInstallSnapshotServer) Recv() (*SnapshotRequest, error) {
+	m := new(SnapshotRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 var _ReplicaService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "protoReplicaV1.ReplicaService",
 	HandlerType: (*ReplicaServiceServer)(nil),
@@ -632,6 +822,11 @@ var _ReplicaService_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "InstallSnapshot",
+			Handler:       _ReplicaService_InstallSnapshot_Handler,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "replica.proto",
 }
@@ -893,6 +1088,94 @@ func (m *ReplicaResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *SnapshotRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SnapshotRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SnapshotRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Data) > 0 {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintReplica(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Dir {
+		i--
+		if m.Dir {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Path) > 0 {
+		i -= len(m.Path)
+		copy(dAtA[i:], m.Path)
+		i = encodeVarintReplica(dAtA, i, uint64(len(m.Path)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.SnapshotIndex != 0 {
+		i = encodeVarintReplica(dAtA, i, uint64(m.SnapshotIndex))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SnapshotResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SnapshotResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SnapshotResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.AckIndex != 0 {
+		i = encodeVarintReplica(dAtA, i, uint64(m.AckIndex))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintReplica(dAtA []byte, offset int, v uint64) int {
 	offset -= sovReplica(v)
 	base := offset
@@ -1032,6 +1315,47 @@ func (m *ReplicaResponse) Size() (n int) {
 	return n
 }
 
+func (m *SnapshotRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SnapshotIndex != 0 {
+		n += 1 + sovReplica(uint64(m.SnapshotIndex))
+	}
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovReplica(uint64(l))
+	}
+	if m.Dir {
+		n += 2
+	}
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovReplica(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SnapshotResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.AckIndex != 0 {
+		n += 1 + sovReplica(uint64(m.AckIndex))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func sovReplica(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -1752,6 +2076,238 @@ func (m *ReplicaResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *SnapshotRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowReplica
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SnapshotRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SnapshotRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SnapshotIndex", wireType)
+			}
+			m.SnapshotIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReplica
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SnapshotIndex |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReplica
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthReplica
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReplica
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Dir", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReplica
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Dir = bool(v != 0)
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReplica
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthReplica
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReplica
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipReplica(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthReplica
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthReplica
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SnapshotResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowReplica
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SnapshotResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SnapshotResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AckIndex", wireType)
+			}
+			m.AckIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReplica
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AckIndex |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipReplica(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthReplica
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthReplica
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipReplica(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -54,3 +54,11 @@ func (qh *queryFactory) NewMetadataQuery(
 ) MetaDataQuery {
 	return newMetadataQuery(ctx, database, stmt, qh)
 }
+
+func (qh *queryFactory) NewDeleteQuery(
+	ctx context.Context,
+	database string,
+	stmt *stmt.Delete,
+) DeleteQuery {
+	return newDeleteQuery(ctx, database, stmt, qh)
+}
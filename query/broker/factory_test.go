@@ -40,5 +40,8 @@ func TestExecutorFactory_NewExecutor(t *testing.T) {
 		context.Background(),
 		"",
 		&stmt.Metadata{}))
-
+	assert.NotNil(t, factory.NewDeleteQuery(
+		context.Background(),
+		"",
+		&stmt.Delete{}))
 }
@@ -79,6 +79,11 @@ type MetaDataQuery interface {
 	WaitResponse() ([]string, error)
 }
 
+// DeleteQuery represents the delete executor which deletes the series data on all replicas.
+type DeleteQuery interface {
+	WaitResponse() error
+}
+
 // Factory is the handler for executing querying tasks
 type Factory interface {
 	NewMetricQuery(
@@ -92,4 +97,10 @@ type Factory interface {
 		databaseName string,
 		stmt *stmt.Metadata,
 	) MetaDataQuery
+
+	NewDeleteQuery(
+		ctx context.Context,
+		databaseName string,
+		stmt *stmt.Delete,
+	) DeleteQuery
 }
@@ -211,6 +211,7 @@ func (p *intermediateTaskProcessor) makeTaskResponse(
 	seriesList := protoCommonV1.TimeSeriesList{
 		TimeSeriesList: timeSeriesList,
 		FieldAggSpecs:  aggregatorSpecs,
+		Exemplars:      event.Exemplars,
 	}
 	data, _ := seriesList.Marshal()
 	return &protoCommonV1.TaskResponse{
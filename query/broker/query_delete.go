@@ -0,0 +1,115 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package brokerquery
+
+import (
+	"context"
+	"errors"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+type deleteQuery struct {
+	runtime *queryFactory
+	ctx     context.Context
+
+	database   string
+	deleteStmt *stmt.Delete
+}
+
+// newDeleteQuery creates the execution which executes the job of deleting series on leader replicas
+func newDeleteQuery(
+	ctx context.Context,
+	database string,
+	stmt *stmt.Delete,
+	queryBuilder *queryFactory,
+) DeleteQuery {
+	return &deleteQuery{
+		deleteStmt: stmt,
+		database:   database,
+		ctx:        ctx,
+		runtime:    queryBuilder,
+	}
+}
+
+func (dq *deleteQuery) WaitResponse() error {
+	physicalPlan, err := dq.makePlan()
+	if err != nil {
+		return err
+	}
+
+	resultCh, err := dq.runtime.taskManager.SubmitDeleteTask(physicalPlan, dq.deleteStmt)
+	if err != nil {
+		return err
+	}
+	for {
+		select {
+		case result, ok := <-resultCh:
+			// received all responses, break for loop
+			if !ok {
+				return nil
+			}
+			if result.ErrMsg != "" {
+				return errors.New(result.ErrMsg)
+			}
+		case <-dq.ctx.Done():
+			return ErrTimeout
+		}
+	}
+}
+
+// makePlan builds distribution physical execute plan, sends the task to the leader replica of shards,
+// leader writes the delete record into write ahead log, then replicates it to all replicas of shard.
+func (dq *deleteQuery) makePlan() (*models.PhysicalPlan, error) {
+	storageNodes, err := dq.runtime.stateMgr.GetShardLeaders(dq.database)
+	if err != nil {
+		return nil, err
+	}
+	storageNodesLen := len(storageNodes)
+	if storageNodesLen == 0 {
+		return nil, constants.ErrReplicaNotFound
+	}
+	curBroker := dq.runtime.stateMgr.GetCurrentNode()
+	curBrokerIndicator := curBroker.Indicator()
+	physicalPlan := &models.PhysicalPlan{
+		Database: dq.database,
+		Root: models.Root{
+			Indicator: curBrokerIndicator,
+			NumOfTask: int32(storageNodesLen),
+		},
+	}
+	receivers := []models.StatelessNode{curBroker}
+	for storageNode, shardStates := range storageNodes {
+		shardIDs := make([]models.ShardID, len(shardStates))
+		for idx := range shardStates {
+			shardIDs[idx] = shardStates[idx].ID
+		}
+		physicalPlan.AddLeaf(models.Leaf{
+			BaseNode: models.BaseNode{
+				Parent:    curBrokerIndicator,
+				Indicator: storageNode,
+			},
+			ShardIDs:    shardIDs,
+			ShardStates: shardStates,
+			Receivers:   receivers,
+		})
+	}
+	return physicalPlan, nil
+}
@@ -0,0 +1,96 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package brokerquery
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/coordinator/broker"
+	"github.com/lindb/lindb/models"
+	protoCommonV1 "github.com/lindb/lindb/proto/gen/v1/common"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func Test_DeleteQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stateMgr := broker.NewMockStateManager(ctrl)
+	thisTaskManager := NewMockTaskManager(ctrl)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	deleteQuery := newDeleteQuery(
+		ctx,
+		"db",
+		&stmt.Delete{},
+		&queryFactory{
+			stateMgr:    stateMgr,
+			taskManager: thisTaskManager,
+		},
+	)
+
+	// GetShardLeaders return err
+	stateMgr.EXPECT().GetShardLeaders("db").Return(nil, io.ErrClosedPipe)
+	assert.Error(t, deleteQuery.WaitResponse())
+	// GetShardLeaders return empty
+	stateMgr.EXPECT().GetShardLeaders("db").Return(map[string][]models.ShardState{}, nil)
+	assert.Error(t, deleteQuery.WaitResponse())
+
+	stateMgr.EXPECT().GetShardLeaders("db").
+		Return(map[string][]models.ShardState{
+			"1.1.1.1:9000": {{ID: 1, Leader: 1}},
+			"1.1.1.2:9000": {{ID: 2, Leader: 2}},
+		}, nil).AnyTimes()
+	stateMgr.EXPECT().GetCurrentNode().Return(models.StatelessNode{
+		HostIP: "1.1.1.3", GRPCPort: 8000,
+	}).AnyTimes()
+
+	// submit error
+	thisTaskManager.EXPECT().SubmitDeleteTask(gomock.Any(), gomock.Any()).Return(nil, io.ErrClosedPipe)
+	assert.Error(t, deleteQuery.WaitResponse())
+
+	// return error
+	response1Ch := make(chan *protoCommonV1.TaskResponse)
+	time.AfterFunc(time.Millisecond*200, func() {
+		response1Ch <- &protoCommonV1.TaskResponse{ErrMsg: "error"}
+	})
+	thisTaskManager.EXPECT().SubmitDeleteTask(gomock.Any(), gomock.Any()).Return(response1Ch, nil)
+	assert.Error(t, deleteQuery.WaitResponse())
+
+	// ok
+	response2Ch := make(chan *protoCommonV1.TaskResponse)
+	time.AfterFunc(time.Millisecond*200, func() {
+		response2Ch <- &protoCommonV1.TaskResponse{}
+		response2Ch <- &protoCommonV1.TaskResponse{}
+		close(response2Ch)
+	})
+	thisTaskManager.EXPECT().SubmitDeleteTask(gomock.Any(), gomock.Any()).Return(response2Ch, nil)
+	assert.NoError(t, deleteQuery.WaitResponse())
+
+	// timeout
+	response3Ch := make(chan *protoCommonV1.TaskResponse)
+	time.AfterFunc(time.Millisecond*200, cancel)
+	thisTaskManager.EXPECT().SubmitDeleteTask(gomock.Any(), gomock.Any()).Return(response3Ch, nil)
+	assert.Equal(t, ErrTimeout, deleteQuery.WaitResponse())
+}
@@ -19,6 +19,7 @@ package brokerquery
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
 	"time"
 
@@ -27,6 +28,7 @@ import (
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/ltoml"
 	"github.com/lindb/lindb/pkg/timeutil"
+	protoCommonV1 "github.com/lindb/lindb/proto/gen/v1/common"
 	"github.com/lindb/lindb/query"
 	"github.com/lindb/lindb/series"
 	"github.com/lindb/lindb/series/tag"
@@ -185,6 +187,7 @@ func (mq *metricQuery) makeResultSet(event *series.TimeSeriesEvent) (resultSet *
 	resultSet.StartTime = mq.stmtQuery.TimeRange.Start
 	resultSet.EndTime = mq.stmtQuery.TimeRange.End
 	resultSet.Interval = mq.stmtQuery.Interval.Int64()
+	resultSet.Exemplars = buildExemplars(event.Exemplars)
 
 	resultSet.Stats = event.Stats
 	if resultSet.Stats != nil {
@@ -196,3 +199,21 @@ func (mq *metricQuery) makeResultSet(event *series.TimeSeriesEvent) (resultSet *
 	}
 	return resultSet
 }
+
+// buildExemplars builds the exemplars of result set, trace/span id with hex encoding.
+func buildExemplars(exemplars []*protoCommonV1.Exemplar) []*models.Exemplar {
+	if len(exemplars) == 0 {
+		return nil
+	}
+	rs := make([]*models.Exemplar, len(exemplars))
+	for idx, e := range exemplars {
+		rs[idx] = &models.Exemplar{
+			Field:     e.Field,
+			Timestamp: e.Timestamp,
+			TraceID:   hex.EncodeToString(e.TraceID),
+			SpanID:    hex.EncodeToString(e.SpanID),
+			Duration:  e.Duration,
+		}
+	}
+	return rs
+}
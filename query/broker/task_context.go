@@ -174,6 +174,7 @@ type metricTaskContext struct {
 	stmtQuery *stmt.Query
 	groupAgg  aggregation.GroupingAggregator
 	stats     *models.QueryStats
+	exemplars []*protoCommonV1.Exemplar
 	// fieldname -> aggregator spec
 	// we will use it during intermediate tasks
 	aggregatorSpecs map[string]*protoCommonV1.AggregatorSpec
@@ -265,6 +266,7 @@ func (c *metricTaskContext) WriteResponse(resp *protoCommonV1.TaskResponse, from
 	case c.eventCh <- &series.TimeSeriesEvent{
 		AggregatorSpecs: c.aggregatorSpecs,
 		SeriesList:      c.groupAgg.ResultSet(),
+		Exemplars:       c.exemplars,
 		Stats:           c.stats,
 	}:
 	default:
@@ -315,6 +317,7 @@ func (c *metricTaskContext) handleTaskResponse(resp *protoCommonV1.TaskResponse,
 	for _, spec := range tsList.FieldAggSpecs {
 		c.aggregatorSpecs[spec.FieldName] = spec
 	}
+	c.exemplars = append(c.exemplars, tsList.Exemplars...)
 
 	if c.groupAgg == nil {
 		AggregatorSpecs := make(aggregation.AggregatorSpecs, len(tsList.FieldAggSpecs))
@@ -25,8 +25,10 @@ import (
 
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/timeutil"
 	protoCommonV1 "github.com/lindb/lindb/proto/gen/v1/common"
 	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/sql/stmt"
 )
 
 func Test_TaskContext_metaDataTaskContext(t *testing.T) {
@@ -158,3 +160,26 @@ func Test_TaskContext_metricTaskContext_notFound(t *testing.T) {
 	)
 
 }
+
+func Test_TaskContext_metricTaskContext_exemplars(t *testing.T) {
+	ch := make(chan *series.TimeSeriesEvent, 1)
+	taskCtx := newMetricTaskContext(
+		"1",
+		RootTask,
+		"",
+		"",
+		&stmt.Query{Interval: timeutil.Interval(timeutil.OneSecond)},
+		2,
+		ch,
+	)
+	exemplars := []*protoCommonV1.Exemplar{{Field: "f", Timestamp: 10, TraceID: []byte{0xab, 0xcd}, Duration: 10}}
+	tsList := protoCommonV1.TimeSeriesList{Exemplars: exemplars}
+	payload, err := tsList.Marshal()
+	assert.NoError(t, err)
+	taskCtx.WriteResponse(&protoCommonV1.TaskResponse{Payload: payload}, "1.1.1.1")
+	taskCtx.WriteResponse(&protoCommonV1.TaskResponse{Payload: payload}, "1.1.1.2")
+	event := <-ch
+	assert.NoError(t, event.Err)
+	assert.Len(t, event.Exemplars, 2)
+	assert.Equal(t, *exemplars[0], *event.Exemplars[1])
+}
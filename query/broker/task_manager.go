@@ -68,6 +68,12 @@ type TaskManager interface {
 		suggest *stmt.Metadata,
 	) (taskResponse <-chan *protoCommonV1.TaskResponse, err error)
 
+	// SubmitDeleteTask concurrently send delete series task to multi leafs.
+	SubmitDeleteTask(
+		physicalPlan *models.PhysicalPlan,
+		deleteStmt *stmt.Delete,
+	) (taskResponse <-chan *protoCommonV1.TaskResponse, err error)
+
 	// SendRequest sends the task request to target node based on node's indicator
 	SendRequest(targetNodeID string, req *protoCommonV1.TaskRequest) error
 	// SendResponse sends the task response to parent node
@@ -311,15 +317,33 @@ func (t *taskManager) SubmitIntermediateMetricTask(
 func (t *taskManager) SubmitMetaDataTask(
 	physicalPlan *models.PhysicalPlan,
 	suggest *stmt.Metadata,
+) (taskResponse <-chan *protoCommonV1.TaskResponse, err error) {
+	suggestMarshalData, _ := suggest.MarshalJSON()
+	return t.submitLeafTask(physicalPlan, protoCommonV1.RequestType_Metadata, suggestMarshalData)
+}
+
+// SubmitDeleteTask concurrently send delete series task to multi leafs.
+func (t *taskManager) SubmitDeleteTask(
+	physicalPlan *models.PhysicalPlan,
+	deleteStmt *stmt.Delete,
+) (taskResponse <-chan *protoCommonV1.TaskResponse, err error) {
+	deleteMarshalData, _ := deleteStmt.MarshalJSON()
+	return t.submitLeafTask(physicalPlan, protoCommonV1.RequestType_Delete, deleteMarshalData)
+}
+
+// submitLeafTask concurrently send task request to multi leafs, the responses of leafs are forwarded to channel.
+func (t *taskManager) submitLeafTask(
+	physicalPlan *models.PhysicalPlan,
+	requestType protoCommonV1.RequestType,
+	payload []byte,
 ) (taskResponse <-chan *protoCommonV1.TaskResponse, err error) {
 	taskID := t.AllocTaskID()
 
-	suggestMarshalData, _ := suggest.MarshalJSON()
 	req := &protoCommonV1.TaskRequest{
-		RequestType:  protoCommonV1.RequestType_Metadata,
+		RequestType:  requestType,
 		ParentTaskID: taskID,
 		PhysicalPlan: encoding.JSONMarshal(physicalPlan),
-		Payload:      suggestMarshalData,
+		Payload:      payload,
 	}
 
 	responseCh := make(chan *protoCommonV1.TaskResponse)
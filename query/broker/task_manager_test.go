@@ -181,6 +181,16 @@ func TestTaskManager_SubmitMetaDataTask(t *testing.T) {
 	_, err = taskManager2.SubmitMetaDataTask(physicalPlan, &stmt.Metadata{})
 	assert.Error(t, err)
 
+	// submit delete task
+	client.EXPECT().Send(gomock.Any()).DoAndReturn(func(req *protoCommonV1.TaskRequest) error {
+		assert.Equal(t, protoCommonV1.RequestType_Delete, req.RequestType)
+		return nil
+	})
+	taskClientFactory.EXPECT().GetTaskClient(gomock.Any()).
+		Return(client)
+	_, err = taskManager2.SubmitDeleteTask(physicalPlan, &stmt.Delete{})
+	assert.NoError(t, err)
+
 	// SubmitIntermediateMetricTask
 	_ = taskManager2.SubmitIntermediateMetricTask(physicalPlan, &stmt.Query{}, "")
 }
@@ -29,6 +29,10 @@ type storageMetadataQuery interface {
 	Execute() (result []string, err error)
 }
 
+type storageDeleteQuery interface {
+	Execute() error
+}
+
 // StorageExecuteContext represents the storage execute context
 type StorageExecuteContext interface {
 	// QueryStats returns the storage query stats
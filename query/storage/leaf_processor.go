@@ -30,6 +30,7 @@ import (
 	"github.com/lindb/lindb/pkg/timeutil"
 	protoCommonV1 "github.com/lindb/lindb/proto/gen/v1/common"
 	"github.com/lindb/lindb/query"
+	"github.com/lindb/lindb/replica"
 	"github.com/lindb/lindb/rpc"
 	"github.com/lindb/lindb/sql/stmt"
 	"github.com/lindb/lindb/tsdb"
@@ -42,11 +43,13 @@ type leafTaskProcessor struct {
 	currentNode       models.Node
 	currentNodeID     string
 	engine            tsdb.Engine
+	walMgr            replica.WriteAheadLogManager
 	taskServerFactory rpc.TaskServerFactory
 	logger            *logger.Logger
 
 	storageMetricQueryCounter  *linmetric.BoundCounter
 	storageMetaQueryCounter    *linmetric.BoundCounter
+	storageDeleteCounter       *linmetric.BoundCounter
 	storageOmitResponseCounter *linmetric.BoundCounter
 }
 
@@ -54,6 +57,7 @@ type leafTaskProcessor struct {
 func NewLeafTaskProcessor(
 	currentNode models.Node,
 	engine tsdb.Engine,
+	walMgr replica.WriteAheadLogManager,
 	taskServerFactory rpc.TaskServerFactory,
 ) query.TaskProcessor {
 	storageQueryScope := linmetric.NewScope("lindb.storage.query")
@@ -61,10 +65,12 @@ func NewLeafTaskProcessor(
 		currentNode:                currentNode,
 		currentNodeID:              currentNode.Indicator(),
 		engine:                     engine,
+		walMgr:                     walMgr,
 		taskServerFactory:          taskServerFactory,
 		logger:                     logger.GetLogger("query", "LeafTaskDispatcher"),
 		storageMetricQueryCounter:  storageQueryScope.NewCounter("metric_queries"),
 		storageMetaQueryCounter:    storageQueryScope.NewCounter("meta_queries"),
+		storageDeleteCounter:       storageQueryScope.NewCounter("delete_requests"),
 		storageOmitResponseCounter: storageQueryScope.NewCounter("omitted_responses"),
 	}
 }
@@ -138,6 +144,11 @@ func (p *leafTaskProcessor) process(
 		if err := p.processMetadataSuggest(db, curLeaf.ShardIDs, req, stream); err != nil {
 			return err
 		}
+	case protoCommonV1.RequestType_Delete:
+		p.storageDeleteCounter.Incr()
+		if err := p.processDelete(db, curLeaf.ShardStates, req, stream); err != nil {
+			return err
+		}
 	default:
 		p.storageOmitResponseCounter.Incr()
 		return nil
@@ -172,6 +183,44 @@ func (p *leafTaskProcessor) processMetadataSuggest(
 	return nil
 }
 
+// processDelete writes the delete record into write ahead log of shards which leader is current node,
+// then the delete record is replicated to all replicas(include leader) in order with written rows,
+// each replica deletes the series data of itself when replays the delete record.
+func (p *leafTaskProcessor) processDelete(
+	db tsdb.Database,
+	shardStates []models.ShardState,
+	req *protoCommonV1.TaskRequest,
+	stream protoCommonV1.TaskService_HandleServer,
+) error {
+	var stmtDelete = &stmt.Delete{}
+	if err := stmtDelete.UnmarshalJSON(req.Payload); err != nil {
+		return query.ErrUnmarshalDelete
+	}
+	record, err := replica.EncodeDeleteRecord(stmtDelete)
+	if err != nil {
+		return err
+	}
+	wal := p.walMgr.GetOrCreateLog(db.Name())
+	for _, shardState := range shardStates {
+		partition, err := wal.GetOrCreatePartition(shardState.ID)
+		if err != nil {
+			return err
+		}
+		if err := partition.BuildReplicaForLeader(shardState.Leader, shardState.Replica.Replicas); err != nil {
+			return err
+		}
+		if err := partition.WriteLog(record); err != nil {
+			return err
+		}
+	}
+	// send result to upstream
+	return stream.Send(&protoCommonV1.TaskResponse{
+		Type:      protoCommonV1.TaskType_Leaf,
+		TaskID:    req.ParentTaskID,
+		Completed: true,
+	})
+}
+
 func (p *leafTaskProcessor) processDataSearch(
 	ctx context.Context,
 	db tsdb.Database,
@@ -31,6 +31,7 @@ import (
 	"github.com/lindb/lindb/pkg/encoding"
 	protoCommonV1 "github.com/lindb/lindb/proto/gen/v1/common"
 	"github.com/lindb/lindb/query"
+	"github.com/lindb/lindb/replica"
 	"github.com/lindb/lindb/rpc"
 	"github.com/lindb/lindb/sql/stmt"
 	"github.com/lindb/lindb/tsdb"
@@ -45,6 +46,7 @@ func TestLeafTaskProcessor_Process_sendStreamFailure(t *testing.T) {
 	leafTaskProcessor := NewLeafTaskProcessor(
 		&models.StatelessNode{HostIP: "1.1.1.1", GRPCPort: 9000},
 		nil,
+		nil,
 		nil)
 	leafTaskProcessor.Process(
 		context.Background(),
@@ -62,7 +64,7 @@ func TestLeafTask_Process_Fail(t *testing.T) {
 	mockDatabase := tsdb.NewMockDatabase(ctrl)
 
 	currentNode := models.StatelessNode{HostIP: "1.1.1.3", GRPCPort: 8000}
-	processorI := NewLeafTaskProcessor(&currentNode, engine, taskServerFactory)
+	processorI := NewLeafTaskProcessor(&currentNode, engine, nil, taskServerFactory)
 	processor := processorI.(*leafTaskProcessor)
 	// unmarshal error
 	err := processor.process(
@@ -127,7 +129,7 @@ func TestLeafProcessor_Process(t *testing.T) {
 	engine := tsdb.NewMockEngine(ctrl)
 
 	currentNode := models.StatelessNode{HostIP: "1.1.1.3", GRPCPort: 8000}
-	processorI := NewLeafTaskProcessor(&currentNode, engine, taskServerFactory)
+	processorI := NewLeafTaskProcessor(&currentNode, engine, nil, taskServerFactory)
 	processor := processorI.(*leafTaskProcessor)
 	mockDatabase := tsdb.NewMockDatabase(ctrl)
 	plan := encoding.JSONMarshal(&models.PhysicalPlan{
@@ -154,7 +156,7 @@ func TestLeafTask_Suggest_Process(t *testing.T) {
 	engine := tsdb.NewMockEngine(ctrl)
 
 	currentNode := models.StatelessNode{HostIP: "1.1.1.3", GRPCPort: 8000}
-	processorI := NewLeafTaskProcessor(&currentNode, engine, taskServerFactory)
+	processorI := NewLeafTaskProcessor(&currentNode, engine, nil, taskServerFactory)
 	processor := processorI.(*leafTaskProcessor)
 	mockDatabase := tsdb.NewMockDatabase(ctrl)
 	plan := encoding.JSONMarshal(&models.PhysicalPlan{
@@ -188,3 +190,66 @@ func TestLeafTask_Suggest_Process(t *testing.T) {
 		Payload:      data})
 	assert.Nil(t, err)
 }
+
+func TestLeafTask_Delete_Process(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskServerFactory := rpc.NewMockTaskServerFactory(ctrl)
+	engine := tsdb.NewMockEngine(ctrl)
+	walMgr := replica.NewMockWriteAheadLogManager(ctrl)
+	wal := replica.NewMockWriteAheadLog(ctrl)
+	partition := replica.NewMockPartition(ctrl)
+	walMgr.EXPECT().GetOrCreateLog("test_db").Return(wal).AnyTimes()
+
+	currentNode := models.StatelessNode{HostIP: "1.1.1.3", GRPCPort: 8000}
+	processorI := NewLeafTaskProcessor(&currentNode, engine, walMgr, taskServerFactory)
+	processor := processorI.(*leafTaskProcessor)
+	mockDatabase := tsdb.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Name().Return("test_db").AnyTimes()
+	replicas := []models.NodeID{1, 2}
+	plan := encoding.JSONMarshal(&models.PhysicalPlan{
+		Database: "test_db",
+		Leafs: []models.Leaf{{
+			BaseNode: models.BaseNode{Indicator: "1.1.1.3:8000"},
+			ShardIDs: []models.ShardID{1},
+			ShardStates: []models.ShardState{{
+				ID: 1, State: models.OnlineShard, Leader: 1, Replica: models.Replica{Replicas: replicas},
+			}},
+		}},
+	})
+	engine.EXPECT().GetDatabase(gomock.Any()).Return(mockDatabase, true).AnyTimes()
+	serverStream := protoCommonV1.NewMockTaskService_HandleServer(ctrl)
+	taskServerFactory.EXPECT().GetStream(gomock.Any()).Return(serverStream).AnyTimes()
+
+	process := func(payload []byte) error {
+		return processor.process(context.Background(), &protoCommonV1.TaskRequest{
+			PhysicalPlan: plan,
+			RequestType:  protoCommonV1.RequestType_Delete,
+			Payload:      payload})
+	}
+	// test unmarshal err
+	assert.Error(t, process([]byte{1, 2, 3}))
+
+	deleteStmt := &stmt.Delete{MetricName: "cpu"}
+	data := encoding.JSONMarshal(deleteStmt)
+	record, err := replica.EncodeDeleteRecord(deleteStmt)
+	assert.NoError(t, err)
+	// test get partition err
+	wal.EXPECT().GetOrCreatePartition(models.ShardID(1)).Return(nil, io.ErrClosedPipe)
+	assert.Error(t, process(data))
+	wal.EXPECT().GetOrCreatePartition(models.ShardID(1)).Return(partition, nil).AnyTimes()
+	// test build replica err
+	partition.EXPECT().BuildReplicaForLeader(models.NodeID(1), replicas).Return(io.ErrClosedPipe)
+	assert.Error(t, process(data))
+	partition.EXPECT().BuildReplicaForLeader(models.NodeID(1), replicas).Return(nil).AnyTimes()
+	// test write log err
+	partition.EXPECT().WriteLog(record).Return(io.ErrClosedPipe)
+	assert.Error(t, process(data))
+	// test send result
+	partition.EXPECT().WriteLog(record).Return(nil).Times(2)
+	serverStream.EXPECT().Send(gomock.Any()).Return(io.ErrClosedPipe)
+	assert.Error(t, process(data))
+	serverStream.EXPECT().Send(gomock.Any()).Return(nil)
+	assert.NoError(t, process(data))
+}
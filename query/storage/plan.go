@@ -26,6 +26,7 @@ import (
 	"github.com/lindb/lindb/aggregation"
 	"github.com/lindb/lindb/aggregation/function"
 	"github.com/lindb/lindb/series/field"
+	"github.com/lindb/lindb/series/metric"
 	"github.com/lindb/lindb/series/tag"
 	"github.com/lindb/lindb/sql/stmt"
 	"github.com/lindb/lindb/tsdb/metadb"
@@ -47,6 +48,7 @@ type storageExecutePlan struct {
 	metricID    uint32
 	fields      map[field.ID]*aggregation.Aggregator
 	groupByTags []tag.Meta
+	hasSketch   bool // if query quantile sketch, need expand sketches into bins when querying
 
 	err error
 }
@@ -78,6 +80,7 @@ func (p *storageExecutePlan) Plan() error {
 	if p.err != nil {
 		return p.err
 	}
+	p.planGaugeAggFields()
 	p.fieldMetas = make(field.Metas, len(p.fields))
 	idx := 0
 	for fieldID := range p.fields {
@@ -251,5 +254,68 @@ func (p *storageExecutePlan) planHistogramFields(e *stmt.CallExpr) {
 		}
 		aggregator.Aggregator.AddFunctionType(function.Sum)
 		aggregator.DownSampling.AddFunctionType(function.Sum)
+		if fieldMeta.Type == field.SketchField {
+			p.hasSketch = true
+		}
+	}
+	if p.hasSketch {
+		// min/max of sketch are used to bound the quantile of sketch
+		p.planSketchBoundFields(metric.HistogramMin, function.Min)
+		p.planSketchBoundFields(metric.HistogramMax, function.Max)
+	}
+}
+
+// planSketchBoundFields plans the min/max field of quantile sketch if exist.
+func (p *storageExecutePlan) planSketchBoundFields(fieldName field.Name, funcType function.FuncType) {
+	fieldMeta, err := p.metadata.MetadataDatabase().GetField(p.namespace, p.query.MetricName, fieldName)
+	if err != nil {
+		// min/max not kept for this metric
+		return
+	}
+	aggregator, exist := p.fields[fieldMeta.ID]
+	if !exist {
+		aggregator = &aggregation.Aggregator{}
+		aggregator.DownSampling = aggregation.NewAggregatorSpec(fieldMeta.Name, fieldMeta.Type)
+		aggregator.Aggregator = aggregation.NewAggregatorSpec(fieldMeta.Name, fieldMeta.Type)
+		p.fields[fieldMeta.ID] = aggregator
+	}
+	aggregator.Aggregator.AddFunctionType(funcType)
+	aggregator.DownSampling.AddFunctionType(funcType)
+}
+
+// planGaugeAggFields plans the hidden aggregate fields of gauge field if exist,
+// because gauge only keeps last value after rollup, min/max/sum/avg can only be answered by aggregate fields.
+// NOTE: gauge field is kept, so that the slots without aggregates(data written before aggregates kept)
+// fall back to gauge value, the fields are merged by name when evaluating expression.
+func (p *storageExecutePlan) planGaugeAggFields() {
+	var gaugeAggregators []aggregation.AggregatorSpec
+	for _, aggregator := range p.fields {
+		if aggregator.DownSampling.GetFieldType() == field.GaugeField {
+			gaugeAggregators = append(gaugeAggregators, aggregator.DownSampling)
+		}
+	}
+	for _, gaugeAggregator := range gaugeAggregators {
+		fieldName := gaugeAggregator.FieldName()
+		for funcType := range gaugeAggregator.Functions() {
+			for _, aggField := range field.GaugeAggFieldsByFunc(funcType) {
+				aggFieldName := aggField.Name(fieldName)
+				aggFieldMeta, err := p.metadata.MetadataDatabase().GetField(p.namespace, p.query.MetricName, aggFieldName)
+				if err != nil {
+					// gauge aggregates not kept for this field
+					continue
+				}
+				aggregator, exist := p.fields[aggFieldMeta.ID]
+				if !exist {
+					aggregator = &aggregation.Aggregator{
+						DownSampling: aggregation.NewAggregatorSpec(aggFieldName, aggFieldMeta.Type),
+						Aggregator:   aggregation.NewAggregatorSpec(aggFieldName, aggFieldMeta.Type),
+					}
+					p.fields[aggFieldMeta.ID] = aggregator
+				}
+				aggFuncType := aggFieldMeta.Type.DownSamplingFunc()
+				aggregator.DownSampling.AddFunctionType(aggFuncType)
+				aggregator.Aggregator.AddFunctionType(aggFuncType)
+			}
+		}
 	}
 }
@@ -286,6 +286,31 @@ func TestStorageExecutePlan_field_ok(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestStorageExecutePlan_sketchFields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+	metadataDB.EXPECT().GetMetricID(gomock.Any(), "latency").Return(uint32(10), nil).AnyTimes()
+	metadataDB.EXPECT().GetAllHistogramFields(gomock.Any(), gomock.Any()).
+		Return(field.Metas{{Name: metric.SketchName, ID: 5, Type: field.SketchField}}, nil).AnyTimes()
+	metadataDB.EXPECT().GetField(gomock.Any(), gomock.Any(), metric.HistogramMin).
+		Return(field.Meta{Name: metric.HistogramMin, ID: 1, Type: field.MinField}, nil)
+	metadataDB.EXPECT().GetField(gomock.Any(), gomock.Any(), metric.HistogramMax).
+		Return(field.Meta{}, constants.ErrNotFound)
+
+	q, err := sql.Parse("select quantile(0.99) from latency")
+	assert.NoError(t, err)
+	plan := newStorageExecutePlan("ns", metadata, q.(*stmt.Query))
+	assert.NoError(t, plan.Plan())
+	assert.True(t, plan.hasSketch)
+	assert.Equal(t, field.Metas{
+		{Name: metric.HistogramMin, ID: 1, Type: field.MinField},
+		{Name: metric.SketchName, ID: 5, Type: field.SketchField},
+	}, plan.getFields())
+}
+
 func TestStorageExecutePlan_histogramFieldsBad(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -303,3 +328,61 @@ func TestStorageExecutePlan_histogramFieldsBad(t *testing.T) {
 	plan.field(nil, nil)
 	assert.Error(t, err)
 }
+
+func TestStorageExecutePlan_gaugeAggFields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+	metadataDB.EXPECT().GetMetricID(gomock.Any(), "cpu").Return(uint32(10), nil).AnyTimes()
+	metadataDB.EXPECT().GetField(gomock.Any(), gomock.Any(), field.Name("usage")).
+		Return(field.Meta{ID: 1, Type: field.GaugeField}, nil).AnyTimes()
+	metadataDB.EXPECT().GetField(gomock.Any(), gomock.Any(), field.Name("__gauge_max_usage")).
+		Return(field.Meta{ID: 3, Type: field.MaxField}, nil).AnyTimes()
+	metadataDB.EXPECT().GetField(gomock.Any(), gomock.Any(), field.Name("__gauge_sum_usage")).
+		Return(field.Meta{ID: 4, Type: field.SumField}, nil).AnyTimes()
+	metadataDB.EXPECT().GetField(gomock.Any(), gomock.Any(), field.Name("__gauge_count_usage")).
+		Return(field.Meta{ID: 5, Type: field.SumField}, nil).AnyTimes()
+	metadataDB.EXPECT().GetField(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(field.Meta{}, constants.ErrNotFound).AnyTimes()
+
+	// case 1: max uses hidden max field, keeps gauge field for old data
+	q, err := sql.Parse("select max(usage) from cpu")
+	assert.NoError(t, err)
+	plan := newStorageExecutePlan("ns", metadata, q.(*stmt.Query))
+	assert.NoError(t, plan.Plan())
+	assert.Equal(t, field.Metas{
+		{ID: 1, Type: field.GaugeField, Name: "usage"},
+		{ID: 3, Type: field.MaxField, Name: "__gauge_max_usage"},
+	}, plan.getFields())
+	assert.Equal(t, map[function.FuncType]function.FuncType{function.Max: function.Max},
+		plan.getAggregatorSpecs()[1].Functions())
+	// case 2: hidden field not exist
+	q, err = sql.Parse("select min(usage) from cpu")
+	assert.NoError(t, err)
+	plan = newStorageExecutePlan("ns", metadata, q.(*stmt.Query))
+	assert.NoError(t, plan.Plan())
+	assert.Equal(t, field.Metas{{ID: 1, Type: field.GaugeField, Name: "usage"}}, plan.getFields())
+	// case 3: multi functions
+	q, err = sql.Parse("select max(usage),min(usage) from cpu")
+	assert.NoError(t, err)
+	plan = newStorageExecutePlan("ns", metadata, q.(*stmt.Query))
+	assert.NoError(t, plan.Plan())
+	assert.Equal(t, field.Metas{
+		{ID: 1, Type: field.GaugeField, Name: "usage"},
+		{ID: 3, Type: field.MaxField, Name: "__gauge_max_usage"},
+	}, plan.getFields())
+	// case 4: avg uses hidden sum/count fields
+	q, err = sql.Parse("select avg(usage) from cpu")
+	assert.NoError(t, err)
+	plan = newStorageExecutePlan("ns", metadata, q.(*stmt.Query))
+	assert.NoError(t, plan.Plan())
+	assert.Equal(t, field.Metas{
+		{ID: 1, Type: field.GaugeField, Name: "usage"},
+		{ID: 4, Type: field.SumField, Name: "__gauge_sum_usage"},
+		{ID: 5, Type: field.SumField, Name: "__gauge_count_usage"},
+	}, plan.getFields())
+	assert.Equal(t, map[function.FuncType]function.FuncType{function.Sum: function.Sum},
+		plan.getAggregatorSpecs()[2].Functions())
+}
@@ -0,0 +1,114 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package storagequery
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lindb/roaring"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/query"
+	"github.com/lindb/lindb/sql/stmt"
+	"github.com/lindb/lindb/tsdb"
+)
+
+// NewDeleteHandler returns the handler which deletes the series data of shard by delete statement,
+// invoked when replica replays the delete record of write ahead log.
+func NewDeleteHandler(engine tsdb.Engine) func(shard tsdb.Shard, deleteStmt *stmt.Delete) error {
+	return func(shard tsdb.Shard, deleteStmt *stmt.Delete) error {
+		db, ok := engine.GetDatabase(shard.DatabaseName())
+		if !ok {
+			return fmt.Errorf("%w: %s", query.ErrNoDatabase, shard.DatabaseName())
+		}
+		err := newStorageDeleteQuery(db, []models.ShardID{shard.ShardID()}, deleteStmt).Execute()
+		if errors.Is(err, constants.ErrNotFound) {
+			// metric/tag not found, nothing to delete
+			return nil
+		}
+		return err
+	}
+}
+
+// deleteStorageExecutor represents the executor which deletes the series data in storage side
+type deleteStorageExecutor struct {
+	database tsdb.Database
+	request  *stmt.Delete
+	shardIDs []models.ShardID
+}
+
+// newStorageDeleteQuery creates a delete executor in storage side
+func newStorageDeleteQuery(
+	database tsdb.Database,
+	shardIDs []models.ShardID,
+	request *stmt.Delete,
+) storageDeleteQuery {
+	return &deleteStorageExecutor{
+		database: database,
+		request:  request,
+		shardIDs: shardIDs,
+	}
+}
+
+// Execute finds the series ids which match the tag filter condition for each shard,
+// then deletes the data of series in time range.
+func (e *deleteStorageExecutor) Execute() error {
+	req := e.request
+	var tagFilterResult map[string]*tagFilterResult
+	if req.Condition != nil {
+		tagSearch := newTagSearchFunc(req.Namespace, req.MetricName, req.Condition, e.database.Metadata())
+		result, err := tagSearch.Filter()
+		if err != nil {
+			return err
+		}
+		if len(result) == 0 {
+			// filter not match, nothing to delete
+			return nil
+		}
+		tagFilterResult = result
+	}
+	for _, shardID := range e.shardIDs {
+		shard, ok := e.database.GetShard(shardID)
+		if !ok {
+			continue
+		}
+		var (
+			seriesIDs *roaring.Bitmap
+			err       error
+		)
+		if req.Condition != nil {
+			seriesSearch := newSeriesSearchFunc(shard.IndexDatabase(), tagFilterResult, req.Condition)
+			seriesIDs, err = seriesSearch.Search()
+		} else {
+			// delete all series of metric, includes the series without tags
+			seriesIDs, err = shard.IndexDatabase().GetSeriesIDsForMetric(req.Namespace, req.MetricName)
+			if err == nil {
+				seriesIDs.Add(constants.SeriesIDWithoutTags)
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if err := shard.DeleteSeries(req.Namespace, req.MetricName, seriesIDs, req.TimeRange); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package storagequery
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lindb/roaring"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/sql/stmt"
+	"github.com/lindb/lindb/tsdb"
+	"github.com/lindb/lindb/tsdb/indexdb"
+	"github.com/lindb/lindb/tsdb/metadb"
+)
+
+func TestDeleteStorageQuery_Execute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		newTagSearchFunc = newTagSearch
+		newSeriesSearchFunc = newSeriesSearch
+
+		ctrl.Finish()
+	}()
+
+	db := tsdb.NewMockDatabase(ctrl)
+	metadata := metadb.NewMockMetadata(ctrl)
+	db.EXPECT().Metadata().Return(metadata).AnyTimes()
+	shard := tsdb.NewMockShard(ctrl)
+	indexDB := indexdb.NewMockIndexDatabase(ctrl)
+	shard.EXPECT().IndexDatabase().Return(indexDB).AnyTimes()
+	db.EXPECT().GetShard(models.ShardID(1)).Return(shard, true).AnyTimes()
+	db.EXPECT().GetShard(models.ShardID(2)).Return(nil, false).AnyTimes()
+	timeRange := timeutil.TimeRange{Start: 10, End: 100}
+
+	// case 1: delete all series of metric, get series ids err
+	exec := newStorageDeleteQuery(db, []models.ShardID{1, 2}, &stmt.Delete{
+		Namespace:  "ns",
+		MetricName: "cpu",
+		TimeRange:  timeRange,
+	})
+	indexDB.EXPECT().GetSeriesIDsForMetric("ns", "cpu").Return(nil, fmt.Errorf("err"))
+	assert.Error(t, exec.Execute())
+	// case 2: delete all series of metric
+	indexDB.EXPECT().GetSeriesIDsForMetric("ns", "cpu").Return(roaring.BitmapOf(1, 2), nil)
+	shard.EXPECT().DeleteSeries("ns", "cpu", roaring.BitmapOf(constants.SeriesIDWithoutTags, 1, 2), timeRange).
+		Return(fmt.Errorf("err"))
+	assert.Error(t, exec.Execute())
+
+	exec = newStorageDeleteQuery(db, []models.ShardID{1, 2}, &stmt.Delete{
+		Namespace:  "ns",
+		MetricName: "cpu",
+		Condition:  &stmt.EqualsExpr{Key: "host", Value: "1.1.1.1"},
+		TimeRange:  timeRange,
+	})
+	tagSearch := NewMockTagSearch(ctrl)
+	newTagSearchFunc = func(namespace, metricName string, condition stmt.Expr, metadata metadb.Metadata) TagSearch {
+		return tagSearch
+	}
+	seriesSearch := NewMockSeriesSearch(ctrl)
+	newSeriesSearchFunc = func(filter series.Filter, filterResult map[string]*tagFilterResult, condition stmt.Expr) SeriesSearch {
+		return seriesSearch
+	}
+	// case 3: tag search err
+	tagSearch.EXPECT().Filter().Return(nil, fmt.Errorf("err"))
+	assert.Error(t, exec.Execute())
+	// case 4: tag not found
+	tagSearch.EXPECT().Filter().Return(nil, nil)
+	assert.NoError(t, exec.Execute())
+
+	tagSearch.EXPECT().Filter().Return(map[string]*tagFilterResult{"key": {}}, nil).AnyTimes()
+	// case 5: series search err
+	seriesSearch.EXPECT().Search().Return(nil, fmt.Errorf("err"))
+	assert.Error(t, exec.Execute())
+	// case 6: delete series
+	seriesSearch.EXPECT().Search().Return(roaring.BitmapOf(1, 2, 3), nil)
+	shard.EXPECT().DeleteSeries("ns", "cpu", roaring.BitmapOf(1, 2, 3), timeRange).Return(nil)
+	assert.NoError(t, exec.Execute())
+}
+
+func TestNewDeleteHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	engine := tsdb.NewMockEngine(ctrl)
+	db := tsdb.NewMockDatabase(ctrl)
+	shard := tsdb.NewMockShard(ctrl)
+	indexDB := indexdb.NewMockIndexDatabase(ctrl)
+	shard.EXPECT().DatabaseName().Return("db").AnyTimes()
+	shard.EXPECT().ShardID().Return(models.ShardID(1)).AnyTimes()
+	shard.EXPECT().IndexDatabase().Return(indexDB).AnyTimes()
+	db.EXPECT().GetShard(models.ShardID(1)).Return(shard, true).AnyTimes()
+	timeRange := timeutil.TimeRange{Start: 10, End: 100}
+	deleteStmt := &stmt.Delete{Namespace: "ns", MetricName: "cpu", TimeRange: timeRange}
+
+	handler := NewDeleteHandler(engine)
+	// case 1: database not exist
+	engine.EXPECT().GetDatabase("db").Return(nil, false)
+	assert.Error(t, handler(shard, deleteStmt))
+	engine.EXPECT().GetDatabase("db").Return(db, true).AnyTimes()
+	// case 2: metric not found, nothing to delete
+	indexDB.EXPECT().GetSeriesIDsForMetric("ns", "cpu").Return(nil, constants.ErrNotFound)
+	assert.NoError(t, handler(shard, deleteStmt))
+	// case 3: delete series err
+	indexDB.EXPECT().GetSeriesIDsForMetric("ns", "cpu").Return(roaring.BitmapOf(1), nil).AnyTimes()
+	shard.EXPECT().DeleteSeries("ns", "cpu", roaring.BitmapOf(constants.SeriesIDWithoutTags, 1), timeRange).
+		Return(fmt.Errorf("err"))
+	assert.Error(t, handler(shard, deleteStmt))
+	// case 4: delete series
+	shard.EXPECT().DeleteSeries("ns", "cpu", roaring.BitmapOf(constants.SeriesIDWithoutTags, 1), timeRange).
+		Return(nil)
+	assert.NoError(t, handler(shard, deleteStmt))
+}
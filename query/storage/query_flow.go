@@ -69,6 +69,7 @@ type storageQueryFlow struct {
 	tagValuesMap []map[uint32]string // tag value id=> tag value for each group by tag key
 	tagValues    []string
 	signal       sync.WaitGroup
+	exemplars    []*protoCommonV1.Exemplar
 
 	mux       sync.Mutex
 	completed atomic.Bool
@@ -179,6 +180,13 @@ func (qf *storageQueryFlow) ReduceTagValues(tagKeyIndex int, tagValues map[uint3
 	qf.signal.Done()
 }
 
+// ReduceExemplars reduces the exemplars of series
+func (qf *storageQueryFlow) ReduceExemplars(exemplars []*protoCommonV1.Exemplar) {
+	qf.mux.Lock()
+	defer qf.mux.Unlock()
+	qf.exemplars = append(qf.exemplars, exemplars...)
+}
+
 func (qf *storageQueryFlow) getTagValues(tags string) string {
 	tagValues, ok := qf.tagsMap[tags]
 	if ok {
@@ -225,6 +233,7 @@ func (qf *storageQueryFlow) completeTask(taskID int32) {
 			leaf2RootSeries := protoCommonV1.TimeSeriesList{
 				TimeSeriesList: timeSeriesList,
 				FieldAggSpecs:  qf.aggregatorSpecs,
+				Exemplars:      qf.exemplars,
 			}
 			leaf2RootSeriesPayload, _ := leaf2RootSeries.Marshal()
 			hashGroupData[0] = leaf2RootSeriesPayload
@@ -243,6 +252,10 @@ func (qf *storageQueryFlow) completeTask(taskID int32) {
 					TimeSeriesList: timeSeriesHashGroup,
 					FieldAggSpecs:  qf.aggregatorSpecs,
 				}
+				// exemplars are not grouped by series, only sends to first intermediate receiver
+				if idx == 0 {
+					leaf2IntermediateSeries.Exemplars = qf.exemplars
+				}
 				leaf2IntermediatePayload, _ := leaf2IntermediateSeries.Marshal()
 				hashGroupData[idx] = leaf2IntermediatePayload
 			}
@@ -202,6 +202,24 @@ func TestStorageQueryFlow_completeTask(t *testing.T) {
 	time.Sleep(300 * time.Millisecond)
 }
 
+func TestStorageQueryFlow_ReduceExemplars(t *testing.T) {
+	queryFlow := NewStorageQueryFlow(
+		context.TODO(),
+		nil,
+		&stmt.Query{},
+		&protoCommonV1.TaskRequest{},
+		nil,
+		&models.Leaf{},
+		testExecPool,
+	)
+	qf := queryFlow.(*storageQueryFlow)
+	assert.Empty(t, qf.exemplars)
+	queryFlow.ReduceExemplars([]*protoCommonV1.Exemplar{{Field: "f", TraceID: []byte{0xab}, Duration: 1}})
+	queryFlow.ReduceExemplars([]*protoCommonV1.Exemplar{{Field: "f", TraceID: []byte{0xcd}, Duration: 2}})
+	assert.Len(t, qf.exemplars, 2)
+	assert.Equal(t, []byte{0xcd}, qf.exemplars[1].TraceID)
+}
+
 func TestStorageQueryFlow_getValues(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -31,10 +31,13 @@ import (
 	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/pkg/timeutil"
+	protoCommonV1 "github.com/lindb/lindb/proto/gen/v1/common"
 	"github.com/lindb/lindb/series"
 	"github.com/lindb/lindb/series/field"
+	"github.com/lindb/lindb/series/metric"
 	"github.com/lindb/lindb/series/tag"
 	"github.com/lindb/lindb/tsdb"
+	"github.com/lindb/lindb/tsdb/tblstore/sketch"
 )
 
 // for testing
@@ -84,6 +87,7 @@ type storageExecutor struct {
 	queryTimeRange     timeutil.TimeRange
 	queryInterval      timeutil.Interval
 	queryIntervalRatio int
+	storageInterval    timeutil.Interval
 
 	// group by query need
 	mutex              sync.Mutex
@@ -165,6 +169,7 @@ func (e *storageExecutor) Execute() {
 	var interval timeutil.Interval
 	_ = interval.ValueOf(option.Interval)
 	//TODO need get storage interval by query time if has rollup config
+	e.storageInterval = interval
 	e.queryTimeRange, e.queryIntervalRatio, e.queryInterval = downSamplingTimeRange(
 		e.ctx.query.Interval, interval, e.ctx.query.TimeRange)
 
@@ -199,9 +204,25 @@ func (e *storageExecutor) executeQuery() {
 			if seriesIDs.IsEmpty() {
 				return
 			}
+			// 2. find exemplars of series if need
+			if e.ctx.query.Exemplars {
+				if err := e.findExemplars(shard, seriesIDs); err != nil {
+					e.queryFlow.Complete(err)
+					return
+				}
+			}
+
+			// 3. find quantile sketches of series if need
+			var sketches map[uint32][]sketch.Sketch
+			if e.storageExecutePlan.hasSketch {
+				if sketches, err = e.findSketches(shard, seriesIDs); err != nil {
+					e.queryFlow.Complete(err)
+					return
+				}
+			}
 
 			rs := newTimeSpanResultSet()
-			// 2. filter data in memory database
+			// 4. filter data in memory database
 			t = newMemoryDataFilterTask(e.ctx, shard, e.metricID, e.fields, seriesIDs, rs)
 			err = t.Run()
 			if err != nil && !errors.Is(err, constants.ErrNotFound) {
@@ -209,7 +230,7 @@ func (e *storageExecutor) executeQuery() {
 				e.queryFlow.Complete(err)
 				return
 			}
-			// 3. filter data each data family in shard
+			// 5. filter data each data family in shard
 			t = newFileDataFilterTask(e.ctx, shard, e.metricID, e.fields, seriesIDs, rs)
 			err = t.Run()
 			if err != nil && !errors.Is(err, constants.ErrNotFound) {
@@ -222,7 +243,7 @@ func (e *storageExecutor) executeQuery() {
 				return
 			}
 
-			// 5. execute group by
+			// 6. execute group by
 			e.pendingForGrouping.Inc()
 			e.queryFlow.Grouping(func() {
 				defer func() {
@@ -230,16 +251,93 @@ func (e *storageExecutor) executeQuery() {
 					// try start collect tag values
 					e.collectGroupByTagValues()
 				}()
-				e.executeGroupBy(shard, rs, rs.getSeriesIDs())
+				e.executeGroupBy(shard, rs, rs.getSeriesIDs(), sketches)
 			})
 		})
 	}
 }
 
+// findExemplars finds the exemplars of series in shard, then reduces them into query flow.
+func (e *storageExecutor) findExemplars(shard tsdb.Shard, seriesIDs *roaring.Bitmap) error {
+	exemplars, err := shard.FindExemplars(e.metricID, e.fields, seriesIDs, e.queryTimeRange)
+	if err != nil {
+		return err
+	}
+	if len(exemplars) == 0 {
+		return nil
+	}
+	rs := make([]*protoCommonV1.Exemplar, len(exemplars))
+	for idx := range exemplars {
+		rs[idx] = &protoCommonV1.Exemplar{
+			Field:     exemplars[idx].Field,
+			Timestamp: exemplars[idx].Timestamp,
+			TraceID:   exemplars[idx].TraceID,
+			SpanID:    exemplars[idx].SpanID,
+			Duration:  exemplars[idx].Duration,
+		}
+	}
+	e.queryFlow.ReduceExemplars(rs)
+	return nil
+}
+
+// findSketches finds the quantile sketches of series in shard, returns the sketches by series id.
+func (e *storageExecutor) findSketches(shard tsdb.Shard, seriesIDs *roaring.Bitmap) (map[uint32][]sketch.Sketch, error) {
+	sketches, err := shard.FindSketches(e.ctx.query.Interval.Type(), e.metricID, seriesIDs, e.queryTimeRange)
+	if err != nil {
+		return nil, err
+	}
+	if len(sketches) == 0 {
+		return nil, nil
+	}
+	rs := make(map[uint32][]sketch.Sketch)
+	for idx := range sketches {
+		seriesID := sketches[idx].SeriesID
+		rs[seriesID] = append(rs[seriesID], sketches[idx])
+	}
+	return rs, nil
+}
+
+// aggregateSketchBins expands the quantile sketches of series into sketch bins, then aggregates the count of bins.
+func (e *storageExecutor) aggregateSketchBins(binAggs map[int32]aggregation.SeriesAggregator, sketches []sketch.Sketch) {
+	calc := e.storageInterval.Calculator()
+	for idx := range sketches {
+		timestamp := sketches[idx].Timestamp
+		segmentTime := calc.CalcSegmentTime(timestamp)
+		familyTime := calc.CalcFamilyStartTime(segmentTime, calc.CalcFamily(timestamp, segmentTime))
+		slot := calc.CalcSlot(timestamp, familyTime, e.storageInterval.Int64())
+		sketches[idx].Data.Bins(func(index int32, count float64) {
+			binAgg, ok := binAggs[index]
+			if !ok {
+				binAgg = aggregation.NewSeriesAggregator(
+					e.ctx.query.Interval,
+					e.queryIntervalRatio,
+					e.ctx.query.TimeRange,
+					aggregation.NewSketchBinAggregatorSpec(metric.SketchBinName(index)))
+				binAggs[index] = binAgg
+			}
+			agg, ok := binAgg.GetAggregator(familyTime)
+			if !ok {
+				return
+			}
+			start, end := agg.SlotRange()
+			pos := slot/e.queryIntervalRatio - start
+			if pos < 0 || pos > end-start {
+				return
+			}
+			agg.AggregateBySlot(pos, count)
+		})
+	}
+}
+
 // executeGroupBy executes the query flow, step as below:
 // 1. grouping
 // 2. loading
-func (e *storageExecutor) executeGroupBy(shard tsdb.Shard, rs *timeSpanResultSet, seriesIDs *roaring.Bitmap) {
+func (e *storageExecutor) executeGroupBy(
+	shard tsdb.Shard,
+	rs *timeSpanResultSet,
+	seriesIDs *roaring.Bitmap,
+	sketches map[uint32][]sketch.Sketch,
+) {
 	groupingResult := &groupingResult{}
 	var groupingCtx series.GroupingContext
 	// timespans sorted by family
@@ -363,7 +461,20 @@ func (e *storageExecutor) executeGroupBy(shard tsdb.Shard, rs *timeSpanResultSet
 							}
 						}
 					}
-					e.queryFlow.Reduce(tags, fieldAggList.ResultSet(tags))
+					resultSet := fieldAggList
+					if len(sketches) > 0 {
+						// expand sketches of grouped series into bins, bins are reduced with fields
+						binAggs := make(map[int32]aggregation.SeriesAggregator)
+						for _, seriesID := range seriesIDs {
+							e.aggregateSketchBins(binAggs, sketches[uint32(seriesIDHighKey)<<16|uint32(seriesID)])
+						}
+						resultSet = make(aggregation.FieldAggregates, 0, len(fieldAggList)+len(binAggs))
+						resultSet = append(resultSet, fieldAggList...)
+						for _, binAgg := range binAggs {
+							resultSet = append(resultSet, binAgg)
+						}
+					}
+					e.queryFlow.Reduce(tags, resultSet.ResultSet(tags))
 					// reset aggregate context
 					fieldAggList.Reset()
 				}
@@ -24,22 +24,28 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/lindb/roaring"
+	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/aggregation"
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/flow"
 	"github.com/lindb/lindb/internal/concurrent"
 	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/pkg/option"
 	"github.com/lindb/lindb/pkg/timeutil"
+	protoCommonV1 "github.com/lindb/lindb/proto/gen/v1/common"
 	"github.com/lindb/lindb/series"
 	"github.com/lindb/lindb/series/field"
+	"github.com/lindb/lindb/series/metric"
 	"github.com/lindb/lindb/series/tag"
 	"github.com/lindb/lindb/sql"
 	"github.com/lindb/lindb/sql/stmt"
 	"github.com/lindb/lindb/tsdb"
 	"github.com/lindb/lindb/tsdb/indexdb"
 	"github.com/lindb/lindb/tsdb/metadb"
+	"github.com/lindb/lindb/tsdb/tblstore/exemplar"
+	"github.com/lindb/lindb/tsdb/tblstore/sketch"
 )
 
 type mockQueryFlow struct {
@@ -48,6 +54,9 @@ type mockQueryFlow struct {
 func (m *mockQueryFlow) ReduceTagValues(_ int, _ map[uint32]string) {
 }
 
+func (m *mockQueryFlow) ReduceExemplars(_ []*protoCommonV1.Exemplar) {
+}
+
 func (m *mockQueryFlow) Prepare(_ timeutil.Interval, _ int, _ timeutil.TimeRange, _ aggregation.AggregatorSpecs) {
 }
 
@@ -301,6 +310,72 @@ func TestStorageExecute_Execute(t *testing.T) {
 	exec.Execute()
 }
 
+// exemplarsQueryFlow records the reduced exemplars
+type exemplarsQueryFlow struct {
+	mockQueryFlow
+	exemplars []*protoCommonV1.Exemplar
+	err       error
+}
+
+func (m *exemplarsQueryFlow) ReduceExemplars(exemplars []*protoCommonV1.Exemplar) {
+	m.exemplars = append(m.exemplars, exemplars...)
+}
+
+func (m *exemplarsQueryFlow) Complete(err error) {
+	m.err = err
+}
+
+func TestStorageExecute_Exemplars(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadataIndex := metadb.NewMockMetadataDatabase(ctrl)
+	metadata.EXPECT().MetadataDatabase().Return(metadataIndex).AnyTimes()
+	metadataIndex.EXPECT().GetMetricID(gomock.Any(), "cpu").Return(uint32(10), nil).AnyTimes()
+	metadataIndex.EXPECT().GetField(gomock.Any(), gomock.Any(), field.Name("f")).
+		Return(field.Meta{ID: 10, Type: field.SumField}, nil).AnyTimes()
+	mockDatabase := tsdb.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().GetOption().Return(option.DatabaseOption{Interval: "10s"}).AnyTimes()
+	mockDatabase.EXPECT().NumOfShards().Return(1).AnyTimes()
+	mockDatabase.EXPECT().Metadata().Return(metadata).AnyTimes()
+	index := indexdb.NewMockIndexDatabase(ctrl)
+	index.EXPECT().GetSeriesIDsForMetric(gomock.Any(), gomock.Any()).Return(roaring.BitmapOf(1, 2), nil).AnyTimes()
+	shard := tsdb.NewMockShard(ctrl)
+	shard.EXPECT().IndexDatabase().Return(index).AnyTimes()
+	shard.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	shard.EXPECT().GetDataFamilies(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockDatabase.EXPECT().GetShard(models.ShardID(1)).Return(shard, true).AnyTimes()
+
+	q, err := sql.Parse("select f from cpu with exemplars")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	// case 1: find exemplars failure
+	queryFlow := &exemplarsQueryFlow{}
+	shard.EXPECT().FindExemplars(uint32(10), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("err"))
+	exec := newStorageMetricQuery(queryFlow, mockDatabase, newStorageExecuteContext([]models.ShardID{1}, query))
+	exec.Execute()
+	assert.Error(t, queryFlow.err)
+	// case 2: find exemplars
+	queryFlow = &exemplarsQueryFlow{}
+	shard.EXPECT().FindExemplars(uint32(10), gomock.Any(), gomock.Any(), gomock.Any()).Return([]exemplar.Exemplar{
+		{SeriesID: 1, Timestamp: 10, Field: "f", TraceID: []byte{0xab, 0xcd}, SpanID: []byte{0x01}, Duration: 10},
+	}, nil)
+	exec = newStorageMetricQuery(queryFlow, mockDatabase, newStorageExecuteContext([]models.ShardID{1}, query))
+	exec.Execute()
+	assert.NoError(t, queryFlow.err)
+	assert.Equal(t, []*protoCommonV1.Exemplar{
+		{Field: "f", Timestamp: 10, TraceID: []byte{0xab, 0xcd}, SpanID: []byte{0x01}, Duration: 10},
+	}, queryFlow.exemplars)
+	// case 3: query without exemplars
+	q, err = sql.Parse("select f from cpu")
+	assert.NoError(t, err)
+	queryFlow = &exemplarsQueryFlow{}
+	exec = newStorageMetricQuery(queryFlow, mockDatabase, newStorageExecuteContext([]models.ShardID{1}, q.(*stmt.Query)))
+	exec.Execute()
+	assert.Empty(t, queryFlow.exemplars)
+}
+
 func TestStorageExecutor_Execute_GroupBy(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer func() {
@@ -334,18 +409,18 @@ func TestStorageExecutor_Execute_GroupBy(t *testing.T) {
 	gCtx.EXPECT().GetGroupByTagValueIDs().Return([]*roaring.Bitmap{roaring.BitmapOf(1, 2, 3)}).AnyTimes()
 	tagMeta.EXPECT().CollectTagValues(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 	exec1.storageExecutePlan = &storageExecutePlan{groupByTags: []tag.Meta{{ID: 1, Key: "host"}}}
-	exec1.executeGroupBy(shard, &timeSpanResultSet{}, roaring.BitmapOf(1, 2, 3))
+	exec1.executeGroupBy(shard, &timeSpanResultSet{}, roaring.BitmapOf(1, 2, 3), nil)
 
 	// case 2: get grouping context err
 	gomock.InOrder(
 		indexDB.EXPECT().GetGroupingContext(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("err")),
 	)
-	exec1.executeGroupBy(shard, &timeSpanResultSet{}, roaring.BitmapOf(1, 2, 3))
+	exec1.executeGroupBy(shard, &timeSpanResultSet{}, roaring.BitmapOf(1, 2, 3), nil)
 	// case 3: get grouping context nil
 	gomock.InOrder(
 		indexDB.EXPECT().GetGroupingContext(gomock.Any(), gomock.Any()).Return(nil, nil),
 	)
-	exec1.executeGroupBy(shard, &timeSpanResultSet{}, roaring.BitmapOf(1, 2, 3))
+	exec1.executeGroupBy(shard, &timeSpanResultSet{}, roaring.BitmapOf(1, 2, 3), nil)
 
 	// case 4: collect tag values err
 	indexDB.EXPECT().GetGroupingContext(gomock.Any(), gomock.Any()).Return(gCtx, nil)
@@ -356,7 +431,7 @@ func TestStorageExecutor_Execute_GroupBy(t *testing.T) {
 	exec1.groupByTagKeyIDs = []tag.Meta{{ID: 1, Key: "host"}}
 	exec1.tagValueIDs = make([]*roaring.Bitmap, len(exec1.groupByTagKeyIDs))
 	exec1.storageExecutePlan = &storageExecutePlan{groupByTags: []tag.Meta{{ID: 1, Key: "host"}}}
-	exec1.executeGroupBy(shard, &timeSpanResultSet{}, roaring.BitmapOf(1, 2, 3))
+	exec1.executeGroupBy(shard, &timeSpanResultSet{}, roaring.BitmapOf(1, 2, 3), nil)
 
 	// case 5: build group series err
 	task := flow.NewMockQueryTask(ctrl)
@@ -366,7 +441,7 @@ func TestStorageExecutor_Execute_GroupBy(t *testing.T) {
 	}
 	indexDB.EXPECT().GetGroupingContext(gomock.Any(), gomock.Any()).Return(gCtx, nil)
 	task.EXPECT().Run().Return(fmt.Errorf("err"))
-	exec1.executeGroupBy(shard, &timeSpanResultSet{}, roaring.BitmapOf(1, 2, 3))
+	exec1.executeGroupBy(shard, &timeSpanResultSet{}, roaring.BitmapOf(1, 2, 3), nil)
 
 	newBuildGroupTaskFunc = newBuildGroupTask
 	// case 6: load data err
@@ -379,7 +454,7 @@ func TestStorageExecutor_Execute_GroupBy(t *testing.T) {
 	indexDB.EXPECT().GetGroupingContext(gomock.Any(), gomock.Any()).Return(gCtx, nil)
 	task.EXPECT().Run().Return(fmt.Errorf("err"))
 	gCtx.EXPECT().BuildGroup(gomock.Any(), gomock.Any()).Return(map[string][]uint16{"host": {1, 2, 3}})
-	exec1.executeGroupBy(shard, &timeSpanResultSet{spanMap: map[int64]*timeSpan{1: {}}, filterRSCount: 1}, roaring.BitmapOf(1, 2, 3))
+	exec1.executeGroupBy(shard, &timeSpanResultSet{spanMap: map[int64]*timeSpan{1: {}}, filterRSCount: 1}, roaring.BitmapOf(1, 2, 3), nil)
 }
 
 func TestStorageExecutor_merge_groupBy_tagValues(t *testing.T) {
@@ -402,3 +477,72 @@ func TestStorageExecutor_merge_groupBy_tagValues(t *testing.T) {
 	// case 3: merge tag value
 	exec1.mergeGroupByTagValueIDs([]*roaring.Bitmap{roaring.BitmapOf(4, 5, 6), roaring.BitmapOf(1, 2, 3), nil})
 }
+
+func TestStorageExecutor_findSketches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	shard := tsdb.NewMockShard(ctrl)
+	exec := newStorageMetricQuery(nil, nil, newStorageExecuteContext([]models.ShardID{1}, &stmt.Query{}))
+	exec1 := exec.(*storageExecutor)
+	// case 1: find sketches failure
+	shard.EXPECT().FindSketches(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("err"))
+	rs, err := exec1.findSketches(shard, roaring.BitmapOf(1, 2))
+	assert.Error(t, err)
+	assert.Nil(t, rs)
+	// case 2: sketches not found
+	shard.EXPECT().FindSketches(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	rs, err = exec1.findSketches(shard, roaring.BitmapOf(1, 2))
+	assert.NoError(t, err)
+	assert.Nil(t, rs)
+	// case 3: sketches grouped by series id
+	s1 := sketch.Sketch{SeriesID: 1, Timestamp: 10, Data: encoding.NewSketch()}
+	s2 := sketch.Sketch{SeriesID: 2, Timestamp: 10, Data: encoding.NewSketch()}
+	s3 := sketch.Sketch{SeriesID: 1, Timestamp: 20, Data: encoding.NewSketch()}
+	// sketches are found from the segment of query interval, same as data families
+	shard.EXPECT().FindSketches(timeutil.Interval(0).Type(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return([]sketch.Sketch{s1, s2, s3}, nil)
+	rs, err = exec1.findSketches(shard, roaring.BitmapOf(1, 2))
+	assert.NoError(t, err)
+	assert.Equal(t, map[uint32][]sketch.Sketch{1: {s1, s3}, 2: {s2}}, rs)
+}
+
+func TestStorageExecutor_aggregateSketchBins(t *testing.T) {
+	now, _ := timeutil.ParseTimestamp("20190702 19:10:00", "20060102 15:04:05")
+	q, err := sql.Parse("select quantile(0.99) from latency where time>=now()-1h")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	query.TimeRange = timeutil.TimeRange{Start: now - timeutil.OneHour, End: now}
+	query.Interval = timeutil.Interval(10 * timeutil.OneSecond)
+	exec := newStorageMetricQuery(nil, nil, newStorageExecuteContext([]models.ShardID{1}, query))
+	exec1 := exec.(*storageExecutor)
+	exec1.storageInterval = timeutil.Interval(10 * timeutil.OneSecond)
+	exec1.queryIntervalRatio = 1
+
+	s1 := encoding.NewSketch()
+	s1.Add(10)
+	s1.Add(10)
+	s2 := encoding.NewSketch()
+	s2.Add(10)
+	s2.Add(100)
+	binAggs := make(map[int32]aggregation.SeriesAggregator)
+	exec1.aggregateSketchBins(binAggs, []sketch.Sketch{
+		{SeriesID: 1, Timestamp: now - timeutil.OneMinute, Data: s1},
+		{SeriesID: 1, Timestamp: now - timeutil.OneMinute, Data: s2},
+		// out of query time range
+		{SeriesID: 1, Timestamp: now - 2*timeutil.OneHour, Data: s2},
+	})
+	assert.Len(t, binAggs, 2)
+	binAgg := binAggs[encoding.SketchBinIndex(10)]
+	assert.Equal(t, metric.SketchBinName(encoding.SketchBinIndex(10)), binAgg.FieldName())
+	assert.Equal(t, field.SketchField, binAgg.GetFieldType())
+	it := binAgg.ResultSet()
+	assert.True(t, it.HasNext())
+	_, fieldIt := it.Next()
+	assert.True(t, fieldIt.HasNext())
+	pIt := fieldIt.Next()
+	assert.True(t, pIt.HasNext())
+	_, value := pIt.Next()
+	assert.Equal(t, 3.0, value)
+	assert.False(t, pIt.HasNext())
+}
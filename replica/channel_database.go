@@ -27,6 +27,7 @@ import (
 	"github.com/lindb/lindb/internal/linmetric"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/option"
 	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/rpc"
 	"github.com/lindb/lindb/series/metric"
@@ -47,6 +48,11 @@ type DatabaseChannel interface {
 	Write(ctx context.Context, brokerBatchRows *metric.BrokerBatchRows) error
 	// CreateChannel creates the shard level replication channel by given shard id
 	CreateChannel(numOfShard int32, shardID models.ShardID) (Channel, error)
+	// EvictOutOfTimeRange rejects the rows which timestamp is out of allowed write ahead/behind range,
+	// returns the number of evicted rows.
+	EvictOutOfTimeRange(brokerBatchRows *metric.BrokerBatchRows) int
+	// AlterOption applies the changed database option(allowed timestamp write ahead/behind) to channel
+	AlterOption(databaseOption option.DatabaseOption)
 	Stop()
 }
 
@@ -86,34 +92,46 @@ func newDatabaseChannel(
 	}
 	ch.shardChannels.value.Store(make(shard2Channel))
 
-	var ahead timeutil.Interval
-	var behind timeutil.Interval
-	_ = ahead.ValueOf(databaseCfg.Option.Ahead)
-	_ = behind.ValueOf(databaseCfg.Option.Behind)
-	ch.ahead = atomic.NewInt64(ahead.Int64())
-	ch.behind = atomic.NewInt64(behind.Int64())
+	ch.ahead = atomic.NewInt64(0)
+	ch.behind = atomic.NewInt64(0)
+	ch.AlterOption(databaseCfg.Option)
 	_ = ch.interval.ValueOf(databaseCfg.Option.Interval)
-	if ch.ahead.Load() <= 0 {
-		ch.ahead.Store(constants.MetricMaxBehindDuration)
-	}
-	if ch.behind.Load() <= 0 {
-		ch.behind.Store(constants.MetricMaxAheadDuration)
-	}
 
 	ch.numOfShard.Store(numOfShard)
 	ch.statistics.evictedCounter = evictedCounterVec.WithTagValues(databaseCfg.Name)
 	return ch, nil
 }
 
+// AlterOption applies the changed database option(allowed timestamp write ahead/behind) to channel
+func (dc *databaseChannel) AlterOption(databaseOption option.DatabaseOption) {
+	var ahead timeutil.Interval
+	var behind timeutil.Interval
+	_ = ahead.ValueOf(databaseOption.Ahead)
+	_ = behind.ValueOf(databaseOption.Behind)
+	if ahead.Int64() <= 0 {
+		ahead = timeutil.Interval(constants.MetricMaxBehindDuration)
+	}
+	if behind.Int64() <= 0 {
+		behind = timeutil.Interval(constants.MetricMaxAheadDuration)
+	}
+	dc.ahead.Store(ahead.Int64())
+	dc.behind.Store(behind.Int64())
+}
+
+// EvictOutOfTimeRange rejects the rows which timestamp is out of allowed write ahead/behind range,
+// returns the number of evicted rows.
+func (dc *databaseChannel) EvictOutOfTimeRange(brokerBatchRows *metric.BrokerBatchRows) int {
+	evicted := brokerBatchRows.EvictOutOfTimeRange(dc.behind.Load(), dc.ahead.Load())
+	dc.statistics.evictedCounter.Add(float64(evicted))
+	return evicted
+}
+
 // Write writes the metric data into channel's buffer
 func (dc *databaseChannel) Write(ctx context.Context, brokerBatchRows *metric.BrokerBatchRows) error {
 	var err error
 
-	behind := dc.behind.Load()
-	ahead := dc.ahead.Load()
-
-	evicted := brokerBatchRows.EvictOutOfTimeRange(behind, ahead)
-	dc.statistics.evictedCounter.Add(float64(evicted))
+	// rows already evicted before writing are skipped
+	dc.EvictOutOfTimeRange(brokerBatchRows)
 
 	// sharding metrics to shards
 	shardingIterator := brokerBatchRows.NewShardGroupIterator(dc.numOfShard.Load())
@@ -25,7 +25,9 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/option"
 	"github.com/lindb/lindb/pkg/timeutil"
 	protoMetricsV1 "github.com/lindb/lindb/proto/gen/v1/metrics"
 	"github.com/lindb/lindb/series/metric"
@@ -98,3 +100,38 @@ func TestDatabaseChannel_CreateChannel(t *testing.T) {
 	_, err = ch.CreateChannel(4, 1)
 	assert.NoError(t, err)
 }
+
+func TestDatabaseChannel_AlterOption(t *testing.T) {
+	ch, err := newDatabaseChannel(context.TODO(), models.Database{Name: "database"}, 1, nil)
+	assert.NoError(t, err)
+	ch1 := ch.(*databaseChannel)
+	assert.Equal(t, int64(constants.MetricMaxBehindDuration), ch1.ahead.Load())
+	assert.Equal(t, int64(constants.MetricMaxAheadDuration), ch1.behind.Load())
+
+	ch.AlterOption(option.DatabaseOption{Ahead: "1h", Behind: "2h"})
+	assert.Equal(t, timeutil.OneHour, ch1.ahead.Load())
+	assert.Equal(t, 2*timeutil.OneHour, ch1.behind.Load())
+}
+
+func TestDatabaseChannel_EvictOutOfTimeRange(t *testing.T) {
+	ch, err := newDatabaseChannel(context.TODO(), models.Database{Name: "database"}, 1, nil)
+	assert.NoError(t, err)
+	ch.AlterOption(option.DatabaseOption{Ahead: "1h", Behind: "1h"})
+
+	converter := metric.NewProtoConverter()
+	batch := metric.NewBrokerBatchRows()
+	for _, timestamp := range []int64{timeutil.Now(), timeutil.Now() - 2*timeutil.OneHour, timeutil.Now() + 2*timeutil.OneHour} {
+		_ = batch.TryAppend(func(row *metric.BrokerRow) error {
+			return converter.ConvertTo(&protoMetricsV1.Metric{
+				Name:      "cpu",
+				Timestamp: timestamp,
+				SimpleFields: []*protoMetricsV1.SimpleField{
+					{Name: "f1", Type: protoMetricsV1.SimpleFieldType_DELTA_SUM, Value: 1}},
+			}, row)
+		})
+	}
+	assert.Equal(t, 2, ch.EvictOutOfTimeRange(batch))
+	assert.Equal(t, 1, batch.AcceptedCount())
+	// evicted rows are not evicted again
+	assert.Equal(t, 0, ch.EvictOutOfTimeRange(batch))
+}
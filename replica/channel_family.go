@@ -37,9 +37,18 @@ type FamilyChannel interface {
 	// ErrCanceled is returned when the channel is canceled before data is written successfully.
 	// Concurrent safe.
 	Write(ctx context.Context, rows []metric.BrokerRow) error
+	// LeaderChanged notifies the channel that the leader of shard is changed,
+	// pending data will be sent to the new leader.
+	LeaderChanged(shardState models.ShardState, liveNodes map[models.NodeID]models.StatefulNode)
 	Stop()
 }
 
+// leaderChangedEvent represents the new leader of shard.
+type leaderChangedEvent struct {
+	shardState models.ShardState
+	target     models.Node
+}
+
 type familyChannel struct {
 	// context to close channel
 	ctx        context.Context
@@ -60,6 +69,8 @@ type familyChannel struct {
 	// channel to convert multiple goroutine writeTask to single goroutine writeTask to FanOutQueue
 	ch    chan *compressedChunk
 	chunk Chunk // buffer current writeTask metric for compress
+	// channel to notify write task switching the target node
+	leaderChangedCh chan *leaderChangedEvent
 
 	lastFlushTime      time.Time     // last flush time
 	checkFlushInterval time.Duration // interval for check flush
@@ -89,6 +100,7 @@ func newFamilyChannel(
 		fct:                fct,
 		newWriteStreamFn:   rpc.NewWriteStream,
 		ch:                 make(chan *compressedChunk, 2),
+		leaderChangedCh:    make(chan *leaderChangedEvent, 1),
 		checkFlushInterval: time.Second,
 		batchTimout:        cfg.BatchTimeout.Duration(),
 		chunk:              newChunk(cfg.BatchBlockSize),
@@ -123,6 +135,21 @@ func (fc *familyChannel) Write(ctx context.Context, rows []metric.BrokerRow) err
 	return nil
 }
 
+// LeaderChanged notifies the channel that the leader of shard is changed,
+// pending data will be sent to the new leader.
+// NOTICE: must be called serially.
+func (fc *familyChannel) LeaderChanged(shardState models.ShardState,
+	liveNodes map[models.NodeID]models.StatefulNode,
+) {
+	target := liveNodes[shardState.Leader]
+	// drop stale event which write task not handled
+	select {
+	case <-fc.leaderChangedCh:
+	default:
+	}
+	fc.leaderChangedCh <- &leaderChangedEvent{shardState: shardState, target: &target}
+}
+
 func (fc *familyChannel) flushChunkOnFull(ctx context.Context) error {
 	if !fc.chunk.IsFull() {
 		return nil
@@ -162,6 +189,20 @@ func (fc *familyChannel) writeTask(shardState models.ShardState, target models.N
 		select {
 		case <-fc.ctx.Done():
 			return
+		case evt := <-fc.leaderChangedCh:
+			// close the stream of old leader, new stream will be created when sending next data
+			if stream != nil {
+				if err := stream.Close(); err != nil {
+					fc.logger.Error("close write stream err when leader changed", logger.Error(err))
+				}
+				stream = nil
+			}
+			shardState = evt.shardState
+			target = evt.target
+			fc.logger.Info("shard leader changed, switch write target",
+				logger.String("db", fc.database),
+				logger.Any("shardID", fc.shardID),
+				logger.Any("leader", shardState.Leader))
 		case compressed := <-fc.ch:
 			if stream == nil {
 				stream, err = fc.newWriteStreamFn(fc.ctx, target, fc.database, &shardState, fc.familyTime, fc.fct)
@@ -210,3 +210,41 @@ func TestChannel_chunk_marshal_err(t *testing.T) {
 	chunk.EXPECT().Compress().Return(nil, nil)
 	ch1.flushChunk()
 }
+
+func TestChannel_LeaderChanged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stream := rpc.NewMockWriteStream(ctrl)
+	stream.EXPECT().Send(gomock.Any()).Return(nil).AnyTimes()
+	stream.EXPECT().Close().Return(fmt.Errorf("err")).AnyTimes()
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	liveNodes := map[models.NodeID]models.StatefulNode{
+		1: {ID: 1, StatelessNode: models.StatelessNode{HostIP: "1.1.1.1"}},
+		2: {ID: 2, StatelessNode: models.StatelessNode{HostIP: "1.1.1.2"}},
+	}
+	targets := make(chan string, 2)
+	ch := newFamilyChannel(ctx, config.GlobalBrokerConfig().Write, "database", 1, 12, nil,
+		models.ShardState{ID: 1, Leader: 1}, liveNodes)
+	ch1 := ch.(*familyChannel)
+	ch1.lock4write.Lock()
+	ch1.newWriteStreamFn = func(ctx context.Context, target models.Node, database string,
+		shardState *models.ShardState, familyTime int64, fct rpc.ClientStreamFactory) (rpc.WriteStream, error) {
+		targets <- target.Indicator()
+		return stream, nil
+	}
+	ch1.lock4write.Unlock()
+
+	var data = compressedChunk([]byte{1, 2, 3})
+	ch1.ch <- &data
+	assert.Equal(t, "1.1.1.1:0", <-targets)
+
+	// stale event is replaced by new event
+	ch.LeaderChanged(models.ShardState{ID: 1, Leader: 1}, liveNodes)
+	ch.LeaderChanged(models.ShardState{ID: 1, Leader: 2}, liveNodes)
+	time.Sleep(100 * time.Millisecond)
+	ch1.ch <- &data
+	assert.Equal(t, "1.1.1.2:0", <-targets)
+}
@@ -27,6 +27,7 @@ import (
 
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/option"
 	"github.com/lindb/lindb/rpc"
 	"github.com/lindb/lindb/series/metric"
 )
@@ -39,10 +40,17 @@ var log = logger.GetLogger("replica", "ChannelManager")
 type ChannelManager interface {
 	// Write writes a MetricList, the manager handler the database, sharding things.
 	Write(ctx context.Context, database string, brokerBatchRows *metric.BrokerBatchRows) error
+	// EvictOutOfTimeRange rejects the rows which timestamp is out of allowed write ahead/behind range of database,
+	// so that only the rows will be written are counted before writing.
+	EvictOutOfTimeRange(database string, brokerBatchRows *metric.BrokerBatchRows)
 	// CreateChannel creates a new channel or returns a existed channel for storage with specific database and shardID,
 	// numOfShard should be greater or equal than the origin setting, otherwise error is returned.
 	// numOfShard is used eot calculate the shardID for a given hash.
 	CreateChannel(databaseCfg models.Database, numOfShard int32, shardID models.ShardID) (Channel, error)
+	// AlterDatabaseOption applies the changed database option to the existing database channel.
+	AlterDatabaseOption(database string, databaseOption option.DatabaseOption)
+	// DropDatabase stops and removes the database channel after database dropped.
+	DropDatabase(database string)
 
 	// Close closes all the channel.
 	Close()
@@ -99,6 +107,17 @@ func (cm *channelManager) Write(ctx context.Context, database string, brokerBatc
 	return databaseChannel.Write(ctx, brokerBatchRows)
 }
 
+// EvictOutOfTimeRange rejects the rows which timestamp is out of allowed write ahead/behind range of database,
+// so that only the rows will be written are counted before writing.
+func (cm *channelManager) EvictOutOfTimeRange(database string, brokerBatchRows *metric.BrokerBatchRows) {
+	if brokerBatchRows == nil || brokerBatchRows.Len() == 0 {
+		return
+	}
+	if databaseChannel, ok := cm.getDatabaseChannel(database); ok {
+		databaseChannel.EvictOutOfTimeRange(brokerBatchRows)
+	}
+}
+
 // CreateChannel creates a new channel or returns a existed channel for storage with specific database and shardID.
 // NumOfShard should be greater or equal than the origin setting, otherwise error is returned.
 func (cm *channelManager) CreateChannel(databaseCfg models.Database, numOfShard int32, shardID models.ShardID) (Channel, error) {
@@ -133,6 +152,32 @@ func (cm *channelManager) CreateChannel(databaseCfg models.Database, numOfShard
 	return ch.CreateChannel(numOfShard, shardID)
 }
 
+// AlterDatabaseOption applies the changed database option to the existing database channel.
+func (cm *channelManager) AlterDatabaseOption(database string, databaseOption option.DatabaseOption) {
+	ch, ok := cm.getDatabaseChannel(database)
+	if !ok {
+		return
+	}
+	ch.AlterOption(databaseOption)
+	cm.logger.Info("alter option of database write channel successfully",
+		logger.String("db", database))
+}
+
+// DropDatabase stops and removes the database channel after database dropped.
+func (cm *channelManager) DropDatabase(database string) {
+	cm.databaseChannels.mu.Lock()
+	defer cm.databaseChannels.mu.Unlock()
+
+	ch, ok := cm.getDatabaseChannel(database)
+	if !ok {
+		return
+	}
+	cm.removeDatabaseChannel(database)
+	ch.Stop()
+	cm.logger.Info("drop database write channel successfully",
+		logger.String("db", database))
+}
+
 // Close closes all the channel.
 func (cm *channelManager) Close() {
 	cm.cancel()
@@ -162,3 +207,14 @@ func (cm *channelManager) insertDatabaseChannel(newDatabaseName string, newChann
 	newMap[newDatabaseName] = newChannel
 	cm.databaseChannels.value.Store(newMap)
 }
+
+func (cm *channelManager) removeDatabaseChannel(removedDatabaseName string) {
+	oldMap := cm.databaseChannels.value.Load().(database2Channel)
+	newMap := make(database2Channel)
+	for databaseName, channel := range oldMap {
+		if databaseName != removedDatabaseName {
+			newMap[databaseName] = channel
+		}
+	}
+	cm.databaseChannels.value.Store(newMap)
+}
@@ -27,6 +27,10 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/pkg/timeutil"
+	protoMetricsV1 "github.com/lindb/lindb/proto/gen/v1/metrics"
+	"github.com/lindb/lindb/series/metric"
 )
 
 func TestChannelManager_GetChannel(t *testing.T) {
@@ -51,6 +55,17 @@ func TestChannelManager_GetChannel(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, ch111, ch1)
 
+	cm.AlterDatabaseOption("not-exist", option.DatabaseOption{Behind: "2h"})
+	cm.AlterDatabaseOption("database", option.DatabaseOption{Behind: "2h"})
+	databaseCh, ok := cm.(*channelManager).getDatabaseChannel("database")
+	assert.True(t, ok)
+	assert.Equal(t, 2*timeutil.OneHour, databaseCh.(*databaseChannel).behind.Load())
+
+	cm.DropDatabase("not-exist")
+	cm.DropDatabase("database")
+	_, ok = cm.(*channelManager).getDatabaseChannel("database")
+	assert.False(t, ok)
+
 	cm.Close()
 }
 
@@ -81,3 +96,28 @@ func TestChannelManager_Write(t *testing.T) {
 	assert.NoError(t, err)
 	cm.Close()
 }
+
+func TestChannelManager_EvictOutOfTimeRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cm := NewChannelManager(context.TODO(), nil)
+	cm.EvictOutOfTimeRange("database", nil)
+
+	batch := metric.NewBrokerBatchRows()
+	_ = batch.TryAppend(func(row *metric.BrokerRow) error {
+		return metric.NewProtoConverter().ConvertTo(&protoMetricsV1.Metric{
+			Name:      "cpu",
+			Timestamp: timeutil.Now(),
+			SimpleFields: []*protoMetricsV1.SimpleField{
+				{Name: "f1", Type: protoMetricsV1.SimpleFieldType_DELTA_SUM, Value: 1}},
+		}, row)
+	})
+	// database not found
+	cm.EvictOutOfTimeRange("database", batch)
+
+	dbChannel := NewMockDatabaseChannel(ctrl)
+	cm.(*channelManager).insertDatabaseChannel("database", dbChannel)
+	dbChannel.EXPECT().EvictOutOfTimeRange(batch).Return(0)
+	cm.EvictOutOfTimeRange("database", batch)
+}
@@ -80,9 +80,18 @@ func newChannel(
 }
 
 func (c *channel) SyncShardState(shardState models.ShardState, liveNodes map[models.NodeID]models.StatefulNode) {
-	//TODO need sync shard state
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	leaderChanged := c.shardState.Leader != shardState.Leader
 	c.shardState = shardState
 	c.liveNodes = liveNodes
+	if leaderChanged {
+		// switch write target of family channels to new leader, e.g. old leader is draining
+		for _, family := range c.families.Entries() {
+			family.LeaderChanged(shardState, liveNodes)
+		}
+	}
 	c.logger.Info("start shard write channel successfully", logger.String("db", c.database),
 		logger.Any("shardID", c.shardID))
 }
@@ -16,3 +16,30 @@
 // under the License.
 
 package replica
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/lindb/lindb/models"
+)
+
+func TestChannel_SyncShardState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ch := newChannel(context.TODO(), "test", 1, nil)
+	family := NewMockFamilyChannel(ctrl)
+	ch.(*channel).families.InsertFamily(1, family)
+	liveNodes := map[models.NodeID]models.StatefulNode{1: {ID: 1}, 2: {ID: 2}}
+
+	family.EXPECT().LeaderChanged(models.ShardState{ID: 1, Leader: 1}, liveNodes)
+	ch.SyncShardState(models.ShardState{ID: 1, Leader: 1}, liveNodes)
+	// leader not changed
+	ch.SyncShardState(models.ShardState{ID: 1, Leader: 1}, liveNodes)
+	// leader changed
+	family.EXPECT().LeaderChanged(models.ShardState{ID: 1, Leader: 2}, liveNodes)
+	ch.SyncShardState(models.ShardState{ID: 1, Leader: 2}, liveNodes)
+}
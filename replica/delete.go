@@ -0,0 +1,65 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package replica
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/golang/snappy"
+
+	"github.com/lindb/lindb/sql/stmt"
+	"github.com/lindb/lindb/tsdb"
+)
+
+// deleteRecordPrefix is the prefix of delete record in write ahead log,
+// rows block always starts with the size of flat row, which never is 0xFFFFFFFF.
+var deleteRecordPrefix = []byte{0xFF, 0xFF, 0xFF, 0xFF}
+
+// DeleteHandler represents the handler which applies the delete statement replicated by write ahead log on shard.
+type DeleteHandler func(shard tsdb.Shard, deleteStmt *stmt.Delete) error
+
+// EncodeDeleteRecord encodes the delete statement as write ahead log record(compressed by snappy),
+// so that delete is replicated to all replicas of shard in the same order with written rows.
+func EncodeDeleteRecord(deleteStmt *stmt.Delete) ([]byte, error) {
+	data, err := deleteStmt.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	block := make([]byte, 0, len(deleteRecordPrefix)+len(data))
+	block = append(block, deleteRecordPrefix...)
+	block = append(block, data...)
+	return snappy.Encode(nil, block), nil
+}
+
+// isDeleteRecord returns if the decoded block is delete record.
+func isDeleteRecord(block []byte) bool {
+	return bytes.HasPrefix(block, deleteRecordPrefix)
+}
+
+// applyDeleteRecord decodes the delete statement from decoded block, then applies it on shard by handler.
+func applyDeleteRecord(deleteHandler DeleteHandler, shard tsdb.Shard, block []byte) error {
+	if deleteHandler == nil {
+		return errors.New("delete handler not set")
+	}
+	deleteStmt := &stmt.Delete{}
+	if err := deleteStmt.UnmarshalJSON(block[len(deleteRecordPrefix):]); err != nil {
+		return err
+	}
+	return deleteHandler(shard, deleteStmt)
+}
@@ -0,0 +1,66 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package replica
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/sql/stmt"
+	"github.com/lindb/lindb/tsdb"
+)
+
+func TestDeleteRecord(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	shard := tsdb.NewMockShard(ctrl)
+	deleteStmt := &stmt.Delete{
+		Namespace:  "ns",
+		MetricName: "cpu",
+		TimeRange:  timeutil.TimeRange{Start: 10, End: 100},
+	}
+	record, err := EncodeDeleteRecord(deleteStmt)
+	assert.NoError(t, err)
+	block, err := snappy.Decode(nil, record)
+	assert.NoError(t, err)
+	assert.True(t, isDeleteRecord(block))
+	assert.False(t, isDeleteRecord([]byte{1, 2, 3, 4, 5}))
+
+	// case 1: handler not set
+	assert.Error(t, applyDeleteRecord(nil, shard, block))
+	// case 2: apply delete statement
+	var applied *stmt.Delete
+	handler := func(s tsdb.Shard, d *stmt.Delete) error {
+		assert.Equal(t, shard, s)
+		applied = d
+		return nil
+	}
+	assert.NoError(t, applyDeleteRecord(handler, shard, block))
+	assert.Equal(t, deleteStmt, applied)
+	// case 3: bad statement
+	assert.Error(t, applyDeleteRecord(handler, shard, append([]byte{}, deleteRecordPrefix...)))
+	// case 4: apply failure
+	assert.Error(t, applyDeleteRecord(func(_ tsdb.Shard, _ *stmt.Delete) error {
+		return fmt.Errorf("err")
+	}, shard, block))
+}
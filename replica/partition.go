@@ -53,6 +53,10 @@ type Partition interface {
 	// ReplicaAckIndex returns the index which replica appended index.
 	ReplicaAckIndex() int64
 	ResetReplicaIndex(idx int64)
+	// IsReplicated returns if all messages of writeTask ahead log are acked by replicas.
+	IsReplicated() bool
+	// ReplicaState returns the replication state of all replica peers.
+	ReplicaState() []models.ReplicaPeerState
 }
 
 // partition implements  Partition interface.
@@ -65,6 +69,7 @@ type partition struct {
 	peers         map[string]ReplicatorPeer
 	cliFct        rpc.ClientStreamFactory
 	stateMgr      storage.StateManager
+	deleteHandler DeleteHandler
 
 	mutex sync.Mutex
 }
@@ -76,6 +81,7 @@ func NewPartition(ctx context.Context,
 	log queue.FanOutQueue,
 	cliFct rpc.ClientStreamFactory,
 	stateMgr storage.StateManager,
+	deleteHandler DeleteHandler,
 ) Partition {
 	return &partition{
 		ctx:           ctx,
@@ -85,6 +91,7 @@ func NewPartition(ctx context.Context,
 		currentNodeID: currentNodeID,
 		cliFct:        cliFct,
 		stateMgr:      stateMgr,
+		deleteHandler: deleteHandler,
 		peers:         make(map[string]ReplicatorPeer),
 	}
 }
@@ -110,6 +117,37 @@ func (p *partition) ResetReplicaIndex(idx int64) {
 	p.log.SetAppendSeq(idx)
 }
 
+// IsReplicated returns if all messages of writeTask ahead log are acked by replicas.
+func (p *partition) IsReplicated() bool {
+	lastSeq := p.log.HeadSeq() - 1
+	if lastSeq < 0 {
+		// no message appended
+		return true
+	}
+	for _, name := range p.log.FanOutNames() {
+		fo, err := p.log.GetOrCreateFanOut(name)
+		if err != nil {
+			return false
+		}
+		if fo.TailSeq() < lastSeq {
+			return false
+		}
+	}
+	return true
+}
+
+// ReplicaState returns the replication state of all replica peers.
+func (p *partition) ReplicaState() []models.ReplicaPeerState {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	var states []models.ReplicaPeerState
+	for _, peer := range p.peers {
+		states = append(states, peer.ReplicaState())
+	}
+	return states
+}
+
 // WriteLog writes msg that leader send replica msg.
 func (p *partition) WriteLog(msg []byte) error {
 	if len(msg) == 0 {
@@ -188,10 +226,10 @@ func (p *partition) buildReplica(leader models.NodeID, replica models.NodeID) {
 	}
 	if replica == p.currentNodeID {
 		// local replicator
-		replicator = newLocalReplicatorFn(&channel, p.shard)
+		replicator = newLocalReplicatorFn(&channel, p.shard, p.deleteHandler)
 	} else {
 		// build remote replicator
-		replicator = newRemoteReplicatorFn(p.ctx, &channel, p.stateMgr, p.cliFct)
+		replicator = newRemoteReplicatorFn(p.ctx, &channel, p.shard, p.stateMgr, p.cliFct)
 	}
 
 	// startup replicator peer
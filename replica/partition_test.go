@@ -43,17 +43,17 @@ func TestPartition_BuildReplicaRelation(t *testing.T) {
 	shard := tsdb.NewMockShard(ctrl)
 	shard.EXPECT().DatabaseName().Return("test").AnyTimes()
 	r.EXPECT().String().Return("test").AnyTimes()
-	newLocalReplicatorFn = func(_ *ReplicatorChannel, _ tsdb.Shard) Replicator {
+	newLocalReplicatorFn = func(_ *ReplicatorChannel, _ tsdb.Shard, _ DeleteHandler) Replicator {
 		return r
 	}
-	newRemoteReplicatorFn = func(_ context.Context, _ *ReplicatorChannel,
+	newRemoteReplicatorFn = func(_ context.Context, _ *ReplicatorChannel, _ tsdb.Shard,
 		_ storage.StateManager, _ rpc.ClientStreamFactory) Replicator {
 		return r
 	}
 
 	log := queue.NewMockFanOutQueue(ctrl)
 	log.EXPECT().GetOrCreateFanOut(gomock.Any()).Return(nil, nil).AnyTimes()
-	p := NewPartition(context.TODO(), 1, shard, 1, log, nil, nil)
+	p := NewPartition(context.TODO(), 1, shard, 1, log, nil, nil, nil)
 	err := p.BuildReplicaForLeader(2, []models.NodeID{1, 2, 3})
 	assert.Error(t, err)
 
@@ -79,17 +79,17 @@ func TestPartition_BuildReplicaForFollower(t *testing.T) {
 	shard := tsdb.NewMockShard(ctrl)
 	shard.EXPECT().DatabaseName().Return("test").AnyTimes()
 	r.EXPECT().String().Return("test").AnyTimes()
-	newLocalReplicatorFn = func(_ *ReplicatorChannel, _ tsdb.Shard) Replicator {
+	newLocalReplicatorFn = func(_ *ReplicatorChannel, _ tsdb.Shard, _ DeleteHandler) Replicator {
 		return r
 	}
-	newRemoteReplicatorFn = func(_ context.Context, _ *ReplicatorChannel,
+	newRemoteReplicatorFn = func(_ context.Context, _ *ReplicatorChannel, _ tsdb.Shard,
 		_ storage.StateManager, _ rpc.ClientStreamFactory) Replicator {
 		return r
 	}
 
 	log := queue.NewMockFanOutQueue(ctrl)
 	log.EXPECT().GetOrCreateFanOut(gomock.Any()).Return(nil, nil).AnyTimes()
-	p := NewPartition(context.TODO(), 1, shard, 1, log, nil, nil)
+	p := NewPartition(context.TODO(), 1, shard, 1, log, nil, nil, nil)
 	err := p.BuildReplicaForFollower(2, 2)
 	assert.Error(t, err)
 
@@ -111,16 +111,16 @@ func TestPartition_Close(t *testing.T) {
 	l := queue.NewMockFanOutQueue(ctrl)
 	l.EXPECT().GetOrCreateFanOut(gomock.Any()).Return(nil, nil).AnyTimes()
 	r.EXPECT().String().Return("test").AnyTimes()
-	newLocalReplicatorFn = func(_ *ReplicatorChannel, _ tsdb.Shard) Replicator {
+	newLocalReplicatorFn = func(_ *ReplicatorChannel, _ tsdb.Shard, _ DeleteHandler) Replicator {
 		return r
 	}
-	newRemoteReplicatorFn = func(_ context.Context, _ *ReplicatorChannel,
+	newRemoteReplicatorFn = func(_ context.Context, _ *ReplicatorChannel, _ tsdb.Shard,
 		_ storage.StateManager, _ rpc.ClientStreamFactory) Replicator {
 		return r
 	}
 
 	l.EXPECT().Close().MaxTimes(2)
-	p := NewPartition(context.TODO(), 1, shard, 1, l, nil, nil)
+	p := NewPartition(context.TODO(), 1, shard, 1, l, nil, nil, nil)
 	err := p.Close()
 	assert.NoError(t, err)
 	r.EXPECT().IsReady().Return(false).AnyTimes()
@@ -136,7 +136,7 @@ func TestPartition_WriteLog(t *testing.T) {
 		ctrl.Finish()
 	}()
 	l := queue.NewMockFanOutQueue(ctrl)
-	p := NewPartition(context.TODO(), 1, nil, 1, l, nil, nil)
+	p := NewPartition(context.TODO(), 1, nil, 1, l, nil, nil, nil)
 	l.EXPECT().Put(gomock.Any()).Return(fmt.Errorf("err"))
 	err := p.WriteLog([]byte{1})
 	assert.Error(t, err)
@@ -151,7 +151,7 @@ func TestPartition_ReplicaLog(t *testing.T) {
 		ctrl.Finish()
 	}()
 	l := queue.NewMockFanOutQueue(ctrl)
-	p := NewPartition(context.TODO(), 1, nil, 1, l, nil, nil)
+	p := NewPartition(context.TODO(), 1, nil, 1, l, nil, nil, nil)
 	// case 1: replica idx err
 	l.EXPECT().HeadSeq().Return(int64(8))
 	idx, err := p.ReplicaLog(10, []byte{1})
@@ -172,3 +172,40 @@ func TestPartition_ReplicaLog(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, idx, int64(10))
 }
+
+func TestPartition_IsReplicated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	l := queue.NewMockFanOutQueue(ctrl)
+	fo := queue.NewMockFanOut(ctrl)
+	p := NewPartition(context.TODO(), 1, nil, 1, l, nil, nil, nil)
+	// case 1: no message
+	l.EXPECT().HeadSeq().Return(int64(0))
+	assert.True(t, p.IsReplicated())
+	// case 2: get fan out err
+	l.EXPECT().HeadSeq().Return(int64(10)).AnyTimes()
+	l.EXPECT().FanOutNames().Return([]string{"1_2"}).AnyTimes()
+	l.EXPECT().GetOrCreateFanOut("1_2").Return(nil, fmt.Errorf("err"))
+	assert.False(t, p.IsReplicated())
+	// case 3: not acked
+	l.EXPECT().GetOrCreateFanOut("1_2").Return(fo, nil).AnyTimes()
+	fo.EXPECT().TailSeq().Return(int64(8))
+	assert.False(t, p.IsReplicated())
+	// case 4: all acked
+	fo.EXPECT().TailSeq().Return(int64(9))
+	assert.True(t, p.IsReplicated())
+}
+
+func TestPartition_ReplicaState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p := NewPartition(context.TODO(), 1, nil, 1, nil, nil, nil, nil)
+	assert.Empty(t, p.ReplicaState())
+	peer := NewMockReplicatorPeer(ctrl)
+	p.(*partition).peers["[1->2]"] = peer
+	state := models.ReplicaPeerState{ReplicaState: models.ReplicaState{Leader: 1, Follower: 2}}
+	peer.EXPECT().ReplicaState().Return(state)
+	assert.Equal(t, []models.ReplicaPeerState{state}, p.ReplicaState())
+}
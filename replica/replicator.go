@@ -20,6 +20,11 @@ package replica
 import (
 	"fmt"
 	"strconv"
+
+	"go.uber.org/atomic"
+
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/timeutil"
 )
 
 //go:generate mockgen -source=./replicator.go -destination=./replicator_mock.go -package=replica
@@ -30,6 +35,8 @@ const (
 	ReplicatorInitState ReplicatorState = iota
 	ReplicatorReadyState
 	ReplicatorFailureState
+	// ReplicatorCatchUpState represents remote replica is catching up by shard snapshot
+	ReplicatorCatchUpState
 )
 
 type Replicator interface {
@@ -48,10 +55,14 @@ type Replicator interface {
 	ResetReplicaIndex(idx int64) error
 	ResetAppendIndex(idx int64)
 	SetAckIndex(ackIdx int64)
+	// ReplicaState returns the replication state of replicator.
+	ReplicaState() models.ReplicaPeerState
 }
 
 type replicator struct {
 	channel *ReplicatorChannel
+
+	lastReplicaTime atomic.Int64 // timestamp of last successful replication
 }
 
 func NewReplicator(channel *ReplicatorChannel) Replicator {
@@ -102,6 +113,35 @@ func (r *replicator) SetAckIndex(ackIdx int64) {
 	r.channel.Queue.Ack(ackIdx)
 }
 
+// ReplicaState returns the replication state of replicator.
+func (r *replicator) ReplicaState() models.ReplicaPeerState {
+	return r.replicaState(r.AckIndex())
+}
+
+// replicaState returns the replication state, lag is calculated by the index which is replicated.
+func (r *replicator) replicaState(replicatedIdx int64) models.ReplicaPeerState {
+	appendIdx := r.AppendIndex()
+	lag := appendIdx - 1 - replicatedIdx
+	if lag < 0 {
+		lag = 0
+	}
+	return models.ReplicaPeerState{
+		ReplicaState:    *r.channel.State,
+		AppendIndex:     appendIdx,
+		ReplicaIndex:    r.ReplicaIndex(),
+		AckIndex:        r.AckIndex(),
+		Lag:             lag,
+		LagBytes:        r.channel.Queue.Queue().DataSize(replicatedIdx),
+		LastReplicaTime: r.lastReplicaTime.Load(),
+		Ready:           true,
+	}
+}
+
+// replicated marks the message replicated successfully.
+func (r *replicator) replicated() {
+	r.lastReplicaTime.Store(timeutil.Now())
+}
+
 func (r *replicator) String() string {
 	return "[" +
 		"database:" + r.channel.State.Database +
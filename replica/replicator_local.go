@@ -21,6 +21,7 @@ import (
 	"github.com/golang/snappy"
 
 	"github.com/lindb/lindb/internal/linmetric"
+	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/series/metric"
 	"github.com/lindb/lindb/tsdb"
@@ -38,9 +39,10 @@ var (
 type localReplicator struct {
 	replicator
 
-	shard     tsdb.Shard
-	logger    *logger.Logger
-	batchRows *metric.StorageBatchRows
+	shard         tsdb.Shard
+	deleteHandler DeleteHandler
+	logger        *logger.Logger
+	batchRows     *metric.StorageBatchRows
 
 	block []byte
 
@@ -53,15 +55,16 @@ type localReplicator struct {
 	}
 }
 
-func NewLocalReplicator(channel *ReplicatorChannel, shard tsdb.Shard) Replicator {
+func NewLocalReplicator(channel *ReplicatorChannel, shard tsdb.Shard, deleteHandler DeleteHandler) Replicator {
 	lr := &localReplicator{
 		replicator: replicator{
 			channel: channel,
 		},
-		shard:     shard,
-		batchRows: metric.NewStorageBatchRows(),
-		logger:    logger.GetLogger("replica", "LocalReplicator"),
-		block:     make([]byte, 256*1024),
+		shard:         shard,
+		deleteHandler: deleteHandler,
+		batchRows:     metric.NewStorageBatchRows(),
+		logger:        logger.GetLogger("replica", "LocalReplicator"),
+		block:         make([]byte, 256*1024),
 	}
 
 	shardStr := shard.ShardID().String()
@@ -70,6 +73,8 @@ func NewLocalReplicator(channel *ReplicatorChannel, shard tsdb.Shard) Replicator
 	lr.statistics.localReplicaBytes = localReplicaBytesVec.WithTagValues(shard.DatabaseName(), shardStr)
 	lr.statistics.localReplicaRows = localReplicaRowsVec.WithTagValues(shard.DatabaseName(), shardStr)
 	lr.statistics.localReplicaSequence = localReplicaSequenceVec.WithTagValues(shard.DatabaseName(), shardStr)
+	// messages before replica index have been applied into shard
+	shard.ApplyReplica(lr.ReplicaIndex()-1, func() {})
 	return lr
 }
 
@@ -100,13 +105,39 @@ func (r *localReplicator) Replica(sequence int64, msg []byte) {
 		r.block = r.block[:0]
 	}()
 
+	// apply message with shard fenced, so the snapshot of shard includes the whole message or nothing
+	r.shard.ApplyReplica(sequence, func() {
+		if isDeleteRecord(r.block) {
+			r.applyDelete()
+			return
+		}
+		r.applyRows()
+	})
+}
+
+// applyDelete applies the delete record into shard.
+func (r *localReplicator) applyDelete() {
+	if err := applyDeleteRecord(r.deleteHandler, r.shard, r.block); err != nil {
+		r.logger.Error("failed applying delete record",
+			logger.String("database", r.shard.DatabaseName()),
+			logger.Int("shardID", int(r.shard.ShardID())),
+			logger.Error(err))
+		return
+	}
+	r.replicated()
+}
+
+// applyRows writes the rows of message into shard.
+func (r *localReplicator) applyRows() {
 	r.batchRows.UnmarshalRows(r.block)
 	r.statistics.localReplicaRows.Add(float64(r.batchRows.Len()))
 
+	success := true
 	familyIterator := r.batchRows.NewFamilyIterator(r.shard.CurrentInterval())
 	for familyIterator.HasNextFamily() {
 		familyTime, rows := familyIterator.NextFamily()
 		if err := r.shard.WriteRows(familyTime, rows); err != nil {
+			success = false
 			r.logger.Error("failed writing family rows",
 				logger.Int64("family", familyTime),
 				logger.Int("rows", len(rows)),
@@ -115,4 +146,13 @@ func (r *localReplicator) Replica(sequence int64, msg []byte) {
 				logger.Error(err))
 		}
 	}
+	if success {
+		r.replicated()
+	}
+}
+
+// ReplicaState returns the replication state of local replicator,
+// local replicator writes message into shard without ack, so lag is calculated by replica index.
+func (r *localReplicator) ReplicaState() models.ReplicaPeerState {
+	return r.replicaState(r.ReplicaIndex() - 1)
 }
@@ -24,9 +24,11 @@ import (
 
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/fasttime"
+	"github.com/lindb/lindb/pkg/queue"
 	"github.com/lindb/lindb/pkg/timeutil"
 	protoMetricsV1 "github.com/lindb/lindb/proto/gen/v1/metrics"
 	"github.com/lindb/lindb/series/metric"
+	"github.com/lindb/lindb/sql/stmt"
 	"github.com/lindb/lindb/tsdb"
 
 	"github.com/golang/mock/gomock"
@@ -45,9 +47,22 @@ func TestLocalReplicator_Replica(t *testing.T) {
 	shard.EXPECT().CurrentInterval().Return(interval).AnyTimes()
 	shard.EXPECT().DatabaseName().Return("test-database").AnyTimes()
 	shard.EXPECT().ShardID().Return(models.ShardID(1)).AnyTimes()
+	var appliedSeq []int64
+	shard.EXPECT().ApplyReplica(gomock.Any(), gomock.Any()).DoAndReturn(func(sequence int64, apply func()) {
+		apply()
+		appliedSeq = append(appliedSeq, sequence)
+	}).AnyTimes()
+	fo := queue.NewMockFanOut(ctrl)
+	fo.EXPECT().HeadSeq().Return(int64(1))
+	var deleteErr error
+	deleteHandler := func(_ tsdb.Shard, deleteStmt *stmt.Delete) error {
+		assert.Equal(t, "cpu", deleteStmt.MetricName)
+		return deleteErr
+	}
 
-	replicator := NewLocalReplicator(&ReplicatorChannel{}, shard)
+	replicator := NewLocalReplicator(&ReplicatorChannel{Queue: fo}, shard, deleteHandler)
 	assert.True(t, replicator.IsReady())
+	assert.Equal(t, []int64{0}, appliedSeq)
 	// bad compressed data
 	replicator.Replica(1, []byte{1, 2, 3})
 	// data ok
@@ -68,8 +83,54 @@ func TestLocalReplicator_Replica(t *testing.T) {
 	dst = snappy.Encode(dst, buf.Bytes())
 	shard.EXPECT().WriteRows(gomock.Any(), gomock.Any()).Return(fmt.Errorf("errj"))
 	replicator.Replica(1, dst)
+	assert.Zero(t, replicator.(*localReplicator).lastReplicaTime.Load())
+	shard.EXPECT().WriteRows(gomock.Any(), gomock.Any()).Return(nil)
+	replicator.Replica(1, dst)
+	assert.NotZero(t, replicator.(*localReplicator).lastReplicaTime.Load())
 	// bad data
 	dst = snappy.Encode(dst, []byte("bad-data"))
 	replicator.Replica(1, dst)
 
+	// delete record
+	record, err := EncodeDeleteRecord(&stmt.Delete{MetricName: "cpu"})
+	assert.NoError(t, err)
+	replicator.(*localReplicator).lastReplicaTime.Store(0)
+	deleteErr = fmt.Errorf("err")
+	replicator.Replica(1, record)
+	assert.Zero(t, replicator.(*localReplicator).lastReplicaTime.Load())
+	deleteErr = nil
+	replicator.Replica(2, record)
+	assert.NotZero(t, replicator.(*localReplicator).lastReplicaTime.Load())
+	assert.Equal(t, int64(2), appliedSeq[len(appliedSeq)-1])
+}
+
+func TestLocalReplicator_ReplicaState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	shard := tsdb.NewMockShard(ctrl)
+	shard.EXPECT().DatabaseName().Return("test-database").AnyTimes()
+	shard.EXPECT().ShardID().Return(models.ShardID(1)).AnyTimes()
+	shard.EXPECT().ApplyReplica(int64(7), gomock.Any())
+	q := queue.NewMockFanOutQueue(ctrl)
+	fo := queue.NewMockFanOut(ctrl)
+	fo.EXPECT().Queue().Return(q).AnyTimes()
+	fo.EXPECT().HeadSeq().Return(int64(8)).Times(3)
+	replicator := NewLocalReplicator(&ReplicatorChannel{
+		State: &models.ReplicaState{Database: "test-database", ShardID: 1, Leader: 1, Follower: 1},
+		Queue: fo,
+	}, shard, nil)
+	q.EXPECT().HeadSeq().Return(int64(10))
+	fo.EXPECT().TailSeq().Return(int64(-1))
+	q.EXPECT().DataSize(int64(7)).Return(int64(100))
+	state := replicator.ReplicaState()
+	assert.Equal(t, models.ReplicaPeerState{
+		ReplicaState: models.ReplicaState{Database: "test-database", ShardID: 1, Leader: 1, Follower: 1},
+		AppendIndex:  10,
+		ReplicaIndex: 8,
+		AckIndex:     -1,
+		Lag:          2,
+		LagBytes:     100,
+		Ready:        true,
+	}, state)
 }
@@ -22,9 +22,12 @@ import (
 
 	"go.uber.org/atomic"
 
+	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/logger"
 )
 
+//go:generate mockgen -source=./replicator_peer.go -destination=./replicator_peer_mock.go -package=replica
+
 // ReplicatorPeer represents wal replica peer.
 // local replicator: from == to.
 // remote replicator: from != to.
@@ -33,6 +36,8 @@ type ReplicatorPeer interface {
 	Startup()
 	// Shutdown shutdown gracefully.
 	Shutdown()
+	// ReplicaState returns the replication state of replicator.
+	ReplicaState() models.ReplicaPeerState
 }
 
 // replicatorPeer implements ReplicatorPeer
@@ -63,6 +68,11 @@ func (r replicatorPeer) Shutdown() {
 	}
 }
 
+// ReplicaState returns the replication state of replicator.
+func (r replicatorPeer) ReplicaState() models.ReplicaPeerState {
+	return r.runner.replicator.ReplicaState()
+}
+
 type replicatorRunner struct {
 	running    *atomic.Bool
 	replicator Replicator
@@ -23,6 +23,9 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/models"
 )
 
 func TestReplicatorPeer(t *testing.T) {
@@ -71,3 +74,14 @@ func TestNewReplicator_runner(t *testing.T) {
 	time.Sleep(100 * time.Millisecond)
 	peer.Shutdown()
 }
+
+func TestReplicatorPeer_ReplicaState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	replicator := NewMockReplicator(ctrl)
+	state := models.ReplicaPeerState{AppendIndex: 10, Ready: true}
+	replicator.EXPECT().ReplicaState().Return(state)
+	peer := NewReplicatorPeer(replicator)
+	assert.Equal(t, state, peer.ReplicaState())
+}
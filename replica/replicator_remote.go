@@ -19,14 +19,18 @@ package replica
 
 import (
 	"context"
+	"fmt"
 	"sync"
 
+	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/coordinator/storage"
+	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/pkg/logger"
 	protoReplicaV1 "github.com/lindb/lindb/proto/gen/v1/replica"
 	"github.com/lindb/lindb/rpc"
+	"github.com/lindb/lindb/tsdb"
 )
 
 type remoteReplicator struct {
@@ -34,6 +38,7 @@ type remoteReplicator struct {
 
 	ctx   context.Context
 	state ReplicatorState
+	shard tsdb.Shard
 
 	//inFlight *InFlightReplica
 
@@ -41,6 +46,8 @@ type remoteReplicator struct {
 	replicaCli    protoReplicaV1.ReplicaServiceClient
 	replicaStream protoReplicaV1.ReplicaService_ReplicaClient
 	stateMgr      storage.StateManager
+	// snapshotFailed represents catching up remote replica by snapshot failure last time
+	snapshotFailed bool
 
 	rwMutex sync.RWMutex
 
@@ -50,6 +57,7 @@ type remoteReplicator struct {
 // NewRemoteReplicator creates remote replicator.
 func NewRemoteReplicator(ctx context.Context,
 	channel *ReplicatorChannel,
+	shard tsdb.Shard,
 	stateMgr storage.StateManager,
 	cliFct rpc.ClientStreamFactory,
 ) Replicator {
@@ -58,6 +66,7 @@ func NewRemoteReplicator(ctx context.Context,
 		replicator: replicator{
 			channel: channel,
 		},
+		shard:    shard,
 		cliFct:   cliFct,
 		stateMgr: stateMgr,
 		state:    ReplicatorInitState,
@@ -67,16 +76,24 @@ func NewRemoteReplicator(ctx context.Context,
 
 // IsReady returns remote replicator channel is ready.
 // 1. state == ready, return true
-// 2. state != ready, do channel init like tcp three-way handshake.
+// 2. state == catch up, shard snapshot is installing in background, return false.
+// 3. state != ready, do channel init like tcp three-way handshake.
 //    a. next remote replica index = current node's replica index, return true.
-//    b. last remote ack index < current node's smallest ack, need reset remote replica index, then return true.
+//    b. last remote ack index < current node's smallest ack, the wal of remote replica needs maybe removed,
+//       catches up remote replica by shard snapshot in background, then return false.
+//       if install snapshot failure, retries it when next checking, only resets remote replica index(data lost)
+//       when reset lost replica is enabled by config explicitly.
 //    c. last remote ack index > current node's append index,
 //   	 need reset current append index/replica index, then return true.
 func (r *remoteReplicator) IsReady() bool {
 	r.rwMutex.Lock()
-	if r.state == ReplicatorReadyState {
+	switch r.state {
+	case ReplicatorReadyState:
 		r.rwMutex.Unlock()
 		return true
+	case ReplicatorCatchUpState:
+		r.rwMutex.Unlock()
+		return false
 	}
 
 	// replicator is not ready, need do init like tcp three-way handshake
@@ -95,12 +112,7 @@ func (r *remoteReplicator) IsReady() bool {
 		return false
 	}
 	r.replicaCli = replicaCli
-	// pass metadata(database/shard state) when create rpc connection.
-	replicaState := encoding.JSONMarshal(&r.channel.State)
-	ctx := rpc.CreateOutgoingContextWithPairs(r.ctx,
-		constants.RPCMetaReplicaState, string(replicaState))
-	r.replicaStream, err = replicaCli.Replica(ctx) //TODO add timeout ??
-	if err != nil {
+	if err := r.createReplicaStream(); err != nil {
 		//TODO add metric
 		r.logger.Warn("create replica service client stream err", logger.Error(err))
 		return false
@@ -124,9 +136,16 @@ func (r *remoteReplicator) IsReady() bool {
 	smallestAckIdx := r.AckIndex()
 	switch {
 	case lastReplicaAckIdx < smallestAckIdx:
-		// maybe new remote replica node add in cluster or remote replica data lost.
+		// maybe new remote replica node add in cluster or remote replica data lost,
+		// the wal which remote replica needs has been acked, maybe removed from queue.
+		if !r.snapshotFailed || !config.GlobalStorageConfig().WAL.ResetLostReplica {
+			// building/sending snapshot is slow, replicator keeps not ready until snapshot installed
+			r.state = ReplicatorCatchUpState
+			go r.catchUpBySnapshot(r.replicaCli, lastReplicaAckIdx)
+			return false
+		}
 		needResetReplicaIdx := smallestAckIdx + 1
-		r.logger.Warn("replica node ack < current node ack, need reset remote replica node's append index",
+		r.logger.Warn("replica node ack < current node ack, need reset remote replica node's append index, data will be lost",
 			logger.String("replicator", r.String()),
 			logger.Int64("lastReplicaAckIdx", lastReplicaAckIdx),
 			logger.Int64("smallestAckIdx", smallestAckIdx),
@@ -145,6 +164,7 @@ func (r *remoteReplicator) IsReady() bool {
 			return false
 		}
 		_ = r.ResetReplicaIndex(nextReplicaIdx)
+		r.snapshotFailed = false
 		r.state = ReplicatorReadyState
 		return true
 	case lastReplicaAckIdx > appendIdx:
@@ -180,6 +200,104 @@ func (r *remoteReplicator) Replica(idx int64, msg []byte) {
 		return
 	}
 	r.SetAckIndex(resp.AckIndex)
+	r.replicated()
+}
+
+// ReplicaState returns the replication state of remote replicator.
+func (r *remoteReplicator) ReplicaState() models.ReplicaPeerState {
+	state := r.replicator.ReplicaState()
+
+	r.rwMutex.RLock()
+	state.Ready = r.state == ReplicatorReadyState
+	r.rwMutex.RUnlock()
+	return state
+}
+
+// catchUpBySnapshot installs the shard snapshot into remote replica without holding lock,
+// then replicates the wal from the message after snapshot index, marks replicator ready if success,
+// else marks replicator failure for retrying when next checking.
+func (r *remoteReplicator) catchUpBySnapshot(replicaCli protoReplicaV1.ReplicaServiceClient, lastReplicaAckIdx int64) {
+	snapshotIdx, err := r.installSnapshot(replicaCli)
+
+	r.rwMutex.Lock()
+	defer r.rwMutex.Unlock()
+
+	if err != nil {
+		r.logger.Warn("install shard snapshot into remote replica err",
+			logger.String("replicator", r.String()),
+			logger.Int64("lastReplicaAckIdx", lastReplicaAckIdx),
+			logger.Error(err))
+		r.snapshotFailed = true
+		r.state = ReplicatorFailureState
+		return
+	}
+	// replicate from the message after snapshot index
+	r.channel.Queue.SetSeq(snapshotIdx)
+	// the wal of remote replica is rebuilt, need re-create replica stream
+	if r.replicaStream != nil {
+		_ = r.replicaStream.CloseSend()
+	}
+	if err := r.createReplicaStream(); err != nil {
+		r.logger.Warn("re-create replica service client stream err after install snapshot",
+			logger.String("replicator", r.String()),
+			logger.Error(err))
+		r.state = ReplicatorFailureState
+		return
+	}
+	r.snapshotFailed = false
+	r.state = ReplicatorReadyState
+	r.logger.Info("catch up remote replica by shard snapshot successfully",
+		logger.String("replicator", r.String()),
+		logger.Int64("lastReplicaAckIdx", lastReplicaAckIdx),
+		logger.Int64("snapshotIdx", snapshotIdx))
+}
+
+// installSnapshot writes a snapshot of shard, sends it to remote replica, returns the snapshot index.
+func (r *remoteReplicator) installSnapshot(replicaCli protoReplicaV1.ReplicaServiceClient) (int64, error) {
+	state := r.channel.State
+	snapshotPath := tsdb.ShardSnapshotPath(state.Database, state.ShardID, fmt.Sprintf("%d_%d", state.Leader, state.Follower))
+	defer func() {
+		if err := removeDir(snapshotPath); err != nil {
+			r.logger.Warn("remove shard snapshot err",
+				logger.String("replicator", r.String()),
+				logger.String("path", snapshotPath),
+				logger.Error(err))
+		}
+	}()
+	// snapshot index is the sequence of message applied last by local replicator when shard is fenced,
+	// messages after snapshot index will be replicated again even if the data of them is included by snapshot.
+	snapshotIdx, err := r.shard.Snapshot(snapshotPath)
+	if err != nil {
+		return 0, err
+	}
+	stream, err := replicaCli.InstallSnapshot(r.newReplicaContext())
+	if err != nil {
+		return 0, err
+	}
+	if err := sendSnapshot(stream, snapshotPath, snapshotIdx); err != nil {
+		return 0, err
+	}
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return 0, err
+	}
+	if resp.AckIndex != snapshotIdx {
+		return 0, fmt.Errorf("remote replica ack index: %d not equals snapshot index: %d", resp.AckIndex, snapshotIdx)
+	}
+	return snapshotIdx, nil
+}
+
+// createReplicaStream creates the replica stream of remote replica.
+func (r *remoteReplicator) createReplicaStream() (err error) {
+	r.replicaStream, err = r.replicaCli.Replica(r.newReplicaContext()) //TODO add timeout ??
+	return err
+}
+
+// newReplicaContext returns the rpc context which passes metadata(database/shard state).
+func (r *remoteReplicator) newReplicaContext() context.Context {
+	replicaState := encoding.JSONMarshal(&r.channel.State)
+	return rpc.CreateOutgoingContextWithPairs(r.ctx,
+		constants.RPCMetaReplicaState, string(replicaState))
 }
 
 // getLastAckIdxFromReplica returns replica replica ack index.
@@ -21,12 +21,16 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/coordinator/storage"
 	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/fileutil"
 	"github.com/lindb/lindb/pkg/queue"
 	protoReplicaV1 "github.com/lindb/lindb/proto/gen/v1/replica"
 	"github.com/lindb/lindb/rpc"
+	"github.com/lindb/lindb/tsdb"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -34,9 +38,12 @@ import (
 
 func TestRemoteReplicator_IsReady(t *testing.T) {
 	ctrl := gomock.NewController(t)
+	tsdbDir := config.GlobalStorageConfig().TSDB.Dir
 	defer func() {
+		config.GlobalStorageConfig().TSDB.Dir = tsdbDir
 		ctrl.Finish()
 	}()
+	config.GlobalStorageConfig().TSDB.Dir = t.TempDir()
 	cliFct := rpc.NewMockClientStreamFactory(ctrl)
 	stateMgr := storage.NewMockStateManager(ctrl)
 	stateMgr.EXPECT().GetLiveNode(gomock.Any()).Return(models.StatefulNode{}, true).AnyTimes()
@@ -44,6 +51,7 @@ func TestRemoteReplicator_IsReady(t *testing.T) {
 	q := queue.NewMockFanOut(ctrl)
 	fq := queue.NewMockFanOutQueue(ctrl)
 	q.EXPECT().Queue().Return(fq).AnyTimes()
+	shard := tsdb.NewMockShard(ctrl)
 	rc := &ReplicatorChannel{
 		State: &models.ReplicaState{
 			Database: "test",
@@ -54,7 +62,7 @@ func TestRemoteReplicator_IsReady(t *testing.T) {
 		Queue: q,
 	}
 
-	r := NewRemoteReplicator(context.TODO(), rc, stateMgr, cliFct)
+	r := NewRemoteReplicator(context.TODO(), rc, nil, stateMgr, cliFct)
 	r1 := r.(*remoteReplicator)
 	// case 1: replicator is ready
 	r1.state = ReplicatorReadyState
@@ -82,29 +90,86 @@ func TestRemoteReplicator_IsReady(t *testing.T) {
 		AckIndex: 10,
 	}, nil)
 	assert.True(t, r.IsReady())
-	// case 6: remote replica ack index < current smallest ack, but reset remote replica index err
-	r = NewRemoteReplicator(context.TODO(), rc, stateMgr, cliFct)
-	fq.EXPECT().HeadSeq().Return(int64(10))
-	q.EXPECT().HeadSeq().Return(int64(12))
-	q.EXPECT().TailSeq().Return(int64(13))
-	replicaCli.EXPECT().GetReplicaAckIndex(gomock.Any(), gomock.Any()).Return(&protoReplicaV1.GetReplicaAckIndexResponse{
-		AckIndex: 10,
-	}, nil)
+	// expectLostReplica expects remote replica ack index < current smallest ack
+	expectLostReplica := func() {
+		fq.EXPECT().HeadSeq().Return(int64(10))
+		q.EXPECT().HeadSeq().Return(int64(12))
+		q.EXPECT().TailSeq().Return(int64(13))
+		replicaCli.EXPECT().GetReplicaAckIndex(gomock.Any(), gomock.Any()).Return(&protoReplicaV1.GetReplicaAckIndexResponse{
+			AckIndex: 10,
+		}, nil)
+	}
+	waitState := func(r Replicator, state ReplicatorState) {
+		r1 := r.(*remoteReplicator)
+		assert.Eventually(t, func() bool {
+			r1.rwMutex.RLock()
+			defer r1.rwMutex.RUnlock()
+			return r1.state == state
+		}, time.Second, time.Millisecond)
+	}
+	// case 6: remote replica ack index < current smallest ack, install snapshot in background
+	r = NewRemoteReplicator(context.TODO(), rc, shard, stateMgr, cliFct)
+	expectLostReplica()
+	snapshotCh := make(chan struct{})
+	shard.EXPECT().Snapshot(gomock.Any()).DoAndReturn(func(targetPath string) (int64, error) {
+		<-snapshotCh
+		return 0, fmt.Errorf("err")
+	})
+	assert.False(t, r.IsReady())
+	// not ready when installing snapshot
+	assert.False(t, r.IsReady())
+	waitState(r, ReplicatorCatchUpState)
+	close(snapshotCh)
+	// case 7: install snapshot err, retry install snapshot instead of resetting remote replica index
+	waitState(r, ReplicatorFailureState)
+	expectLostReplica()
+	shard.EXPECT().Snapshot(gomock.Any()).Return(int64(0), fmt.Errorf("err"))
+	assert.False(t, r.IsReady())
+	waitState(r, ReplicatorFailureState)
+	// case 8: reset lost replica enabled explicitly, reset remote replica index err
+	config.GlobalStorageConfig().WAL.ResetLostReplica = true
+	defer func() {
+		config.GlobalStorageConfig().WAL.ResetLostReplica = false
+	}()
+	expectLostReplica()
 	replicaCli.EXPECT().Reset(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("err"))
 	assert.False(t, r.IsReady())
-	// case 7: remote replica ack index < current smallest ack, reset success
-	r = NewRemoteReplicator(context.TODO(), rc, stateMgr, cliFct)
-	fq.EXPECT().HeadSeq().Return(int64(10))
-	q.EXPECT().HeadSeq().Return(int64(12))
-	q.EXPECT().TailSeq().Return(int64(13))
-	replicaCli.EXPECT().GetReplicaAckIndex(gomock.Any(), gomock.Any()).Return(&protoReplicaV1.GetReplicaAckIndexResponse{
-		AckIndex: 10,
-	}, nil)
+	// case 9: reset lost replica enabled explicitly, reset success
+	expectLostReplica()
 	replicaCli.EXPECT().Reset(gomock.Any(), gomock.Any()).Return(nil, nil)
 	q.EXPECT().SetHeadSeq(int64(11))
 	assert.True(t, r.IsReady())
-	// case 8: remote replica ack index > current append index, maybe leader lost data.
-	r = NewRemoteReplicator(context.TODO(), rc, stateMgr, cliFct)
+	// case 10: install snapshot successfully
+	r = NewRemoteReplicator(context.TODO(), rc, shard, stateMgr, cliFct)
+	expectLostReplica()
+	shard.EXPECT().Snapshot(gomock.Any()).DoAndReturn(func(targetPath string) (int64, error) {
+		return 18, fileutil.MkDirIfNotExist(targetPath)
+	}).AnyTimes()
+	snapshotCli := protoReplicaV1.NewMockReplicaService_InstallSnapshotClient(ctrl)
+	replicaCli.EXPECT().InstallSnapshot(gomock.Any()).Return(snapshotCli, nil)
+	snapshotCli.EXPECT().Send(gomock.Any()).Return(nil).AnyTimes()
+	snapshotCli.EXPECT().CloseAndRecv().Return(&protoReplicaV1.SnapshotResponse{AckIndex: 18}, nil)
+	q.EXPECT().SetSeq(int64(18))
+	assert.False(t, r.IsReady())
+	waitState(r, ReplicatorReadyState)
+	assert.True(t, r.IsReady())
+	assert.False(t, fileutil.Exist(tsdb.ShardSnapshotPath("test", 0, "1_2")))
+	// case 11: remote replica ack index not equals snapshot index
+	r = NewRemoteReplicator(context.TODO(), rc, shard, stateMgr, cliFct)
+	expectLostReplica()
+	replicaCli.EXPECT().InstallSnapshot(gomock.Any()).Return(snapshotCli, nil)
+	snapshotCli.EXPECT().CloseAndRecv().Return(&protoReplicaV1.SnapshotResponse{AckIndex: 10}, nil)
+	assert.False(t, r.IsReady())
+	waitState(r, ReplicatorFailureState)
+	assert.True(t, r.(*remoteReplicator).snapshotFailed)
+	// case 12: create install snapshot stream err
+	r = NewRemoteReplicator(context.TODO(), rc, shard, stateMgr, cliFct)
+	expectLostReplica()
+	replicaCli.EXPECT().InstallSnapshot(gomock.Any()).Return(nil, fmt.Errorf("err"))
+	assert.False(t, r.IsReady())
+	waitState(r, ReplicatorFailureState)
+	// case 13: remote replica ack index > current append index, maybe leader lost data.
+	r = NewRemoteReplicator(context.TODO(), rc, nil, stateMgr, cliFct)
 	fq.EXPECT().HeadSeq().Return(int64(5))
 	q.EXPECT().HeadSeq().Return(int64(12))
 	q.EXPECT().TailSeq().Return(int64(9))
@@ -134,7 +199,7 @@ func TestRemoteReplicator_Replica(t *testing.T) {
 		Queue: q,
 	}
 
-	r := NewRemoteReplicator(context.TODO(), rc, stateMgr, cliFct)
+	r := NewRemoteReplicator(context.TODO(), rc, nil, stateMgr, cliFct)
 	r1 := r.(*remoteReplicator)
 	cli := protoReplicaV1.NewMockReplicaService_ReplicaClient(ctrl)
 	r1.replicaStream = cli
@@ -152,4 +217,31 @@ func TestRemoteReplicator_Replica(t *testing.T) {
 	}, nil)
 	q.EXPECT().Ack(int64(1))
 	r.Replica(1, []byte{})
+	assert.NotZero(t, r1.lastReplicaTime.Load())
+}
+
+func TestRemoteReplicator_ReplicaState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fq := queue.NewMockFanOutQueue(ctrl)
+	q := queue.NewMockFanOut(ctrl)
+	q.EXPECT().Queue().Return(fq).AnyTimes()
+	fq.EXPECT().HeadSeq().Return(int64(10)).AnyTimes()
+	q.EXPECT().HeadSeq().Return(int64(10)).AnyTimes()
+	q.EXPECT().TailSeq().Return(int64(9)).AnyTimes()
+	fq.EXPECT().DataSize(int64(9)).Return(int64(0)).AnyTimes()
+	rc := &ReplicatorChannel{
+		State: &models.ReplicaState{Database: "test", ShardID: 0, Leader: 1, Follower: 2},
+		Queue: q,
+	}
+	r := NewRemoteReplicator(context.TODO(), rc, nil, nil, nil)
+	// case 1: not ready
+	state := r.ReplicaState()
+	assert.False(t, state.Ready)
+	assert.Equal(t, int64(9), state.AckIndex)
+	assert.Zero(t, state.Lag)
+	// case 2: ready
+	r.(*remoteReplicator).state = ReplicatorReadyState
+	assert.True(t, r.ReplicaState().Ready)
 }
@@ -21,7 +21,9 @@ import (
 	"testing"
 
 	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/queue"
 
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -36,3 +38,38 @@ func TestReplicator_String(t *testing.T) {
 	})
 	assert.Equal(t, "[database:test,shard:1,from(leader):1,to(follower):2]", r.String())
 }
+
+func TestReplicator_ReplicaState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	q := queue.NewMockFanOutQueue(ctrl)
+	fo := queue.NewMockFanOut(ctrl)
+	fo.EXPECT().Queue().Return(q).AnyTimes()
+	r := NewReplicator(&ReplicatorChannel{
+		State: &models.ReplicaState{Database: "test", ShardID: 1, Leader: 1, Follower: 2},
+		Queue: fo,
+	})
+	// case 1: lag
+	r.(*replicator).replicated()
+	q.EXPECT().HeadSeq().Return(int64(10))
+	fo.EXPECT().HeadSeq().Return(int64(9))
+	fo.EXPECT().TailSeq().Return(int64(5)).Times(2)
+	q.EXPECT().DataSize(int64(5)).Return(int64(40))
+	state := r.ReplicaState()
+	assert.Equal(t, int64(10), state.AppendIndex)
+	assert.Equal(t, int64(9), state.ReplicaIndex)
+	assert.Equal(t, int64(5), state.AckIndex)
+	assert.Equal(t, int64(4), state.Lag)
+	assert.Equal(t, int64(40), state.LagBytes)
+	assert.NotZero(t, state.LastReplicaTime)
+	assert.True(t, state.Ready)
+	// case 2: no lag
+	q.EXPECT().HeadSeq().Return(int64(0))
+	fo.EXPECT().HeadSeq().Return(int64(0))
+	fo.EXPECT().TailSeq().Return(int64(0)).Times(2)
+	q.EXPECT().DataSize(int64(0)).Return(int64(0))
+	state = r.ReplicaState()
+	assert.Zero(t, state.Lag)
+	assert.Zero(t, state.LagBytes)
+}
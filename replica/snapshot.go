@@ -0,0 +1,135 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package replica
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lindb/lindb/pkg/fileutil"
+	protoReplicaV1 "github.com/lindb/lindb/proto/gen/v1/replica"
+)
+
+// for testing
+var (
+	snapshotChunkSize = 1024 * 1024 // 1MB
+	mkDirIfNotExist   = fileutil.MkDirIfNotExist
+)
+
+// sendSnapshot sends the dirs/files of shard snapshot to remote replica by stream,
+// the data of file is split into chunks, each chunk is sent in one request.
+func sendSnapshot(stream protoReplicaV1.ReplicaService_InstallSnapshotClient, snapshotPath string, snapshotIdx int64) error {
+	return filepath.Walk(snapshotPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(snapshotPath, path)
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return stream.Send(&protoReplicaV1.SnapshotRequest{
+				SnapshotIndex: snapshotIdx,
+				Path:          relPath,
+				Dir:           true,
+			})
+		}
+		return sendSnapshotFile(stream, path, relPath, snapshotIdx)
+	})
+}
+
+// sendSnapshotFile sends the data of file by chunks, empty file is sent with an empty chunk.
+func sendSnapshotFile(stream protoReplicaV1.ReplicaService_InstallSnapshotClient,
+	path, relPath string, snapshotIdx int64,
+) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	chunk := make([]byte, snapshotChunkSize)
+	sent := false
+	for {
+		n, err := f.Read(chunk)
+		if n > 0 || (!sent && err == io.EOF) {
+			if sendErr := stream.Send(&protoReplicaV1.SnapshotRequest{
+				SnapshotIndex: snapshotIdx,
+				Path:          relPath,
+				Data:          chunk[:n],
+			}); sendErr != nil {
+				return sendErr
+			}
+			sent = true
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// ReceiveSnapshot receives the dirs/files of shard snapshot from replica leader, writes them into snapshot path,
+// returns the snapshot index which is the last wal index included by snapshot.
+func ReceiveSnapshot(stream protoReplicaV1.ReplicaService_InstallSnapshotServer, snapshotPath string) (int64, error) {
+	if err := removeDir(snapshotPath); err != nil {
+		return 0, err
+	}
+	if err := mkDirIfNotExist(snapshotPath); err != nil {
+		return 0, err
+	}
+	snapshotIdx := int64(-1)
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return snapshotIdx, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		snapshotIdx = req.SnapshotIndex
+		if err := writeSnapshotChunk(snapshotPath, req); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// writeSnapshotChunk creates the dir or appends the chunk into file under snapshot path.
+func writeSnapshotChunk(snapshotPath string, req *protoReplicaV1.SnapshotRequest) error {
+	if filepath.IsAbs(req.Path) || strings.HasPrefix(filepath.Clean(req.Path), "..") {
+		return fmt.Errorf("invalid snapshot path: %s", req.Path)
+	}
+	path := filepath.Join(snapshotPath, req.Path)
+	if req.Dir {
+		return mkDirIfNotExist(path)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(req.Data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,142 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package replica
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/fileutil"
+	protoReplicaV1 "github.com/lindb/lindb/proto/gen/v1/replica"
+)
+
+func TestSnapshot_Send_Receive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		snapshotChunkSize = 1024 * 1024
+		ctrl.Finish()
+	}()
+	snapshotChunkSize = 4
+
+	dir := t.TempDir()
+	source := filepath.Join(dir, "source")
+	assert.NoError(t, fileutil.MkDirIfNotExist(filepath.Join(source, "shard", "1", "index")))
+	assert.NoError(t, fileutil.MkDirIfNotExist(filepath.Join(source, "meta")))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(source, "BACKUP"), []byte("database = \"test\""), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(source, "shard", "1", "index", "000001.sst"), []byte("1234567890"), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(source, "shard", "1", "LOCK"), nil, 0644))
+
+	// collect requests from send stream
+	var requests []*protoReplicaV1.SnapshotRequest
+	cli := protoReplicaV1.NewMockReplicaService_InstallSnapshotClient(ctrl)
+	cli.EXPECT().Send(gomock.Any()).DoAndReturn(func(req *protoReplicaV1.SnapshotRequest) error {
+		data := make([]byte, len(req.Data))
+		copy(data, req.Data)
+		requests = append(requests, &protoReplicaV1.SnapshotRequest{
+			SnapshotIndex: req.SnapshotIndex,
+			Path:          req.Path,
+			Dir:           req.Dir,
+			Data:          data,
+		})
+		return nil
+	}).AnyTimes()
+	assert.NoError(t, sendSnapshot(cli, source, 10))
+	// source not exist
+	assert.Error(t, sendSnapshot(cli, filepath.Join(dir, "not_exist"), 10))
+
+	// replay requests into receive stream
+	server := protoReplicaV1.NewMockReplicaService_InstallSnapshotServer(ctrl)
+	for _, req := range requests {
+		server.EXPECT().Recv().Return(req, nil)
+	}
+	server.EXPECT().Recv().Return(nil, io.EOF)
+	target := filepath.Join(dir, "target")
+	snapshotIdx, err := ReceiveSnapshot(server, target)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), snapshotIdx)
+	data, err := ioutil.ReadFile(filepath.Join(target, "shard", "1", "index", "000001.sst"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("1234567890"), data)
+	data, err = ioutil.ReadFile(filepath.Join(target, "BACKUP"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("database = \"test\""), data)
+	info, err := os.Stat(filepath.Join(target, "shard", "1", "LOCK"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), info.Size())
+	assert.True(t, fileutil.Exist(filepath.Join(target, "meta")))
+}
+
+func TestSnapshot_Send_Err(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir := t.TempDir()
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "data"), []byte("123"), 0644))
+	cli := protoReplicaV1.NewMockReplicaService_InstallSnapshotClient(ctrl)
+	// case 1: send dir err
+	cli.EXPECT().Send(gomock.Any()).Return(fmt.Errorf("err"))
+	assert.Error(t, sendSnapshot(cli, dir, 1))
+	// case 2: send file err
+	cli.EXPECT().Send(gomock.Any()).Return(nil)
+	cli.EXPECT().Send(gomock.Any()).Return(fmt.Errorf("err"))
+	assert.Error(t, sendSnapshot(cli, dir, 1))
+}
+
+func TestSnapshot_Receive_Err(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		removeDir = fileutil.RemoveDir
+		mkDirIfNotExist = fileutil.MkDirIfNotExist
+		ctrl.Finish()
+	}()
+
+	target := filepath.Join(t.TempDir(), "target")
+	server := protoReplicaV1.NewMockReplicaService_InstallSnapshotServer(ctrl)
+	// case 1: remove old snapshot err
+	removeDir = func(path string) error {
+		return fmt.Errorf("err")
+	}
+	_, err := ReceiveSnapshot(server, target)
+	assert.Error(t, err)
+	removeDir = fileutil.RemoveDir
+	// case 2: make snapshot dir err
+	mkDirIfNotExist = func(path string) error {
+		return fmt.Errorf("err")
+	}
+	_, err = ReceiveSnapshot(server, target)
+	assert.Error(t, err)
+	mkDirIfNotExist = fileutil.MkDirIfNotExist
+	// case 3: recv err
+	server.EXPECT().Recv().Return(nil, fmt.Errorf("err"))
+	_, err = ReceiveSnapshot(server, target)
+	assert.Error(t, err)
+	// case 4: invalid path
+	server.EXPECT().Recv().Return(&protoReplicaV1.SnapshotRequest{Path: "../data"}, nil)
+	_, err = ReceiveSnapshot(server, target)
+	assert.Error(t, err)
+	// case 5: write file err, parent dir not exist
+	server.EXPECT().Recv().Return(&protoReplicaV1.SnapshotRequest{Path: "a/data"}, nil)
+	_, err = ReceiveSnapshot(server, target)
+	assert.Error(t, err)
+}
@@ -21,12 +21,14 @@ import (
 	"context"
 	"errors"
 	"path"
+	"sort"
 	"strconv"
 	"sync"
 
 	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/coordinator/storage"
 	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/fileutil"
 	"github.com/lindb/lindb/pkg/queue"
 	"github.com/lindb/lindb/rpc"
 	"github.com/lindb/lindb/tsdb"
@@ -38,6 +40,7 @@ import (
 var (
 	newFanOutQueue   = queue.NewFanOutQueue
 	newWriteAheadLog = NewWriteAheadLog
+	removeDir        = fileutil.RemoveDir
 )
 
 // WriteAheadLogManager represents manage all writeTask ahead log.
@@ -45,6 +48,15 @@ type WriteAheadLogManager interface {
 	// GetOrCreateLog returns writeTask ahead log for database,
 	// if exist returns it, else creates a new log.
 	GetOrCreateLog(database string) WriteAheadLog
+	// DropLog closes the writeTask ahead log of database, then removes the log files.
+	DropLog(database string) error
+	// DropPartition closes the partition of database's writeTask ahead log, then removes the log files of partition.
+	DropPartition(database string, shardID models.ShardID) error
+	// IsReplicated returns if all writeTask ahead logs are acked by replicas.
+	IsReplicated() bool
+	// ReplicaState returns the replication state of all replica peers,
+	// sorted by database/shard/leader/follower.
+	ReplicaState() []models.ReplicaPeerState
 }
 
 // WriteAheadLog represents writeTask ahead log underlying fan out queue.
@@ -52,6 +64,14 @@ type WriteAheadLog interface {
 	// GetOrCreatePartition returns a partition of writeTask ahead log.
 	// if exist returns it, else create a new partition.
 	GetOrCreatePartition(shardID models.ShardID) (Partition, error)
+	// DropPartition closes the partition of writeTask ahead log, then removes the log files of partition.
+	DropPartition(shardID models.ShardID) error
+	// IsReplicated returns if all partitions are acked by replicas.
+	IsReplicated() bool
+	// ReplicaState returns the replication state of all replica peers of partitions.
+	ReplicaState() []models.ReplicaPeerState
+	// Close closes all partitions of writeTask ahead log.
+	Close() error
 }
 
 // writeAheadLogManager implements WriteAheadLogManager.
@@ -63,6 +83,7 @@ type writeAheadLogManager struct {
 	engine        tsdb.Engine
 	cliFct        rpc.ClientStreamFactory
 	stateMgr      storage.StateManager
+	deleteHandler DeleteHandler
 
 	mutex sync.Mutex
 }
@@ -75,6 +96,7 @@ func NewWriteAheadLogManager(
 	engine tsdb.Engine,
 	cliFct rpc.ClientStreamFactory,
 	stateMgr storage.StateManager,
+	deleteHandler DeleteHandler,
 ) WriteAheadLogManager {
 	return &writeAheadLogManager{
 		ctx:           ctx,
@@ -83,6 +105,7 @@ func NewWriteAheadLogManager(
 		engine:        engine,
 		cliFct:        cliFct,
 		stateMgr:      stateMgr,
+		deleteHandler: deleteHandler,
 
 		databaseLogs: make(map[string]WriteAheadLog),
 	}
@@ -99,11 +122,76 @@ func (w *writeAheadLogManager) GetOrCreateLog(database string) WriteAheadLog {
 		return log
 	}
 	// create new, then put in cache
-	log = newWriteAheadLog(w.ctx, w.cfg, w.currentNodeID, database, w.engine, w.cliFct, w.stateMgr)
+	log = newWriteAheadLog(w.ctx, w.cfg, w.currentNodeID, database, w.engine, w.cliFct, w.stateMgr, w.deleteHandler)
 	w.databaseLogs[database] = log
 	return log
 }
 
+// DropLog closes the writeTask ahead log of database, then removes the log files.
+func (w *writeAheadLogManager) DropLog(database string) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if log, ok := w.databaseLogs[database]; ok {
+		delete(w.databaseLogs, database)
+		if err := log.Close(); err != nil {
+			return err
+		}
+	}
+	return removeDir(path.Join(w.cfg.Dir, database))
+}
+
+// DropPartition closes the partition of database's writeTask ahead log, then removes the log files of partition.
+func (w *writeAheadLogManager) DropPartition(database string, shardID models.ShardID) error {
+	w.mutex.Lock()
+	log, ok := w.databaseLogs[database]
+	w.mutex.Unlock()
+
+	if ok {
+		return log.DropPartition(shardID)
+	}
+	return removeDir(path.Join(w.cfg.Dir, database, strconv.Itoa(int(shardID))))
+}
+
+// IsReplicated returns if all writeTask ahead logs are acked by replicas.
+func (w *writeAheadLogManager) IsReplicated() bool {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	for _, log := range w.databaseLogs {
+		if !log.IsReplicated() {
+			return false
+		}
+	}
+	return true
+}
+
+// ReplicaState returns the replication state of all replica peers,
+// sorted by database/shard/leader/follower.
+func (w *writeAheadLogManager) ReplicaState() []models.ReplicaPeerState {
+	w.mutex.Lock()
+	var states []models.ReplicaPeerState
+	for _, log := range w.databaseLogs {
+		states = append(states, log.ReplicaState()...)
+	}
+	w.mutex.Unlock()
+
+	sort.Slice(states, func(i, j int) bool {
+		si, sj := states[i], states[j]
+		switch {
+		case si.Database != sj.Database:
+			return si.Database < sj.Database
+		case si.ShardID != sj.ShardID:
+			return si.ShardID < sj.ShardID
+		case si.Leader != sj.Leader:
+			return si.Leader < sj.Leader
+		default:
+			return si.Follower < sj.Follower
+		}
+	})
+	return states
+}
+
 // writeAheadLog implements WriteAheadLog.
 type writeAheadLog struct {
 	ctx           context.Context
@@ -114,6 +202,7 @@ type writeAheadLog struct {
 	engine        tsdb.Engine
 	cliFct        rpc.ClientStreamFactory
 	stateMgr      storage.StateManager
+	deleteHandler DeleteHandler
 
 	mutex sync.Mutex
 }
@@ -126,6 +215,7 @@ func NewWriteAheadLog(ctx context.Context,
 	engine tsdb.Engine,
 	cliFct rpc.ClientStreamFactory,
 	stateMgr storage.StateManager,
+	deleteHandler DeleteHandler,
 ) WriteAheadLog {
 	return &writeAheadLog{
 		ctx:           ctx,
@@ -135,6 +225,7 @@ func NewWriteAheadLog(ctx context.Context,
 		engine:        engine,
 		cliFct:        cliFct,
 		stateMgr:      stateMgr,
+		deleteHandler: deleteHandler,
 		shardLogs:     make(map[models.ShardID]Partition),
 	}
 }
@@ -160,7 +251,60 @@ func (w *writeAheadLog) GetOrCreatePartition(shardID models.ShardID) (Partition,
 	if err != nil {
 		return nil, err
 	}
-	p = NewPartition(w.ctx, shardID, shard, w.currentNodeID, q, w.cliFct, w.stateMgr)
+	p = NewPartition(w.ctx, shardID, shard, w.currentNodeID, q, w.cliFct, w.stateMgr, w.deleteHandler)
 	w.shardLogs[shardID] = p
 	return p, nil
 }
+
+// DropPartition closes the partition of writeTask ahead log, then removes the log files of partition.
+func (w *writeAheadLog) DropPartition(shardID models.ShardID) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if p, ok := w.shardLogs[shardID]; ok {
+		delete(w.shardLogs, shardID)
+		if err := p.Close(); err != nil {
+			return err
+		}
+	}
+	return removeDir(path.Join(w.cfg.Dir, w.database, strconv.Itoa(int(shardID))))
+}
+
+// IsReplicated returns if all partitions are acked by replicas.
+func (w *writeAheadLog) IsReplicated() bool {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	for _, p := range w.shardLogs {
+		if !p.IsReplicated() {
+			return false
+		}
+	}
+	return true
+}
+
+// ReplicaState returns the replication state of all replica peers of partitions.
+func (w *writeAheadLog) ReplicaState() []models.ReplicaPeerState {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	var states []models.ReplicaPeerState
+	for _, p := range w.shardLogs {
+		states = append(states, p.ReplicaState()...)
+	}
+	return states
+}
+
+// Close closes all partitions of writeTask ahead log.
+func (w *writeAheadLog) Close() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	for shardID, p := range w.shardLogs {
+		if err := p.Close(); err != nil {
+			return err
+		}
+		delete(w.shardLogs, shardID)
+	}
+	return nil
+}
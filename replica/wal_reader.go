@@ -0,0 +1,116 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package replica
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+
+	"github.com/golang/snappy"
+
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/fileutil"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/queue"
+	"github.com/lindb/lindb/series/metric"
+	"github.com/lindb/lindb/tsdb"
+)
+
+// for testing
+var (
+	newQueueFunc = queue.NewQueue
+)
+
+// NewWriteAheadLogRowSource returns the row source which scans the un-acked rows in write ahead log of shard,
+// the corrupted messages are skipped.
+// NOTICE: only for offline repair, write ahead log cannot be opened by running replicas at the same time.
+func NewWriteAheadLogRowSource(cfg config.WAL, database string, shardID models.ShardID) tsdb.RowSource {
+	dirPath := path.Join(cfg.Dir, database, strconv.Itoa(int(shardID)))
+	return func(fn func(row *metric.StorageRow) error) error {
+		if !fileutil.Exist(dirPath) {
+			// no write ahead log for shard
+			return nil
+		}
+		q, err := newQueueFunc(dirPath, cfg.GetDataSizeLimit(), cfg.RemoveTaskInterval.Duration())
+		if err != nil {
+			return err
+		}
+		defer q.Close()
+
+		r := &walRowReader{
+			logger: logger.GetLogger("replica", "WALReader"),
+		}
+		for seq := q.TailSeq() + 1; seq <= q.HeadSeq(); seq++ {
+			msg, err := q.Get(seq)
+			if err != nil {
+				r.logger.Warn("read message from write ahead log failure, skip it",
+					logger.String("path", dirPath), logger.Any("sequence", seq), logger.Error(err))
+				continue
+			}
+			if err := r.read(msg, fn); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// walRowReader decodes the rows of write ahead log message.
+type walRowReader struct {
+	block     []byte
+	batchRows metric.StorageBatchRows
+	logger    *logger.Logger
+}
+
+// read decodes the rows from message, then invokes fn for each row.
+func (r *walRowReader) read(msg []byte, fn func(row *metric.StorageRow) error) (err error) {
+	r.block, err = snappy.Decode(r.block, msg)
+	if err != nil {
+		r.logger.Warn("decompress write ahead log message failure, skip it", logger.Error(err))
+		return nil
+	}
+	if isDeleteRecord(r.block) {
+		// delete record has no rows
+		return nil
+	}
+	if ok := r.unmarshal(); !ok {
+		return nil
+	}
+	rows := r.batchRows.Rows()
+	for idx := range rows {
+		if err := fn(&rows[idx]); err != nil {
+			return fmt.Errorf("rebuild from write ahead log row failure: %w", err)
+		}
+	}
+	return nil
+}
+
+// unmarshal unmarshals the rows from decoded block, returns false if block corrupted.
+func (r *walRowReader) unmarshal() (ok bool) {
+	// unmarshal panics if block is corrupted
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			r.logger.Warn("corrupted flat block in write ahead log, skip it",
+				logger.Int("decoded-length", len(r.block)))
+			ok = false
+		}
+	}()
+	r.batchRows.UnmarshalRows(r.block)
+	return true
+}
@@ -0,0 +1,99 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package replica
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/pkg/ltoml"
+	"github.com/lindb/lindb/pkg/queue"
+	protoMetricsV1 "github.com/lindb/lindb/proto/gen/v1/metrics"
+	"github.com/lindb/lindb/series/metric"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestWriteAheadLogRowSource(t *testing.T) {
+	dir := t.TempDir()
+	defer func() {
+		newQueueFunc = queue.NewQueue
+	}()
+	cfg := config.WAL{Dir: dir, RemoveTaskInterval: ltoml.Duration(time.Minute)}
+	collect := func(source func(fn func(row *metric.StorageRow) error) error) (names []string, err error) {
+		err = source(func(row *metric.StorageRow) error {
+			names = append(names, string(row.Name()))
+			return nil
+		})
+		return
+	}
+
+	// case 1: write ahead log not exist
+	names, err := collect(NewWriteAheadLogRowSource(cfg, "db", 1))
+	assert.NoError(t, err)
+	assert.Empty(t, names)
+
+	q, err := queue.NewQueue(path.Join(dir, "db", "1"), cfg.GetDataSizeLimit(), time.Minute)
+	assert.NoError(t, err)
+	marshal := func(metricNames ...string) []byte {
+		var ml protoMetricsV1.MetricList
+		for _, name := range metricNames {
+			ml.Metrics = append(ml.Metrics, &protoMetricsV1.Metric{
+				Name:      name,
+				Timestamp: 1,
+				SimpleFields: []*protoMetricsV1.SimpleField{
+					{Name: "f1", Type: protoMetricsV1.SimpleFieldType_DELTA_SUM, Value: 1}},
+			})
+		}
+		var buf bytes.Buffer
+		_, _ = metric.NewProtoConverter().MarshalProtoMetricListV1To(ml, &buf)
+		return snappy.Encode(nil, buf.Bytes())
+	}
+	assert.NoError(t, q.Put(marshal("acked")))
+	assert.NoError(t, q.Put([]byte("bad snappy data")))
+	assert.NoError(t, q.Put(snappy.Encode(nil, []byte("bad flat block"))))
+	assert.NoError(t, q.Put(marshal("m1", "m2")))
+	deleteRecord, err := EncodeDeleteRecord(&stmt.Delete{MetricName: "m1"})
+	assert.NoError(t, err)
+	assert.NoError(t, q.Put(deleteRecord))
+	assert.NoError(t, q.Put(marshal("m3")))
+	q.Ack(0)
+	q.Close()
+
+	// case 2: scan un-acked rows, skip corrupted messages and delete records
+	source := NewWriteAheadLogRowSource(cfg, "db", 1)
+	names, err = collect(source)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"m1", "m2", "m3"}, names)
+	// case 3: rebuild row err
+	err = source(func(row *metric.StorageRow) error {
+		return fmt.Errorf("err")
+	})
+	assert.Error(t, err)
+	// case 4: open queue err
+	newQueueFunc = func(dirPath string, dataSizeLimit int64, removeTaskInterval time.Duration) (queue.Queue, error) {
+		return nil, fmt.Errorf("err")
+	}
+	_, err = collect(source)
+	assert.Error(t, err)
+}
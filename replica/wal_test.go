@@ -20,12 +20,14 @@ package replica
 import (
 	"context"
 	"fmt"
+	"path"
 	"testing"
 	"time"
 
 	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/coordinator/storage"
 	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/fileutil"
 	"github.com/lindb/lindb/pkg/queue"
 	"github.com/lindb/lindb/rpc"
 	"github.com/lindb/lindb/tsdb"
@@ -46,10 +48,11 @@ func TestWriteAheadLogManager_GetOrCreateLog(t *testing.T) {
 		engine tsdb.Engine,
 		cliFct rpc.ClientStreamFactory,
 		_ storage.StateManager,
+		_ DeleteHandler,
 	) WriteAheadLog {
 		return NewMockWriteAheadLog(ctrl)
 	}
-	m := NewWriteAheadLogManager(context.TODO(), config.WAL{}, 1, nil, nil, nil)
+	m := NewWriteAheadLogManager(context.TODO(), config.WAL{}, 1, nil, nil, nil, nil)
 	// create new
 	l := m.GetOrCreateLog("test")
 	assert.NotNil(t, l)
@@ -65,7 +68,7 @@ func TestWriteAheadLog_GetOrCreatePartition(t *testing.T) {
 		ctrl.Finish()
 	}()
 	engine := tsdb.NewMockEngine(ctrl)
-	l := NewWriteAheadLog(context.TODO(), config.WAL{}, 1, "test", engine, nil, nil)
+	l := NewWriteAheadLog(context.TODO(), config.WAL{}, 1, "test", engine, nil, nil, nil)
 
 	// case 1: shard not exist
 	engine.EXPECT().GetShard(gomock.Any(), gomock.Any()).Return(nil, false)
@@ -95,3 +98,195 @@ func TestWriteAheadLog_GetOrCreatePartition(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, p)
 }
+
+func TestWriteAheadLogManager_DropLog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		newWriteAheadLog = NewWriteAheadLog
+		removeDir = fileutil.RemoveDir
+		ctrl.Finish()
+	}()
+
+	log := NewMockWriteAheadLog(ctrl)
+	newWriteAheadLog = func(_ context.Context, cfg config.WAL,
+		currentNodeID models.NodeID, database string,
+		engine tsdb.Engine,
+		cliFct rpc.ClientStreamFactory,
+		_ storage.StateManager,
+		_ DeleteHandler,
+	) WriteAheadLog {
+		return log
+	}
+	var removedPath string
+	removeDir = func(dir string) error {
+		removedPath = dir
+		return nil
+	}
+	m := NewWriteAheadLogManager(context.TODO(), config.WAL{Dir: "wal"}, 1, nil, nil, nil, nil)
+	// case 1: log not in memory, only remove files
+	assert.NoError(t, m.DropLog("test"))
+	assert.Equal(t, path.Join("wal", "test"), removedPath)
+	// case 2: close log err
+	m.GetOrCreateLog("test")
+	log.EXPECT().Close().Return(fmt.Errorf("err"))
+	assert.Error(t, m.DropLog("test"))
+	// case 3: drop log successfully
+	m.GetOrCreateLog("test")
+	log.EXPECT().Close().Return(nil)
+	assert.NoError(t, m.DropLog("test"))
+}
+
+func TestWriteAheadLogManager_DropPartition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		newWriteAheadLog = NewWriteAheadLog
+		removeDir = fileutil.RemoveDir
+		ctrl.Finish()
+	}()
+
+	log := NewMockWriteAheadLog(ctrl)
+	newWriteAheadLog = func(_ context.Context, cfg config.WAL,
+		currentNodeID models.NodeID, database string,
+		engine tsdb.Engine,
+		cliFct rpc.ClientStreamFactory,
+		_ storage.StateManager,
+		_ DeleteHandler,
+	) WriteAheadLog {
+		return log
+	}
+	var removedPath string
+	removeDir = func(dir string) error {
+		removedPath = dir
+		return nil
+	}
+	m := NewWriteAheadLogManager(context.TODO(), config.WAL{Dir: "wal"}, 1, nil, nil, nil, nil)
+	// case 1: log not in memory, only remove files of partition
+	assert.NoError(t, m.DropPartition("test", 1))
+	assert.Equal(t, path.Join("wal", "test", "1"), removedPath)
+	// case 2: drop partition of log
+	m.GetOrCreateLog("test")
+	log.EXPECT().DropPartition(models.ShardID(1)).Return(fmt.Errorf("err"))
+	assert.Error(t, m.DropPartition("test", 1))
+	log.EXPECT().DropPartition(models.ShardID(1)).Return(nil)
+	assert.NoError(t, m.DropPartition("test", 1))
+}
+
+func TestWriteAheadLog_Close(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	l := NewWriteAheadLog(context.TODO(), config.WAL{}, 1, "test", nil, nil, nil, nil)
+	p := NewMockPartition(ctrl)
+	l.(*writeAheadLog).shardLogs[1] = p
+	// case 1: close partition err
+	p.EXPECT().Close().Return(fmt.Errorf("err"))
+	assert.Error(t, l.Close())
+	// case 2: close partitions successfully
+	p.EXPECT().Close().Return(nil)
+	assert.NoError(t, l.Close())
+	assert.Empty(t, l.(*writeAheadLog).shardLogs)
+}
+
+func TestWriteAheadLog_DropPartition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		removeDir = fileutil.RemoveDir
+		ctrl.Finish()
+	}()
+
+	l := NewWriteAheadLog(context.TODO(), config.WAL{Dir: "wal"}, 1, "test", nil, nil, nil, nil)
+	p1 := NewMockPartition(ctrl)
+	p2 := NewMockPartition(ctrl)
+	l.(*writeAheadLog).shardLogs[1] = p1
+	l.(*writeAheadLog).shardLogs[2] = p2
+	var removed []string
+	removeDir = func(path string) error {
+		removed = append(removed, path)
+		return nil
+	}
+	// case 1: close partition err
+	p1.EXPECT().Close().Return(fmt.Errorf("err"))
+	assert.Error(t, l.DropPartition(1))
+	// case 2: drop partition successfully, other partitions are kept
+	assert.NoError(t, l.DropPartition(1))
+	assert.Equal(t, []string{path.Join("wal", "test", "1")}, removed)
+	assert.Len(t, l.(*writeAheadLog).shardLogs, 1)
+	// case 3: remove log files err
+	removeDir = func(path string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, l.DropPartition(3))
+}
+
+func TestWriteAheadLog_IsReplicated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		newWriteAheadLog = NewWriteAheadLog
+		ctrl.Finish()
+	}()
+
+	log := NewMockWriteAheadLog(ctrl)
+	newWriteAheadLog = func(_ context.Context, cfg config.WAL,
+		currentNodeID models.NodeID, database string,
+		engine tsdb.Engine,
+		cliFct rpc.ClientStreamFactory,
+		_ storage.StateManager,
+		_ DeleteHandler,
+	) WriteAheadLog {
+		return log
+	}
+	m := NewWriteAheadLogManager(context.TODO(), config.WAL{Dir: "wal"}, 1, nil, nil, nil, nil)
+	assert.True(t, m.IsReplicated())
+	m.GetOrCreateLog("test")
+	log.EXPECT().IsReplicated().Return(false)
+	assert.False(t, m.IsReplicated())
+	log.EXPECT().IsReplicated().Return(true)
+	assert.True(t, m.IsReplicated())
+
+	l := NewWriteAheadLog(context.TODO(), config.WAL{}, 1, "test", nil, nil, nil, nil)
+	p := NewMockPartition(ctrl)
+	l.(*writeAheadLog).shardLogs[1] = p
+	p.EXPECT().IsReplicated().Return(false)
+	assert.False(t, l.IsReplicated())
+	p.EXPECT().IsReplicated().Return(true)
+	assert.True(t, l.IsReplicated())
+}
+
+func TestWriteAheadLog_ReplicaState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		newWriteAheadLog = NewWriteAheadLog
+		ctrl.Finish()
+	}()
+
+	log := NewMockWriteAheadLog(ctrl)
+	newWriteAheadLog = func(_ context.Context, cfg config.WAL,
+		currentNodeID models.NodeID, database string,
+		engine tsdb.Engine,
+		cliFct rpc.ClientStreamFactory,
+		_ storage.StateManager,
+		_ DeleteHandler,
+	) WriteAheadLog {
+		return log
+	}
+	m := NewWriteAheadLogManager(context.TODO(), config.WAL{Dir: "wal"}, 1, nil, nil, nil, nil)
+	assert.Empty(t, m.ReplicaState())
+	m.GetOrCreateLog("test")
+	peer := func(db string, shardID models.ShardID, leader, follower models.NodeID) models.ReplicaPeerState {
+		return models.ReplicaPeerState{ReplicaState: models.ReplicaState{
+			Database: db, ShardID: shardID, Leader: leader, Follower: follower,
+		}}
+	}
+	log.EXPECT().ReplicaState().Return([]models.ReplicaPeerState{
+		peer("test", 2, 1, 1), peer("test", 1, 1, 3), peer("test", 1, 1, 2), peer("a", 3, 1, 1), peer("test", 1, 0, 1),
+	})
+	assert.Equal(t, []models.ReplicaPeerState{
+		peer("a", 3, 1, 1), peer("test", 1, 0, 1), peer("test", 1, 1, 2), peer("test", 1, 1, 3), peer("test", 2, 1, 1),
+	}, m.ReplicaState())
+
+	l := NewWriteAheadLog(context.TODO(), config.WAL{}, 1, "test", nil, nil, nil, nil)
+	p := NewMockPartition(ctrl)
+	l.(*writeAheadLog).shardLogs[1] = p
+	p.EXPECT().ReplicaState().Return([]models.ReplicaPeerState{peer("test", 1, 1, 2)})
+	assert.Equal(t, []models.ReplicaPeerState{peer("test", 1, 1, 2)}, l.ReplicaState())
+}
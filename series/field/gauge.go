@@ -0,0 +1,97 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package field
+
+import (
+	"strings"
+
+	"github.com/lindb/lindb/aggregation/function"
+)
+
+// gaugeAggFieldPrefix is the reserved prefix of hidden fields which keep the aggregates of gauge.
+const gaugeAggFieldPrefix = "__gauge_"
+
+// GaugeAggField represents a hidden field which keeps one aggregate of gauge,
+// gauge only keeps the last value when down sampling, so min/max/sum/count are lost after rollup.
+type GaugeAggField struct {
+	AggType AggType // aggregate of gauge value
+	Type    Type    // field type of hidden field
+}
+
+// GaugeAggFields defines all hidden aggregate fields of gauge, the order is the write order.
+var GaugeAggFields = []GaugeAggField{
+	{AggType: Min, Type: MinField},
+	{AggType: Max, Type: MaxField},
+	{AggType: Sum, Type: SumField},
+	{AggType: Count, Type: SumField},
+}
+
+// Name returns the hidden field name for given gauge field name.
+func (f GaugeAggField) Name(gaugeName Name) Name {
+	var aggName string
+	switch f.AggType {
+	case Min:
+		aggName = "min"
+	case Max:
+		aggName = "max"
+	case Sum:
+		aggName = "sum"
+	case Count:
+		aggName = "count"
+	}
+	return Name(gaugeAggFieldPrefix + aggName + "_" + string(gaugeName))
+}
+
+// Value returns the value written into hidden field for given gauge value.
+func (f GaugeAggField) Value(gaugeValue float64) float64 {
+	if f.AggType == Count {
+		return 1
+	}
+	return gaugeValue
+}
+
+// GaugeAggFieldsByFunc returns the hidden aggregate fields which can answer given function from rollup data,
+// avg is answered by sum/count, returns nil if function cannot be answered.
+func GaugeAggFieldsByFunc(funcType function.FuncType) []GaugeAggField {
+	var aggTypes []AggType
+	switch funcType {
+	case function.Min:
+		aggTypes = []AggType{Min}
+	case function.Max:
+		aggTypes = []AggType{Max}
+	case function.Sum:
+		aggTypes = []AggType{Sum}
+	case function.Avg:
+		aggTypes = []AggType{Sum, Count}
+	default:
+		return nil
+	}
+	var result []GaugeAggField
+	for _, aggType := range aggTypes {
+		for _, f := range GaugeAggFields {
+			if f.AggType == aggType {
+				result = append(result, f)
+			}
+		}
+	}
+	return result
+}
+
+// IsGaugeAggFieldName checks if field name is a hidden aggregate field of gauge.
+func IsGaugeAggFieldName(name Name) bool {
+	return strings.HasPrefix(string(name), gaugeAggFieldPrefix)
+}
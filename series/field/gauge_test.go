@@ -0,0 +1,53 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package field
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/aggregation/function"
+)
+
+func TestGaugeAggField(t *testing.T) {
+	names := []Name{"__gauge_min_cpu", "__gauge_max_cpu", "__gauge_sum_cpu", "__gauge_count_cpu"}
+	for idx, f := range GaugeAggFields {
+		assert.Equal(t, names[idx], f.Name("cpu"))
+		assert.True(t, IsGaugeAggFieldName(f.Name("cpu")))
+	}
+	assert.False(t, IsGaugeAggFieldName("cpu"))
+	assert.Equal(t, 10.0, GaugeAggFields[0].Value(10))
+	assert.Equal(t, 1.0, GaugeAggFields[3].Value(10))
+}
+
+func TestGaugeAggFieldsByFunc(t *testing.T) {
+	fs := GaugeAggFieldsByFunc(function.Max)
+	assert.Len(t, fs, 1)
+	assert.Equal(t, MaxField, fs[0].Type)
+	fs = GaugeAggFieldsByFunc(function.Min)
+	assert.Len(t, fs, 1)
+	assert.Equal(t, MinField, fs[0].Type)
+	fs = GaugeAggFieldsByFunc(function.Sum)
+	assert.Len(t, fs, 1)
+	assert.Equal(t, Sum, fs[0].AggType)
+	fs = GaugeAggFieldsByFunc(function.Avg)
+	assert.Len(t, fs, 2)
+	assert.Equal(t, Sum, fs[0].AggType)
+	assert.Equal(t, Count, fs[1].AggType)
+	assert.Nil(t, GaugeAggFieldsByFunc(function.LastValue))
+}
@@ -72,6 +72,7 @@ const (
 	MaxField
 	GaugeField
 	HistogramField // alias for sumField, only visible for tsdb
+	SketchField    // alias for sumField(count of quantile sketch/sketch bin), only visible for tsdb
 )
 
 // String returns the field type's string value
@@ -87,6 +88,8 @@ func (t Type) String() string {
 		return "gauge"
 	case HistogramField:
 		return "histogram"
+	case SketchField:
+		return "sketch"
 	default:
 		return "unknown"
 	}
@@ -95,7 +98,7 @@ func (t Type) String() string {
 // AggType returns the aggregate function
 func (t Type) AggType() AggType {
 	switch t {
-	case SumField, HistogramField:
+	case SumField, HistogramField, SketchField:
 		return Sum
 	case MinField:
 		return Min
@@ -117,7 +120,7 @@ func (t Type) DownSamplingFunc() function.FuncType {
 		return function.Max
 	case GaugeField:
 		return function.LastValue
-	case HistogramField:
+	case HistogramField, SketchField:
 		return function.Sum
 	default:
 		return function.Unknown
@@ -149,12 +152,12 @@ func (t Type) IsFuncSupported(funcType function.FuncType) bool {
 		}
 	case GaugeField:
 		switch funcType {
-		case function.Sum, function.Min, function.Max, function.LastValue:
+		case function.Sum, function.Min, function.Max, function.Avg, function.LastValue:
 			return true
 		default:
 			return false
 		}
-	case HistogramField:
+	case HistogramField, SketchField:
 		switch funcType {
 		case function.Sum:
 			return true
@@ -177,8 +180,8 @@ func (t Type) GetFuncFieldParams(funcType function.FuncType) []AggType {
 		return getFieldParamsForMinField(funcType)
 	case MaxField:
 		return []AggType{Max}
-	case HistogramField:
-		// Histogram field only supports sum
+	case HistogramField, SketchField:
+		// Histogram/Sketch field only supports sum
 		return []AggType{Sum}
 	}
 	return nil
@@ -195,7 +198,7 @@ func (t Type) GetDefaultFuncFieldParams() []AggType {
 		return []AggType{LastValue}
 	case MaxField:
 		return []AggType{Max}
-	case HistogramField:
+	case HistogramField, SketchField:
 		return []AggType{Sum}
 	}
 	return nil
@@ -28,6 +28,7 @@ import (
 func TestDownSamplingFunc(t *testing.T) {
 	assert.Equal(t, function.Sum, SumField.DownSamplingFunc())
 	assert.Equal(t, function.Sum, HistogramField.DownSamplingFunc())
+	assert.Equal(t, function.Sum, SketchField.DownSamplingFunc())
 	assert.Equal(t, function.Min, MinField.DownSamplingFunc())
 	assert.Equal(t, function.Max, MaxField.DownSamplingFunc())
 	assert.Equal(t, function.LastValue, GaugeField.DownSamplingFunc())
@@ -40,11 +41,13 @@ func TestType_String(t *testing.T) {
 	assert.Equal(t, "min", MinField.String())
 	assert.Equal(t, "gauge", GaugeField.String())
 	assert.Equal(t, "histogram", HistogramField.String())
+	assert.Equal(t, "sketch", SketchField.String())
 	assert.Equal(t, "unknown", Unknown.String())
 }
 
 func TestIsSupportFunc(t *testing.T) {
 	assert.True(t, HistogramField.IsFuncSupported(function.Sum))
+	assert.True(t, SketchField.IsFuncSupported(function.Sum))
 	assert.False(t, HistogramField.IsFuncSupported(function.LastValue))
 
 	assert.True(t, SumField.IsFuncSupported(function.Sum))
@@ -56,6 +59,7 @@ func TestIsSupportFunc(t *testing.T) {
 	assert.False(t, MaxField.IsFuncSupported(function.Quantile))
 
 	assert.True(t, GaugeField.IsFuncSupported(function.LastValue))
+	assert.True(t, GaugeField.IsFuncSupported(function.Avg))
 	assert.False(t, GaugeField.IsFuncSupported(function.Quantile))
 
 	assert.True(t, MinField.IsFuncSupported(function.Min))
@@ -31,6 +31,7 @@ import (
 type TimeSeriesEvent struct {
 	SeriesList      GroupedIterators
 	AggregatorSpecs map[string]*protoCommonV1.AggregatorSpec
+	Exemplars       []*protoCommonV1.Exemplar
 	Stats           *models.QueryStats
 	Err             error
 }
@@ -38,4 +38,8 @@ var (
 	ErrMetricNanField = fmt.Errorf("%w, field is not a number", ErrBadMetricPBFormat)
 	// ErrMetricInfField represents field value is infinity, positive or negative
 	ErrMetricInfField = fmt.Errorf("%w, field is infinity", ErrBadMetricPBFormat)
+	// ErrMetricOutOfBehindRange represents metric timestamp is older than the behind range of database
+	ErrMetricOutOfBehindRange = errors.New("metric timestamp is out of behind time range")
+	// ErrMetricOutOfAheadRange represents metric timestamp is newer than the ahead range of database
+	ErrMetricOutOfAheadRange = errors.New("metric timestamp is out of ahead time range")
 )
@@ -0,0 +1,78 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package metric
+
+import (
+	flatbuffers "github.com/google/flatbuffers/go"
+
+	"github.com/lindb/lindb/proto/gen/v1/flatMetricsV1"
+)
+
+// Exemplar represents a sampled trace of field value, which links the metric to trace.
+type Exemplar struct {
+	TraceID  []byte
+	SpanID   []byte
+	Duration int64
+}
+
+// reset copies the trace info into exemplar, reuses the underlying buffers.
+func (e *Exemplar) reset(traceID, spanID []byte, duration int64) {
+	e.TraceID = append(e.TraceID[:0], traceID...)
+	e.SpanID = append(e.SpanID[:0], spanID...)
+	e.Duration = duration
+}
+
+// FromFlat reads the flat exemplar into exemplar, reuses the underlying buffers.
+func (e *Exemplar) FromFlat(flat *flatMetricsV1.Exemplar) {
+	e.TraceID = e.TraceID[:0]
+	for idx := 0; idx < flat.TraceIDLength(); idx++ {
+		e.TraceID = append(e.TraceID, byte(flat.TraceID(idx)))
+	}
+	e.SpanID = e.SpanID[:0]
+	for idx := 0; idx < flat.SpanIDLength(); idx++ {
+		e.SpanID = append(e.SpanID, byte(flat.SpanID(idx)))
+	}
+	e.Duration = flat.Duration()
+}
+
+// buildFlatExemplars builds the exemplars vector, returns 0 if exemplars are empty.
+// NOTICE: must be called before starting the table which holds the exemplars.
+func buildFlatExemplars(
+	builder *flatbuffers.Builder,
+	offsets []flatbuffers.UOffsetT,
+	startVectorFn func(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT,
+	exemplars []Exemplar,
+) (vector flatbuffers.UOffsetT, buf []flatbuffers.UOffsetT) {
+	if len(exemplars) == 0 {
+		return 0, offsets
+	}
+	offsets = offsets[:0]
+	for idx := range exemplars {
+		traceID := builder.CreateByteVector(exemplars[idx].TraceID)
+		spanID := builder.CreateByteVector(exemplars[idx].SpanID)
+		flatMetricsV1.ExemplarStart(builder)
+		flatMetricsV1.ExemplarAddTraceID(builder, traceID)
+		flatMetricsV1.ExemplarAddSpanID(builder, spanID)
+		flatMetricsV1.ExemplarAddDuration(builder, exemplars[idx].Duration)
+		offsets = append(offsets, flatMetricsV1.ExemplarEnd(builder))
+	}
+	startVectorFn(builder, len(offsets))
+	for idx := len(offsets) - 1; idx >= 0; idx-- {
+		builder.PrependUOffsetT(offsets[idx])
+	}
+	return builder.EndVector(len(offsets)), offsets
+}
@@ -40,6 +40,8 @@ type BrokerRow struct {
 	// IsOutOfTimeRange marks if this row is out-of time-range
 	// data is not accessible when its set to true
 	IsOutOfTimeRange bool
+	// Line is the line number of text protocol or index(starts with 1) of binary protocol in payload
+	Line int
 }
 
 // FromBlock resets buffer, unmarshal from a new block,
@@ -67,6 +69,16 @@ func (row *BrokerRow) WriteTo(writer io.Writer) (int, error) {
 	return writer.Write(row.buffer)
 }
 
+// maxRejections is the max number of rejection details kept in a batch.
+const maxRejections = 100
+
+// Rejection represents a rejected row of ingestion with reason.
+type Rejection struct {
+	// Line is the line number of text protocol or index(starts with 1) of binary protocol in payload
+	Line   int    `json:"line"`
+	Reason string `json:"reason"`
+}
+
 var brokerBatchRowsPool sync.Pool
 
 // BrokerBatchRows holds rows from ingestion
@@ -75,6 +87,10 @@ type BrokerBatchRows struct {
 	rows     []BrokerRow
 	rowCount int
 
+	rejections    []Rejection // first maxRejections rejected rows
+	rejectedCount int         // count of rejected rows, includes out-of time-range rows
+	evictedCount  int         // count of out-of time-range rows
+
 	shardGroupIterator BrokerBatchShardIterator
 }
 
@@ -96,7 +112,12 @@ func NewBrokerBatchRows() (batch *BrokerBatchRows) {
 // Release releases rows context into sync.Pool
 func (br *BrokerBatchRows) Release() { brokerBatchRowsPool.Put(br) }
 
-func (br *BrokerBatchRows) reset() { br.rowCount = 0 }
+func (br *BrokerBatchRows) reset() {
+	br.rowCount = 0
+	br.rejections = br.rejections[:0]
+	br.rejectedCount = 0
+	br.evictedCount = 0
+}
 
 func (br *BrokerBatchRows) Len() int { return br.rowCount }
 func (br *BrokerBatchRows) Less(i, j int) bool {
@@ -105,27 +126,89 @@ func (br *BrokerBatchRows) Less(i, j int) bool {
 func (br *BrokerBatchRows) Swap(i, j int)     { br.rows[i], br.rows[j] = br.rows[j], br.rows[i] }
 func (br *BrokerBatchRows) Rows() []BrokerRow { return br.rows[:br.rowCount] }
 
-// EvictOutOfTimeRange evicts and marks out-of-range metrics invalid
+// AcceptedCount returns the count of rows which will be written into storage.
+func (br *BrokerBatchRows) AcceptedCount() int { return br.rowCount - br.evictedCount }
+
+// RejectedCount returns the count of rejected rows, includes out-of time-range rows.
+func (br *BrokerBatchRows) RejectedCount() int { return br.rejectedCount }
+
+// Rejections returns the first rejected rows with reason, sorted by line number.
+func (br *BrokerBatchRows) Rejections() []Rejection { return br.rejections }
+
+// Reject records a rejected row with line number and reason,
+// rejections are kept sorted by line number, only the first maxRejections rows are kept.
+func (br *BrokerBatchRows) Reject(line int, err error) {
+	br.rejectedCount++
+	idx := sort.Search(len(br.rejections), func(i int) bool {
+		return br.rejections[i].Line > line
+	})
+	if len(br.rejections) < maxRejections {
+		br.rejections = append(br.rejections, Rejection{})
+	} else if idx == len(br.rejections) {
+		// line is after all kept rejections
+		return
+	}
+	copy(br.rejections[idx+1:], br.rejections[idx:])
+	br.rejections[idx] = Rejection{Line: line, Reason: err.Error()}
+}
+
+// Retain keeps the rows which retainFunc returns true, removes others from batch.
+func (br *BrokerBatchRows) Retain(retainFunc func(row *BrokerRow) bool) (removed int) {
+	kept := 0
+	for idx := 0; idx < br.rowCount; idx++ {
+		if !retainFunc(&br.rows[idx]) {
+			continue
+		}
+		// swap rows for reusing buffer of removed row
+		br.rows[kept], br.rows[idx] = br.rows[idx], br.rows[kept]
+		kept++
+	}
+	removed = br.rowCount - kept
+	br.rowCount = kept
+	return removed
+}
+
+// EvictOutOfTimeRange evicts and marks out-of-range metrics invalid, evicted rows are rejected.
 func (br *BrokerBatchRows) EvictOutOfTimeRange(behind, ahead int64) (evicted int) {
 	// check metric timestamp if in acceptable time range
 	now := fasttime.UnixMilliseconds()
 	for idx := 0; idx < br.Len(); idx++ {
-		if (behind > 0 && br.rows[idx].m.Timestamp() < now-behind) ||
-			(ahead > 0 && br.rows[idx].m.Timestamp() > now+ahead) {
-			br.rows[idx].IsOutOfTimeRange = true
-			evicted++
+		row := &br.rows[idx]
+		if row.IsOutOfTimeRange {
+			continue
+		}
+		switch {
+		case behind > 0 && row.m.Timestamp() < now-behind:
+			br.Reject(row.Line, ErrMetricOutOfBehindRange)
+		case ahead > 0 && row.m.Timestamp() > now+ahead:
+			br.Reject(row.Line, ErrMetricOutOfAheadRange)
+		default:
+			continue
 		}
+		row.IsOutOfTimeRange = true
+		evicted++
 	}
+	br.evictedCount += evicted
 	return evicted
 }
 
+// TryAppend appends a row with the next index as line number, rejects it if append failure.
 func (br *BrokerBatchRows) TryAppend(appendFunc func(row *BrokerRow) error) error {
+	return br.TryAppendLine(br.rowCount+br.rejectedCount+1, appendFunc)
+}
+
+// TryAppendLine appends a row with line number in payload, rejects it if append failure.
+func (br *BrokerBatchRows) TryAppendLine(line int, appendFunc func(row *BrokerRow) error) error {
 	if len(br.rows) <= br.rowCount {
 		br.rows = append(br.rows, BrokerRow{})
 	}
-	if err := appendFunc(&br.rows[br.rowCount]); err != nil {
+	row := &br.rows[br.rowCount]
+	row.IsOutOfTimeRange = false
+	if err := appendFunc(row); err != nil {
+		br.Reject(line, err)
 		return err
 	}
+	row.Line = line
 	// decoded successfully, move to next row index
 	br.rowCount++
 	return nil
@@ -109,6 +109,56 @@ func Test_BrokerBatchRows_AppendError(t *testing.T) {
 		return io.ErrShortBuffer
 	}))
 	assert.Equal(t, 0, batch.Len())
+	assert.Equal(t, 1, batch.RejectedCount())
+	assert.Equal(t, []Rejection{{Line: 1, Reason: io.ErrShortBuffer.Error()}}, batch.Rejections())
+}
+
+func Test_BrokerBatchRows_Rejections(t *testing.T) {
+	batch := NewBrokerBatchRows()
+	defer batch.Release()
+
+	now := fasttime.UnixMilliseconds()
+	assert.NoError(t, batch.TryAppendLine(2, func(row *BrokerRow) error {
+		buildRow(row, now-timeutil.OneHour)
+		return nil
+	}))
+	assert.NoError(t, batch.TryAppendLine(5, func(row *BrokerRow) error {
+		buildRow(row, now)
+		return nil
+	}))
+	assert.NoError(t, batch.TryAppendLine(7, func(row *BrokerRow) error {
+		buildRow(row, now+timeutil.OneHour)
+		return nil
+	}))
+	batch.Reject(3, io.ErrShortBuffer)
+	assert.Equal(t, 2, batch.EvictOutOfTimeRange(timeutil.OneMinute, timeutil.OneMinute))
+	// evicted rows are not evicted twice
+	assert.Equal(t, 0, batch.EvictOutOfTimeRange(timeutil.OneMinute, timeutil.OneMinute))
+
+	assert.Equal(t, 3, batch.Len())
+	assert.Equal(t, 1, batch.AcceptedCount())
+	assert.Equal(t, 3, batch.RejectedCount())
+	assert.Equal(t, []Rejection{
+		{Line: 2, Reason: ErrMetricOutOfBehindRange.Error()},
+		{Line: 3, Reason: io.ErrShortBuffer.Error()},
+		{Line: 7, Reason: ErrMetricOutOfAheadRange.Error()},
+	}, batch.Rejections())
+
+	for i := 0; i < maxRejections; i++ {
+		batch.Reject(i, io.ErrShortBuffer)
+	}
+	assert.Len(t, batch.Rejections(), maxRejections)
+	assert.Equal(t, 3+maxRejections, batch.RejectedCount())
+	// keep the first rejected rows by line number
+	assert.Equal(t, 0, batch.Rejections()[0].Line)
+	assert.Equal(t, maxRejections-4, batch.Rejections()[maxRejections-1].Line)
+	batch.Reject(maxRejections+1, io.ErrShortBuffer)
+	assert.Len(t, batch.Rejections(), maxRejections)
+	assert.Equal(t, maxRejections-4, batch.Rejections()[maxRejections-1].Line)
+
+	batch.reset()
+	assert.Zero(t, batch.RejectedCount())
+	assert.Empty(t, batch.Rejections())
 }
 
 func Test_BrokerRow_Writer(t *testing.T) {
@@ -203,3 +253,26 @@ func Test_BrokerBatchRows_FamilyRowsForNextShard_SameFamily(t *testing.T) {
 	assert.True(t, familyItr.HasNextFamily())
 	assert.False(t, familyItr.HasNextFamily())
 }
+
+func Test_BrokerBatchRows_Retain(t *testing.T) {
+	batch := NewBrokerBatchRows()
+	defer batch.Release()
+
+	now := fasttime.UnixMilliseconds()
+	for i := 0; i < 10; i++ {
+		i := i
+		assert.NoError(t, batch.TryAppend(func(row *BrokerRow) error {
+			buildRow(row, now+int64(i))
+			return nil
+		}))
+	}
+	assert.Equal(t, 5, batch.Retain(func(row *BrokerRow) bool {
+		return row.Line%2 == 0
+	}))
+	assert.Equal(t, 5, batch.Len())
+	for _, row := range batch.Rows() {
+		m := row.Metric()
+		assert.Equal(t, now+int64(row.Line-1), m.Timestamp())
+		assert.Zero(t, row.Line%2)
+	}
+}
@@ -28,6 +28,7 @@ import (
 	flatbuffers "github.com/google/flatbuffers/go"
 
 	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/pkg/fasttime"
 	"github.com/lindb/lindb/proto/gen/v1/flatMetricsV1"
 )
@@ -50,9 +51,10 @@ func (items rowKVs) Less(i, j int) bool {
 }
 
 type rowSimpleField struct {
-	name  []byte
-	fType flatMetricsV1.SimpleFieldType
-	value float64
+	name      []byte
+	fType     flatMetricsV1.SimpleFieldType
+	value     float64
+	exemplars []Exemplar
 }
 
 // RowBuilder builds a flat metric in order.
@@ -74,6 +76,9 @@ type RowBuilder struct {
 	compoundFieldMax            float64
 	compoundFieldSum            float64
 	compoundFieldCount          float64
+	compoundFieldExemplars      []Exemplar
+	compoundFieldSketch         []byte
+	sketch                      *encoding.Sketch // lazy initialization, for validating sketch
 
 	// context for building flat metrics
 	flatBuilder *flatbuffers.Builder
@@ -82,6 +87,8 @@ type RowBuilder struct {
 	kvs         []flatbuffers.UOffsetT
 	fieldNames  []flatbuffers.UOffsetT
 	fields      []flatbuffers.UOffsetT
+	exemplars   []flatbuffers.UOffsetT // exemplars vector of each simple field
+	offsets     []flatbuffers.UOffsetT // offsets buffer for building exemplars vector
 }
 
 var rowBuilderPool sync.Pool
@@ -156,9 +163,43 @@ func (rb *RowBuilder) AddSimpleField(fieldName []byte, fieldType flatMetricsV1.S
 	// copy field type, field value
 	rb.simpleFields[sfIdx].fType = fieldType
 	rb.simpleFields[sfIdx].value = fieldValue
+	rb.simpleFields[sfIdx].exemplars = rb.simpleFields[sfIdx].exemplars[:0]
 	return nil
 }
 
+// AddSimpleFieldExemplar appends an exemplar for the last added simple field.
+func (rb *RowBuilder) AddSimpleFieldExemplar(traceID, spanID []byte, duration int64) error {
+	if rb.simpleFieldCount == 0 {
+		return fmt.Errorf("there is no simple field for exemplar")
+	}
+	if len(traceID) == 0 {
+		return fmt.Errorf("exemplar trace id is empty")
+	}
+	sf := &rb.simpleFields[rb.simpleFieldCount-1]
+	sf.exemplars = appendExemplar(sf.exemplars, traceID, spanID, duration)
+	return nil
+}
+
+// AddCompoundFieldExemplar appends an exemplar for the compound field.
+func (rb *RowBuilder) AddCompoundFieldExemplar(traceID, spanID []byte, duration int64) error {
+	if len(traceID) == 0 {
+		return fmt.Errorf("exemplar trace id is empty")
+	}
+	rb.compoundFieldExemplars = appendExemplar(rb.compoundFieldExemplars, traceID, spanID, duration)
+	return nil
+}
+
+// appendExemplar appends an exemplar, reuses the exemplar buffer if possible.
+func appendExemplar(exemplars []Exemplar, traceID, spanID []byte, duration int64) []Exemplar {
+	if len(exemplars) < cap(exemplars) {
+		exemplars = exemplars[:len(exemplars)+1]
+	} else {
+		exemplars = append(exemplars, Exemplar{})
+	}
+	exemplars[len(exemplars)-1].reset(traceID, spanID, duration)
+	return exemplars
+}
+
 func (rb *RowBuilder) AddTimestamp(ts int64) { rb.timestamp = ts }
 
 func (rb *RowBuilder) AddCompoundFieldData(values, bounds []float64) error {
@@ -200,6 +241,22 @@ func (rb *RowBuilder) AddCompoundFieldData(values, bounds []float64) error {
 	return nil
 }
 
+// AddCompoundFieldSketch adds the binary of quantile sketch as compound field data,
+// explicit bounds/values must be empty if compound field is a sketch.
+func (rb *RowBuilder) AddCompoundFieldSketch(data []byte) error {
+	if rb.sketch == nil {
+		rb.sketch = encoding.NewSketch()
+	}
+	if err := rb.sketch.UnmarshalBinary(data); err != nil {
+		return fmt.Errorf("bad compound sketch: %w", err)
+	}
+	if rb.sketch.BinsLen() == 0 {
+		return fmt.Errorf("compound sketch is empty")
+	}
+	rb.compoundFieldSketch = append(rb.compoundFieldSketch[:0], data...)
+	return nil
+}
+
 func (rb *RowBuilder) AddCompoundFieldMMSC(min, max, sum, count float64) error {
 	rb.compoundFieldMin = min
 	rb.compoundFieldMax = max
@@ -248,6 +305,8 @@ func (rb *RowBuilder) Reset() {
 	rb.compoundFieldMax = 0
 	rb.compoundFieldSum = 0
 	rb.compoundFieldCount = 0
+	rb.compoundFieldExemplars = rb.compoundFieldExemplars[:0]
+	rb.compoundFieldSketch = rb.compoundFieldSketch[:0]
 
 	// reset flat builder context
 	rb.flatBuilder.Reset()
@@ -256,6 +315,7 @@ func (rb *RowBuilder) Reset() {
 	rb.kvs = rb.kvs[:0]
 	rb.fieldNames = rb.fieldNames[:0]
 	rb.fields = rb.fields[:0]
+	rb.exemplars = rb.exemplars[:0]
 }
 
 var (
@@ -288,9 +348,18 @@ func (rb *RowBuilder) Build() ([]byte, error) {
 	if len(rb.metricName) == 0 {
 		return nil, fmt.Errorf("metric-name is empty")
 	}
-	if rb.simpleFieldCount == 0 && len(rb.compoundFieldValues) == 0 {
+	hasSketch := len(rb.compoundFieldSketch) > 0
+	if rb.simpleFieldCount == 0 && len(rb.compoundFieldValues) == 0 && !hasSketch {
 		return nil, fmt.Errorf("simple field and compound field are both empty")
 	}
+	if hasSketch {
+		if len(rb.compoundFieldValues) > 0 {
+			return nil, fmt.Errorf("compound field has both sketch and explicit bounds")
+		}
+		if err := validateSketchMMSC(rb.sketch, rb.compoundFieldMin, rb.compoundFieldMax, rb.compoundFieldCount); err != nil {
+			return nil, err
+		}
+	}
 	if rb.rowKVs.kvCount > constants.DefaultMaxTagKeysCount {
 		return nil, fmt.Errorf("too many tag pairs: %d", rb.rowKVs.kvCount)
 	}
@@ -305,9 +374,13 @@ func (rb *RowBuilder) Build() ([]byte, error) {
 		flatMetricsV1.KeyValueAddValue(rb.flatBuilder, rb.values[i])
 		rb.kvs = append(rb.kvs, flatMetricsV1.KeyValueEnd(rb.flatBuilder))
 	}
-	// building field names
+	// building field names and exemplars
+	var exemplars flatbuffers.UOffsetT
 	for i := 0; i < rb.simpleFieldCount; i++ {
 		rb.fieldNames = append(rb.fieldNames, rb.flatBuilder.CreateByteString(rb.simpleFields[i].name))
+		exemplars, rb.offsets = buildFlatExemplars(rb.flatBuilder, rb.offsets,
+			flatMetricsV1.SimpleFieldStartExemplarsVector, rb.simpleFields[i].exemplars)
+		rb.exemplars = append(rb.exemplars, exemplars)
 	}
 
 	for i := 0; i < rb.simpleFieldCount; i++ {
@@ -315,6 +388,9 @@ func (rb *RowBuilder) Build() ([]byte, error) {
 		flatMetricsV1.SimpleFieldAddName(rb.flatBuilder, rb.fieldNames[i])
 		flatMetricsV1.SimpleFieldAddType(rb.flatBuilder, rb.simpleFields[i].fType)
 		flatMetricsV1.SimpleFieldAddValue(rb.flatBuilder, rb.simpleFields[i].value)
+		if rb.exemplars[i] != 0 {
+			flatMetricsV1.SimpleFieldAddExemplars(rb.flatBuilder, rb.exemplars[i])
+		}
 		rb.fields = append(rb.fields, flatMetricsV1.SimpleFieldEnd(rb.flatBuilder))
 	}
 	flatMetricsV1.MetricStartKeyValuesVector(rb.flatBuilder, rb.rowKVs.kvCount)
@@ -332,9 +408,10 @@ func (rb *RowBuilder) Build() ([]byte, error) {
 	var (
 		compoundFieldBounds flatbuffers.UOffsetT
 		compoundFieldValues flatbuffers.UOffsetT
+		compoundFieldSketch flatbuffers.UOffsetT
 		compoundField       flatbuffers.UOffsetT
 	)
-	if len(rb.compoundFieldValues) == 0 {
+	if len(rb.compoundFieldValues) == 0 && !hasSketch {
 		goto Serialize
 	}
 	// serialize compound fields
@@ -350,14 +427,25 @@ func (rb *RowBuilder) Build() ([]byte, error) {
 		rb.flatBuilder.PrependFloat64(rb.compoundFieldExplicitValues[i])
 	}
 	compoundFieldBounds = rb.flatBuilder.EndVector(len(rb.compoundFieldExplicitValues))
+	if hasSketch {
+		compoundFieldSketch = rb.flatBuilder.CreateByteVector(rb.compoundFieldSketch)
+	}
+	exemplars, rb.offsets = buildFlatExemplars(rb.flatBuilder, rb.offsets,
+		flatMetricsV1.CompoundFieldStartExemplarsVector, rb.compoundFieldExemplars)
 	// add count sum min max
 	flatMetricsV1.CompoundFieldStart(rb.flatBuilder)
+	if exemplars != 0 {
+		flatMetricsV1.CompoundFieldAddExemplars(rb.flatBuilder, exemplars)
+	}
 	flatMetricsV1.CompoundFieldAddCount(rb.flatBuilder, rb.compoundFieldCount)
 	flatMetricsV1.CompoundFieldAddSum(rb.flatBuilder, rb.compoundFieldSum)
 	flatMetricsV1.CompoundFieldAddMin(rb.flatBuilder, rb.compoundFieldMin)
 	flatMetricsV1.CompoundFieldAddMax(rb.flatBuilder, rb.compoundFieldMax)
 	flatMetricsV1.CompoundFieldAddValues(rb.flatBuilder, compoundFieldValues)
 	flatMetricsV1.CompoundFieldAddExplicitBounds(rb.flatBuilder, compoundFieldBounds)
+	if compoundFieldSketch != 0 {
+		flatMetricsV1.CompoundFieldAddSketch(rb.flatBuilder, compoundFieldSketch)
+	}
 	compoundField = flatMetricsV1.CompoundFieldEnd(rb.flatBuilder)
 
 Serialize:
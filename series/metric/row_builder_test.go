@@ -21,6 +21,9 @@ import (
 	"math"
 	"testing"
 
+	flatbuffers "github.com/google/flatbuffers/go"
+
+	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/proto/gen/v1/flatMetricsV1"
 
 	"github.com/stretchr/testify/assert"
@@ -173,3 +176,108 @@ func Test_RowBuilder_BuildTo(t *testing.T) {
 	assert.Equal(t, float64(1), cf.ExplicitBounds(0))
 	assert.True(t, math.IsInf(cf.ExplicitBounds(5), 1))
 }
+
+func Test_RowBuilder_Exemplars(t *testing.T) {
+	rb := newRowBuilder()
+	// no simple field
+	assert.Error(t, rb.AddSimpleFieldExemplar([]byte("trace"), []byte("span"), 10))
+	rb.AddMetricName([]byte("http"))
+	assert.NoError(t, rb.AddSimpleField([]byte("latency"), flatMetricsV1.SimpleFieldTypeMax, 100))
+	// empty trace id
+	assert.Error(t, rb.AddSimpleFieldExemplar(nil, []byte("span"), 10))
+	assert.NoError(t, rb.AddSimpleFieldExemplar([]byte("trace1"), []byte("span1"), 10))
+	assert.NoError(t, rb.AddSimpleFieldExemplar([]byte("trace2"), []byte("span2"), 20))
+	assert.NoError(t, rb.AddSimpleField([]byte("count"), flatMetricsV1.SimpleFieldTypeDeltaSum, 1))
+	assert.Error(t, rb.AddCompoundFieldExemplar(nil, nil, 1))
+	assert.NoError(t, rb.AddCompoundFieldExemplar([]byte("trace3"), nil, 30))
+	assert.NoError(t, rb.AddCompoundFieldMMSC(1, 1, 1, 1))
+	assert.NoError(t, rb.AddCompoundFieldData(
+		[]float64{1, 2, 3},
+		[]float64{1, 2, math.Inf(1)},
+	))
+
+	var row BrokerRow
+	assert.NoError(t, rb.BuildTo(&row))
+	var (
+		e  Exemplar
+		sr StorageRow
+	)
+	sr.Unmarshal(row.buffer[flatbuffers.SizeUOffsetT:])
+	itr := sr.NewSimpleFieldIterator()
+	assert.True(t, itr.HasNext())
+	assert.Equal(t, 2, itr.NextExemplarsLen())
+	itr.NextExemplar(1, &e)
+	assert.Equal(t, Exemplar{TraceID: []byte("trace2"), SpanID: []byte("span2"), Duration: 20}, e)
+	assert.True(t, itr.HasNext())
+	assert.Zero(t, itr.NextExemplarsLen())
+
+	cfItr, ok := sr.NewCompoundFieldIterator()
+	assert.True(t, ok)
+	assert.Equal(t, 1, cfItr.ExemplarsLen())
+	cfItr.Exemplar(0, &e)
+	assert.Equal(t, "trace3", string(e.TraceID))
+	assert.Empty(t, e.SpanID)
+	assert.Equal(t, int64(30), e.Duration)
+
+	// reset clears exemplars
+	rb.Reset()
+	rb.AddMetricName([]byte("http"))
+	assert.NoError(t, rb.AddSimpleField([]byte("latency"), flatMetricsV1.SimpleFieldTypeMax, 100))
+	assert.NoError(t, rb.BuildTo(&row))
+	sr.Unmarshal(row.buffer[flatbuffers.SizeUOffsetT:])
+	itr = sr.NewSimpleFieldIterator()
+	assert.True(t, itr.HasNext())
+	assert.Zero(t, itr.NextExemplarsLen())
+	_, ok = sr.NewCompoundFieldIterator()
+	assert.False(t, ok)
+}
+
+func Test_RowBuilder_Sketch(t *testing.T) {
+	rb := newRowBuilder()
+	rb.AddMetricName([]byte("latency"))
+	// bad sketch
+	assert.Error(t, rb.AddCompoundFieldSketch([]byte{1, 2}))
+	// empty sketch
+	assert.Error(t, rb.AddCompoundFieldSketch(encoding.NewSketch().MarshalBinary()))
+	sketch := encoding.NewSketch()
+	sketch.Add(1)
+	sketch.Add(100)
+	assert.NoError(t, rb.AddCompoundFieldSketch(sketch.MarshalBinary()))
+	// count not match
+	assert.NoError(t, rb.AddCompoundFieldMMSC(1, 100, 101, 3))
+	_, err := rb.Build()
+	assert.Error(t, err)
+	// min > max
+	assert.NoError(t, rb.AddCompoundFieldMMSC(100, 1, 101, 2))
+	_, err = rb.Build()
+	assert.Error(t, err)
+	// sketch with explicit bounds
+	assert.NoError(t, rb.AddCompoundFieldMMSC(1, 100, 101, 2))
+	assert.NoError(t, rb.AddCompoundFieldData([]float64{1, 2}, []float64{1, math.Inf(1)}))
+	_, err = rb.Build()
+	assert.Error(t, err)
+
+	rb.Reset()
+	rb.AddMetricName([]byte("latency"))
+	assert.NoError(t, rb.AddCompoundFieldSketch(sketch.MarshalBinary()))
+	assert.NoError(t, rb.AddCompoundFieldMMSC(1, 100, 101, 2))
+	var row BrokerRow
+	assert.NoError(t, rb.BuildTo(&row))
+	var sr StorageRow
+	sr.Unmarshal(row.buffer[flatbuffers.SizeUOffsetT:])
+	itr, ok := sr.NewCompoundFieldIterator()
+	assert.True(t, ok)
+	assert.True(t, itr.HasSketch())
+	assert.False(t, itr.HasNextBucket())
+	assert.Equal(t, 1.0, itr.Min())
+	assert.Equal(t, 100.0, itr.Max())
+	rowSketch, err := itr.Sketch()
+	assert.NoError(t, err)
+	assert.Equal(t, sketch.Count(), rowSketch.Count())
+
+	// reset clears sketch
+	rb.Reset()
+	rb.AddMetricName([]byte("latency"))
+	_, err = rb.Build()
+	assert.Error(t, err)
+}
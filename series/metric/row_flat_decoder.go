@@ -125,12 +125,18 @@ func (itr *BrokerRowFlatDecoder) rebuild() error {
 	if !ok {
 		goto End
 	}
-	for compoundFieldItr.HasNextBucket() {
-		itr.compoundBounds = append(itr.compoundBounds, compoundFieldItr.NextExplicitBound())
-		itr.compoundValues = append(itr.compoundValues, compoundFieldItr.NextValue())
-	}
-	if err := itr.rowBuilder.AddCompoundFieldData(itr.compoundValues, itr.compoundBounds); err != nil {
-		return err
+	if compoundFieldItr.HasSketch() {
+		if err := itr.rowBuilder.AddCompoundFieldSketch(compoundFieldItr.SketchBytes()); err != nil {
+			return err
+		}
+	} else {
+		for compoundFieldItr.HasNextBucket() {
+			itr.compoundBounds = append(itr.compoundBounds, compoundFieldItr.NextExplicitBound())
+			itr.compoundValues = append(itr.compoundValues, compoundFieldItr.NextValue())
+		}
+		if err := itr.rowBuilder.AddCompoundFieldData(itr.compoundValues, itr.compoundBounds); err != nil {
+			return err
+		}
 	}
 	if err := itr.rowBuilder.AddCompoundFieldMMSC(
 		compoundFieldItr.Min(),
@@ -22,8 +22,10 @@ import (
 	"math"
 	"testing"
 
+	flatbuffers "github.com/google/flatbuffers/go"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/pkg/encoding"
 	protoMetricsV1 "github.com/lindb/lindb/proto/gen/v1/metrics"
 	"github.com/lindb/lindb/series/tag"
 )
@@ -66,6 +68,22 @@ func Test_NewBrokerRowFlatDecoder(t *testing.T) {
 	assert.NoError(t, err)
 	_, _ = buf.Write(data2)
 
+	sketch := encoding.NewSketch()
+	sketch.Add(1)
+	sketch.Add(100)
+	data3, err := converter2.MarshalProtoMetricV1(&protoMetricsV1.Metric{
+		Name: "latency",
+		CompoundField: &protoMetricsV1.CompoundField{
+			Min:    1,
+			Max:    100,
+			Sum:    101,
+			Count:  2,
+			Sketch: sketch.MarshalBinary(),
+		},
+	})
+	assert.NoError(t, err)
+	_, _ = buf.Write(data3)
+
 	decoder, releaseFunc := NewBrokerRowFlatDecoder(
 		buf.Bytes(),
 		[]byte("lindb-ns"),
@@ -81,6 +99,19 @@ func Test_NewBrokerRowFlatDecoder(t *testing.T) {
 	assert.True(t, decoder.HasNext())
 	assert.NoError(t, decoder.DecodeTo(&row))
 
+	// quantile sketch
+	assert.True(t, decoder.HasNext())
+	assert.NoError(t, decoder.DecodeTo(&row))
+	var sr StorageRow
+	sr.Unmarshal(row.buffer[flatbuffers.SizeUOffsetT:])
+	itr, ok := sr.NewCompoundFieldIterator()
+	assert.True(t, ok)
+	assert.True(t, itr.HasSketch())
+	assert.Equal(t, 101.0, itr.Sum())
+	rowSketch, err := itr.Sketch()
+	assert.NoError(t, err)
+	assert.Equal(t, sketch.Count(), rowSketch.Count())
+
 	assert.False(t, decoder.HasNext())
 	assert.Error(t, decoder.DecodeTo(&row))
 }
@@ -18,12 +18,14 @@
 package metric
 
 import (
+	"fmt"
 	"io"
 	"math"
 	"sync"
 
 	flatbuffers "github.com/google/flatbuffers/go"
 
+	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/pkg/fasttime"
 	"github.com/lindb/lindb/pkg/strutil"
 	"github.com/lindb/lindb/proto/gen/v1/flatMetricsV1"
@@ -39,6 +41,12 @@ type BrokerRowProtoConverter struct {
 	kvs        []flatbuffers.UOffsetT
 	fieldNames []flatbuffers.UOffsetT
 	fields     []flatbuffers.UOffsetT
+	exemplars  []flatbuffers.UOffsetT
+	offsets    []flatbuffers.UOffsetT
+	// exemplarBuf holding exemplars of one field for building
+	exemplarBuf []Exemplar
+	// sketch holding quantile sketch for validating
+	sketch *encoding.Sketch
 
 	// ingestion meta info
 	namespace    []byte
@@ -59,6 +67,24 @@ func (rc *BrokerRowProtoConverter) resetForNextConverter() {
 	rc.fieldNames = rc.fieldNames[:0]
 	rc.kvs = rc.kvs[:0]
 	rc.fields = rc.fields[:0]
+	rc.exemplars = rc.exemplars[:0]
+}
+
+// buildExemplars builds the exemplars vector from proto exemplars, returns 0 if exemplars are empty.
+func (rc *BrokerRowProtoConverter) buildExemplars(
+	startVectorFn func(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT,
+	exemplars []*protoMetricsV1.Exemplar,
+) flatbuffers.UOffsetT {
+	rc.exemplarBuf = rc.exemplarBuf[:0]
+	for _, e := range exemplars {
+		if e == nil || len(e.TraceId) == 0 {
+			continue
+		}
+		rc.exemplarBuf = appendExemplar(rc.exemplarBuf, e.TraceId, e.SpanId, e.Duration)
+	}
+	var vector flatbuffers.UOffsetT
+	vector, rc.offsets = buildFlatExemplars(rc.flatBuilder, rc.offsets, startVectorFn, rc.exemplarBuf)
+	return vector
 }
 
 func (rc *BrokerRowProtoConverter) validateMetric(m *protoMetricsV1.Metric) error {
@@ -135,6 +161,9 @@ func (rc *BrokerRowProtoConverter) validateMetric(m *protoMetricsV1.Metric) erro
 	if m.CompoundField == nil {
 		return nil
 	}
+	if len(m.CompoundField.Sketch) > 0 {
+		return rc.validateSketch(m.CompoundField)
+	}
 	// value length zero or length not match
 	if len(m.CompoundField.Values) != len(m.CompoundField.ExplicitBounds) ||
 		len(m.CompoundField.Values) <= 2 {
@@ -165,6 +194,26 @@ func (rc *BrokerRowProtoConverter) validateMetric(m *protoMetricsV1.Metric) erro
 	return nil
 }
 
+// validateSketch validates the compound field with quantile sketch, explicit bounds/values must be empty.
+func (rc *BrokerRowProtoConverter) validateSketch(f *protoMetricsV1.CompoundField) error {
+	if len(f.Values) > 0 || len(f.ExplicitBounds) > 0 {
+		return ErrBadMetricPBFormat
+	}
+	if f.Max < 0 || f.Min < 0 || f.Sum < 0 || f.Count < 0 {
+		return ErrBadMetricPBFormat
+	}
+	if err := rc.sketch.UnmarshalBinary(f.Sketch); err != nil {
+		return fmt.Errorf("%w, bad sketch: %s", ErrBadMetricPBFormat, err)
+	}
+	if rc.sketch.BinsLen() == 0 {
+		return fmt.Errorf("%w, sketch is empty", ErrBadMetricPBFormat)
+	}
+	if err := validateSketchMMSC(rc.sketch, f.Min, f.Max, f.Count); err != nil {
+		return fmt.Errorf("%w, %s", ErrBadMetricPBFormat, err)
+	}
+	return nil
+}
+
 func (rc *BrokerRowProtoConverter) MarshalProtoMetricV1(m *protoMetricsV1.Metric) ([]byte, error) {
 	rc.resetForNextConverter()
 
@@ -188,6 +237,8 @@ func (rc *BrokerRowProtoConverter) MarshalProtoMetricV1(m *protoMetricsV1.Metric
 
 	for i := 0; i < len(m.SimpleFields); i++ {
 		rc.fieldNames = append(rc.fieldNames, rc.flatBuilder.CreateString(m.SimpleFields[i].Name))
+		rc.exemplars = append(rc.exemplars,
+			rc.buildExemplars(flatMetricsV1.SimpleFieldStartExemplarsVector, m.SimpleFields[i].Exemplars))
 	}
 
 	// building field names
@@ -204,8 +255,13 @@ func (rc *BrokerRowProtoConverter) MarshalProtoMetricV1(m *protoMetricsV1.Metric
 			flatMetricsV1.SimpleFieldAddType(rc.flatBuilder, flatMetricsV1.SimpleFieldTypeMax)
 		case protoMetricsV1.SimpleFieldType_Min:
 			flatMetricsV1.SimpleFieldAddType(rc.flatBuilder, flatMetricsV1.SimpleFieldTypeMin)
+		case protoMetricsV1.SimpleFieldType_CUMULATIVE_SUM:
+			flatMetricsV1.SimpleFieldAddType(rc.flatBuilder, flatMetricsV1.SimpleFieldTypeCumulativeSum)
 		}
 		flatMetricsV1.SimpleFieldAddValue(rc.flatBuilder, sf.Value)
+		if rc.exemplars[i] != 0 {
+			flatMetricsV1.SimpleFieldAddExemplars(rc.flatBuilder, rc.exemplars[i])
+		}
 		rc.fields = append(rc.fields, flatMetricsV1.SimpleFieldEnd(rc.flatBuilder))
 	}
 
@@ -226,7 +282,9 @@ func (rc *BrokerRowProtoConverter) MarshalProtoMetricV1(m *protoMetricsV1.Metric
 	var (
 		compoundFieldBounds flatbuffers.UOffsetT
 		compoundFieldValues flatbuffers.UOffsetT
+		compoundFieldSketch flatbuffers.UOffsetT
 		compoundField       flatbuffers.UOffsetT
+		compoundExemplars   flatbuffers.UOffsetT
 	)
 
 	if m.CompoundField == nil {
@@ -245,15 +303,25 @@ func (rc *BrokerRowProtoConverter) MarshalProtoMetricV1(m *protoMetricsV1.Metric
 		rc.flatBuilder.PrependFloat64(m.CompoundField.ExplicitBounds[i])
 	}
 	compoundFieldBounds = rc.flatBuilder.EndVector(len(m.CompoundField.ExplicitBounds))
+	if len(m.CompoundField.Sketch) > 0 {
+		compoundFieldSketch = rc.flatBuilder.CreateByteVector(m.CompoundField.Sketch)
+	}
+	compoundExemplars = rc.buildExemplars(flatMetricsV1.CompoundFieldStartExemplarsVector, m.CompoundField.Exemplars)
 
 	// add count sum min max
 	flatMetricsV1.CompoundFieldStart(rc.flatBuilder)
+	if compoundExemplars != 0 {
+		flatMetricsV1.CompoundFieldAddExemplars(rc.flatBuilder, compoundExemplars)
+	}
 	flatMetricsV1.CompoundFieldAddCount(rc.flatBuilder, m.CompoundField.Count)
 	flatMetricsV1.CompoundFieldAddSum(rc.flatBuilder, m.CompoundField.Sum)
 	flatMetricsV1.CompoundFieldAddMin(rc.flatBuilder, m.CompoundField.Min)
 	flatMetricsV1.CompoundFieldAddMax(rc.flatBuilder, m.CompoundField.Max)
 	flatMetricsV1.CompoundFieldAddValues(rc.flatBuilder, compoundFieldValues)
 	flatMetricsV1.CompoundFieldAddExplicitBounds(rc.flatBuilder, compoundFieldBounds)
+	if compoundFieldSketch != 0 {
+		flatMetricsV1.CompoundFieldAddSketch(rc.flatBuilder, compoundFieldSketch)
+	}
 	compoundField = flatMetricsV1.CompoundFieldEnd(rc.flatBuilder)
 
 Serialize:
@@ -319,6 +387,8 @@ func NewProtoConverter() *BrokerRowProtoConverter {
 		fieldNames:  make([]flatbuffers.UOffsetT, 0, 32),
 		kvs:         make([]flatbuffers.UOffsetT, 0, 32),
 		fields:      make([]flatbuffers.UOffsetT, 0, 32),
+		exemplars:   make([]flatbuffers.UOffsetT, 0, 32),
+		sketch:      encoding.NewSketch(),
 	}
 }
 
@@ -24,9 +24,14 @@ import (
 	"strconv"
 	"testing"
 
+	flatbuffers "github.com/google/flatbuffers/go"
+
+	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/pkg/fasttime"
 	"github.com/lindb/lindb/pkg/strutil"
+	"github.com/lindb/lindb/proto/gen/v1/flatMetricsV1"
 	protoMetricsV1 "github.com/lindb/lindb/proto/gen/v1/metrics"
+	"github.com/lindb/lindb/series/field"
 	"github.com/lindb/lindb/series/tag"
 
 	"github.com/stretchr/testify/assert"
@@ -241,6 +246,73 @@ func Test_BrokerRowProtoConverter_ValidateMetric(t *testing.T) {
 			Values:         []float64{1, 2, 3, 4, 5},
 		},
 	}))
+
+	// quantile sketch
+	sketch := encoding.NewSketch()
+	sketch.Add(10)
+	assert.NoError(t, converter.validateMetric(&protoMetricsV1.Metric{
+		Name:          "test-metric",
+		CompoundField: &protoMetricsV1.CompoundField{Sketch: sketch.MarshalBinary(), Count: 1, Sum: 10},
+	}))
+	// sketch with explicit bounds
+	assert.Error(t, converter.validateMetric(&protoMetricsV1.Metric{
+		Name: "test-metric",
+		CompoundField: &protoMetricsV1.CompoundField{
+			Sketch:         sketch.MarshalBinary(),
+			ExplicitBounds: []float64{1, 2, 3, 4, math.Inf(1)},
+			Values:         []float64{1, 2, 3, 4, 5},
+		},
+	}))
+	// sketch with invalid mmsc
+	assert.Error(t, converter.validateMetric(&protoMetricsV1.Metric{
+		Name:          "test-metric",
+		CompoundField: &protoMetricsV1.CompoundField{Sketch: sketch.MarshalBinary(), Count: -1},
+	}))
+	// bad sketch
+	assert.Error(t, converter.validateMetric(&protoMetricsV1.Metric{
+		Name:          "test-metric",
+		CompoundField: &protoMetricsV1.CompoundField{Sketch: []byte{1, 2}},
+	}))
+	// empty sketch
+	assert.Error(t, converter.validateMetric(&protoMetricsV1.Metric{
+		Name:          "test-metric",
+		CompoundField: &protoMetricsV1.CompoundField{Sketch: encoding.NewSketch().MarshalBinary()},
+	}))
+}
+
+func Test_BrokerRowProtoConverter_Sketch(t *testing.T) {
+	sketch := encoding.NewSketch()
+	for i := 1; i <= 100; i++ {
+		sketch.Add(float64(i))
+	}
+	m := &protoMetricsV1.Metric{
+		Name:      "test-metric",
+		Timestamp: fasttime.UnixMilliseconds(),
+		CompoundField: &protoMetricsV1.CompoundField{
+			Sketch: sketch.MarshalBinary(),
+			Count:  100,
+			Sum:    5050,
+		},
+	}
+	// proto binary round trip
+	data, err := m.Marshal()
+	assert.NoError(t, err)
+	var m2 protoMetricsV1.Metric
+	assert.NoError(t, m2.Unmarshal(data))
+	assert.Equal(t, m.CompoundField.Sketch, m2.CompoundField.Sketch)
+
+	converter := NewProtoConverter()
+	data, err = converter.MarshalProtoMetricV1(&m2)
+	assert.NoError(t, err)
+	var row StorageRow
+	row.Unmarshal(data[flatbuffers.SizeUOffsetT:])
+	itr, ok := row.NewCompoundFieldIterator()
+	assert.True(t, ok)
+	assert.Zero(t, itr.BucketLen())
+	rowSketch, err := itr.Sketch()
+	assert.NoError(t, err)
+	assert.Equal(t, sketch.BinsLen(), rowSketch.BinsLen())
+	assert.Equal(t, sketch.Count(), rowSketch.Count())
 }
 
 func Test_BrokerRowProtoConverter_MarshalProtoMetricV1(t *testing.T) {
@@ -285,4 +357,52 @@ func Test_BrokerRowProtoConverter_MarshalProtoMetricV1(t *testing.T) {
 	_, err = converter.MarshalProtoMetricListV1To(ml, &buf)
 	assert.NoError(t, err)
 
+	// marshal with exemplars
+	m.SimpleFields[0].Exemplars = []*protoMetricsV1.Exemplar{
+		nil,
+		{TraceId: []byte("trace1"), SpanId: []byte("span1"), Duration: 10},
+	}
+	m.CompoundField = &protoMetricsV1.CompoundField{
+		Values:         []float64{1, 2, 3},
+		ExplicitBounds: []float64{1, 2, math.Inf(1)},
+		Exemplars:      []*protoMetricsV1.Exemplar{{TraceId: []byte("trace2"), Duration: 20}},
+	}
+	assert.NoError(t, converter.ConvertTo(m, &row))
+	var (
+		e  Exemplar
+		sr StorageRow
+	)
+	sr.Unmarshal(row.buffer[flatbuffers.SizeUOffsetT:])
+	itr := sr.NewSimpleFieldIterator()
+	assert.True(t, itr.HasNext())
+	assert.Equal(t, 1, itr.NextExemplarsLen())
+	itr.NextExemplar(0, &e)
+	assert.Equal(t, Exemplar{TraceID: []byte("trace1"), SpanID: []byte("span1"), Duration: 10}, e)
+	cfItr, ok := sr.NewCompoundFieldIterator()
+	assert.True(t, ok)
+	assert.Equal(t, 1, cfItr.ExemplarsLen())
+	cfItr.Exemplar(0, &e)
+	assert.Equal(t, "trace2", string(e.TraceID))
+}
+
+func Test_BrokerRowProtoConverter_CumulativeSum(t *testing.T) {
+	converter := NewProtoConverter()
+	data, err := converter.MarshalProtoMetricV1(&protoMetricsV1.Metric{
+		Name:      "test-metric",
+		Timestamp: fasttime.UnixMilliseconds(),
+		SimpleFields: []*protoMetricsV1.SimpleField{
+			{Name: "f1", Type: protoMetricsV1.SimpleFieldType_CUMULATIVE_SUM, Value: 10},
+		},
+	})
+	assert.NoError(t, err)
+	var row StorageRow
+	row.Unmarshal(data[flatbuffers.SizeUOffsetT:])
+	itr := row.NewSimpleFieldIterator()
+	assert.True(t, itr.HasNext())
+	assert.Equal(t, flatMetricsV1.SimpleFieldTypeCumulativeSum, itr.NextRawType())
+	assert.Equal(t, field.SumField, itr.NextType())
+	assert.True(t, itr.MutateNextValue(3))
+	itr.Reset()
+	assert.True(t, itr.HasNext())
+	assert.Equal(t, float64(3), itr.NextValue())
 }
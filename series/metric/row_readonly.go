@@ -23,6 +23,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/proto/gen/v1/flatMetricsV1"
 	"github.com/lindb/lindb/series/field"
 )
@@ -92,6 +93,7 @@ func (itr *KeyValueIterator) Reset()            { itr.idx = -1 }
 type SimpleFieldIterator struct {
 	m   *flatMetricsV1.Metric
 	f   flatMetricsV1.SimpleField
+	e   flatMetricsV1.Exemplar
 	idx int
 	num int
 }
@@ -111,10 +113,15 @@ func (itr *SimpleFieldIterator) NextName() field.Name                       { re
 func (itr *SimpleFieldIterator) NextRawName() []byte                        { return itr.f.Name() }
 func (itr *SimpleFieldIterator) NextValue() float64                         { return itr.f.Value() }
 func (itr *SimpleFieldIterator) NextRawType() flatMetricsV1.SimpleFieldType { return itr.f.Type() }
+func (itr *SimpleFieldIterator) NextExemplarsLen() int                      { return itr.f.ExemplarsLength() }
+
+// MutateNextValue overwrites the value of current simple field in place.
+func (itr *SimpleFieldIterator) MutateNextValue(v float64) bool { return itr.f.MutateValue(v) }
+
 func (itr *SimpleFieldIterator) NextType() field.Type {
 	switch itr.f.Type() {
-	// assertion: cumulative should be converted before writing into memdb
-	case flatMetricsV1.SimpleFieldTypeDeltaSum:
+	// cumulative sum is converted into delta by shard before writing into memdb
+	case flatMetricsV1.SimpleFieldTypeDeltaSum, flatMetricsV1.SimpleFieldTypeCumulativeSum:
 		return field.SumField
 	case flatMetricsV1.SimpleFieldTypeGauge:
 		return field.GaugeField
@@ -127,11 +134,21 @@ func (itr *SimpleFieldIterator) NextType() field.Type {
 	}
 }
 
+// NextExemplar reads the exemplar of current simple field by index.
+func (itr *SimpleFieldIterator) NextExemplar(idx int, e *Exemplar) {
+	if itr.f.Exemplars(&itr.e, idx) {
+		e.FromFlat(&itr.e)
+	}
+}
+
 type CompoundFieldIterator struct {
 	m   *flatMetricsV1.Metric
 	f   flatMetricsV1.CompoundField
+	e   flatMetricsV1.Exemplar
 	idx int
 	num int
+
+	sketch *encoding.Sketch // lazy initialization
 }
 
 func (itr *CompoundFieldIterator) HasNextBucket() bool {
@@ -148,21 +165,68 @@ func (itr *CompoundFieldIterator) Min() float64       { return itr.f.Min() }
 func (itr *CompoundFieldIterator) Max() float64       { return itr.f.Max() }
 func (itr *CompoundFieldIterator) Sum() float64       { return itr.f.Sum() }
 func (itr *CompoundFieldIterator) Count() float64     { return itr.f.Count() }
+func (itr *CompoundFieldIterator) ExemplarsLen() int  { return itr.f.ExemplarsLength() }
+
+// Exemplar reads the exemplar of compound field by index.
+func (itr *CompoundFieldIterator) Exemplar(idx int, e *Exemplar) {
+	if itr.f.Exemplars(&itr.e, idx) {
+		e.FromFlat(&itr.e)
+	}
+}
+
+// HasSketch checks if compound field is a quantile sketch.
+func (itr *CompoundFieldIterator) HasSketch() bool { return itr.f.SketchLength() > 0 }
+
+// SketchBytes returns the binary of quantile sketch, returns nil if compound field has no sketch.
+func (itr *CompoundFieldIterator) SketchBytes() []byte { return itr.f.SketchBytes() }
+
+// Sketch decodes the quantile sketch of compound field, returns nil if compound field has no sketch.
+func (itr *CompoundFieldIterator) Sketch() (*encoding.Sketch, error) {
+	data := itr.f.SketchBytes()
+	if len(data) == 0 {
+		return nil, nil
+	}
+	if itr.sketch == nil {
+		itr.sketch = encoding.NewSketch()
+	}
+	if err := itr.sketch.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return itr.sketch, nil
+}
+
+// validateSketchMMSC validates the min/max/count of compound field with quantile sketch,
+// count must be equal to the count of sketch, because count/sum/min/max are stored with sketch.
+func validateSketchMMSC(sketch *encoding.Sketch, min, max, count float64) error {
+	if count != sketch.Count() {
+		return fmt.Errorf("compound count: %f != sketch count: %f", count, sketch.Count())
+	}
+	if min > max {
+		return fmt.Errorf("compound min: %f > max: %f", min, max)
+	}
+	return nil
+}
+
 func (itr *CompoundFieldIterator) BucketName() field.Name {
 	return field.Name(BucketNameOfHistogramExplicitBound(itr.NextExplicitBound()))
 }
 
+// reserved field names of compound field
 const (
-	histogramSum   = field.Name("HistogramSum")
-	histogramCount = field.Name("HistogramCount")
-	histogramMax   = field.Name("HistogramMax")
-	histogramMin   = field.Name("HistogramMin")
+	HistogramSum   = field.Name("HistogramSum")
+	HistogramCount = field.Name("HistogramCount")
+	HistogramMax   = field.Name("HistogramMax")
+	HistogramMin   = field.Name("HistogramMin")
+	// SketchName is the field name of quantile sketch, field value is the count of sketch,
+	// and the binary of sketch is stored in sketch family of segment.
+	SketchName = field.Name("__sketch")
 )
 
-func (itr *CompoundFieldIterator) HistogramSumFieldName() field.Name   { return histogramSum }
-func (itr *CompoundFieldIterator) HistogramCountFieldName() field.Name { return histogramCount }
-func (itr *CompoundFieldIterator) HistogramMaxFieldName() field.Name   { return histogramMax }
-func (itr *CompoundFieldIterator) HistogramMinFieldName() field.Name   { return histogramMin }
+func (itr *CompoundFieldIterator) HistogramSumFieldName() field.Name   { return HistogramSum }
+func (itr *CompoundFieldIterator) HistogramCountFieldName() field.Name { return HistogramCount }
+func (itr *CompoundFieldIterator) HistogramMaxFieldName() field.Name   { return HistogramMax }
+func (itr *CompoundFieldIterator) HistogramMinFieldName() field.Name   { return HistogramMin }
+func (itr *CompoundFieldIterator) SketchFieldName() field.Name         { return SketchName }
 
 // BucketNameOfHistogramExplicitBound converts reserved field-name for histogram buckets.
 func BucketNameOfHistogramExplicitBound(upperBound float64) string {
@@ -181,3 +245,24 @@ func UpperBound(bucketName string) (float64, error) {
 	raw := bucketName[len("__bucket_"):]
 	return strconv.ParseFloat(raw, 64)
 }
+
+const sketchBinPrefix = "__sketch_"
+
+// SketchBinName converts reserved field-name for quantile sketch bin,
+// sketch bins are expanded from the sketches of series when querying.
+func SketchBinName(index int32) field.Name {
+	return field.Name(sketchBinPrefix + strconv.FormatInt(int64(index), 10))
+}
+
+// SketchBinIndex extracts the bin index from sketch bin name
+func SketchBinIndex(binName string) (int32, error) {
+	// make sure it has prefix with __sketch_
+	if !strings.HasPrefix(binName, sketchBinPrefix) {
+		return 0, fmt.Errorf("binName:%s not startswith '%s'", binName, sketchBinPrefix)
+	}
+	index, err := strconv.ParseInt(binName[len(sketchBinPrefix):], 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return int32(index), nil
+}
@@ -32,6 +32,12 @@ type StorageRow struct {
 	SeriesID  uint32
 	SlotIndex uint16
 	FieldIDs  []field.ID
+	// GaugeAggFieldIDs holds ids of hidden aggregate fields for each gauge field(same order as simple fields),
+	// empty if gauge aggregates are not kept for database.
+	GaugeAggFieldIDs []field.ID
+	// SkippedFields holds indexes of simple fields which are not written into memory database,
+	// e.g. cumulative sum field whose delta cannot be calculated.
+	SkippedFields []int
 
 	Writable bool // Writable symbols if all meta information is set
 	readOnlyRow
@@ -44,9 +50,21 @@ func (mr *StorageRow) Unmarshal(data []byte) {
 	mr.SeriesID = 0
 	mr.SlotIndex = 0
 	mr.FieldIDs = mr.FieldIDs[:0]
+	mr.GaugeAggFieldIDs = mr.GaugeAggFieldIDs[:0]
+	mr.SkippedFields = mr.SkippedFields[:0]
 	mr.Writable = false
 }
 
+// IsFieldSkipped returns if the simple field of index is not written into memory database.
+func (mr *StorageRow) IsFieldSkipped(idx int) bool {
+	for _, skipped := range mr.SkippedFields {
+		if skipped == idx {
+			return true
+		}
+	}
+	return false
+}
+
 // StorageBatchRows holds multi rows for inserting into memdb
 // It is reused in sync.Pool
 type StorageBatchRows struct {
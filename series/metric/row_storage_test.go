@@ -183,6 +183,11 @@ func Test_MetricRow_WithCompoundField(t *testing.T) {
 	_ = itr.HistogramMaxFieldName()
 	_ = itr.HistogramMinFieldName()
 
+	// no sketch
+	sketch, err := itr.Sketch()
+	assert.NoError(t, err)
+	assert.Nil(t, sketch)
+
 	for i := 0; i < 10; i++ {
 		itr.Reset()
 		var count int
@@ -310,3 +315,15 @@ func Test_HistogramConverter(t *testing.T) {
 	_, err = UpperBound("__bucket_x")
 	assert.NotNil(t, err)
 }
+
+func Test_SketchBinConverter(t *testing.T) {
+	assert.Equal(t, field.Name("__sketch_10"), SketchBinName(10))
+	assert.Equal(t, field.Name("__sketch_-2147483648"), SketchBinName(math.MinInt32))
+	index, err := SketchBinIndex("__sketch_-10")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(-10), index)
+	_, err = SketchBinIndex("__bucket_10")
+	assert.Error(t, err)
+	_, err = SketchBinIndex("__sketch_x")
+	assert.Error(t, err)
+}
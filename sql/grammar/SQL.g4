@@ -10,7 +10,8 @@ statementList           : showDatabaseStmt
                         | showFieldsStmt
                         | showTagKeysStmt
                         | showTagValuesStmt
-                        | queryStmt;
+                        | queryStmt
+                        | deleteStmt;
 //meta data query statement
 showDatabaseStmt     : T_SHOW T_DATASBAES ;
 showNameSpacesStmt   : T_SHOW T_NAMESPACES (T_WHERE T_NAMESPACE T_EQUAL prefix)? limitClause?;
@@ -23,7 +24,7 @@ withTagKey           : ident ;
 namespace            : ident ;
 
 //data query plan
-queryStmt               : T_EXPLAIN? selectExpr (T_ON namespace)? fromClause whereClause? groupByClause? orderByClause? limitClause? T_WITH_VALUE?;
+queryStmt               : T_EXPLAIN? selectExpr (T_ON namespace)? fromClause whereClause? groupByClause? orderByClause? limitClause? T_WITH_VALUE? (T_WITH T_EXEMPLARS)?;
 selectExpr              : T_SELECT fields;
 //select fields
 fields                  : field ( T_COMMA field )* ;
@@ -206,8 +207,13 @@ nonReservedWords      :
                         | T_WEEK
                         | T_MONTH
                         | T_YEAR
+                        | T_EXEMPLARS
+                        | T_DELETE
                         ;
 
+//delete series data statement
+deleteStmt              : T_DELETE (T_ON namespace)? fromClause whereClause ;
+
 // Lexer rules
 T_CREATE             : C R E A T E                      ;
 T_UPDATE             : U P D A T E                      ;
@@ -315,6 +321,9 @@ T_DIV                :  '/'   ;
 T_MUL                :  '*'   ;
 T_MOD                :  '%'   ;
 
+T_EXEMPLARS          : E X E M P L A R S                ;
+T_DELETE             : D E L E T E                      ;
+
 L_ID                 : L_ID_PART ;
 L_INT                : L_DIGIT+       ;                                               // Integer
 L_DEC                : L_DIGIT+ '.' ~'.' L_DIGIT*                               // Decimal number
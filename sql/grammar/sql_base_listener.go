@@ -366,3 +366,9 @@ func (s *BaseSQLListener) EnterNonReservedWords(ctx *NonReservedWordsContext) {}
 
 // ExitNonReservedWords is called when production nonReservedWords is exited.
 func (s *BaseSQLListener) ExitNonReservedWords(ctx *NonReservedWordsContext) {}
+
+// EnterDeleteStmt is called when production deleteStmt is entered.
+func (s *BaseSQLListener) EnterDeleteStmt(ctx *DeleteStmtContext) {}
+
+// ExitDeleteStmt is called when production deleteStmt is exited.
+func (s *BaseSQLListener) ExitDeleteStmt(ctx *DeleteStmtContext) {}
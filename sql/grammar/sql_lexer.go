@@ -31,7 +31,7 @@ var _ = fmt.Printf
 var _ = unicode.IsLetter
 
 var serializedLexerAtn = []uint16{
-	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 2, 105, 891,
+	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 2, 107, 912,
 	8, 1, 4, 2, 9, 2, 4, 3, 9, 3, 4, 4, 9, 4, 4, 5, 9, 5, 4, 6, 9, 6, 4, 7,
 	9, 7, 4, 8, 9, 8, 4, 9, 9, 9, 4, 10, 9, 10, 4, 11, 9, 11, 4, 12, 9, 12,
 	4, 13, 9, 13, 4, 14, 9, 14, 4, 15, 9, 15, 4, 16, 9, 16, 4, 17, 9, 17, 4,
@@ -50,15 +50,15 @@ var serializedLexerAtn = []uint16{
 	81, 9, 81, 4, 82, 9, 82, 4, 83, 9, 83, 4, 84, 9, 84, 4, 85, 9, 85, 4, 86,
 	9, 86, 4, 87, 9, 87, 4, 88, 9, 88, 4, 89, 9, 89, 4, 90, 9, 90, 4, 91, 9,
 	91, 4, 92, 9, 92, 4, 93, 9, 93, 4, 94, 9, 94, 4, 95, 9, 95, 4, 96, 9, 96,
-	4, 97, 9, 97, 4, 98, 9, 98, 4, 99, 9, 99, 4, 100, 9, 100, 4, 101, 9, 101,
-	4, 102, 9, 102, 4, 103, 9, 103, 4, 104, 9, 104, 4, 105, 9, 105, 4, 106,
-	9, 106, 4, 107, 9, 107, 4, 108, 9, 108, 4, 109, 9, 109, 4, 110, 9, 110,
-	4, 111, 9, 111, 4, 112, 9, 112, 4, 113, 9, 113, 4, 114, 9, 114, 4, 115,
-	9, 115, 4, 116, 9, 116, 4, 117, 9, 117, 4, 118, 9, 118, 4, 119, 9, 119,
-	4, 120, 9, 120, 4, 121, 9, 121, 4, 122, 9, 122, 4, 123, 9, 123, 4, 124,
-	9, 124, 4, 125, 9, 125, 4, 126, 9, 126, 4, 127, 9, 127, 4, 128, 9, 128,
-	4, 129, 9, 129, 4, 130, 9, 130, 4, 131, 9, 131, 4, 132, 9, 132, 4, 133,
-	9, 133, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 3, 3, 3, 3, 3, 3,
+	4, 97, 9, 97, 4, 98, 9, 98, 4, 99, 9, 99, 4, 100, 9, 100, 4, 103, 9, 103,
+	4, 104, 9, 104, 4, 105, 9, 105, 4, 106, 9, 106, 4, 107, 9, 107, 4, 108,
+	9, 108, 4, 109, 9, 109, 4, 110, 9, 110, 4, 111, 9, 111, 4, 112, 9, 112,
+	4, 113, 9, 113, 4, 114, 9, 114, 4, 115, 9, 115, 4, 116, 9, 116, 4, 117,
+	9, 117, 4, 118, 9, 118, 4, 119, 9, 119, 4, 120, 9, 120, 4, 121, 9, 121,
+	4, 122, 9, 122, 4, 123, 9, 123, 4, 124, 9, 124, 4, 125, 9, 125, 4, 126,
+	9, 126, 4, 127, 9, 127, 4, 128, 9, 128, 4, 129, 9, 129, 4, 130, 9, 130,
+	4, 131, 9, 131, 4, 132, 9, 132, 4, 133, 9, 133, 4, 134, 9, 134, 4, 135,
+	9, 135, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 4, 3, 4, 3, 4, 3, 4, 3, 5, 3, 5, 3, 5, 3, 5, 3,
 	5, 3, 6, 3, 6, 3, 6, 3, 6, 3, 6, 3, 6, 3, 6, 3, 6, 3, 6, 3, 7, 3, 7, 3,
 	7, 3, 7, 3, 7, 3, 8, 3, 8, 3, 8, 3, 8, 3, 8, 3, 8, 3, 9, 3, 9, 3, 9, 3,
@@ -103,83 +103,86 @@ var serializedLexerAtn = []uint16{
 	85, 3, 85, 3, 86, 3, 86, 3, 86, 3, 87, 3, 87, 3, 87, 3, 88, 3, 88, 3, 88,
 	3, 89, 3, 89, 3, 90, 3, 90, 3, 91, 3, 91, 3, 92, 3, 92, 3, 93, 3, 93, 3,
 	94, 3, 94, 3, 95, 3, 95, 3, 96, 3, 96, 3, 97, 3, 97, 3, 98, 3, 98, 3, 99,
-	3, 99, 3, 100, 3, 100, 3, 101, 3, 101, 3, 102, 6, 102, 752, 10, 102, 13,
-	102, 14, 102, 753, 3, 103, 6, 103, 757, 10, 103, 13, 103, 14, 103, 758,
-	3, 103, 3, 103, 3, 103, 7, 103, 764, 10, 103, 12, 103, 14, 103, 767, 11,
-	103, 3, 103, 3, 103, 6, 103, 771, 10, 103, 13, 103, 14, 103, 772, 5, 103,
-	775, 10, 103, 3, 104, 6, 104, 778, 10, 104, 13, 104, 14, 104, 779, 3, 104,
-	3, 104, 3, 105, 3, 105, 3, 106, 3, 106, 3, 107, 3, 107, 3, 107, 3, 107,
-	7, 107, 792, 10, 107, 12, 107, 14, 107, 795, 11, 107, 3, 107, 3, 107, 3,
-	107, 7, 107, 800, 10, 107, 12, 107, 14, 107, 803, 11, 107, 3, 107, 3, 107,
-	3, 107, 3, 107, 3, 107, 6, 107, 810, 10, 107, 13, 107, 14, 107, 811, 3,
-	107, 3, 107, 7, 107, 816, 10, 107, 12, 107, 14, 107, 819, 11, 107, 3, 107,
-	3, 107, 3, 107, 7, 107, 824, 10, 107, 12, 107, 14, 107, 827, 11, 107, 3,
-	107, 3, 107, 3, 107, 7, 107, 832, 10, 107, 12, 107, 14, 107, 835, 11, 107,
-	3, 107, 5, 107, 838, 10, 107, 3, 108, 3, 108, 3, 109, 3, 109, 3, 110, 3,
-	110, 3, 111, 3, 111, 3, 112, 3, 112, 3, 113, 3, 113, 3, 114, 3, 114, 3,
-	115, 3, 115, 3, 116, 3, 116, 3, 117, 3, 117, 3, 118, 3, 118, 3, 119, 3,
-	119, 3, 120, 3, 120, 3, 121, 3, 121, 3, 122, 3, 122, 3, 123, 3, 123, 3,
-	124, 3, 124, 3, 125, 3, 125, 3, 126, 3, 126, 3, 127, 3, 127, 3, 128, 3,
-	128, 3, 129, 3, 129, 3, 130, 3, 130, 3, 131, 3, 131, 3, 132, 3, 132, 3,
-	133, 3, 133, 6, 801, 817, 825, 833, 2, 134, 3, 3, 5, 4, 7, 5, 9, 6, 11,
-	7, 13, 8, 15, 9, 17, 10, 19, 11, 21, 12, 23, 13, 25, 14, 27, 15, 29, 16,
-	31, 17, 33, 18, 35, 19, 37, 20, 39, 21, 41, 22, 43, 23, 45, 24, 47, 25,
-	49, 26, 51, 27, 53, 28, 55, 29, 57, 30, 59, 31, 61, 32, 63, 33, 65, 34,
-	67, 35, 69, 36, 71, 37, 73, 38, 75, 39, 77, 40, 79, 41, 81, 42, 83, 43,
-	85, 44, 87, 45, 89, 46, 91, 47, 93, 48, 95, 49, 97, 50, 99, 51, 101, 52,
-	103, 53, 105, 54, 107, 55, 109, 56, 111, 57, 113, 58, 115, 59, 117, 60,
-	119, 61, 121, 62, 123, 63, 125, 64, 127, 65, 129, 66, 131, 67, 133, 68,
-	135, 69, 137, 70, 139, 71, 141, 72, 143, 73, 145, 74, 147, 75, 149, 76,
-	151, 77, 153, 78, 155, 79, 157, 80, 159, 81, 161, 82, 163, 83, 165, 84,
-	167, 85, 169, 86, 171, 87, 173, 88, 175, 89, 177, 90, 179, 91, 181, 92,
-	183, 93, 185, 94, 187, 95, 189, 96, 191, 97, 193, 98, 195, 99, 197, 100,
-	199, 101, 201, 102, 203, 103, 205, 104, 207, 105, 209, 2, 211, 2, 213,
-	2, 215, 2, 217, 2, 219, 2, 221, 2, 223, 2, 225, 2, 227, 2, 229, 2, 231,
-	2, 233, 2, 235, 2, 237, 2, 239, 2, 241, 2, 243, 2, 245, 2, 247, 2, 249,
-	2, 251, 2, 253, 2, 255, 2, 257, 2, 259, 2, 261, 2, 263, 2, 265, 2, 3, 2,
-	34, 3, 2, 48, 48, 5, 2, 11, 12, 15, 15, 34, 34, 3, 2, 50, 59, 4, 2, 67,
-	92, 99, 124, 4, 2, 48, 48, 97, 97, 6, 2, 37, 38, 60, 60, 66, 66, 97, 97,
-	4, 2, 67, 67, 99, 99, 4, 2, 68, 68, 100, 100, 4, 2, 69, 69, 101, 101, 4,
-	2, 70, 70, 102, 102, 4, 2, 71, 71, 103, 103, 4, 2, 72, 72, 104, 104, 4,
-	2, 73, 73, 105, 105, 4, 2, 74, 74, 106, 106, 4, 2, 75, 75, 107, 107, 4,
-	2, 76, 76, 108, 108, 4, 2, 77, 77, 109, 109, 4, 2, 78, 78, 110, 110, 4,
-	2, 79, 79, 111, 111, 4, 2, 80, 80, 112, 112, 4, 2, 81, 81, 113, 113, 4,
-	2, 82, 82, 114, 114, 4, 2, 83, 83, 115, 115, 4, 2, 84, 84, 116, 116, 4,
-	2, 85, 85, 117, 117, 4, 2, 86, 86, 118, 118, 4, 2, 87, 87, 119, 119, 4,
-	2, 88, 88, 120, 120, 4, 2, 89, 89, 121, 121, 4, 2, 90, 90, 122, 122, 4,
-	2, 91, 91, 123, 123, 4, 2, 92, 92, 124, 124, 2, 882, 2, 3, 3, 2, 2, 2,
-	2, 5, 3, 2, 2, 2, 2, 7, 3, 2, 2, 2, 2, 9, 3, 2, 2, 2, 2, 11, 3, 2, 2, 2,
-	2, 13, 3, 2, 2, 2, 2, 15, 3, 2, 2, 2, 2, 17, 3, 2, 2, 2, 2, 19, 3, 2, 2,
-	2, 2, 21, 3, 2, 2, 2, 2, 23, 3, 2, 2, 2, 2, 25, 3, 2, 2, 2, 2, 27, 3, 2,
-	2, 2, 2, 29, 3, 2, 2, 2, 2, 31, 3, 2, 2, 2, 2, 33, 3, 2, 2, 2, 2, 35, 3,
-	2, 2, 2, 2, 37, 3, 2, 2, 2, 2, 39, 3, 2, 2, 2, 2, 41, 3, 2, 2, 2, 2, 43,
-	3, 2, 2, 2, 2, 45, 3, 2, 2, 2, 2, 47, 3, 2, 2, 2, 2, 49, 3, 2, 2, 2, 2,
-	51, 3, 2, 2, 2, 2, 53, 3, 2, 2, 2, 2, 55, 3, 2, 2, 2, 2, 57, 3, 2, 2, 2,
-	2, 59, 3, 2, 2, 2, 2, 61, 3, 2, 2, 2, 2, 63, 3, 2, 2, 2, 2, 65, 3, 2, 2,
-	2, 2, 67, 3, 2, 2, 2, 2, 69, 3, 2, 2, 2, 2, 71, 3, 2, 2, 2, 2, 73, 3, 2,
-	2, 2, 2, 75, 3, 2, 2, 2, 2, 77, 3, 2, 2, 2, 2, 79, 3, 2, 2, 2, 2, 81, 3,
-	2, 2, 2, 2, 83, 3, 2, 2, 2, 2, 85, 3, 2, 2, 2, 2, 87, 3, 2, 2, 2, 2, 89,
-	3, 2, 2, 2, 2, 91, 3, 2, 2, 2, 2, 93, 3, 2, 2, 2, 2, 95, 3, 2, 2, 2, 2,
-	97, 3, 2, 2, 2, 2, 99, 3, 2, 2, 2, 2, 101, 3, 2, 2, 2, 2, 103, 3, 2, 2,
-	2, 2, 105, 3, 2, 2, 2, 2, 107, 3, 2, 2, 2, 2, 109, 3, 2, 2, 2, 2, 111,
-	3, 2, 2, 2, 2, 113, 3, 2, 2, 2, 2, 115, 3, 2, 2, 2, 2, 117, 3, 2, 2, 2,
-	2, 119, 3, 2, 2, 2, 2, 121, 3, 2, 2, 2, 2, 123, 3, 2, 2, 2, 2, 125, 3,
-	2, 2, 2, 2, 127, 3, 2, 2, 2, 2, 129, 3, 2, 2, 2, 2, 131, 3, 2, 2, 2, 2,
-	133, 3, 2, 2, 2, 2, 135, 3, 2, 2, 2, 2, 137, 3, 2, 2, 2, 2, 139, 3, 2,
-	2, 2, 2, 141, 3, 2, 2, 2, 2, 143, 3, 2, 2, 2, 2, 145, 3, 2, 2, 2, 2, 147,
-	3, 2, 2, 2, 2, 149, 3, 2, 2, 2, 2, 151, 3, 2, 2, 2, 2, 153, 3, 2, 2, 2,
-	2, 155, 3, 2, 2, 2, 2, 157, 3, 2, 2, 2, 2, 159, 3, 2, 2, 2, 2, 161, 3,
-	2, 2, 2, 2, 163, 3, 2, 2, 2, 2, 165, 3, 2, 2, 2, 2, 167, 3, 2, 2, 2, 2,
-	169, 3, 2, 2, 2, 2, 171, 3, 2, 2, 2, 2, 173, 3, 2, 2, 2, 2, 175, 3, 2,
-	2, 2, 2, 177, 3, 2, 2, 2, 2, 179, 3, 2, 2, 2, 2, 181, 3, 2, 2, 2, 2, 183,
-	3, 2, 2, 2, 2, 185, 3, 2, 2, 2, 2, 187, 3, 2, 2, 2, 2, 189, 3, 2, 2, 2,
-	2, 191, 3, 2, 2, 2, 2, 193, 3, 2, 2, 2, 2, 195, 3, 2, 2, 2, 2, 197, 3,
-	2, 2, 2, 2, 199, 3, 2, 2, 2, 2, 201, 3, 2, 2, 2, 2, 203, 3, 2, 2, 2, 2,
-	205, 3, 2, 2, 2, 2, 207, 3, 2, 2, 2, 3, 267, 3, 2, 2, 2, 5, 274, 3, 2,
-	2, 2, 7, 281, 3, 2, 2, 2, 9, 285, 3, 2, 2, 2, 11, 290, 3, 2, 2, 2, 13,
-	299, 3, 2, 2, 2, 15, 304, 3, 2, 2, 2, 17, 310, 3, 2, 2, 2, 19, 322, 3,
-	2, 2, 2, 21, 326, 3, 2, 2, 2, 23, 334, 3, 2, 2, 2, 25, 342, 3, 2, 2, 2,
-	27, 352, 3, 2, 2, 2, 29, 357, 3, 2, 2, 2, 31, 360, 3, 2, 2, 2, 33, 365,
+	3, 99, 3, 100, 3, 100, 3, 103, 3, 103, 3, 104, 6, 104, 752, 10, 104, 13,
+	104, 14, 104, 753, 3, 105, 6, 105, 757, 10, 105, 13, 105, 14, 105, 758,
+	3, 105, 3, 105, 3, 105, 7, 105, 764, 10, 105, 12, 105, 14, 105, 767, 11,
+	105, 3, 105, 3, 105, 6, 105, 771, 10, 105, 13, 105, 14, 105, 772, 5, 105,
+	775, 10, 105, 3, 106, 6, 106, 778, 10, 106, 13, 106, 14, 106, 779, 3, 106,
+	3, 106, 3, 107, 3, 107, 3, 108, 3, 108, 3, 109, 3, 109, 3, 109, 3, 109,
+	7, 109, 792, 10, 109, 12, 109, 14, 109, 795, 11, 109, 3, 109, 3, 109, 3,
+	109, 7, 109, 800, 10, 109, 12, 109, 14, 109, 803, 11, 109, 3, 109, 3, 109,
+	3, 109, 3, 109, 3, 109, 6, 109, 810, 10, 109, 13, 109, 14, 109, 811, 3,
+	109, 3, 109, 7, 109, 816, 10, 109, 12, 109, 14, 109, 819, 11, 109, 3, 109,
+	3, 109, 3, 109, 7, 109, 824, 10, 109, 12, 109, 14, 109, 827, 11, 109, 3,
+	109, 3, 109, 3, 109, 7, 109, 832, 10, 109, 12, 109, 14, 109, 835, 11, 109,
+	3, 109, 5, 109, 838, 10, 109, 3, 110, 3, 110, 3, 111, 3, 111, 3, 112, 3,
+	112, 3, 113, 3, 113, 3, 114, 3, 114, 3, 115, 3, 115, 3, 116, 3, 116, 3,
+	117, 3, 117, 3, 118, 3, 118, 3, 119, 3, 119, 3, 120, 3, 120, 3, 121, 3,
+	121, 3, 122, 3, 122, 3, 123, 3, 123, 3, 124, 3, 124, 3, 125, 3, 125, 3,
+	126, 3, 126, 3, 127, 3, 127, 3, 128, 3, 128, 3, 129, 3, 129, 3, 130, 3,
+	130, 3, 131, 3, 131, 3, 132, 3, 132, 3, 133, 3, 133, 3, 134, 3, 134, 3,
+	135, 3, 135, 4, 101, 9, 101, 3, 101, 3, 101, 3, 101, 3, 101, 3, 101, 3,
+	101, 3, 101, 3, 101, 3, 101, 3, 101, 4, 102, 9, 102, 3, 102, 3, 102, 3,
+	102, 3, 102, 3, 102, 3, 102, 3, 102, 6, 801, 817, 825, 833, 2, 136, 3,
+	3, 5, 4, 7, 5, 9, 6, 11, 7, 13, 8, 15, 9, 17, 10, 19, 11, 21, 12, 23, 13,
+	25, 14, 27, 15, 29, 16, 31, 17, 33, 18, 35, 19, 37, 20, 39, 21, 41, 22,
+	43, 23, 45, 24, 47, 25, 49, 26, 51, 27, 53, 28, 55, 29, 57, 30, 59, 31,
+	61, 32, 63, 33, 65, 34, 67, 35, 69, 36, 71, 37, 73, 38, 75, 39, 77, 40,
+	79, 41, 81, 42, 83, 43, 85, 44, 87, 45, 89, 46, 91, 47, 93, 48, 95, 49,
+	97, 50, 99, 51, 101, 52, 103, 53, 105, 54, 107, 55, 109, 56, 111, 57, 113,
+	58, 115, 59, 117, 60, 119, 61, 121, 62, 123, 63, 125, 64, 127, 65, 129,
+	66, 131, 67, 133, 68, 135, 69, 137, 70, 139, 71, 141, 72, 143, 73, 145,
+	74, 147, 75, 149, 76, 151, 77, 153, 78, 155, 79, 157, 80, 159, 81, 161,
+	82, 163, 83, 165, 84, 167, 85, 169, 86, 171, 87, 173, 88, 175, 89, 177,
+	90, 179, 91, 181, 92, 183, 93, 185, 94, 187, 95, 189, 96, 191, 97, 193,
+	98, 195, 99, 197, 100, 199, 101, 891, 102, 903, 103, 201, 104, 203, 105,
+	205, 106, 207, 107, 209, 2, 211, 2, 213, 2, 215, 2, 217, 2, 219, 2, 221,
+	2, 223, 2, 225, 2, 227, 2, 229, 2, 231, 2, 233, 2, 235, 2, 237, 2, 239,
+	2, 241, 2, 243, 2, 245, 2, 247, 2, 249, 2, 251, 2, 253, 2, 255, 2, 257,
+	2, 259, 2, 261, 2, 263, 2, 265, 2, 3, 2, 34, 3, 2, 48, 48, 5, 2, 11, 12,
+	15, 15, 34, 34, 3, 2, 50, 59, 4, 2, 67, 92, 99, 124, 4, 2, 48, 48, 97,
+	97, 6, 2, 37, 38, 60, 60, 66, 66, 97, 97, 4, 2, 67, 67, 99, 99, 4, 2, 68,
+	68, 100, 100, 4, 2, 69, 69, 101, 101, 4, 2, 70, 70, 102, 102, 4, 2, 71,
+	71, 103, 103, 4, 2, 72, 72, 104, 104, 4, 2, 73, 73, 105, 105, 4, 2, 74,
+	74, 106, 106, 4, 2, 75, 75, 107, 107, 4, 2, 76, 76, 108, 108, 4, 2, 77,
+	77, 109, 109, 4, 2, 78, 78, 110, 110, 4, 2, 79, 79, 111, 111, 4, 2, 80,
+	80, 112, 112, 4, 2, 81, 81, 113, 113, 4, 2, 82, 82, 114, 114, 4, 2, 83,
+	83, 115, 115, 4, 2, 84, 84, 116, 116, 4, 2, 85, 85, 117, 117, 4, 2, 86,
+	86, 118, 118, 4, 2, 87, 87, 119, 119, 4, 2, 88, 88, 120, 120, 4, 2, 89,
+	89, 121, 121, 4, 2, 90, 90, 122, 122, 4, 2, 91, 91, 123, 123, 4, 2, 92,
+	92, 124, 124, 2, 903, 2, 3, 3, 2, 2, 2, 2, 5, 3, 2, 2, 2, 2, 7, 3, 2, 2,
+	2, 2, 9, 3, 2, 2, 2, 2, 11, 3, 2, 2, 2, 2, 13, 3, 2, 2, 2, 2, 15, 3, 2,
+	2, 2, 2, 17, 3, 2, 2, 2, 2, 19, 3, 2, 2, 2, 2, 21, 3, 2, 2, 2, 2, 23, 3,
+	2, 2, 2, 2, 25, 3, 2, 2, 2, 2, 27, 3, 2, 2, 2, 2, 29, 3, 2, 2, 2, 2, 31,
+	3, 2, 2, 2, 2, 33, 3, 2, 2, 2, 2, 35, 3, 2, 2, 2, 2, 37, 3, 2, 2, 2, 2,
+	39, 3, 2, 2, 2, 2, 41, 3, 2, 2, 2, 2, 43, 3, 2, 2, 2, 2, 45, 3, 2, 2, 2,
+	2, 47, 3, 2, 2, 2, 2, 49, 3, 2, 2, 2, 2, 51, 3, 2, 2, 2, 2, 53, 3, 2, 2,
+	2, 2, 55, 3, 2, 2, 2, 2, 57, 3, 2, 2, 2, 2, 59, 3, 2, 2, 2, 2, 61, 3, 2,
+	2, 2, 2, 63, 3, 2, 2, 2, 2, 65, 3, 2, 2, 2, 2, 67, 3, 2, 2, 2, 2, 69, 3,
+	2, 2, 2, 2, 71, 3, 2, 2, 2, 2, 73, 3, 2, 2, 2, 2, 75, 3, 2, 2, 2, 2, 77,
+	3, 2, 2, 2, 2, 79, 3, 2, 2, 2, 2, 81, 3, 2, 2, 2, 2, 83, 3, 2, 2, 2, 2,
+	85, 3, 2, 2, 2, 2, 87, 3, 2, 2, 2, 2, 89, 3, 2, 2, 2, 2, 91, 3, 2, 2, 2,
+	2, 93, 3, 2, 2, 2, 2, 95, 3, 2, 2, 2, 2, 97, 3, 2, 2, 2, 2, 99, 3, 2, 2,
+	2, 2, 101, 3, 2, 2, 2, 2, 103, 3, 2, 2, 2, 2, 105, 3, 2, 2, 2, 2, 107,
+	3, 2, 2, 2, 2, 109, 3, 2, 2, 2, 2, 111, 3, 2, 2, 2, 2, 113, 3, 2, 2, 2,
+	2, 115, 3, 2, 2, 2, 2, 117, 3, 2, 2, 2, 2, 119, 3, 2, 2, 2, 2, 121, 3,
+	2, 2, 2, 2, 123, 3, 2, 2, 2, 2, 125, 3, 2, 2, 2, 2, 127, 3, 2, 2, 2, 2,
+	129, 3, 2, 2, 2, 2, 131, 3, 2, 2, 2, 2, 133, 3, 2, 2, 2, 2, 135, 3, 2,
+	2, 2, 2, 137, 3, 2, 2, 2, 2, 139, 3, 2, 2, 2, 2, 141, 3, 2, 2, 2, 2, 143,
+	3, 2, 2, 2, 2, 145, 3, 2, 2, 2, 2, 147, 3, 2, 2, 2, 2, 149, 3, 2, 2, 2,
+	2, 151, 3, 2, 2, 2, 2, 153, 3, 2, 2, 2, 2, 155, 3, 2, 2, 2, 2, 157, 3,
+	2, 2, 2, 2, 159, 3, 2, 2, 2, 2, 161, 3, 2, 2, 2, 2, 163, 3, 2, 2, 2, 2,
+	165, 3, 2, 2, 2, 2, 167, 3, 2, 2, 2, 2, 169, 3, 2, 2, 2, 2, 171, 3, 2,
+	2, 2, 2, 173, 3, 2, 2, 2, 2, 175, 3, 2, 2, 2, 2, 177, 3, 2, 2, 2, 2, 179,
+	3, 2, 2, 2, 2, 181, 3, 2, 2, 2, 2, 183, 3, 2, 2, 2, 2, 185, 3, 2, 2, 2,
+	2, 187, 3, 2, 2, 2, 2, 189, 3, 2, 2, 2, 2, 191, 3, 2, 2, 2, 2, 193, 3,
+	2, 2, 2, 2, 195, 3, 2, 2, 2, 2, 197, 3, 2, 2, 2, 2, 199, 3, 2, 2, 2, 2,
+	891, 3, 2, 2, 2, 2, 903, 3, 2, 2, 2, 2, 201, 3, 2, 2, 2, 2, 203, 3, 2,
+	2, 2, 2, 205, 3, 2, 2, 2, 2, 207, 3, 2, 2, 2, 3, 267, 3, 2, 2, 2, 5, 274,
+	3, 2, 2, 2, 7, 281, 3, 2, 2, 2, 9, 285, 3, 2, 2, 2, 11, 290, 3, 2, 2, 2,
+	13, 299, 3, 2, 2, 2, 15, 304, 3, 2, 2, 2, 17, 310, 3, 2, 2, 2, 19, 322,
+	3, 2, 2, 2, 21, 326, 3, 2, 2, 2, 23, 334, 3, 2, 2, 2, 25, 342, 3, 2, 2,
+	2, 27, 352, 3, 2, 2, 2, 29, 357, 3, 2, 2, 2, 31, 360, 3, 2, 2, 2, 33, 365,
 	3, 2, 2, 2, 35, 374, 3, 2, 2, 2, 37, 384, 3, 2, 2, 2, 39, 394, 3, 2, 2,
 	2, 41, 405, 3, 2, 2, 2, 43, 410, 3, 2, 2, 2, 45, 418, 3, 2, 2, 2, 47, 425,
 	3, 2, 2, 2, 49, 431, 3, 2, 2, 2, 51, 438, 3, 2, 2, 2, 53, 442, 3, 2, 2,
@@ -215,154 +218,154 @@ var serializedLexerAtn = []uint16{
 	2, 2, 247, 871, 3, 2, 2, 2, 249, 873, 3, 2, 2, 2, 251, 875, 3, 2, 2, 2,
 	253, 877, 3, 2, 2, 2, 255, 879, 3, 2, 2, 2, 257, 881, 3, 2, 2, 2, 259,
 	883, 3, 2, 2, 2, 261, 885, 3, 2, 2, 2, 263, 887, 3, 2, 2, 2, 265, 889,
-	3, 2, 2, 2, 267, 268, 5, 219, 110, 2, 268, 269, 5, 249, 125, 2, 269, 270,
-	5, 223, 112, 2, 270, 271, 5, 215, 108, 2, 271, 272, 5, 253, 127, 2, 272,
-	273, 5, 223, 112, 2, 273, 4, 3, 2, 2, 2, 274, 275, 5, 255, 128, 2, 275,
-	276, 5, 245, 123, 2, 276, 277, 5, 221, 111, 2, 277, 278, 5, 215, 108, 2,
-	278, 279, 5, 253, 127, 2, 279, 280, 5, 223, 112, 2, 280, 6, 3, 2, 2, 2,
-	281, 282, 5, 251, 126, 2, 282, 283, 5, 223, 112, 2, 283, 284, 5, 253, 127,
-	2, 284, 8, 3, 2, 2, 2, 285, 286, 5, 221, 111, 2, 286, 287, 5, 249, 125,
-	2, 287, 288, 5, 243, 122, 2, 288, 289, 5, 245, 123, 2, 289, 10, 3, 2, 2,
-	2, 290, 291, 5, 231, 116, 2, 291, 292, 5, 241, 121, 2, 292, 293, 5, 253,
-	127, 2, 293, 294, 5, 223, 112, 2, 294, 295, 5, 249, 125, 2, 295, 296, 5,
-	257, 129, 2, 296, 297, 5, 215, 108, 2, 297, 298, 5, 237, 119, 2, 298, 12,
-	3, 2, 2, 2, 299, 300, 5, 241, 121, 2, 300, 301, 5, 215, 108, 2, 301, 302,
-	5, 239, 120, 2, 302, 303, 5, 223, 112, 2, 303, 14, 3, 2, 2, 2, 304, 305,
-	5, 251, 126, 2, 305, 306, 5, 229, 115, 2, 306, 307, 5, 215, 108, 2, 307,
-	308, 5, 249, 125, 2, 308, 309, 5, 221, 111, 2, 309, 16, 3, 2, 2, 2, 310,
-	311, 5, 249, 125, 2, 311, 312, 5, 223, 112, 2, 312, 313, 5, 245, 123, 2,
-	313, 314, 5, 237, 119, 2, 314, 315, 5, 231, 116, 2, 315, 316, 5, 219, 110,
-	2, 316, 317, 5, 215, 108, 2, 317, 318, 5, 253, 127, 2, 318, 319, 5, 231,
-	116, 2, 319, 320, 5, 243, 122, 2, 320, 321, 5, 241, 121, 2, 321, 18, 3,
-	2, 2, 2, 322, 323, 5, 253, 127, 2, 323, 324, 5, 253, 127, 2, 324, 325,
-	5, 237, 119, 2, 325, 20, 3, 2, 2, 2, 326, 327, 5, 239, 120, 2, 327, 328,
-	5, 223, 112, 2, 328, 329, 5, 253, 127, 2, 329, 330, 5, 215, 108, 2, 330,
-	331, 5, 253, 127, 2, 331, 332, 5, 253, 127, 2, 332, 333, 5, 237, 119, 2,
-	333, 22, 3, 2, 2, 2, 334, 335, 5, 245, 123, 2, 335, 336, 5, 215, 108, 2,
-	336, 337, 5, 251, 126, 2, 337, 338, 5, 253, 127, 2, 338, 339, 5, 253, 127,
-	2, 339, 340, 5, 253, 127, 2, 340, 341, 5, 237, 119, 2, 341, 24, 3, 2, 2,
-	2, 342, 343, 5, 225, 113, 2, 343, 344, 5, 255, 128, 2, 344, 345, 5, 253,
-	127, 2, 345, 346, 5, 255, 128, 2, 346, 347, 5, 249, 125, 2, 347, 348, 5,
-	223, 112, 2, 348, 349, 5, 253, 127, 2, 349, 350, 5, 253, 127, 2, 350, 351,
-	5, 237, 119, 2, 351, 26, 3, 2, 2, 2, 352, 353, 5, 235, 118, 2, 353, 354,
-	5, 231, 116, 2, 354, 355, 5, 237, 119, 2, 355, 356, 5, 237, 119, 2, 356,
-	28, 3, 2, 2, 2, 357, 358, 5, 243, 122, 2, 358, 359, 5, 241, 121, 2, 359,
-	30, 3, 2, 2, 2, 360, 361, 5, 251, 126, 2, 361, 362, 5, 229, 115, 2, 362,
-	363, 5, 243, 122, 2, 363, 364, 5, 259, 130, 2, 364, 32, 3, 2, 2, 2, 365,
-	366, 5, 221, 111, 2, 366, 367, 5, 215, 108, 2, 367, 368, 5, 253, 127, 2,
-	368, 369, 5, 215, 108, 2, 369, 370, 5, 217, 109, 2, 370, 371, 5, 215, 108,
-	2, 371, 372, 5, 251, 126, 2, 372, 373, 5, 223, 112, 2, 373, 34, 3, 2, 2,
-	2, 374, 375, 5, 221, 111, 2, 375, 376, 5, 215, 108, 2, 376, 377, 5, 253,
-	127, 2, 377, 378, 5, 215, 108, 2, 378, 379, 5, 217, 109, 2, 379, 380, 5,
-	215, 108, 2, 380, 381, 5, 251, 126, 2, 381, 382, 5, 223, 112, 2, 382, 383,
-	5, 251, 126, 2, 383, 36, 3, 2, 2, 2, 384, 385, 5, 241, 121, 2, 385, 386,
-	5, 215, 108, 2, 386, 387, 5, 239, 120, 2, 387, 388, 5, 223, 112, 2, 388,
-	389, 5, 251, 126, 2, 389, 390, 5, 245, 123, 2, 390, 391, 5, 215, 108, 2,
-	391, 392, 5, 219, 110, 2, 392, 393, 5, 223, 112, 2, 393, 38, 3, 2, 2, 2,
-	394, 395, 5, 241, 121, 2, 395, 396, 5, 215, 108, 2, 396, 397, 5, 239, 120,
-	2, 397, 398, 5, 223, 112, 2, 398, 399, 5, 251, 126, 2, 399, 400, 5, 245,
-	123, 2, 400, 401, 5, 215, 108, 2, 401, 402, 5, 219, 110, 2, 402, 403, 5,
-	223, 112, 2, 403, 404, 5, 251, 126, 2, 404, 40, 3, 2, 2, 2, 405, 406, 5,
-	241, 121, 2, 406, 407, 5, 243, 122, 2, 407, 408, 5, 221, 111, 2, 408, 409,
-	5, 223, 112, 2, 409, 42, 3, 2, 2, 2, 410, 411, 5, 239, 120, 2, 411, 412,
-	5, 223, 112, 2, 412, 413, 5, 253, 127, 2, 413, 414, 5, 249, 125, 2, 414,
-	415, 5, 231, 116, 2, 415, 416, 5, 219, 110, 2, 416, 417, 5, 251, 126, 2,
-	417, 44, 3, 2, 2, 2, 418, 419, 5, 239, 120, 2, 419, 420, 5, 223, 112, 2,
-	420, 421, 5, 253, 127, 2, 421, 422, 5, 249, 125, 2, 422, 423, 5, 231, 116,
-	2, 423, 424, 5, 219, 110, 2, 424, 46, 3, 2, 2, 2, 425, 426, 5, 225, 113,
-	2, 426, 427, 5, 231, 116, 2, 427, 428, 5, 223, 112, 2, 428, 429, 5, 237,
-	119, 2, 429, 430, 5, 221, 111, 2, 430, 48, 3, 2, 2, 2, 431, 432, 5, 225,
-	113, 2, 432, 433, 5, 231, 116, 2, 433, 434, 5, 223, 112, 2, 434, 435, 5,
-	237, 119, 2, 435, 436, 5, 221, 111, 2, 436, 437, 5, 251, 126, 2, 437, 50,
-	3, 2, 2, 2, 438, 439, 5, 253, 127, 2, 439, 440, 5, 215, 108, 2, 440, 441,
-	5, 227, 114, 2, 441, 52, 3, 2, 2, 2, 442, 443, 5, 231, 116, 2, 443, 444,
-	5, 241, 121, 2, 444, 445, 5, 225, 113, 2, 445, 446, 5, 243, 122, 2, 446,
-	54, 3, 2, 2, 2, 447, 448, 5, 235, 118, 2, 448, 449, 5, 223, 112, 2, 449,
-	450, 5, 263, 132, 2, 450, 451, 5, 251, 126, 2, 451, 56, 3, 2, 2, 2, 452,
-	453, 5, 235, 118, 2, 453, 454, 5, 223, 112, 2, 454, 455, 5, 263, 132, 2,
-	455, 58, 3, 2, 2, 2, 456, 457, 5, 259, 130, 2, 457, 458, 5, 231, 116, 2,
-	458, 459, 5, 253, 127, 2, 459, 460, 5, 229, 115, 2, 460, 60, 3, 2, 2, 2,
-	461, 462, 5, 257, 129, 2, 462, 463, 5, 215, 108, 2, 463, 464, 5, 237, 119,
-	2, 464, 465, 5, 255, 128, 2, 465, 466, 5, 223, 112, 2, 466, 467, 5, 251,
-	126, 2, 467, 62, 3, 2, 2, 2, 468, 469, 5, 257, 129, 2, 469, 470, 5, 215,
-	108, 2, 470, 471, 5, 237, 119, 2, 471, 472, 5, 255, 128, 2, 472, 473, 5,
-	223, 112, 2, 473, 64, 3, 2, 2, 2, 474, 475, 5, 225, 113, 2, 475, 476, 5,
-	249, 125, 2, 476, 477, 5, 243, 122, 2, 477, 478, 5, 239, 120, 2, 478, 66,
-	3, 2, 2, 2, 479, 480, 5, 259, 130, 2, 480, 481, 5, 229, 115, 2, 481, 482,
-	5, 223, 112, 2, 482, 483, 5, 249, 125, 2, 483, 484, 5, 223, 112, 2, 484,
-	68, 3, 2, 2, 2, 485, 486, 5, 237, 119, 2, 486, 487, 5, 231, 116, 2, 487,
-	488, 5, 239, 120, 2, 488, 489, 5, 231, 116, 2, 489, 490, 5, 253, 127, 2,
-	490, 70, 3, 2, 2, 2, 491, 492, 5, 247, 124, 2, 492, 493, 5, 255, 128, 2,
-	493, 494, 5, 223, 112, 2, 494, 495, 5, 249, 125, 2, 495, 496, 5, 231, 116,
-	2, 496, 497, 5, 223, 112, 2, 497, 498, 5, 251, 126, 2, 498, 72, 3, 2, 2,
-	2, 499, 500, 5, 247, 124, 2, 500, 501, 5, 255, 128, 2, 501, 502, 5, 223,
-	112, 2, 502, 503, 5, 249, 125, 2, 503, 504, 5, 263, 132, 2, 504, 74, 3,
-	2, 2, 2, 505, 506, 5, 223, 112, 2, 506, 507, 5, 261, 131, 2, 507, 508,
-	5, 245, 123, 2, 508, 509, 5, 237, 119, 2, 509, 510, 5, 215, 108, 2, 510,
-	511, 5, 231, 116, 2, 511, 512, 5, 241, 121, 2, 512, 76, 3, 2, 2, 2, 513,
-	514, 5, 259, 130, 2, 514, 515, 5, 231, 116, 2, 515, 516, 5, 253, 127, 2,
-	516, 517, 5, 229, 115, 2, 517, 518, 5, 257, 129, 2, 518, 519, 5, 215, 108,
-	2, 519, 520, 5, 237, 119, 2, 520, 521, 5, 255, 128, 2, 521, 522, 5, 223,
-	112, 2, 522, 78, 3, 2, 2, 2, 523, 524, 5, 251, 126, 2, 524, 525, 5, 223,
-	112, 2, 525, 526, 5, 237, 119, 2, 526, 527, 5, 223, 112, 2, 527, 528, 5,
-	219, 110, 2, 528, 529, 5, 253, 127, 2, 529, 80, 3, 2, 2, 2, 530, 531, 5,
-	215, 108, 2, 531, 532, 5, 251, 126, 2, 532, 82, 3, 2, 2, 2, 533, 534, 5,
-	215, 108, 2, 534, 535, 5, 241, 121, 2, 535, 536, 5, 221, 111, 2, 536, 84,
-	3, 2, 2, 2, 537, 538, 5, 243, 122, 2, 538, 539, 5, 249, 125, 2, 539, 86,
-	3, 2, 2, 2, 540, 541, 5, 225, 113, 2, 541, 542, 5, 231, 116, 2, 542, 543,
-	5, 237, 119, 2, 543, 544, 5, 237, 119, 2, 544, 88, 3, 2, 2, 2, 545, 546,
-	5, 241, 121, 2, 546, 547, 5, 255, 128, 2, 547, 548, 5, 237, 119, 2, 548,
-	549, 5, 237, 119, 2, 549, 90, 3, 2, 2, 2, 550, 551, 5, 245, 123, 2, 551,
-	552, 5, 249, 125, 2, 552, 553, 5, 223, 112, 2, 553, 554, 5, 257, 129, 2,
-	554, 555, 5, 231, 116, 2, 555, 556, 5, 243, 122, 2, 556, 557, 5, 255, 128,
-	2, 557, 558, 5, 251, 126, 2, 558, 92, 3, 2, 2, 2, 559, 560, 5, 243, 122,
-	2, 560, 561, 5, 249, 125, 2, 561, 562, 5, 221, 111, 2, 562, 563, 5, 223,
-	112, 2, 563, 564, 5, 249, 125, 2, 564, 94, 3, 2, 2, 2, 565, 566, 5, 215,
-	108, 2, 566, 567, 5, 251, 126, 2, 567, 568, 5, 219, 110, 2, 568, 96, 3,
-	2, 2, 2, 569, 570, 5, 221, 111, 2, 570, 571, 5, 223, 112, 2, 571, 572,
-	5, 251, 126, 2, 572, 573, 5, 219, 110, 2, 573, 98, 3, 2, 2, 2, 574, 575,
-	5, 237, 119, 2, 575, 576, 5, 231, 116, 2, 576, 577, 5, 235, 118, 2, 577,
-	578, 5, 223, 112, 2, 578, 100, 3, 2, 2, 2, 579, 580, 5, 241, 121, 2, 580,
-	581, 5, 243, 122, 2, 581, 582, 5, 253, 127, 2, 582, 102, 3, 2, 2, 2, 583,
-	584, 5, 217, 109, 2, 584, 585, 5, 223, 112, 2, 585, 586, 5, 253, 127, 2,
-	586, 587, 5, 259, 130, 2, 587, 588, 5, 223, 112, 2, 588, 589, 5, 223, 112,
-	2, 589, 590, 5, 241, 121, 2, 590, 104, 3, 2, 2, 2, 591, 592, 5, 231, 116,
-	2, 592, 593, 5, 251, 126, 2, 593, 106, 3, 2, 2, 2, 594, 595, 5, 227, 114,
-	2, 595, 596, 5, 249, 125, 2, 596, 597, 5, 243, 122, 2, 597, 598, 5, 255,
-	128, 2, 598, 599, 5, 245, 123, 2, 599, 108, 3, 2, 2, 2, 600, 601, 5, 229,
-	115, 2, 601, 602, 5, 215, 108, 2, 602, 603, 5, 257, 129, 2, 603, 604, 5,
-	231, 116, 2, 604, 605, 5, 241, 121, 2, 605, 606, 5, 227, 114, 2, 606, 110,
-	3, 2, 2, 2, 607, 608, 5, 217, 109, 2, 608, 609, 5, 263, 132, 2, 609, 112,
-	3, 2, 2, 2, 610, 611, 5, 225, 113, 2, 611, 612, 5, 243, 122, 2, 612, 613,
-	5, 249, 125, 2, 613, 114, 3, 2, 2, 2, 614, 615, 5, 251, 126, 2, 615, 616,
-	5, 253, 127, 2, 616, 617, 5, 215, 108, 2, 617, 618, 5, 253, 127, 2, 618,
-	619, 5, 251, 126, 2, 619, 116, 3, 2, 2, 2, 620, 621, 5, 253, 127, 2, 621,
-	622, 5, 231, 116, 2, 622, 623, 5, 239, 120, 2, 623, 624, 5, 223, 112, 2,
-	624, 118, 3, 2, 2, 2, 625, 626, 5, 241, 121, 2, 626, 627, 5, 243, 122,
-	2, 627, 628, 5, 259, 130, 2, 628, 120, 3, 2, 2, 2, 629, 630, 5, 231, 116,
-	2, 630, 631, 5, 241, 121, 2, 631, 122, 3, 2, 2, 2, 632, 633, 5, 237, 119,
-	2, 633, 634, 5, 243, 122, 2, 634, 635, 5, 227, 114, 2, 635, 124, 3, 2,
-	2, 2, 636, 637, 5, 245, 123, 2, 637, 638, 5, 249, 125, 2, 638, 639, 5,
-	243, 122, 2, 639, 640, 5, 225, 113, 2, 640, 641, 5, 231, 116, 2, 641, 642,
-	5, 237, 119, 2, 642, 643, 5, 223, 112, 2, 643, 126, 3, 2, 2, 2, 644, 645,
-	5, 251, 126, 2, 645, 646, 5, 255, 128, 2, 646, 647, 5, 239, 120, 2, 647,
-	128, 3, 2, 2, 2, 648, 649, 5, 239, 120, 2, 649, 650, 5, 231, 116, 2, 650,
-	651, 5, 241, 121, 2, 651, 130, 3, 2, 2, 2, 652, 653, 5, 239, 120, 2, 653,
-	654, 5, 215, 108, 2, 654, 655, 5, 261, 131, 2, 655, 132, 3, 2, 2, 2, 656,
-	657, 5, 219, 110, 2, 657, 658, 5, 243, 122, 2, 658, 659, 5, 255, 128, 2,
-	659, 660, 5, 241, 121, 2, 660, 661, 5, 253, 127, 2, 661, 134, 3, 2, 2,
-	2, 662, 663, 5, 215, 108, 2, 663, 664, 5, 257, 129, 2, 664, 665, 5, 227,
-	114, 2, 665, 136, 3, 2, 2, 2, 666, 667, 5, 251, 126, 2, 667, 668, 5, 253,
-	127, 2, 668, 669, 5, 221, 111, 2, 669, 670, 5, 221, 111, 2, 670, 671, 5,
-	223, 112, 2, 671, 672, 5, 257, 129, 2, 672, 138, 3, 2, 2, 2, 673, 674,
-	5, 247, 124, 2, 674, 675, 5, 255, 128, 2, 675, 676, 5, 215, 108, 2, 676,
-	677, 5, 241, 121, 2, 677, 678, 5, 253, 127, 2, 678, 679, 5, 231, 116, 2,
-	679, 680, 5, 237, 119, 2, 680, 681, 5, 223, 112, 2, 681, 140, 3, 2, 2,
-	2, 682, 683, 5, 251, 126, 2, 683, 142, 3, 2, 2, 2, 684, 685, 7, 111, 2,
-	2, 685, 144, 3, 2, 2, 2, 686, 687, 5, 229, 115, 2, 687, 146, 3, 2, 2, 2,
-	688, 689, 5, 221, 111, 2, 689, 148, 3, 2, 2, 2, 690, 691, 5, 259, 130,
+	3, 2, 2, 2, 267, 268, 5, 219, 112, 2, 268, 269, 5, 249, 127, 2, 269, 270,
+	5, 223, 114, 2, 270, 271, 5, 215, 110, 2, 271, 272, 5, 253, 129, 2, 272,
+	273, 5, 223, 114, 2, 273, 4, 3, 2, 2, 2, 274, 275, 5, 255, 130, 2, 275,
+	276, 5, 245, 125, 2, 276, 277, 5, 221, 113, 2, 277, 278, 5, 215, 110, 2,
+	278, 279, 5, 253, 129, 2, 279, 280, 5, 223, 114, 2, 280, 6, 3, 2, 2, 2,
+	281, 282, 5, 251, 128, 2, 282, 283, 5, 223, 114, 2, 283, 284, 5, 253, 129,
+	2, 284, 8, 3, 2, 2, 2, 285, 286, 5, 221, 113, 2, 286, 287, 5, 249, 127,
+	2, 287, 288, 5, 243, 124, 2, 288, 289, 5, 245, 125, 2, 289, 10, 3, 2, 2,
+	2, 290, 291, 5, 231, 118, 2, 291, 292, 5, 241, 123, 2, 292, 293, 5, 253,
+	129, 2, 293, 294, 5, 223, 114, 2, 294, 295, 5, 249, 127, 2, 295, 296, 5,
+	257, 131, 2, 296, 297, 5, 215, 110, 2, 297, 298, 5, 237, 121, 2, 298, 12,
+	3, 2, 2, 2, 299, 300, 5, 241, 123, 2, 300, 301, 5, 215, 110, 2, 301, 302,
+	5, 239, 122, 2, 302, 303, 5, 223, 114, 2, 303, 14, 3, 2, 2, 2, 304, 305,
+	5, 251, 128, 2, 305, 306, 5, 229, 117, 2, 306, 307, 5, 215, 110, 2, 307,
+	308, 5, 249, 127, 2, 308, 309, 5, 221, 113, 2, 309, 16, 3, 2, 2, 2, 310,
+	311, 5, 249, 127, 2, 311, 312, 5, 223, 114, 2, 312, 313, 5, 245, 125, 2,
+	313, 314, 5, 237, 121, 2, 314, 315, 5, 231, 118, 2, 315, 316, 5, 219, 112,
+	2, 316, 317, 5, 215, 110, 2, 317, 318, 5, 253, 129, 2, 318, 319, 5, 231,
+	118, 2, 319, 320, 5, 243, 124, 2, 320, 321, 5, 241, 123, 2, 321, 18, 3,
+	2, 2, 2, 322, 323, 5, 253, 129, 2, 323, 324, 5, 253, 129, 2, 324, 325,
+	5, 237, 121, 2, 325, 20, 3, 2, 2, 2, 326, 327, 5, 239, 122, 2, 327, 328,
+	5, 223, 114, 2, 328, 329, 5, 253, 129, 2, 329, 330, 5, 215, 110, 2, 330,
+	331, 5, 253, 129, 2, 331, 332, 5, 253, 129, 2, 332, 333, 5, 237, 121, 2,
+	333, 22, 3, 2, 2, 2, 334, 335, 5, 245, 125, 2, 335, 336, 5, 215, 110, 2,
+	336, 337, 5, 251, 128, 2, 337, 338, 5, 253, 129, 2, 338, 339, 5, 253, 129,
+	2, 339, 340, 5, 253, 129, 2, 340, 341, 5, 237, 121, 2, 341, 24, 3, 2, 2,
+	2, 342, 343, 5, 225, 115, 2, 343, 344, 5, 255, 130, 2, 344, 345, 5, 253,
+	129, 2, 345, 346, 5, 255, 130, 2, 346, 347, 5, 249, 127, 2, 347, 348, 5,
+	223, 114, 2, 348, 349, 5, 253, 129, 2, 349, 350, 5, 253, 129, 2, 350, 351,
+	5, 237, 121, 2, 351, 26, 3, 2, 2, 2, 352, 353, 5, 235, 120, 2, 353, 354,
+	5, 231, 118, 2, 354, 355, 5, 237, 121, 2, 355, 356, 5, 237, 121, 2, 356,
+	28, 3, 2, 2, 2, 357, 358, 5, 243, 124, 2, 358, 359, 5, 241, 123, 2, 359,
+	30, 3, 2, 2, 2, 360, 361, 5, 251, 128, 2, 361, 362, 5, 229, 117, 2, 362,
+	363, 5, 243, 124, 2, 363, 364, 5, 259, 132, 2, 364, 32, 3, 2, 2, 2, 365,
+	366, 5, 221, 113, 2, 366, 367, 5, 215, 110, 2, 367, 368, 5, 253, 129, 2,
+	368, 369, 5, 215, 110, 2, 369, 370, 5, 217, 111, 2, 370, 371, 5, 215, 110,
+	2, 371, 372, 5, 251, 128, 2, 372, 373, 5, 223, 114, 2, 373, 34, 3, 2, 2,
+	2, 374, 375, 5, 221, 113, 2, 375, 376, 5, 215, 110, 2, 376, 377, 5, 253,
+	129, 2, 377, 378, 5, 215, 110, 2, 378, 379, 5, 217, 111, 2, 379, 380, 5,
+	215, 110, 2, 380, 381, 5, 251, 128, 2, 381, 382, 5, 223, 114, 2, 382, 383,
+	5, 251, 128, 2, 383, 36, 3, 2, 2, 2, 384, 385, 5, 241, 123, 2, 385, 386,
+	5, 215, 110, 2, 386, 387, 5, 239, 122, 2, 387, 388, 5, 223, 114, 2, 388,
+	389, 5, 251, 128, 2, 389, 390, 5, 245, 125, 2, 390, 391, 5, 215, 110, 2,
+	391, 392, 5, 219, 112, 2, 392, 393, 5, 223, 114, 2, 393, 38, 3, 2, 2, 2,
+	394, 395, 5, 241, 123, 2, 395, 396, 5, 215, 110, 2, 396, 397, 5, 239, 122,
+	2, 397, 398, 5, 223, 114, 2, 398, 399, 5, 251, 128, 2, 399, 400, 5, 245,
+	125, 2, 400, 401, 5, 215, 110, 2, 401, 402, 5, 219, 112, 2, 402, 403, 5,
+	223, 114, 2, 403, 404, 5, 251, 128, 2, 404, 40, 3, 2, 2, 2, 405, 406, 5,
+	241, 123, 2, 406, 407, 5, 243, 124, 2, 407, 408, 5, 221, 113, 2, 408, 409,
+	5, 223, 114, 2, 409, 42, 3, 2, 2, 2, 410, 411, 5, 239, 122, 2, 411, 412,
+	5, 223, 114, 2, 412, 413, 5, 253, 129, 2, 413, 414, 5, 249, 127, 2, 414,
+	415, 5, 231, 118, 2, 415, 416, 5, 219, 112, 2, 416, 417, 5, 251, 128, 2,
+	417, 44, 3, 2, 2, 2, 418, 419, 5, 239, 122, 2, 419, 420, 5, 223, 114, 2,
+	420, 421, 5, 253, 129, 2, 421, 422, 5, 249, 127, 2, 422, 423, 5, 231, 118,
+	2, 423, 424, 5, 219, 112, 2, 424, 46, 3, 2, 2, 2, 425, 426, 5, 225, 115,
+	2, 426, 427, 5, 231, 118, 2, 427, 428, 5, 223, 114, 2, 428, 429, 5, 237,
+	121, 2, 429, 430, 5, 221, 113, 2, 430, 48, 3, 2, 2, 2, 431, 432, 5, 225,
+	115, 2, 432, 433, 5, 231, 118, 2, 433, 434, 5, 223, 114, 2, 434, 435, 5,
+	237, 121, 2, 435, 436, 5, 221, 113, 2, 436, 437, 5, 251, 128, 2, 437, 50,
+	3, 2, 2, 2, 438, 439, 5, 253, 129, 2, 439, 440, 5, 215, 110, 2, 440, 441,
+	5, 227, 116, 2, 441, 52, 3, 2, 2, 2, 442, 443, 5, 231, 118, 2, 443, 444,
+	5, 241, 123, 2, 444, 445, 5, 225, 115, 2, 445, 446, 5, 243, 124, 2, 446,
+	54, 3, 2, 2, 2, 447, 448, 5, 235, 120, 2, 448, 449, 5, 223, 114, 2, 449,
+	450, 5, 263, 134, 2, 450, 451, 5, 251, 128, 2, 451, 56, 3, 2, 2, 2, 452,
+	453, 5, 235, 120, 2, 453, 454, 5, 223, 114, 2, 454, 455, 5, 263, 134, 2,
+	455, 58, 3, 2, 2, 2, 456, 457, 5, 259, 132, 2, 457, 458, 5, 231, 118, 2,
+	458, 459, 5, 253, 129, 2, 459, 460, 5, 229, 117, 2, 460, 60, 3, 2, 2, 2,
+	461, 462, 5, 257, 131, 2, 462, 463, 5, 215, 110, 2, 463, 464, 5, 237, 121,
+	2, 464, 465, 5, 255, 130, 2, 465, 466, 5, 223, 114, 2, 466, 467, 5, 251,
+	128, 2, 467, 62, 3, 2, 2, 2, 468, 469, 5, 257, 131, 2, 469, 470, 5, 215,
+	110, 2, 470, 471, 5, 237, 121, 2, 471, 472, 5, 255, 130, 2, 472, 473, 5,
+	223, 114, 2, 473, 64, 3, 2, 2, 2, 474, 475, 5, 225, 115, 2, 475, 476, 5,
+	249, 127, 2, 476, 477, 5, 243, 124, 2, 477, 478, 5, 239, 122, 2, 478, 66,
+	3, 2, 2, 2, 479, 480, 5, 259, 132, 2, 480, 481, 5, 229, 117, 2, 481, 482,
+	5, 223, 114, 2, 482, 483, 5, 249, 127, 2, 483, 484, 5, 223, 114, 2, 484,
+	68, 3, 2, 2, 2, 485, 486, 5, 237, 121, 2, 486, 487, 5, 231, 118, 2, 487,
+	488, 5, 239, 122, 2, 488, 489, 5, 231, 118, 2, 489, 490, 5, 253, 129, 2,
+	490, 70, 3, 2, 2, 2, 491, 492, 5, 247, 126, 2, 492, 493, 5, 255, 130, 2,
+	493, 494, 5, 223, 114, 2, 494, 495, 5, 249, 127, 2, 495, 496, 5, 231, 118,
+	2, 496, 497, 5, 223, 114, 2, 497, 498, 5, 251, 128, 2, 498, 72, 3, 2, 2,
+	2, 499, 500, 5, 247, 126, 2, 500, 501, 5, 255, 130, 2, 501, 502, 5, 223,
+	114, 2, 502, 503, 5, 249, 127, 2, 503, 504, 5, 263, 134, 2, 504, 74, 3,
+	2, 2, 2, 505, 506, 5, 223, 114, 2, 506, 507, 5, 261, 133, 2, 507, 508,
+	5, 245, 125, 2, 508, 509, 5, 237, 121, 2, 509, 510, 5, 215, 110, 2, 510,
+	511, 5, 231, 118, 2, 511, 512, 5, 241, 123, 2, 512, 76, 3, 2, 2, 2, 513,
+	514, 5, 259, 132, 2, 514, 515, 5, 231, 118, 2, 515, 516, 5, 253, 129, 2,
+	516, 517, 5, 229, 117, 2, 517, 518, 5, 257, 131, 2, 518, 519, 5, 215, 110,
+	2, 519, 520, 5, 237, 121, 2, 520, 521, 5, 255, 130, 2, 521, 522, 5, 223,
+	114, 2, 522, 78, 3, 2, 2, 2, 523, 524, 5, 251, 128, 2, 524, 525, 5, 223,
+	114, 2, 525, 526, 5, 237, 121, 2, 526, 527, 5, 223, 114, 2, 527, 528, 5,
+	219, 112, 2, 528, 529, 5, 253, 129, 2, 529, 80, 3, 2, 2, 2, 530, 531, 5,
+	215, 110, 2, 531, 532, 5, 251, 128, 2, 532, 82, 3, 2, 2, 2, 533, 534, 5,
+	215, 110, 2, 534, 535, 5, 241, 123, 2, 535, 536, 5, 221, 113, 2, 536, 84,
+	3, 2, 2, 2, 537, 538, 5, 243, 124, 2, 538, 539, 5, 249, 127, 2, 539, 86,
+	3, 2, 2, 2, 540, 541, 5, 225, 115, 2, 541, 542, 5, 231, 118, 2, 542, 543,
+	5, 237, 121, 2, 543, 544, 5, 237, 121, 2, 544, 88, 3, 2, 2, 2, 545, 546,
+	5, 241, 123, 2, 546, 547, 5, 255, 130, 2, 547, 548, 5, 237, 121, 2, 548,
+	549, 5, 237, 121, 2, 549, 90, 3, 2, 2, 2, 550, 551, 5, 245, 125, 2, 551,
+	552, 5, 249, 127, 2, 552, 553, 5, 223, 114, 2, 553, 554, 5, 257, 131, 2,
+	554, 555, 5, 231, 118, 2, 555, 556, 5, 243, 124, 2, 556, 557, 5, 255, 130,
+	2, 557, 558, 5, 251, 128, 2, 558, 92, 3, 2, 2, 2, 559, 560, 5, 243, 124,
+	2, 560, 561, 5, 249, 127, 2, 561, 562, 5, 221, 113, 2, 562, 563, 5, 223,
+	114, 2, 563, 564, 5, 249, 127, 2, 564, 94, 3, 2, 2, 2, 565, 566, 5, 215,
+	110, 2, 566, 567, 5, 251, 128, 2, 567, 568, 5, 219, 112, 2, 568, 96, 3,
+	2, 2, 2, 569, 570, 5, 221, 113, 2, 570, 571, 5, 223, 114, 2, 571, 572,
+	5, 251, 128, 2, 572, 573, 5, 219, 112, 2, 573, 98, 3, 2, 2, 2, 574, 575,
+	5, 237, 121, 2, 575, 576, 5, 231, 118, 2, 576, 577, 5, 235, 120, 2, 577,
+	578, 5, 223, 114, 2, 578, 100, 3, 2, 2, 2, 579, 580, 5, 241, 123, 2, 580,
+	581, 5, 243, 124, 2, 581, 582, 5, 253, 129, 2, 582, 102, 3, 2, 2, 2, 583,
+	584, 5, 217, 111, 2, 584, 585, 5, 223, 114, 2, 585, 586, 5, 253, 129, 2,
+	586, 587, 5, 259, 132, 2, 587, 588, 5, 223, 114, 2, 588, 589, 5, 223, 114,
+	2, 589, 590, 5, 241, 123, 2, 590, 104, 3, 2, 2, 2, 591, 592, 5, 231, 118,
+	2, 592, 593, 5, 251, 128, 2, 593, 106, 3, 2, 2, 2, 594, 595, 5, 227, 116,
+	2, 595, 596, 5, 249, 127, 2, 596, 597, 5, 243, 124, 2, 597, 598, 5, 255,
+	130, 2, 598, 599, 5, 245, 125, 2, 599, 108, 3, 2, 2, 2, 600, 601, 5, 229,
+	117, 2, 601, 602, 5, 215, 110, 2, 602, 603, 5, 257, 131, 2, 603, 604, 5,
+	231, 118, 2, 604, 605, 5, 241, 123, 2, 605, 606, 5, 227, 116, 2, 606, 110,
+	3, 2, 2, 2, 607, 608, 5, 217, 111, 2, 608, 609, 5, 263, 134, 2, 609, 112,
+	3, 2, 2, 2, 610, 611, 5, 225, 115, 2, 611, 612, 5, 243, 124, 2, 612, 613,
+	5, 249, 127, 2, 613, 114, 3, 2, 2, 2, 614, 615, 5, 251, 128, 2, 615, 616,
+	5, 253, 129, 2, 616, 617, 5, 215, 110, 2, 617, 618, 5, 253, 129, 2, 618,
+	619, 5, 251, 128, 2, 619, 116, 3, 2, 2, 2, 620, 621, 5, 253, 129, 2, 621,
+	622, 5, 231, 118, 2, 622, 623, 5, 239, 122, 2, 623, 624, 5, 223, 114, 2,
+	624, 118, 3, 2, 2, 2, 625, 626, 5, 241, 123, 2, 626, 627, 5, 243, 124,
+	2, 627, 628, 5, 259, 132, 2, 628, 120, 3, 2, 2, 2, 629, 630, 5, 231, 118,
+	2, 630, 631, 5, 241, 123, 2, 631, 122, 3, 2, 2, 2, 632, 633, 5, 237, 121,
+	2, 633, 634, 5, 243, 124, 2, 634, 635, 5, 227, 116, 2, 635, 124, 3, 2,
+	2, 2, 636, 637, 5, 245, 125, 2, 637, 638, 5, 249, 127, 2, 638, 639, 5,
+	243, 124, 2, 639, 640, 5, 225, 115, 2, 640, 641, 5, 231, 118, 2, 641, 642,
+	5, 237, 121, 2, 642, 643, 5, 223, 114, 2, 643, 126, 3, 2, 2, 2, 644, 645,
+	5, 251, 128, 2, 645, 646, 5, 255, 130, 2, 646, 647, 5, 239, 122, 2, 647,
+	128, 3, 2, 2, 2, 648, 649, 5, 239, 122, 2, 649, 650, 5, 231, 118, 2, 650,
+	651, 5, 241, 123, 2, 651, 130, 3, 2, 2, 2, 652, 653, 5, 239, 122, 2, 653,
+	654, 5, 215, 110, 2, 654, 655, 5, 261, 133, 2, 655, 132, 3, 2, 2, 2, 656,
+	657, 5, 219, 112, 2, 657, 658, 5, 243, 124, 2, 658, 659, 5, 255, 130, 2,
+	659, 660, 5, 241, 123, 2, 660, 661, 5, 253, 129, 2, 661, 134, 3, 2, 2,
+	2, 662, 663, 5, 215, 110, 2, 663, 664, 5, 257, 131, 2, 664, 665, 5, 227,
+	116, 2, 665, 136, 3, 2, 2, 2, 666, 667, 5, 251, 128, 2, 667, 668, 5, 253,
+	129, 2, 668, 669, 5, 221, 113, 2, 669, 670, 5, 221, 113, 2, 670, 671, 5,
+	223, 114, 2, 671, 672, 5, 257, 131, 2, 672, 138, 3, 2, 2, 2, 673, 674,
+	5, 247, 126, 2, 674, 675, 5, 255, 130, 2, 675, 676, 5, 215, 110, 2, 676,
+	677, 5, 241, 123, 2, 677, 678, 5, 253, 129, 2, 678, 679, 5, 231, 118, 2,
+	679, 680, 5, 237, 121, 2, 680, 681, 5, 223, 114, 2, 681, 140, 3, 2, 2,
+	2, 682, 683, 5, 251, 128, 2, 683, 142, 3, 2, 2, 2, 684, 685, 7, 111, 2,
+	2, 685, 144, 3, 2, 2, 2, 686, 687, 5, 229, 117, 2, 687, 146, 3, 2, 2, 2,
+	688, 689, 5, 221, 113, 2, 689, 148, 3, 2, 2, 2, 690, 691, 5, 259, 132,
 	2, 691, 150, 3, 2, 2, 2, 692, 693, 7, 79, 2, 2, 693, 152, 3, 2, 2, 2, 694,
-	695, 5, 263, 132, 2, 695, 154, 3, 2, 2, 2, 696, 697, 7, 48, 2, 2, 697,
+	695, 5, 263, 134, 2, 695, 154, 3, 2, 2, 2, 696, 697, 7, 48, 2, 2, 697,
 	156, 3, 2, 2, 2, 698, 699, 7, 60, 2, 2, 699, 158, 3, 2, 2, 2, 700, 701,
 	7, 63, 2, 2, 701, 160, 3, 2, 2, 2, 702, 703, 7, 62, 2, 2, 703, 704, 7,
 	64, 2, 2, 704, 162, 3, 2, 2, 2, 705, 706, 7, 35, 2, 2, 706, 707, 7, 63,
@@ -379,28 +382,28 @@ var serializedLexerAtn = []uint16{
 	2, 2, 739, 192, 3, 2, 2, 2, 740, 741, 7, 47, 2, 2, 741, 194, 3, 2, 2, 2,
 	742, 743, 7, 49, 2, 2, 743, 196, 3, 2, 2, 2, 744, 745, 7, 44, 2, 2, 745,
 	198, 3, 2, 2, 2, 746, 747, 7, 39, 2, 2, 747, 200, 3, 2, 2, 2, 748, 749,
-	5, 213, 107, 2, 749, 202, 3, 2, 2, 2, 750, 752, 5, 211, 106, 2, 751, 750,
+	5, 213, 109, 2, 749, 202, 3, 2, 2, 2, 750, 752, 5, 211, 108, 2, 751, 750,
 	3, 2, 2, 2, 752, 753, 3, 2, 2, 2, 753, 751, 3, 2, 2, 2, 753, 754, 3, 2,
-	2, 2, 754, 204, 3, 2, 2, 2, 755, 757, 5, 211, 106, 2, 756, 755, 3, 2, 2,
+	2, 2, 754, 204, 3, 2, 2, 2, 755, 757, 5, 211, 108, 2, 756, 755, 3, 2, 2,
 	2, 757, 758, 3, 2, 2, 2, 758, 756, 3, 2, 2, 2, 758, 759, 3, 2, 2, 2, 759,
 	760, 3, 2, 2, 2, 760, 761, 7, 48, 2, 2, 761, 765, 10, 2, 2, 2, 762, 764,
-	5, 211, 106, 2, 763, 762, 3, 2, 2, 2, 764, 767, 3, 2, 2, 2, 765, 763, 3,
+	5, 211, 108, 2, 763, 762, 3, 2, 2, 2, 764, 767, 3, 2, 2, 2, 765, 763, 3,
 	2, 2, 2, 765, 766, 3, 2, 2, 2, 766, 775, 3, 2, 2, 2, 767, 765, 3, 2, 2,
-	2, 768, 770, 7, 48, 2, 2, 769, 771, 5, 211, 106, 2, 770, 769, 3, 2, 2,
+	2, 768, 770, 7, 48, 2, 2, 769, 771, 5, 211, 108, 2, 770, 769, 3, 2, 2,
 	2, 771, 772, 3, 2, 2, 2, 772, 770, 3, 2, 2, 2, 772, 773, 3, 2, 2, 2, 773,
 	775, 3, 2, 2, 2, 774, 756, 3, 2, 2, 2, 774, 768, 3, 2, 2, 2, 775, 206,
-	3, 2, 2, 2, 776, 778, 5, 209, 105, 2, 777, 776, 3, 2, 2, 2, 778, 779, 3,
+	3, 2, 2, 2, 776, 778, 5, 209, 107, 2, 777, 776, 3, 2, 2, 2, 778, 779, 3,
 	2, 2, 2, 779, 777, 3, 2, 2, 2, 779, 780, 3, 2, 2, 2, 780, 781, 3, 2, 2,
-	2, 781, 782, 8, 104, 2, 2, 782, 208, 3, 2, 2, 2, 783, 784, 9, 3, 2, 2,
+	2, 781, 782, 8, 106, 2, 2, 782, 208, 3, 2, 2, 2, 783, 784, 9, 3, 2, 2,
 	784, 210, 3, 2, 2, 2, 785, 786, 9, 4, 2, 2, 786, 212, 3, 2, 2, 2, 787,
-	793, 9, 5, 2, 2, 788, 792, 9, 5, 2, 2, 789, 792, 5, 211, 106, 2, 790, 792,
+	793, 9, 5, 2, 2, 788, 792, 9, 5, 2, 2, 789, 792, 5, 211, 108, 2, 790, 792,
 	9, 6, 2, 2, 791, 788, 3, 2, 2, 2, 791, 789, 3, 2, 2, 2, 791, 790, 3, 2,
 	2, 2, 792, 795, 3, 2, 2, 2, 793, 791, 3, 2, 2, 2, 793, 794, 3, 2, 2, 2,
 	794, 838, 3, 2, 2, 2, 795, 793, 3, 2, 2, 2, 796, 797, 7, 38, 2, 2, 797,
 	801, 7, 125, 2, 2, 798, 800, 11, 2, 2, 2, 799, 798, 3, 2, 2, 2, 800, 803,
 	3, 2, 2, 2, 801, 802, 3, 2, 2, 2, 801, 799, 3, 2, 2, 2, 802, 804, 3, 2,
 	2, 2, 803, 801, 3, 2, 2, 2, 804, 838, 7, 127, 2, 2, 805, 809, 9, 7, 2,
-	2, 806, 810, 9, 5, 2, 2, 807, 810, 5, 211, 106, 2, 808, 810, 9, 7, 2, 2,
+	2, 806, 810, 9, 5, 2, 2, 807, 810, 5, 211, 108, 2, 808, 810, 9, 7, 2, 2,
 	809, 806, 3, 2, 2, 2, 809, 807, 3, 2, 2, 2, 809, 808, 3, 2, 2, 2, 810,
 	811, 3, 2, 2, 2, 811, 809, 3, 2, 2, 2, 811, 812, 3, 2, 2, 2, 812, 838,
 	3, 2, 2, 2, 813, 817, 7, 36, 2, 2, 814, 816, 11, 2, 2, 2, 815, 814, 3,
@@ -429,9 +432,15 @@ var serializedLexerAtn = []uint16{
 	2, 2, 2, 879, 880, 9, 28, 2, 2, 880, 256, 3, 2, 2, 2, 881, 882, 9, 29,
 	2, 2, 882, 258, 3, 2, 2, 2, 883, 884, 9, 30, 2, 2, 884, 260, 3, 2, 2, 2,
 	885, 886, 9, 31, 2, 2, 886, 262, 3, 2, 2, 2, 887, 888, 9, 32, 2, 2, 888,
-	264, 3, 2, 2, 2, 889, 890, 9, 33, 2, 2, 890, 266, 3, 2, 2, 2, 18, 2, 753,
-	758, 765, 772, 774, 779, 791, 793, 801, 809, 811, 817, 825, 833, 837, 3,
-	8, 2, 2,
+	264, 3, 2, 2, 2, 889, 890, 9, 33, 2, 2, 890, 266, 3, 2, 2, 2, 891, 893,
+	3, 2, 2, 2, 893, 894, 5, 223, 114, 2, 894, 895, 5, 261, 133, 2, 895, 896,
+	5, 223, 114, 2, 896, 897, 5, 239, 122, 2, 897, 898, 5, 245, 125, 2, 898,
+	899, 5, 237, 121, 2, 899, 900, 5, 215, 110, 2, 900, 901, 5, 249, 127, 2,
+	901, 902, 5, 251, 128, 2, 902, 892, 3, 2, 2, 2, 903, 905, 3, 2, 2, 2, 905,
+	906, 5, 221, 113, 2, 906, 907, 5, 223, 114, 2, 907, 908, 5, 237, 121, 2,
+	908, 909, 5, 223, 114, 2, 909, 910, 5, 253, 129, 2, 910, 911, 5, 223, 114,
+	2, 911, 904, 3, 2, 2, 2, 18, 2, 753, 758, 765, 772, 774, 779, 791, 793,
+	801, 809, 811, 817, 825, 833, 837, 3, 8, 2, 2,
 }
 
 var lexerChannelNames = []string{
@@ -467,8 +476,8 @@ var lexerSymbolicNames = []string{
 	"T_YEAR", "T_DOT", "T_COLON", "T_EQUAL", "T_NOTEQUAL", "T_NOTEQUAL2", "T_GREATER",
 	"T_GREATEREQUAL", "T_LESS", "T_LESSEQUAL", "T_REGEXP", "T_NEQREGEXP", "T_COMMA",
 	"T_OPEN_B", "T_CLOSE_B", "T_OPEN_SB", "T_CLOSE_SB", "T_OPEN_P", "T_CLOSE_P",
-	"T_ADD", "T_SUB", "T_DIV", "T_MUL", "T_MOD", "L_ID", "L_INT", "L_DEC",
-	"WS",
+	"T_ADD", "T_SUB", "T_DIV", "T_MUL", "T_MOD", "T_EXEMPLARS", "T_DELETE",
+	"L_ID", "L_INT", "L_DEC", "WS",
 }
 
 var lexerRuleNames = []string{
@@ -486,10 +495,10 @@ var lexerRuleNames = []string{
 	"T_YEAR", "T_DOT", "T_COLON", "T_EQUAL", "T_NOTEQUAL", "T_NOTEQUAL2", "T_GREATER",
 	"T_GREATEREQUAL", "T_LESS", "T_LESSEQUAL", "T_REGEXP", "T_NEQREGEXP", "T_COMMA",
 	"T_OPEN_B", "T_CLOSE_B", "T_OPEN_SB", "T_CLOSE_SB", "T_OPEN_P", "T_CLOSE_P",
-	"T_ADD", "T_SUB", "T_DIV", "T_MUL", "T_MOD", "L_ID", "L_INT", "L_DEC",
-	"WS", "BLANK", "L_DIGIT", "L_ID_PART", "A", "B", "C", "D", "E", "F", "G",
-	"H", "I", "J", "K", "L", "M", "N", "O", "P", "Q", "R", "S", "T", "U", "V",
-	"W", "X", "Y", "Z",
+	"T_ADD", "T_SUB", "T_DIV", "T_MUL", "T_MOD", "T_EXEMPLARS", "T_DELETE",
+	"L_ID", "L_INT", "L_DEC", "WS", "BLANK", "L_DIGIT", "L_ID_PART", "A", "B",
+	"C", "D", "E", "F", "G", "H", "I", "J", "K", "L", "M", "N", "O", "P", "Q",
+	"R", "S", "T", "U", "V", "W", "X", "Y", "Z",
 }
 
 type SQLLexer struct {
@@ -628,8 +637,10 @@ const (
 	SQLLexerT_DIV           = 97
 	SQLLexerT_MUL           = 98
 	SQLLexerT_MOD           = 99
-	SQLLexerL_ID            = 100
-	SQLLexerL_INT           = 101
-	SQLLexerL_DEC           = 102
-	SQLLexerWS              = 103
+	SQLLexerT_EXEMPLARS     = 100
+	SQLLexerT_DELETE        = 101
+	SQLLexerL_ID            = 102
+	SQLLexerL_INT           = 103
+	SQLLexerL_DEC           = 104
+	SQLLexerWS              = 105
 )
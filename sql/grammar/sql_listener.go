@@ -189,6 +189,9 @@ type SQLListener interface {
 	// EnterNonReservedWords is called when entering the nonReservedWords production.
 	EnterNonReservedWords(c *NonReservedWordsContext)
 
+	// EnterDeleteStmt is called when entering the deleteStmt production.
+	EnterDeleteStmt(c *DeleteStmtContext)
+
 	// ExitStatement is called when exiting the statement production.
 	ExitStatement(c *StatementContext)
 
@@ -353,4 +356,7 @@ type SQLListener interface {
 
 	// ExitNonReservedWords is called when exiting the nonReservedWords production.
 	ExitNonReservedWords(c *NonReservedWordsContext)
+
+	// ExitDeleteStmt is called when exiting the deleteStmt production.
+	ExitDeleteStmt(c *DeleteStmtContext)
 }
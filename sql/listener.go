@@ -32,6 +32,13 @@ type listener struct {
 // EnterQueryStmt is called when production queryStmt is entered.
 func (l *listener) EnterQueryStmt(ctx *grammar.QueryStmtContext) {
 	l.stmt = newQueryStmtParse(ctx.T_EXPLAIN() != nil)
+	l.stmt.exemplars = ctx.T_EXEMPLARS() != nil
+}
+
+// EnterDeleteStmt is called when production deleteStmt is entered.
+func (l *listener) EnterDeleteStmt(ctx *grammar.DeleteStmtContext) {
+	l.stmt = newQueryStmtParse(false)
+	l.stmt.delete = true
 }
 
 // EnterShowDatabaseStmt is called when production showDatabaseStmt is entered.
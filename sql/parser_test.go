@@ -24,7 +24,9 @@ import (
 	"github.com/antlr/antlr4/runtime/Go/antlr"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/sql/grammar"
 	"github.com/lindb/lindb/sql/stmt"
 )
@@ -65,6 +67,86 @@ func Test_Meta_SQL_Parse(t *testing.T) {
 	assert.True(t, ok)
 }
 
+func TestParse_WithExemplars(t *testing.T) {
+	q, err := Parse("select quantile(0.99) from http where ip='1.1.1.1' group by ip With Exemplars")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.True(t, query.Exemplars)
+	assert.Equal(t, "http", query.MetricName)
+
+	q, err = Parse("select f from cpu")
+	assert.NoError(t, err)
+	assert.False(t, q.(*stmt.Query).Exemplars)
+	// quoted
+	q, err = Parse("select f from cpu where ip='with exemplars'")
+	assert.NoError(t, err)
+	assert.False(t, q.(*stmt.Query).Exemplars)
+	// not query statement
+	_, err = Parse("show databases with exemplars")
+	assert.Error(t, err)
+	// bad sql
+	_, err = Parse("select f from with exemplars")
+	assert.Error(t, err)
+	_, err = Parse("select f from cpu with exemplars limit 10")
+	assert.Error(t, err)
+	// exemplars as identifier
+	q, err = Parse("select exemplars from exemplars where exemplars='a' with exemplars")
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.True(t, query.Exemplars)
+	assert.Equal(t, "exemplars", query.MetricName)
+	assert.Equal(t, []string{"exemplars"}, query.FieldNames)
+}
+
+func TestParse_Delete(t *testing.T) {
+	q, err := Parse("Delete from http where ip='1.1.1.1' and time>'20190410 00:00:00' and time<'20190410 10:00:00'")
+	assert.NoError(t, err)
+	deleteStmt := q.(*stmt.Delete)
+	assert.Equal(t, "http", deleteStmt.MetricName)
+	assert.Equal(t, constants.DefaultNamespace, deleteStmt.Namespace)
+	assert.Equal(t, &stmt.EqualsExpr{Key: "ip", Value: "1.1.1.1"}, deleteStmt.Condition)
+	start, _ := timeutil.ParseTimestamp("20190410 00:00:00")
+	end, _ := timeutil.ParseTimestamp("20190410 10:00:00")
+	assert.Equal(t, timeutil.TimeRange{Start: start, End: end}, deleteStmt.TimeRange)
+
+	q, err = Parse(" delete on ns from http where time>now()-1h")
+	assert.NoError(t, err)
+	deleteStmt = q.(*stmt.Delete)
+	assert.Equal(t, "ns", deleteStmt.Namespace)
+	assert.Nil(t, deleteStmt.Condition)
+	assert.True(t, deleteStmt.TimeRange.End > deleteStmt.TimeRange.Start)
+
+	// start time required
+	_, err = Parse("delete from http where ip='1.1.1.1'")
+	assert.Error(t, err)
+	// group by not support
+	_, err = Parse("delete from http where time>now()-1h group by ip")
+	assert.Error(t, err)
+	// with exemplars not support
+	_, err = Parse("delete from http where time>now()-1h with exemplars")
+	assert.Error(t, err)
+	// bad sql
+	_, err = Parse("delete from where time>now()-1h")
+	assert.Error(t, err)
+	_, err = Parse("delete show databases")
+	assert.Error(t, err)
+
+	// where clause required
+	_, err = Parse("delete from http")
+	assert.Error(t, err)
+	// start time > end time
+	_, err = Parse("delete from http where time>now()-1h and time<now()-2h")
+	assert.Error(t, err)
+
+	// delete is non-reserved word
+	q, err = Parse("select delete from delete where delete='1'")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.Equal(t, "delete", query.MetricName)
+	assert.Equal(t, []string{"delete"}, query.FieldNames)
+	assert.Equal(t, &stmt.EqualsExpr{Key: "delete", Value: "1"}, query.Condition)
+}
+
 func TestParse_panic(t *testing.T) {
 	defer func() {
 		getSQLParserFunc = getSQLParser
@@ -34,7 +34,9 @@ import (
 // queryStmtParse represents query statement parser using visitor
 type queryStmtParse struct {
 	baseStmtParser
-	explain bool
+	explain   bool
+	exemplars bool
+	delete    bool // delete statement shares from/where clause with query statement
 
 	selectItems []stmt.Expr
 	fieldNames  map[string]struct{}
@@ -65,12 +67,16 @@ func newQueryStmtParse(explain bool) *queryStmtParse {
 
 // build builds query statement based on parse result
 func (q *queryStmtParse) build() (stmt.Statement, error) {
+	if q.delete {
+		return q.buildDelete()
+	}
 	if err := q.validation(); err != nil {
 		return nil, err
 	}
 
 	query := &stmt.Query{}
 	query.Explain = q.explain
+	query.Exemplars = q.exemplars
 	query.Namespace = q.namespace
 	query.MetricName = q.metricName
 	query.SelectItems = q.selectItems
@@ -105,6 +111,32 @@ func (q *queryStmtParse) build() (stmt.Statement, error) {
 	return query, nil
 }
 
+// buildDelete builds delete statement based on parse result, delete statement requires start time of time range.
+func (q *queryStmtParse) buildDelete() (stmt.Statement, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+	if len(q.metricName) == 0 {
+		return nil, fmt.Errorf("metric name cannot be empty")
+	}
+	if q.startTime <= 0 {
+		return nil, fmt.Errorf("delete statement requires start time, such as 'time > now()-1h'")
+	}
+	timeRange := timeutil.TimeRange{Start: q.startTime, End: q.endTime}
+	if timeRange.End <= 0 {
+		timeRange.End = timeutil.Now()
+	}
+	if timeRange.End < timeRange.Start {
+		return nil, fmt.Errorf("start time cannot be larger than end time")
+	}
+	return &stmt.Delete{
+		Namespace:  q.namespace,
+		MetricName: q.metricName,
+		Condition:  q.condition,
+		TimeRange:  timeRange,
+	}, nil
+}
+
 // validation tests data if invalid
 func (q *queryStmtParse) validation() error {
 	if q.err != nil {
@@ -38,6 +38,8 @@ type Query struct {
 
 	GroupBy []string // group by tag keys
 	Limit   int      // num. of time series list for result
+
+	Exemplars bool // need return exemplars which link the field values to traces
 }
 
 // HasGroupBy returns whether query has group by tag keys
@@ -59,6 +61,8 @@ type innerQuery struct {
 
 	GroupBy []string `json:"groupBy,omitempty"`
 	Limit   int      `json:"limit,omitempty"`
+
+	Exemplars bool `json:"exemplars,omitempty"`
 }
 
 // MarshalJSON returns json data of query
@@ -73,6 +77,7 @@ func (q *Query) MarshalJSON() ([]byte, error) {
 		Interval:   q.Interval,
 		GroupBy:    q.GroupBy,
 		Limit:      q.Limit,
+		Exemplars:  q.Exemplars,
 	}
 	for _, item := range q.SelectItems {
 		inner.SelectItems = append(inner.SelectItems, Marshal(item))
@@ -110,5 +115,6 @@ func (q *Query) UnmarshalJSON(value []byte) error {
 	q.Interval = inner.Interval
 	q.GroupBy = inner.GroupBy
 	q.Limit = inner.Limit
+	q.Exemplars = inner.Exemplars
 	return nil
 }
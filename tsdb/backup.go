@@ -0,0 +1,87 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package tsdb
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/fileutil"
+	"github.com/lindb/lindb/pkg/option"
+)
+
+// for testing
+var (
+	copyDir = fileutil.CopyDir
+)
+
+// backupManifestName is the manifest file name of database backup,
+// manifest is written after all files are copied, so the backup without manifest is incomplete.
+const backupManifestName = "BACKUP"
+
+// snapshotDir represents the dir under tsdb dir which keeps the shard snapshots for replica catching up.
+const snapshotDir = ".snapshot"
+
+// BackupManifest represents the manifest of database backup.
+// Backup directory tree(same as database path):
+//    xx/BACKUP
+//    xx/OPTIONS
+//    xx/meta/metric/ (metric metadata)
+//    xx/meta/tag/ (tag metadata)
+//    xx/shard/1/meta/ (series id mapping)
+//    xx/shard/1/index/ (forward/inverted index)
+//    xx/shard/1/segment/day/20191012/
+type BackupManifest struct {
+	Database  string                `toml:"database"`  // database's name
+	Timestamp int64                 `toml:"timestamp"` // backup time
+	ShardIDs  []models.ShardID      `toml:"shardIDs"`  // shard ids in backup
+	Option    option.DatabaseOption `toml:"option"`    // database option
+}
+
+// hasShard checks if the shard exists in backup
+func (m *BackupManifest) hasShard(shardID models.ShardID) bool {
+	return containsShard(m.ShardIDs, shardID)
+}
+
+// loadBackupManifest loads the manifest of backup from backup path.
+func loadBackupManifest(backupPath string) (*BackupManifest, error) {
+	manifest := &BackupManifest{}
+	manifestPath := filepath.Join(backupPath, backupManifestName)
+	if err := decodeToml(manifestPath, manifest); err != nil {
+		return nil, fmt.Errorf("load backup manifest from file[%s] with error: %s", manifestPath, err)
+	}
+	return manifest, nil
+}
+
+// ShardSnapshotPath returns the path of shard snapshot which is used to catch up replica,
+// snapshot path is under the snapshot dir of tsdb dir, name is used to distinguish the snapshots of shard.
+func ShardSnapshotPath(databaseName string, shardID models.ShardID, name string) string {
+	return filepath.Join(config.GlobalStorageConfig().TSDB.Dir, snapshotDir, databaseName, strconv.Itoa(int(shardID)), name)
+}
+
+// containsShard checks if the shard id in shard id list
+func containsShard(shardIDs []models.ShardID, shardID models.ShardID) bool {
+	for _, id := range shardIDs {
+		if id == shardID {
+			return true
+		}
+	}
+	return false
+}
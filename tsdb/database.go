@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"io"
 	"path/filepath"
+	"reflect"
 	"runtime"
 	"strconv"
 	"sync"
@@ -33,9 +34,11 @@ import (
 	"github.com/lindb/lindb/internal/linmetric"
 	"github.com/lindb/lindb/kv"
 	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/fileutil"
 	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/pkg/ltoml"
 	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/tsdb/metadb"
 	"github.com/lindb/lindb/tsdb/tblstore/tagkeymeta"
 )
@@ -79,6 +82,14 @@ type Database interface {
 	FlushMeta() error
 	// Flush flushes memory data of all shards to disk
 	Flush() error
+	// Backup writes a point-in-time copy of shards and metadata into target path,
+	// backups all shards if shard ids not given.
+	Backup(targetPath string, shardIDs ...models.ShardID) error
+	// InstallShard installs the shard from backup(such as shard snapshot from replica leader),
+	// merges the metadata of backup first, then replaces the shard if exist.
+	InstallShard(backupPath string, shardID models.ShardID) error
+	// DropShard closes the shard, then removes the data of shard(such as replica moved to other node).
+	DropShard(shardID models.ShardID) error
 }
 
 // databaseConfig represents a database configuration about config and shards
@@ -200,6 +211,10 @@ func (db *database) CreateShards(
 	if len(shardIDs) == 0 {
 		return fmt.Errorf("shardIDs list is empty")
 	}
+	// apply changed option to existing shards
+	if err := db.alterOption(option); err != nil {
+		return err
+	}
 	for _, shardID := range shardIDs {
 		_, ok := db.GetShard(shardID)
 		if ok {
@@ -212,6 +227,36 @@ func (db *database) CreateShards(
 	return nil
 }
 
+// alterOption validates the changed option on all existing shards, then applies it to the shards and persists the new option,
+// so that the option of shards is not altered partially if the option is invalid for any shard.
+func (db *database) alterOption(option option.DatabaseOption) error {
+	// be careful need do mutex unlock
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if reflect.DeepEqual(db.config.Option, option) {
+		return nil
+	}
+	entries := db.shardSet.Entries()
+	for _, entry := range entries {
+		if err := entry.shard.ValidateOption(option); err != nil {
+			return fmt.Errorf("validate option of shard[%d] for engine[%s] with error: %s", entry.shardID, db.name, err)
+		}
+	}
+	for _, entry := range entries {
+		if err := entry.shard.AlterOption(option); err != nil {
+			return fmt.Errorf("alter option of shard[%d] for engine[%s] with error: %s", entry.shardID, db.name, err)
+		}
+	}
+	newCfg := &databaseConfig{Option: option, ShardIDs: db.config.ShardIDs}
+	if err := db.dumpDatabaseConfig(newCfg); err != nil {
+		return err
+	}
+	engineLogger.Info("alter database option successfully",
+		logger.String("db", db.name), logger.Any("option", option))
+	return nil
+}
+
 // createShard creates a new shard based on option
 func (db *database) createShard(shardID models.ShardID, option option.DatabaseOption) error {
 	// be careful need do mutex unlock
@@ -290,11 +335,7 @@ func (db *database) initMetadata() error {
 	if err != nil {
 		return err
 	}
-	tagMetaFamily, err := metaStore.CreateFamily(
-		tagValueDir,
-		kv.FamilyOption{
-			CompactThreshold: 0,
-			Merger:           string(tagkeymeta.MergerName)})
+	tagMetaFamily, err := createTagMetaFamily(metaStore, db.config.Option.Compression)
 	if err != nil {
 		return err
 	}
@@ -307,6 +348,118 @@ func (db *database) initMetadata() error {
 	return nil
 }
 
+// createTagMetaFamily creates the family of tag metadata in meta kv store
+func createTagMetaFamily(metaStore kv.Store, compression option.CompressionOption) (kv.Family, error) {
+	return metaStore.CreateFamily(
+		tagValueDir,
+		kv.FamilyOption{
+			CompactThreshold: 0,
+			Merger:           string(tagkeymeta.MergerName),
+			Compression:      compressionOf(compression.Meta)})
+}
+
+// InstallShard installs the shard from backup(such as shard snapshot from replica leader),
+// the ids of metadata are used by the data of shard, so merges the metadata of backup first,
+// then closes the shard if exist, replaces the data of shard with the shard in backup.
+func (db *database) InstallShard(backupPath string, shardID models.ShardID) error {
+	if err := db.mergeMetadata(filepath.Join(backupPath, metaDir)); err != nil {
+		return fmt.Errorf("merge metadata of database[%s] with error: %w", db.name, err)
+	}
+	// be careful need do mutex unlock
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if oldShard, ok := db.shardSet.GetShard(shardID); ok {
+		db.shardSet.RemoveShard(shardID)
+		if err := oldShard.Close(); err != nil {
+			return fmt.Errorf("close shard[%d] of database[%s] with error: %s", shardID, db.name, err)
+		}
+	}
+	shardPath := filepath.Join(db.path, shardDir, strconv.Itoa(int(shardID)))
+	if err := removeDir(shardPath); err != nil {
+		return err
+	}
+	if err := copyDir(filepath.Join(backupPath, shardDir, strconv.Itoa(int(shardID))), shardPath); err != nil {
+		return err
+	}
+	installedShard, err := newShardFunc(db, shardID, shardPath, db.config.Option)
+	if err != nil {
+		return fmt.Errorf("open shard[%d] of database[%s] with error: %s", shardID, db.name, err)
+	}
+	if !containsShard(db.config.ShardIDs, shardID) {
+		newCfg := &databaseConfig{Option: db.config.Option, ShardIDs: db.config.ShardIDs}
+		newCfg.ShardIDs = append(newCfg.ShardIDs, shardID)
+		if err := db.dumpDatabaseConfig(newCfg); err != nil {
+			return err
+		}
+	}
+	db.shardSet.InsertShard(shardID, installedShard)
+	engineLogger.Info("install shard successfully",
+		logger.String("db", db.name), logger.Any("shardID", shardID), logger.String("path", backupPath))
+	return nil
+}
+
+// DropShard closes the shard, then removes the data of shard(such as replica moved to other node).
+func (db *database) DropShard(shardID models.ShardID) error {
+	// be careful need do mutex unlock
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if shard, ok := db.shardSet.GetShard(shardID); ok {
+		db.shardSet.RemoveShard(shardID)
+		if err := shard.Close(); err != nil {
+			return fmt.Errorf("close shard[%d] of database[%s] with error: %s", shardID, db.name, err)
+		}
+	}
+	if err := removeDir(filepath.Join(db.path, shardDir, strconv.Itoa(int(shardID)))); err != nil {
+		return err
+	}
+	if containsShard(db.config.ShardIDs, shardID) {
+		newCfg := &databaseConfig{Option: db.config.Option}
+		for _, id := range db.config.ShardIDs {
+			if id != shardID {
+				newCfg.ShardIDs = append(newCfg.ShardIDs, id)
+			}
+		}
+		if err := db.dumpDatabaseConfig(newCfg); err != nil {
+			return err
+		}
+	}
+	engineLogger.Info("drop shard successfully",
+		logger.String("db", db.name), logger.Any("shardID", shardID))
+	return nil
+}
+
+// mergeMetadata opens the metadata in backup as source, then merges it into the metadata of database.
+func (db *database) mergeMetadata(metaPath string) error {
+	storeOption := kv.DefaultStoreOption(filepath.Join(metaPath, tagMetaDir))
+	store, err := newKVStoreFunc(storeOption.Path, storeOption)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := store.Close(); err != nil {
+			engineLogger.Warn("close meta kv store of backup err",
+				logger.String("db", db.name), logger.String("path", storeOption.Path), logger.Error(err))
+		}
+	}()
+	family, err := createTagMetaFamily(store, db.GetOption().Compression)
+	if err != nil {
+		return err
+	}
+	source, err := newMetadataFunc(context.TODO(), db.name, filepath.Join(metaPath, metricMetaDir), family)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := source.Close(); err != nil {
+			engineLogger.Warn("close metadata of backup err",
+				logger.String("db", db.name), logger.String("path", metaPath), logger.Error(err))
+		}
+	}()
+	return db.metadata.Merge(source)
+}
+
 func (db *database) FlushMeta() (err error) {
 	// another flush process is running
 	if !db.isFlushing.CAS(false, true) {
@@ -326,6 +479,58 @@ func (db *database) Flush() error {
 	return nil
 }
 
+// Backup writes a point-in-time copy of shards and metadata into target path, backups all shards if shard ids not given,
+// backup manifest is written at last, so backup without manifest is incomplete.
+func (db *database) Backup(targetPath string, shardIDs ...models.ShardID) error {
+	if fileutil.Exist(filepath.Join(targetPath, backupManifestName)) {
+		return fmt.Errorf("backup of database[%s] already exist in path[%s]", db.name, targetPath)
+	}
+	if err := mkDirIfNotExist(targetPath); err != nil {
+		return err
+	}
+	// backup shards first, metadata must be newer than the data of shards
+	entries := db.shardSet.Entries()
+	backupShardIDs := make([]models.ShardID, 0, len(entries))
+	for _, entry := range entries {
+		if len(shardIDs) > 0 && !containsShard(shardIDs, entry.shardID) {
+			continue
+		}
+		shardPath := filepath.Join(targetPath, shardDir, strconv.Itoa(int(entry.shardID)))
+		if err := entry.shard.Backup(shardPath); err != nil {
+			return fmt.Errorf("backup shard[%d] of database[%s] with error: %s", entry.shardID, db.name, err)
+		}
+		backupShardIDs = append(backupShardIDs, entry.shardID)
+	}
+	if len(backupShardIDs) != len(shardIDs) && len(shardIDs) > 0 {
+		return fmt.Errorf("shards%v of database[%s] not found", shardIDs, db.name)
+	}
+	if err := db.metadata.Flush(); err != nil {
+		return err
+	}
+	if err := db.metadata.MetadataDatabase().Backup(filepath.Join(targetPath, metaDir, metricMetaDir)); err != nil {
+		return err
+	}
+	if err := db.metaStore.Backup(filepath.Join(targetPath, metaDir, tagMetaDir)); err != nil {
+		return err
+	}
+	cfg := &databaseConfig{Option: db.config.Option, ShardIDs: backupShardIDs}
+	if err := encodeToml(optionsPath(targetPath), cfg); err != nil {
+		return err
+	}
+	manifest := &BackupManifest{
+		Database:  db.name,
+		Timestamp: timeutil.Now(),
+		ShardIDs:  backupShardIDs,
+		Option:    db.config.Option,
+	}
+	if err := encodeToml(filepath.Join(targetPath, backupManifestName), manifest); err != nil {
+		return err
+	}
+	engineLogger.Info("backup database successfully",
+		logger.String("db", db.name), logger.String("path", targetPath))
+	return nil
+}
+
 // optionsPath returns options file path
 func optionsPath(path string) string {
 	return filepath.Join(path, options)
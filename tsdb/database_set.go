@@ -40,6 +40,17 @@ func (ds *databaseSet) PutDatabase(newDBName string, newDB Database) {
 	ds.value.Store(newDBSet)
 }
 
+func (ds *databaseSet) DropDatabase(dbName string) {
+	oldDBSet := ds.Entries()
+	var newDBSet = make(map[string]Database)
+	for name, db := range oldDBSet {
+		if name != dbName {
+			newDBSet[name] = db
+		}
+	}
+	ds.value.Store(newDBSet)
+}
+
 func (ds *databaseSet) GetDatabase(dbName string) (Database, bool) {
 	db, ok := ds.Entries()[dbName]
 	return db, ok
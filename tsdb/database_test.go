@@ -20,6 +20,7 @@ package tsdb
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"sync"
 	"testing"
 
@@ -138,9 +139,10 @@ func TestDatabase_CreateShards(t *testing.T) {
 		encodeToml = ltoml.EncodeToml
 		ctrl.Finish()
 	}()
+	dbOption := option.DatabaseOption{Interval: "10s"}
 	db, err := newDatabase("db", testPath, &databaseConfig{
 		ShardIDs: []models.ShardID{1, 2, 3},
-		Option:   option.DatabaseOption{Interval: "10s"},
+		Option:   dbOption,
 	}, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, db)
@@ -153,17 +155,17 @@ func TestDatabase_CreateShards(t *testing.T) {
 		shardPath string, option option.DatabaseOption) (s Shard, err error) {
 		return nil, fmt.Errorf("err")
 	}
-	err = db.CreateShards(option.DatabaseOption{}, []models.ShardID{4, 5, 6})
+	err = db.CreateShards(dbOption, []models.ShardID{4, 5, 6})
 	assert.Error(t, err)
 	// case 3: create exist shard
-	err = db.CreateShards(option.DatabaseOption{}, []models.ShardID{1, 2, 3})
+	err = db.CreateShards(dbOption, []models.ShardID{1, 2, 3})
 	assert.NoError(t, err)
 	// case 4: create shard success
 	newShardFunc = func(db Database, shardID models.ShardID,
 		shardPath string, option option.DatabaseOption) (s Shard, err error) {
 		return nil, nil
 	}
-	err = db.CreateShards(option.DatabaseOption{}, []models.ShardID{4, 5, 6})
+	err = db.CreateShards(dbOption, []models.ShardID{4, 5, 6})
 	assert.NoError(t, err)
 	// case 5: dump option err
 	newShardFunc = func(db Database, shardID models.ShardID,
@@ -173,7 +175,7 @@ func TestDatabase_CreateShards(t *testing.T) {
 	encodeToml = func(fileName string, v interface{}) error {
 		return fmt.Errorf("err")
 	}
-	err = db.CreateShards(option.DatabaseOption{}, []models.ShardID{9})
+	err = db.CreateShards(dbOption, []models.ShardID{9})
 	assert.Error(t, err)
 	// case 6: create exist shard
 	db1 := db.(*database)
@@ -181,6 +183,52 @@ func TestDatabase_CreateShards(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestDatabase_AlterOption(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	_ = fileutil.MkDirIfNotExist(testPath)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		encodeToml = ltoml.EncodeToml
+		ctrl.Finish()
+	}()
+	shard := NewMockShard(ctrl)
+	db := &database{
+		name:     "db",
+		path:     testPath,
+		config:   &databaseConfig{ShardIDs: []models.ShardID{1}, Option: option.DatabaseOption{Interval: "10s"}},
+		shardSet: *newShardSet(),
+	}
+	db.shardSet.InsertShard(1, shard)
+	newOption := option.DatabaseOption{Interval: "10s", Rollup: []string{"5m"}}
+	// case 1: validate shard option err, shard option not altered
+	shard.EXPECT().ValidateOption(newOption).Return(fmt.Errorf("err"))
+	err := db.CreateShards(newOption, []models.ShardID{1})
+	assert.Error(t, err)
+	// case 2: alter shard option err
+	shard.EXPECT().ValidateOption(newOption).Return(nil)
+	shard.EXPECT().AlterOption(newOption).Return(fmt.Errorf("err"))
+	err = db.CreateShards(newOption, []models.ShardID{1})
+	assert.Error(t, err)
+	// case 3: dump option err
+	encodeToml = func(fileName string, v interface{}) error {
+		return fmt.Errorf("err")
+	}
+	shard.EXPECT().ValidateOption(newOption).Return(nil)
+	shard.EXPECT().AlterOption(newOption).Return(nil)
+	err = db.CreateShards(newOption, []models.ShardID{1})
+	assert.Error(t, err)
+	// case 4: alter option successfully
+	encodeToml = ltoml.EncodeToml
+	shard.EXPECT().ValidateOption(newOption).Return(nil)
+	shard.EXPECT().AlterOption(newOption).Return(nil)
+	err = db.CreateShards(newOption, []models.ShardID{1})
+	assert.NoError(t, err)
+	assert.Equal(t, newOption, db.config.Option)
+	// case 5: option not changed
+	err = db.CreateShards(newOption, []models.ShardID{1})
+	assert.NoError(t, err)
+}
+
 func TestDatabase_Close(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -257,6 +305,114 @@ func TestDatabase_Flush(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestDatabase_Backup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		encodeToml = ltoml.EncodeToml
+		ctrl.Finish()
+	}()
+
+	metaStore := kv.NewMockStore(ctrl)
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+	shard1 := NewMockShard(ctrl)
+	db := &database{
+		name:      "db",
+		config:    &databaseConfig{Option: option.DatabaseOption{Interval: "10s"}},
+		metadata:  metadata,
+		shardSet:  *newShardSet(),
+		metaStore: metaStore}
+	db.shardSet.InsertShard(1, shard1)
+	target := filepath.Join(testPath, "backup")
+	// case 1: backup shard err
+	shard1.EXPECT().Backup(filepath.Join(target, shardDir, "1")).Return(fmt.Errorf("err"))
+	assert.Error(t, db.Backup(target))
+	shard1.EXPECT().Backup(gomock.Any()).Return(nil).AnyTimes()
+	// case 2: flush metadata err
+	metadata.EXPECT().Flush().Return(fmt.Errorf("err"))
+	assert.Error(t, db.Backup(target))
+	metadata.EXPECT().Flush().Return(nil).AnyTimes()
+	// case 3: backup metric metadata err
+	metadataDB.EXPECT().Backup(filepath.Join(target, metaDir, metricMetaDir)).Return(fmt.Errorf("err"))
+	assert.Error(t, db.Backup(target))
+	metadataDB.EXPECT().Backup(gomock.Any()).Return(nil).AnyTimes()
+	// case 4: backup tag metadata err
+	metaStore.EXPECT().Backup(filepath.Join(target, metaDir, tagMetaDir)).Return(fmt.Errorf("err"))
+	assert.Error(t, db.Backup(target))
+	metaStore.EXPECT().Backup(gomock.Any()).Return(nil).AnyTimes()
+	// case 5: write options err
+	encodeToml = func(fileName string, v interface{}) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, db.Backup(target))
+	encodeToml = ltoml.EncodeToml
+	// case 6: backup successfully
+	assert.NoError(t, db.Backup(target))
+	manifest := &BackupManifest{}
+	assert.NoError(t, ltoml.DecodeToml(filepath.Join(target, backupManifestName), manifest))
+	assert.Equal(t, "db", manifest.Database)
+	assert.Equal(t, []models.ShardID{1}, manifest.ShardIDs)
+	assert.Equal(t, db.config.Option, manifest.Option)
+	// case 7: backup exist
+	assert.Error(t, db.Backup(target))
+	// case 8: backup shard not exist
+	assert.Error(t, db.Backup(filepath.Join(testPath, "backup2"), 2))
+	// case 9: backup given shard
+	shard2 := NewMockShard(ctrl)
+	shard2.EXPECT().Backup(gomock.Any()).Return(nil).AnyTimes()
+	db.shardSet.InsertShard(2, shard2)
+	target = filepath.Join(testPath, "backup3")
+	assert.NoError(t, db.Backup(target, 2))
+	assert.NoError(t, ltoml.DecodeToml(filepath.Join(target, backupManifestName), manifest))
+	assert.Equal(t, []models.ShardID{2}, manifest.ShardIDs)
+}
+
+func TestDatabase_DropShard(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		encodeToml = ltoml.EncodeToml
+		removeDir = fileutil.RemoveDir
+		ctrl.Finish()
+	}()
+
+	assert.NoError(t, fileutil.MkDirIfNotExist(testPath))
+	shard1 := NewMockShard(ctrl)
+	db := &database{
+		name:     "db",
+		path:     testPath,
+		config:   &databaseConfig{Option: option.DatabaseOption{Interval: "10s"}, ShardIDs: []models.ShardID{1, 2}},
+		shardSet: *newShardSet(),
+	}
+	db.shardSet.InsertShard(1, shard1)
+	// case 1: close shard err
+	shard1.EXPECT().Close().Return(fmt.Errorf("err"))
+	assert.Error(t, db.DropShard(1))
+	_, ok := db.shardSet.GetShard(1)
+	assert.False(t, ok)
+	// case 2: remove shard dir err
+	removeDir = func(path string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, db.DropShard(1))
+	removeDir = fileutil.RemoveDir
+	// case 3: write options err
+	encodeToml = func(fileName string, v interface{}) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, db.DropShard(1))
+	assert.Equal(t, []models.ShardID{1, 2}, db.config.ShardIDs)
+	encodeToml = ltoml.EncodeToml
+	// case 4: drop shard successfully
+	assert.NoError(t, db.DropShard(1))
+	assert.Equal(t, []models.ShardID{2}, db.config.ShardIDs)
+	// case 5: drop shard not exist
+	assert.NoError(t, db.DropShard(1))
+	assert.Equal(t, []models.ShardID{2}, db.config.ShardIDs)
+}
+
 func Test_ShardSet_multi(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
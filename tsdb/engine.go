@@ -21,15 +21,22 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
+
+	"go.uber.org/atomic"
 
 	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/kv/table"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/pkg/fileutil"
 	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/pkg/ltoml"
 	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/pkg/timeutil"
 )
 
 //go:generate mockgen -source=./engine.go -destination=./engine_mock.go -package=tsdb
@@ -38,12 +45,24 @@ import (
 var (
 	mkDirIfNotExist = fileutil.MkDirIfNotExist
 	listDir         = fileutil.ListDir
+	removeDir       = fileutil.RemoveDir
 	decodeToml      = ltoml.DecodeToml
 	newDatabaseFunc = newDatabase
 )
 
+var (
+	// can be modified in runtime
+	dataExpireCheckInterval = *atomic.NewDuration(time.Minute)
+	seriesGCInterval        = *atomic.NewDuration(time.Hour)
+	segmentMoveInterval     = *atomic.NewDuration(10 * time.Minute)
+)
+
 var engineLogger = logger.GetLogger("tsdb", "Engine")
 
+// trashDir represents the dir under tsdb dir(and storage tier dir) which keeps the data of dropped databases
+// during purge delay, the name of dropped database dir is database name + "." + expire time.
+const trashDir = ".trash"
+
 // Engine represents a time series engine
 type Engine interface {
 	// createDatabase creates database instance by database's name
@@ -64,6 +83,17 @@ type Engine interface {
 	GetDatabase(databaseName string) (Database, bool)
 	// FlushDatabase produces a signal to workers for flushing memory database by name
 	FlushDatabase(ctx context.Context, databaseName string) bool
+	// BackupDatabase writes a point-in-time copy of database into target path
+	BackupDatabase(databaseName string, targetPath string) error
+	// RestoreDatabase rebuilds database from the backup path, installs the shards into database if it exists,
+	// restores all shards in backup if shard ids not given.
+	RestoreDatabase(backupPath string, shardIDs ...models.ShardID) error
+	// InstallShardSnapshot installs the shard snapshot from replica leader,
+	// merges the metadata of snapshot into database, only the shard is replaced.
+	InstallShardSnapshot(snapshotPath string, shardID models.ShardID) error
+	// DropDatabase closes the database, then removes the data of database(includes the data in storage tiers),
+	// if purge delay > 0, moves the data into trash, and removes it after purge delay.
+	DropDatabase(databaseName string, purgeDelay time.Duration) error
 	// Close closes the cached time series databases
 	Close()
 
@@ -88,12 +118,17 @@ func NewEngine() (Engine, error) {
 		return nil, fmt.Errorf("create time sereis storage path[%s] erorr: %s",
 			config.GlobalStorageConfig().TSDB.Dir, err)
 	}
+	// set the memory budget of block cache shared by all table readers
+	table.SetBlockCacheSize(int64(config.GlobalStorageConfig().TSDB.GetBlockCacheSize()))
 	e := &engine{
 		dbSet: *newDatabaseSet(),
 	}
 	e.ctx, e.cancel = context.WithCancel(context.Background())
 	e.dataFlushChecker = newDataFlushChecker(e.ctx)
 	e.dataFlushChecker.Start()
+	go e.checkDataExpire()
+	go e.gcSeries()
+	go e.moveSegments()
 
 	if err := e.load(); err != nil {
 		engineLogger.Error("load engine data error when create a new engine", logger.Error(err))
@@ -182,6 +217,9 @@ func (e *engine) Close() {
 	if e.dataFlushChecker != nil {
 		e.dataFlushChecker.Stop()
 	}
+	if e.cancel != nil {
+		e.cancel()
+	}
 	for dbName, db := range e.dbSet.Entries() {
 		if err := db.Close(); err != nil {
 			engineLogger.Error("close database",
@@ -203,6 +241,171 @@ func (e *engine) FlushDatabase(ctx context.Context, name string) bool {
 	return true
 }
 
+// BackupDatabase writes a point-in-time copy of database into target path
+func (e *engine) BackupDatabase(databaseName string, targetPath string) error {
+	db, ok := e.dbSet.GetDatabase(databaseName)
+	if !ok {
+		return fmt.Errorf("database[%s] not found", databaseName)
+	}
+	return db.Backup(targetPath)
+}
+
+// RestoreDatabase rebuilds database from the backup path, installs the shards into database if it exists,
+// restores all shards in backup if shard ids not given.
+func (e *engine) RestoreDatabase(backupPath string, shardIDs ...models.ShardID) (err error) {
+	manifest, err := loadBackupManifest(backupPath)
+	if err != nil {
+		return err
+	}
+	if len(shardIDs) == 0 {
+		shardIDs = manifest.ShardIDs
+	}
+	for _, shardID := range shardIDs {
+		if !manifest.hasShard(shardID) {
+			return fmt.Errorf("shard[%d] of database[%s] not found in backup", shardID, manifest.Database)
+		}
+	}
+	databaseName := manifest.Database
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if db, ok := e.GetDatabase(databaseName); ok {
+		// rebuilds the shards of live database, other shards are untouched
+		for _, shardID := range shardIDs {
+			if err = db.InstallShard(backupPath, shardID); err != nil {
+				return err
+			}
+		}
+		engineLogger.Info("restore shards of database successfully",
+			logger.String("db", databaseName), logger.Any("shardIDs", shardIDs), logger.String("path", backupPath))
+		return nil
+	}
+	dbPath := filepath.Join(config.GlobalStorageConfig().TSDB.Dir, databaseName)
+	if fileutil.Exist(dbPath) {
+		return fmt.Errorf("path[%s] of database[%s] already exist", dbPath, databaseName)
+	}
+	defer func() {
+		if err != nil {
+			if removeErr := removeDir(dbPath); removeErr != nil {
+				engineLogger.Error("remove database path err when restore failure",
+					logger.String("db", databaseName), logger.Error(removeErr))
+			}
+		}
+	}()
+	if err = copyDir(filepath.Join(backupPath, metaDir), filepath.Join(dbPath, metaDir)); err != nil {
+		return err
+	}
+	for _, shardID := range shardIDs {
+		shardPath := filepath.Join(shardDir, strconv.Itoa(int(shardID)))
+		if err = copyDir(filepath.Join(backupPath, shardPath), filepath.Join(dbPath, shardPath)); err != nil {
+			return err
+		}
+	}
+	cfg := &databaseConfig{Option: manifest.Option, ShardIDs: shardIDs}
+	if err = encodeToml(optionsPath(dbPath), cfg); err != nil {
+		return err
+	}
+	if _, err = e.createDatabase(databaseName); err != nil {
+		return err
+	}
+	engineLogger.Info("restore database successfully",
+		logger.String("db", databaseName), logger.String("path", backupPath))
+	return nil
+}
+
+// InstallShardSnapshot installs the shard snapshot from replica leader, only the shard is replaced:
+// 1. restores the database with the shard from snapshot if database not exist;
+// 2. else merges the metadata of snapshot into database, then replaces the shard, other shards are untouched.
+func (e *engine) InstallShardSnapshot(snapshotPath string, shardID models.ShardID) error {
+	if err := e.RestoreDatabase(snapshotPath, shardID); err != nil {
+		return err
+	}
+	engineLogger.Info("install shard snapshot successfully",
+		logger.Any("shardID", shardID), logger.String("path", snapshotPath))
+	return nil
+}
+
+// DropDatabase closes the database, then removes the data of database(includes the data in storage tiers),
+// if purge delay > 0, moves the data into trash, and removes it after purge delay.
+func (e *engine) DropDatabase(databaseName string, purgeDelay time.Duration) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if db, ok := e.dbSet.GetDatabase(databaseName); ok {
+		e.dbSet.DropDatabase(databaseName)
+		if err := db.Close(); err != nil {
+			return fmt.Errorf("close database[%s] with error: %s", databaseName, err)
+		}
+	}
+	expireAt := timeutil.Now() + purgeDelay.Milliseconds()
+	for _, dir := range dataDirs() {
+		dbPath := filepath.Join(dir, databaseName)
+		if !fileutil.Exist(dbPath) {
+			continue
+		}
+		if purgeDelay <= 0 {
+			if err := removeDir(dbPath); err != nil {
+				return fmt.Errorf("remove path[%s] of database[%s] with error: %s", dbPath, databaseName, err)
+			}
+			continue
+		}
+		if err := mkDirIfNotExist(filepath.Join(dir, trashDir)); err != nil {
+			return err
+		}
+		trashPath := filepath.Join(dir, trashDir, databaseName+"."+strconv.FormatInt(expireAt, 10))
+		if err := renameDir(dbPath, trashPath); err != nil {
+			return fmt.Errorf("move path[%s] of database[%s] into trash with error: %s", dbPath, databaseName, err)
+		}
+	}
+	engineLogger.Info("drop database successfully",
+		logger.String("db", databaseName), logger.String("purgeDelay", purgeDelay.String()))
+	return nil
+}
+
+// purgeDroppedDatabases removes the data of dropped databases in trash after purge delay
+func (e *engine) purgeDroppedDatabases(now int64) {
+	for _, dir := range dataDirs() {
+		trashPath := filepath.Join(dir, trashDir)
+		if !fileutil.Exist(trashPath) {
+			continue
+		}
+		names, err := listDir(trashPath)
+		if err != nil {
+			engineLogger.Error("list dropped databases error",
+				logger.String("path", trashPath), logger.Error(err))
+			continue
+		}
+		for _, name := range names {
+			idx := strings.LastIndex(name, ".")
+			if idx < 0 {
+				continue
+			}
+			expireAt, err := strconv.ParseInt(name[idx+1:], 10, 64)
+			if err != nil || expireAt > now {
+				continue
+			}
+			if err := removeDir(filepath.Join(trashPath, name)); err != nil {
+				engineLogger.Error("remove dropped database error",
+					logger.String("path", trashPath), logger.String("db", name[:idx]), logger.Error(err))
+				continue
+			}
+			engineLogger.Info("remove dropped database after purge delay",
+				logger.String("path", trashPath), logger.String("db", name[:idx]))
+		}
+	}
+}
+
+// dataDirs returns the tsdb dir and the dirs of storage tiers
+func dataDirs() []string {
+	tsdbCfg := config.GlobalStorageConfig().TSDB
+	dirs := []string{tsdbCfg.Dir}
+	for _, tier := range tsdbCfg.Tiers {
+		dirs = append(dirs, tier.Dir)
+	}
+	return dirs
+}
+
 // load loads the time series engines if exist
 func (e *engine) load() error {
 	databaseNames, err := listDir(config.GlobalStorageConfig().TSDB.Dir)
@@ -212,6 +415,9 @@ func (e *engine) load() error {
 	e.mutex.Lock()
 	defer e.mutex.Unlock()
 	for _, databaseName := range databaseNames {
+		if databaseName == trashDir || databaseName == snapshotDir {
+			continue
+		}
 		_, err := e.createDatabase(databaseName)
 		if err != nil {
 			return err
@@ -220,6 +426,69 @@ func (e *engine) load() error {
 	return nil
 }
 
+// checkDataExpire expires the data of all shards periodically based on data retention(ttl)
+func (e *engine) checkDataExpire() {
+	ticker := time.NewTicker(dataExpireCheckInterval.Load())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-e.ctx.Done():
+			return
+		case <-ticker.C:
+			GetShardManager().WalkEntry(func(shard Shard) {
+				shard.ExpireData()
+			})
+			e.purgeDroppedDatabases(timeutil.Now())
+		}
+	}
+}
+
+// moveSegments moves the old segments of all shards into cold storage tiers periodically
+func (e *engine) moveSegments() {
+	if len(config.GlobalStorageConfig().TSDB.Tiers) == 0 {
+		return
+	}
+	ticker := time.NewTicker(segmentMoveInterval.Load())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-e.ctx.Done():
+			return
+		case <-ticker.C:
+			GetShardManager().WalkEntry(func(shard Shard) {
+				shard.MoveSegments()
+			})
+		}
+	}
+}
+
+// gcSeries removes the series which have no data from index of all shards periodically
+func (e *engine) gcSeries() {
+	ticker := time.NewTicker(seriesGCInterval.Load())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-e.ctx.Done():
+			return
+		case <-ticker.C:
+			GetShardManager().WalkEntry(func(shard Shard) {
+				collected, err := shard.GCSeries()
+				if err != nil {
+					engineLogger.Error("gc series error",
+						logger.String("shard", shard.ShardInfo()), logger.Error(err))
+				}
+				if collected > 0 {
+					engineLogger.Info("gc series successfully",
+						logger.String("shard", shard.ShardInfo()), logger.Int("collected", collected))
+				}
+			})
+		}
+	}
+}
+
 //func (e *engine) databaseMetaFlusher(ctx context.Context) {
 //	ticker := time.NewTicker(flushMetaInterval.Load())
 //	defer ticker.Stop()
@@ -19,18 +19,24 @@ package tsdb
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/atomic"
 
 	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/pkg/fileutil"
 	"github.com/lindb/lindb/pkg/ltoml"
+	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/pkg/timeutil"
 )
 
 var testPath = "test_data"
@@ -197,6 +203,216 @@ func Test_Engine_Flush_Database(t *testing.T) {
 	assert.False(t, ok)
 }
 
+func Test_Engine_Backup_Restore(t *testing.T) {
+	backupPath := filepath.Join(testPath+"_backup", "db")
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		_ = fileutil.RemoveDir(testPath + "_backup")
+		copyDir = fileutil.CopyDir
+		encodeToml = ltoml.EncodeToml
+	}()
+	withTestPath()
+
+	e, err := NewEngine()
+	assert.NoError(t, err)
+	// case 1: database not exist
+	assert.Error(t, e.BackupDatabase("db", backupPath))
+	// case 2: backup successfully
+	opt := option.DatabaseOption{Interval: "10s"}
+	assert.NoError(t, e.CreateShards("db", opt, 1, 2))
+	assert.NoError(t, e.BackupDatabase("db", backupPath))
+	assert.True(t, fileutil.Exist(filepath.Join(backupPath, backupManifestName)))
+	assert.True(t, fileutil.Exist(filepath.Join(backupPath, metaDir, tagMetaDir)))
+	assert.True(t, fileutil.Exist(filepath.Join(backupPath, shardDir, "2", indexParentDir)))
+	// case 3: backup exist
+	assert.Error(t, e.BackupDatabase("db", backupPath))
+	// case 4: restore shards into live database
+	assert.NoError(t, e.RestoreDatabase(backupPath, 2))
+	_, ok := e.GetShard("db", 2)
+	assert.True(t, ok)
+	assert.NoError(t, e.RestoreDatabase(backupPath))
+	_, ok = e.GetShard("db", 1)
+	assert.True(t, ok)
+	e.Close()
+
+	_ = fileutil.RemoveDir(testPath)
+	e, err = NewEngine()
+	assert.NoError(t, err)
+	defer e.Close()
+	// case 5: manifest not found
+	assert.Error(t, e.RestoreDatabase(testPath))
+	// case 6: shard not in backup
+	assert.Error(t, e.RestoreDatabase(backupPath, 3))
+	// case 7: copy err
+	copyDir = func(src, dst string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, e.RestoreDatabase(backupPath, 1))
+	assert.False(t, fileutil.Exist(filepath.Join(testPath, "db")))
+	copyDir = fileutil.CopyDir
+	// case 8: write options err
+	encodeToml = func(fileName string, v interface{}) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, e.RestoreDatabase(backupPath, 1))
+	encodeToml = ltoml.EncodeToml
+	// case 9: restore successfully
+	assert.NoError(t, e.RestoreDatabase(backupPath, 1))
+	db, ok := e.GetDatabase("db")
+	assert.True(t, ok)
+	assert.Equal(t, opt, db.GetOption())
+	// case 10: install shard into live database err
+	copyDir = func(src, dst string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, e.RestoreDatabase(backupPath, 2))
+	copyDir = fileutil.CopyDir
+	_, ok = e.GetShard("db", 1)
+	assert.True(t, ok)
+	_, ok = e.GetShard("db", 2)
+	assert.False(t, ok)
+}
+
+func Test_Engine_InstallShardSnapshot(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+	withTestPath()
+
+	e, err := NewEngine()
+	assert.NoError(t, err)
+	defer e.Close()
+
+	snapshotPath := ShardSnapshotPath("db", 1, "1_2")
+	// case 1: manifest not found
+	assert.Error(t, e.InstallShardSnapshot(snapshotPath, 1))
+	opt := option.DatabaseOption{Interval: "10s"}
+	assert.NoError(t, e.CreateShards("db", opt, 1, 2))
+	db, _ := e.GetDatabase("db")
+	cpuID, err := db.Metadata().MetadataDatabase().GenMetricID("ns", "cpu")
+	assert.NoError(t, err)
+	shard, _ := e.GetShard("db", 1)
+	// case 2: no replica message applied
+	_, err = shard.Snapshot(snapshotPath)
+	assert.Error(t, err)
+	shard.ApplyReplica(5, func() {})
+	snapshotIdx, err := shard.Snapshot(snapshotPath)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), snapshotIdx)
+	// case 3: shard not in snapshot
+	assert.Error(t, e.InstallShardSnapshot(snapshotPath, 2))
+	// case 4: install snapshot into database with other shards
+	assert.NoError(t, e.InstallShardSnapshot(snapshotPath, 1))
+	_, ok := e.GetShard("db", 1)
+	assert.True(t, ok)
+	_, ok = e.GetShard("db", 2)
+	assert.True(t, ok)
+	// case 5: metadata conflict with snapshot
+	assert.NoError(t, e.DropDatabase("db", 0))
+	assert.NoError(t, e.CreateShards("db", opt, 2))
+	db, _ = e.GetDatabase("db")
+	memID, err := db.Metadata().MetadataDatabase().GenMetricID("ns", "mem")
+	assert.NoError(t, err)
+	assert.Equal(t, cpuID, memID)
+	err = e.InstallShardSnapshot(snapshotPath, 1)
+	assert.True(t, errors.Is(err, constants.ErrMetadataConflict))
+	_, ok = e.GetShard("db", 1)
+	assert.False(t, ok)
+	// case 6: merge metadata of snapshot
+	assert.NoError(t, e.DropDatabase("db", 0))
+	assert.NoError(t, e.CreateShards("db", opt, 2))
+	assert.NoError(t, e.InstallShardSnapshot(snapshotPath, 1))
+	db, _ = e.GetDatabase("db")
+	metricID, err := db.Metadata().MetadataDatabase().GetMetricID("ns", "cpu")
+	assert.NoError(t, err)
+	assert.Equal(t, cpuID, metricID)
+	_, ok = e.GetShard("db", 1)
+	assert.True(t, ok)
+	_, ok = e.GetShard("db", 2)
+	assert.True(t, ok)
+	// case 7: restore database from snapshot if not exist
+	assert.NoError(t, e.DropDatabase("db", 0))
+	assert.NoError(t, e.InstallShardSnapshot(snapshotPath, 1))
+	_, ok = e.GetShard("db", 1)
+	assert.True(t, ok)
+	_, ok = e.GetShard("db", 2)
+	assert.False(t, ok)
+}
+
+func Test_Engine_DropDatabase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		_ = fileutil.RemoveDir(testPath + "_cold")
+		config.GlobalStorageConfig().TSDB.Tiers = nil
+		removeDir = fileutil.RemoveDir
+		renameDir = os.Rename
+		ctrl.Finish()
+	}()
+	withTestPath()
+	tierPath := testPath + "_cold"
+	config.GlobalStorageConfig().TSDB.Tiers = []config.StorageTier{{Dir: tierPath}}
+
+	e, err := NewEngine()
+	assert.NoError(t, err)
+	defer e.Close()
+	engineImpl := e.(*engine)
+	// case 1: close database err
+	mockDatabase := NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Close().Return(fmt.Errorf("err"))
+	engineImpl.dbSet.PutDatabase("db", mockDatabase)
+	assert.Error(t, e.DropDatabase("db", 0))
+	_, ok := e.GetDatabase("db")
+	assert.False(t, ok)
+	// case 2: remove data err
+	opt := option.DatabaseOption{Interval: "10s"}
+	assert.NoError(t, e.CreateShards("db", opt, 1))
+	removeDir = func(path string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, e.DropDatabase("db", 0))
+	removeDir = fileutil.RemoveDir
+	// case 3: drop database without purge delay
+	assert.NoError(t, fileutil.MkDirIfNotExist(filepath.Join(tierPath, "db")))
+	assert.NoError(t, e.DropDatabase("db", 0))
+	assert.False(t, fileutil.Exist(filepath.Join(testPath, "db")))
+	assert.False(t, fileutil.Exist(filepath.Join(tierPath, "db")))
+	// case 4: move into trash err
+	assert.NoError(t, e.CreateShards("db", opt, 1))
+	renameDir = func(oldPath, newPath string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, e.DropDatabase("db", time.Hour))
+	renameDir = os.Rename
+	// case 5: drop database with purge delay
+	assert.NoError(t, e.CreateShards("db", opt, 1))
+	assert.NoError(t, fileutil.MkDirIfNotExist(filepath.Join(tierPath, "db")))
+	assert.NoError(t, e.DropDatabase("db", time.Hour))
+	assert.False(t, fileutil.Exist(filepath.Join(testPath, "db")))
+	names, _ := fileutil.ListDir(filepath.Join(testPath, trashDir))
+	assert.Len(t, names, 1)
+	names, _ = fileutil.ListDir(filepath.Join(tierPath, trashDir))
+	assert.Len(t, names, 1)
+	// case 6: trash is not loaded as database
+	e.Close()
+	e, err = NewEngine()
+	assert.NoError(t, err)
+	engineImpl = e.(*engine)
+	_, ok = e.GetDatabase(trashDir)
+	assert.False(t, ok)
+	// case 7: keep data during purge delay
+	assert.NoError(t, fileutil.MkDirIfNotExist(filepath.Join(testPath, trashDir, "invalid")))
+	engineImpl.purgeDroppedDatabases(timeutil.Now())
+	names, _ = fileutil.ListDir(filepath.Join(testPath, trashDir))
+	assert.Len(t, names, 2)
+	// case 8: remove data after purge delay
+	engineImpl.purgeDroppedDatabases(timeutil.Now() + 2*timeutil.OneHour)
+	names, _ = fileutil.ListDir(filepath.Join(testPath, trashDir))
+	assert.Equal(t, []string{"invalid"}, names)
+	names, _ = fileutil.ListDir(filepath.Join(tierPath, trashDir))
+	assert.Empty(t, names)
+}
+
 var testDatabaseNames = []string{
 	"_internal", "system", "docker", "network", "java",
 	"runtime", "go", "php", "k8s", "infra", "prometheus",
@@ -18,10 +18,15 @@
 package tsdb
 
 import (
+	"errors"
+
 	"github.com/lindb/roaring"
 
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/flow"
 	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/kv/table"
+	"github.com/lindb/lindb/kv/version"
 	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/series/field"
@@ -44,6 +49,13 @@ type DataFamily interface {
 	TimeRange() timeutil.TimeRange
 	// Family returns the raw kv family
 	Family() kv.Family
+	// DeleteSeries writes tombstone which marks the data of series in time range as deleted,
+	// the data will be purged when compaction.
+	DeleteSeries(metricID uint32, seriesIDs *roaring.Bitmap, timeRange timeutil.TimeRange) error
+	// GetSeriesIDsWithData returns the series ids of metric which still have data(not deleted).
+	GetSeriesIDsWithData(metricID uint32) (*roaring.Bitmap, error)
+	// GetMetricIDs returns the ids of metrics which have data(includes tombstone) in data family.
+	GetMetricIDs() (*roaring.Bitmap, error)
 
 	// DataFilter filters data under data family based on query condition
 	flow.DataFilter
@@ -84,6 +96,109 @@ func (f *dataFamily) Family() kv.Family {
 	return f.family
 }
 
+// DeleteSeries writes tombstone which marks the data of series in time range as deleted,
+// the data will be purged when compaction.
+// NOTICE: tombstone only deletes the data of files existing now(version = max file number),
+// so memory data need be flushed before deleting, and the data written after deleting is kept.
+func (f *dataFamily) DeleteSeries(metricID uint32, seriesIDs *roaring.Bitmap, timeRange timeutil.TimeRange) error {
+	start, end := timeRange.Start, timeRange.End
+	if start < f.timeRange.Start {
+		start = f.timeRange.Start
+	}
+	if end > f.timeRange.End {
+		end = f.timeRange.End
+	}
+	if start > end || seriesIDs.IsEmpty() {
+		return nil
+	}
+	// fence compaction, make sure the files of current version not be merged into new file before tombstone written
+	release := f.family.FenceCompaction()
+	defer release()
+
+	snapshot := f.family.GetSnapshot()
+	var version table.FileNumber
+	for _, fileMeta := range snapshot.GetCurrent().GetAllFiles() {
+		if fileMeta.GetFileNumber() > version {
+			version = fileMeta.GetFileNumber()
+		}
+	}
+	snapshot.Close()
+	if version == 0 {
+		// no data need to delete
+		return nil
+	}
+	calc := f.interval.Calculator()
+	block, err := metricsdata.EncodeTombstones(metricsdata.Tombstones{{
+		SeriesIDs: seriesIDs,
+		SlotRange: timeutil.NewSlotRange(
+			uint16(calc.CalcSlot(start, f.timeRange.Start, f.interval.Int64())),
+			uint16(calc.CalcSlot(end, f.timeRange.Start, f.interval.Int64())),
+		),
+		Version: version,
+	}})
+	if err != nil {
+		return err
+	}
+	flusher := f.family.NewFlusher()
+	if err := flusher.Add(metricID, block); err != nil {
+		return err
+	}
+	return flusher.Commit()
+}
+
+// GetMetricIDs returns the ids of metrics which have data(includes tombstone) in data family.
+func (f *dataFamily) GetMetricIDs() (*roaring.Bitmap, error) {
+	snapShot := f.family.GetSnapshot()
+	defer snapShot.Close()
+
+	metricIDs := roaring.New()
+	for _, file := range snapShot.GetCurrent().GetAllFiles() {
+		reader, err := snapShot.GetReader(file.GetFileNumber())
+		if err != nil {
+			return nil, err
+		}
+		it := reader.Iterator()
+		for it.HasNext() {
+			metricIDs.Add(it.Key())
+		}
+	}
+	return metricIDs, nil
+}
+
+// GetSeriesIDsWithData returns the series ids of metric which still have data(not deleted).
+func (f *dataFamily) GetSeriesIDsWithData(metricID uint32) (*roaring.Bitmap, error) {
+	snapShot := f.family.GetSnapshot()
+	defer snapShot.Close()
+
+	metricReaders, tombstones, err := f.readMetric(snapShot, metricID)
+	if err != nil {
+		return nil, err
+	}
+	seriesIDs := roaring.New()
+	if len(metricReaders) == 0 {
+		return seriesIDs, nil
+	}
+	var fields field.Metas
+	for _, reader := range metricReaders {
+		seriesIDs.Or(reader.GetSeriesIDs())
+		for _, fm := range reader.GetFields() {
+			if _, ok := fields.GetFromID(fm.ID); !ok {
+				fields = fields.Insert(fm)
+			}
+		}
+	}
+	filter := newFilterFunc(f.timeRange.Start, snapShot, metricReaders, tombstones)
+	rs, err := filter.Filter(seriesIDs, fields)
+	if err != nil && !errors.Is(err, constants.ErrNotFound) {
+		return nil, err
+	}
+	seriesIDs = roaring.New()
+	for _, r := range rs {
+		seriesIDs.Or(r.SeriesIDs())
+	}
+	return seriesIDs, nil
+}
+
 // Filter filters the data based on metric/version/seriesIDs,
 // if finds data then returns the FilterResultSet, else returns nil
 func (f *dataFamily) Filter(metricID uint32,
@@ -97,27 +212,58 @@ func (f *dataFamily) Filter(metricID uint32,
 			snapShot.Close()
 		}
 	}()
-	readers, err := snapShot.FindReaders(metricID)
+	metricReaders, tombstones, err := f.readMetric(snapShot, metricID)
 	if err != nil {
-		engineLogger.Error("filter data family error", logger.Error(err))
+		return nil, err
+	}
+	if len(metricReaders) == 0 {
 		return
 	}
-	var metricReaders []metricsdata.MetricReader
-	for _, reader := range readers {
+	filter := newFilterFunc(f.timeRange.Start, snapShot, metricReaders, tombstones)
+	return filter.Filter(seriesIDs, fields)
+}
+
+// readMetric returns the metric readers and the tombstones which apply to each reader under snapshot.
+func (f *dataFamily) readMetric(snapShot version.Snapshot, metricID uint32) (
+	metricReaders []metricsdata.MetricReader, tombstones []metricsdata.Tombstones, err error,
+) {
+	var (
+		allTombstones metricsdata.Tombstones
+		fileNumbers   []table.FileNumber
+	)
+	for _, fileMeta := range snapShot.GetCurrent().FindFiles(metricID) {
+		reader, err := snapShot.GetReader(fileMeta.GetFileNumber())
+		if err != nil {
+			engineLogger.Error("filter data family error", logger.Error(err))
+			return nil, nil, err
+		}
+		if reader == nil {
+			continue
+		}
 		value, err := reader.Get(metricID)
 		// metric data not found
 		if err != nil {
 			continue
 		}
+		if metricsdata.IsTombstones(value) {
+			if allTombstones, err = metricsdata.DecodeTombstones(allTombstones, value); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
 		r, err := newReaderFunc(reader.Path(), value)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		metricReaders = append(metricReaders, r)
+		fileNumbers = append(fileNumbers, fileMeta.GetFileNumber())
 	}
-	if len(metricReaders) == 0 {
-		return
+	if len(allTombstones) > 0 {
+		// tombstone only deletes the data of files which written before deleting
+		tombstones = make([]metricsdata.Tombstones, len(metricReaders))
+		for idx, fileNumber := range fileNumbers {
+			tombstones[idx] = allTombstones.ForFile(fileNumber)
+		}
 	}
-	filter := newFilterFunc(f.timeRange.Start, snapShot, metricReaders)
-	return filter.Filter(seriesIDs, fields)
+	return metricReaders, tombstones, nil
 }
@@ -23,12 +23,16 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/lindb/roaring"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/flow"
 	"github.com/lindb/lindb/kv"
 	"github.com/lindb/lindb/kv/table"
 	"github.com/lindb/lindb/kv/version"
 	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/series/field"
 	"github.com/lindb/lindb/tsdb/tblstore/metricsdata"
 )
 
@@ -64,15 +68,19 @@ func TestDataFamily_Filter(t *testing.T) {
 		End:   50,
 	}
 	dataFamily := newDataFamily(timeutil.Interval(timeutil.OneSecond*10), timeRange, family)
+	v := version.NewMockVersion(ctrl)
+	snapshot.EXPECT().GetCurrent().Return(v).AnyTimes()
+	files := []*version.FileMeta{version.NewFileMeta(1, 10, 20, 100)}
 
-	// test find kv readers err
-	snapshot.EXPECT().FindReaders(gomock.Any()).Return(nil, fmt.Errorf("err"))
+	// test get kv reader err
+	v.EXPECT().FindFiles(gomock.Any()).Return(files)
+	snapshot.EXPECT().GetReader(table.FileNumber(1)).Return(nil, fmt.Errorf("err"))
 	rs, err := dataFamily.Filter(uint32(10), nil, timeutil.TimeRange{}, nil)
 	assert.Error(t, err)
 	assert.Nil(t, rs)
 
-	// case 1: find kv readers nil
-	snapshot.EXPECT().FindReaders(gomock.Any()).Return(nil, nil)
+	// case 1: find kv files nil
+	v.EXPECT().FindFiles(gomock.Any()).Return(nil)
 	rs, err = dataFamily.Filter(uint32(10), nil, timeutil.TimeRange{}, nil)
 	assert.NoError(t, err)
 	assert.Nil(t, rs)
@@ -80,7 +88,8 @@ func TestDataFamily_Filter(t *testing.T) {
 	// case 2: not find in reader
 	reader := table.NewMockReader(ctrl)
 	reader.EXPECT().Path().Return("test_path").AnyTimes()
-	snapshot.EXPECT().FindReaders(gomock.Any()).Return([]table.Reader{reader}, nil)
+	v.EXPECT().FindFiles(gomock.Any()).Return(files).AnyTimes()
+	snapshot.EXPECT().GetReader(table.FileNumber(1)).Return(reader, nil).AnyTimes()
 	reader.EXPECT().Get(gomock.Any()).Return(nil, io.EOF)
 	rs, err = dataFamily.Filter(uint32(10), nil, timeutil.TimeRange{}, nil)
 	assert.NoError(t, err)
@@ -90,7 +99,6 @@ func TestDataFamily_Filter(t *testing.T) {
 	newReaderFunc = func(file string, buf []byte) (reader metricsdata.MetricReader, err error) {
 		return nil, fmt.Errorf("err")
 	}
-	snapshot.EXPECT().FindReaders(gomock.Any()).Return([]table.Reader{reader}, nil)
 	reader.EXPECT().Get(gomock.Any()).Return([]byte{1, 2, 3}, nil)
 	rs, err = dataFamily.Filter(uint32(10), nil, timeutil.TimeRange{}, nil)
 	assert.Error(t, err)
@@ -101,12 +109,163 @@ func TestDataFamily_Filter(t *testing.T) {
 		return nil, nil
 	}
 	filter := metricsdata.NewMockFilter(ctrl)
-	newFilterFunc = func(familyTime int64, snapshot version.Snapshot, readers []metricsdata.MetricReader) metricsdata.Filter {
+	newFilterFunc = func(familyTime int64, snapshot version.Snapshot, readers []metricsdata.MetricReader,
+		_ []metricsdata.Tombstones) metricsdata.Filter {
 		return filter
 	}
-	snapshot.EXPECT().FindReaders(gomock.Any()).Return([]table.Reader{reader}, nil)
 	reader.EXPECT().Get(gomock.Any()).Return([]byte{1, 2, 3}, nil)
 	filter.EXPECT().Filter(gomock.Any(), gomock.Any()).Return(nil, nil)
 	_, err = dataFamily.Filter(uint32(10), nil, timeutil.TimeRange{}, nil)
 	assert.NoError(t, err)
 }
+
+func TestDataFamily_DeleteSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	family := kv.NewMockFamily(ctrl)
+	flusher := kv.NewMockFlusher(ctrl)
+	family.EXPECT().NewFlusher().Return(flusher).AnyTimes()
+	family.EXPECT().FenceCompaction().Return(func() {}).AnyTimes()
+	snapshot := version.NewMockSnapshot(ctrl)
+	snapshot.EXPECT().Close().AnyTimes()
+	family.EXPECT().GetSnapshot().Return(snapshot).AnyTimes()
+	v := version.NewMockVersion(ctrl)
+	snapshot.EXPECT().GetCurrent().Return(v).AnyTimes()
+	timeRange := timeutil.TimeRange{
+		Start: 0,
+		End:   timeutil.OneHour - 10*timeutil.OneSecond,
+	}
+	dataFamily := newDataFamily(timeutil.Interval(timeutil.OneSecond*10), timeRange, family)
+
+	// case 1: time range not match
+	err := dataFamily.DeleteSeries(10, roaring.BitmapOf(1, 2), timeutil.TimeRange{Start: timeutil.OneHour, End: timeutil.OneHour * 2})
+	assert.NoError(t, err)
+	// case 2: series ids empty
+	err = dataFamily.DeleteSeries(10, roaring.New(), timeutil.TimeRange{Start: 0, End: timeutil.OneHour})
+	assert.NoError(t, err)
+	// case 3: no file, no data need to delete
+	v.EXPECT().GetAllFiles().Return(nil)
+	err = dataFamily.DeleteSeries(10, roaring.BitmapOf(1, 2), timeutil.TimeRange{Start: 0, End: timeutil.OneHour})
+	assert.NoError(t, err)
+	v.EXPECT().GetAllFiles().Return([]*version.FileMeta{
+		version.NewFileMeta(3, 10, 20, 100),
+		version.NewFileMeta(5, 10, 20, 100),
+	}).AnyTimes()
+	// case 4: add err
+	flusher.EXPECT().Add(uint32(10), gomock.Any()).Return(fmt.Errorf("err"))
+	err = dataFamily.DeleteSeries(10, roaring.BitmapOf(1, 2), timeutil.TimeRange{Start: -100, End: timeutil.OneHour})
+	assert.Error(t, err)
+	// case 5: write tombstone with max file number, time range clipped by family
+	flusher.EXPECT().Add(uint32(10), gomock.Any()).DoAndReturn(func(_ uint32, block []byte) error {
+		tombstones, err := metricsdata.DecodeTombstones(nil, block)
+		assert.NoError(t, err)
+		assert.Len(t, tombstones, 1)
+		assert.Equal(t, timeutil.NewSlotRange(1, 359), tombstones[0].SlotRange)
+		assert.Equal(t, []uint32{1, 2}, tombstones[0].SeriesIDs.ToArray())
+		assert.Equal(t, table.FileNumber(5), tombstones[0].Version)
+		return nil
+	})
+	flusher.EXPECT().Commit().Return(nil)
+	err = dataFamily.DeleteSeries(10, roaring.BitmapOf(1, 2), timeutil.TimeRange{Start: 10 * timeutil.OneSecond, End: timeutil.OneHour})
+	assert.NoError(t, err)
+}
+
+func TestDataFamily_GetSeriesIDsWithData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		ctrl.Finish()
+		newReaderFunc = metricsdata.NewReader
+		newFilterFunc = metricsdata.NewFilter
+	}()
+
+	family := kv.NewMockFamily(ctrl)
+	snapshot := version.NewMockSnapshot(ctrl)
+	snapshot.EXPECT().Close().AnyTimes()
+	family.EXPECT().GetSnapshot().Return(snapshot).AnyTimes()
+	dataFamily := newDataFamily(timeutil.Interval(timeutil.OneSecond*10), timeutil.TimeRange{Start: 10, End: 50}, family)
+	v := version.NewMockVersion(ctrl)
+	snapshot.EXPECT().GetCurrent().Return(v).AnyTimes()
+	files := []*version.FileMeta{version.NewFileMeta(1, 10, 20, 100)}
+
+	// case 1: get reader err
+	v.EXPECT().FindFiles(gomock.Any()).Return(files)
+	snapshot.EXPECT().GetReader(table.FileNumber(1)).Return(nil, fmt.Errorf("err"))
+	seriesIDs, err := dataFamily.GetSeriesIDsWithData(10)
+	assert.Error(t, err)
+	assert.Nil(t, seriesIDs)
+	// case 2: no data
+	v.EXPECT().FindFiles(gomock.Any()).Return(nil)
+	seriesIDs, err = dataFamily.GetSeriesIDsWithData(10)
+	assert.NoError(t, err)
+	assert.True(t, seriesIDs.IsEmpty())
+
+	reader := table.NewMockReader(ctrl)
+	reader.EXPECT().Path().Return("test_path").AnyTimes()
+	reader.EXPECT().Get(gomock.Any()).Return([]byte{1, 2, 3}, nil).AnyTimes()
+	v.EXPECT().FindFiles(gomock.Any()).Return(files).AnyTimes()
+	snapshot.EXPECT().GetReader(table.FileNumber(1)).Return(reader, nil).AnyTimes()
+	metricReader := metricsdata.NewMockMetricReader(ctrl)
+	metricReader.EXPECT().GetSeriesIDs().Return(roaring.BitmapOf(1, 2, 3)).AnyTimes()
+	metricReader.EXPECT().GetFields().Return(field.Metas{{ID: 1}}).AnyTimes()
+	newReaderFunc = func(file string, buf []byte) (reader metricsdata.MetricReader, err error) {
+		return metricReader, nil
+	}
+	filter := metricsdata.NewMockFilter(ctrl)
+	newFilterFunc = func(familyTime int64, snapshot version.Snapshot, readers []metricsdata.MetricReader,
+		_ []metricsdata.Tombstones) metricsdata.Filter {
+		return filter
+	}
+	// case 3: filter err
+	filter.EXPECT().Filter(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("err"))
+	seriesIDs, err = dataFamily.GetSeriesIDsWithData(10)
+	assert.Error(t, err)
+	assert.Nil(t, seriesIDs)
+	// case 4: all series deleted
+	filter.EXPECT().Filter(gomock.Any(), gomock.Any()).Return(nil, constants.ErrNotFound)
+	seriesIDs, err = dataFamily.GetSeriesIDsWithData(10)
+	assert.NoError(t, err)
+	assert.True(t, seriesIDs.IsEmpty())
+	// case 5: return series ids with data
+	rs := flow.NewMockFilterResultSet(ctrl)
+	rs.EXPECT().SeriesIDs().Return(roaring.BitmapOf(1, 3))
+	filter.EXPECT().Filter(gomock.Any(), gomock.Any()).Return([]flow.FilterResultSet{rs}, nil)
+	seriesIDs, err = dataFamily.GetSeriesIDsWithData(10)
+	assert.NoError(t, err)
+	assert.Equal(t, roaring.BitmapOf(1, 3), seriesIDs)
+}
+
+func TestDataFamily_GetMetricIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	family := kv.NewMockFamily(ctrl)
+	snapshot := version.NewMockSnapshot(ctrl)
+	snapshot.EXPECT().Close().AnyTimes()
+	family.EXPECT().GetSnapshot().Return(snapshot).AnyTimes()
+	v := version.NewMockVersion(ctrl)
+	snapshot.EXPECT().GetCurrent().Return(v).AnyTimes()
+	v.EXPECT().GetAllFiles().Return([]*version.FileMeta{version.NewFileMeta(1, 10, 20, 100)}).AnyTimes()
+	dataFamily := newDataFamily(timeutil.Interval(timeutil.OneSecond*10), timeutil.TimeRange{Start: 10, End: 50}, family)
+
+	// case 1: get reader err
+	snapshot.EXPECT().GetReader(table.FileNumber(1)).Return(nil, fmt.Errorf("err"))
+	metricIDs, err := dataFamily.GetMetricIDs()
+	assert.Error(t, err)
+	assert.Nil(t, metricIDs)
+	// case 2: collect metric ids
+	reader := table.NewMockReader(ctrl)
+	snapshot.EXPECT().GetReader(table.FileNumber(1)).Return(reader, nil)
+	it := table.NewMockIterator(ctrl)
+	reader.EXPECT().Iterator().Return(it)
+	gomock.InOrder(
+		it.EXPECT().HasNext().Return(true),
+		it.EXPECT().Key().Return(uint32(10)),
+		it.EXPECT().HasNext().Return(true),
+		it.EXPECT().Key().Return(uint32(20)),
+		it.EXPECT().HasNext().Return(false),
+	)
+	metricIDs, err = dataFamily.GetMetricIDs()
+	assert.NoError(t, err)
+	assert.Equal(t, roaring.BitmapOf(10, 20), metricIDs)
+}
@@ -0,0 +1,281 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package tsdb
+
+import (
+	"errors"
+
+	"github.com/lindb/roaring"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/logger"
+	protoMetricsV1 "github.com/lindb/lindb/proto/gen/v1/metrics"
+	"github.com/lindb/lindb/series/metric"
+	"github.com/lindb/lindb/series/tag"
+)
+
+// RowSource iterates the rows for rebuilding index, e.g. the rows in replica write ahead log.
+type RowSource func(fn func(row *metric.StorageRow) error) error
+
+// IndexRebuildReport represents the result of rebuilding index of shard.
+type IndexRebuildReport struct {
+	Database          string         `json:"database"`
+	ShardID           models.ShardID `json:"shardId"`
+	RestoredSeries    int            `json:"restoredSeries"`    // series of which id mapping restored from forward index
+	ScannedRows       int            `json:"scannedRows"`       // rows scanned from row source
+	UnknownMetricRows int            `json:"unknownMetricRows"` // rows of which metric not exist in metadata
+	RebuiltSeries     int            `json:"rebuiltSeries"`     // series of which index rebuilt from rows
+	NewSeries         int            `json:"newSeries"`         // series without id mapping, assigned new series id
+	// metric id => series ids which have data, but tags cannot be resolved(orphaned data)
+	UnresolvedSeries map[uint32]*roaring.Bitmap `json:"-"`
+}
+
+// NumOfUnresolvedSeries returns the number of series which have data, but tags cannot be resolved.
+func (r *IndexRebuildReport) NumOfUnresolvedSeries() (count int) {
+	for _, seriesIDs := range r.UnresolvedSeries {
+		count += int(seriesIDs.GetCardinality())
+	}
+	return count
+}
+
+// RebuildIndex rebuilds the lost series id mapping and index of the series which have data:
+// 1) restores the lost series id mapping from forward index and tag metadata;
+// 2) rebuilds series id mapping and index from the rows of source if source not nil,
+//    the series without mapping are assigned new series ids which do not conflict with existing data;
+// 3) reports the series which have data, but cannot be resolved.
+// NOTICE: existing index data is kept, rebuilt index is appended into index database.
+func (s *shard) RebuildIndex(source RowSource) (*IndexRebuildReport, error) {
+	// flush memory databases, make sure all series with data are stored in data families
+	if err := s.flushAll(); err != nil {
+		return nil, err
+	}
+	seriesWithData, err := s.getSeriesIDsWithData()
+	if err != nil {
+		return nil, err
+	}
+	report := &IndexRebuildReport{
+		Database:         s.databaseName,
+		ShardID:          s.id,
+		UnresolvedSeries: make(map[uint32]*roaring.Bitmap),
+	}
+	for metricID, seriesIDs := range seriesWithData {
+		restored, err := s.restoreSeriesIDs(metricID, seriesIDs)
+		if err != nil {
+			return nil, err
+		}
+		report.RestoredSeries += restored
+	}
+	if source != nil {
+		rebuilt := make(map[uint32]*roaring.Bitmap)
+		if err := source(func(row *metric.StorageRow) error {
+			return s.rebuildSeriesIndex(row, rebuilt, report)
+		}); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.indexDB.Flush(); err != nil {
+		return nil, err
+	}
+	for metricID, seriesIDs := range seriesWithData {
+		unresolved, err := s.getUnresolvedSeriesIDs(metricID, seriesIDs)
+		if err != nil {
+			return nil, err
+		}
+		if !unresolved.IsEmpty() {
+			report.UnresolvedSeries[metricID] = unresolved
+		}
+	}
+	s.logger.Info("rebuild index of shard successfully",
+		logger.Any("shardID", s.id),
+		logger.String("database", s.databaseName),
+		logger.Int("restored", report.RestoredSeries),
+		logger.Int("rebuilt", report.RebuiltSeries),
+		logger.Int("unresolved", report.NumOfUnresolvedSeries()))
+	return report, nil
+}
+
+// getSeriesIDsWithData returns the series ids which have data in all retained data families, key: metric id.
+func (s *shard) getSeriesIDsWithData() (map[uint32]*roaring.Bitmap, error) {
+	result := make(map[uint32]*roaring.Bitmap)
+	for _, segment := range s.getSegments() {
+		for _, family := range segment.getAllDataFamilies() {
+			metricIDs, err := family.GetMetricIDs()
+			if err != nil {
+				return nil, err
+			}
+			it := metricIDs.Iterator()
+			for it.HasNext() {
+				metricID := it.Next()
+				seriesIDs, err := family.GetSeriesIDsWithData(metricID)
+				if err != nil {
+					return nil, err
+				}
+				if seriesIDs.IsEmpty() {
+					continue
+				}
+				if exist, ok := result[metricID]; ok {
+					exist.Or(seriesIDs)
+				} else {
+					result[metricID] = seriesIDs
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+// getMappedSeriesIDs returns the series ids of metric which have series id mapping.
+func (s *shard) getMappedSeriesIDs(metricID uint32) (*roaring.Bitmap, error) {
+	seriesIDs, err := s.indexDB.GetSeriesIDsByMetricID(metricID)
+	if err != nil {
+		if errors.Is(err, constants.ErrNotFound) {
+			return roaring.New(), nil
+		}
+		return nil, err
+	}
+	return seriesIDs, nil
+}
+
+// getTagKeys returns the tag keys of metric, returns empty if metric has no tags.
+func (s *shard) getTagKeys(metricID uint32) ([]tag.Meta, error) {
+	tagKeys, err := s.metadata.MetadataDatabase().GetAllTagKeysByMetricID(metricID)
+	if err != nil && !errors.Is(err, constants.ErrNotFound) {
+		return nil, err
+	}
+	return tagKeys, nil
+}
+
+// restoreSeriesIDs restores the series id mapping of series which have data, but mapping lost,
+// the tags of series are collected from forward index and tag metadata, returns the number of restored series.
+func (s *shard) restoreSeriesIDs(metricID uint32, seriesIDs *roaring.Bitmap) (int, error) {
+	mapped, err := s.getMappedSeriesIDs(metricID)
+	if err != nil {
+		return 0, err
+	}
+	lost := roaring.AndNot(seriesIDs, mapped)
+	lost.Remove(constants.SeriesIDWithoutTags)
+	if lost.IsEmpty() {
+		return 0, nil
+	}
+	tagKeys, err := s.getTagKeys(metricID)
+	if err != nil {
+		return 0, err
+	}
+	tagMetadata := s.metadata.TagMetadata()
+	seriesTags := make(map[uint32]tag.KeyValues)
+	for _, tagKey := range tagKeys {
+		tagValueIDs, err := s.indexDB.GetTagValueIDsForSeries(tagKey.ID, lost)
+		if err != nil {
+			return 0, err
+		}
+		if len(tagValueIDs) == 0 {
+			continue
+		}
+		ids := roaring.New()
+		for _, tagValueID := range tagValueIDs {
+			ids.Add(tagValueID)
+		}
+		tagValues := make(map[uint32]string)
+		if err := tagMetadata.CollectTagValues(tagKey.ID, ids, tagValues); err != nil {
+			return 0, err
+		}
+		for seriesID, tagValueID := range tagValueIDs {
+			// tag value lost in tag metadata
+			if tagValue, ok := tagValues[tagValueID]; ok {
+				seriesTags[seriesID] = append(seriesTags[seriesID], &protoMetricsV1.KeyValue{Key: tagKey.Key, Value: tagValue})
+			}
+		}
+	}
+	restored := make(map[uint64]uint32, len(seriesTags))
+	for seriesID, tags := range seriesTags {
+		restored[tag.XXHashOfKeyValues(tags)] = seriesID
+	}
+	// raise the series id sequence even if nothing restored, new series id cannot conflict with orphaned data
+	if err := s.indexDB.RestoreSeriesIDs(metricID, restored, lost.Maximum()); err != nil {
+		return 0, err
+	}
+	return len(restored), nil
+}
+
+// rebuildSeriesIndex rebuilds the series id mapping and index of series from row,
+// the metric of row must exist in metadata.
+func (s *shard) rebuildSeriesIndex(row *metric.StorageRow, rebuilt map[uint32]*roaring.Bitmap, report *IndexRebuildReport) error {
+	report.ScannedRows++
+	namespace := constants.DefaultNamespace
+	if len(row.NameSpace()) > 0 {
+		namespace = string(row.NameSpace())
+	}
+	metricName := string(row.Name())
+	metricID, err := s.metadata.MetadataDatabase().GetMetricID(namespace, metricName)
+	if err != nil {
+		if errors.Is(err, constants.ErrNotFound) {
+			report.UnknownMetricRows++
+			return nil
+		}
+		return err
+	}
+	if row.TagsLen() == 0 {
+		// metric without tags uses default series id
+		return nil
+	}
+	tagsHash := row.TagsHash()
+	seriesID, err := s.indexDB.GetSeriesID(metricID, tagsHash)
+	if err != nil {
+		if !errors.Is(err, constants.ErrNotFound) {
+			return err
+		}
+		if seriesID, _, err = s.indexDB.GetOrCreateSeriesID(metricID, tagsHash); err != nil {
+			return err
+		}
+		report.NewSeries++
+	}
+	seriesIDs, ok := rebuilt[metricID]
+	if !ok {
+		seriesIDs = roaring.New()
+		rebuilt[metricID] = seriesIDs
+	}
+	if !seriesIDs.CheckedAdd(seriesID) {
+		return nil
+	}
+	s.indexDB.BuildInvertIndex(namespace, metricName, row.NewKeyValueIterator(), seriesID)
+	report.RebuiltSeries++
+	return nil
+}
+
+// getUnresolvedSeriesIDs returns the series ids which have data, but without series id mapping or index.
+func (s *shard) getUnresolvedSeriesIDs(metricID uint32, seriesIDs *roaring.Bitmap) (*roaring.Bitmap, error) {
+	resolved, err := s.getMappedSeriesIDs(metricID)
+	if err != nil {
+		return nil, err
+	}
+	tagKeys, err := s.getTagKeys(metricID)
+	if err != nil {
+		return nil, err
+	}
+	indexed := roaring.New()
+	for _, tagKey := range tagKeys {
+		ids, err := s.indexDB.GetSeriesIDsForTag(tagKey.ID)
+		if err != nil {
+			return nil, err
+		}
+		indexed.Or(ids)
+	}
+	resolved.And(indexed)
+	resolved.Add(constants.SeriesIDWithoutTags)
+	return roaring.AndNot(seriesIDs, resolved), nil
+}
@@ -0,0 +1,204 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package tsdb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lindb/roaring"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/pkg/fileutil"
+	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/pkg/timeutil"
+	protoMetricsV1 "github.com/lindb/lindb/proto/gen/v1/metrics"
+	"github.com/lindb/lindb/series/metric"
+	"github.com/lindb/lindb/series/tag"
+	"github.com/lindb/lindb/tsdb/indexdb"
+	"github.com/lindb/lindb/tsdb/metadb"
+)
+
+func TestShard_RebuildIndex(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db := NewMockDatabase(ctrl)
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	tagMetadata := metadb.NewMockTagMetadata(ctrl)
+	metadata.EXPECT().DatabaseName().Return("test").AnyTimes()
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+	metadata.EXPECT().TagMetadata().Return(tagMetadata).AnyTimes()
+	db.EXPECT().Name().Return("test-db").AnyTimes()
+	db.EXPECT().Metadata().Return(metadata).AnyTimes()
+	shardINTF, err := newShard(db, 1, _testShard1Path, option.DatabaseOption{Interval: "10s", Behind: "1m", Ahead: "1m"})
+	assert.NoError(t, err)
+	shardIns := shardINTF.(*shard)
+	indexDB := indexdb.NewMockIndexDatabase(ctrl)
+	indexDB.EXPECT().Flush().Return(nil).AnyTimes()
+	indexDB.EXPECT().Close().Return(nil).AnyTimes()
+	shardIns.indexDB = indexDB
+	segment := NewMockIntervalSegment(ctrl)
+	segment.EXPECT().Close().AnyTimes()
+	family := NewMockDataFamily(ctrl)
+	segment.EXPECT().getAllDataFamilies().Return([]DataFamily{family}).AnyTimes()
+	shardIns.segments = map[timeutil.IntervalType]IntervalSegment{timeutil.Day: segment}
+
+	// case 1: get metric ids err
+	family.EXPECT().GetMetricIDs().Return(nil, fmt.Errorf("err"))
+	report, err := shardIns.RebuildIndex(nil)
+	assert.Error(t, err)
+	assert.Nil(t, report)
+	family.EXPECT().GetMetricIDs().Return(roaring.BitmapOf(10), nil).AnyTimes()
+	// case 2: get series ids with data err
+	family.EXPECT().GetSeriesIDsWithData(uint32(10)).Return(nil, fmt.Errorf("err"))
+	report, err = shardIns.RebuildIndex(nil)
+	assert.Error(t, err)
+	assert.Nil(t, report)
+	family.EXPECT().GetSeriesIDsWithData(uint32(10)).Return(roaring.BitmapOf(0, 1, 2, 3), nil).AnyTimes()
+	// case 3: get series ids err
+	indexDB.EXPECT().GetSeriesIDsByMetricID(uint32(10)).Return(nil, fmt.Errorf("err"))
+	report, err = shardIns.RebuildIndex(nil)
+	assert.Error(t, err)
+	assert.Nil(t, report)
+	// case 4: get tag keys err
+	indexDB.EXPECT().GetSeriesIDsByMetricID(uint32(10)).Return(nil, constants.ErrNotFound)
+	metadataDB.EXPECT().GetAllTagKeysByMetricID(uint32(10)).Return(nil, fmt.Errorf("err"))
+	report, err = shardIns.RebuildIndex(nil)
+	assert.Error(t, err)
+	assert.Nil(t, report)
+	metadataDB.EXPECT().GetAllTagKeysByMetricID(uint32(10)).Return([]tag.Meta{{Key: "host", ID: 5}}, nil).AnyTimes()
+	// case 5: get tag value ids err
+	indexDB.EXPECT().GetSeriesIDsByMetricID(uint32(10)).Return(roaring.BitmapOf(1), nil)
+	indexDB.EXPECT().GetTagValueIDsForSeries(uint32(5), roaring.BitmapOf(2, 3)).Return(nil, fmt.Errorf("err"))
+	report, err = shardIns.RebuildIndex(nil)
+	assert.Error(t, err)
+	assert.Nil(t, report)
+	// case 6: collect tag values err
+	indexDB.EXPECT().GetSeriesIDsByMetricID(uint32(10)).Return(roaring.BitmapOf(1), nil)
+	indexDB.EXPECT().GetTagValueIDsForSeries(uint32(5), gomock.Any()).Return(map[uint32]uint32{2: 20}, nil)
+	tagMetadata.EXPECT().CollectTagValues(uint32(5), roaring.BitmapOf(20), gomock.Any()).Return(fmt.Errorf("err"))
+	report, err = shardIns.RebuildIndex(nil)
+	assert.Error(t, err)
+	assert.Nil(t, report)
+	// case 7: restore series ids err
+	indexDB.EXPECT().GetSeriesIDsByMetricID(uint32(10)).Return(roaring.BitmapOf(1), nil)
+	indexDB.EXPECT().GetTagValueIDsForSeries(uint32(5), gomock.Any()).Return(map[uint32]uint32{2: 20}, nil)
+	tagMetadata.EXPECT().CollectTagValues(uint32(5), roaring.BitmapOf(20), gomock.Any()).
+		DoAndReturn(func(_ uint32, _ *roaring.Bitmap, tagValues map[uint32]string) error {
+			tagValues[20] = "1.1.1.1"
+			return nil
+		})
+	hash := tag.XXHashOfKeyValues(tag.KeyValues{{Key: "host", Value: "1.1.1.1"}})
+	indexDB.EXPECT().RestoreSeriesIDs(uint32(10), map[uint64]uint32{hash: 2}, uint32(3)).Return(fmt.Errorf("err"))
+	report, err = shardIns.RebuildIndex(nil)
+	assert.Error(t, err)
+	assert.Nil(t, report)
+	// case 8: restore series ids, series 3 unresolved
+	indexDB.EXPECT().GetSeriesIDsByMetricID(uint32(10)).Return(roaring.BitmapOf(1), nil)
+	indexDB.EXPECT().GetTagValueIDsForSeries(uint32(5), gomock.Any()).Return(map[uint32]uint32{2: 20}, nil)
+	tagMetadata.EXPECT().CollectTagValues(uint32(5), roaring.BitmapOf(20), gomock.Any()).
+		DoAndReturn(func(_ uint32, _ *roaring.Bitmap, tagValues map[uint32]string) error {
+			tagValues[20] = "1.1.1.1"
+			return nil
+		})
+	indexDB.EXPECT().RestoreSeriesIDs(uint32(10), map[uint64]uint32{hash: 2}, uint32(3)).Return(nil)
+	indexDB.EXPECT().GetSeriesIDsByMetricID(uint32(10)).Return(roaring.BitmapOf(1, 2), nil)
+	indexDB.EXPECT().GetSeriesIDsForTag(uint32(5)).Return(roaring.BitmapOf(1, 2), nil)
+	report, err = shardIns.RebuildIndex(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.RestoredSeries)
+	assert.Equal(t, 1, report.NumOfUnresolvedSeries())
+	assert.Equal(t, roaring.BitmapOf(3), report.UnresolvedSeries[10])
+	// case 9: get series ids for tag err
+	indexDB.EXPECT().GetSeriesIDsByMetricID(uint32(10)).Return(roaring.BitmapOf(1, 2, 3), nil).AnyTimes()
+	indexDB.EXPECT().GetSeriesIDsForTag(uint32(5)).Return(nil, fmt.Errorf("err"))
+	report, err = shardIns.RebuildIndex(nil)
+	assert.Error(t, err)
+	assert.Nil(t, report)
+	// case 10: row source err
+	report, err = shardIns.RebuildIndex(func(fn func(row *metric.StorageRow) error) error {
+		return fmt.Errorf("err")
+	})
+	assert.Error(t, err)
+	assert.Nil(t, report)
+	// case 11: rebuild index from rows
+	indexDB.EXPECT().GetSeriesIDsForTag(uint32(5)).Return(roaring.BitmapOf(1, 2, 3), nil).AnyTimes()
+	rows := []*metric.StorageRow{
+		mockBatchRows(&protoMetricsV1.Metric{Name: "unknown", Timestamp: 1,
+			SimpleFields: []*protoMetricsV1.SimpleField{{Name: "f1", Type: protoMetricsV1.SimpleFieldType_DELTA_SUM, Value: 1}}}),
+		mockBatchRows(&protoMetricsV1.Metric{Name: "test", Timestamp: 1,
+			SimpleFields: []*protoMetricsV1.SimpleField{{Name: "f1", Type: protoMetricsV1.SimpleFieldType_DELTA_SUM, Value: 1}}}),
+		mockBatchRows(&protoMetricsV1.Metric{Name: "test", Timestamp: 1,
+			Tags:         []*protoMetricsV1.KeyValue{{Key: "host", Value: "1.1.1.1"}},
+			SimpleFields: []*protoMetricsV1.SimpleField{{Name: "f1", Type: protoMetricsV1.SimpleFieldType_DELTA_SUM, Value: 1}}}),
+		mockBatchRows(&protoMetricsV1.Metric{Name: "test", Timestamp: 2,
+			Tags:         []*protoMetricsV1.KeyValue{{Key: "host", Value: "1.1.1.1"}},
+			SimpleFields: []*protoMetricsV1.SimpleField{{Name: "f1", Type: protoMetricsV1.SimpleFieldType_DELTA_SUM, Value: 1}}}),
+		mockBatchRows(&protoMetricsV1.Metric{Name: "test", Timestamp: 1,
+			Tags:         []*protoMetricsV1.KeyValue{{Key: "host", Value: "1.1.1.2"}},
+			SimpleFields: []*protoMetricsV1.SimpleField{{Name: "f1", Type: protoMetricsV1.SimpleFieldType_DELTA_SUM, Value: 1}}}),
+	}
+	source := func(fn func(row *metric.StorageRow) error) error {
+		for _, row := range rows {
+			if err := fn(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	metadataDB.EXPECT().GetMetricID(constants.DefaultNamespace, "unknown").Return(uint32(0), constants.ErrNotFound).AnyTimes()
+	metadataDB.EXPECT().GetMetricID(constants.DefaultNamespace, "test").Return(uint32(10), nil).AnyTimes()
+	indexDB.EXPECT().GetSeriesID(uint32(10), rows[2].TagsHash()).Return(uint32(2), nil).Times(2)
+	indexDB.EXPECT().GetSeriesID(uint32(10), rows[4].TagsHash()).Return(uint32(0), constants.ErrNotFound)
+	indexDB.EXPECT().GetOrCreateSeriesID(uint32(10), rows[4].TagsHash()).Return(uint32(4), true, nil)
+	indexDB.EXPECT().BuildInvertIndex(constants.DefaultNamespace, "test", gomock.Any(), uint32(2))
+	indexDB.EXPECT().BuildInvertIndex(constants.DefaultNamespace, "test", gomock.Any(), uint32(4))
+	report, err = shardIns.RebuildIndex(source)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, report.ScannedRows)
+	assert.Equal(t, 1, report.UnknownMetricRows)
+	assert.Equal(t, 2, report.RebuiltSeries)
+	assert.Equal(t, 1, report.NewSeries)
+	assert.Zero(t, report.NumOfUnresolvedSeries())
+	// case 12: get series id err
+	indexDB.EXPECT().GetSeriesID(uint32(10), gomock.Any()).Return(uint32(0), fmt.Errorf("err"))
+	report, err = shardIns.RebuildIndex(source)
+	assert.Error(t, err)
+	assert.Nil(t, report)
+	// case 13: create series id err
+	indexDB.EXPECT().GetSeriesID(uint32(10), gomock.Any()).Return(uint32(0), constants.ErrNotFound)
+	indexDB.EXPECT().GetOrCreateSeriesID(uint32(10), gomock.Any()).Return(uint32(0), false, fmt.Errorf("err"))
+	report, err = shardIns.RebuildIndex(source)
+	assert.Error(t, err)
+	assert.Nil(t, report)
+	// case 14: get metric id err
+	metadataDB.EXPECT().GetMetricID("ns", "test").Return(uint32(0), fmt.Errorf("err"))
+	report, err = shardIns.RebuildIndex(func(fn func(row *metric.StorageRow) error) error {
+		return fn(mockBatchRows(&protoMetricsV1.Metric{Namespace: "ns", Name: "test", Timestamp: 1,
+			SimpleFields: []*protoMetricsV1.SimpleField{{Name: "f1", Type: protoMetricsV1.SimpleFieldType_DELTA_SUM, Value: 1}}}))
+	})
+	assert.Error(t, err)
+	assert.Nil(t, report)
+}
@@ -61,6 +61,17 @@ func (event *mappingEvent) addSeriesID(metricID uint32, tagsHash uint64, seriesI
 	event.pending++
 }
 
+// setSequence sets the series id sequence of metric directly,
+// used for saving series ids which are not generated in order
+func (event *mappingEvent) setSequence(metricID uint32, seq uint32) {
+	e, ok := event.events[metricID]
+	if !ok {
+		e = &metricEvent{}
+		event.events[metricID] = e
+	}
+	e.metricIDSeq = seq
+}
+
 // isFull returns if events is full
 func (event *mappingEvent) isFull() bool {
 	return event.pending > full
@@ -36,6 +36,11 @@ func TestMappingEvent(t *testing.T) {
 	assert.Equal(t, uint32(120), e.events[1].metricIDSeq)
 	assert.Equal(t, []seriesEvent{{seriesID: 100, tagsHash: 30}, {seriesID: 200, tagsHash: 40}}, e.events[2].events)
 	assert.Equal(t, uint32(200), e.events[2].metricIDSeq)
+	e.setSequence(2, 300)
+	assert.Equal(t, uint32(300), e.events[2].metricIDSeq)
+	e.setSequence(3, 10)
+	assert.Equal(t, uint32(10), e.events[3].metricIDSeq)
+	assert.Empty(t, e.events[3].events)
 	assert.False(t, e.isEmpty())
 	for i := 0; i < full; i++ {
 		e.addSeriesID(2, uint64(i), uint32(200+i))
@@ -24,6 +24,7 @@ import (
 	"path"
 	"time"
 
+	"github.com/lindb/roaring"
 	"go.etcd.io/bbolt"
 
 	"github.com/lindb/lindb/constants"
@@ -58,6 +59,15 @@ type IDMappingBackend interface {
 	getSeriesID(metricID uint32, tagsHash uint64) (seriesID uint32, err error)
 	// saveMapping saves the id mapping event
 	saveMapping(event *mappingEvent) (err error)
+	// deleteSeriesIDs deletes the tags hash => series id mappings of the deleted series ids under metric,
+	// returns the number of removed mappings
+	deleteSeriesIDs(metricID uint32, seriesIDs *roaring.Bitmap) (removed uint32, err error)
+	// getMetricIDs returns all metric ids which have series id mappings
+	getMetricIDs() (metricIDs []uint32, err error)
+	// getSeriesIDs returns all series ids under metric, if not exist return constants.ErrNotFount
+	getSeriesIDs(metricID uint32) (seriesIDs *roaring.Bitmap, err error)
+	// backup writes a consistent copy of bbolt.DB file into target path
+	backup(targetPath string) error
 }
 
 // idMappingBackend implements IDMappingBackend interface
@@ -98,7 +108,7 @@ func newIDMappingBackend(parent string) (IDMappingBackend, error) {
 
 // loadMetricIDMapping loads metric id mapping include id sequence
 func (imb *idMappingBackend) loadMetricIDMapping(metricID uint32) (idMapping MetricIDMapping, err error) {
-	var sequence uint32
+	var sequence, seriesCount uint32
 	var scratch [4]byte
 	binary.LittleEndian.PutUint32(scratch[:], metricID)
 	err = imb.db.View(func(tx *bbolt.Tx) error {
@@ -107,13 +117,14 @@ func (imb *idMappingBackend) loadMetricIDMapping(metricID uint32) (idMapping Met
 			return fmt.Errorf("%w, metricID: %d", constants.ErrMetricBucketNotFound, metricID)
 		}
 		sequence = uint32(metricBucket.Sequence())
+		seriesCount = uint32(metricBucket.Stats().KeyN)
 		return nil
 	})
 	if err != nil {
 		return nil, fmt.Errorf("%w, metricID: %d, loadMetricIDMapping with error: %s",
 			constants.ErrMetricBucketNotFound, metricID, err)
 	}
-	return newMetricIDMapping(metricID, sequence), nil
+	return newMetricIDMapping(metricID, sequence, seriesCount), nil
 }
 
 // getSeriesID gets series id by metric id/tags hash, if not exist return constants.ErrNotFount
@@ -175,6 +186,83 @@ func (imb *idMappingBackend) saveMapping(event *mappingEvent) (err error) {
 	return err
 }
 
+// deleteSeriesIDs deletes the tags hash => series id mappings of the deleted series ids under metric,
+// returns the number of removed mappings
+func (imb *idMappingBackend) deleteSeriesIDs(metricID uint32, seriesIDs *roaring.Bitmap) (removed uint32, err error) {
+	var scratch [4]byte
+	binary.LittleEndian.PutUint32(scratch[:], metricID)
+	err = imb.db.Update(func(tx *bbolt.Tx) error {
+		metricBucket := tx.Bucket(seriesBucketName).Bucket(scratch[:])
+		if metricBucket == nil {
+			return nil
+		}
+		var keys [][]byte
+		if err := metricBucket.ForEach(func(k, v []byte) error {
+			if len(v) == 4 && seriesIDs.Contains(binary.LittleEndian.Uint32(v)) {
+				keys = append(keys, k)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		for _, key := range keys {
+			if err := metricBucket.Delete(key); err != nil {
+				return err
+			}
+		}
+		removed = uint32(len(keys))
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return removed, nil
+}
+
+// getMetricIDs returns all metric ids which have series id mappings
+func (imb *idMappingBackend) getMetricIDs() (metricIDs []uint32, err error) {
+	err = imb.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(seriesBucketName).ForEach(func(k, v []byte) error {
+			// value of nested bucket is nil
+			if v == nil && len(k) == 4 {
+				metricIDs = append(metricIDs, binary.LittleEndian.Uint32(k))
+			}
+			return nil
+		})
+	})
+	return
+}
+
+// getSeriesIDs returns all series ids under metric, if not exist return constants.ErrNotFount
+func (imb *idMappingBackend) getSeriesIDs(metricID uint32) (seriesIDs *roaring.Bitmap, err error) {
+	var scratch [4]byte
+	binary.LittleEndian.PutUint32(scratch[:], metricID)
+	seriesIDs = roaring.New()
+	err = imb.db.View(func(tx *bbolt.Tx) error {
+		metricBucket := tx.Bucket(seriesBucketName).Bucket(scratch[:])
+		if metricBucket == nil {
+			return fmt.Errorf("%w, metricID: %d", constants.ErrMetricBucketNotFound, metricID)
+		}
+		return metricBucket.ForEach(func(k, v []byte) error {
+			if len(v) == 4 {
+				seriesIDs.Add(binary.LittleEndian.Uint32(v))
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return seriesIDs, nil
+}
+
+// backup writes a consistent copy of bbolt.DB file into target path
+func (imb *idMappingBackend) backup(targetPath string) error {
+	return imb.db.View(func(tx *bbolt.Tx) error {
+		return tx.CopyFile(path.Join(targetPath, MappingDB), 0600)
+	})
+}
+
 // Close closes the bbolt.DB
 func (imb *idMappingBackend) Close() error {
 	return imb.db.Close()
@@ -23,6 +23,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/lindb/roaring"
 	"github.com/stretchr/testify/assert"
 	"go.etcd.io/bbolt"
 
@@ -123,6 +124,49 @@ func TestIdMappingBackend_mapping(t *testing.T) {
 	assert.Equal(t, uint32(300), mapping1.idSequence.Load())
 }
 
+func TestIdMappingBackend_deleteSeriesIDs(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+	backend, err := newIDMappingBackend(filepath.Join(testPath, "test"))
+	assert.NoError(t, err)
+	event := newMappingEvent()
+	event.addSeriesID(2, 10, 100)
+	event.addSeriesID(2, 30, 300)
+	err = backend.saveMapping(event)
+	assert.NoError(t, err)
+
+	metricIDs, err := backend.getMetricIDs()
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{2}, metricIDs)
+	seriesIDs, err := backend.getSeriesIDs(2)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{100, 300}, seriesIDs.ToArray())
+	seriesIDs, err = backend.getSeriesIDs(3)
+	assert.True(t, errors.Is(err, constants.ErrNotFound))
+	assert.Nil(t, seriesIDs)
+
+	// metric id not exist
+	removed, err := backend.deleteSeriesIDs(3, roaring.BitmapOf(100))
+	assert.NoError(t, err)
+	assert.Zero(t, removed)
+	removed, err = backend.deleteSeriesIDs(2, roaring.BitmapOf(100))
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1), removed)
+	_, err = backend.getSeriesID(2, 10)
+	assert.True(t, errors.Is(err, constants.ErrNotFound))
+	seriesID, err := backend.getSeriesID(2, 30)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(300), seriesID)
+	// sequence not changed
+	mapping, err := backend.loadMetricIDMapping(2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(300), mapping.(*metricIDMapping).idSequence.Load())
+	assert.Equal(t, uint32(1), mapping.GetSeriesCount())
+	err = backend.Close()
+	assert.NoError(t, err)
+}
+
 func TestIdMappingBackend_save_err(t *testing.T) {
 	defer func() {
 		_ = fileutil.RemoveDir(testPath)
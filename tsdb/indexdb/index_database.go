@@ -27,6 +27,7 @@ import (
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/internal/linmetric"
 	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/pkg/fileutil"
 	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/series"
@@ -41,6 +42,7 @@ import (
 var (
 	createBackend   = newIDMappingBackend
 	createSeriesWAL = wal.NewSeriesWAL
+	copyDirFunc     = fileutil.CopyDir
 )
 
 var (
@@ -159,7 +161,7 @@ func (db *indexDatabase) GetOrCreateSeriesID(metricID uint32, tagsHash uint64,
 		// if metric id not exist in backend storage
 		if errors.Is(err, constants.ErrNotFound) {
 			// create new metric id mapping with 0 sequence
-			metricIDMapping = newMetricIDMapping(metricID, 0)
+			metricIDMapping = newMetricIDMapping(metricID, 0, 0)
 			// cache metric id mapping
 			db.metricID2Mapping[metricID] = metricIDMapping
 		} else {
@@ -232,6 +234,123 @@ func (db *indexDatabase) BuildInvertIndex(
 	buildInvertedIndexCounterVec.WithTagValues(db.metadata.DatabaseName()).Incr()
 }
 
+// DeleteSeries deletes the series ids under metric, removes them from id mapping and inverted index,
+// deleted series id will not be reused, new series with same tags will be assigned a new series id.
+func (db *indexDatabase) DeleteSeries(metricID uint32, tagKeyIDs []uint32, seriesIDs *roaring.Bitmap) error {
+	if seriesIDs == nil || seriesIDs.IsEmpty() {
+		return nil
+	}
+	db.rwMutex.Lock()
+	defer db.rwMutex.Unlock()
+
+	// make sure pending series in wal are saved into backend storage
+	if db.seriesWAL.NeedRecovery() {
+		db.seriesRecovery()
+	}
+	removed, err := db.backend.deleteSeriesIDs(metricID, seriesIDs)
+	if err != nil {
+		return err
+	}
+	if metricIDMapping, ok := db.metricID2Mapping[metricID]; ok {
+		metricIDMapping.RemoveSeriesIDs(seriesIDs, removed)
+	}
+	return db.index.deleteSeriesIDs(tagKeyIDs, seriesIDs)
+}
+
+// GetMetricIDs returns all metric ids which have assigned series ids
+func (db *indexDatabase) GetMetricIDs() ([]uint32, error) {
+	db.rwMutex.Lock()
+	defer db.rwMutex.Unlock()
+
+	// make sure pending series in wal are saved into backend storage
+	if db.seriesWAL.NeedRecovery() {
+		db.seriesRecovery()
+	}
+	return db.backend.getMetricIDs()
+}
+
+// GetSeriesIDsByMetricID returns all assigned series ids under metric,
+// if not exist return constants.ErrNotFound
+func (db *indexDatabase) GetSeriesIDsByMetricID(metricID uint32) (*roaring.Bitmap, error) {
+	db.rwMutex.Lock()
+	defer db.rwMutex.Unlock()
+
+	// make sure pending series in wal are saved into backend storage
+	if db.seriesWAL.NeedRecovery() {
+		db.seriesRecovery()
+	}
+	return db.backend.getSeriesIDs(metricID)
+}
+
+// GetSeriesID gets series id by tags hash without generating new series id,
+// if not exist return constants.ErrNotFound
+func (db *indexDatabase) GetSeriesID(metricID uint32, tagsHash uint64) (seriesID uint32, err error) {
+	db.rwMutex.Lock()
+	defer db.rwMutex.Unlock()
+
+	if metricIDMapping, ok := db.metricID2Mapping[metricID]; ok {
+		if seriesID, ok = metricIDMapping.GetSeriesID(tagsHash); ok {
+			return seriesID, nil
+		}
+	}
+	// make sure pending series in wal are saved into backend storage
+	if db.seriesWAL.NeedRecovery() {
+		db.seriesRecovery()
+	}
+	return db.backend.getSeriesID(metricID, tagsHash)
+}
+
+// GetTagValueIDsForSeries returns the tag value id of each series under tag key from forward index,
+// key: series id, value: tag value id
+func (db *indexDatabase) GetTagValueIDsForSeries(tagKeyID uint32, seriesIDs *roaring.Bitmap) (map[uint32]uint32, error) {
+	return db.index.GetTagValueIDsForSeries(tagKeyID, seriesIDs)
+}
+
+// RestoreSeriesIDs restores the lost tags hash => series id mappings of metric, and raises the series id
+// sequence of metric to max series id at least, so that new series id will not conflict with existing data.
+func (db *indexDatabase) RestoreSeriesIDs(metricID uint32, seriesIDs map[uint64]uint32, maxSeriesID uint32) error {
+	db.rwMutex.Lock()
+	defer db.rwMutex.Unlock()
+
+	// make sure pending series in wal are saved into backend storage
+	if db.seriesWAL.NeedRecovery() {
+		db.seriesRecovery()
+	}
+	metricIDMapping, ok := db.metricID2Mapping[metricID]
+	if ok && metricIDMapping.GetSeriesIDSequence() > maxSeriesID {
+		// the sequence in memory includes the series ids generated but not saved into backend
+		maxSeriesID = metricIDMapping.GetSeriesIDSequence()
+	}
+	event := newMappingEvent()
+	restored := make(map[uint64]uint32)
+	for tagsHash, seriesID := range seriesIDs {
+		if seriesID > maxSeriesID {
+			maxSeriesID = seriesID
+		}
+		// skip the mapping which is not lost
+		if ok {
+			if _, exist := metricIDMapping.GetSeriesID(tagsHash); exist {
+				continue
+			}
+		}
+		if _, err := db.backend.getSeriesID(metricID, tagsHash); err == nil {
+			continue
+		}
+		event.addSeriesID(metricID, tagsHash, seriesID)
+		restored[tagsHash] = seriesID
+	}
+	// series ids are not restored in order, set sequence with max series id,
+	// backend keeps the greater one of stored sequence and max series id, so sequence never moves backwards
+	event.setSequence(metricID, maxSeriesID)
+	if err := db.backend.saveMapping(event); err != nil {
+		return err
+	}
+	if ok {
+		metricIDMapping.RestoreSeriesIDs(restored, maxSeriesID)
+	}
+	return nil
+}
+
 // Flush flushes index data to disk
 func (db *indexDatabase) Flush() error {
 	if err := db.seriesWAL.Sync(); err != nil {
@@ -242,6 +361,25 @@ func (db *indexDatabase) Flush() error {
 	return db.index.Flush()
 }
 
+// Backup writes a consistent copy of series id mapping(bbolt.DB file and series wal) into target path,
+// the pending series of series wal will be recovered when opening the copy.
+func (db *indexDatabase) Backup(targetPath string) error {
+	// block generating new series id and recovering series wal
+	db.rwMutex.Lock()
+	defer db.rwMutex.Unlock()
+
+	if err := mkDir(targetPath); err != nil {
+		return err
+	}
+	if err := db.seriesWAL.Sync(); err != nil {
+		return err
+	}
+	if err := db.backend.backup(targetPath); err != nil {
+		return err
+	}
+	return copyDirFunc(filepath.Join(db.path, walPath), filepath.Join(targetPath, walPath))
+}
+
 // Close closes the database, releases the resources
 func (db *indexDatabase) Close() error {
 	db.cancel()
@@ -264,9 +402,11 @@ func (db *indexDatabase) checkSync() {
 	for {
 		select {
 		case <-ticker.C:
+			db.rwMutex.Lock()
 			if db.seriesWAL.NeedRecovery() {
 				db.seriesRecovery()
 			}
+			db.rwMutex.Unlock()
 		case <-db.ctx.Done():
 			ticker.Stop()
 			indexLogger.Info("received ctx.Done(), stopped checkSync", logger.String("db", db.path))
@@ -20,7 +20,9 @@ package indexdb
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -29,6 +31,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/atomic"
 
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/pkg/fileutil"
 	"github.com/lindb/lindb/pkg/timeutil"
 	protoMetricsV1 "github.com/lindb/lindb/proto/gen/v1/metrics"
@@ -284,6 +287,146 @@ func TestIndexDatabase_GetOrCreateSeriesID(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestIndexDatabase_DeleteSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+
+		ctrl.Finish()
+	}()
+
+	meta := metadb.NewMockMetadata(ctrl)
+	meta.EXPECT().DatabaseName().Return("test").AnyTimes()
+	db, err := NewIndexDatabase(context.TODO(), testPath, meta, nil, nil)
+	assert.NoError(t, err)
+	index := NewMockInvertedIndex(ctrl)
+	db1 := db.(*indexDatabase)
+	db1.index = index
+	index.EXPECT().Flush().Return(nil).AnyTimes()
+	seriesID, _, err := db.GetOrCreateSeriesID(1, 10)
+	assert.NoError(t, err)
+	// case 1: nothing to delete
+	assert.NoError(t, db.DeleteSeries(1, []uint32{1}, roaring.New()))
+	// case 2: delete series
+	index.EXPECT().deleteSeriesIDs([]uint32{1}, roaring.BitmapOf(seriesID)).Return(nil)
+	assert.NoError(t, db.DeleteSeries(1, []uint32{1}, roaring.BitmapOf(seriesID)))
+	// new series id for same tags
+	seriesID, isCreated, err := db.GetOrCreateSeriesID(1, 10)
+	assert.NoError(t, err)
+	assert.True(t, isCreated)
+	assert.Equal(t, uint32(2), seriesID)
+	// case 3: delete series from backend err
+	backend := NewMockIDMappingBackend(ctrl)
+	oldBackend := db1.backend
+	db1.backend = backend
+	backend.EXPECT().deleteSeriesIDs(uint32(1), gomock.Any()).Return(uint32(0), fmt.Errorf("err"))
+	assert.Error(t, db.DeleteSeries(1, []uint32{1}, roaring.BitmapOf(seriesID)))
+	// case 4: get series ids of metric from backend
+	backend.EXPECT().getMetricIDs().Return([]uint32{1}, nil)
+	metricIDs, err := db.GetMetricIDs()
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{1}, metricIDs)
+	backend.EXPECT().getSeriesIDs(uint32(1)).Return(roaring.BitmapOf(seriesID), nil)
+	seriesIDs, err := db.GetSeriesIDsByMetricID(1)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{seriesID}, seriesIDs.ToArray())
+	db1.backend = oldBackend
+
+	// close db
+	err = db.Close()
+	assert.NoError(t, err)
+}
+
+func TestIndexDatabase_RestoreSeriesIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+
+		ctrl.Finish()
+	}()
+
+	meta := metadb.NewMockMetadata(ctrl)
+	meta.EXPECT().DatabaseName().Return("test").AnyTimes()
+	db, err := NewIndexDatabase(context.TODO(), testPath, meta, nil, nil)
+	assert.NoError(t, err)
+	index := NewMockInvertedIndex(ctrl)
+	db1 := db.(*indexDatabase)
+	db1.index = index
+	index.EXPECT().Flush().Return(nil).AnyTimes()
+	seriesID, _, err := db.GetOrCreateSeriesID(1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1), seriesID)
+	// case 1: get series id from cache
+	seriesID, err = db.GetSeriesID(1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1), seriesID)
+	// case 2: series id not exist
+	_, err = db.GetSeriesID(1, 20)
+	assert.True(t, errors.Is(err, constants.ErrNotFound))
+	_, err = db.GetSeriesID(2, 20)
+	assert.True(t, errors.Is(err, constants.ErrNotFound))
+	// case 3: restore series ids, raise sequence, keep existing mapping
+	err = db.RestoreSeriesIDs(1, map[uint64]uint32{10: 7, 20: 5, 30: 3}, 8)
+	assert.NoError(t, err)
+	for tagsHash, expect := range map[uint64]uint32{10: 1, 20: 5, 30: 3} {
+		seriesID, err = db.GetSeriesID(1, tagsHash)
+		assert.NoError(t, err)
+		assert.Equal(t, expect, seriesID)
+	}
+	seriesID, isCreated, err := db.GetOrCreateSeriesID(1, 40)
+	assert.NoError(t, err)
+	assert.True(t, isCreated)
+	assert.Equal(t, uint32(9), seriesID)
+	// case 4: restored series id greater than max series id
+	err = db.RestoreSeriesIDs(2, map[uint64]uint32{20: 5}, 0)
+	assert.NoError(t, err)
+	seriesID, _, err = db.GetOrCreateSeriesID(2, 40)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(6), seriesID)
+	// case 5: save mapping err
+	backend := NewMockIDMappingBackend(ctrl)
+	oldBackend := db1.backend
+	db1.backend = backend
+	backend.EXPECT().getSeriesID(uint32(1), uint64(50)).Return(uint32(0), constants.ErrSeriesIDNotFound)
+	backend.EXPECT().saveMapping(gomock.Any()).Return(fmt.Errorf("err"))
+	assert.Error(t, db.RestoreSeriesIDs(1, map[uint64]uint32{50: 2}, 2))
+	db1.backend = oldBackend
+	// case 6: lost series id below live sequence, sequence not moved backwards
+	for tagsHash := uint64(1); tagsHash <= 5; tagsHash++ {
+		_, _, err = db.GetOrCreateSeriesID(3, tagsHash)
+		assert.NoError(t, err)
+	}
+	err = db.RestoreSeriesIDs(3, map[uint64]uint32{100: 2}, 2)
+	assert.NoError(t, err)
+	mapping, err := db1.backend.loadMetricIDMapping(3)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(5), mapping.GetSeriesIDSequence())
+	seriesID, _, err = db.GetOrCreateSeriesID(3, 6)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(6), seriesID)
+	// case 7: get tag value ids of series
+	index.EXPECT().GetTagValueIDsForSeries(uint32(1), roaring.BitmapOf(1)).Return(map[uint32]uint32{1: 2}, nil)
+	tagValueIDs, err := db.GetTagValueIDsForSeries(1, roaring.BitmapOf(1))
+	assert.NoError(t, err)
+	assert.Equal(t, map[uint32]uint32{1: 2}, tagValueIDs)
+
+	// close db
+	err = db.Close()
+	assert.NoError(t, err)
+	// case 8: reload, new series id after max sequence
+	db, err = NewIndexDatabase(context.TODO(), testPath, meta, nil, nil)
+	assert.NoError(t, err)
+	seriesID, err = db.GetSeriesID(3, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(2), seriesID)
+	seriesID, isCreated, err = db.GetOrCreateSeriesID(3, 7)
+	assert.NoError(t, err)
+	assert.True(t, isCreated)
+	assert.Equal(t, uint32(7), seriesID)
+	err = db.Close()
+	assert.NoError(t, err)
+}
+
 func TestIndexDatabase_GetOrCreateSeriesID_err(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer func() {
@@ -312,7 +455,7 @@ func TestIndexDatabase_GetOrCreateSeriesID_err(t *testing.T) {
 	assert.Equal(t, uint32(0), seriesID)
 
 	// case 2: load series err
-	backend.EXPECT().loadMetricIDMapping(uint32(1)).Return(newMetricIDMapping(1, 0), nil)
+	backend.EXPECT().loadMetricIDMapping(uint32(1)).Return(newMetricIDMapping(1, 0, 0), nil)
 	backend.EXPECT().getSeriesID(uint32(1), uint64(30)).Return(uint32(0), fmt.Errorf("err"))
 	seriesID, isCreated, err = db.GetOrCreateSeriesID(1, 30)
 	assert.Error(t, err)
@@ -426,6 +569,67 @@ func TestIndexDatabase_Close(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestIndexDatabase_Backup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		mkDir = fileutil.MkDirIfNotExist
+		copyDirFunc = fileutil.CopyDir
+		ctrl.Finish()
+	}()
+	backupPath := filepath.Join(testPath, "backup")
+	meta := metadb.NewMockMetadata(ctrl)
+	meta.EXPECT().DatabaseName().Return("test").AnyTimes()
+	db, err := NewIndexDatabase(context.TODO(), filepath.Join(testPath, "db"), meta, nil, nil)
+	assert.NoError(t, err)
+	seriesID, _, err := db.GetOrCreateSeriesID(1, 10)
+	assert.NoError(t, err)
+	assert.NoError(t, db.Backup(backupPath))
+	// series created after backup not included
+	_, _, err = db.GetOrCreateSeriesID(1, 20)
+	assert.NoError(t, err)
+	assert.NoError(t, db.Close())
+
+	// open the copy, pending series of wal is recovered
+	backup, err := NewIndexDatabase(context.TODO(), backupPath, meta, nil, nil)
+	assert.NoError(t, err)
+	id, isCreated, err := backup.GetOrCreateSeriesID(1, 10)
+	assert.NoError(t, err)
+	assert.False(t, isCreated)
+	assert.Equal(t, seriesID, id)
+	_, isCreated, err = backup.GetOrCreateSeriesID(1, 20)
+	assert.NoError(t, err)
+	assert.True(t, isCreated)
+
+	// case: mkdir err
+	mkDir = func(path string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, backup.Backup(filepath.Join(testPath, "backup1")))
+	mkDir = fileutil.MkDirIfNotExist
+	// case: copy wal err
+	copyDirFunc = func(src, dst string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, backup.Backup(filepath.Join(testPath, "backup1")))
+	// case: backup bbolt.DB err
+	db1 := backup.(*indexDatabase)
+	backend := NewMockIDMappingBackend(ctrl)
+	oldBackend := db1.backend
+	db1.backend = backend
+	backend.EXPECT().backup(gomock.Any()).Return(fmt.Errorf("err"))
+	assert.Error(t, backup.Backup(filepath.Join(testPath, "backup1")))
+	// case: sync wal err
+	mockSeriesWAL := wal.NewMockSeriesWAL(ctrl)
+	oldWAL := db1.seriesWAL
+	db1.seriesWAL = mockSeriesWAL
+	mockSeriesWAL.EXPECT().Sync().Return(fmt.Errorf("err"))
+	assert.Error(t, backup.Backup(filepath.Join(testPath, "backup1")))
+	db1.seriesWAL = oldWAL
+	db1.backend = oldBackend
+	assert.NoError(t, backup.Close())
+}
+
 func TestIndexDatabase_Flush(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer func() {
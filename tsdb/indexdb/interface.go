@@ -20,6 +20,8 @@ package indexdb
 import (
 	"io"
 
+	"github.com/lindb/roaring"
+
 	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/series"
 	"github.com/lindb/lindb/series/metric"
@@ -51,6 +53,26 @@ type IndexDatabase interface {
 	// BuildInvertIndex builds the inverted index for tag value => series ids,
 	// the tags is considered as a empty key-value pair while tags is nil.
 	BuildInvertIndex(namespace, metricName string, tagIterator *metric.KeyValueIterator, seriesID uint32)
+	// DeleteSeries deletes the series ids under metric, removes them from id mapping and inverted index,
+	// deleted series id will not be reused, new series with same tags will be assigned a new series id.
+	DeleteSeries(metricID uint32, tagKeyIDs []uint32, seriesIDs *roaring.Bitmap) error
+	// GetMetricIDs returns all metric ids which have assigned series ids
+	GetMetricIDs() ([]uint32, error)
+	// GetSeriesIDsByMetricID returns all assigned series ids under metric,
+	// if not exist return constants.ErrNotFound
+	GetSeriesIDsByMetricID(metricID uint32) (*roaring.Bitmap, error)
+	// GetSeriesID gets series id by tags hash without generating new series id,
+	// if not exist return constants.ErrNotFound
+	GetSeriesID(metricID uint32, tagsHash uint64) (seriesID uint32, err error)
+	// GetTagValueIDsForSeries returns the tag value id of each series under tag key from forward index,
+	// key: series id, value: tag value id
+	GetTagValueIDsForSeries(tagKeyID uint32, seriesIDs *roaring.Bitmap) (map[uint32]uint32, error)
+	// RestoreSeriesIDs restores the lost tags hash => series id mappings of metric, and raises the series id
+	// sequence of metric to max series id at least, so that new series id will not conflict with existing data.
+	RestoreSeriesIDs(metricID uint32, seriesIDs map[uint64]uint32, maxSeriesID uint32) error
 	// Flush flushes index data to disk
 	Flush() error
+	// Backup writes a consistent copy of series id mapping(bbolt.DB file and series wal) into target path,
+	// inverted index is stored in kv store, which need be backup by kv store.
+	Backup(targetPath string) error
 }
@@ -18,6 +18,7 @@
 package indexdb
 
 import (
+	"sort"
 	"sync"
 
 	"github.com/lindb/lindb/internal/linmetric"
@@ -58,9 +59,14 @@ type InvertedIndex interface {
 	GetSeriesIDsForTags(tagKeyIDs []uint32) (*roaring.Bitmap, error)
 	// GetGroupingContext returns the context of group by
 	GetGroupingContext(tagKeyIDs []uint32, seriesIDs *roaring.Bitmap) (series.GroupingContext, error)
+	// GetTagValueIDsForSeries returns the tag value id of each series under tag key from forward index,
+	// key: series id, value: tag value id
+	GetTagValueIDsForSeries(tagKeyID uint32, seriesIDs *roaring.Bitmap) (map[uint32]uint32, error)
 	// buildInvertIndex builds the inverted index for tag value => series ids,
 	// the tags is considered as a empty key-value pair while tags is nil.
 	buildInvertIndex(namespace, metricName string, tagIterator *metric.KeyValueIterator, seriesID uint32)
+	// deleteSeriesIDs removes the deleted series ids from the inverted index of tag keys
+	deleteSeriesIDs(tagKeyIDs []uint32, seriesIDs *roaring.Bitmap) error
 	// Flush flushes the inverted-index of tag value id=>series ids under tag key
 	Flush() error
 }
@@ -188,6 +194,39 @@ func (index *invertedIndex) GetGroupingContext(
 	return query.NewGroupContext(tagKeyIDs, scannerMap), nil
 }
 
+// GetTagValueIDsForSeries returns the tag value id of each series under tag key from forward index,
+// key: series id, value: tag value id
+func (index *invertedIndex) GetTagValueIDsForSeries(tagKeyID uint32, seriesIDs *roaring.Bitmap) (map[uint32]uint32, error) {
+	// get kv store snapshot
+	snapshot := index.forwardFamily.GetSnapshot()
+	defer snapshot.Close()
+
+	scanners, err := index.getGroupingScanners(tagKeyID, seriesIDs, snapshot)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[uint32]uint32)
+	for _, highKey := range seriesIDs.GetHighKeys() {
+		baseSeriesID := uint32(highKey) << 16
+		for _, scanner := range scanners {
+			container, tagValueIDs := scanner.GetSeriesAndTagValue(highKey)
+			if container == nil {
+				continue
+			}
+			it := container.PeekableIterator()
+			idx := 0
+			for it.HasNext() && idx < len(tagValueIDs) {
+				seriesID := baseSeriesID | uint32(it.Next())
+				if seriesIDs.Contains(seriesID) {
+					result[seriesID] = tagValueIDs[idx]
+				}
+				idx++
+			}
+		}
+	}
+	return result, nil
+}
+
 // getGroupingScanners returns the grouping scanner list for tag key, need match series ids
 func (index *invertedIndex) getGroupingScanners(
 	tagKeyID uint32,
@@ -262,6 +301,46 @@ func (index *invertedIndex) buildInvertIndex(namespace, metricName string, tagIt
 	}
 }
 
+// deleteSeriesIDs removes the deleted series ids from the inverted index of tag keys,
+// removes them from memory directly, writes series tombstone into kv store which will be purged when compaction.
+func (index *invertedIndex) deleteSeriesIDs(tagKeyIDs []uint32, seriesIDs *roaring.Bitmap) error {
+	if len(tagKeyIDs) == 0 || seriesIDs.IsEmpty() {
+		return nil
+	}
+	index.rwMutex.Lock()
+	for _, tagKeyID := range tagKeyIDs {
+		if tagIndex, ok := index.mutable.Get(tagKeyID); ok {
+			tagIndex.deleteSeriesIDs(seriesIDs)
+		}
+		if index.immutable != nil {
+			if tagIndex, ok := index.immutable.Get(tagKeyID); ok {
+				tagIndex.deleteSeriesIDs(seriesIDs)
+			}
+		}
+	}
+	index.rwMutex.Unlock()
+
+	tombstone, err := tagindex.EncodeSeriesTombstone(seriesIDs)
+	if err != nil {
+		return err
+	}
+	// kv flusher need add key in order
+	keys := append([]uint32{}, tagKeyIDs...)
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	for _, family := range []kv.Family{index.forwardFamily, index.invertedFamily} {
+		flusher := family.NewFlusher()
+		for _, tagKeyID := range keys {
+			if err := flusher.Add(tagKeyID, tombstone); err != nil {
+				return err
+			}
+		}
+		if err := flusher.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Flush flushes the inverted-index of tag value id=>series ids under tag key
 func (index *invertedIndex) Flush() error {
 	if !index.checkFlush() {
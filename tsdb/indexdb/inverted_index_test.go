@@ -217,6 +217,38 @@ func TestInvertedIndex_GetGroupingContext(t *testing.T) {
 	assert.NotNil(t, ctx)
 }
 
+func TestInvertedIndex_GetTagValueIDsForSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	index := prepareInvertedIndex(ctrl)
+	idx := index.(*invertedIndex)
+	family := kv.NewMockFamily(ctrl)
+	snapshot := version.NewMockSnapshot(ctrl)
+	snapshot.EXPECT().Close().AnyTimes()
+	family.EXPECT().GetSnapshot().Return(snapshot).AnyTimes()
+	idx.forwardFamily = family
+
+	// case 1: get sst file reader err
+	snapshot.EXPECT().FindReaders(gomock.Any()).Return(nil, fmt.Errorf("err"))
+	tagValueIDs, err := index.GetTagValueIDsForSeries(2, roaring.BitmapOf(1, 2, 3))
+	assert.Error(t, err)
+	assert.Nil(t, tagValueIDs)
+	// case 2: get tag value ids from memory
+	snapshot.EXPECT().FindReaders(gomock.Any()).Return(nil, nil).AnyTimes()
+	tagValueIDs, err = index.GetTagValueIDsForSeries(2, roaring.BitmapOf(1, 2, 3))
+	assert.NoError(t, err)
+	assert.Equal(t, map[uint32]uint32{1: 1, 2: 2}, tagValueIDs)
+	// case 3: filter by series ids
+	tagValueIDs, err = index.GetTagValueIDsForSeries(1, roaring.BitmapOf(2, 3, 65536))
+	assert.NoError(t, err)
+	assert.Equal(t, map[uint32]uint32{2: 1}, tagValueIDs)
+	// case 4: tag key not exist
+	tagValueIDs, err = index.GetTagValueIDsForSeries(10, roaring.BitmapOf(1, 2))
+	assert.NoError(t, err)
+	assert.Empty(t, tagValueIDs)
+}
+
 func TestInvertedIndex_FlushInvertedIndexTo(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer func() {
@@ -290,6 +322,45 @@ func TestInvertedIndex_FlushInvertedIndexTo(t *testing.T) {
 	assert.Nil(t, idx.immutable)
 }
 
+func TestInvertedIndex_deleteSeriesIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	index := prepareInvertedIndex(ctrl)
+	idx := index.(*invertedIndex)
+	forwardFamily := kv.NewMockFamily(ctrl)
+	invertedFamily := kv.NewMockFamily(ctrl)
+	idx.forwardFamily = forwardFamily
+	idx.invertedFamily = invertedFamily
+	// case 1: nothing to delete
+	assert.NoError(t, index.deleteSeriesIDs(nil, roaring.BitmapOf(1)))
+	// case 2: add tombstone err
+	forwardFlusher := kv.NewMockFlusher(ctrl)
+	invertedFlusher := kv.NewMockFlusher(ctrl)
+	forwardFamily.EXPECT().NewFlusher().Return(forwardFlusher).AnyTimes()
+	invertedFamily.EXPECT().NewFlusher().Return(invertedFlusher).AnyTimes()
+	forwardFlusher.EXPECT().Add(uint32(1), gomock.Any()).Return(fmt.Errorf("err"))
+	assert.Error(t, index.deleteSeriesIDs([]uint32{2, 1}, roaring.BitmapOf(1)))
+	// case 3: commit err
+	forwardFlusher.EXPECT().Add(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	forwardFlusher.EXPECT().Commit().Return(fmt.Errorf("err"))
+	assert.Error(t, index.deleteSeriesIDs([]uint32{2, 1}, roaring.BitmapOf(1)))
+	// case 4: delete success, write tombstone by tag key id in order
+	gomock.InOrder(
+		forwardFlusher.EXPECT().Add(uint32(1), gomock.Any()).Return(nil),
+		forwardFlusher.EXPECT().Add(uint32(2), gomock.Any()).Return(nil),
+		forwardFlusher.EXPECT().Commit().Return(nil),
+		invertedFlusher.EXPECT().Add(uint32(1), gomock.Any()).Return(nil),
+		invertedFlusher.EXPECT().Add(uint32(2), gomock.Any()).Return(nil),
+		invertedFlusher.EXPECT().Commit().Return(nil),
+	)
+	assert.NoError(t, index.deleteSeriesIDs([]uint32{2, 1}, roaring.BitmapOf(1)))
+	tagIndex, _ := idx.mutable.Get(1)
+	assert.Equal(t, []uint32{2}, tagIndex.getAllSeriesIDs().ToArray())
+	tagIndex, _ = idx.mutable.Get(2)
+	assert.Equal(t, []uint32{2}, tagIndex.getAllSeriesIDs().ToArray())
+}
+
 func prepareInvertedIndex(ctrl *gomock.Controller) InvertedIndex {
 	metadata := metadb.NewMockMetadata(ctrl)
 	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
@@ -18,6 +18,7 @@
 package indexdb
 
 import (
+	"github.com/lindb/roaring"
 	"go.uber.org/atomic"
 
 	"github.com/lindb/lindb/constants"
@@ -34,8 +35,18 @@ type MetricIDMapping interface {
 	GenSeriesID(tagsHash uint64) (seriesID uint32)
 	// RemoveSeriesID removes series id by tags hash
 	RemoveSeriesID(tagsHash uint64)
+	// RemoveSeriesIDs removes the deleted series ids, then reclaims the limit budget of removed series,
+	// the series ids will not be recycled
+	RemoveSeriesIDs(seriesIDs *roaring.Bitmap, removed uint32)
+	// GetSeriesCount returns the number of live series which counts against the max series ids limit
+	GetSeriesCount() uint32
+	// GetSeriesIDSequence returns the series id sequence, includes the series ids not saved into backend
+	GetSeriesIDSequence() uint32
 	// AddSeriesID adds the series id init cache
 	AddSeriesID(tagsHash uint64, seriesID uint32)
+	// RestoreSeriesIDs adds the restored series ids into cache which count against the max series ids limit,
+	// then raises the id sequence to max series id at least
+	RestoreSeriesIDs(seriesIDs map[uint64]uint32, maxSeriesID uint32)
 	// SetMaxSeriesIDsLimit sets the max series ids limit
 	SetMaxSeriesIDsLimit(limit uint32)
 	// GetMaxSeriesIDsLimit returns the max series ids limit
@@ -50,15 +61,18 @@ type metricIDMapping struct {
 	hash2SeriesID     map[uint64]uint32
 	idSequence        atomic.Uint32
 	maxSeriesIDsLimit atomic.Uint32 // maximum number of combinations of series ids
+
+	seriesCount atomic.Uint32 // number of live series, excludes the removed series
 }
 
 // newMetricIDMapping returns a new metric id mapping
-func newMetricIDMapping(metricID, sequence uint32) MetricIDMapping {
+func newMetricIDMapping(metricID, sequence, seriesCount uint32) MetricIDMapping {
 	return &metricIDMapping{
 		metricID:          metricID,
 		hash2SeriesID:     make(map[uint64]uint32),
 		idSequence:        *atomic.NewUint32(sequence), // first value is 1
 		maxSeriesIDsLimit: *atomic.NewUint32(constants.DefaultMaxSeriesIDsCount),
+		seriesCount:       *atomic.NewUint32(seriesCount),
 	}
 }
 
@@ -73,19 +87,37 @@ func (mim *metricIDMapping) GetSeriesID(tagsHash uint64) (seriesID uint32, ok bo
 	return
 }
 
+// GetSeriesIDSequence returns the series id sequence, includes the series ids not saved into backend
+func (mim *metricIDMapping) GetSeriesIDSequence() uint32 {
+	return mim.idSequence.Load()
+}
+
 // AddSeriesID adds the series id init cache
 func (mim *metricIDMapping) AddSeriesID(tagsHash uint64, seriesID uint32) {
 	mim.hash2SeriesID[tagsHash] = seriesID
 }
 
+// RestoreSeriesIDs adds the restored series ids into cache which count against the max series ids limit,
+// then raises the id sequence to max series id at least
+func (mim *metricIDMapping) RestoreSeriesIDs(seriesIDs map[uint64]uint32, maxSeriesID uint32) {
+	for tagsHash, seriesID := range seriesIDs {
+		mim.hash2SeriesID[tagsHash] = seriesID
+		mim.seriesCount.Inc()
+	}
+	if mim.idSequence.Load() < maxSeriesID {
+		mim.idSequence.Store(maxSeriesID)
+	}
+}
+
 // GenSeriesID generates series id by tags hash, then cache new series id
 func (mim *metricIDMapping) GenSeriesID(tagsHash uint64) (seriesID uint32) {
 	// generate new series id
-	if mim.maxSeriesIDsLimit.Load() == mim.idSequence.Load() {
-		//FIXME too many series id, use max limit????
-		seriesID = mim.maxSeriesIDsLimit.Load()
+	if mim.seriesCount.Load() >= mim.maxSeriesIDsLimit.Load() {
+		//FIXME too many series id, use last series id????
+		seriesID = mim.idSequence.Load()
 	} else {
 		seriesID = mim.idSequence.Inc()
+		mim.seriesCount.Inc()
 	}
 	// cache it
 	mim.hash2SeriesID[tagsHash] = seriesID
@@ -98,11 +130,32 @@ func (mim *metricIDMapping) RemoveSeriesID(tagsHash uint64) {
 	if ok {
 		if seriesID == mim.idSequence.Load() {
 			mim.idSequence.Dec() // recycle series id
+			mim.seriesCount.Dec()
 		}
 		delete(mim.hash2SeriesID, tagsHash)
 	}
 }
 
+// RemoveSeriesIDs removes the deleted series ids, then reclaims the limit budget of removed series,
+// the series ids will not be recycled
+func (mim *metricIDMapping) RemoveSeriesIDs(seriesIDs *roaring.Bitmap, removed uint32) {
+	for tagsHash, seriesID := range mim.hash2SeriesID {
+		if seriesIDs.Contains(seriesID) {
+			delete(mim.hash2SeriesID, tagsHash)
+		}
+	}
+	if count := mim.seriesCount.Load(); count > removed {
+		mim.seriesCount.Store(count - removed)
+	} else {
+		mim.seriesCount.Store(0)
+	}
+}
+
+// GetSeriesCount returns the number of live series which counts against the max series ids limit
+func (mim *metricIDMapping) GetSeriesCount() uint32 {
+	return mim.seriesCount.Load()
+}
+
 // SetMaxSeriesIDsLimit sets the max series ids limit
 func (mim *metricIDMapping) SetMaxSeriesIDsLimit(limit uint32) {
 	mim.maxSeriesIDsLimit.Store(limit)
@@ -20,18 +20,19 @@ package indexdb
 import (
 	"testing"
 
+	"github.com/lindb/roaring"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/constants"
 )
 
 func TestMetricIDMapping_GetMetricID(t *testing.T) {
-	idMapping := newMetricIDMapping(10, 0)
+	idMapping := newMetricIDMapping(10, 0, 0)
 	assert.Equal(t, uint32(10), idMapping.GetMetricID())
 }
 
 func TestMetricIDMapping_GetOrCreateSeriesID(t *testing.T) {
-	idMapping := newMetricIDMapping(10, 0)
+	idMapping := newMetricIDMapping(10, 0, 0)
 	seriesID, ok := idMapping.GetSeriesID(100)
 	assert.False(t, ok)
 	assert.Equal(t, uint32(0), seriesID)
@@ -50,7 +51,7 @@ func TestMetricIDMapping_GetOrCreateSeriesID(t *testing.T) {
 }
 
 func TestMetricIDMapping_SetMaxTagsLimit(t *testing.T) {
-	idMapping := newMetricIDMapping(10, 0)
+	idMapping := newMetricIDMapping(10, 0, 0)
 	seriesID := idMapping.GenSeriesID(100)
 	assert.Equal(t, uint32(1), seriesID)
 	assert.Equal(t, uint32(constants.DefaultMaxSeriesIDsCount), idMapping.GetMaxSeriesIDsLimit())
@@ -61,7 +62,7 @@ func TestMetricIDMapping_SetMaxTagsLimit(t *testing.T) {
 }
 
 func TestMetricIDMapping_RemoveSeriesID(t *testing.T) {
-	idMapping := newMetricIDMapping(10, 0)
+	idMapping := newMetricIDMapping(10, 0, 0)
 	seriesID := idMapping.GenSeriesID(100)
 	assert.Equal(t, uint32(1), seriesID)
 	idMapping.RemoveSeriesID(100)
@@ -69,3 +70,40 @@ func TestMetricIDMapping_RemoveSeriesID(t *testing.T) {
 	assert.Equal(t, uint32(1), seriesID)
 	idMapping.RemoveSeriesID(1200)
 }
+
+func TestMetricIDMapping_RemoveSeriesIDs(t *testing.T) {
+	idMapping := newMetricIDMapping(10, 0, 0)
+	assert.Equal(t, uint32(1), idMapping.GenSeriesID(100))
+	assert.Equal(t, uint32(2), idMapping.GenSeriesID(200))
+	assert.Equal(t, uint32(2), idMapping.GetSeriesCount())
+	idMapping.RemoveSeriesIDs(roaring.BitmapOf(2), 1)
+	_, ok := idMapping.GetSeriesID(200)
+	assert.False(t, ok)
+	_, ok = idMapping.GetSeriesID(100)
+	assert.True(t, ok)
+	assert.Equal(t, uint32(1), idMapping.GetSeriesCount())
+	// deleted series id not recycle
+	assert.Equal(t, uint32(3), idMapping.GenSeriesID(200))
+	// reclaim limit budget
+	idMapping.SetMaxSeriesIDsLimit(2)
+	assert.Equal(t, uint32(3), idMapping.GenSeriesID(300))
+	idMapping.RemoveSeriesIDs(roaring.BitmapOf(1, 3), 2)
+	assert.Equal(t, uint32(4), idMapping.GenSeriesID(400))
+	idMapping.RemoveSeriesIDs(roaring.BitmapOf(4), 10)
+	assert.Equal(t, uint32(0), idMapping.GetSeriesCount())
+}
+
+func TestMetricIDMapping_RestoreSeriesIDs(t *testing.T) {
+	idMapping := newMetricIDMapping(10, 0, 0)
+	assert.Equal(t, uint32(1), idMapping.GenSeriesID(100))
+	idMapping.RestoreSeriesIDs(map[uint64]uint32{200: 5, 300: 3}, 10)
+	seriesID, ok := idMapping.GetSeriesID(200)
+	assert.True(t, ok)
+	assert.Equal(t, uint32(5), seriesID)
+	assert.Equal(t, uint32(3), idMapping.GetSeriesCount())
+	assert.Equal(t, uint32(11), idMapping.GenSeriesID(400))
+	// sequence not decreased
+	idMapping.RestoreSeriesIDs(nil, 2)
+	assert.Equal(t, uint32(11), idMapping.GetSeriesIDSequence())
+	assert.Equal(t, uint32(12), idMapping.GenSeriesID(500))
+}
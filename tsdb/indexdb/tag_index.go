@@ -38,6 +38,8 @@ type TagIndex interface {
 	getValues() *InvertedStore
 	// getAllSeriesIDs returns all series ids
 	getAllSeriesIDs() *roaring.Bitmap
+	// deleteSeriesIDs removes the deleted series ids from tag index
+	deleteSeriesIDs(seriesIDs *roaring.Bitmap)
 	// flush flushes tag index under spec tag key,
 	// write series ids of tag key level with constants.TagValueIDForTag
 	flush(tagKeyID uint32, forward tagindex.ForwardFlusher, inverted tagindex.InvertedFlusher) error
@@ -125,6 +127,26 @@ func (index *tagIndex) getAllSeriesIDs() *roaring.Bitmap {
 	return index.forward.keys.Clone()
 }
 
+// deleteSeriesIDs removes the deleted series ids from tag index
+func (index *tagIndex) deleteSeriesIDs(seriesIDs *roaring.Bitmap) {
+	if !index.forward.Keys().Intersects(seriesIDs) {
+		return
+	}
+	// rebuild forward index without deleted series ids
+	forward := NewForwardStore()
+	_ = index.forward.WalkEntry(func(seriesID uint32, tagValueID uint32) error {
+		if !seriesIDs.Contains(seriesID) {
+			forward.Put(seriesID, tagValueID)
+		}
+		return nil
+	})
+	index.forward = forward
+	_ = index.inverted.WalkEntry(func(_ uint32, value *roaring.Bitmap) error {
+		value.AndNot(seriesIDs)
+		return nil
+	})
+}
+
 // getValues returns the all tag values and series ids
 func (index *tagIndex) getValues() *InvertedStore {
 	return index.inverted
@@ -161,6 +161,19 @@ func TestTagIndex_GetData(t *testing.T) {
 	_, _ = r.GetSeriesAndTagValue(4)
 }
 
+func TestTagIndex_deleteSeriesIDs(t *testing.T) {
+	index := prepareTagIdx()
+	index.buildInvertedIndex(1, 10)
+	index.deleteSeriesIDs(roaring.BitmapOf(100))
+	assert.Equal(t, []uint32{1, 2, 3, 4, 5, 6, 7, 8, 10}, index.getAllSeriesIDs().ToArray())
+	index.deleteSeriesIDs(roaring.BitmapOf(1, 2))
+	assert.Equal(t, []uint32{3, 4, 5, 6, 7, 8, 10}, index.getAllSeriesIDs().ToArray())
+	assert.Equal(t, []uint32{10}, index.getSeriesIDsByTagValueIDs(roaring.BitmapOf(1, 2)).ToArray())
+	v, ok := index.(*tagIndex).forward.Get(10)
+	assert.True(t, ok)
+	assert.Equal(t, uint32(1), v)
+}
+
 func prepareTagIdx() TagIndex {
 	tagIndex := newTagIndex()
 	tagIndex.buildInvertedIndex(1, 1)
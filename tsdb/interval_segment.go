@@ -19,22 +19,82 @@ package tsdb
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/pkg/fileutil"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/option"
 	"github.com/lindb/lindb/pkg/timeutil"
 )
 
 //go:generate mockgen -source=./interval_segment.go -destination=./interval_segment_mock.go -package=tsdb
 
+// for testing
+var (
+	renameDir = os.Rename
+)
+
+// movingSegmentSuffix represents the suffix of segment dir which is copying into storage tier
+const movingSegmentSuffix = ".moving"
+
+// storageTier represents a cold storage tier of interval segment,
+// the segments whose data are all older than age are stored in path.
+type storageTier struct {
+	path string
+	age  int64
+}
+
+// newStorageTiers returns the cold storage tiers(sorted by age) of interval segment path under tsdb dir.
+func newStorageTiers(path string) []storageTier {
+	tsdbCfg := config.GlobalStorageConfig().TSDB
+	if len(tsdbCfg.Tiers) == 0 {
+		return nil
+	}
+	relPath, err := filepath.Rel(tsdbCfg.Dir, path)
+	if err != nil || strings.HasPrefix(relPath, "..") {
+		// path not under tsdb dir
+		return nil
+	}
+	tiers := make([]storageTier, len(tsdbCfg.Tiers))
+	for idx, tier := range tsdbCfg.Tiers {
+		tiers[idx] = storageTier{
+			path: filepath.Join(tier.Dir, relPath),
+			age:  tier.Age.Duration().Milliseconds(),
+		}
+	}
+	return tiers
+}
+
 // IntervalSegment represents a interval segment, there are some segments in a shard.
 type IntervalSegment interface {
 	// GetOrCreateSegment creates new segment if not exist, if exist return it
 	GetOrCreateSegment(segmentName string) (Segment, error)
 	// getDataFamilies returns data family list by time range, return nil if not match
 	getDataFamilies(timeRange timeutil.TimeRange) []DataFamily
+	// getAllDataFamilies returns all data families of the segments
+	getAllDataFamilies() []DataFamily
+	// getSegments returns the segments which overlap the time range
+	getSegments(timeRange timeutil.TimeRange) []Segment
+	// expireSegments detaches the segments whose data are all before expire time,
+	// then closes and removes the detached segments which are not used by any snapshot.
+	expireSegments(expireTime int64)
+	// moveSegments copies the segments whose data are all older than the age of storage tier into the tier,
+	// then switches to the copied segments, the segments not before the given time are not moved.
+	moveSegments(now, before int64)
 	// Close closes interval segment, release resource
 	Close()
+	// Backup creates a point-in-time copy of all segments into target path, each segment in sub dir
+	Backup(targetPath string) error
+	// getInterval returns the interval of interval segment
+	getInterval() timeutil.Interval
+	// registerRollup registers the rollup relation, rolls up the data of all segments into target interval segment,
+	// the segments created later are registered also, the target cannot be changed after registered.
+	registerRollup(target IntervalSegment)
 }
 
 // intervalSegment implements IntervalSegment interface
@@ -42,14 +102,28 @@ type intervalSegment struct {
 	path     string
 	interval timeutil.Interval
 	segments sync.Map
+	// compression returns the compression option of the families created by segments
+	compression func() option.CompressionOption
+	expired     map[string]Segment // detached segments, waiting for removing
+	tiers       []storageTier      // cold storage tiers, sorted by age
+	dirs        map[string]string  // segment name => dir of segment in path or storage tier
+	moved       map[string]Segment // dir of segment => segment moved into storage tier, waiting for removing
+
+	// segments before expired segment time cannot be created again(e.g. late write/rollup after expired)
+	expiredSegmentTime int64
+	// rollup target of segments, nil if no rollup
+	rollupTarget IntervalSegment
 
 	mutex sync.Mutex
+
+	logger *logger.Logger
 }
 
 // newIntervalSegment create interval segment based on interval/type/path etc.
 func newIntervalSegment(
 	interval timeutil.Interval,
 	path string,
+	compression func() option.CompressionOption,
 ) (
 	segment IntervalSegment,
 	err error,
@@ -58,8 +132,14 @@ func newIntervalSegment(
 		return segment, err
 	}
 	intervalSegment := &intervalSegment{
-		path:     path,
-		interval: interval,
+		path:        path,
+		interval:    interval,
+		compression: compression,
+		expired:     make(map[string]Segment),
+		tiers:       newStorageTiers(path),
+		dirs:        make(map[string]string),
+		moved:       make(map[string]Segment),
+		logger:      logger.GetLogger("tsdb", "IntervalSegment"),
 	}
 
 	defer func() {
@@ -71,17 +151,18 @@ func newIntervalSegment(
 
 	// load segments if exist
 	//TODO too many kv store load???
-	segmentNames, err := listDir(path)
+	segmentDirs, err := intervalSegment.listSegmentDirs()
 	if err != nil {
 		return segment, err
 	}
-	for _, segmentName := range segmentNames {
-		seg, err := newSegment(segmentName, intervalSegment.interval, filepath.Join(path, segmentName))
+	for segmentName, segmentDir := range segmentDirs {
+		seg, err := newSegment(segmentName, intervalSegment.interval, segmentDir, compression)
 		if err != nil {
 			err = fmt.Errorf("create segmenet error: %s", err)
 			return segment, err
 		}
 		intervalSegment.segments.Store(segmentName, seg)
+		intervalSegment.dirs[segmentName] = segmentDir
 	}
 
 	// set segment
@@ -98,11 +179,26 @@ func (s *intervalSegment) GetOrCreateSegment(segmentName string) (Segment, error
 		defer s.mutex.Unlock()
 		segment, ok = s.getSegment(segmentName)
 		if !ok {
-			seg, err := newSegment(segmentName, s.interval, filepath.Join(s.path, segmentName))
+			if _, expired := s.expired[segmentName]; expired {
+				return nil, fmt.Errorf("%w: segment[%s]", constants.ErrSegmentExpired, segmentName)
+			}
+			segmentTime, err := s.interval.Calculator().ParseSegmentTime(segmentName)
+			if err != nil {
+				return nil, fmt.Errorf("parse segment[%s] base time error: %s", segmentName, err)
+			}
+			if segmentTime < s.expiredSegmentTime {
+				return nil, fmt.Errorf("%w: segment[%s]", constants.ErrSegmentExpired, segmentName)
+			}
+			segmentDir := filepath.Join(s.path, segmentName)
+			seg, err := newSegment(segmentName, s.interval, segmentDir, s.compression)
 			if err != nil {
 				return nil, fmt.Errorf("create segmenet error: %s", err)
 			}
+			if s.rollupTarget != nil {
+				seg.registerRollup(s.rollupTarget)
+			}
 			s.segments.Store(segmentName, seg)
+			s.dirs[segmentName] = segmentDir
 			return seg, nil
 		}
 	}
@@ -134,6 +230,153 @@ func (s *intervalSegment) getDataFamilies(timeRange timeutil.TimeRange) []DataFa
 	return result
 }
 
+// getSegments returns the segments which overlap the time range
+func (s *intervalSegment) getSegments(timeRange timeutil.TimeRange) []Segment {
+	var result []Segment
+	segmentQueryTimeRange := &timeutil.TimeRange{
+		Start: s.interval.Calculator().CalcSegmentTime(timeRange.Start),
+		End:   timeRange.End,
+	}
+	s.segments.Range(func(k, v interface{}) bool {
+		if segment, ok := v.(Segment); ok && segmentQueryTimeRange.Contains(segment.BaseTime()) {
+			result = append(result, segment)
+		}
+		return true
+	})
+	return result
+}
+
+// getAllDataFamilies returns all data families of the segments
+func (s *intervalSegment) getAllDataFamilies() []DataFamily {
+	var result []DataFamily
+	s.segments.Range(func(k, v interface{}) bool {
+		if segment, ok := v.(Segment); ok {
+			result = append(result, segment.getAllDataFamilies()...)
+		}
+		return true
+	})
+	return result
+}
+
+// expireSegments detaches the segments whose data are all before expire time,
+// then closes and removes the detached segments which are not used by any snapshot.
+// Detached segments are removed in next round at least, because query may get data family before detaching.
+func (s *intervalSegment) expireSegments(expireTime int64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// 1. close and remove detached segments if not in use
+	for segmentName, seg := range s.expired {
+		if seg.inUse() {
+			continue
+		}
+		seg.Close()
+		delete(s.expired, segmentName)
+		segmentDir := s.getSegmentDir(segmentName)
+		delete(s.dirs, segmentName)
+		if err := removeDir(segmentDir); err != nil {
+			// segment will be expired again when reloading
+			s.logger.Error("remove expired segment error",
+				logger.String("path", s.path), logger.String("segment", segmentName), logger.Error(err))
+			continue
+		}
+		s.logger.Info("remove expired segment successfully",
+			logger.String("path", s.path), logger.String("segment", segmentName))
+	}
+	// 2. detach expired segments, segment expired if the segment which expire time belongs to is after it
+	expireSegmentTime := s.interval.Calculator().CalcSegmentTime(expireTime)
+	if expireSegmentTime > s.expiredSegmentTime {
+		s.expiredSegmentTime = expireSegmentTime
+	}
+	s.segments.Range(func(k, v interface{}) bool {
+		seg, ok := v.(Segment)
+		if ok && seg.BaseTime() < expireSegmentTime {
+			segmentName := k.(string)
+			s.segments.Delete(segmentName)
+			s.expired[segmentName] = seg
+		}
+		return true
+	})
+}
+
+// moveSegments copies the segments whose data are all older than the age of storage tier into the tier,
+// then switches to the copied segments, the segments not before the given time(e.g. still written by
+// memory database) are not moved. Old segments are removed in next round at least when not used by any snapshot.
+func (s *intervalSegment) moveSegments(now, before int64) {
+	if len(s.tiers) == 0 {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// 1. close and remove the old segments which are moved into storage tier if not in use
+	for segmentDir, seg := range s.moved {
+		if seg.inUse() {
+			continue
+		}
+		seg.Close()
+		delete(s.moved, segmentDir)
+		if err := removeDir(segmentDir); err != nil {
+			// old segment will be removed when reloading, because segment exists in colder storage tier
+			s.logger.Error("remove moved segment error",
+				logger.String("segment", segmentDir), logger.Error(err))
+			continue
+		}
+		s.logger.Info("remove moved segment successfully", logger.String("segment", segmentDir))
+	}
+	// 2. move the segments into the coldest storage tier which segment passes the age of
+	calc := s.interval.Calculator()
+	s.segments.Range(func(k, v interface{}) bool {
+		seg, ok := v.(Segment)
+		if !ok {
+			return true
+		}
+		segmentName := k.(string)
+		targetTier := -1
+		for idx, tier := range s.tiers {
+			moveTime := now - tier.age
+			if moveTime > before {
+				moveTime = before
+			}
+			if seg.BaseTime() < calc.CalcSegmentTime(moveTime) {
+				targetTier = idx
+			}
+		}
+		if targetTier > s.getSegmentTier(segmentName) {
+			s.moveSegment(segmentName, seg, s.tiers[targetTier])
+		}
+		return true
+	})
+}
+
+// getInterval returns the interval of interval segment
+func (s *intervalSegment) getInterval() timeutil.Interval {
+	return s.interval
+}
+
+// registerRollup registers the rollup relation, rolls up the data of all segments into target interval segment,
+// the segments created later are registered also, the target cannot be changed after registered.
+func (s *intervalSegment) registerRollup(target IntervalSegment) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.rollupTarget != nil {
+		if s.rollupTarget.getInterval() != target.getInterval() {
+			s.logger.Warn("rollup target of interval segment cannot be changed, ignore it",
+				logger.String("path", s.path),
+				logger.Any("target", s.rollupTarget.getInterval()), logger.Any("new", target.getInterval()))
+		}
+		return
+	}
+	s.rollupTarget = target
+	s.segments.Range(func(k, v interface{}) bool {
+		if seg, ok := v.(Segment); ok {
+			seg.registerRollup(target)
+		}
+		return true
+	})
+}
+
 // Close closes interval segment, release resource
 func (s *intervalSegment) Close() {
 	s.segments.Range(func(k, v interface{}) bool {
@@ -143,6 +386,35 @@ func (s *intervalSegment) Close() {
 		}
 		return true
 	})
+	s.mutex.Lock()
+	for _, seg := range s.expired {
+		seg.Close()
+	}
+	for _, seg := range s.moved {
+		seg.Close()
+	}
+	s.mutex.Unlock()
+}
+
+// Backup creates a point-in-time copy of all segments into target path, each segment in sub dir
+func (s *intervalSegment) Backup(targetPath string) (err error) {
+	// hold lock, avoid removing expired segment when copying
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.segments.Range(func(k, v interface{}) bool {
+		seg, ok := v.(Segment)
+		if !ok {
+			return true
+		}
+		segmentName := k.(string)
+		if err = seg.Backup(filepath.Join(targetPath, segmentName)); err != nil {
+			err = fmt.Errorf("backup segment[%s] error: %s", segmentName, err)
+			return false
+		}
+		return true
+	})
+	return err
 }
 
 // getSegment returns segment by name
@@ -151,3 +423,104 @@ func (s *intervalSegment) getSegment(segmentName string) (Segment, bool) {
 	seg, ok := segment.(Segment)
 	return seg, ok
 }
+
+// getSegmentDir returns the dir of segment in path or storage tier
+func (s *intervalSegment) getSegmentDir(segmentName string) string {
+	if segmentDir, ok := s.dirs[segmentName]; ok {
+		return segmentDir
+	}
+	return filepath.Join(s.path, segmentName)
+}
+
+// getSegmentTier returns the index of storage tier which segment stored in, returns -1 if in path.
+func (s *intervalSegment) getSegmentTier(segmentName string) int {
+	parentDir := filepath.Dir(s.getSegmentDir(segmentName))
+	for idx, tier := range s.tiers {
+		if tier.path == parentDir {
+			return idx
+		}
+	}
+	return -1
+}
+
+// moveSegment copies segment into storage tier, then replaces segment with the copied one,
+// keeps the old segment if moving fail. Compaction/rollup jobs of old segment are fenced before copying
+// and never released after moved, so that no data written into the old segment is lost, caller must fence flushing.
+func (s *intervalSegment) moveSegment(segmentName string, seg Segment, tier storageTier) {
+	release := seg.fenceCompaction()
+
+	targetDir := filepath.Join(tier.path, segmentName)
+	movingDir := targetDir + movingSegmentSuffix
+	err := func() error {
+		// remove incomplete segment of last moving
+		if err := removeDir(movingDir); err != nil {
+			return err
+		}
+		if err := seg.Backup(movingDir); err != nil {
+			return err
+		}
+		return renameDir(movingDir, targetDir)
+	}()
+	if err != nil {
+		release()
+		_ = removeDir(movingDir)
+		s.logger.Error("copy segment into storage tier error",
+			logger.String("path", s.path), logger.String("segment", segmentName),
+			logger.String("tier", tier.path), logger.Error(err))
+		return
+	}
+	newSeg, err := newSegment(segmentName, s.interval, targetDir, s.compression)
+	if err != nil {
+		release()
+		_ = removeDir(targetDir)
+		s.logger.Error("open segment in storage tier error",
+			logger.String("path", s.path), logger.String("segment", segmentName),
+			logger.String("tier", tier.path), logger.Error(err))
+		return
+	}
+	if s.rollupTarget != nil {
+		newSeg.registerRollup(s.rollupTarget)
+	}
+	s.moved[s.getSegmentDir(segmentName)] = seg
+	s.segments.Store(segmentName, newSeg)
+	s.dirs[segmentName] = targetDir
+	s.logger.Info("move segment into storage tier successfully",
+		logger.String("path", s.path), logger.String("segment", segmentName), logger.String("tier", tier.path))
+}
+
+// listSegmentDirs returns the dirs of segments in path and all storage tiers, key: segment name.
+// If segment exists in multi tiers(moving not finished), the copy in colder tier is complete,
+// so removes the copies in warmer tiers.
+func (s *intervalSegment) listSegmentDirs() (map[string]string, error) {
+	paths := []string{s.path}
+	for _, tier := range s.tiers {
+		paths = append(paths, tier.path)
+	}
+	segmentDirs := make(map[string]string)
+	for _, path := range paths {
+		if !fileutil.Exist(path) {
+			continue
+		}
+		segmentNames, err := listDir(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, segmentName := range segmentNames {
+			segmentDir := filepath.Join(path, segmentName)
+			if strings.HasSuffix(segmentName, movingSegmentSuffix) {
+				// remove incomplete segment of moving
+				if err := removeDir(segmentDir); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			if staleDir, ok := segmentDirs[segmentName]; ok {
+				if err := removeDir(staleDir); err != nil {
+					return nil, err
+				}
+			}
+			segmentDirs[segmentName] = segmentDir
+		}
+	}
+	return segmentDirs, nil
+}
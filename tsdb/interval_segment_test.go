@@ -18,13 +18,19 @@
 package tsdb
 
 import (
+	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/pkg/fileutil"
+	"github.com/lindb/lindb/pkg/ltoml"
 	"github.com/lindb/lindb/pkg/timeutil"
 )
 
@@ -38,7 +44,7 @@ func TestIntervalSegment_New(t *testing.T) {
 	mkDirIfNotExist = func(path string) error {
 		return fmt.Errorf("err")
 	}
-	s, err := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath)
+	s, err := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath, nil)
 	assert.Error(t, err)
 	assert.Nil(t, s)
 	mkDirIfNotExist = fileutil.MkDirIfNotExist
@@ -47,13 +53,13 @@ func TestIntervalSegment_New(t *testing.T) {
 	listDir = func(path string) (strings []string, err error) {
 		return nil, fmt.Errorf("err")
 	}
-	s, err = newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath)
+	s, err = newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath, nil)
 	assert.Error(t, err)
 	assert.Nil(t, s)
 	listDir = fileutil.ListDir
 
 	// case 3: create segment success
-	s, err = newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath)
+	s, err = newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, s)
 	assert.True(t, fileutil.Exist(segPath))
@@ -63,11 +69,12 @@ func TestIntervalSegment_New(t *testing.T) {
 	s1, err := newSegment(
 		"20190903",
 		timeutil.Interval(timeutil.OneSecond*10),
-		filepath.Join(segPath, "20190903"))
+		filepath.Join(segPath, "20190903"),
+		nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, s1)
 	// case 5: cannot re-open kv-store
-	s, err = newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath)
+	s, err = newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath, nil)
 	assert.Nil(t, s)
 	assert.Error(t, err)
 }
@@ -76,7 +83,7 @@ func TestIntervalSegment_GetOrCreateSegment(t *testing.T) {
 	defer func() {
 		_ = fileutil.RemoveDir(testPath)
 	}()
-	s, _ := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath)
+	s, _ := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath, nil)
 	seg, err := s.GetOrCreateSegment("20190702")
 	assert.Nil(t, err)
 	assert.NotNil(t, seg)
@@ -95,7 +102,7 @@ func TestIntervalSegment_GetOrCreateSegment(t *testing.T) {
 
 	s.Close()
 
-	s, _ = newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath)
+	s, _ = newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath, nil)
 
 	s1, ok := s.(*intervalSegment)
 	if ok {
@@ -112,7 +119,7 @@ func TestIntervalSegment_getDataFamilies(t *testing.T) {
 	defer func() {
 		_ = fileutil.RemoveDir(testPath)
 	}()
-	s, _ := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath)
+	s, _ := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath, nil)
 	segment1, _ := s.GetOrCreateSegment("20190902")
 	now, _ := timeutil.ParseTimestamp("20190902 19:10:48", "20060102 15:04:05")
 	_, _ = segment1.GetDataFamily(now)
@@ -155,4 +162,147 @@ func TestIntervalSegment_getDataFamilies(t *testing.T) {
 	end, _ = timeutil.ParseTimestamp("20190902 19:40:48", "20060102 15:04:05")
 	segments = s.getDataFamilies(timeutil.TimeRange{Start: start, End: end})
 	assert.Equal(t, 1, len(segments))
+
+	assert.Len(t, s.getAllDataFamilies(), 5)
+}
+
+func TestIntervalSegment_getSegments(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+	s, _ := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath, nil)
+	_, _ = s.GetOrCreateSegment("20190902")
+	_, _ = s.GetOrCreateSegment("20190904")
+	start, _ := timeutil.ParseTimestamp("20190902 19:10:48", "20060102 15:04:05")
+	end, _ := timeutil.ParseTimestamp("20190903 19:10:48", "20060102 15:04:05")
+	segments := s.getSegments(timeutil.TimeRange{Start: start, End: end})
+	assert.Len(t, segments, 1)
+	assert.Equal(t, "20190902", timeutil.FormatTimestamp(segments[0].BaseTime(), "20060102"))
+	end, _ = timeutil.ParseTimestamp("20190904 19:10:48", "20060102 15:04:05")
+	assert.Len(t, s.getSegments(timeutil.TimeRange{Start: start, End: end}), 2)
+	s.Close()
+}
+
+func TestIntervalSegment_expireSegments(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		removeDir = fileutil.RemoveDir
+	}()
+	s, _ := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath, nil)
+	segment1, _ := s.GetOrCreateSegment("20190902")
+	now, _ := timeutil.ParseTimestamp("20190902 19:10:48", "20060102 15:04:05")
+	family, _ := segment1.GetDataFamily(now)
+	segment2, _ := s.GetOrCreateSegment("20190904")
+	now, _ = timeutil.ParseTimestamp("20190904 20:10:48", "20060102 15:04:05")
+	_, _ = segment2.GetDataFamily(now)
+	timeRange := timeutil.TimeRange{Start: 0, End: now}
+	assert.Len(t, s.getDataFamilies(timeRange), 2)
+
+	// case 1: detach expired segment, but query holds snapshot
+	snapshot := family.Family().GetSnapshot()
+	expireTime, _ := timeutil.ParseTimestamp("20190903 10:10:48", "20060102 15:04:05")
+	s.expireSegments(expireTime)
+	assert.Len(t, s.getDataFamilies(timeRange), 1)
+	_, err := s.GetOrCreateSegment("20190902")
+	assert.Error(t, err)
+	s.expireSegments(expireTime)
+	assert.True(t, fileutil.Exist(filepath.Join(segPath, "20190902")))
+	// case 2: remove dir err
+	snapshot.Close()
+	removeDir = func(path string) error {
+		return fmt.Errorf("err")
+	}
+	s.expireSegments(expireTime)
+	assert.True(t, fileutil.Exist(filepath.Join(segPath, "20190902")))
+	removeDir = fileutil.RemoveDir
+	s.Close()
+
+	// case 3: reload expired segment, then remove it
+	s, _ = newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath, nil)
+	s.expireSegments(expireTime)
+	s.expireSegments(expireTime)
+	assert.False(t, fileutil.Exist(filepath.Join(segPath, "20190902")))
+	assert.True(t, fileutil.Exist(filepath.Join(segPath, "20190904")))
+	// case 4: late write cannot recreate expired segment
+	_, err = s.GetOrCreateSegment("20190902")
+	assert.True(t, errors.Is(err, constants.ErrSegmentExpired))
+	assert.False(t, fileutil.Exist(filepath.Join(segPath, "20190902")))
+	_, err = s.GetOrCreateSegment("bad")
+	assert.Error(t, err)
+	segment1, err = s.GetOrCreateSegment("20190905")
+	assert.NoError(t, err)
+	assert.NotNil(t, segment1)
+	s.Close()
+}
+
+func TestIntervalSegment_moveSegments(t *testing.T) {
+	cfg := config.GlobalStorageConfig()
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		config.SetGlobalStorageConfig(cfg)
+		renameDir = os.Rename
+	}()
+	tsdbDir := filepath.Join(testPath, "hot")
+	coldDir := filepath.Join(testPath, "cold")
+	tieredCfg := *cfg
+	tieredCfg.TSDB.Dir = tsdbDir
+	tieredCfg.TSDB.Tiers = []config.StorageTier{{Dir: coldDir, Age: ltoml.Duration(24 * time.Hour)}}
+	config.SetGlobalStorageConfig(&tieredCfg)
+	path := filepath.Join(tsdbDir, "db", shardDir, "1", segmentDir, timeutil.Day.String())
+	coldPath := filepath.Join(coldDir, "db", shardDir, "1", segmentDir, timeutil.Day.String())
+	// path not under tsdb dir
+	assert.Empty(t, newStorageTiers(segPath))
+	assert.Equal(t, []storageTier{{path: coldPath, age: timeutil.OneDay}}, newStorageTiers(path))
+
+	s, err := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), path, nil)
+	assert.NoError(t, err)
+	segment1, _ := s.GetOrCreateSegment("20190902")
+	familyTime, _ := timeutil.ParseTimestamp("20190902 19:10:48", "20060102 15:04:05")
+	family, _ := segment1.GetDataFamily(familyTime)
+	segment2, _ := s.GetOrCreateSegment("20190904")
+	now, _ := timeutil.ParseTimestamp("20190904 20:10:48", "20060102 15:04:05")
+	_, _ = segment2.GetDataFamily(now)
+	timeRange := timeutil.TimeRange{Start: 0, End: now}
+
+	// case 1: segment still written by memory database
+	s.moveSegments(now, familyTime)
+	assert.False(t, fileutil.Exist(filepath.Join(coldPath, "20190902")))
+	// case 2: copy segment err
+	renameDir = func(oldpath, newpath string) error {
+		return fmt.Errorf("err")
+	}
+	s.moveSegments(now, now)
+	assert.False(t, fileutil.Exist(filepath.Join(coldPath, "20190902")))
+	assert.False(t, fileutil.Exist(filepath.Join(coldPath, "20190902"+movingSegmentSuffix)))
+	renameDir = os.Rename
+	// case 3: move segment, but query holds snapshot of old segment
+	snapshot := family.Family().GetSnapshot()
+	s.moveSegments(now, now)
+	assert.True(t, fileutil.Exist(filepath.Join(coldPath, "20190902", fmt.Sprintf("%d", 19))))
+	assert.False(t, fileutil.Exist(filepath.Join(coldPath, "20190904")))
+	assert.Len(t, s.getDataFamilies(timeRange), 2)
+	s.moveSegments(now, now)
+	assert.True(t, fileutil.Exist(filepath.Join(path, "20190902")))
+	// case 4: remove old segment after snapshot closed
+	snapshot.Close()
+	s.moveSegments(now, now)
+	assert.False(t, fileutil.Exist(filepath.Join(path, "20190902")))
+	assert.True(t, fileutil.Exist(filepath.Join(path, "20190904")))
+	s.Close()
+
+	// case 5: reload segments, remove incomplete moving and stale copy
+	assert.NoError(t, fileutil.MkDirIfNotExist(filepath.Join(path, "20190902")))
+	assert.NoError(t, fileutil.MkDirIfNotExist(filepath.Join(coldPath, "20190904"+movingSegmentSuffix)))
+	s, err = newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), path, nil)
+	assert.NoError(t, err)
+	assert.False(t, fileutil.Exist(filepath.Join(path, "20190902")))
+	assert.False(t, fileutil.Exist(filepath.Join(coldPath, "20190904"+movingSegmentSuffix)))
+	assert.Len(t, s.getDataFamilies(timeRange), 2)
+	assert.Equal(t, 0, s.(*intervalSegment).getSegmentTier("20190902"))
+	assert.Equal(t, -1, s.(*intervalSegment).getSegmentTier("20190904"))
+	// case 6: expire segment in storage tier
+	s.expireSegments(now)
+	s.expireSegments(now)
+	assert.False(t, fileutil.Exist(filepath.Join(coldPath, "20190902")))
+	s.Close()
 }
@@ -0,0 +1,191 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package memdb
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"sync"
+
+	"github.com/lindb/lindb/pkg/fileutil"
+	"github.com/lindb/lindb/pkg/stream"
+	"github.com/lindb/lindb/series/field"
+)
+
+//go:generate mockgen -source ./cumulative_store.go -destination=./cumulative_store_mock.go -package memdb
+
+// for testing
+var (
+	writeCumulativeFileFunc  = ioutil.WriteFile
+	readCumulativeFileFunc   = ioutil.ReadFile
+	renameCumulativeFileFunc = os.Rename
+)
+
+// cumulativePointSize is the encoded size of series field state,
+// metric id(4)+series id(4)+field id(1)+value(8)+timestamp(8).
+const cumulativePointSize = 25
+
+// CumulativeStore keeps the last seen value of cumulative sum fields for each series,
+// converts cumulative value into delta value before writing into memory database.
+// NOTE: store is owned by shard, so the state can survive the rotation of family memory database,
+// the state is persisted into file when shard flushing, so that it can survive the restart of storage.
+type CumulativeStore interface {
+	// Delta returns the delta value between given value and last seen value of the series field,
+	// returns false if the delta cannot be calculated(first point or out of order point).
+	// if given value is less than the last seen value, it is considered as a counter reset,
+	// then given value is returned as the delta.
+	Delta(metricID, seriesID uint32, fieldID field.ID, timestamp int64, value float64) (delta float64, ok bool)
+	// Evict removes the state which is not updated since given timestamp, returns the number of evicted state.
+	Evict(before int64) int
+	// Size returns the number of series field state.
+	Size() int
+	// Persist writes all series field state into file.
+	Persist() error
+}
+
+// cumulativeKey represents the unique key of series field.
+type cumulativeKey struct {
+	metricID uint32
+	seriesID uint32
+	fieldID  field.ID
+}
+
+// cumulativePoint represents the last seen point of series field.
+type cumulativePoint struct {
+	value     float64
+	timestamp int64
+}
+
+// cumulativeStore implements CumulativeStore interface.
+type cumulativeStore struct {
+	fileName string
+	points   map[cumulativeKey]cumulativePoint
+	lock     sync.Mutex
+}
+
+// NewCumulativeStore creates a CumulativeStore instance, loads the state persisted in file if exist.
+func NewCumulativeStore(fileName string) (CumulativeStore, error) {
+	s := &cumulativeStore{
+		fileName: fileName,
+		points:   make(map[cumulativeKey]cumulativePoint),
+	}
+	if !fileutil.Exist(fileName) {
+		return s, nil
+	}
+	data, err := readCumulativeFileFunc(fileName)
+	if err != nil {
+		return nil, err
+	}
+	if len(data)%cumulativePointSize != 0 {
+		return nil, fmt.Errorf("cumulative state file[%s] is corrupted, size: %d", fileName, len(data))
+	}
+	reader := stream.NewReader(data)
+	for !reader.Empty() {
+		key := cumulativeKey{
+			metricID: reader.ReadUint32(),
+			seriesID: reader.ReadUint32(),
+			fieldID:  field.ID(reader.ReadByte()),
+		}
+		s.points[key] = cumulativePoint{
+			value:     math.Float64frombits(reader.ReadUint64()),
+			timestamp: reader.ReadInt64(),
+		}
+	}
+	if err := reader.Error(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Delta returns the delta value between given value and last seen value of the series field.
+func (s *cumulativeStore) Delta(metricID, seriesID uint32, fieldID field.ID,
+	timestamp int64, value float64,
+) (delta float64, ok bool) {
+	key := cumulativeKey{metricID: metricID, seriesID: seriesID, fieldID: fieldID}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	last, exist := s.points[key]
+	if exist && timestamp <= last.timestamp {
+		// out of order or duplicate point, keep last seen state
+		return 0, false
+	}
+	s.points[key] = cumulativePoint{value: value, timestamp: timestamp}
+	if !exist {
+		// first point, no previous value for calculating delta
+		return 0, false
+	}
+	if value < last.value {
+		// counter reset(restart of client)
+		return value, true
+	}
+	return value - last.value, true
+}
+
+// Evict removes the state which is not updated since given timestamp.
+func (s *cumulativeStore) Evict(before int64) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	evicted := 0
+	for key, point := range s.points {
+		if point.timestamp < before {
+			delete(s.points, key)
+			evicted++
+		}
+	}
+	return evicted
+}
+
+// Size returns the number of series field state.
+func (s *cumulativeStore) Size() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return len(s.points)
+}
+
+// Persist writes all series field state into tmp file, then renames tmp file to state file.
+func (s *cumulativeStore) Persist() error {
+	s.lock.Lock()
+	writer := stream.NewBufferWriter(bytes.NewBuffer(make([]byte, 0, len(s.points)*cumulativePointSize)))
+	for key, point := range s.points {
+		writer.PutUint32(key.metricID)
+		writer.PutUint32(key.seriesID)
+		writer.PutByte(byte(key.fieldID))
+		writer.PutUint64(math.Float64bits(point.value))
+		writer.PutInt64(point.timestamp)
+	}
+	s.lock.Unlock()
+
+	data, err := writer.Bytes()
+	if err != nil {
+		return err
+	}
+	tmp := fmt.Sprintf("%s.tmp", s.fileName)
+	if err := writeCumulativeFileFunc(tmp, data, 0644); err != nil {
+		return fmt.Errorf("write cumulative state tmp file[%s] error:%s", tmp, err)
+	}
+	if err := renameCumulativeFileFunc(tmp, s.fileName); err != nil {
+		return fmt.Errorf("rename cumulative state tmp file[%s] error:%s", tmp, err)
+	}
+	return nil
+}
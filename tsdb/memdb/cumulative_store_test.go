@@ -0,0 +1,124 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package memdb
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCumulativeStore_Delta(t *testing.T) {
+	store, err := NewCumulativeStore(filepath.Join(t.TempDir(), "cumulative"))
+	assert.NoError(t, err)
+	// first point
+	delta, ok := store.Delta(1, 1, 1, 10, 100)
+	assert.False(t, ok)
+	assert.Zero(t, delta)
+	// increase
+	delta, ok = store.Delta(1, 1, 1, 20, 150)
+	assert.True(t, ok)
+	assert.Equal(t, float64(50), delta)
+	// out of order point
+	delta, ok = store.Delta(1, 1, 1, 15, 120)
+	assert.False(t, ok)
+	assert.Zero(t, delta)
+	// duplicate point
+	_, ok = store.Delta(1, 1, 1, 20, 150)
+	assert.False(t, ok)
+	// counter reset
+	delta, ok = store.Delta(1, 1, 1, 30, 20)
+	assert.True(t, ok)
+	assert.Equal(t, float64(20), delta)
+	delta, ok = store.Delta(1, 1, 1, 40, 25)
+	assert.True(t, ok)
+	assert.Equal(t, float64(5), delta)
+	// other series
+	_, ok = store.Delta(1, 2, 1, 40, 25)
+	assert.False(t, ok)
+	// other field
+	_, ok = store.Delta(1, 1, 2, 40, 25)
+	assert.False(t, ok)
+	assert.Equal(t, 3, store.Size())
+}
+
+func TestCumulativeStore_Evict(t *testing.T) {
+	store, err := NewCumulativeStore(filepath.Join(t.TempDir(), "cumulative"))
+	assert.NoError(t, err)
+	store.Delta(1, 1, 1, 10, 100)
+	store.Delta(1, 2, 1, 20, 100)
+	store.Delta(1, 3, 1, 30, 100)
+	assert.Equal(t, 2, store.Evict(30))
+	assert.Equal(t, 1, store.Size())
+	assert.Zero(t, store.Evict(30))
+	// evicted series starts again
+	_, ok := store.Delta(1, 1, 1, 40, 200)
+	assert.False(t, ok)
+}
+
+func TestCumulativeStore_Persist(t *testing.T) {
+	defer func() {
+		writeCumulativeFileFunc = ioutil.WriteFile
+		readCumulativeFileFunc = ioutil.ReadFile
+		renameCumulativeFileFunc = os.Rename
+	}()
+	fileName := filepath.Join(t.TempDir(), "cumulative")
+	store, err := NewCumulativeStore(fileName)
+	assert.NoError(t, err)
+	store.Delta(1, 1, 1, 10, 100)
+	store.Delta(1, 2, 3, 20, 1.5)
+	assert.NoError(t, store.Persist())
+	// case 1: reload state, continue calculating delta after restart
+	store, err = NewCumulativeStore(fileName)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, store.Size())
+	delta, ok := store.Delta(1, 1, 1, 20, 150)
+	assert.True(t, ok)
+	assert.Equal(t, float64(50), delta)
+	delta, ok = store.Delta(1, 2, 3, 30, 2.5)
+	assert.True(t, ok)
+	assert.Equal(t, float64(1), delta)
+	// case 2: write tmp file err
+	writeCumulativeFileFunc = func(filename string, data []byte, perm os.FileMode) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, store.Persist())
+	writeCumulativeFileFunc = ioutil.WriteFile
+	// case 3: rename tmp file err
+	renameCumulativeFileFunc = func(oldpath, newpath string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, store.Persist())
+	renameCumulativeFileFunc = os.Rename
+	// case 4: read file err
+	readCumulativeFileFunc = func(filename string) ([]byte, error) {
+		return nil, fmt.Errorf("err")
+	}
+	store, err = NewCumulativeStore(fileName)
+	assert.Error(t, err)
+	assert.Nil(t, store)
+	readCumulativeFileFunc = ioutil.ReadFile
+	// case 5: corrupted file
+	assert.NoError(t, ioutil.WriteFile(fileName, []byte{1, 2, 3}, 0644))
+	store, err = NewCumulativeStore(fileName)
+	assert.Error(t, err)
+	assert.Nil(t, store)
+}
@@ -191,32 +191,62 @@ func (md *memoryDatabase) WriteRow(row *metric.StorageRow) error {
 		written = true
 	}
 
+	var gaugeAggFieldIDIdx = 0
 	simpleFieldItr := row.NewSimpleFieldIterator()
-	for simpleFieldItr.HasNext() {
+	for idx := 0; simpleFieldItr.HasNext(); idx++ {
+		fieldType := simpleFieldItr.NextType()
+		fieldValue := simpleFieldItr.NextValue()
+		if row.IsFieldSkipped(idx) {
+			fieldIDIdx++
+			if fieldType == field.GaugeField && len(row.GaugeAggFieldIDs) > 0 {
+				gaugeAggFieldIDIdx += len(field.GaugeAggFields)
+			}
+			continue
+		}
 		writtenLinFieldSize, err := md.writeLinField(
 			row.SlotIndex,
 			row.FieldIDs[fieldIDIdx],
-			simpleFieldItr.NextType(),
-			simpleFieldItr.NextValue(),
+			fieldType,
+			fieldValue,
 			mStore, tStore,
 		)
 		if err != nil {
 			return err
 		}
 		afterWrite(writtenLinFieldSize)
+		if fieldType != field.GaugeField || len(row.GaugeAggFieldIDs) == 0 {
+			continue
+		}
+		// write hidden aggregate fields of gauge
+		for _, aggField := range field.GaugeAggFields {
+			writtenLinFieldSize, err = md.writeLinField(
+				row.SlotIndex,
+				row.GaugeAggFieldIDs[gaugeAggFieldIDIdx],
+				aggField.Type,
+				aggField.Value(fieldValue),
+				mStore, tStore,
+			)
+			if err != nil {
+				return err
+			}
+			gaugeAggFieldIDIdx++
+			size += writtenLinFieldSize
+		}
 	}
 	compoundFieldItr, ok := row.NewCompoundFieldIterator()
 
 	var (
 		err                 error
 		writtenLinFieldSize int
+		hasSketch           bool
 	)
 	if !ok {
 		goto End
 	}
+	hasSketch = compoundFieldItr.HasSketch()
 
-	// write histogram_min
-	if compoundFieldItr.Min() > 0 {
+	// write histogram_min, always write min/max of sketch, same as field ids
+	if compoundFieldItr.Min() > 0 || hasSketch {
 		writtenLinFieldSize, err = md.writeLinField(
 			row.SlotIndex, row.FieldIDs[fieldIDIdx],
 			field.MinField, compoundFieldItr.Min(),
@@ -227,7 +257,7 @@ func (md *memoryDatabase) WriteRow(row *metric.StorageRow) error {
 		afterWrite(writtenLinFieldSize)
 	}
 	// write histogram_max
-	if compoundFieldItr.Max() > 0 {
+	if compoundFieldItr.Max() > 0 || hasSketch {
 		writtenLinFieldSize, err = md.writeLinField(
 			row.SlotIndex, row.FieldIDs[fieldIDIdx],
 			field.MaxField, compoundFieldItr.Max(),
@@ -270,6 +300,17 @@ func (md *memoryDatabase) WriteRow(row *metric.StorageRow) error {
 		}
 		afterWrite(writtenLinFieldSize)
 	}
+	// write __sketch, the value is the count of sketch, the sketch self is buffered in shard
+	if hasSketch {
+		writtenLinFieldSize, err = md.writeLinField(
+			row.SlotIndex, row.FieldIDs[fieldIDIdx],
+			field.SketchField, compoundFieldItr.Count(),
+			mStore, tStore)
+		if err != nil {
+			return err
+		}
+		afterWrite(writtenLinFieldSize)
+	}
 
 End:
 	if written {
@@ -28,6 +28,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/flow"
+	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/pkg/fileutil"
 	"github.com/lindb/lindb/pkg/timeutil"
 	protoMetricsV1 "github.com/lindb/lindb/proto/gen/v1/metrics"
@@ -201,6 +202,132 @@ func TestMemoryDatabase_Write(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestMemoryDatabase_Write_GaugeAggFields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockMStore := NewMockmStoreINTF(ctrl)
+	tStore := NewMocktStoreINTF(ctrl)
+	fStore := NewMockfStoreINTF(ctrl)
+	fStore.EXPECT().Capacity().Return(100).AnyTimes()
+	mockMStore.EXPECT().Capacity().Return(100).AnyTimes()
+	mockMStore.EXPECT().GetOrCreateTStore(uint32(10)).Return(tStore, false).AnyTimes()
+	mockMStore.EXPECT().SetSlot(gomock.Any()).AnyTimes()
+	tStore.EXPECT().GetFStore(gomock.Any()).Return(fStore, true).AnyTimes()
+	mdINTF, err := NewMemoryDatabase(cfg)
+	assert.NoError(t, err)
+	md := mdINTF.(*memoryDatabase)
+	md.mStores.Put(uint32(1), mockMStore)
+
+	gomock.InOrder(
+		fStore.EXPECT().Write(field.GaugeField, uint16(1), 10.0),
+		fStore.EXPECT().Write(field.MinField, uint16(1), 10.0),
+		fStore.EXPECT().Write(field.MaxField, uint16(1), 10.0),
+		fStore.EXPECT().Write(field.SumField, uint16(1), 10.0),
+		fStore.EXPECT().Write(field.SumField, uint16(1), 1.0),
+		fStore.EXPECT().Write(field.SumField, uint16(1), 5.0),
+	)
+	row := protoToStorageRow(&protoMetricsV1.Metric{
+		Name:      "test1",
+		Namespace: "ns",
+		SimpleFields: []*protoMetricsV1.SimpleField{
+			{Name: "f1", Type: protoMetricsV1.SimpleFieldType_GAUGE, Value: 10},
+			{Name: "f2", Type: protoMetricsV1.SimpleFieldType_DELTA_SUM, Value: 5},
+		},
+	})
+	row.MetricID = 1
+	row.SeriesID = 10
+	row.SlotIndex = 1
+	row.FieldIDs = []field.ID{1, 2}
+	row.GaugeAggFieldIDs = []field.ID{3, 4, 5, 6}
+	assert.NoError(t, md.WriteRow(row))
+	assert.NoError(t, md.Close())
+}
+
+func TestMemoryDatabase_Write_SkippedFields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockMStore := NewMockmStoreINTF(ctrl)
+	tStore := NewMocktStoreINTF(ctrl)
+	fStore := NewMockfStoreINTF(ctrl)
+	fStore.EXPECT().Capacity().Return(100).AnyTimes()
+	mockMStore.EXPECT().Capacity().Return(100).AnyTimes()
+	mockMStore.EXPECT().GetOrCreateTStore(uint32(10)).Return(tStore, false).AnyTimes()
+	mockMStore.EXPECT().SetSlot(gomock.Any()).AnyTimes()
+	mdINTF, err := NewMemoryDatabase(cfg)
+	assert.NoError(t, err)
+	md := mdINTF.(*memoryDatabase)
+	md.mStores.Put(uint32(1), mockMStore)
+
+	// skipped gauge field and its aggregate fields are not written
+	tStore.EXPECT().GetFStore(field.ID(2)).Return(fStore, true)
+	fStore.EXPECT().Write(field.SumField, uint16(1), 5.0)
+	row := protoToStorageRow(&protoMetricsV1.Metric{
+		Name:      "test1",
+		Namespace: "ns",
+		SimpleFields: []*protoMetricsV1.SimpleField{
+			{Name: "f1", Type: protoMetricsV1.SimpleFieldType_GAUGE, Value: 10},
+			{Name: "f2", Type: protoMetricsV1.SimpleFieldType_DELTA_SUM, Value: 5},
+			{Name: "f3", Type: protoMetricsV1.SimpleFieldType_CUMULATIVE_SUM, Value: 100},
+		},
+	})
+	row.MetricID = 1
+	row.SeriesID = 10
+	row.SlotIndex = 1
+	row.FieldIDs = []field.ID{1, 2, 3}
+	row.GaugeAggFieldIDs = []field.ID{4, 5, 6, 7}
+	row.SkippedFields = []int{0, 2}
+	assert.NoError(t, md.WriteRow(row))
+	assert.NoError(t, md.Close())
+}
+
+func TestMemoryDatabase_Write_Sketch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockMStore := NewMockmStoreINTF(ctrl)
+	tStore := NewMocktStoreINTF(ctrl)
+	fStore := NewMockfStoreINTF(ctrl)
+	fStore.EXPECT().Capacity().Return(100).AnyTimes()
+	mockMStore.EXPECT().Capacity().Return(100).AnyTimes()
+	mockMStore.EXPECT().GetOrCreateTStore(uint32(10)).Return(tStore, false).AnyTimes()
+	mockMStore.EXPECT().SetSlot(gomock.Any()).AnyTimes()
+	tStore.EXPECT().GetFStore(gomock.Any()).Return(fStore, true).AnyTimes()
+	mdINTF, err := NewMemoryDatabase(cfg)
+	assert.NoError(t, err)
+	md := mdINTF.(*memoryDatabase)
+	md.mStores.Put(uint32(1), mockMStore)
+
+	sketch := encoding.NewSketch()
+	sketch.Add(1)
+	sketch.Add(100)
+	sketch.Add(100)
+	gomock.InOrder(
+		// min/max are always written for sketch
+		fStore.EXPECT().Write(field.MinField, uint16(1), 0.0),
+		fStore.EXPECT().Write(field.MaxField, uint16(1), 100.0),
+		// sum/count
+		fStore.EXPECT().Write(field.SumField, uint16(1), 201.0),
+		fStore.EXPECT().Write(field.SumField, uint16(1), 3.0),
+		// count of sketch
+		fStore.EXPECT().Write(field.SketchField, uint16(1), 3.0),
+	)
+	row := protoToStorageRow(&protoMetricsV1.Metric{
+		Name:      "test1",
+		Namespace: "ns",
+		CompoundField: &protoMetricsV1.CompoundField{
+			Max:    100,
+			Sum:    201,
+			Count:  3,
+			Sketch: sketch.MarshalBinary(),
+		},
+	})
+	row.MetricID = 1
+	row.SeriesID = 10
+	row.SlotIndex = 1
+	row.FieldIDs = []field.ID{1, 2, 3, 4, 5}
+	assert.NoError(t, md.WriteRow(row))
+	assert.NoError(t, md.Close())
+}
+
 func TestMemoryDatabase_Write_err(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer func() {
@@ -58,9 +58,24 @@ type IDGetter interface {
 	// GetAllFields returns the all visible fields by namespace/metric name,
 	// if not exist return series.ErrNotFound
 	GetAllFields(namespace, metricName string) (fields []field.Meta, err error)
-	// GetAllHistogramFields returns histogram-fields namespace/metric name,
+	// GetAllHistogramFields returns histogram-fields(include quantile sketch field) namespace/metric name,
 	// if not exist return series.ErrNotFound
 	GetAllHistogramFields(namespace, metricName string) (fields field.Metas, err error)
+	// GetAllTagKeysByMetricID returns the all tag keys by metric id,
+	// if not exist return constants.ErrMetricBucketNotFound
+	GetAllTagKeysByMetricID(metricID uint32) (tags []tag.Meta, err error)
+	// GetAllFieldsByMetricID returns the all visible fields by metric id,
+	// if not exist return series.ErrNotFound
+	GetAllFieldsByMetricID(metricID uint32) (fields []field.Meta, err error)
+}
+
+// MetricMeta represents the metadata of metric include fields/tag keys.
+type MetricMeta struct {
+	Namespace string
+	Name      string
+	ID        uint32
+	Fields    field.Metas
+	TagKeys   []tag.Meta
 }
 
 // Metadata represents all metadata of tsdb, like metric/tag metadata
@@ -73,6 +88,9 @@ type Metadata interface {
 	TagMetadata() TagMetadata
 	// Flush flushes the metadata to disk
 	Flush() error
+	// Merge merges the metadata of source(such as shard snapshot from other node) with same ids,
+	// returns constants.ErrMetadataConflict if the ids of source conflict with current metadata.
+	Merge(source Metadata) error
 }
 
 // MetadataDatabase represents the metadata storage includes namespace/metric metadata
@@ -86,4 +104,11 @@ type MetadataDatabase interface {
 	SuggestNamespace(prefix string, limit int) (namespaces []string, err error)
 	// Sync syncs the pending metadata update event
 	Sync() error
+	// Backup writes a consistent copy of metadata(bbolt.DB file and meta wal) into target path
+	Backup(targetPath string) error
+	// AllMetrics returns the metadata of all metrics include fields/tag keys
+	AllMetrics() ([]MetricMeta, error)
+	// MergeMetrics merges the metadata of metrics with same ids, nothing is merged if ids conflict,
+	// returns constants.ErrMetadataConflict if name has different id or id is used by other name.
+	MergeMetrics(metrics []MetricMeta) error
 }
@@ -73,3 +73,26 @@ func (m *metadata) Flush() error {
 	}
 	return m.tagMetadata.Flush()
 }
+
+// Merge merges the metadata of source(such as shard snapshot from other node) with same ids,
+// merges metric metadata first, then tag values of the tag keys in source.
+func (m *metadata) Merge(source Metadata) error {
+	metrics, err := source.MetadataDatabase().AllMetrics()
+	if err != nil {
+		return err
+	}
+	tagValues := make(map[uint32]TagValues)
+	for _, metric := range metrics {
+		for _, tagKey := range metric.TagKeys {
+			values, err := source.TagMetadata().GetTagValues(tagKey.ID)
+			if err != nil {
+				return err
+			}
+			tagValues[tagKey.ID] = values
+		}
+	}
+	if err := m.metadataDatabase.MergeMetrics(metrics); err != nil {
+		return err
+	}
+	return m.tagMetadata.MergeTagValues(tagValues)
+}
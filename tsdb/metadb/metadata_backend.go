@@ -94,15 +94,20 @@ type MetadataBackend interface {
 	// getAllFields returns the  all fields by metric id,
 	// if not exist return constants.ErrMetricBucketNotFound
 	getAllFields(metricID uint32) (fields []field.Meta, err error)
-	// getAllHistogramFields returns all histogram-bucket fields by metric id,
+	// getAllHistogramFields returns all histogram-bucket(include quantile sketch bin) fields by metric id,
 	// if not exist return constants.ErrHistogramFieldNotFound
 	getAllHistogramFields(metricID uint32) (fields []field.Meta, err error)
 
+	// getAllMetrics returns the metadata of all metrics include fields/tag keys
+	getAllMetrics() (metrics []MetricMeta, err error)
+
 	// saveMetadata saves the pending metadata include namespace/metric metadata
 	saveMetadata(event *metadataUpdateEvent) error
 
 	// sync syncs bbolt.DB file data
 	sync() error
+	// backup writes a consistent copy of bbolt.DB file into target path
+	backup(targetPath string) error
 }
 
 // metadataBackend implements the MetadataBackend interface
@@ -358,13 +363,42 @@ func (mb *metadataBackend) getAllHistogramFields(metricID uint32) (fields []fiel
 	}
 	var histogramFields field.Metas
 	for idx := range fields {
-		if fields[idx].Type == field.HistogramField {
+		if fieldType := fields[idx].Type; fieldType == field.HistogramField || fieldType == field.SketchField {
 			histogramFields = append(histogramFields, fields[idx])
 		}
 	}
 	return histogramFields, nil
 }
 
+// getAllMetrics returns the metadata of all metrics include fields/tag keys
+func (mb *metadataBackend) getAllMetrics() (metrics []MetricMeta, err error) {
+	err = mb.db.View(func(tx *bbolt.Tx) error {
+		metricRootBucket := tx.Bucket(metricBucketName)
+		nsCursor := tx.Bucket(nsBucketName).Cursor()
+		for ns, v := nsCursor.First(); ns != nil; ns, v = nsCursor.Next() {
+			if v != nil {
+				// not namespace bucket
+				continue
+			}
+			metricCursor := tx.Bucket(nsBucketName).Bucket(ns).Cursor()
+			for name, value := metricCursor.First(); name != nil; name, value = metricCursor.Next() {
+				metric := MetricMeta{
+					Namespace: string(ns),
+					Name:      string(name),
+					ID:        binary.LittleEndian.Uint32(value),
+				}
+				if metricBucket := metricRootBucket.Bucket(value); metricBucket != nil {
+					metric.Fields = loadFields(metricBucket.Bucket(fieldBucketName))
+					metric.TagKeys = loadTagKeys(metricBucket.Bucket(tagBucketName))
+				}
+				metrics = append(metrics, metric)
+			}
+		}
+		return nil
+	})
+	return
+}
+
 // saveMetadata saves the pending metadata include namespace/metric metadata
 func (mb *metadataBackend) saveMetadata(event *metadataUpdateEvent) (err error) {
 	err = mb.db.Update(func(tx *bbolt.Tx) error {
@@ -384,6 +418,13 @@ func (mb *metadataBackend) sync() error {
 	return mb.db.Sync()
 }
 
+// backup writes a consistent copy of bbolt.DB file into target path
+func (mb *metadataBackend) backup(targetPath string) error {
+	return mb.db.View(func(tx *bbolt.Tx) error {
+		return tx.CopyFile(path.Join(targetPath, MetaDB), 0600)
+	})
+}
+
 // Close closes the bbolt.DB
 func (mb *metadataBackend) Close() error {
 	return mb.db.Close()
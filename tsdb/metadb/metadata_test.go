@@ -26,6 +26,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/pkg/fileutil"
+	"github.com/lindb/lindb/series/tag"
 )
 
 func TestNewMetadata(t *testing.T) {
@@ -73,3 +74,35 @@ func TestMetadata_Flush(t *testing.T) {
 	err = metadata1.Flush()
 	assert.NoError(t, err)
 }
+
+func TestMetadata_Merge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sourceDB := NewMockMetadataDatabase(ctrl)
+	sourceTag := NewMockTagMetadata(ctrl)
+	source := NewMockMetadata(ctrl)
+	source.EXPECT().MetadataDatabase().Return(sourceDB).AnyTimes()
+	source.EXPECT().TagMetadata().Return(sourceTag).AnyTimes()
+	db := NewMockMetadataDatabase(ctrl)
+	tagMeta := NewMockTagMetadata(ctrl)
+	m := &metadata{metadataDatabase: db, tagMetadata: tagMeta}
+
+	metrics := []MetricMeta{{Namespace: "ns", Name: "cpu", ID: 1, TagKeys: []tag.Meta{{Key: "host", ID: 2}}}}
+	tagValues := TagValues{Seq: 1, Values: map[string]uint32{"1.1.1.1": 1}}
+	// case 1: get source metrics err
+	sourceDB.EXPECT().AllMetrics().Return(nil, fmt.Errorf("err"))
+	assert.Error(t, m.Merge(source))
+	// case 2: get source tag values err
+	sourceDB.EXPECT().AllMetrics().Return(metrics, nil).AnyTimes()
+	sourceTag.EXPECT().GetTagValues(uint32(2)).Return(TagValues{}, fmt.Errorf("err"))
+	assert.Error(t, m.Merge(source))
+	// case 3: merge metrics err
+	sourceTag.EXPECT().GetTagValues(uint32(2)).Return(tagValues, nil).AnyTimes()
+	db.EXPECT().MergeMetrics(metrics).Return(fmt.Errorf("err"))
+	assert.Error(t, m.Merge(source))
+	// case 4: merge success
+	db.EXPECT().MergeMetrics(metrics).Return(nil)
+	tagMeta.EXPECT().MergeTagValues(map[uint32]TagValues{2: tagValues}).Return(nil)
+	assert.NoError(t, m.Merge(source))
+}
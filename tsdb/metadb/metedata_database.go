@@ -28,6 +28,7 @@ import (
 
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/internal/linmetric"
+	"github.com/lindb/lindb/pkg/fileutil"
 	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/series"
@@ -41,6 +42,7 @@ import (
 var (
 	createMetadataBackend = newMetadataBackend
 	createMetaWAL         = wal.NewMetricMetaWAL
+	copyDirFunc           = fileutil.CopyDir
 )
 
 var (
@@ -77,7 +79,8 @@ type metadataDatabase struct {
 
 	syncInterval int64
 
-	rwMux sync.RWMutex
+	rwMux        sync.RWMutex
+	recoveryLock sync.Mutex // lock for recovering meta wal
 
 	statistics struct {
 		genMetricIDCounter   *linmetric.BoundCounter
@@ -257,6 +260,36 @@ func (mdb *metadataDatabase) GetAllFields(namespace, metricName string) (fields
 	return mdb.backend.getAllFields(metricID)
 }
 
+// GetAllTagKeysByMetricID returns the all tag keys by metric id,
+// if not exist return constants.ErrMetricBucketNotFound
+func (mdb *metadataDatabase) GetAllTagKeysByMetricID(metricID uint32) (tags []tag.Meta, err error) {
+	if metricMetadata, ok := mdb.getMetricMetadataByID(metricID); ok {
+		return metricMetadata.getAllTagKeys(), nil
+	}
+	return mdb.backend.getAllTagKeys(metricID)
+}
+
+// GetAllFieldsByMetricID returns the all visible fields by metric id,
+// if not exist return series.ErrNotFound
+func (mdb *metadataDatabase) GetAllFieldsByMetricID(metricID uint32) (fields []field.Meta, err error) {
+	if metricMetadata, ok := mdb.getMetricMetadataByID(metricID); ok {
+		return metricMetadata.getAllFields(), nil
+	}
+	return mdb.backend.getAllFields(metricID)
+}
+
+// getMetricMetadataByID returns the cached metric metadata by metric id
+func (mdb *metadataDatabase) getMetricMetadataByID(metricID uint32) (MetricMetadata, bool) {
+	mdb.rwMux.RLock()
+	defer mdb.rwMux.RUnlock()
+	for _, metricMetadata := range mdb.metrics {
+		if metricMetadata.getMetricID() == metricID {
+			return metricMetadata, true
+		}
+	}
+	return nil, false
+}
+
 func (mdb *metadataDatabase) GetAllHistogramFields(namespace, metricName string) (fields field.Metas, err error) {
 	key := metricchecker.JoinNamespaceMetric(namespace, metricName)
 	mdb.rwMux.RLock()
@@ -417,6 +450,204 @@ func (mdb *metadataDatabase) Sync() error {
 	return nil
 }
 
+// Backup writes a consistent copy of metadata(bbolt.DB file and meta wal) into target path,
+// the pending metadata of meta wal will be recovered when opening the copy.
+func (mdb *metadataDatabase) Backup(targetPath string) error {
+	// block generating new metadata and recovering meta wal
+	mdb.rwMux.Lock()
+	defer mdb.rwMux.Unlock()
+	mdb.recoveryLock.Lock()
+	defer mdb.recoveryLock.Unlock()
+
+	if err := mkDir(targetPath); err != nil {
+		return err
+	}
+	if err := mdb.metaWAL.Sync(); err != nil {
+		return err
+	}
+	if err := mdb.backend.backup(targetPath); err != nil {
+		return err
+	}
+	return copyDirFunc(filepath.Join(mdb.path, walPath), filepath.Join(targetPath, walPath))
+}
+
+// AllMetrics returns the metadata of all metrics include fields/tag keys,
+// saves the pending metadata of meta wal into backend storage first.
+func (mdb *metadataDatabase) AllMetrics() ([]MetricMeta, error) {
+	// block generating new metadata
+	mdb.rwMux.Lock()
+	defer mdb.rwMux.Unlock()
+
+	if err := mdb.savePending(); err != nil {
+		return nil, err
+	}
+	return mdb.allMetrics()
+}
+
+// MergeMetrics merges the metadata of metrics with same ids, nothing is merged if ids conflict,
+// returns constants.ErrMetadataConflict if name has different id or id is used by other name.
+func (mdb *metadataDatabase) MergeMetrics(metrics []MetricMeta) error {
+	// block generating new metadata, all metadata are in backend storage after saving pending metadata
+	mdb.rwMux.Lock()
+	defer mdb.rwMux.Unlock()
+
+	if err := mdb.savePending(); err != nil {
+		return err
+	}
+	existMetrics, err := mdb.allMetrics()
+	if err != nil {
+		return err
+	}
+	metricIDs := make(map[uint32]string)
+	tagKeyIDs := make(map[uint32]string)
+	exists := make(map[string]MetricMeta)
+	for _, metric := range existMetrics {
+		key := metricchecker.JoinNamespaceMetric(metric.Namespace, metric.Name)
+		exists[key] = metric
+		metricIDs[metric.ID] = key
+		for _, tagKey := range metric.TagKeys {
+			tagKeyIDs[tagKey.ID] = key
+		}
+	}
+	event := newMetadataUpdateEvent()
+	var maxMetricID, maxTagKeyID uint32
+	for _, metric := range metrics {
+		key := metricchecker.JoinNamespaceMetric(metric.Namespace, metric.Name)
+		exist, ok := exists[key]
+		switch {
+		case ok && exist.ID != metric.ID:
+			return fmt.Errorf("%w: metric[%s] id %d not equals %d", constants.ErrMetadataConflict, key, metric.ID, exist.ID)
+		case !ok:
+			if other, used := metricIDs[metric.ID]; used {
+				return fmt.Errorf("%w: metric id %d of [%s] is used by [%s]",
+					constants.ErrMetadataConflict, metric.ID, key, other)
+			}
+			metricIDs[metric.ID] = key
+			event.addMetric(metric.Namespace, metric.Name, metric.ID)
+			if metric.ID > maxMetricID {
+				maxMetricID = metric.ID
+			}
+		}
+		var fieldIDSeq uint16
+		for _, f := range metric.Fields {
+			if existField, ok := exist.Fields.GetFromName(f.Name); ok {
+				if existField.ID != f.ID || existField.Type != f.Type {
+					return fmt.Errorf("%w: field[%s] of metric[%s] is different", constants.ErrMetadataConflict, f.Name, key)
+				}
+				continue
+			}
+			if _, used := exist.Fields.GetFromID(f.ID); used {
+				return fmt.Errorf("%w: field id %d of metric[%s] is used", constants.ErrMetadataConflict, f.ID, key)
+			}
+			event.addField(metric.ID, f)
+			if uint16(f.ID) > fieldIDSeq {
+				fieldIDSeq = uint16(f.ID)
+			}
+		}
+		if meta, ok := event.metrics[metric.ID]; ok {
+			meta.fieldIDSeq = fieldIDSeq
+		}
+		for _, tagKey := range metric.TagKeys {
+			if tagKeyID, ok := findTagKeyID(exist.TagKeys, tagKey.Key); ok {
+				if tagKeyID != tagKey.ID {
+					return fmt.Errorf("%w: tag key[%s] of metric[%s] id %d not equals %d",
+						constants.ErrMetadataConflict, tagKey.Key, key, tagKey.ID, tagKeyID)
+				}
+				continue
+			}
+			if other, used := tagKeyIDs[tagKey.ID]; used {
+				return fmt.Errorf("%w: tag key id %d of metric[%s] is used by [%s]",
+					constants.ErrMetadataConflict, tagKey.ID, key, other)
+			}
+			tagKeyIDs[tagKey.ID] = key
+			event.addTagKey(metric.ID, tagKey)
+			if tagKey.ID > maxTagKeyID {
+				maxTagKeyID = tagKey.ID
+			}
+		}
+	}
+	if event.isEmpty() {
+		return nil
+	}
+	// sequences are moved forward only
+	event.metricSeqID = maxMetricID
+	event.tagKeySeqID = maxTagKeyID
+	if err := mdb.backend.saveMetadata(event); err != nil {
+		return err
+	}
+	// update the cached metric metadata, because the metadata in cache is used to generate ids
+	for _, metric := range metrics {
+		metricMetadata, ok := mdb.metrics[metricchecker.JoinNamespaceMetric(metric.Namespace, metric.Name)]
+		if !ok {
+			continue
+		}
+		for _, f := range metric.Fields {
+			if _, exist := metricMetadata.getField(f.Name); !exist {
+				metricMetadata.mergeField(f)
+			}
+		}
+		for _, tagKey := range metric.TagKeys {
+			if _, exist := metricMetadata.getTagKeyID(tagKey.Key); !exist {
+				metricMetadata.createTagKey(tagKey.Key, tagKey.ID)
+			}
+		}
+	}
+	return mdb.backend.sync()
+}
+
+// allMetrics returns the metadata of all metrics in backend storage and memory cache,
+// the metadata in cache maybe not saved into backend storage(pending in meta wal).
+// NOTE: must hold the lock of memory cache.
+func (mdb *metadataDatabase) allMetrics() ([]MetricMeta, error) {
+	metrics, err := mdb.backend.getAllMetrics()
+	if err != nil {
+		return nil, err
+	}
+	cached := make(map[string]struct{}, len(mdb.metrics))
+	for idx := range metrics {
+		key := metricchecker.JoinNamespaceMetric(metrics[idx].Namespace, metrics[idx].Name)
+		if metricMetadata, ok := mdb.metrics[key]; ok {
+			metrics[idx].Fields = metricMetadata.getAllFields()
+			metrics[idx].TagKeys = metricMetadata.getAllTagKeys()
+			cached[key] = struct{}{}
+		}
+	}
+	for key, metricMetadata := range mdb.metrics {
+		if _, ok := cached[key]; ok {
+			continue
+		}
+		// namespace/metric name are sanitized, cannot include delimiter
+		idx := strings.Index(key, "|")
+		metrics = append(metrics, MetricMeta{
+			Namespace: key[:idx],
+			Name:      key[idx+1:],
+			ID:        metricMetadata.getMetricID(),
+			Fields:    metricMetadata.getAllFields(),
+			TagKeys:   metricMetadata.getAllTagKeys(),
+		})
+	}
+	return metrics, nil
+}
+
+// savePending saves the pending metadata of meta wal into backend storage.
+func (mdb *metadataDatabase) savePending() error {
+	mdb.metaRecovery()
+	if mdb.metaWAL.NeedRecovery() {
+		return ErrNeedRecoveryWAL
+	}
+	return nil
+}
+
+// findTagKeyID finds the tag key id by tag key
+func findTagKeyID(tagKeys []tag.Meta, tagKey string) (uint32, bool) {
+	for _, t := range tagKeys {
+		if t.Key == tagKey {
+			return t.ID, true
+		}
+	}
+	return 0, false
+}
+
 // Close closes the resources
 func (mdb *metadataDatabase) Close() error {
 	mdb.cancel()
@@ -476,6 +707,9 @@ func (mdb *metadataDatabase) checkSync() {
 
 // metaRecovery recovers meta wal data
 func (mdb *metadataDatabase) metaRecovery() {
+	mdb.recoveryLock.Lock()
+	defer mdb.recoveryLock.Unlock()
+
 	startTime := time.Now()
 
 	defer mdb.statistics.recoveryMetaWALTimer.UpdateSince(startTime)
@@ -21,6 +21,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -340,6 +341,51 @@ func TestMetadataDatabase_GetField(t *testing.T) {
 	_ = db.Close()
 }
 
+func TestMetadataDatabase_GetByMetricID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		createMetadataBackend = newMetadataBackend
+		_ = fileutil.RemoveDir(testPath)
+
+		ctrl.Finish()
+	}()
+	mockBackend := NewMockMetadataBackend(ctrl)
+	createMetadataBackend = func(parent string) (backend MetadataBackend, err error) {
+		return mockBackend, nil
+	}
+	db, err := NewMetadataDatabase(context.TODO(), "test", testPath)
+	assert.NoError(t, err)
+	meta := NewMockMetricMetadata(ctrl)
+	mockBackend.EXPECT().loadMetricMetadata("ns-1", "name1").Return(meta, nil)
+	meta.EXPECT().getMetricID().Return(uint32(1)).AnyTimes()
+	_, err = db.GenMetricID("ns-1", "name1")
+	assert.NoError(t, err)
+
+	// case 1: from memory
+	meta.EXPECT().getAllTagKeys().Return([]tag.Meta{{Key: "host", ID: 1}})
+	tags, err := db.GetAllTagKeysByMetricID(1)
+	assert.NoError(t, err)
+	assert.Equal(t, []tag.Meta{{Key: "host", ID: 1}}, tags)
+	meta.EXPECT().getAllFields().Return([]field.Meta{{ID: 19, Type: field.SumField}})
+	fields, err := db.GetAllFieldsByMetricID(1)
+	assert.NoError(t, err)
+	assert.Equal(t, []field.Meta{{ID: 19, Type: field.SumField}}, fields)
+
+	// case 2: from backend
+	mockBackend.EXPECT().getAllTagKeys(uint32(10)).Return(nil, constants.ErrNotFound)
+	tags, err = db.GetAllTagKeysByMetricID(10)
+	assert.True(t, errors.Is(err, constants.ErrNotFound))
+	assert.Nil(t, tags)
+	mockBackend.EXPECT().getAllFields(uint32(10)).Return([]field.Meta{{ID: 19, Type: field.SumField}}, nil)
+	fields, err = db.GetAllFieldsByMetricID(10)
+	assert.NoError(t, err)
+	assert.Equal(t, []field.Meta{{ID: 19, Type: field.SumField}}, fields)
+
+	mockBackend.EXPECT().saveMetadata(gomock.Any()).AnyTimes()
+	mockBackend.EXPECT().Close().Return(nil)
+	_ = db.Close()
+}
+
 func TestMetadataDatabase_GenMetricID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer func() {
@@ -640,6 +686,77 @@ func TestMetadataDatabase_reopen(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestMetadataDatabase_Backup(t *testing.T) {
+	backupPath := filepath.Join(testPath, "backup")
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+
+	db, err := NewMetadataDatabase(context.TODO(), "test", filepath.Join(testPath, "db"))
+	assert.NoError(t, err)
+	metricID, err := db.GenMetricID("ns-1", "name1")
+	assert.NoError(t, err)
+	fieldID, err := db.GenFieldID("ns-1", "name1", "f", field.SumField)
+	assert.NoError(t, err)
+	assert.NoError(t, db.Backup(backupPath))
+	// metadata generated after backup not included
+	_, err = db.GenMetricID("ns-1", "name2")
+	assert.NoError(t, err)
+	assert.NoError(t, db.Close())
+
+	// open the copy, pending metadata of wal is recovered
+	backup, err := NewMetadataDatabase(context.TODO(), "test", backupPath)
+	assert.NoError(t, err)
+	id, err := backup.GetMetricID("ns-1", "name1")
+	assert.NoError(t, err)
+	assert.Equal(t, metricID, id)
+	f, err := backup.GetField("ns-1", "name1", "f")
+	assert.NoError(t, err)
+	assert.Equal(t, fieldID, f.ID)
+	_, err = backup.GetMetricID("ns-1", "name2")
+	assert.Error(t, err)
+	assert.NoError(t, backup.Close())
+}
+
+func TestMetadataDatabase_Backup_err(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		mkDir = fileutil.MkDirIfNotExist
+		copyDirFunc = fileutil.CopyDir
+		ctrl.Finish()
+	}()
+	db := newMockMetadataDatabase(t)
+	db1 := db.(*metadataDatabase)
+	backend := NewMockMetadataBackend(ctrl)
+	mockWAL := wal.NewMockMetricMetaWAL(ctrl)
+	db1.backend = backend
+	db1.metaWAL = mockWAL
+	// case 1: mkdir err
+	mkDir = func(path string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, db.Backup(testPath))
+	mkDir = fileutil.MkDirIfNotExist
+	// case 2: sync wal err
+	mockWAL.EXPECT().Sync().Return(fmt.Errorf("err"))
+	assert.Error(t, db.Backup(testPath))
+	// case 3: backup bbolt.DB err
+	mockWAL.EXPECT().Sync().Return(nil).AnyTimes()
+	backend.EXPECT().backup(gomock.Any()).Return(fmt.Errorf("err"))
+	assert.Error(t, db.Backup(testPath))
+	// case 4: copy wal err
+	backend.EXPECT().backup(gomock.Any()).Return(nil)
+	copyDirFunc = func(src, dst string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, db.Backup(testPath))
+
+	mockWAL.EXPECT().Close().Return(nil)
+	backend.EXPECT().Close().Return(nil)
+	assert.NoError(t, db.Close())
+}
+
 func TestMetadataDatabase_Sync(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer func() {
@@ -836,3 +953,107 @@ func newMockMetadataDatabase(t *testing.T) MetadataDatabase {
 
 	return db
 }
+
+func TestMetadataDatabase_MergeMetrics(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+
+	source, err := NewMetadataDatabase(context.TODO(), "test", filepath.Join(testPath, "source"))
+	assert.NoError(t, err)
+	_, err = source.GenMetricID("ns-1", "cpu")
+	assert.NoError(t, err)
+	_, err = source.GenFieldID("ns-1", "cpu", "f1", field.SumField)
+	assert.NoError(t, err)
+	_, err = source.GenTagKeyID("ns-1", "cpu", "host")
+	assert.NoError(t, err)
+	metrics, err := source.AllMetrics()
+	assert.NoError(t, err)
+	assert.Equal(t, []MetricMeta{{
+		Namespace: "ns-1", Name: "cpu", ID: 1,
+		Fields:  field.Metas{{ID: 1, Type: field.SumField, Name: "f1"}},
+		TagKeys: []tag.Meta{{Key: "host", ID: 1}},
+	}}, metrics)
+	assert.NoError(t, source.Close())
+
+	db, err := NewMetadataDatabase(context.TODO(), "test", filepath.Join(testPath, "db"))
+	assert.NoError(t, err)
+	// case 1: merge into empty database
+	assert.NoError(t, db.MergeMetrics(metrics))
+	// case 2: merge again
+	assert.NoError(t, db.MergeMetrics(metrics))
+	metricID, err := db.GetMetricID("ns-1", "cpu")
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1), metricID)
+	f, err := db.GetField("ns-1", "cpu", "f1")
+	assert.NoError(t, err)
+	assert.Equal(t, field.ID(1), f.ID)
+	tagKeyID, err := db.GetTagKeyID("ns-1", "cpu", "host")
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1), tagKeyID)
+	// case 3: new ids after merged sequences
+	metricID, err = db.GenMetricID("ns-1", "mem")
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(2), metricID)
+	_, err = db.GenMetricID("ns-1", "cpu")
+	assert.NoError(t, err)
+	fieldID, err := db.GenFieldID("ns-1", "cpu", "f2", field.SumField)
+	assert.NoError(t, err)
+	assert.Equal(t, field.ID(2), fieldID)
+	tagKeyID, err = db.GenTagKeyID("ns-1", "mem", "zone")
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(2), tagKeyID)
+
+	// case 4: conflicts, nothing merged
+	cases := []MetricMeta{
+		// metric name with different id
+		{Namespace: "ns-1", Name: "cpu", ID: 5},
+		// metric id used by other metric
+		{Namespace: "ns-1", Name: "disk", ID: 1},
+		// field with different id
+		{Namespace: "ns-1", Name: "cpu", ID: 1, Fields: field.Metas{{ID: 3, Type: field.SumField, Name: "f1"}}},
+		// field id used by other field
+		{Namespace: "ns-1", Name: "cpu", ID: 1, Fields: field.Metas{{ID: 1, Type: field.SumField, Name: "f3"}}},
+		// tag key with different id
+		{Namespace: "ns-1", Name: "cpu", ID: 1, TagKeys: []tag.Meta{{Key: "host", ID: 7}}},
+		// tag key id used by other tag key
+		{Namespace: "ns-1", Name: "cpu", ID: 1, TagKeys: []tag.Meta{{Key: "ip", ID: 2}}},
+	}
+	for _, c := range cases {
+		err = db.MergeMetrics([]MetricMeta{c, {Namespace: "ns-1", Name: "net", ID: 10}})
+		assert.True(t, errors.Is(err, constants.ErrMetadataConflict))
+	}
+	_, err = db.GetMetricID("ns-1", "net")
+	assert.Error(t, err)
+
+	// case 5: merge new field/tag key into cached metric
+	err = db.MergeMetrics([]MetricMeta{{
+		Namespace: "ns-1", Name: "cpu", ID: 1,
+		Fields:  field.Metas{{ID: 5, Type: field.SumField, Name: "f5"}},
+		TagKeys: []tag.Meta{{Key: "ip", ID: 5}},
+	}})
+	assert.NoError(t, err)
+	f, err = db.GetField("ns-1", "cpu", "f5")
+	assert.NoError(t, err)
+	assert.Equal(t, field.ID(5), f.ID)
+	fieldID, err = db.GenFieldID("ns-1", "cpu", "f6", field.SumField)
+	assert.NoError(t, err)
+	assert.Equal(t, field.ID(6), fieldID)
+	tagKeyID, err = db.GetTagKeyID("ns-1", "cpu", "ip")
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(5), tagKeyID)
+	assert.NoError(t, db.Close())
+
+	// case 6: merged metadata is persisted
+	db, err = NewMetadataDatabase(context.TODO(), "test", filepath.Join(testPath, "db"))
+	assert.NoError(t, err)
+	metrics, err = db.AllMetrics()
+	assert.NoError(t, err)
+	assert.Len(t, metrics, 2)
+	_, err = db.GenMetricID("ns-1", "mem")
+	assert.NoError(t, err)
+	tagKeyID, err = db.GenTagKeyID("ns-1", "mem", "region")
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(6), tagKeyID)
+	assert.NoError(t, db.Close())
+}
@@ -56,6 +56,8 @@ type MetricMetadata interface {
 	rollbackFieldID(fieldID field.ID)
 	// addField adds field meta
 	addField(f field.Meta)
+	// mergeField adds field meta with assigned field id, moves field id sequence forward if id is larger
+	mergeField(f field.Meta)
 	// createTagKey creates the tag key
 	createTagKey(tagKey string, tagKeyID uint32)
 }
@@ -103,11 +105,11 @@ func (mm *metricMetadata) getAllFields() (fields []field.Meta) {
 	return mm.fields
 }
 
-// getAllHistogramFields returns histogram buckets fields,
-// with format like __bucket_${boundary}
+// getAllHistogramFields returns histogram buckets fields and quantile sketch field,
+// with format like __bucket_${boundary}/__sketch
 func (mm *metricMetadata) getAllHistogramFields() (fields field.Metas) {
 	for idx := range mm.fields {
-		if mm.fields[idx].Type == field.HistogramField {
+		if fieldType := mm.fields[idx].Type; fieldType == field.HistogramField || fieldType == field.SketchField {
 			fields = append(fields, mm.fields[idx])
 		}
 	}
@@ -152,6 +154,14 @@ func (mm *metricMetadata) addField(f field.Meta) {
 	mm.fields = append(mm.fields, f)
 }
 
+// mergeField adds field meta with assigned field id, moves field id sequence forward if id is larger
+func (mm *metricMetadata) mergeField(f field.Meta) {
+	mm.fields = append(mm.fields, f)
+	if mm.fieldIDSeq.Load() < int32(f.ID) {
+		mm.fieldIDSeq.Store(int32(f.ID))
+	}
+}
+
 // checkTagKeyCount checks the tag keys if limit, if limit return series.ErrTooManyTagKeys
 func (mm *metricMetadata) checkTagKeyCount() error {
 	// check tag keys count
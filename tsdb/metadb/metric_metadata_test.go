@@ -124,3 +124,26 @@ func TestMetricMetadata_rollback(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, field.ID(2), fieldID)
 }
+
+func TestMetricMetadata_mergeField(t *testing.T) {
+	mm := newMetricMetadata(1, 2)
+	mm.mergeField(field.Meta{ID: 1, Type: field.SumField, Name: "f1"})
+	f, ok := mm.getField("f1")
+	assert.True(t, ok)
+	assert.Equal(t, field.ID(1), f.ID)
+	mm.mergeField(field.Meta{ID: 5, Type: field.SumField, Name: "f5"})
+	fieldID, err := mm.createField("f", field.SumField)
+	assert.NoError(t, err)
+	assert.Equal(t, field.ID(6), fieldID)
+}
+
+func TestMetricMetadata_getAllHistogramFields(t *testing.T) {
+	mm := newMetricMetadata(1, 0)
+	mm.addField(field.Meta{ID: 1, Name: "f", Type: field.SumField})
+	mm.addField(field.Meta{ID: 2, Name: "__bucket_1", Type: field.HistogramField})
+	mm.addField(field.Meta{ID: 3, Name: "__sketch", Type: field.SketchField})
+	fields := mm.getAllHistogramFields()
+	assert.Len(t, fields, 2)
+	assert.Equal(t, field.HistogramField, fields[0].Type)
+	assert.Equal(t, field.SketchField, fields[1].Type)
+}
@@ -33,6 +33,8 @@ type TagEntry interface {
 	genTagValueID() uint32
 	// getTagValueIDSeq returns the current tag value id sequence
 	getTagValueIDSeq() uint32
+	// updateTagValueIDSeq moves the tag value id sequence forward if given sequence is larger
+	updateTagValueIDSeq(tagValueSeq uint32)
 	// addTagValue adds tag value=>id mapping
 	addTagValue(tagValue string, tagValueID uint32)
 	// findSeriesIDsByExpr finds tag value ids by tag filter expr
@@ -72,6 +74,13 @@ func (t *tagEntry) getTagValueIDSeq() uint32 {
 	return t.tagValueSeq.Load()
 }
 
+// updateTagValueIDSeq moves the tag value id sequence forward if given sequence is larger
+func (t *tagEntry) updateTagValueIDSeq(tagValueSeq uint32) {
+	if t.tagValueSeq.Load() < tagValueSeq {
+		t.tagValueSeq.Store(tagValueSeq)
+	}
+}
+
 // addTagValue adds tag value=>id mapping
 func (t *tagEntry) addTagValue(tagValue string, tagValueID uint32) {
 	t.tagValues[tagValue] = tagValueID
@@ -37,6 +37,15 @@ func TestTagEntry_genTagValueID(t *testing.T) {
 	assert.Equal(t, uint32(101), tagEntry.getTagValueIDSeq())
 }
 
+func TestTagEntry_updateTagValueIDSeq(t *testing.T) {
+	tagEntry := newTagEntry(10)
+	tagEntry.updateTagValueIDSeq(5)
+	assert.Equal(t, uint32(10), tagEntry.getTagValueIDSeq())
+	tagEntry.updateTagValueIDSeq(20)
+	assert.Equal(t, uint32(20), tagEntry.getTagValueIDSeq())
+	assert.Equal(t, uint32(21), tagEntry.genTagValueID())
+}
+
 func TestTagEntry_getTagValueID(t *testing.T) {
 	tagIndex := prepareTagEntry()
 	id, ok := tagIndex.getTagValueID("abc")
@@ -19,6 +19,7 @@ package metadb
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 	"sync"
 
@@ -56,10 +57,21 @@ type TagMetadata interface {
 		tagValueIDs *roaring.Bitmap,
 		tagValues map[uint32]string,
 	) error
+	// GetTagValues returns all tag values with ids and the tag value id sequence of spec tag key
+	GetTagValues(tagKeyID uint32) (TagValues, error)
+	// MergeTagValues merges the tag values with same ids, nothing is merged if ids conflict,
+	// returns constants.ErrMetadataConflict if tag value has different id or id is used by other tag value.
+	MergeTagValues(tagValues map[uint32]TagValues) error
 	// Flush flushes the memory tag metadata into kv store
 	Flush() error
 }
 
+// TagValues represents all tag values with ids under the tag key.
+type TagValues struct {
+	Seq    uint32            // tag value id sequence
+	Values map[string]uint32 // tag value => tag value id
+}
+
 // tagMetadata implements TagMetadata interface
 type tagMetadata struct {
 	databaseName string
@@ -255,6 +267,109 @@ func (m *tagMetadata) CollectTagValues(tagKeyID uint32,
 	return nil
 }
 
+// GetTagValues returns all tag values with ids and the tag value id sequence of spec tag key
+func (m *tagMetadata) GetTagValues(tagKeyID uint32) (TagValues, error) {
+	m.rwMutex.RLock()
+	defer m.rwMutex.RUnlock()
+
+	return m.getTagValues(tagKeyID)
+}
+
+// MergeTagValues merges the tag values with same ids, nothing is merged if ids conflict,
+// returns constants.ErrMetadataConflict if tag value has different id or id is used by other tag value.
+func (m *tagMetadata) MergeTagValues(tagValues map[uint32]TagValues) error {
+	// block generating new tag value id
+	m.rwMutex.Lock()
+	defer m.rwMutex.Unlock()
+
+	pending := make(map[uint32]TagValues)
+	for tagKeyID, values := range tagValues {
+		exist, err := m.getTagValues(tagKeyID)
+		if err != nil {
+			return err
+		}
+		usedIDs := make(map[uint32]string, len(exist.Values))
+		for tagValue, tagValueID := range exist.Values {
+			usedIDs[tagValueID] = tagValue
+		}
+		merged := TagValues{Seq: exist.Seq, Values: make(map[string]uint32)}
+		for tagValue, tagValueID := range values.Values {
+			if existID, ok := exist.Values[tagValue]; ok {
+				if existID != tagValueID {
+					return fmt.Errorf("%w: tag value[%s] of tag key id %d, id %d not equals %d",
+						constants.ErrMetadataConflict, tagValue, tagKeyID, tagValueID, existID)
+				}
+				continue
+			}
+			if other, used := usedIDs[tagValueID]; used {
+				return fmt.Errorf("%w: tag value id %d of tag value[%s] is used by [%s], tag key id %d",
+					constants.ErrMetadataConflict, tagValueID, tagValue, other, tagKeyID)
+			}
+			usedIDs[tagValueID] = tagValue
+			merged.Values[tagValue] = tagValueID
+		}
+		if values.Seq > merged.Seq {
+			merged.Seq = values.Seq
+		}
+		if len(merged.Values) > 0 || merged.Seq > exist.Seq {
+			pending[tagKeyID] = merged
+		}
+	}
+	for tagKeyID, values := range pending {
+		tag, ok := m.mutable.Get(tagKeyID)
+		if !ok {
+			tag = newTagEntry(values.Seq)
+			m.mutable.Put(tagKeyID, tag)
+		}
+		tag.updateTagValueIDSeq(values.Seq)
+		for tagValue, tagValueID := range values.Values {
+			tag.addTagValue(tagValue, tagValueID)
+		}
+	}
+	return nil
+}
+
+// getTagValues returns all tag values with ids and the tag value id sequence in memory and kv store,
+// NOTE: must hold the lock of memory store.
+func (m *tagMetadata) getTagValues(tagKeyID uint32) (TagValues, error) {
+	result := TagValues{Values: make(map[string]uint32)}
+	collect := func(tagStore *TagStore) {
+		if tagStore == nil {
+			return
+		}
+		if tag, ok := tagStore.Get(tagKeyID); ok {
+			for tagValue, tagValueID := range tag.getTagValues() {
+				result.Values[tagValue] = tagValueID
+			}
+			if seq := tag.getTagValueIDSeq(); seq > result.Seq {
+				result.Seq = seq
+			}
+		}
+	}
+	collect(m.mutable)
+	collect(m.immutable)
+	err := m.loadTagValueIDsInKV(tagKeyID, func(reader tagkeymeta.Reader) error {
+		seq, err := reader.GetTagValueSeq(tagKeyID)
+		if err != nil {
+			if errors.Is(err, constants.ErrNotFound) {
+				return nil
+			}
+			return err
+		}
+		if seq > result.Seq {
+			result.Seq = seq
+		}
+		return reader.WalkTagValues(tagKeyID, "", func(tagValue []byte, tagValueID uint32) bool {
+			result.Values[string(tagValue)] = tagValueID
+			return true
+		})
+	})
+	if err != nil {
+		return TagValues{}, err
+	}
+	return result, nil
+}
+
 // Flush flushes the memory tag metadata into kv store
 func (m *tagMetadata) Flush() error {
 	if !m.checkFlush() {
@@ -18,6 +18,7 @@
 package metadb
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -355,3 +356,83 @@ func mockTagMetadataMemData(meta TagMetadata) {
 
 	m.rwMutex.Unlock()
 }
+
+func TestTagMetadata_GetTagValues(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		newTagReaderFunc = tagkeymeta.NewReader
+		ctrl.Finish()
+	}()
+
+	meta, _, snapshot := mockTagMetadata(ctrl)
+	mockTagMetadataMemData(meta)
+	tagReader := tagkeymeta.NewMockReader(ctrl)
+	newTagReaderFunc = func(readers []table.Reader) tagkeymeta.Reader {
+		return tagReader
+	}
+	// case 1: find readers err
+	snapshot.EXPECT().FindReaders(uint32(5)).Return(nil, fmt.Errorf("err"))
+	_, err := meta.GetTagValues(5)
+	assert.Error(t, err)
+	// case 2: only memory data
+	snapshot.EXPECT().FindReaders(uint32(5)).Return(nil, nil)
+	tagValues, err := meta.GetTagValues(5)
+	assert.NoError(t, err)
+	assert.Equal(t, TagValues{Seq: 10, Values: map[string]uint32{"tag-value-5": 10}}, tagValues)
+	// case 3: get tag value seq err
+	snapshot.EXPECT().FindReaders(gomock.Any()).Return([]table.Reader{table.NewMockReader(ctrl)}, nil).AnyTimes()
+	tagReader.EXPECT().GetTagValueSeq(uint32(5)).Return(uint32(0), fmt.Errorf("err"))
+	_, err = meta.GetTagValues(5)
+	assert.Error(t, err)
+	// case 4: tag key not found in kv store
+	tagReader.EXPECT().GetTagValueSeq(uint32(5)).Return(uint32(0), constants.ErrNotFound)
+	tagValues, err = meta.GetTagValues(5)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(10), tagValues.Seq)
+	// case 5: merge memory and kv store data
+	tagReader.EXPECT().GetTagValueSeq(uint32(5)).Return(uint32(30), nil)
+	tagReader.EXPECT().WalkTagValues(uint32(5), "", gomock.Any()).
+		DoAndReturn(func(tagKeyID uint32, prefix string, fn func(tagValue []byte, tagValueID uint32) bool) error {
+			fn([]byte("tag-value-30"), 30)
+			return nil
+		})
+	tagValues, err = meta.GetTagValues(5)
+	assert.NoError(t, err)
+	assert.Equal(t, TagValues{Seq: 30, Values: map[string]uint32{"tag-value-5": 10, "tag-value-30": 30}}, tagValues)
+}
+
+func TestTagMetadata_MergeTagValues(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	meta, _, snapshot := mockTagMetadata(ctrl)
+	mockTagMetadataMemData(meta)
+	snapshot.EXPECT().FindReaders(gomock.Any()).Return(nil, nil).AnyTimes()
+	// case 1: find readers err
+	meta2, _, snapshot2 := mockTagMetadata(ctrl)
+	snapshot2.EXPECT().FindReaders(gomock.Any()).Return(nil, fmt.Errorf("err"))
+	err := meta2.MergeTagValues(map[uint32]TagValues{5: {Seq: 1, Values: map[string]uint32{"a": 1}}})
+	assert.Error(t, err)
+	// case 2: tag value with different id
+	err = meta.MergeTagValues(map[uint32]TagValues{5: {Seq: 11, Values: map[string]uint32{"tag-value-5": 11}}})
+	assert.True(t, errors.Is(err, constants.ErrMetadataConflict))
+	// case 3: tag value id used by other tag value
+	err = meta.MergeTagValues(map[uint32]TagValues{5: {Seq: 10, Values: map[string]uint32{"tag-value-x": 10}}})
+	assert.True(t, errors.Is(err, constants.ErrMetadataConflict))
+	// case 4: merge success
+	err = meta.MergeTagValues(map[uint32]TagValues{
+		5: {Seq: 15, Values: map[string]uint32{"tag-value-5": 10, "tag-value-15": 15}},
+		8: {Seq: 3, Values: map[string]uint32{"tag-value-3": 3}},
+	})
+	assert.NoError(t, err)
+	tagValues, err := meta.GetTagValues(5)
+	assert.NoError(t, err)
+	assert.Equal(t, TagValues{Seq: 15, Values: map[string]uint32{"tag-value-5": 10, "tag-value-15": 15}}, tagValues)
+	tagValues, err = meta.GetTagValues(8)
+	assert.NoError(t, err)
+	assert.Equal(t, TagValues{Seq: 3, Values: map[string]uint32{"tag-value-3": 3}}, tagValues)
+	// case 5: new tag value id after merged sequence
+	tagValueID, err := meta.GenTagValueID(5, "tag-value-new")
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(16), tagValueID)
+}
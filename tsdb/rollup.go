@@ -0,0 +1,127 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"strconv"
+
+	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/timeutil"
+)
+
+// rollup implements kv.Rollup interface, rolls up the data of source segment into the segment of target interval,
+// the data family of source rolls up into the data family of target which family time belongs to,
+// the sketch family of source rolls up into the sketch family of target segment which base time belongs to.
+type rollup struct {
+	sourceInterval timeutil.Interval
+	targetInterval timeutil.Interval
+	// base time of source segment
+	baseTime int64
+	// start time of source data family, base time of segment for sketch family
+	familyTime int64
+	// sketch represents the rollup of sketch family
+	sketch bool
+	target IntervalSegment
+
+	logger *logger.Logger
+}
+
+// newRollup creates the rollup relation from source segment into target interval segment.
+func newRollup(sourceInterval timeutil.Interval, baseTime int64, target IntervalSegment) kv.Rollup {
+	return &rollup{
+		sourceInterval: sourceInterval,
+		targetInterval: target.getInterval(),
+		baseTime:       baseTime,
+		target:         target,
+		logger:         logger.GetLogger("tsdb", "Rollup"),
+	}
+}
+
+// ForFamily returns the rollup of source family, returns nil if the data of source family need not rollup,
+// data family and sketch family need rollup, family name of data family is family time.
+func (r *rollup) ForFamily(sourceFamilyName string) kv.Rollup {
+	familyRollup := *r
+	if sourceFamilyName == sketchFamilyName {
+		familyRollup.sketch = true
+		familyRollup.familyTime = r.baseTime
+		return &familyRollup
+	}
+	familyTime, err := strconv.Atoi(sourceFamilyName)
+	if err != nil {
+		return nil
+	}
+	familyRollup.familyTime = r.sourceInterval.Calculator().CalcFamilyStartTime(r.baseTime, familyTime)
+	return &familyRollup
+}
+
+// GetTimestamp returns the timestamp based on source family and source slot
+func (r *rollup) GetTimestamp(slot uint16) int64 {
+	return r.familyTime + int64(slot)*r.sourceInterval.Int64()
+}
+
+// IntervalRatio return interval ratio = target interval/source interval
+func (r *rollup) IntervalRatio() uint16 {
+	return uint16(r.targetInterval.Int64() / r.sourceInterval.Int64())
+}
+
+// CalcSlot calculates the target slot based on source timestamp
+func (r *rollup) CalcSlot(timestamp int64) uint16 {
+	calc := r.targetInterval.Calculator()
+	return uint16(calc.CalcSlot(timestamp, r.targetFamilyTime(timestamp), r.targetInterval.Int64()))
+}
+
+// TruncateTimestamp returns the start time of target slot which source timestamp belongs to
+func (r *rollup) TruncateTimestamp(timestamp int64) int64 {
+	return r.targetFamilyTime(timestamp) + int64(r.CalcSlot(timestamp))*r.targetInterval.Int64()
+}
+
+// GetTargetFamily returns the target family based on source family name, creates it if not exist,
+// returns nil if cannot get it(e.g. target segment expired).
+func (r *rollup) GetTargetFamily(sourceFamilyName string) kv.Family {
+	segmentName := r.targetInterval.Calculator().GetSegment(r.familyTime)
+	segment, err := r.target.GetOrCreateSegment(segmentName)
+	if err != nil {
+		r.logger.Error("get target segment of rollup error",
+			logger.String("segment", segmentName), logger.String("family", sourceFamilyName), logger.Error(err))
+		return nil
+	}
+	if r.sketch {
+		family, err := segment.GetOrCreateSketchFamily()
+		if err != nil {
+			r.logger.Error("get target sketch family of rollup error",
+				logger.String("segment", segmentName), logger.Error(err))
+			return nil
+		}
+		return family
+	}
+	family, err := segment.GetDataFamily(r.familyTime)
+	if err != nil {
+		r.logger.Error("get target data family of rollup error",
+			logger.String("segment", segmentName), logger.String("family", sourceFamilyName), logger.Error(err))
+		return nil
+	}
+	return family.Family()
+}
+
+// targetFamilyTime returns the start time of target family which timestamp belongs to
+func (r *rollup) targetFamilyTime(timestamp int64) int64 {
+	calc := r.targetInterval.Calculator()
+	segmentTime := calc.CalcSegmentTime(timestamp)
+	return calc.CalcFamilyStartTime(segmentTime, calc.CalcFamily(timestamp, segmentTime))
+}
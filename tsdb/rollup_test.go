@@ -0,0 +1,165 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/fileutil"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/series/field"
+	"github.com/lindb/lindb/tsdb/tblstore/metricsdata"
+)
+
+func TestRollup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	target := NewMockIntervalSegment(ctrl)
+	target.EXPECT().getInterval().Return(timeutil.Interval(5 * timeutil.OneMinute)).AnyTimes()
+	baseTime, _ := timeutil.ParseTimestamp("20190702 00:00:00", "20060102 15:04:05")
+	r := newRollup(timeutil.Interval(10*timeutil.OneSecond), baseTime, target)
+	// case 1: family need not rollup
+	assert.Nil(t, r.ForFamily(exemplarFamilyName))
+	// case 2: data family 01:00
+	r = r.ForFamily("1")
+	assert.NotNil(t, r)
+	familyTime := baseTime + timeutil.OneHour
+	assert.Equal(t, familyTime+10*timeutil.OneSecond, r.GetTimestamp(1))
+	assert.Equal(t, uint16(30), r.IntervalRatio())
+	// 01:00 is slot 12 of target family(day)
+	assert.Equal(t, uint16(12), r.CalcSlot(familyTime+timeutil.OneMinute))
+	assert.Equal(t, familyTime, r.TruncateTimestamp(familyTime+timeutil.OneMinute))
+	// case 3: get target segment err
+	target.EXPECT().GetOrCreateSegment("201907").Return(nil, fmt.Errorf("err"))
+	assert.Nil(t, r.GetTargetFamily("1"))
+	// case 4: get target data family err
+	segment := NewMockSegment(ctrl)
+	target.EXPECT().GetOrCreateSegment("201907").Return(segment, nil).AnyTimes()
+	segment.EXPECT().GetDataFamily(familyTime).Return(nil, fmt.Errorf("err"))
+	assert.Nil(t, r.GetTargetFamily("1"))
+	// case 5: get target data family
+	dataFamily := NewMockDataFamily(ctrl)
+	family := kv.NewMockFamily(ctrl)
+	segment.EXPECT().GetDataFamily(familyTime).Return(dataFamily, nil)
+	dataFamily.EXPECT().Family().Return(family)
+	assert.Equal(t, family, r.GetTargetFamily("1"))
+	// case 6: sketch family rolls up into sketch family of target segment
+	r = newRollup(timeutil.Interval(10*timeutil.OneSecond), baseTime, target).ForFamily(sketchFamilyName)
+	assert.NotNil(t, r)
+	assert.Equal(t, familyTime, r.TruncateTimestamp(familyTime+timeutil.OneMinute))
+	segment.EXPECT().GetOrCreateSketchFamily().Return(nil, fmt.Errorf("err"))
+	assert.Nil(t, r.GetTargetFamily(sketchFamilyName))
+	segment.EXPECT().GetOrCreateSketchFamily().Return(family, nil)
+	assert.Equal(t, family, r.GetTargetFamily(sketchFamilyName))
+}
+
+func TestRollup_intervalSegment(t *testing.T) {
+	defer func() {
+		newStore = kv.NewStore
+		_ = fileutil.RemoveDir(testPath)
+	}()
+	newStore = func(name string, option kv.StoreOption) (kv.Store, error) {
+		option.RollupCheckInterval = 1
+		return kv.NewStore(name, option)
+	}
+	daySegment, err := newIntervalSegment(timeutil.Interval(10*timeutil.OneSecond),
+		filepath.Join(testPath, timeutil.Day.String()), nil)
+	assert.NoError(t, err)
+	defer daySegment.Close()
+	monthSegment, err := newIntervalSegment(timeutil.Interval(5*timeutil.OneMinute),
+		filepath.Join(testPath, timeutil.Month.String()), nil)
+	assert.NoError(t, err)
+	defer monthSegment.Close()
+
+	now, _ := timeutil.ParseTimestamp("20190702 01:00:00", "20060102 15:04:05")
+	// segment created before registering rollup relation
+	_, err = daySegment.GetOrCreateSegment("20190701")
+	assert.NoError(t, err)
+	registerRollups(map[timeutil.IntervalType]IntervalSegment{
+		timeutil.Day:   daySegment,
+		timeutil.Month: monthSegment,
+	})
+	// segment created after registering rollup relation
+	segment, err := daySegment.GetOrCreateSegment("20190702")
+	assert.NoError(t, err)
+	dataFamily, err := segment.GetDataFamily(now)
+	assert.NoError(t, err)
+	// write raw data into family of 01:00, slot 0~2 with value 10, default rollup threshold is 3
+	for slot := uint16(0); slot < 3; slot++ {
+		flusher, err := metricsdata.NewFlusher(dataFamily.Family().NewFlusher())
+		assert.NoError(t, err)
+		flusher.PrepareMetric(10, field.Metas{{ID: 1, Type: field.SumField}})
+		encoder := encoding.NewTSDEncoder(slot)
+		encoder.AppendTime(true)
+		encoder.AppendValue(math.Float64bits(10))
+		data, err := encoder.BytesWithoutTime()
+		assert.NoError(t, err)
+		assert.NoError(t, flusher.FlushField(data))
+		assert.NoError(t, flusher.FlushSeries(1))
+		assert.NoError(t, flusher.CommitMetric(timeutil.SlotRange{Start: slot, End: slot}))
+		assert.NoError(t, flusher.Close())
+	}
+	// read rollup data from target interval, 01:00 is slot 12 of day family of month segment
+	readRollup := func() (float64, bool) {
+		dataFamilies := monthSegment.getDataFamilies(timeutil.TimeRange{Start: now, End: now})
+		if len(dataFamilies) != 1 {
+			return 0, false
+		}
+		snapshot := dataFamilies[0].Family().GetSnapshot()
+		defer snapshot.Close()
+		readers, err := snapshot.FindReaders(10)
+		if err != nil || len(readers) != 1 {
+			return 0, false
+		}
+		block, err := readers[0].Get(10)
+		if err != nil {
+			return 0, false
+		}
+		reader, err := metricsdata.NewReader("rollup", block)
+		if err != nil {
+			return 0, false
+		}
+		_, fields := reader.Load(0, reader.GetSeriesIDs().GetContainer(0), reader.GetFields()).Load(1)
+		if len(fields) != 1 {
+			return 0, false
+		}
+		decoder := encoding.NewTSDDecoder(nil)
+		decoder.ResetWithTimeRange(fields[0], 12, 12)
+		if !decoder.HasValueWithSlot(12) {
+			return 0, false
+		}
+		return math.Float64frombits(decoder.Value()), true
+	}
+	assert.Eventually(t, func() bool {
+		value, ok := readRollup()
+		return ok && value == 30
+	}, 10*time.Second, 100*time.Millisecond)
+	// rollup relation cannot be changed
+	daySegment.registerRollup(daySegment)
+	assert.Equal(t, monthSegment, daySegment.(*intervalSegment).rollupTarget)
+}
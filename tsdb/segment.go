@@ -24,9 +24,13 @@ import (
 
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/kv/table"
 	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/option"
 	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/tsdb/tblstore/exemplar"
 	"github.com/lindb/lindb/tsdb/tblstore/metricsdata"
+	"github.com/lindb/lindb/tsdb/tblstore/sketch"
 )
 
 //go:generate mockgen -source=./segment.go -destination=./segment_mock.go -package=tsdb
@@ -36,6 +40,13 @@ var (
 	newStore = kv.NewStore
 )
 
+const (
+	// exemplarFamilyName is the family name of exemplars in segment, data family name is family time.
+	exemplarFamilyName = "exemplar"
+	// sketchFamilyName is the family name of quantile sketches in segment.
+	sketchFamilyName = "sketch"
+)
+
 // Segment represents a time based segment, there are some segments in a interval segment.
 // A segment use k/v store for storing time series data.
 type Segment interface {
@@ -43,10 +54,29 @@ type Segment interface {
 	BaseTime() int64
 	// GetDataFamily returns the data family based on timestamp
 	GetDataFamily(timestamp int64) (DataFamily, error)
+	// GetOrCreateExemplarFamily returns the exemplar family of segment, creates it if not exist
+	GetOrCreateExemplarFamily() (kv.Family, error)
+	// GetOrCreateSketchFamily returns the sketch family of segment, creates it if not exist
+	GetOrCreateSketchFamily() (kv.Family, error)
 	// Close closes segment, include kv store
 	Close()
+	// Backup creates a point-in-time copy of segment's kv store into target path
+	Backup(targetPath string) error
 	// getDataFamilies returns data family list by time range, return nil if not match
 	getDataFamilies(timeRange timeutil.TimeRange) []DataFamily
+	// getAllDataFamilies returns all data families of segment
+	getAllDataFamilies() []DataFamily
+	// getExemplarFamily returns the exemplar family of segment, returns nil if not exist
+	getExemplarFamily() kv.Family
+	// getSketchFamily returns the sketch family of segment, returns nil if not exist
+	getSketchFamily() kv.Family
+	// inUse returns if any data family is still referenced by snapshot(query/compact etc.)
+	inUse() bool
+	// fenceCompaction waits the running compaction/rollup jobs of all families completed,
+	// then blocks new jobs until the returned release function is invoked.
+	fenceCompaction() (release func())
+	// registerRollup registers the rollup relation, rolls up the data of segment into target interval segment
+	registerRollup(target IntervalSegment)
 }
 
 // segment implements Segment interface
@@ -55,6 +85,12 @@ type segment struct {
 	kvStore  kv.Store
 	interval timeutil.Interval
 	families sync.Map
+	// exemplars of segment, so that exemplars are expired/moved with segment
+	exemplarFamily kv.Family
+	// quantile sketches of segment, expired/moved with segment like exemplars
+	sketchFamily kv.Family
+	// compression returns the compression option of the families created by segment
+	compression func() option.CompressionOption
 
 	mutex sync.Mutex
 
@@ -66,6 +102,7 @@ func newSegment(
 	segmentName string,
 	interval timeutil.Interval,
 	path string,
+	compression func() option.CompressionOption,
 ) (
 	Segment,
 	error,
@@ -82,12 +119,21 @@ func newSegment(
 	}
 	familyNames := kvStore.ListFamilyNames()
 	s := &segment{
-		baseTime: baseTime,
-		kvStore:  kvStore,
-		interval: interval,
-		logger:   logger.GetLogger("tsdb", "Segment"),
+		baseTime:    baseTime,
+		kvStore:     kvStore,
+		interval:    interval,
+		compression: compression,
+		logger:      logger.GetLogger("tsdb", "Segment"),
 	}
 	for _, familyName := range familyNames {
+		switch familyName {
+		case exemplarFamilyName:
+			s.exemplarFamily = kvStore.GetFamily(familyName)
+			continue
+		case sketchFamilyName:
+			s.sketchFamily = kvStore.GetFamily(familyName)
+			continue
+		}
 		familyTime, err := strconv.Atoi(familyName)
 		if err != nil {
 			return nil, fmt.Errorf("load data family error:%s", err)
@@ -124,6 +170,18 @@ func (s *segment) getDataFamilies(timeRange timeutil.TimeRange) []DataFamily {
 	return result
 }
 
+// getAllDataFamilies returns all data families of segment
+func (s *segment) getAllDataFamilies() []DataFamily {
+	var result []DataFamily
+	s.families.Range(func(k, v interface{}) bool {
+		if family, ok := v.(DataFamily); ok {
+			result = append(result, family)
+		}
+		return true
+	})
+	return result
+}
+
 // GetDataFamily returns the data family based on timestamp
 func (s *segment) GetDataFamily(timestamp int64) (DataFamily, error) {
 	calc := s.interval.Calculator()
@@ -145,6 +203,7 @@ func (s *segment) GetDataFamily(timestamp int64) (DataFamily, error) {
 			familyOption := kv.FamilyOption{
 				CompactThreshold: 0,
 				Merger:           string(metricsdata.MetricDataMerger),
+				Compression:      compressionOf(s.getCompression().Data),
 			}
 			// create kv family
 			f, err := s.kvStore.CreateFamily(fmt.Sprintf("%d", familyTime), familyOption)
@@ -163,6 +222,114 @@ func (s *segment) GetDataFamily(timestamp int64) (DataFamily, error) {
 	return f, nil
 }
 
+// GetOrCreateExemplarFamily returns the exemplar family of segment, creates it if not exist
+func (s *segment) GetOrCreateExemplarFamily() (kv.Family, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.exemplarFamily != nil {
+		return s.exemplarFamily, nil
+	}
+	f, err := s.kvStore.CreateFamily(exemplarFamilyName, kv.FamilyOption{
+		CompactThreshold: 0,
+		Merger:           string(exemplar.MergerName),
+		Compression:      compressionOf(s.getCompression().Exemplar),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create exemplar family: %s", err)
+	}
+	s.exemplarFamily = f
+	return f, nil
+}
+
+// getExemplarFamily returns the exemplar family of segment, returns nil if not exist
+func (s *segment) getExemplarFamily() kv.Family {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.exemplarFamily
+}
+
+// GetOrCreateSketchFamily returns the sketch family of segment, creates it if not exist
+func (s *segment) GetOrCreateSketchFamily() (kv.Family, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.sketchFamily != nil {
+		return s.sketchFamily, nil
+	}
+	f, err := s.kvStore.CreateFamily(sketchFamilyName, kv.FamilyOption{
+		CompactThreshold: 0,
+		Merger:           string(sketch.MergerName),
+		Compression:      compressionOf(s.getCompression().Sketch),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sketch family: %s", err)
+	}
+	s.sketchFamily = f
+	return f, nil
+}
+
+// getSketchFamily returns the sketch family of segment, returns nil if not exist
+func (s *segment) getSketchFamily() kv.Family {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.sketchFamily
+}
+
+// inUse returns if any data family is still referenced by snapshot(query/compact etc.)
+func (s *segment) inUse() bool {
+	if f := s.getExemplarFamily(); f != nil && f.InUse() {
+		return true
+	}
+	if f := s.getSketchFamily(); f != nil && f.InUse() {
+		return true
+	}
+	used := false
+	s.families.Range(func(k, v interface{}) bool {
+		family, ok := v.(DataFamily)
+		if ok && family.Family().InUse() {
+			used = true
+			return false
+		}
+		return true
+	})
+	return used
+}
+
+// fenceCompaction waits the running compaction/rollup jobs of all families completed,
+// then blocks new jobs until the returned release function is invoked.
+func (s *segment) fenceCompaction() (release func()) {
+	var families []kv.Family
+	if f := s.getExemplarFamily(); f != nil {
+		families = append(families, f)
+	}
+	if f := s.getSketchFamily(); f != nil {
+		families = append(families, f)
+	}
+	s.families.Range(func(k, v interface{}) bool {
+		if family, ok := v.(DataFamily); ok {
+			families = append(families, family.Family())
+		}
+		return true
+	})
+	releases := make([]func(), len(families))
+	for idx, f := range families {
+		releases[idx] = f.FenceCompaction()
+	}
+	return func() {
+		for _, release := range releases {
+			release()
+		}
+	}
+}
+
+// registerRollup registers the rollup relation, rolls up the data of segment into target interval segment
+func (s *segment) registerRollup(target IntervalSegment) {
+	s.kvStore.RegisterRollup(target.getInterval(), newRollup(s.interval, s.baseTime, target))
+}
+
 // Close closes segment, include kv store
 func (s *segment) Close() {
 	if err := s.kvStore.Close(); err != nil {
@@ -170,6 +337,11 @@ func (s *segment) Close() {
 	}
 }
 
+// Backup creates a point-in-time copy of segment's kv store into target path
+func (s *segment) Backup(targetPath string) error {
+	return s.kvStore.Backup(targetPath)
+}
+
 func (s *segment) initDataFamily(familyTime int, family kv.Family) DataFamily {
 	calc := s.interval.Calculator()
 	// create data family
@@ -181,3 +353,20 @@ func (s *segment) initDataFamily(familyTime int, family kv.Family) DataFamily {
 	s.families.Store(familyTime, dataFamily)
 	return dataFamily
 }
+
+// getCompression returns the compression option of the families created by segment
+func (s *segment) getCompression() option.CompressionOption {
+	if s.compression == nil {
+		return option.CompressionOption{}
+	}
+	return s.compression()
+}
+
+// compressionOf returns the configured compression of family, returns no compression if not configured,
+// so that the files written by existing database can be read after rolling back.
+func compressionOf(compression string) string {
+	if compression == "" {
+		return table.NoCompression.String()
+	}
+	return compression
+}
@@ -22,13 +22,16 @@ import (
 	"fmt"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/kv/table"
 	"github.com/lindb/lindb/pkg/fileutil"
+	"github.com/lindb/lindb/pkg/option"
 	"github.com/lindb/lindb/pkg/timeutil"
 )
 
@@ -38,7 +41,7 @@ func TestSegment_Close(t *testing.T) {
 	defer func() {
 		_ = fileutil.RemoveDir(testPath)
 	}()
-	s, _ := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath)
+	s, _ := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath, nil)
 	seg, _ := s.GetOrCreateSegment("20190702")
 	seg1 := seg.(*segment)
 
@@ -55,7 +58,7 @@ func TestSegment_GetDataFamily(t *testing.T) {
 	defer func() {
 		_ = fileutil.RemoveDir(testPath)
 	}()
-	s, _ := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath)
+	s, _ := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath, nil)
 	seg, _ := s.GetOrCreateSegment("20190904")
 	now, _ := timeutil.ParseTimestamp("20190904 19:10:48", "20060102 15:04:05")
 	familyBaseTime, _ := timeutil.ParseTimestamp("20190904 19:00:00", "20060102 15:04:05")
@@ -104,7 +107,7 @@ func TestSegment_New(t *testing.T) {
 	defer func() {
 		_ = fileutil.RemoveDir(testPath)
 	}()
-	s, err := newSegment("20190904", timeutil.Interval(timeutil.OneSecond*10), testPath)
+	s, err := newSegment("20190904", timeutil.Interval(timeutil.OneSecond*10), testPath, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, s)
 	now, _ := timeutil.ParseTimestamp("20190904 19:10:40", "20060102 15:04:05")
@@ -114,7 +117,7 @@ func TestSegment_New(t *testing.T) {
 	s.Close()
 
 	// reopen
-	s, err = newSegment("20190904", timeutil.Interval(timeutil.OneSecond*10), testPath)
+	s, err = newSegment("20190904", timeutil.Interval(timeutil.OneSecond*10), testPath, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, s)
 	f, err = s.GetDataFamily(now)
@@ -122,7 +125,7 @@ func TestSegment_New(t *testing.T) {
 	assert.NotNil(t, f)
 
 	// cannot reopen
-	s2, err := newSegment("20190904", timeutil.Interval(timeutil.OneSecond*10), testPath)
+	s2, err := newSegment("20190904", timeutil.Interval(timeutil.OneSecond*10), testPath, nil)
 	assert.Error(t, err)
 	assert.Nil(t, s2)
 
@@ -143,7 +146,178 @@ func TestSegment_loadFamily_err(t *testing.T) {
 		return kvStore, nil
 	}
 	kvStore.EXPECT().ListFamilyNames().Return([]string{"abc"})
-	s, err := newSegment("20190904", timeutil.Interval(timeutil.OneSecond*10), testPath)
+	s, err := newSegment("20190904", timeutil.Interval(timeutil.OneSecond*10), testPath, nil)
 	assert.Error(t, err)
 	assert.Nil(t, s)
 }
+
+func TestSegment_ExemplarFamily(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		ctrl.Finish()
+	}()
+	s, err := newSegment("20190904", timeutil.Interval(timeutil.OneSecond*10), testPath, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, s.getExemplarFamily())
+	now, _ := timeutil.ParseTimestamp("20190904 19:10:40", "20060102 15:04:05")
+	_, err = s.GetDataFamily(now)
+	assert.NoError(t, err)
+	family, err := s.GetOrCreateExemplarFamily()
+	assert.NoError(t, err)
+	assert.NotNil(t, family)
+	family1, err := s.GetOrCreateExemplarFamily()
+	assert.NoError(t, err)
+	assert.Equal(t, family, family1)
+	// exemplar family is used by snapshot
+	snapshot := family.GetSnapshot()
+	assert.True(t, s.inUse())
+	snapshot.Close()
+	assert.False(t, s.inUse())
+	s.Close()
+
+	// reopen, load data family and exemplar family
+	s, err = newSegment("20190904", timeutil.Interval(timeutil.OneSecond*10), testPath, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, s.getExemplarFamily())
+	assert.Len(t, s.getAllDataFamilies(), 1)
+	s.Close()
+
+	// create exemplar family err
+	s, err = newSegment("20190905", timeutil.Interval(timeutil.OneSecond*10), testPath+"2", nil)
+	assert.NoError(t, err)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath + "2")
+	}()
+	store := kv.NewMockStore(ctrl)
+	kvStore := s.(*segment).kvStore
+	s.(*segment).kvStore = store
+	store.EXPECT().CreateFamily(exemplarFamilyName, gomock.Any()).Return(nil, fmt.Errorf("err"))
+	family, err = s.GetOrCreateExemplarFamily()
+	assert.Error(t, err)
+	assert.Nil(t, family)
+	s.(*segment).kvStore = kvStore
+	s.Close()
+}
+
+func TestSegment_SketchFamily(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		ctrl.Finish()
+	}()
+	s, err := newSegment("20190904", timeutil.Interval(timeutil.OneSecond*10), testPath, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, s.getSketchFamily())
+	family, err := s.GetOrCreateSketchFamily()
+	assert.NoError(t, err)
+	assert.NotNil(t, family)
+	family1, err := s.GetOrCreateSketchFamily()
+	assert.NoError(t, err)
+	assert.Equal(t, family, family1)
+	// sketch family is used by snapshot
+	snapshot := family.GetSnapshot()
+	assert.True(t, s.inUse())
+	snapshot.Close()
+	assert.False(t, s.inUse())
+	s.Close()
+
+	// reopen, load sketch family
+	s, err = newSegment("20190904", timeutil.Interval(timeutil.OneSecond*10), testPath, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, s.getSketchFamily())
+	assert.Empty(t, s.getAllDataFamilies())
+
+	// create sketch family err
+	store := kv.NewMockStore(ctrl)
+	kvStore := s.(*segment).kvStore
+	s.(*segment).kvStore = store
+	s.(*segment).sketchFamily = nil
+	store.EXPECT().CreateFamily(sketchFamilyName, gomock.Any()).Return(nil, fmt.Errorf("err"))
+	family, err = s.GetOrCreateSketchFamily()
+	assert.Error(t, err)
+	assert.Nil(t, family)
+	s.(*segment).kvStore = kvStore
+	s.Close()
+}
+
+func TestSegment_fenceCompaction(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+	s, err := newSegment("20190904", timeutil.Interval(timeutil.OneSecond*10), testPath, nil)
+	assert.NoError(t, err)
+	// no families
+	release := s.fenceCompaction()
+	release()
+
+	familyTime, _ := timeutil.ParseTimestamp("20190904 19:10:48", "20060102 15:04:05")
+	dataFamily, err := s.GetDataFamily(familyTime)
+	assert.NoError(t, err)
+	exemplarFamily, err := s.GetOrCreateExemplarFamily()
+	assert.NoError(t, err)
+	sketchFamily, err := s.GetOrCreateSketchFamily()
+	assert.NoError(t, err)
+	release = s.fenceCompaction()
+	fenced := make(chan struct{})
+	go func() {
+		// fence of each family waits the segment released
+		for _, f := range []kv.Family{dataFamily.Family(), exemplarFamily, sketchFamily} {
+			f.FenceCompaction()()
+		}
+		close(fenced)
+	}()
+	select {
+	case <-fenced:
+		assert.Fail(t, "families should be fenced by segment")
+	case <-time.After(50 * time.Millisecond):
+	}
+	release()
+	<-fenced
+	s.Close()
+}
+
+func TestSegment_compression(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		newStore = kv.NewStore
+		ctrl.Finish()
+	}()
+	kvStore := kv.NewMockStore(ctrl)
+	newStore = func(name string, option kv.StoreOption) (store kv.Store, e error) {
+		return kvStore, nil
+	}
+	kvStore.EXPECT().ListFamilyNames().Return(nil).AnyTimes()
+	now, _ := timeutil.ParseTimestamp("20190904 19:10:40", "20060102 15:04:05")
+	createFamily := func(expect string) {
+		kvStore.EXPECT().CreateFamily(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ string, option kv.FamilyOption) (kv.Family, error) {
+				assert.Equal(t, expect, option.Compression)
+				return nil, fmt.Errorf("err")
+			})
+	}
+	// case 1: no compression by default
+	s, err := newSegment("20190904", timeutil.Interval(timeutil.OneSecond*10), testPath, nil)
+	assert.NoError(t, err)
+	createFamily(table.NoCompression.String())
+	_, _ = s.GetDataFamily(now)
+	createFamily(table.NoCompression.String())
+	_, _ = s.GetOrCreateExemplarFamily()
+	createFamily(table.NoCompression.String())
+	_, _ = s.GetOrCreateSketchFamily()
+	// case 2: compression of database option
+	compression := option.CompressionOption{Data: "snappy", Exemplar: "none", Sketch: "zstd"}
+	s, err = newSegment("20190904", timeutil.Interval(timeutil.OneSecond*10), testPath,
+		func() option.CompressionOption { return compression })
+	assert.NoError(t, err)
+	createFamily("snappy")
+	_, _ = s.GetDataFamily(now)
+	createFamily("none")
+	_, _ = s.GetOrCreateExemplarFamily()
+	createFamily("zstd")
+	_, _ = s.GetOrCreateSketchFamily()
+	// case 3: altered compression is applied to new family
+	compression.Data = "zstd"
+	createFamily("zstd")
+	_, _ = s.GetDataFamily(now)
+}
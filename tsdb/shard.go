@@ -19,9 +19,11 @@ package tsdb
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"sync"
 	"time"
@@ -34,18 +36,23 @@ import (
 	"github.com/lindb/lindb/flow"
 	"github.com/lindb/lindb/internal/linmetric"
 	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/kv/table"
 	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/fileutil"
 	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/pkg/ltoml"
 	"github.com/lindb/lindb/pkg/option"
 	"github.com/lindb/lindb/pkg/queue"
 	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/proto/gen/v1/flatMetricsV1"
 	"github.com/lindb/lindb/series/field"
 	"github.com/lindb/lindb/series/metric"
 	"github.com/lindb/lindb/tsdb/indexdb"
 	"github.com/lindb/lindb/tsdb/memdb"
 	"github.com/lindb/lindb/tsdb/metadb"
+	"github.com/lindb/lindb/tsdb/tblstore/exemplar"
 	"github.com/lindb/lindb/tsdb/tblstore/metricsdata"
+	"github.com/lindb/lindb/tsdb/tblstore/sketch"
 	"github.com/lindb/lindb/tsdb/tblstore/tagindex"
 )
 
@@ -60,6 +67,9 @@ var (
 	newMemoryDBFunc        = memdb.NewMemoryDatabase
 )
 
+// cumulativeStateTTL is the max idle duration of cumulative sum field state, default 1 hour.
+var cumulativeStateTTL = timeutil.OneHour
+
 var (
 	shardScope             = linmetric.NewScope("lindb.tsdb.shard")
 	writeMetricFailuresVec = shardScope.NewCounterVec("write_metric_failures", "db", "shard")
@@ -70,6 +80,7 @@ var (
 	memdbNumberVec         = shardScope.NewGaugeVec("memdb_number", "db", "shard")
 	memFlushTimerVec       = shardScope.Scope("memdb_flush_duration").NewHistogramVec("db", "shard")
 	indexFlushTimerVec     = shardScope.Scope("indexdb_flush_duration").NewHistogramVec("db", "shard")
+	seriesGCCollectedVec   = shardScope.NewCounterVec("series_gc_collected", "db", "shard")
 )
 
 const (
@@ -80,6 +91,7 @@ const (
 	invertedIndexDir = "inverted"
 	metaDir          = "meta"
 	tempDir          = "temp"
+	cumulativeFile   = "cumulative"
 )
 
 // Shard is a horizontal partition of metrics for LinDB.
@@ -100,6 +112,30 @@ type Shard interface {
 	IndexDatabase() indexdb.IndexDatabase
 	// WriteRows writes metric rows with same family in batch
 	WriteRows(familyTime int64, rows []metric.StorageRow) error
+	// FindExemplars returns the exemplars of metric which match the series ids and time range
+	FindExemplars(
+		metricID uint32,
+		fields field.Metas,
+		seriesIDs *roaring.Bitmap,
+		timeRange timeutil.TimeRange,
+	) ([]exemplar.Exemplar, error)
+	// FindSketches returns the quantile sketches of metric which match the series ids and time range
+	// from the segment of interval type, the sketches of same series/slot are merged.
+	FindSketches(
+		intervalType timeutil.IntervalType,
+		metricID uint32,
+		seriesIDs *roaring.Bitmap,
+		timeRange timeutil.TimeRange,
+	) ([]sketch.Sketch, error)
+	// DeleteSeries deletes the data of series in time range, then removes the series from index
+	// which have no data left after deleting.
+	DeleteSeries(namespace, metricName string, seriesIDs *roaring.Bitmap, timeRange timeutil.TimeRange) error
+	// GCSeries removes the series which have no data in any retained family from index,
+	// returns the number of collected series.
+	GCSeries() (collected int, err error)
+	// RebuildIndex rebuilds the lost series id mapping and index of the series which have data,
+	// the rows of source(e.g. replica write ahead log) are used for rebuilding if source not nil.
+	RebuildIndex(source RowSource) (*IndexRebuildReport, error)
 	// GetOrCreateSequence gets the replica sequence by given remote peer if exist, else creates a new sequence
 	GetOrCreateSequence(replicaPeer string) (queue.Sequence, error)
 	// MemDBTotalSize returns the total size of mutable and immutable memdb
@@ -110,6 +146,24 @@ type Shard interface {
 	NeedFlush() bool
 	// IsFlushing checks if this shard is in flushing
 	IsFlushing() bool
+	// ExpireData closes and removes the segments which are out of the data retention(ttl)
+	ExpireData()
+	// MoveSegments moves the segments whose data are all older than the age of storage tier into the tier
+	MoveSegments()
+	// ValidateOption validates if the changed database option can be applied to running shard.
+	ValidateOption(option option.DatabaseOption) error
+	// AlterOption applies the changed database option to running shard,
+	// creates the interval segments for new rollup intervals.
+	AlterOption(option option.DatabaseOption) error
+	// Backup creates a point-in-time copy of shard into target path, the layout of copy is same as shard path
+	Backup(targetPath string) error
+	// ApplyReplica applies the replica message with sequence by apply func, records the sequence as applied,
+	// snapshot waits the applying message completed.
+	ApplyReplica(sequence int64, apply func())
+	// Snapshot writes a point-in-time copy of shard with the metadata of database into target path,
+	// the layout of snapshot is same as database backup which only includes this shard,
+	// returns the sequence of replica message applied last, all messages before it are included by snapshot.
+	Snapshot(targetPath string) (int64, error)
 	// initIndexDatabase initializes index database
 	initIndexDatabase() error
 	// Closer releases shard's resource, such as flush data, spawned goroutines etc.
@@ -126,12 +180,15 @@ type Shard interface {
 //    xx/shard/1/meta/
 //    xx/shard/1/index/inverted/
 //    xx/shard/1/data/20191012/
+//    xx/shard/1/data/20191012/exemplar/
 //    xx/shard/1/data/20191013/
 type shard struct {
+	db           Database
 	databaseName string
 	id           models.ShardID
 	path         string
 	option       option.DatabaseOption
+	optionLock   sync.RWMutex // lock for option/segments/ttls which can be altered online
 	sequence     ReplicaSequence
 
 	mutex    sync.Mutex     // mutex for update families
@@ -146,13 +203,21 @@ type shard struct {
 	segments       map[timeutil.IntervalType]IntervalSegment
 	segment        IntervalSegment // smallest interval for writing data
 	isFlushing     atomic.Bool     // restrict flusher concurrency
-	flushCondition sync.WaitGroup  // flush condition
+	flushLock      sync.Mutex      // serializes flush jobs(flush/flush all/close/move segments)
+	// ttls keeps the data retention of each interval, 0 means keeping data forever
+	ttls map[timeutil.Interval]int64
 
 	indexStore     kv.Store  // kv stores
 	forwardFamily  kv.Family // forward store
 	invertedFamily kv.Family // inverted store
+	exemplars      *exemplar.Buffer
+	sketches       *sketch.Buffer
+	cumulative     memdb.CumulativeStore // last seen state of cumulative sum fields
 	logger         *logger.Logger
 
+	replicaLock       sync.Mutex // fences applying replica message when taking snapshot or collecting series
+	appliedReplicaSeq int64      // sequence of replica message applied last
+
 	statistics struct {
 		writeBatches        *linmetric.BoundCounter
 		writeMetrics        *linmetric.BoundCounter
@@ -162,6 +227,7 @@ type shard struct {
 		memdbNumber         *linmetric.BoundGauge
 		memFlushTimer       *linmetric.BoundHistogram
 		indexFlushTimer     *linmetric.BoundHistogram
+		seriesGCCollected   *linmetric.BoundCounter
 	}
 }
 
@@ -179,6 +245,10 @@ func newShard(
 	}
 	var interval timeutil.Interval
 	_ = interval.ValueOf(option.Interval)
+	ttls, err := retentions(option)
+	if err != nil {
+		return nil, err
+	}
 
 	if err := mkDirIfNotExist(shardPath); err != nil {
 		return nil, err
@@ -187,7 +257,12 @@ func newShard(
 	if err != nil {
 		return nil, err
 	}
+	cumulative, err := memdb.NewCumulativeStore(filepath.Join(shardPath, cumulativeFile))
+	if err != nil {
+		return nil, err
+	}
 	createdShard := &shard{
+		db:           db,
 		databaseName: db.Name(),
 		id:           shardID,
 		path:         shardPath,
@@ -197,8 +272,14 @@ func newShard(
 		metadata:     db.Metadata(),
 		interval:     interval,
 		segments:     make(map[timeutil.IntervalType]IntervalSegment),
+		ttls:         ttls,
 		isFlushing:   *atomic.NewBool(false),
+		exemplars:    exemplar.NewBuffer(),
+		sketches:     sketch.NewBuffer(),
+		cumulative:   cumulative,
 		logger:       logger.GetLogger("tsdb", "Shard"),
+
+		appliedReplicaSeq: -1,
 	}
 	// initialize metrics
 	shardIDStr := strconv.Itoa(int(shardID))
@@ -210,11 +291,13 @@ func newShard(
 	createdShard.statistics.memdbNumber = memdbNumberVec.WithTagValues(db.Name(), shardIDStr)
 	createdShard.statistics.memFlushTimer = memFlushTimerVec.WithTagValues(db.Name(), shardIDStr)
 	createdShard.statistics.indexFlushTimer = indexFlushTimerVec.WithTagValues(db.Name(), shardIDStr)
+	createdShard.statistics.seriesGCCollected = seriesGCCollectedVec.WithTagValues(db.Name(), shardIDStr)
 
 	// new segment for writing
 	createdShard.segment, err = newIntervalSegmentFunc(
 		interval,
-		filepath.Join(shardPath, segmentDir, interval.Type().String()))
+		filepath.Join(shardPath, segmentDir, interval.Type().String()),
+		createdShard.getCompression)
 
 	if err != nil {
 		return nil, err
@@ -233,6 +316,11 @@ func newShard(
 				logger.String("shard", createdShard.path), logger.Error(err))
 		}
 	}()
+	// new segments for rollup intervals
+	if createdShard.segments, err = createdShard.newRollupSegments(option); err != nil {
+		return nil, err
+	}
+	registerRollups(createdShard.segments)
 	if err = createdShard.initIndexDatabase(); err != nil {
 		return nil, fmt.Errorf("create index database for shard[%d] error: %s", shardID, err)
 	}
@@ -260,11 +348,68 @@ func (s *shard) GetOrCreateSequence(replicaPeer string) (queue.Sequence, error)
 func (s *shard) IndexDatabase() indexdb.IndexDatabase { return s.indexDB }
 
 func (s *shard) GetDataFamilies(intervalType timeutil.IntervalType, timeRange timeutil.TimeRange) []DataFamily {
-	segment, ok := s.segments[intervalType]
-	if ok {
-		return segment.getDataFamilies(timeRange)
+	segment, timeRange, ok := s.getIntervalSegment(intervalType, timeRange)
+	if !ok {
+		return nil
+	}
+	return segment.getDataFamilies(timeRange)
+}
+
+// getIntervalSegment returns the interval segment of interval type and the time range which is not expired,
+// returns false if segment not exist or all time range is expired.
+func (s *shard) getIntervalSegment(
+	intervalType timeutil.IntervalType,
+	timeRange timeutil.TimeRange,
+) (IntervalSegment, timeutil.TimeRange, bool) {
+	segment, ok := s.getSegments()[intervalType]
+	if !ok {
+		return nil, timeRange, false
+	}
+	// exclude the expired time range
+	if ttl, ok := s.getTTL(intervalType); ok {
+		expireTime := timeutil.Now() - ttl
+		if timeRange.Start < expireTime {
+			timeRange.Start = expireTime
+		}
+		if timeRange.Start > timeRange.End {
+			return nil, timeRange, false
+		}
+	}
+	return segment, timeRange, true
+}
+
+// ExpireData closes and removes the segments which are out of the data retention(ttl)
+func (s *shard) ExpireData() {
+	now := timeutil.Now()
+	for intervalType, segment := range s.getSegments() {
+		if ttl, ok := s.getTTL(intervalType); ok {
+			segment.expireSegments(now - ttl)
+		}
+	}
+	// remove the state of cumulative sum fields which are not written for a long time
+	s.cumulative.Evict(now - cumulativeStateTTL)
+}
+
+// MoveSegments moves the segments whose data are all older than the age of storage tier into the tier,
+// holds flush lock so that memory database/exemplars/sketches cannot be flushed into the moving segments.
+func (s *shard) MoveSegments() {
+	s.flushLock.Lock()
+	defer s.flushLock.Unlock()
+
+	now := timeutil.Now()
+	for intervalType, segment := range s.getSegments() {
+		before := now
+		if intervalType == s.interval.Type() {
+			// keep the segments which memory databases will be flushed into
+			entries := s.families.Entries()
+			for idx := range entries {
+				if entries[idx].familyTime < before {
+					before = entries[idx].familyTime
+				}
+			}
+		}
+		segment.moveSegments(now, before)
 	}
-	return nil
 }
 
 // GetOrCreateMemoryDatabase returns memory database by given family time.
@@ -355,24 +500,41 @@ func (s *shard) lookupRowMeta(row *metric.StorageRow) (err error) {
 			row.SeriesID)
 	}
 	// set field id
+	gaugeRollupAggregates := s.getOption().GaugeRollupAggregates
 	simpleFieldItr := row.NewSimpleFieldIterator()
 	var fieldID field.ID
 	for simpleFieldItr.HasNext() {
+		fieldName := simpleFieldItr.NextName()
+		fieldType := simpleFieldItr.NextType()
 		if fieldID, err = s.metadata.MetadataDatabase().GenFieldID(
 			namespace, metricName,
-			simpleFieldItr.NextName(),
-			simpleFieldItr.NextType()); err != nil {
+			fieldName,
+			fieldType); err != nil {
 			return err
 		}
 		row.FieldIDs = append(row.FieldIDs, fieldID)
+		if fieldType != field.GaugeField || !gaugeRollupAggregates {
+			continue
+		}
+		// keep min/max/sum/count of gauge, because gauge only keeps last value after rollup
+		for _, aggField := range field.GaugeAggFields {
+			if fieldID, err = s.metadata.MetadataDatabase().GenFieldID(
+				namespace, metricName, aggField.Name(fieldName), aggField.Type); err != nil {
+				return err
+			}
+			row.GaugeAggFieldIDs = append(row.GaugeAggFieldIDs, fieldID)
+		}
 	}
 
+	var hasSketch bool
 	compoundFieldItr, ok := row.NewCompoundFieldIterator()
 	if !ok {
 		goto Done
 	}
+	// min/max of quantile sketch are always kept, because sketch only keeps the relative accuracy
+	hasSketch = compoundFieldItr.HasSketch()
 	// min
-	if compoundFieldItr.Min() > 0 {
+	if compoundFieldItr.Min() > 0 || hasSketch {
 		if fieldID, err = s.metadata.MetadataDatabase().GenFieldID(
 			namespace, metricName, compoundFieldItr.HistogramMinFieldName(), field.MinField); err != nil {
 			return err
@@ -380,7 +542,7 @@ func (s *shard) lookupRowMeta(row *metric.StorageRow) (err error) {
 		row.FieldIDs = append(row.FieldIDs, fieldID)
 	}
 	// max
-	if compoundFieldItr.Max() > 0 {
+	if compoundFieldItr.Max() > 0 || hasSketch {
 		if fieldID, err = s.metadata.MetadataDatabase().GenFieldID(
 			namespace, metricName, compoundFieldItr.HistogramMaxFieldName(), field.MaxField); err != nil {
 			return err
@@ -408,6 +570,18 @@ func (s *shard) lookupRowMeta(row *metric.StorageRow) (err error) {
 		}
 		row.FieldIDs = append(row.FieldIDs, fieldID)
 	}
+	// quantile sketch, only one field whose value is the count of sketch, sketch is buffered in shard
+	if hasSketch {
+		if _, err = compoundFieldItr.Sketch(); err != nil {
+			return err
+		}
+		if fieldID, err = s.metadata.MetadataDatabase().GenFieldID(
+			namespace, metricName,
+			compoundFieldItr.SketchFieldName(), field.SketchField); err != nil {
+			return err
+		}
+		row.FieldIDs = append(row.FieldIDs, fieldID)
+	}
 
 Done:
 	row.Writable = true
@@ -424,11 +598,17 @@ func (s *shard) WriteRows(familyTime int64, rows []metric.StorageRow) error {
 			s.logger.Error("failed to lookup meta of row", logger.Error(err))
 			continue
 		}
+		if !s.convertCumulativeFields(&rows[idx]) {
+			rows[idx].Writable = false
+			continue
+		}
 		rows[idx].SlotIndex = uint16(intervalCalc.CalcSlot(
 			rows[idx].Timestamp(),
 			familyTime,
 			s.interval.Int64()),
 		)
+		s.bufferExemplars(familyTime, &rows[idx])
+		s.bufferSketch(familyTime, &rows[idx])
 	}
 	db, err := s.GetOrCreateMemoryDatabase(familyTime)
 	if err != nil {
@@ -460,9 +640,394 @@ func (s *shard) WriteRows(familyTime int64, rows []metric.StorageRow) error {
 	return nil
 }
 
+// convertCumulativeFields converts the value of cumulative sum fields into delta value in place,
+// skips the field if delta cannot be calculated(first point or out of order point),
+// returns false if the value of field cannot be converted, then the point is rejected.
+func (s *shard) convertCumulativeFields(row *metric.StorageRow) bool {
+	simpleFieldItr := row.NewSimpleFieldIterator()
+	for idx := 0; simpleFieldItr.HasNext(); idx++ {
+		if simpleFieldItr.NextRawType() != flatMetricsV1.SimpleFieldTypeCumulativeSum {
+			continue
+		}
+		value := simpleFieldItr.NextValue()
+		delta, ok := s.cumulative.Delta(row.MetricID, row.SeriesID, row.FieldIDs[idx], row.Timestamp(), value)
+		if !ok {
+			row.SkippedFields = append(row.SkippedFields, idx)
+			continue
+		}
+		if delta == value {
+			continue
+		}
+		// mutate fails if value is omitted by flat buffer(zero value)
+		if !simpleFieldItr.MutateNextValue(delta) {
+			s.logger.Error("failed to convert cumulative sum field into delta, reject the point",
+				logger.String("database", s.databaseName),
+				logger.Any("shardID", s.id),
+				logger.Any("metricID", row.MetricID),
+				logger.Any("seriesID", row.SeriesID))
+			return false
+		}
+	}
+	return true
+}
+
+// bufferExemplars buffers the exemplars of row's fields, which belong to the slot of row.
+func (s *shard) bufferExemplars(familyTime int64, row *metric.StorageRow) {
+	var e metric.Exemplar
+	slotTime := familyTime + int64(row.SlotIndex)*s.interval.Int64()
+	add := func(fieldName string) {
+		s.exemplars.Add(row.MetricID, exemplar.Exemplar{
+			SeriesID:  row.SeriesID,
+			Timestamp: slotTime,
+			Field:     fieldName,
+			TraceID:   append([]byte(nil), e.TraceID...),
+			SpanID:    append([]byte(nil), e.SpanID...),
+			Duration:  e.Duration,
+		})
+	}
+	simpleFieldItr := row.NewSimpleFieldIterator()
+	for simpleFieldItr.HasNext() {
+		for idx := 0; idx < simpleFieldItr.NextExemplarsLen(); idx++ {
+			simpleFieldItr.NextExemplar(idx, &e)
+			add(string(simpleFieldItr.NextName()))
+		}
+	}
+	if compoundFieldItr, ok := row.NewCompoundFieldIterator(); ok {
+		for idx := 0; idx < compoundFieldItr.ExemplarsLen(); idx++ {
+			compoundFieldItr.Exemplar(idx, &e)
+			add(exemplar.CompoundFieldName)
+		}
+	}
+}
+
+// bufferSketch buffers the quantile sketch of row's compound field, which belongs to the slot of row.
+func (s *shard) bufferSketch(familyTime int64, row *metric.StorageRow) {
+	compoundFieldItr, ok := row.NewCompoundFieldIterator()
+	if !ok || !compoundFieldItr.HasSketch() {
+		return
+	}
+	// sketch is validated when looking up row meta
+	data, err := compoundFieldItr.Sketch()
+	if err != nil {
+		return
+	}
+	slotTime := familyTime + int64(row.SlotIndex)*s.interval.Int64()
+	s.sketches.Add(row.MetricID, row.SeriesID, slotTime, data)
+}
+
+// FindSketches returns the quantile sketches of metric which match the series ids and time range
+// from the segment of interval type, includes the flushed sketches in kv store, and the buffered
+// sketches in memory if interval type is the type of write interval(buffered sketches are not rolled up).
+func (s *shard) FindSketches(
+	intervalType timeutil.IntervalType,
+	metricID uint32,
+	seriesIDs *roaring.Bitmap,
+	timeRange timeutil.TimeRange,
+) ([]sketch.Sketch, error) {
+	intervalSegment, timeRange, ok := s.getIntervalSegment(intervalType, timeRange)
+	if !ok {
+		return nil, nil
+	}
+	var rs []sketch.Sketch
+	if intervalType == s.interval.Type() {
+		rs = s.sketches.Find(metricID, seriesIDs, timeRange)
+	}
+	for _, segment := range intervalSegment.getSegments(timeRange) {
+		family := segment.getSketchFamily()
+		if family == nil {
+			continue
+		}
+		snapshot := family.GetSnapshot()
+		flushed, err := sketch.Read(snapshot, metricID, seriesIDs, timeRange)
+		snapshot.Close()
+		if err != nil {
+			return nil, err
+		}
+		rs = append(rs, flushed...)
+	}
+	return sketch.Merge(rs), nil
+}
+
+// FindExemplars returns the exemplars of metric which match the series ids and time range,
+// includes the buffered exemplars in memory and the flushed exemplars in kv store.
+func (s *shard) FindExemplars(
+	metricID uint32,
+	fields field.Metas,
+	seriesIDs *roaring.Bitmap,
+	timeRange timeutil.TimeRange,
+) ([]exemplar.Exemplar, error) {
+	fieldNames := make(map[string]struct{}, len(fields))
+	for idx := range fields {
+		fieldNames[string(fields[idx].Name)] = struct{}{}
+		if fields[idx].Type == field.HistogramField || fields[idx].Type == field.SketchField {
+			// exemplars of compound field are stored with the same field name
+			fieldNames[exemplar.CompoundFieldName] = struct{}{}
+		}
+	}
+	rs := s.exemplars.Find(metricID, fieldNames, seriesIDs, timeRange)
+	for _, segment := range s.segment.getSegments(timeRange) {
+		family := segment.getExemplarFamily()
+		if family == nil {
+			continue
+		}
+		flushed, err := s.readExemplars(family, metricID, fieldNames, seriesIDs, timeRange)
+		if err != nil {
+			return nil, err
+		}
+		rs = append(rs, flushed...)
+	}
+	return exemplar.Bound(rs), nil
+}
+
+// readExemplars reads the flushed exemplars of metric from exemplar family of segment.
+func (s *shard) readExemplars(
+	family kv.Family,
+	metricID uint32,
+	fields map[string]struct{},
+	seriesIDs *roaring.Bitmap,
+	timeRange timeutil.TimeRange,
+) ([]exemplar.Exemplar, error) {
+	snapshot := family.GetSnapshot()
+	defer snapshot.Close()
+
+	return exemplar.Read(snapshot, metricID, fields, seriesIDs, timeRange)
+}
+
+// DeleteSeries deletes the data of series in time range, flushes all memory databases first,
+// then writes tombstone into the data families which overlap the time range,
+// then removes the series which have no data left from index database.
+// NOTICE: tombstone only deletes the data written before deleting, the data written while deleting may be kept.
+func (s *shard) DeleteSeries(
+	namespace, metricName string,
+	seriesIDs *roaring.Bitmap,
+	timeRange timeutil.TimeRange,
+) error {
+	if seriesIDs == nil || seriesIDs.IsEmpty() {
+		return nil
+	}
+	metadataDB := s.metadata.MetadataDatabase()
+	metricID, err := metadataDB.GetMetricID(namespace, metricName)
+	if err != nil {
+		return err
+	}
+	// hold flush lock, make sure no new file flushed before tombstone written
+	s.flushLock.Lock()
+	defer s.flushLock.Unlock()
+
+	if err := s.flushAllLocked(); err != nil {
+		return err
+	}
+	remaining := roaring.New()
+	for _, segment := range s.getSegments() {
+		for _, family := range segment.getAllDataFamilies() {
+			if timeRange.Overlap(family.TimeRange()) {
+				if err := family.DeleteSeries(metricID, seriesIDs, timeRange); err != nil {
+					return err
+				}
+			}
+			seriesIDsWithData, err := family.GetSeriesIDsWithData(metricID)
+			if err != nil {
+				return err
+			}
+			remaining.Or(seriesIDsWithData)
+		}
+	}
+	if err := s.deleteExemplarsAndSketches(metricID, seriesIDs, timeRange); err != nil {
+		return err
+	}
+	// the data of memory database written while deleting will be kept after flushing
+	fields, err := metadataDB.GetAllFields(namespace, metricName)
+	if err != nil {
+		return err
+	}
+	if len(fields) > 0 {
+		calc := s.interval.Calculator()
+		entries := s.families.Entries()
+		for idx := range entries {
+			familyTime := entries[idx].familyTime
+			familyRange := timeutil.TimeRange{Start: familyTime, End: calc.CalcFamilyEndTime(familyTime)}
+			// ignore not found error
+			resultSet, _ := entries[idx].memDB.Filter(metricID, seriesIDs, familyRange, fields)
+			for _, rs := range resultSet {
+				remaining.Or(rs.SeriesIDs())
+			}
+		}
+	}
+	deletedSeriesIDs := roaring.AndNot(seriesIDs, remaining)
+	if deletedSeriesIDs.IsEmpty() {
+		return nil
+	}
+	tagKeys, err := metadataDB.GetAllTagKeys(namespace, metricName)
+	if err != nil {
+		return err
+	}
+	tagKeyIDs := make([]uint32, len(tagKeys))
+	for idx := range tagKeys {
+		tagKeyIDs[idx] = tagKeys[idx].ID
+	}
+	return s.indexDB.DeleteSeries(metricID, tagKeyIDs, deletedSeriesIDs)
+}
+
+// deleteExemplarsAndSketches writes tombstone into the exemplar/sketch families of segments
+// which overlap the time range, tombstone only deletes the exemplars/sketches flushed before deleting.
+func (s *shard) deleteExemplarsAndSketches(metricID uint32, seriesIDs *roaring.Bitmap, timeRange timeutil.TimeRange) error {
+	// timestamp of exemplar/sketch is the start time of slot
+	timeRange.Start -= timeRange.Start % s.interval.Int64()
+	for _, segment := range s.segment.getSegments(timeRange) {
+		for _, family := range []kv.Family{segment.getExemplarFamily(), segment.getSketchFamily()} {
+			if family == nil {
+				continue
+			}
+			if err := writeTombstone(family, metricID, seriesIDs, timeRange); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeTombstone writes the tombstone of series into exemplar/sketch family,
+// tombstone's version is the max file number of family when deleting.
+func writeTombstone(
+	family kv.Family,
+	metricID uint32,
+	seriesIDs *roaring.Bitmap,
+	timeRange timeutil.TimeRange,
+) error {
+	// fence compaction, make sure the files of current version not be merged into new file before tombstone written
+	release := family.FenceCompaction()
+	defer release()
+
+	snapshot := family.GetSnapshot()
+	var version table.FileNumber
+	for _, fileMeta := range snapshot.GetCurrent().GetAllFiles() {
+		if fileMeta.GetFileNumber() > version {
+			version = fileMeta.GetFileNumber()
+		}
+	}
+	snapshot.Close()
+	if version == 0 {
+		// no exemplar/sketch need to delete
+		return nil
+	}
+	block, err := exemplar.EncodeTombstones(exemplar.Tombstones{{
+		SeriesIDs: seriesIDs,
+		TimeRange: timeRange,
+		Version:   version,
+	}})
+	if err != nil {
+		return err
+	}
+	flusher := family.NewFlusher()
+	if err := flusher.Add(metricID, block); err != nil {
+		return err
+	}
+	return flusher.Commit()
+}
+
+// GCSeries removes the series which have no data in any retained family from index,
+// reclaims the series limit budget of metric, returns the number of collected series.
+// NOTICE: the data of series which is written while collecting may be lost.
+func (s *shard) GCSeries() (collected int, err error) {
+	metricIDs, err := s.indexDB.GetMetricIDs()
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		s.statistics.seriesGCCollected.Add(float64(collected))
+	}()
+	for _, metricID := range metricIDs {
+		n, err := s.gcMetricSeries(metricID)
+		if err != nil {
+			return collected, err
+		}
+		collected += n
+	}
+	return collected, nil
+}
+
+// gcMetricSeries removes the series of metric which have no data from index,
+// returns the number of collected series.
+func (s *shard) gcMetricSeries(metricID uint32) (int, error) {
+	seriesIDs, err := s.indexDB.GetSeriesIDsByMetricID(metricID)
+	if err != nil {
+		if errors.Is(err, constants.ErrNotFound) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	if seriesIDs.IsEmpty() {
+		return 0, nil
+	}
+	staleSeriesIDs, err := s.findStaleSeries(metricID, seriesIDs)
+	if err != nil || staleSeriesIDs.IsEmpty() {
+		return 0, err
+	}
+	// rows are written when applying replica message, re-check the stale series under replica lock,
+	// so that the series created or written after first check are kept.
+	s.replicaLock.Lock()
+	defer s.replicaLock.Unlock()
+
+	staleSeriesIDs, err = s.findStaleSeries(metricID, staleSeriesIDs)
+	if err != nil || staleSeriesIDs.IsEmpty() {
+		return 0, err
+	}
+	metadataDB := s.metadata.MetadataDatabase()
+	tagKeys, err := metadataDB.GetAllTagKeysByMetricID(metricID)
+	if err != nil {
+		return 0, err
+	}
+	tagKeyIDs := make([]uint32, len(tagKeys))
+	for idx := range tagKeys {
+		tagKeyIDs[idx] = tagKeys[idx].ID
+	}
+	if err := s.indexDB.DeleteSeries(metricID, tagKeyIDs, staleSeriesIDs); err != nil {
+		return 0, err
+	}
+	return int(staleSeriesIDs.GetCardinality()), nil
+}
+
+// findStaleSeries returns the series which have no data in memory database and data family.
+func (s *shard) findStaleSeries(metricID uint32, seriesIDs *roaring.Bitmap) (*roaring.Bitmap, error) {
+	fields, err := s.metadata.MetadataDatabase().GetAllFieldsByMetricID(metricID)
+	if err != nil {
+		// cannot check the data of memory database without fields, keep the series
+		if errors.Is(err, constants.ErrNotFound) {
+			return roaring.New(), nil
+		}
+		return nil, err
+	}
+	remaining := roaring.New()
+	// check memory database first, because the data of memory database will be flushed into data family
+	if len(fields) > 0 {
+		calc := s.interval.Calculator()
+		entries := s.families.Entries()
+		for idx := range entries {
+			familyTime := entries[idx].familyTime
+			familyRange := timeutil.TimeRange{Start: familyTime, End: calc.CalcFamilyEndTime(familyTime)}
+			// ignore not found error
+			resultSet, _ := entries[idx].memDB.Filter(metricID, seriesIDs, familyRange, fields)
+			for _, rs := range resultSet {
+				remaining.Or(rs.SeriesIDs())
+			}
+		}
+	}
+	for _, segment := range s.getSegments() {
+		for _, family := range segment.getAllDataFamilies() {
+			seriesIDsWithData, err := family.GetSeriesIDsWithData(metricID)
+			if err != nil {
+				return nil, err
+			}
+			remaining.Or(seriesIDsWithData)
+		}
+	}
+	return roaring.AndNot(seriesIDs, remaining), nil
+}
+
 func (s *shard) Close() error {
 	// wait previous flush job completed
-	s.flushCondition.Wait()
+	s.flushLock.Lock()
+	defer s.flushLock.Unlock()
 
 	GetShardManager().RemoveShard(s)
 	if s.indexDB != nil {
@@ -470,6 +1035,15 @@ func (s *shard) Close() error {
 			return err
 		}
 	}
+	if err := s.flushExemplars(); err != nil {
+		return err
+	}
+	if err := s.flushSketches(); err != nil {
+		return err
+	}
+	if err := s.cumulative.Persist(); err != nil {
+		return err
+	}
 	if s.indexStore != nil {
 		if err := s.indexStore.Close(); err != nil {
 			return err
@@ -480,6 +1054,9 @@ func (s *shard) Close() error {
 			return err
 		}
 	}
+	for _, segment := range s.getSegments() {
+		segment.Close()
+	}
 	s.ackReplicaSeq()
 	return s.sequence.Close()
 }
@@ -582,32 +1159,13 @@ func (s *shard) Flush() (err error) {
 	if !s.isFlushing.CAS(false, true) {
 		return nil
 	}
-	// 1. mark flush job doing
-	s.flushCondition.Add(1)
-
-	defer func() {
-		//TODO add commit kv meta after ack successfully
-		// mark flush job complete, notify
-		s.flushCondition.Done()
-		s.isFlushing.Store(false)
-	}()
+	defer s.isFlushing.Store(false)
+	// serializes with flush all/close
+	s.flushLock.Lock()
+	defer s.flushLock.Unlock()
 
-	startTime := time.Now()
-	//FIXME stone1100
-	// index flush
-	if s.indexDB != nil {
-		if err = s.indexDB.Flush(); err != nil {
-			s.logger.Error("failed to flush indexDB ",
-				logger.Any("shardID", s.id),
-				logger.String("database", s.databaseName),
-				logger.Error(err))
-			return err
-		}
-		s.logger.Info("flush indexDB successfully",
-			logger.Any("shardID", s.id),
-			logger.String("database", s.databaseName),
-		)
-		s.statistics.indexFlushTimer.UpdateSince(startTime)
+	if err = s.flushIndex(); err != nil {
+		return err
 	}
 
 	var waitingFlushMemDB memdb.MemoryDatabase
@@ -634,7 +1192,7 @@ func (s *shard) Flush() (err error) {
 		return nil
 	}
 
-	startTime = time.Now()
+	startTime := time.Now()
 	if err := s.flushMemoryDatabase(waitingFlushMemDB); err != nil {
 		s.logger.Error("failed to flush memdb",
 			logger.Any("shardID", s.id),
@@ -662,6 +1220,163 @@ func (s *shard) Flush() (err error) {
 	return nil
 }
 
+// Backup creates a point-in-time copy of shard into target path, the layout of copy is same as shard path.
+// 1. flushes memory databases and index database;
+// 2. copies series id mapping of index database;
+// 3. copies kv stores of index and all interval segments, and state of cumulative sum fields.
+// NOTE: replica sequence is not included, replication starts again after restoring.
+func (s *shard) Backup(targetPath string) error {
+	if err := s.flushAll(); err != nil {
+		return err
+	}
+	if err := s.indexDB.Backup(filepath.Join(targetPath, metaDir)); err != nil {
+		return fmt.Errorf("backup index database of shard[%d] error: %s", s.id, err)
+	}
+	if err := s.indexStore.Backup(filepath.Join(targetPath, indexParentDir)); err != nil {
+		return fmt.Errorf("backup index store of shard[%d] error: %s", s.id, err)
+	}
+	for intervalType, segment := range s.getSegments() {
+		if err := segment.Backup(filepath.Join(targetPath, segmentDir, intervalType.String())); err != nil {
+			return fmt.Errorf("backup %s segment of shard[%d] error: %s", intervalType, s.id, err)
+		}
+	}
+	if stateFile := filepath.Join(s.path, cumulativeFile); fileutil.Exist(stateFile) {
+		if err := mkDirIfNotExist(targetPath); err != nil {
+			return err
+		}
+		if err := fileutil.CopyFile(stateFile, filepath.Join(targetPath, cumulativeFile)); err != nil {
+			return fmt.Errorf("backup cumulative state of shard[%d] error: %s", s.id, err)
+		}
+	}
+	s.logger.Info("backup shard successfully",
+		logger.Any("shardID", s.id),
+		logger.String("database", s.databaseName),
+		logger.String("target", targetPath))
+	return nil
+}
+
+// ApplyReplica applies the replica message with sequence by apply func, records the sequence as applied,
+// snapshot waits the applying message completed.
+func (s *shard) ApplyReplica(sequence int64, apply func()) {
+	s.replicaLock.Lock()
+	defer s.replicaLock.Unlock()
+
+	apply()
+	s.appliedReplicaSeq = sequence
+}
+
+// Snapshot writes a point-in-time copy of shard with the metadata of database into target path,
+// the shard ids of database in snapshot only includes this shard, the snapshot is restored as database backup.
+// 1. fences applying replica message, records the sequence applied last and flushes all memory data;
+// 2. writes the copy after fence, the data of messages after the sequence maybe included by snapshot.
+// NOTE: the old data in target path will be removed.
+func (s *shard) Snapshot(targetPath string) (int64, error) {
+	s.replicaLock.Lock()
+	appliedSeq := s.appliedReplicaSeq
+	err := s.flushAll()
+	s.replicaLock.Unlock()
+
+	if err != nil {
+		return 0, err
+	}
+	if appliedSeq < 0 {
+		return 0, fmt.Errorf("no replica message applied into shard[%d] of database[%s]", s.id, s.databaseName)
+	}
+	if err := removeDir(targetPath); err != nil {
+		return 0, err
+	}
+	if err := s.db.Backup(targetPath, s.id); err != nil {
+		return 0, err
+	}
+	return appliedSeq, nil
+}
+
+// flushAll flushes index database and all memory databases which have data before invoking,
+// waits the running flush job completed first.
+func (s *shard) flushAll() error {
+	s.flushLock.Lock()
+	defer s.flushLock.Unlock()
+
+	return s.flushAllLocked()
+}
+
+// flushAllLocked flushes index database and all memory databases, the caller must hold the flush lock.
+func (s *shard) flushAllLocked() error {
+	s.isFlushing.Store(true)
+	defer s.isFlushing.Store(false)
+
+	if err := s.flushIndex(); err != nil {
+		return err
+	}
+	// switch all mutable memory databases to immutable, new writes go to new memory databases
+	s.mutex.Lock()
+	for _, entry := range s.families.MutableEntries() {
+		if entry.memDB.MemSize() > 0 {
+			s.families.SetFamilyImmutable(entry.familyTime)
+		}
+	}
+	s.mutex.Unlock()
+
+	for _, entry := range s.families.ImmutableEntries() {
+		if err := s.flushMemoryDatabase(entry.memDB); err != nil {
+			s.logger.Error("failed to flush memdb",
+				logger.Any("shardID", s.id),
+				logger.String("database", s.databaseName),
+				logger.Int64("familyTime", entry.familyTime),
+				logger.Error(err))
+			return err
+		}
+		s.mutex.Lock()
+		s.families.RemoveHeadImmutable()
+		s.mutex.Unlock()
+	}
+	s.ackReplicaSeq()
+	return nil
+}
+
+// flushIndex flushes index database, buffered exemplars and sketches, persists state of cumulative sum fields
+func (s *shard) flushIndex() error {
+	startTime := time.Now()
+	//FIXME stone1100
+	// index flush
+	if s.indexDB != nil {
+		if err := s.indexDB.Flush(); err != nil {
+			s.logger.Error("failed to flush indexDB ",
+				logger.Any("shardID", s.id),
+				logger.String("database", s.databaseName),
+				logger.Error(err))
+			return err
+		}
+		s.logger.Info("flush indexDB successfully",
+			logger.Any("shardID", s.id),
+			logger.String("database", s.databaseName),
+		)
+		s.statistics.indexFlushTimer.UpdateSince(startTime)
+	}
+	if err := s.flushExemplars(); err != nil {
+		s.logger.Error("failed to flush exemplars",
+			logger.Any("shardID", s.id),
+			logger.String("database", s.databaseName),
+			logger.Error(err))
+		return err
+	}
+	if err := s.flushSketches(); err != nil {
+		s.logger.Error("failed to flush sketches",
+			logger.Any("shardID", s.id),
+			logger.String("database", s.databaseName),
+			logger.Error(err))
+		return err
+	}
+	if err := s.cumulative.Persist(); err != nil {
+		s.logger.Error("failed to persist state of cumulative sum fields",
+			logger.Any("shardID", s.id),
+			logger.String("database", s.databaseName),
+			logger.Error(err))
+		return err
+	}
+	return nil
+}
+
 // initIndexDatabase initializes the index database
 func (s *shard) initIndexDatabase() error {
 	var err error
@@ -674,7 +1389,8 @@ func (s *shard) initIndexDatabase() error {
 		forwardIndexDir,
 		kv.FamilyOption{
 			CompactThreshold: 0,
-			Merger:           string(tagindex.SeriesForwardMerger)})
+			Merger:           string(tagindex.SeriesForwardMerger),
+			Compression:      compressionOf(s.getCompression().Index)})
 	if err != nil {
 		return err
 	}
@@ -682,7 +1398,8 @@ func (s *shard) initIndexDatabase() error {
 		invertedIndexDir,
 		kv.FamilyOption{
 			CompactThreshold: 0,
-			Merger:           string(tagindex.SeriesInvertedMerger)})
+			Merger:           string(tagindex.SeriesInvertedMerger),
+			Compression:      compressionOf(s.getCompression().Index)})
 	if err != nil {
 		return err
 	}
@@ -697,6 +1414,60 @@ func (s *shard) initIndexDatabase() error {
 	return nil
 }
 
+// flushExemplars flushes the buffered exemplars into the exemplar family of segment which exemplar belongs to,
+// drops the exemplars of expired segment.
+func (s *shard) flushExemplars() error {
+	if s.exemplars.IsEmpty() {
+		return nil
+	}
+	calc := s.interval.Calculator()
+	return s.exemplars.FlushTo(calc.CalcSegmentTime, func(segmentTime int64) (kv.Flusher, error) {
+		segment, err := s.segment.GetOrCreateSegment(calc.GetSegment(segmentTime))
+		if err != nil {
+			if errors.Is(err, constants.ErrSegmentExpired) {
+				s.logger.Warn("drop exemplars of expired segment",
+					logger.Any("shardID", s.id),
+					logger.String("database", s.databaseName),
+					logger.Int64("segmentTime", segmentTime))
+				return nil, nil
+			}
+			return nil, err
+		}
+		family, err := segment.GetOrCreateExemplarFamily()
+		if err != nil {
+			return nil, err
+		}
+		return family.NewFlusher(), nil
+	})
+}
+
+// flushSketches flushes the buffered sketches into the sketch family of segment which sketch belongs to,
+// drops the sketches of expired segment.
+func (s *shard) flushSketches() error {
+	if s.sketches.IsEmpty() {
+		return nil
+	}
+	calc := s.interval.Calculator()
+	return s.sketches.FlushTo(calc.CalcSegmentTime, func(segmentTime int64) (kv.Flusher, error) {
+		segment, err := s.segment.GetOrCreateSegment(calc.GetSegment(segmentTime))
+		if err != nil {
+			if errors.Is(err, constants.ErrSegmentExpired) {
+				s.logger.Warn("drop sketches of expired segment",
+					logger.Any("shardID", s.id),
+					logger.String("database", s.databaseName),
+					logger.Int64("segmentTime", segmentTime))
+				return nil, nil
+			}
+			return nil, err
+		}
+		family, err := segment.GetOrCreateSketchFamily()
+		if err != nil {
+			return nil, err
+		}
+		return family.NewFlusher(), nil
+	})
+}
+
 // createMemoryDatabase creates a new memory database for writing data points
 func (s *shard) createMemoryDatabase(familyTime int64) (memdb.MemoryDatabase, error) {
 	return newMemoryDBFunc(memdb.MemoryDatabaseCfg{
@@ -742,3 +1513,159 @@ func (s *shard) ackReplicaSeq() {
 		engineLogger.Error("ack replica sequence error", logger.String("shard", s.path), logger.Error(err))
 	}
 }
+
+// ValidateOption validates if the changed database option can be applied to running shard.
+func (s *shard) ValidateOption(option option.DatabaseOption) error {
+	return s.validateOption(option, s.getOption())
+}
+
+// AlterOption applies the changed database option to running shard,
+// creates the interval segments for new rollup intervals.
+func (s *shard) AlterOption(option option.DatabaseOption) error {
+	s.optionLock.Lock()
+	defer s.optionLock.Unlock()
+
+	if err := s.validateOption(option, s.option); err != nil {
+		return err
+	}
+	ttls, err := retentions(option)
+	if err != nil {
+		return err
+	}
+	segments, err := s.newRollupSegments(option)
+	if err != nil {
+		return err
+	}
+	registerRollups(segments)
+	// replace segments/ttls with new map, so that the reader can iterate old map without lock
+	s.option = option
+	s.segments = segments
+	s.ttls = ttls
+	s.logger.Info("alter shard option successfully",
+		logger.Any("shardID", s.id),
+		logger.String("database", s.databaseName),
+		logger.Any("option", option))
+	return nil
+}
+
+// validateOption validates the new option and checks if it can replace the old option.
+func (s *shard) validateOption(option, old option.DatabaseOption) error {
+	if err := option.Validate(); err != nil {
+		return fmt.Errorf("engine option is invalid, err: %s", err)
+	}
+	return option.ValidateAlter(old)
+}
+
+// newRollupSegments returns a copy of interval segments, includes the segments for rollup intervals of option,
+// the rollup interval whose type is same as exist segment will share the exist segment.
+func (s *shard) newRollupSegments(option option.DatabaseOption) (map[timeutil.IntervalType]IntervalSegment, error) {
+	segments := make(map[timeutil.IntervalType]IntervalSegment)
+	for intervalType, segment := range s.segments {
+		segments[intervalType] = segment
+	}
+	for _, intervalStr := range option.Rollup {
+		var interval timeutil.Interval
+		if err := interval.ValueOf(intervalStr); err != nil {
+			return nil, err
+		}
+		intervalType := interval.Type()
+		if _, ok := segments[intervalType]; ok {
+			continue
+		}
+		segment, err := newIntervalSegmentFunc(
+			interval,
+			filepath.Join(s.path, segmentDir, intervalType.String()),
+			s.getCompression)
+		if err != nil {
+			return nil, err
+		}
+		segments[intervalType] = segment
+	}
+	return segments, nil
+}
+
+// registerRollups registers the rollup relations between interval segments, the data of interval segment
+// is rolled up into the segment with next larger interval, so raw data is rolled up to each rollup interval step by step.
+func registerRollups(segments map[timeutil.IntervalType]IntervalSegment) {
+	sortedSegments := make([]IntervalSegment, 0, len(segments))
+	for _, segment := range segments {
+		sortedSegments = append(sortedSegments, segment)
+	}
+	sort.Slice(sortedSegments, func(i, j int) bool {
+		return sortedSegments[i].getInterval() < sortedSegments[j].getInterval()
+	})
+	for idx := 1; idx < len(sortedSegments); idx++ {
+		sortedSegments[idx-1].registerRollup(sortedSegments[idx])
+	}
+}
+
+// getOption returns the current database option
+func (s *shard) getOption() option.DatabaseOption {
+	s.optionLock.RLock()
+	defer s.optionLock.RUnlock()
+	return s.option
+}
+
+// getCompression returns the compression option of the families created by shard
+func (s *shard) getCompression() option.CompressionOption {
+	return s.getOption().Compression
+}
+
+// getSegments returns all interval segments, the returned map cannot be modified
+func (s *shard) getSegments() map[timeutil.IntervalType]IntervalSegment {
+	s.optionLock.RLock()
+	defer s.optionLock.RUnlock()
+	return s.segments
+}
+
+// getTTL returns the data retention of the segment for interval type, the intervals with same type
+// share the segment, so keeps the longest retention of them, returns false if any of them keeps data forever.
+func (s *shard) getTTL(intervalType timeutil.IntervalType) (ttl int64, ok bool) {
+	s.optionLock.RLock()
+	defer s.optionLock.RUnlock()
+	for interval, intervalTTL := range s.ttls {
+		if interval.Type() != intervalType {
+			continue
+		}
+		if intervalTTL <= 0 {
+			return 0, false
+		}
+		if intervalTTL > ttl {
+			ttl = intervalTTL
+		}
+		ok = true
+	}
+	return
+}
+
+// retentions returns the data retention of each interval based on database option,
+// empty ttl means keeping data forever(0), returns err if interval or ttl is invalid.
+func retentions(option option.DatabaseOption) (map[timeutil.Interval]int64, error) {
+	ttls := make(map[timeutil.Interval]int64)
+	addTTL := func(intervalStr, ttlStr string) error {
+		var interval, ttl timeutil.Interval
+		if err := interval.ValueOf(intervalStr); err != nil {
+			return fmt.Errorf("invalid interval: %s, err: %w", intervalStr, err)
+		}
+		if ttlStr != "" {
+			if err := ttl.ValueOf(ttlStr); err != nil {
+				return fmt.Errorf("invalid ttl: %s of interval: %s, err: %w", ttlStr, intervalStr, err)
+			}
+		}
+		ttls[interval] = ttl.Int64()
+		return nil
+	}
+	if err := addTTL(option.Interval, option.TTL); err != nil {
+		return nil, err
+	}
+	for idx, rollup := range option.Rollup {
+		ttl := ""
+		if idx < len(option.RollupTTL) {
+			ttl = option.RollupTTL[idx]
+		}
+		if err := addTTL(rollup, ttl); err != nil {
+			return nil, err
+		}
+	}
+	return ttls, nil
+}
@@ -70,6 +70,23 @@ func (ss *shardSet) InsertShard(shardID models.ShardID, shard Shard) {
 	ss.num.Inc()
 }
 
+// RemoveShard removes the shard from the slice,
+// then changes atomic.Value to the new set
+func (ss *shardSet) RemoveShard(shardID models.ShardID) {
+	oldEntries := ss.value.Load().(shardEntries)
+	newEntries := make([]shardEntry, 0, oldEntries.Len())
+	for _, entry := range oldEntries {
+		if entry.shardID != shardID {
+			newEntries = append(newEntries, entry)
+		}
+	}
+	if len(newEntries) == oldEntries.Len() {
+		return
+	}
+	ss.value.Store(shardEntries(newEntries))
+	ss.num.Dec()
+}
+
 // GetShard searches the shard by shardID from the shardSet
 // BinarySearch is not always faster than iterating
 func (ss *shardSet) GetShard(shardID models.ShardID) (Shard, bool) {
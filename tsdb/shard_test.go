@@ -21,18 +21,23 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"math"
 	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/lindb/roaring"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/flow"
 	"github.com/lindb/lindb/kv"
 	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/pkg/fileutil"
+	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/pkg/option"
 	"github.com/lindb/lindb/pkg/timeutil"
 	protoMetricsV1 "github.com/lindb/lindb/proto/gen/v1/metrics"
@@ -42,6 +47,7 @@ import (
 	"github.com/lindb/lindb/tsdb/indexdb"
 	"github.com/lindb/lindb/tsdb/memdb"
 	"github.com/lindb/lindb/tsdb/metadb"
+	"github.com/lindb/lindb/tsdb/tblstore/exemplar"
 )
 
 var _testShard1Path = filepath.Join(testPath, shardDir, "1")
@@ -90,7 +96,7 @@ func TestShard_New(t *testing.T) {
 	assert.Nil(t, thisShard)
 	// case 5: new interval segment err
 	newReplicaSequenceFunc = newReplicaSequence
-	newIntervalSegmentFunc = func(interval timeutil.Interval, path string) (segment IntervalSegment, err error) {
+	newIntervalSegmentFunc = func(interval timeutil.Interval, path string, _ func() option.CompressionOption) (segment IntervalSegment, err error) {
 		return nil, fmt.Errorf("err")
 	}
 	thisShard, err = newShard(db, 1, _testShard1Path, option.DatabaseOption{Interval: "10s"})
@@ -166,6 +172,203 @@ func TestShard_GetDataFamilies(t *testing.T) {
 	assert.Equal(t, 0, len(s.GetDataFamilies(timeutil.Day, timeutil.TimeRange{})))
 }
 
+func TestShard_ExpireData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	segment := NewMockIntervalSegment(ctrl)
+	s := &shard{
+		segments: map[timeutil.IntervalType]IntervalSegment{timeutil.Day: segment, timeutil.Month: segment},
+		ttls:     mockRetentions(option.DatabaseOption{Interval: "10s", TTL: "14d"}),
+	}
+	cumulative, err := memdb.NewCumulativeStore(filepath.Join(t.TempDir(), cumulativeFile))
+	assert.NoError(t, err)
+	s.cumulative = cumulative
+	now := timeutil.Now()
+	// case 1: exclude expired time range
+	segment.EXPECT().getDataFamilies(gomock.Any()).DoAndReturn(func(timeRange timeutil.TimeRange) []DataFamily {
+		assert.True(t, timeRange.Start >= now-14*timeutil.OneDay)
+		return nil
+	})
+	assert.Nil(t, s.GetDataFamilies(timeutil.Day, timeutil.TimeRange{Start: 0, End: now}))
+	// case 2: all expired
+	assert.Nil(t, s.GetDataFamilies(timeutil.Day, timeutil.TimeRange{Start: 0, End: now - 15*timeutil.OneDay}))
+	// case 3: no ttl
+	segment.EXPECT().getDataFamilies(timeutil.TimeRange{Start: 0, End: now}).Return(nil)
+	assert.Nil(t, s.GetDataFamilies(timeutil.Month, timeutil.TimeRange{Start: 0, End: now}))
+	// case 4: only expire segment with ttl
+	segment.EXPECT().expireSegments(gomock.Any()).DoAndReturn(func(expireTime int64) {
+		assert.True(t, expireTime >= now-14*timeutil.OneDay)
+	})
+	// case 5: expire state of cumulative sum fields
+	s.cumulative.Delta(1, 1, 1, now-2*cumulativeStateTTL, 10)
+	s.cumulative.Delta(1, 2, 1, now, 10)
+	s.ExpireData()
+	assert.Equal(t, 1, s.cumulative.Size())
+}
+
+func TestShard_MoveSegments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	daySegment := NewMockIntervalSegment(ctrl)
+	monthSegment := NewMockIntervalSegment(ctrl)
+	s := &shard{
+		interval: timeutil.Interval(10 * timeutil.OneSecond),
+		segments: map[timeutil.IntervalType]IntervalSegment{timeutil.Day: daySegment, timeutil.Month: monthSegment},
+		families: *newFamilyMemDBSet(),
+	}
+	now := timeutil.Now()
+	memDB := memdb.NewMockMemoryDatabase(ctrl)
+	s.families.InsertFamily(now-timeutil.OneHour, memDB)
+	// segments of memory database cannot be moved
+	daySegment.EXPECT().moveSegments(gomock.Any(), now-timeutil.OneHour)
+	monthSegment.EXPECT().moveSegments(gomock.Any(), gomock.Any()).DoAndReturn(func(now, before int64) {
+		assert.Equal(t, now, before)
+	})
+	s.MoveSegments()
+}
+
+func TestShard_AlterOption(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		newIntervalSegmentFunc = newIntervalSegment
+		ctrl.Finish()
+	}()
+
+	daySegment := NewMockIntervalSegment(ctrl)
+	monthSegment := NewMockIntervalSegment(ctrl)
+	daySegment.EXPECT().getInterval().Return(timeutil.Interval(10 * timeutil.OneSecond)).AnyTimes()
+	monthSegment.EXPECT().getInterval().Return(timeutil.Interval(5 * timeutil.OneMinute)).AnyTimes()
+	oldOption := option.DatabaseOption{Interval: "10s", TTL: "14d"}
+	s := &shard{
+		path:     _testShard1Path,
+		option:   oldOption,
+		interval: timeutil.Interval(10 * timeutil.OneSecond),
+		segments: map[timeutil.IntervalType]IntervalSegment{timeutil.Day: daySegment},
+		ttls:     mockRetentions(oldOption),
+		logger:   logger.GetLogger("tsdb", "Shard"),
+	}
+	// case 1: option invalid
+	assert.Error(t, s.ValidateOption(option.DatabaseOption{}))
+	assert.Error(t, s.AlterOption(option.DatabaseOption{}))
+	// case 2: write interval cannot be changed
+	assert.Error(t, s.ValidateOption(option.DatabaseOption{Interval: "20s"}))
+	assert.Error(t, s.AlterOption(option.DatabaseOption{Interval: "20s"}))
+	// case 3: ttl invalid
+	assert.Error(t, s.ValidateOption(option.DatabaseOption{Interval: "10s", TTL: "aa"}))
+	assert.Error(t, s.AlterOption(option.DatabaseOption{Interval: "10s", TTL: "aa"}))
+	// case 4: new rollup segment err
+	newIntervalSegmentFunc = func(interval timeutil.Interval, path string, _ func() option.CompressionOption) (IntervalSegment, error) {
+		return nil, fmt.Errorf("err")
+	}
+	newOption := option.DatabaseOption{Interval: "10s", TTL: "14d", Rollup: []string{"5m"}, RollupTTL: []string{"90d"}}
+	assert.NoError(t, s.ValidateOption(newOption))
+	assert.Error(t, s.AlterOption(newOption))
+	assert.Equal(t, oldOption, s.getOption())
+	// case 5: add rollup interval
+	newIntervalSegmentFunc = func(interval timeutil.Interval, path string, _ func() option.CompressionOption) (IntervalSegment, error) {
+		assert.Equal(t, timeutil.Interval(5*timeutil.OneMinute), interval)
+		assert.Equal(t, filepath.Join(_testShard1Path, segmentDir, timeutil.Month.String()), path)
+		return monthSegment, nil
+	}
+	// raw data rolls up into new rollup interval
+	daySegment.EXPECT().registerRollup(monthSegment).Times(2)
+	assert.NoError(t, s.AlterOption(newOption))
+	assert.Equal(t, newOption, s.getOption())
+	assert.Equal(t, map[timeutil.IntervalType]IntervalSegment{
+		timeutil.Day:   daySegment,
+		timeutil.Month: monthSegment,
+	}, s.getSegments())
+	ttl, ok := s.getTTL(timeutil.Month)
+	assert.True(t, ok)
+	assert.Equal(t, 90*timeutil.OneDay, ttl)
+	// case 6: rollup interval with exist interval type shares the segment
+	newOption.Rollup = append(newOption.Rollup, "10m")
+	assert.NoError(t, s.AlterOption(newOption))
+	assert.Len(t, s.getSegments(), 2)
+}
+
+func TestShard_registerRollups(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	daySegment := NewMockIntervalSegment(ctrl)
+	monthSegment := NewMockIntervalSegment(ctrl)
+	yearSegment := NewMockIntervalSegment(ctrl)
+	daySegment.EXPECT().getInterval().Return(timeutil.Interval(10 * timeutil.OneSecond)).AnyTimes()
+	monthSegment.EXPECT().getInterval().Return(timeutil.Interval(5 * timeutil.OneMinute)).AnyTimes()
+	yearSegment.EXPECT().getInterval().Return(timeutil.Interval(timeutil.OneHour)).AnyTimes()
+	// rollup step by step: day => month => year
+	daySegment.EXPECT().registerRollup(monthSegment)
+	monthSegment.EXPECT().registerRollup(yearSegment)
+	registerRollups(map[timeutil.IntervalType]IntervalSegment{
+		timeutil.Year:  yearSegment,
+		timeutil.Day:   daySegment,
+		timeutil.Month: monthSegment,
+	})
+	// no rollup interval
+	registerRollups(map[timeutil.IntervalType]IntervalSegment{timeutil.Day: daySegment})
+}
+
+func TestShard_retentions(t *testing.T) {
+	ttls, err := retentions(option.DatabaseOption{Interval: "10s"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[timeutil.Interval]int64{timeutil.Interval(10 * timeutil.OneSecond): 0}, ttls)
+	ttls, err = retentions(option.DatabaseOption{
+		Interval:  "10s",
+		Rollup:    []string{"5m", "1h"},
+		TTL:       "14d",
+		RollupTTL: []string{"90d", "2y"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[timeutil.Interval]int64{
+		timeutil.Interval(10 * timeutil.OneSecond): 14 * timeutil.OneDay,
+		timeutil.Interval(5 * timeutil.OneMinute):  90 * timeutil.OneDay,
+		timeutil.Interval(timeutil.OneHour):        2 * timeutil.OneYear,
+	}, ttls)
+	// invalid ttl cannot be treated as keeping data forever
+	_, err = retentions(option.DatabaseOption{Interval: "10s", TTL: "aa"})
+	assert.Error(t, err)
+	_, err = retentions(option.DatabaseOption{Interval: "10s", Rollup: []string{"5m"}, RollupTTL: []string{"bb"}})
+	assert.Error(t, err)
+	_, err = retentions(option.DatabaseOption{Interval: "cc"})
+	assert.Error(t, err)
+}
+
+func TestShard_getTTL(t *testing.T) {
+	// keeps the longest ttl for same interval type
+	s := &shard{ttls: mockRetentions(option.DatabaseOption{
+		Interval:  "10s",
+		Rollup:    []string{"5m", "10m"},
+		TTL:       "14d",
+		RollupTTL: []string{"90d", "180d"},
+	})}
+	ttl, ok := s.getTTL(timeutil.Month)
+	assert.True(t, ok)
+	assert.Equal(t, 180*timeutil.OneDay, ttl)
+	ttl, ok = s.getTTL(timeutil.Day)
+	assert.True(t, ok)
+	assert.Equal(t, 14*timeutil.OneDay, ttl)
+	_, ok = s.getTTL(timeutil.Year)
+	assert.False(t, ok)
+	// keeps data forever if one interval without ttl
+	s.ttls = mockRetentions(option.DatabaseOption{
+		Interval:  "10s",
+		Rollup:    []string{"5m", "10m"},
+		RollupTTL: []string{"90d"},
+	})
+	_, ok = s.getTTL(timeutil.Month)
+	assert.False(t, ok)
+	_, ok = s.getTTL(timeutil.Day)
+	assert.False(t, ok)
+}
+
+func mockRetentions(option option.DatabaseOption) map[timeutil.Interval]int64 {
+	ttls, _ := retentions(option)
+	return ttls
+}
+
 func mockBatchRows(m *protoMetricsV1.Metric) *metric.StorageRow {
 	var ml = protoMetricsV1.MetricList{Metrics: []*protoMetricsV1.Metric{m}}
 	var buf bytes.Buffer
@@ -252,7 +455,274 @@ func TestShard_Write(t *testing.T) {
 			Type:  protoMetricsV1.SimpleFieldType_DELTA_SUM,
 		}},
 	})))
+	// case 7: keep gauge aggregates
+	shardIns.option.GaugeRollupAggregates = true
+	metadataDB.EXPECT().GenFieldID(gomock.Any(), gomock.Any(), field.Name("f2"), field.GaugeField).Return(field.ID(2), nil)
+	for idx, aggField := range field.GaugeAggFields {
+		metadataDB.EXPECT().GenFieldID(gomock.Any(), gomock.Any(), aggField.Name("f2"), aggField.Type).
+			Return(field.ID(3+idx), nil)
+	}
+	rows := mockBatchRows(&protoMetricsV1.Metric{
+		Name:      "test",
+		Timestamp: timestamp,
+		SimpleFields: []*protoMetricsV1.SimpleField{{
+			Name:  "f2",
+			Value: 1.0,
+			Type:  protoMetricsV1.SimpleFieldType_GAUGE,
+		}},
+	})
+	assert.NoError(t, shardIns.lookupRowMeta(rows))
+	assert.Equal(t, []field.ID{2}, rows.FieldIDs)
+	assert.Equal(t, []field.ID{3, 4, 5, 6}, rows.GaugeAggFieldIDs)
+	// case 8: quantile sketch, min/max/sum/count and one sketch field
+	sketch := encoding.NewSketch()
+	sketch.Add(1)
+	sketch.Add(100)
+	metadataDB.EXPECT().GenFieldID(gomock.Any(), gomock.Any(), metric.HistogramMin, field.MinField).Return(field.ID(5), nil)
+	metadataDB.EXPECT().GenFieldID(gomock.Any(), gomock.Any(), metric.HistogramMax, field.MaxField).Return(field.ID(6), nil)
+	metadataDB.EXPECT().GenFieldID(gomock.Any(), gomock.Any(), gomock.Any(), field.SumField).Return(field.ID(7), nil).Times(2)
+	metadataDB.EXPECT().GenFieldID(gomock.Any(), gomock.Any(), metric.SketchName, field.SketchField).Return(field.ID(9), nil)
+	rows = mockBatchRows(&protoMetricsV1.Metric{
+		Name:          "test",
+		Timestamp:     timestamp,
+		CompoundField: &protoMetricsV1.CompoundField{Min: 1, Max: 100, Sum: 101, Count: 2, Sketch: sketch.MarshalBinary()},
+	})
+	assert.NoError(t, shardIns.lookupRowMeta(rows))
+	assert.Equal(t, []field.ID{5, 6, 7, 7, 9}, rows.FieldIDs)
+	// case 9: gen sketch field id err
+	metadataDB.EXPECT().GenFieldID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(field.ID(7), nil).Times(4)
+	metadataDB.EXPECT().GenFieldID(gomock.Any(), gomock.Any(), gomock.Any(), field.SketchField).
+		Return(field.ID(0), fmt.Errorf("err"))
+	assert.Error(t, shardIns.lookupRowMeta(mockBatchRows(&protoMetricsV1.Metric{
+		Name:          "test",
+		Timestamp:     timestamp,
+		CompoundField: &protoMetricsV1.CompoundField{Min: 1, Max: 100, Sum: 101, Count: 2, Sketch: sketch.MarshalBinary()},
+	})))
+	// case 10: gen gauge aggregate field id err
+	metadataDB.EXPECT().GenFieldID(gomock.Any(), gomock.Any(), field.Name("f2"), field.GaugeField).Return(field.ID(2), nil)
+	metadataDB.EXPECT().GenFieldID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(field.ID(0), fmt.Errorf("err"))
+	assert.Error(t, shardIns.lookupRowMeta(mockBatchRows(&protoMetricsV1.Metric{
+		Name:      "test",
+		Timestamp: timestamp,
+		SimpleFields: []*protoMetricsV1.SimpleField{{
+			Name:  "f2",
+			Value: 1.0,
+			Type:  protoMetricsV1.SimpleFieldType_GAUGE,
+		}},
+	})))
+}
 
+func TestShard_convertCumulativeFields(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), cumulativeFile)
+	cumulative, err := memdb.NewCumulativeStore(stateFile)
+	assert.NoError(t, err)
+	s := &shard{cumulative: cumulative}
+	timestamp := timeutil.Now()
+	newRow := func(timestamp int64, value float64) *metric.StorageRow {
+		row := mockBatchRows(&protoMetricsV1.Metric{
+			Name:      "test",
+			Timestamp: timestamp,
+			SimpleFields: []*protoMetricsV1.SimpleField{
+				{Name: "f1", Value: 1.0, Type: protoMetricsV1.SimpleFieldType_DELTA_SUM},
+				{Name: "f2", Value: value, Type: protoMetricsV1.SimpleFieldType_CUMULATIVE_SUM},
+			},
+		})
+		row.MetricID = 1
+		row.SeriesID = 1
+		row.FieldIDs = []field.ID{1, 2}
+		return row
+	}
+	values := func(row *metric.StorageRow) (rs []float64) {
+		itr := row.NewSimpleFieldIterator()
+		for itr.HasNext() {
+			rs = append(rs, itr.NextValue())
+		}
+		return rs
+	}
+	cases := []struct {
+		timestamp int64
+		value     float64
+		expect    []float64
+		skipped   []int
+	}{
+		{timestamp: timestamp, value: 10, expect: []float64{1, 10}, skipped: []int{1}},     // first point
+		{timestamp: timestamp + 10, value: 15, expect: []float64{1, 5}},                    // delta
+		{timestamp: timestamp + 5, value: 12, expect: []float64{1, 12}, skipped: []int{1}}, // out of order
+		{timestamp: timestamp + 20, value: 3, expect: []float64{1, 3}},                     // counter reset
+		{timestamp: timestamp + 30, value: 3, expect: []float64{1, 0}},                     // no change
+		{timestamp: timestamp + 40, value: 0, expect: []float64{1, 0}},                     // reset to zero
+		{timestamp: timestamp + 50, value: 7.5, expect: []float64{1, 7.5}},                 // after reset
+	}
+	for _, c := range cases {
+		row := newRow(c.timestamp, c.value)
+		assert.True(t, s.convertCumulativeFields(row))
+		assert.Equal(t, c.expect, values(row))
+		assert.Equal(t, c.skipped, row.SkippedFields)
+	}
+	// state is persisted, delta continues after restart
+	assert.NoError(t, s.cumulative.Persist())
+	s.cumulative, err = memdb.NewCumulativeStore(stateFile)
+	assert.NoError(t, err)
+	row := newRow(timestamp+60, 10)
+	assert.True(t, s.convertCumulativeFields(row))
+	assert.Equal(t, []float64{1, 2.5}, values(row))
+	assert.Empty(t, row.SkippedFields)
+}
+
+func TestShard_Exemplars(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db := NewMockDatabase(ctrl)
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadata.EXPECT().DatabaseName().Return("test").AnyTimes()
+	db.EXPECT().Name().Return("test-db").AnyTimes()
+	db.EXPECT().Metadata().Return(metadata).AnyTimes()
+	shardINTF, err := newShard(db, 1, _testShard1Path, option.DatabaseOption{Interval: "10s", Behind: "1m", Ahead: "1m"})
+	assert.NoError(t, err)
+	shardIns := shardINTF.(*shard)
+	indexDB := indexdb.NewMockIndexDatabase(ctrl)
+	indexDB.EXPECT().Flush().Return(nil).AnyTimes()
+	indexDB.EXPECT().Close().Return(nil).AnyTimes()
+	shardIns.indexDB = indexDB
+
+	row := mockBatchRows(&protoMetricsV1.Metric{
+		Name:      "test",
+		Timestamp: 12000,
+		SimpleFields: []*protoMetricsV1.SimpleField{{
+			Name:      "f1",
+			Value:     1.0,
+			Type:      protoMetricsV1.SimpleFieldType_DELTA_SUM,
+			Exemplars: []*protoMetricsV1.Exemplar{{TraceId: []byte("trace1"), SpanId: []byte("span1"), Duration: 10}},
+		}},
+		CompoundField: &protoMetricsV1.CompoundField{
+			Values:         []float64{1, 2, 3},
+			ExplicitBounds: []float64{1, 2, math.Inf(1)},
+			Exemplars:      []*protoMetricsV1.Exemplar{{TraceId: []byte("trace2"), Duration: 20}},
+		},
+	})
+	row.MetricID = 10
+	row.SeriesID = 1
+	row.SlotIndex = 1
+	shardIns.bufferExemplars(0, row)
+	timeRange := timeutil.TimeRange{Start: 0, End: 20000}
+	fields := field.Metas{{Name: "f1", Type: field.SumField}, {Name: "h", Type: field.HistogramField}}
+	// find exemplars in memory
+	rs, err := shardIns.FindExemplars(10, fields, roaring.BitmapOf(1), timeRange)
+	assert.NoError(t, err)
+	assert.Len(t, rs, 2)
+	assert.Equal(t, int64(10000), rs[0].Timestamp)
+	// find exemplars in kv store after flush
+	assert.NoError(t, shardIns.Flush())
+	rs, err = shardIns.FindExemplars(10, fields, roaring.BitmapOf(1), timeRange)
+	assert.NoError(t, err)
+	assert.Len(t, rs, 2)
+	assert.Equal(t, "f1", rs[0].Field)
+	assert.Equal(t, "trace1", string(rs[0].TraceID))
+	assert.Equal(t, "span1", string(rs[0].SpanID))
+	assert.Equal(t, exemplar.CompoundFieldName, rs[1].Field)
+	// series not match
+	rs, err = shardIns.FindExemplars(10, fields, roaring.BitmapOf(2), timeRange)
+	assert.NoError(t, err)
+	assert.Empty(t, rs)
+	// field not match
+	rs, err = shardIns.FindExemplars(10, field.Metas{{Name: "f2", Type: field.SumField}}, roaring.BitmapOf(1), timeRange)
+	assert.NoError(t, err)
+	assert.Empty(t, rs)
+	// delete the flushed exemplars of slot
+	assert.NoError(t, shardIns.deleteExemplarsAndSketches(10, roaring.BitmapOf(1), timeutil.TimeRange{Start: 10001, End: 10001}))
+	rs, err = shardIns.FindExemplars(10, fields, roaring.BitmapOf(1), timeRange)
+	assert.NoError(t, err)
+	assert.Empty(t, rs)
+	// exemplars written after deleting are kept
+	shardIns.bufferExemplars(0, row)
+	assert.NoError(t, shardIns.Flush())
+	rs, err = shardIns.FindExemplars(10, fields, roaring.BitmapOf(1), timeRange)
+	assert.NoError(t, err)
+	assert.Len(t, rs, 2)
+	// exemplars are expired with segment
+	shardIns.segment.expireSegments(timeutil.OneDay)
+	rs, err = shardIns.FindExemplars(10, fields, roaring.BitmapOf(1), timeRange)
+	assert.NoError(t, err)
+	assert.Empty(t, rs)
+	// exemplars of expired segment are dropped when flushing
+	shardIns.bufferExemplars(0, row)
+	assert.NoError(t, shardIns.Flush())
+	assert.True(t, shardIns.exemplars.IsEmpty())
+	assert.NoError(t, shardIns.Close())
+}
+
+func TestShard_Sketches(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db := NewMockDatabase(ctrl)
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadata.EXPECT().DatabaseName().Return("test").AnyTimes()
+	db.EXPECT().Name().Return("test-db").AnyTimes()
+	db.EXPECT().Metadata().Return(metadata).AnyTimes()
+	shardINTF, err := newShard(db, 1, _testShard1Path, option.DatabaseOption{Interval: "10s", Behind: "1m", Ahead: "1m"})
+	assert.NoError(t, err)
+	shardIns := shardINTF.(*shard)
+	indexDB := indexdb.NewMockIndexDatabase(ctrl)
+	indexDB.EXPECT().Flush().Return(nil).AnyTimes()
+	indexDB.EXPECT().Close().Return(nil).AnyTimes()
+	shardIns.indexDB = indexDB
+
+	data := encoding.NewSketch()
+	data.Add(1)
+	data.Add(100)
+	row := mockBatchRows(&protoMetricsV1.Metric{
+		Name:          "test",
+		Timestamp:     12000,
+		CompoundField: &protoMetricsV1.CompoundField{Min: 1, Max: 100, Sum: 101, Count: 2, Sketch: data.MarshalBinary()},
+	})
+	row.MetricID = 10
+	row.SeriesID = 1
+	row.SlotIndex = 1
+	shardIns.bufferSketch(0, row)
+	shardIns.bufferSketch(0, row)
+	timeRange := timeutil.TimeRange{Start: 0, End: 20000}
+	// find sketches in memory, sketches of same slot are merged
+	rs, err := shardIns.FindSketches(timeutil.Day, 10, roaring.BitmapOf(1), timeRange)
+	assert.NoError(t, err)
+	assert.Len(t, rs, 1)
+	assert.Equal(t, int64(10000), rs[0].Timestamp)
+	assert.Equal(t, 4.0, rs[0].Data.Count())
+	// segment of interval type not exist
+	rs, err = shardIns.FindSketches(timeutil.Month, 10, roaring.BitmapOf(1), timeRange)
+	assert.NoError(t, err)
+	assert.Empty(t, rs)
+	// find sketches in kv store after flush
+	assert.NoError(t, shardIns.Flush())
+	assert.True(t, shardIns.sketches.IsEmpty())
+	rs, err = shardIns.FindSketches(timeutil.Day, 10, roaring.BitmapOf(1), timeRange)
+	assert.NoError(t, err)
+	assert.Len(t, rs, 1)
+	assert.Equal(t, 4.0, rs[0].Data.Count())
+	// series not match
+	rs, err = shardIns.FindSketches(timeutil.Day, 10, roaring.BitmapOf(2), timeRange)
+	assert.NoError(t, err)
+	assert.Empty(t, rs)
+	// delete the flushed sketches of slot
+	assert.NoError(t, shardIns.deleteExemplarsAndSketches(10, roaring.BitmapOf(1), timeutil.TimeRange{Start: 10001, End: 10001}))
+	rs, err = shardIns.FindSketches(timeutil.Day, 10, roaring.BitmapOf(1), timeRange)
+	assert.NoError(t, err)
+	assert.Empty(t, rs)
+	// sketches of expired segment are dropped when flushing
+	shardIns.segment.expireSegments(timeutil.OneDay)
+	shardIns.bufferSketch(0, row)
+	assert.NoError(t, shardIns.Flush())
+	assert.True(t, shardIns.sketches.IsEmpty())
+	assert.NoError(t, shardIns.Close())
 }
 
 func Test_familyMemDBSet(t *testing.T) {
@@ -392,6 +862,143 @@ func TestShard_Flush(t *testing.T) {
 	assert.NoError(t, s2.Flush())
 }
 
+func TestShard_flushAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		ctrl.Finish()
+	}()
+
+	meta := metadb.NewMockMetadata(ctrl)
+	meta.EXPECT().DatabaseName().Return("test").AnyTimes()
+	db := NewMockDatabase(ctrl)
+	db.EXPECT().Name().Return("test-db").AnyTimes()
+	db.EXPECT().Metadata().Return(meta).AnyTimes()
+	s, _ := newShard(db, 2, _testShard1Path, option.DatabaseOption{Interval: "10s"})
+	index := indexdb.NewMockIndexDatabase(ctrl)
+	s2 := s.(*shard)
+	s2.indexDB = index
+
+	// case 1: flush index error
+	index.EXPECT().Flush().Return(fmt.Errorf("error"))
+	assert.Error(t, s2.flushAll())
+	index.EXPECT().Flush().Return(nil).AnyTimes()
+
+	newMemDB := func(familyTime, size int64) *memdb.MockMemoryDatabase {
+		memDB := memdb.NewMockMemoryDatabase(ctrl)
+		memDB.EXPECT().FamilyTime().Return(familyTime).AnyTimes()
+		memDB.EXPECT().MemSize().Return(size).AnyTimes()
+		memDB.EXPECT().Close().Return(nil).AnyTimes()
+		return memDB
+	}
+	immutableDB1 := newMemDB(1, 1000)
+	mutableDB2 := newMemDB(2, 1000)
+	mutableDB3 := newMemDB(3, 1000)
+	emptyDB4 := newMemDB(4, 0)
+	s2.families.InsertFamily(1, immutableDB1)
+	s2.families.InsertFamily(2, mutableDB2)
+	s2.families.InsertFamily(3, mutableDB3)
+	s2.families.InsertFamily(4, emptyDB4)
+	s2.families.SetFamilyImmutable(1)
+	// case 2: flush memory database error, keeps the memory databases not flushed
+	immutableDB1.EXPECT().FlushFamilyTo(gomock.Any()).Return(nil)
+	mutableDB2.EXPECT().FlushFamilyTo(gomock.Any()).Return(fmt.Errorf("error"))
+	assert.Error(t, s2.flushAll())
+	assert.Len(t, s2.families.ImmutableEntries(), 2)
+	assert.False(t, s2.IsFlushing())
+	// case 3: flush all memory databases which have data
+	mutableDB2.EXPECT().FlushFamilyTo(gomock.Any()).Return(nil)
+	mutableDB3.EXPECT().FlushFamilyTo(gomock.Any()).Return(nil)
+	assert.NoError(t, s2.flushAll())
+	assert.Empty(t, s2.families.ImmutableEntries())
+	assert.Len(t, s2.families.MutableEntries(), 1)
+}
+
+func TestShard_Backup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		ctrl.Finish()
+	}()
+
+	meta := metadb.NewMockMetadata(ctrl)
+	meta.EXPECT().DatabaseName().Return("test").AnyTimes()
+	db := NewMockDatabase(ctrl)
+	db.EXPECT().Name().Return("test-db").AnyTimes()
+	db.EXPECT().Metadata().Return(meta).AnyTimes()
+	s, _ := newShard(db, 1, _testShard1Path, option.DatabaseOption{Interval: "10s"})
+	index := indexdb.NewMockIndexDatabase(ctrl)
+	indexStore := kv.NewMockStore(ctrl)
+	daySegment := NewMockIntervalSegment(ctrl)
+	s1 := s.(*shard)
+	s1.indexDB = index
+	s1.indexStore = indexStore
+	s1.segments = map[timeutil.IntervalType]IntervalSegment{timeutil.Day: daySegment}
+
+	target := filepath.Join(testPath, "backup")
+	// case 1: flush index err
+	index.EXPECT().Flush().Return(fmt.Errorf("err"))
+	assert.Error(t, s1.Backup(target))
+	index.EXPECT().Flush().Return(nil).AnyTimes()
+	// case 2: backup index database err
+	index.EXPECT().Backup(filepath.Join(target, metaDir)).Return(fmt.Errorf("err"))
+	assert.Error(t, s1.Backup(target))
+	index.EXPECT().Backup(gomock.Any()).Return(nil).AnyTimes()
+	// case 3: backup index store err
+	indexStore.EXPECT().Backup(filepath.Join(target, indexParentDir)).Return(fmt.Errorf("err"))
+	assert.Error(t, s1.Backup(target))
+	indexStore.EXPECT().Backup(gomock.Any()).Return(nil).AnyTimes()
+	// case 4: backup segment err
+	daySegment.EXPECT().Backup(filepath.Join(target, segmentDir, timeutil.Day.String())).Return(fmt.Errorf("err"))
+	assert.Error(t, s1.Backup(target))
+	// case 5: backup successfully
+	daySegment.EXPECT().Backup(gomock.Any()).Return(nil)
+	assert.NoError(t, s1.Backup(target))
+	assert.True(t, fileutil.Exist(filepath.Join(target, cumulativeFile)))
+}
+
+func TestShard_Snapshot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		removeDir = fileutil.RemoveDir
+		_ = fileutil.RemoveDir(testPath)
+		ctrl.Finish()
+	}()
+
+	meta := metadb.NewMockMetadata(ctrl)
+	meta.EXPECT().DatabaseName().Return("test").AnyTimes()
+	db := NewMockDatabase(ctrl)
+	db.EXPECT().Name().Return("test-db").AnyTimes()
+	db.EXPECT().Metadata().Return(meta).AnyTimes()
+	s, _ := newShard(db, 1, _testShard1Path, option.DatabaseOption{Interval: "10s"})
+
+	target := filepath.Join(testPath, "snapshot")
+	// case 1: no replica message applied
+	_, err := s.Snapshot(target)
+	assert.Error(t, err)
+	applied := false
+	s.ApplyReplica(3, func() {
+		applied = true
+	})
+	assert.True(t, applied)
+	// case 2: remove old snapshot err
+	removeDir = func(path string) error {
+		return fmt.Errorf("err")
+	}
+	_, err = s.Snapshot(target)
+	assert.Error(t, err)
+	removeDir = fileutil.RemoveDir
+	// case 3: backup err
+	db.EXPECT().Backup(target, models.ShardID(1)).Return(fmt.Errorf("err"))
+	_, err = s.Snapshot(target)
+	assert.Error(t, err)
+	// case 4: snapshot successfully, returns the sequence applied last
+	db.EXPECT().Backup(target, models.ShardID(1)).Return(nil)
+	snapshotIdx, err := s.Snapshot(target)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), snapshotIdx)
+}
+
 func TestShard_NeedFlush(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -488,3 +1095,186 @@ func TestShard_GetOrCreateMemoryDatabase(t *testing.T) {
 	_, err = s4.GetOrCreateMemoryDatabase(2)
 	assert.Error(t, err)
 }
+
+func TestShard_DeleteSeries(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db := NewMockDatabase(ctrl)
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	metadata.EXPECT().DatabaseName().Return("test").AnyTimes()
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+	db.EXPECT().Name().Return("test-db").AnyTimes()
+	db.EXPECT().Metadata().Return(metadata).AnyTimes()
+	shardINTF, err := newShard(db, 1, _testShard1Path, option.DatabaseOption{Interval: "10s", Behind: "1m", Ahead: "1m"})
+	assert.NoError(t, err)
+	shardIns := shardINTF.(*shard)
+	indexDB := indexdb.NewMockIndexDatabase(ctrl)
+	indexDB.EXPECT().Flush().Return(nil).AnyTimes()
+	indexDB.EXPECT().Close().Return(nil).AnyTimes()
+	shardIns.indexDB = indexDB
+	segment := NewMockIntervalSegment(ctrl)
+	segment.EXPECT().Close().AnyTimes()
+	family := NewMockDataFamily(ctrl)
+	family.EXPECT().TimeRange().Return(timeutil.TimeRange{Start: 0, End: timeutil.OneHour - 1}).AnyTimes()
+	segment.EXPECT().getAllDataFamilies().Return([]DataFamily{family}).AnyTimes()
+	shardIns.segments = map[timeutil.IntervalType]IntervalSegment{timeutil.Day: segment}
+	timeRange := timeutil.TimeRange{Start: 0, End: 20000}
+
+	// case 1: series ids empty
+	assert.NoError(t, shardIns.DeleteSeries("ns", "test", roaring.New(), timeRange))
+	// case 2: metric not found
+	metadataDB.EXPECT().GetMetricID("ns", "test").Return(uint32(0), constants.ErrNotFound)
+	assert.Error(t, shardIns.DeleteSeries("ns", "test", roaring.BitmapOf(1, 2), timeRange))
+	metadataDB.EXPECT().GetMetricID("ns", "test").Return(uint32(10), nil).AnyTimes()
+	// case 3: write tombstone err
+	family.EXPECT().DeleteSeries(uint32(10), gomock.Any(), timeRange).Return(fmt.Errorf("err"))
+	assert.Error(t, shardIns.DeleteSeries("ns", "test", roaring.BitmapOf(1, 2), timeRange))
+	family.EXPECT().DeleteSeries(uint32(10), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	// case 4: get series ids with data err
+	family.EXPECT().GetSeriesIDsWithData(uint32(10)).Return(nil, fmt.Errorf("err"))
+	assert.Error(t, shardIns.DeleteSeries("ns", "test", roaring.BitmapOf(1, 2), timeRange))
+	// case 5: all series have data
+	family.EXPECT().GetSeriesIDsWithData(uint32(10)).Return(roaring.BitmapOf(1, 2), nil)
+	metadataDB.EXPECT().GetAllFields("ns", "test").Return(field.Metas{{ID: 1}}, nil)
+	assert.NoError(t, shardIns.DeleteSeries("ns", "test", roaring.BitmapOf(1, 2), timeRange))
+	// case 6: get fields err
+	family.EXPECT().GetSeriesIDsWithData(uint32(10)).Return(roaring.BitmapOf(1), nil).AnyTimes()
+	metadataDB.EXPECT().GetAllFields("ns", "test").Return(nil, fmt.Errorf("err"))
+	assert.Error(t, shardIns.DeleteSeries("ns", "test", roaring.BitmapOf(1, 2), timeRange))
+	metadataDB.EXPECT().GetAllFields("ns", "test").Return(field.Metas{{ID: 1}}, nil).AnyTimes()
+	// case 7: get tag keys err
+	metadataDB.EXPECT().GetAllTagKeys("ns", "test").Return(nil, fmt.Errorf("err"))
+	assert.Error(t, shardIns.DeleteSeries("ns", "test", roaring.BitmapOf(1, 2), timeRange))
+	// case 8: remove series without data from index
+	metadataDB.EXPECT().GetAllTagKeys("ns", "test").Return([]tag.Meta{{Key: "host", ID: 5}}, nil)
+	indexDB.EXPECT().DeleteSeries(uint32(10), []uint32{5}, roaring.BitmapOf(2)).Return(nil)
+	assert.NoError(t, shardIns.DeleteSeries("ns", "test", roaring.BitmapOf(1, 2), timeRange))
+	// case 9: series has data in memory database
+	memDB := memdb.NewMockMemoryDatabase(ctrl)
+	memDB.EXPECT().MemSize().Return(int64(0)).AnyTimes()
+	shardIns.families.InsertFamily(timeutil.OneHour, memDB)
+	rs := flow.NewMockFilterResultSet(ctrl)
+	rs.EXPECT().SeriesIDs().Return(roaring.BitmapOf(2))
+	memDB.EXPECT().Filter(uint32(10), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return([]flow.FilterResultSet{rs}, nil)
+	assert.NoError(t, shardIns.DeleteSeries("ns", "test", roaring.BitmapOf(1, 2), timeRange))
+	// case 10: series not found in memory database
+	memDB.EXPECT().Filter(uint32(10), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, constants.ErrSeriesIDNotFound)
+	metadataDB.EXPECT().GetAllTagKeys("ns", "test").Return([]tag.Meta{{Key: "host", ID: 5}}, nil)
+	indexDB.EXPECT().DeleteSeries(uint32(10), []uint32{5}, roaring.BitmapOf(2)).Return(nil)
+	assert.NoError(t, shardIns.DeleteSeries("ns", "test", roaring.BitmapOf(1, 2),
+		timeutil.TimeRange{Start: 0, End: timeutil.OneHour + 10}))
+	// case 11: flush index err before deleting
+	indexDB2 := indexdb.NewMockIndexDatabase(ctrl)
+	indexDB2.EXPECT().Flush().Return(fmt.Errorf("err"))
+	shardIns.indexDB = indexDB2
+	assert.Error(t, shardIns.DeleteSeries("ns", "test", roaring.BitmapOf(1, 2), timeRange))
+	shardIns.indexDB = indexDB
+}
+
+func TestShard_GCSeries(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db := NewMockDatabase(ctrl)
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	metadata.EXPECT().DatabaseName().Return("test").AnyTimes()
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+	db.EXPECT().Name().Return("test-db").AnyTimes()
+	db.EXPECT().Metadata().Return(metadata).AnyTimes()
+	shardINTF, err := newShard(db, 1, _testShard1Path, option.DatabaseOption{Interval: "10s", Behind: "1m", Ahead: "1m"})
+	assert.NoError(t, err)
+	shardIns := shardINTF.(*shard)
+	indexDB := indexdb.NewMockIndexDatabase(ctrl)
+	indexDB.EXPECT().Flush().Return(nil).AnyTimes()
+	indexDB.EXPECT().Close().Return(nil).AnyTimes()
+	shardIns.indexDB = indexDB
+	segment := NewMockIntervalSegment(ctrl)
+	segment.EXPECT().Close().AnyTimes()
+	family := NewMockDataFamily(ctrl)
+	segment.EXPECT().getAllDataFamilies().Return([]DataFamily{family}).AnyTimes()
+	shardIns.segments = map[timeutil.IntervalType]IntervalSegment{timeutil.Day: segment}
+
+	// case 1: get metric ids err
+	indexDB.EXPECT().GetMetricIDs().Return(nil, fmt.Errorf("err"))
+	collected, err := shardIns.GCSeries()
+	assert.Error(t, err)
+	assert.Zero(t, collected)
+	indexDB.EXPECT().GetMetricIDs().Return([]uint32{10}, nil).AnyTimes()
+	// case 2: get series ids err
+	indexDB.EXPECT().GetSeriesIDsByMetricID(uint32(10)).Return(nil, fmt.Errorf("err"))
+	_, err = shardIns.GCSeries()
+	assert.Error(t, err)
+	// case 3: series ids not found
+	indexDB.EXPECT().GetSeriesIDsByMetricID(uint32(10)).Return(nil, constants.ErrNotFound)
+	collected, err = shardIns.GCSeries()
+	assert.NoError(t, err)
+	assert.Zero(t, collected)
+	indexDB.EXPECT().GetSeriesIDsByMetricID(uint32(10)).Return(roaring.BitmapOf(1, 2, 3), nil).AnyTimes()
+	// case 4: fields not found, keep series
+	metadataDB.EXPECT().GetAllFieldsByMetricID(uint32(10)).Return(nil, constants.ErrNotFound)
+	collected, err = shardIns.GCSeries()
+	assert.NoError(t, err)
+	assert.Zero(t, collected)
+	// case 5: get fields err
+	metadataDB.EXPECT().GetAllFieldsByMetricID(uint32(10)).Return(nil, fmt.Errorf("err"))
+	_, err = shardIns.GCSeries()
+	assert.Error(t, err)
+	metadataDB.EXPECT().GetAllFieldsByMetricID(uint32(10)).Return(field.Metas{{ID: 1}}, nil).AnyTimes()
+	// case 6: get series ids with data err
+	family.EXPECT().GetSeriesIDsWithData(uint32(10)).Return(nil, fmt.Errorf("err"))
+	_, err = shardIns.GCSeries()
+	assert.Error(t, err)
+	// case 7: all series have data
+	family.EXPECT().GetSeriesIDsWithData(uint32(10)).Return(roaring.BitmapOf(1, 2, 3), nil)
+	collected, err = shardIns.GCSeries()
+	assert.NoError(t, err)
+	assert.Zero(t, collected)
+	family.EXPECT().GetSeriesIDsWithData(uint32(10)).Return(roaring.BitmapOf(1), nil).AnyTimes()
+	// case 8: get tag keys err
+	metadataDB.EXPECT().GetAllTagKeysByMetricID(uint32(10)).Return(nil, fmt.Errorf("err"))
+	_, err = shardIns.GCSeries()
+	assert.Error(t, err)
+	metadataDB.EXPECT().GetAllTagKeysByMetricID(uint32(10)).Return([]tag.Meta{{Key: "host", ID: 5}}, nil).AnyTimes()
+	// case 9: delete series err
+	indexDB.EXPECT().DeleteSeries(uint32(10), []uint32{5}, roaring.BitmapOf(2, 3)).Return(fmt.Errorf("err"))
+	_, err = shardIns.GCSeries()
+	assert.Error(t, err)
+	// case 10: collect series without data, series 2 has data in memory database
+	memDB := memdb.NewMockMemoryDatabase(ctrl)
+	memDB.EXPECT().MemSize().Return(int64(0)).AnyTimes()
+	shardIns.families.InsertFamily(timeutil.OneHour, memDB)
+	rs := flow.NewMockFilterResultSet(ctrl)
+	rs.EXPECT().SeriesIDs().Return(roaring.BitmapOf(2)).Times(2)
+	memDB.EXPECT().Filter(uint32(10), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return([]flow.FilterResultSet{rs}, nil).Times(2)
+	indexDB.EXPECT().DeleteSeries(uint32(10), []uint32{5}, roaring.BitmapOf(3)).Return(nil)
+	collected, err = shardIns.GCSeries()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, collected)
+	// case 11: series 3 written after first check, keep it
+	rs2 := flow.NewMockFilterResultSet(ctrl)
+	rs2.EXPECT().SeriesIDs().Return(roaring.BitmapOf(2, 3))
+	gomock.InOrder(
+		memDB.EXPECT().Filter(uint32(10), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return([]flow.FilterResultSet{rs}, nil),
+		memDB.EXPECT().Filter(uint32(10), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return([]flow.FilterResultSet{rs2}, nil),
+	)
+	rs.EXPECT().SeriesIDs().Return(roaring.BitmapOf(2))
+	collected, err = shardIns.GCSeries()
+	assert.NoError(t, err)
+	assert.Zero(t, collected)
+}
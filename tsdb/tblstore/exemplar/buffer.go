@@ -0,0 +1,223 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package exemplar
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/lindb/roaring"
+
+	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/pkg/timeutil"
+)
+
+// slotKey represents the series/field/slot which exemplars belong to.
+type slotKey struct {
+	seriesID  uint32
+	timestamp int64
+	field     string
+}
+
+// Buffer holds the written exemplars of each metric in memory before flushing,
+// the exemplars of each series/field/slot are bounded by MaxExemplarsPerSlot.
+type Buffer struct {
+	exemplars map[uint32]map[slotKey][]Exemplar // metric id => slot => exemplars
+	flushing  map[uint32]map[slotKey][]Exemplar // exemplars being flushed, visible until flush completed
+	mutex     sync.RWMutex
+	flushLock sync.Mutex
+}
+
+// NewBuffer creates an exemplar buffer.
+func NewBuffer() *Buffer {
+	return &Buffer{
+		exemplars: make(map[uint32]map[slotKey][]Exemplar),
+	}
+}
+
+// Add adds an exemplar of metric into buffer,
+// replaces the exemplar with minimum duration if the slot is full.
+func (b *Buffer) Add(metricID uint32, e Exemplar) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.add(metricID, e)
+}
+
+// add adds an exemplar of metric into buffer without lock.
+func (b *Buffer) add(metricID uint32, e Exemplar) {
+	slots, ok := b.exemplars[metricID]
+	if !ok {
+		slots = make(map[slotKey][]Exemplar)
+		b.exemplars[metricID] = slots
+	}
+	key := slotKey{seriesID: e.SeriesID, timestamp: e.Timestamp, field: e.Field}
+	list := slots[key]
+	if len(list) < MaxExemplarsPerSlot {
+		slots[key] = append(list, e)
+		return
+	}
+	minIdx := 0
+	for idx := range list {
+		if list[idx].Duration < list[minIdx].Duration {
+			minIdx = idx
+		}
+	}
+	if e.Duration > list[minIdx].Duration {
+		list[minIdx] = e
+	}
+}
+
+// Find returns the buffered exemplars of metric which match the fields, series ids and time range,
+// includes the exemplars being flushed.
+func (b *Buffer) Find(
+	metricID uint32,
+	fields map[string]struct{},
+	seriesIDs *roaring.Bitmap,
+	timeRange timeutil.TimeRange,
+) (rs []Exemplar) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	for _, list := range b.exemplars[metricID] {
+		rs = filter(rs, list, fields, seriesIDs, timeRange)
+	}
+	for _, list := range b.flushing[metricID] {
+		rs = filter(rs, list, fields, seriesIDs, timeRange)
+	}
+	return rs
+}
+
+// IsEmpty checks if there is no buffered exemplar.
+func (b *Buffer) IsEmpty() bool {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	return len(b.exemplars) == 0
+}
+
+// FlushTo flushes all buffered exemplars into kv stores, the exemplars are partitioned by the partition
+// of timestamp(e.g. segment which exemplar belongs to), newFlusher returns the flusher of partition,
+// the exemplars of partition are dropped if newFlusher returns nil flusher(e.g. segment expired).
+// The flushing exemplars are still visible for query until flush completed,
+// and the exemplars not flushed are put back into buffer if flush failure.
+func (b *Buffer) FlushTo(
+	partition func(timestamp int64) int64,
+	newFlusher func(partition int64) (kv.Flusher, error),
+) error {
+	b.flushLock.Lock()
+	defer b.flushLock.Unlock()
+
+	b.mutex.Lock()
+	flushing := b.exemplars
+	b.flushing = flushing
+	b.exemplars = make(map[uint32]map[slotKey][]Exemplar)
+	b.mutex.Unlock()
+
+	partitions := make(map[int64]map[uint32]map[slotKey][]Exemplar)
+	for metricID, slots := range flushing {
+		for key, list := range slots {
+			p := partition(key.timestamp)
+			metrics, ok := partitions[p]
+			if !ok {
+				metrics = make(map[uint32]map[slotKey][]Exemplar)
+				partitions[p] = metrics
+			}
+			if _, ok := metrics[metricID]; !ok {
+				metrics[metricID] = make(map[slotKey][]Exemplar)
+			}
+			metrics[metricID][key] = list
+		}
+	}
+	var err error
+	for p, buffered := range partitions {
+		var flusher kv.Flusher
+		if flusher, err = newFlusher(p); err != nil {
+			break
+		}
+		if flusher != nil {
+			if err = flush(flusher, buffered); err != nil {
+				break
+			}
+		}
+		delete(partitions, p)
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.flushing = nil
+	// put back the exemplars not flushed, retry in next flush
+	for _, buffered := range partitions {
+		for metricID, slots := range buffered {
+			for _, list := range slots {
+				for idx := range list {
+					b.add(metricID, list[idx])
+				}
+			}
+		}
+	}
+	return err
+}
+
+// flush writes the exemplars of each metric into kv store.
+func flush(flusher kv.Flusher, buffered map[uint32]map[slotKey][]Exemplar) error {
+	if len(buffered) == 0 {
+		return nil
+	}
+	metricIDs := make([]uint32, 0, len(buffered))
+	for metricID := range buffered {
+		metricIDs = append(metricIDs, metricID)
+	}
+	// kv store requires keys in order
+	sort.Slice(metricIDs, func(i, j int) bool { return metricIDs[i] < metricIDs[j] })
+	var list []Exemplar
+	for _, metricID := range metricIDs {
+		list = list[:0]
+		for _, slot := range buffered[metricID] {
+			list = append(list, slot...)
+		}
+		block, err := Encode(Bound(list))
+		if err != nil {
+			return err
+		}
+		if err := flusher.Add(metricID, block); err != nil {
+			return err
+		}
+	}
+	return flusher.Commit()
+}
+
+// filter appends the exemplars which match the fields, series ids and time range into dst.
+func filter(
+	dst, list []Exemplar,
+	fields map[string]struct{},
+	seriesIDs *roaring.Bitmap,
+	timeRange timeutil.TimeRange,
+) []Exemplar {
+	for idx := range list {
+		e := list[idx]
+		if _, ok := fields[e.Field]; !ok {
+			continue
+		}
+		if timeRange.Contains(e.Timestamp) && seriesIDs.Contains(e.SeriesID) {
+			dst = append(dst, e)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,118 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package exemplar
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lindb/roaring"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/pkg/timeutil"
+)
+
+var fields = map[string]struct{}{"f1": {}}
+
+func TestBuffer_Add_Find(t *testing.T) {
+	buf := NewBuffer()
+	for i := 0; i < 5; i++ {
+		buf.Add(1, Exemplar{SeriesID: 1, Timestamp: 10, Field: "f1", TraceID: []byte("trace"), Duration: int64(i)})
+	}
+	// smaller duration dropped
+	buf.Add(1, Exemplar{SeriesID: 1, Timestamp: 10, Field: "f1", TraceID: []byte("trace"), Duration: 0})
+	buf.Add(1, Exemplar{SeriesID: 2, Timestamp: 20, Field: "f1", TraceID: []byte("trace"), Duration: 1})
+
+	rs := buf.Find(1, fields, roaring.BitmapOf(1), timeutil.TimeRange{Start: 0, End: 100})
+	assert.Len(t, rs, MaxExemplarsPerSlot)
+	for _, e := range rs {
+		assert.True(t, e.Duration >= 2)
+	}
+	assert.Len(t, buf.Find(1, fields, roaring.BitmapOf(1, 2), timeutil.TimeRange{Start: 0, End: 100}), MaxExemplarsPerSlot+1)
+	assert.Len(t, buf.Find(1, fields, roaring.BitmapOf(1, 2), timeutil.TimeRange{Start: 15, End: 100}), 1)
+	assert.Empty(t, buf.Find(2, fields, roaring.BitmapOf(1, 2), timeutil.TimeRange{Start: 0, End: 100}))
+	// field not match
+	assert.Empty(t, buf.Find(1, map[string]struct{}{"f2": {}}, roaring.BitmapOf(1, 2), timeutil.TimeRange{Start: 0, End: 100}))
+}
+
+func TestBuffer_FlushTo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buf := NewBuffer()
+	flusher := kv.NewMockFlusher(ctrl)
+	// partition by 100ms
+	partition := func(timestamp int64) int64 { return timestamp / 100 * 100 }
+	flushers := func(p int64) (kv.Flusher, error) {
+		assert.Equal(t, int64(0), p)
+		return flusher, nil
+	}
+	// empty buffer
+	assert.True(t, buf.IsEmpty())
+	assert.NoError(t, buf.FlushTo(partition, flushers))
+
+	buf.Add(2, Exemplar{SeriesID: 1, Timestamp: 10, Field: "f1", TraceID: []byte("trace"), Duration: 1})
+	buf.Add(1, Exemplar{SeriesID: 1, Timestamp: 10, Field: "f1", TraceID: []byte("trace"), Duration: 1})
+	assert.False(t, buf.IsEmpty())
+	gomock.InOrder(
+		flusher.EXPECT().Add(uint32(1), gomock.Any()).Return(nil),
+		flusher.EXPECT().Add(uint32(2), gomock.Any()).Return(nil),
+		flusher.EXPECT().Commit().DoAndReturn(func() error {
+			// exemplars are visible during flushing
+			assert.Len(t, buf.Find(1, fields, roaring.BitmapOf(1), timeutil.TimeRange{Start: 0, End: 100}), 1)
+			return nil
+		}),
+	)
+	assert.NoError(t, buf.FlushTo(partition, flushers))
+	assert.Empty(t, buf.Find(1, fields, roaring.BitmapOf(1), timeutil.TimeRange{Start: 0, End: 100}))
+
+	// add failure
+	buf.Add(1, Exemplar{SeriesID: 1, Timestamp: 10, Field: "f1", TraceID: []byte("trace"), Duration: 1})
+	flusher.EXPECT().Add(gomock.Any(), gomock.Any()).Return(fmt.Errorf("err"))
+	assert.Error(t, buf.FlushTo(partition, flushers))
+	// commit failure
+	flusher.EXPECT().Add(gomock.Any(), gomock.Any()).Return(nil)
+	flusher.EXPECT().Commit().Return(fmt.Errorf("err"))
+	assert.Error(t, buf.FlushTo(partition, flushers))
+	// exemplars are put back after flush failure
+	assert.False(t, buf.IsEmpty())
+	assert.Len(t, buf.Find(1, fields, roaring.BitmapOf(1), timeutil.TimeRange{Start: 0, End: 100}), 1)
+	// new flusher failure
+	assert.Error(t, buf.FlushTo(partition, func(_ int64) (kv.Flusher, error) {
+		return nil, fmt.Errorf("err")
+	}))
+	assert.False(t, buf.IsEmpty())
+
+	// flush each partition into its flusher, drop the exemplars of partition without flusher
+	buf.Add(1, Exemplar{SeriesID: 1, Timestamp: 110, Field: "f1", TraceID: []byte("trace"), Duration: 1})
+	buf.Add(1, Exemplar{SeriesID: 1, Timestamp: 210, Field: "f1", TraceID: []byte("trace"), Duration: 1})
+	flusher.EXPECT().Add(uint32(1), gomock.Any()).Return(nil).Times(2)
+	flusher.EXPECT().Commit().Return(nil).Times(2)
+	var partitions []int64
+	assert.NoError(t, buf.FlushTo(partition, func(p int64) (kv.Flusher, error) {
+		partitions = append(partitions, p)
+		if p == 200 {
+			return nil, nil
+		}
+		return flusher, nil
+	}))
+	assert.ElementsMatch(t, []int64{0, 100, 200}, partitions)
+	assert.True(t, buf.IsEmpty())
+}
@@ -0,0 +1,155 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package exemplar
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/lindb/lindb/pkg/stream"
+)
+
+// MaxExemplarsPerSlot is the max number of exemplars retained for each field of series in one time slot.
+const MaxExemplarsPerSlot = 3
+
+// CompoundFieldName is the field name of exemplars which belongs to compound(histogram) field.
+const CompoundFieldName = "histogram"
+
+// Exemplar represents a sampled trace which links the field value of series to trace.
+type Exemplar struct {
+	SeriesID  uint32
+	Timestamp int64 // start time of slot which exemplar belongs to
+	Field     string
+	TraceID   []byte
+	SpanID    []byte
+	Duration  int64
+}
+
+// sameSlot checks if the two exemplars belong to the same series/field/slot.
+func (e *Exemplar) sameSlot(o *Exemplar) bool {
+	return e.SeriesID == o.SeriesID && e.Timestamp == o.Timestamp && e.Field == o.Field
+}
+
+// exemplars sorts exemplars by series id/timestamp/field, then by duration desc.
+type exemplars []Exemplar
+
+func (es exemplars) Len() int      { return len(es) }
+func (es exemplars) Swap(i, j int) { es[i], es[j] = es[j], es[i] }
+func (es exemplars) Less(i, j int) bool {
+	if es[i].SeriesID != es[j].SeriesID {
+		return es[i].SeriesID < es[j].SeriesID
+	}
+	if es[i].Timestamp != es[j].Timestamp {
+		return es[i].Timestamp < es[j].Timestamp
+	}
+	if es[i].Field != es[j].Field {
+		return es[i].Field < es[j].Field
+	}
+	if es[i].Duration != es[j].Duration {
+		return es[i].Duration > es[j].Duration
+	}
+	if c := bytes.Compare(es[i].TraceID, es[j].TraceID); c != 0 {
+		return c < 0
+	}
+	return bytes.Compare(es[i].SpanID, es[j].SpanID) < 0
+}
+
+// same checks if the two exemplars are the same sampled trace of series/field/slot.
+func (e *Exemplar) same(o *Exemplar) bool {
+	return e.sameSlot(o) && e.Duration == o.Duration &&
+		bytes.Equal(e.TraceID, o.TraceID) && bytes.Equal(e.SpanID, o.SpanID)
+}
+
+// Bound sorts the exemplars and removes the duplicated ones, then keeps at most MaxExemplarsPerSlot
+// exemplars with the largest duration for each series/field/slot.
+func Bound(list []Exemplar) []Exemplar {
+	sort.Sort(exemplars(list))
+	result := list[:0]
+	count := 0
+	for idx := range list {
+		if len(result) > 0 && list[idx].same(&result[len(result)-1]) {
+			continue
+		}
+		if len(result) > 0 && list[idx].sameSlot(&result[len(result)-1]) {
+			count++
+		} else {
+			count = 1
+		}
+		if count > MaxExemplarsPerSlot {
+			continue
+		}
+		result = append(result, list[idx])
+	}
+	return result
+}
+
+// Encode encodes the exemplars into binary block.
+// Layout: count(uvarint) + [seriesID(uvarint) timestamp(varint) field traceID spanID duration(varint)]...
+func Encode(list []Exemplar) ([]byte, error) {
+	writer := stream.NewBufferWriter(nil)
+	writer.PutUvarint64(uint64(len(list)))
+	for idx := range list {
+		e := &list[idx]
+		writer.PutUvarint32(e.SeriesID)
+		writer.PutVarint64(e.Timestamp)
+		writer.PutUvarint64(uint64(len(e.Field)))
+		writer.PutBytes([]byte(e.Field))
+		writer.PutUvarint64(uint64(len(e.TraceID)))
+		writer.PutBytes(e.TraceID)
+		writer.PutUvarint64(uint64(len(e.SpanID)))
+		writer.PutBytes(e.SpanID)
+		writer.PutVarint64(e.Duration)
+	}
+	return writer.Bytes()
+}
+
+// Decode decodes the exemplars from binary block, appends them into dst.
+func Decode(dst []Exemplar, block []byte) ([]Exemplar, error) {
+	reader := stream.NewReader(block)
+	count := reader.ReadUvarint64()
+	for i := uint64(0); i < count && reader.Error() == nil; i++ {
+		var e Exemplar
+		e.SeriesID = reader.ReadUvarint32()
+		e.Timestamp = reader.ReadVarint64()
+		e.Field = string(readBytes(reader))
+		e.TraceID = readBytes(reader)
+		e.SpanID = readBytes(reader)
+		e.Duration = reader.ReadVarint64()
+		if reader.Error() == nil {
+			dst = append(dst, e)
+		}
+	}
+	if err := reader.Error(); err != nil {
+		return dst, fmt.Errorf("decode exemplars failure: %w", err)
+	}
+	return dst, nil
+}
+
+// readBytes reads the length prefixed bytes, returns a copy of bytes.
+func readBytes(reader *stream.Reader) []byte {
+	length := int(reader.ReadUvarint64())
+	if reader.Error() != nil || length == 0 {
+		return nil
+	}
+	slice := reader.ReadSlice(length)
+	if reader.Error() != nil {
+		return nil
+	}
+	return append([]byte(nil), slice...)
+}
@@ -0,0 +1,74 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package exemplar
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExemplar_Encode_Decode(t *testing.T) {
+	list := []Exemplar{
+		{SeriesID: 1, Timestamp: 10, Field: "f1", TraceID: []byte("trace1"), SpanID: []byte("span1"), Duration: 100},
+		{SeriesID: 2, Timestamp: 20, Field: CompoundFieldName, TraceID: []byte("trace2"), Duration: -1},
+	}
+	block, err := Encode(list)
+	assert.NoError(t, err)
+	rs, err := Decode(nil, block)
+	assert.NoError(t, err)
+	assert.Equal(t, list, rs)
+
+	// empty
+	block, err = Encode(nil)
+	assert.NoError(t, err)
+	rs, err = Decode(nil, block)
+	assert.NoError(t, err)
+	assert.Empty(t, rs)
+
+	// corrupted block
+	block, err = Encode(list)
+	assert.NoError(t, err)
+	rs, err = Decode(nil, block[:len(block)-3])
+	assert.Error(t, err)
+	assert.Len(t, rs, 1)
+	_, err = Decode(nil, nil)
+	assert.Error(t, err)
+}
+
+func TestExemplar_Bound(t *testing.T) {
+	var list []Exemplar
+	for i := 0; i < 5; i++ {
+		list = append(list,
+			Exemplar{SeriesID: 1, Timestamp: 10, Field: "f1", TraceID: []byte("trace"), Duration: int64(i)},
+			Exemplar{SeriesID: 1, Timestamp: 10, Field: "f2", TraceID: []byte("trace"), Duration: int64(i)},
+		)
+	}
+	list = append(list,
+		Exemplar{SeriesID: 0, Timestamp: 20, Field: "f1", TraceID: []byte("trace"), Duration: 1},
+		// duplicated exemplar
+		Exemplar{SeriesID: 1, Timestamp: 10, Field: "f1", TraceID: []byte("trace"), Duration: 4},
+	)
+	rs := Bound(list)
+	assert.Len(t, rs, 2*MaxExemplarsPerSlot+1)
+	assert.Equal(t, uint32(0), rs[0].SeriesID)
+	assert.Equal(t, "f1", rs[1].Field)
+	assert.Equal(t, int64(4), rs[1].Duration)
+	assert.Equal(t, int64(2), rs[3].Duration)
+	assert.Equal(t, "f2", rs[4].Field)
+}
@@ -0,0 +1,90 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package exemplar
+
+import (
+	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/kv/table"
+)
+
+// MergerName represents the merger name of exemplar family.
+var MergerName kv.MergerType = "ExemplarMerger"
+
+// init registers exemplar merger create function
+func init() {
+	kv.RegisterMerger(MergerName, NewMerger)
+}
+
+// merger implements kv.Merger for merging exemplars of each metric.
+type merger struct {
+	kvFlusher kv.Flusher
+}
+
+// NewMerger creates a merger for compacting exemplars.
+func NewMerger(kvFlusher kv.Flusher) (kv.Merger, error) {
+	return &merger{
+		kvFlusher: kvFlusher,
+	}, nil
+}
+
+func (m *merger) Init(_ map[string]interface{}) {}
+
+// Merge merges the exemplars of same metric, then bounds the exemplars of each series/field/slot,
+// the exemplars deleted by tombstones are purged, and tombstones are dropped after merge.
+func (m *merger) Merge(metricID uint32, dataBlocks [][]byte) error {
+	return m.MergeVersioned(metricID, nil, dataBlocks)
+}
+
+// MergeVersioned merges the exemplars like Merge, but tombstone only purges the exemplars
+// which come from file whose number <= tombstone's version, if file numbers is nil, all tombstones apply.
+func (m *merger) MergeVersioned(metricID uint32, fileNumbers []table.FileNumber, dataBlocks [][]byte) error {
+	var (
+		tombstones Tombstones
+		err        error
+	)
+	for _, block := range dataBlocks {
+		if IsTombstones(block) {
+			if tombstones, err = DecodeTombstones(tombstones, block); err != nil {
+				return err
+			}
+		}
+	}
+	var list, rs []Exemplar
+	for idx, block := range dataBlocks {
+		if IsTombstones(block) {
+			continue
+		}
+		if list, err = Decode(list[:0], block); err != nil {
+			return err
+		}
+		if fileNumbers == nil {
+			rs = append(rs, tombstones.Purge(list)...)
+		} else {
+			rs = append(rs, tombstones.ForFile(fileNumbers[idx]).Purge(list)...)
+		}
+	}
+	if len(rs) == 0 {
+		// all exemplars are deleted
+		return nil
+	}
+	block, err := Encode(Bound(rs))
+	if err != nil {
+		return err
+	}
+	return m.kvFlusher.Add(metricID, block)
+}
@@ -0,0 +1,91 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package exemplar
+
+import (
+	"testing"
+
+	"github.com/lindb/roaring"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/kv/table"
+	"github.com/lindb/lindb/pkg/timeutil"
+)
+
+func TestMerger_Merge(t *testing.T) {
+	nopFlusher := kv.NewNopFlusher()
+	merger, err := NewMerger(nopFlusher)
+	assert.NoError(t, err)
+	merger.Init(nil)
+
+	var blocks [][]byte
+	for i := 0; i < 5; i++ {
+		block, err := Encode([]Exemplar{
+			{SeriesID: 1, Timestamp: 10, Field: "f1", TraceID: []byte("trace"), Duration: int64(i)},
+		})
+		assert.NoError(t, err)
+		blocks = append(blocks, block)
+	}
+	assert.NoError(t, merger.Merge(1, blocks))
+	rs, err := Decode(nil, nopFlusher.Bytes())
+	assert.NoError(t, err)
+	assert.Len(t, rs, MaxExemplarsPerSlot)
+	assert.Equal(t, int64(4), rs[0].Duration)
+
+	// bad block
+	assert.Error(t, merger.Merge(1, [][]byte{{1, 2, 3}}))
+}
+
+func TestMerger_MergeVersioned(t *testing.T) {
+	nopFlusher := kv.NewNopFlusher()
+	m, err := NewMerger(nopFlusher)
+	assert.NoError(t, err)
+
+	oldBlock, err := Encode([]Exemplar{
+		{SeriesID: 1, Timestamp: 10, Field: "f1", TraceID: []byte("old"), Duration: 1},
+		{SeriesID: 2, Timestamp: 10, Field: "f1", TraceID: []byte("kept"), Duration: 1},
+	})
+	assert.NoError(t, err)
+	newBlock, err := Encode([]Exemplar{
+		{SeriesID: 1, Timestamp: 10, Field: "f1", TraceID: []byte("new"), Duration: 1},
+	})
+	assert.NoError(t, err)
+	tombstone, err := EncodeTombstones(Tombstones{{
+		SeriesIDs: roaring.BitmapOf(1), TimeRange: timeutil.TimeRange{Start: 0, End: 100}, Version: 1,
+	}})
+	assert.NoError(t, err)
+
+	// tombstone only purges the exemplars of file written before deleting
+	merger := m.(kv.VersionedMerger)
+	assert.NoError(t, merger.MergeVersioned(1, []table.FileNumber{1, 2, 3},
+		[][]byte{oldBlock, tombstone, newBlock}))
+	rs, err := Decode(nil, nopFlusher.Bytes())
+	assert.NoError(t, err)
+	assert.Len(t, rs, 2)
+	assert.Equal(t, "new", string(rs[0].TraceID))
+	assert.Equal(t, "kept", string(rs[1].TraceID))
+	// all exemplars are deleted, tombstones are dropped
+	nopFlusher = kv.NewNopFlusher()
+	m, err = NewMerger(nopFlusher)
+	assert.NoError(t, err)
+	assert.NoError(t, m.Merge(1, [][]byte{tombstone, newBlock}))
+	assert.Empty(t, nopFlusher.Bytes())
+	// bad tombstone
+	assert.Error(t, m.Merge(1, [][]byte{tombstoneMagic}))
+}
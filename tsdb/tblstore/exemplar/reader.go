@@ -0,0 +1,81 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package exemplar
+
+import (
+	"errors"
+
+	"github.com/lindb/roaring"
+
+	"github.com/lindb/lindb/kv/table"
+	"github.com/lindb/lindb/kv/version"
+	"github.com/lindb/lindb/pkg/timeutil"
+)
+
+// Read reads the exemplars of metric from the files of kv snapshot which match the fields, series ids and time range,
+// the exemplars deleted by tombstones are purged.
+func Read(
+	snapshot version.Snapshot,
+	metricID uint32,
+	fields map[string]struct{},
+	seriesIDs *roaring.Bitmap,
+	timeRange timeutil.TimeRange,
+) ([]Exemplar, error) {
+	var (
+		tombstones  Tombstones
+		blocks      [][]byte
+		fileNumbers []table.FileNumber
+	)
+	for _, fileMeta := range snapshot.GetCurrent().FindFiles(metricID) {
+		reader, err := snapshot.GetReader(fileMeta.GetFileNumber())
+		if err != nil {
+			return nil, err
+		}
+		if reader == nil {
+			continue
+		}
+		block, err := reader.Get(metricID)
+		if errors.Is(err, table.ErrKeyNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if IsTombstones(block) {
+			if tombstones, err = DecodeTombstones(tombstones, block); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		blocks = append(blocks, block)
+		fileNumbers = append(fileNumbers, fileMeta.GetFileNumber())
+	}
+	var (
+		rs, list []Exemplar
+		err      error
+	)
+	for idx, block := range blocks {
+		if list, err = Decode(list[:0], block); err != nil {
+			return nil, err
+		}
+		// tombstone only deletes the exemplars of files which written before deleting
+		list = tombstones.ForFile(fileNumbers[idx]).Purge(list)
+		rs = filter(rs, list, fields, seriesIDs, timeRange)
+	}
+	return rs, nil
+}
@@ -0,0 +1,135 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package exemplar
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/lindb/roaring"
+
+	"github.com/lindb/lindb/kv/table"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/stream"
+	"github.com/lindb/lindb/pkg/timeutil"
+)
+
+// tombstoneMagic is the head of tombstone block, which distinguishes tombstone block from exemplar block,
+// exemplar block starts with count of exemplars, empty exemplar block is never written.
+var tombstoneMagic = []byte{0x00, 'T', 'O', 'M', 'B'}
+
+// Tombstone marks the exemplars of series in time range[start,end] as deleted,
+// only the exemplars in files which file number <= version are deleted,
+// so that the exemplars written after deleting are kept.
+type Tombstone struct {
+	SeriesIDs *roaring.Bitmap
+	TimeRange timeutil.TimeRange
+	Version   table.FileNumber
+}
+
+// Tombstones represents the tombstones under metric level of exemplar family.
+type Tombstones []Tombstone
+
+// IsTombstones checks if the block of metric is tombstone block.
+func IsTombstones(block []byte) bool {
+	return bytes.HasPrefix(block, tombstoneMagic)
+}
+
+// EncodeTombstones encodes the tombstones into binary block.
+// Layout: magic + count(uvarint) + [version(uvarint) start(varint) end(varint) len(uvarint) series ids]...
+func EncodeTombstones(tombstones Tombstones) ([]byte, error) {
+	writer := stream.NewBufferWriter(nil)
+	writer.PutBytes(tombstoneMagic)
+	writer.PutUvarint64(uint64(len(tombstones)))
+	for idx := range tombstones {
+		t := &tombstones[idx]
+		data, err := encoding.BitmapMarshal(t.SeriesIDs)
+		if err != nil {
+			return nil, err
+		}
+		writer.PutUvarint64(uint64(t.Version))
+		writer.PutVarint64(t.TimeRange.Start)
+		writer.PutVarint64(t.TimeRange.End)
+		writer.PutUvarint64(uint64(len(data)))
+		writer.PutBytes(data)
+	}
+	return writer.Bytes()
+}
+
+// DecodeTombstones decodes the tombstones from binary block, appends them into dst.
+func DecodeTombstones(dst Tombstones, block []byte) (Tombstones, error) {
+	if !IsTombstones(block) {
+		return dst, fmt.Errorf("decode exemplar tombstones failure: bad magic")
+	}
+	reader := stream.NewReader(block[len(tombstoneMagic):])
+	count := reader.ReadUvarint64()
+	for i := uint64(0); i < count && reader.Error() == nil; i++ {
+		var t Tombstone
+		t.Version = table.FileNumber(reader.ReadUvarint64())
+		t.TimeRange.Start = reader.ReadVarint64()
+		t.TimeRange.End = reader.ReadVarint64()
+		data := reader.ReadSlice(int(reader.ReadUvarint64()))
+		if reader.Error() != nil {
+			break
+		}
+		t.SeriesIDs = roaring.New()
+		if err := t.SeriesIDs.UnmarshalBinary(data); err != nil {
+			return dst, fmt.Errorf("decode exemplar tombstones failure: %w", err)
+		}
+		dst = append(dst, t)
+	}
+	if err := reader.Error(); err != nil {
+		return dst, fmt.Errorf("decode exemplar tombstones failure: %w", err)
+	}
+	return dst, nil
+}
+
+// ForFile returns the tombstones which apply to the exemplars of file.
+func (ts Tombstones) ForFile(fileNumber table.FileNumber) (rs Tombstones) {
+	for idx := range ts {
+		if ts[idx].Version >= fileNumber {
+			rs = append(rs, ts[idx])
+		}
+	}
+	return
+}
+
+// Purge removes the deleted exemplars from list in place.
+func (ts Tombstones) Purge(list []Exemplar) []Exemplar {
+	if len(ts) == 0 {
+		return list
+	}
+	result := list[:0]
+	for idx := range list {
+		if !ts.IsDeleted(list[idx].SeriesID, list[idx].Timestamp) {
+			result = append(result, list[idx])
+		}
+	}
+	return result
+}
+
+// IsDeleted checks if the data of series at timestamp is deleted by any tombstone,
+// tombstone is shared with the sketches of segment.
+func (ts Tombstones) IsDeleted(seriesID uint32, timestamp int64) bool {
+	for idx := range ts {
+		if ts[idx].TimeRange.Contains(timestamp) && ts[idx].SeriesIDs.Contains(seriesID) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package exemplar
+
+import (
+	"testing"
+
+	"github.com/lindb/roaring"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/timeutil"
+)
+
+func TestTombstones_Encode_Decode(t *testing.T) {
+	tombstones := Tombstones{
+		{SeriesIDs: roaring.BitmapOf(1, 2), TimeRange: timeutil.TimeRange{Start: 10, End: 20}, Version: 3},
+		{SeriesIDs: roaring.BitmapOf(3), TimeRange: timeutil.TimeRange{Start: 30, End: 40}, Version: 5},
+	}
+	block, err := EncodeTombstones(tombstones)
+	assert.NoError(t, err)
+	assert.True(t, IsTombstones(block))
+	rs, err := DecodeTombstones(nil, block)
+	assert.NoError(t, err)
+	assert.Len(t, rs, 2)
+	for idx := range tombstones {
+		assert.Equal(t, tombstones[idx].Version, rs[idx].Version)
+		assert.Equal(t, tombstones[idx].TimeRange, rs[idx].TimeRange)
+		assert.Equal(t, tombstones[idx].SeriesIDs.ToArray(), rs[idx].SeriesIDs.ToArray())
+	}
+
+	// exemplar block is not tombstone block
+	block, err = Encode([]Exemplar{{SeriesID: 1, Timestamp: 10, Field: "f1"}})
+	assert.NoError(t, err)
+	assert.False(t, IsTombstones(block))
+	_, err = DecodeTombstones(nil, block)
+	assert.Error(t, err)
+	// corrupted block
+	_, err = DecodeTombstones(nil, append(append([]byte{}, tombstoneMagic...), 1, 1))
+	assert.Error(t, err)
+	_, err = DecodeTombstones(nil, append(append([]byte{}, tombstoneMagic...), 1, 1, 1, 1, 1, 1, 1))
+	assert.Error(t, err)
+}
+
+func TestTombstones_ForFile_Purge(t *testing.T) {
+	tombstones := Tombstones{
+		{SeriesIDs: roaring.BitmapOf(1), TimeRange: timeutil.TimeRange{Start: 10, End: 20}, Version: 3},
+		{SeriesIDs: roaring.BitmapOf(2), TimeRange: timeutil.TimeRange{Start: 10, End: 20}, Version: 5},
+	}
+	assert.Len(t, tombstones.ForFile(3), 2)
+	assert.Len(t, tombstones.ForFile(4), 1)
+	assert.Empty(t, tombstones.ForFile(6))
+
+	list := []Exemplar{
+		{SeriesID: 1, Timestamp: 10},
+		{SeriesID: 1, Timestamp: 30},
+		{SeriesID: 2, Timestamp: 20},
+		{SeriesID: 3, Timestamp: 10},
+	}
+	assert.Len(t, Tombstones(nil).Purge(list), 4)
+	assert.Equal(t, []Exemplar{{SeriesID: 1, Timestamp: 30}, {SeriesID: 3, Timestamp: 10}}, tombstones.Purge(list))
+}
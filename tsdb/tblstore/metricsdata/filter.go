@@ -40,14 +40,17 @@ type metricsDataFilter struct {
 	familyTime int64
 	snapshot   version.Snapshot //FIXME stone1100, need close version snapshot
 	readers    []MetricReader
+	tombstones []Tombstones
 }
 
-// NewFilter creates the sst file data filter
-func NewFilter(familyTime int64, snapshot version.Snapshot, readers []MetricReader) Filter {
+// NewFilter creates the sst file data filter, data deleted by tombstones will be ignored,
+// tombstones[i] is the tombstones which apply to readers[i].
+func NewFilter(familyTime int64, snapshot version.Snapshot, readers []MetricReader, tombstones []Tombstones) Filter {
 	return &metricsDataFilter{
 		familyTime: familyTime,
 		snapshot:   snapshot,
 		readers:    readers,
+		tombstones: tombstones,
 	}
 }
 
@@ -56,7 +59,7 @@ func NewFilter(familyTime int64, snapshot version.Snapshot, readers []MetricRead
 func (f *metricsDataFilter) Filter(
 	seriesIDs *roaring.Bitmap, fields field.Metas,
 ) (rs []flow.FilterResultSet, err error) {
-	for _, reader := range f.readers {
+	for idx, reader := range f.readers {
 		//FIXME add time range compare????
 		fieldMetas, _ := reader.GetFields().Intersects(fields)
 		if len(fieldMetas) == 0 {
@@ -65,11 +68,26 @@ func (f *metricsDataFilter) Filter(
 		}
 		// after and operator, query bitmap is sub of store bitmap
 		matchSeriesIDs := roaring.FastAnd(seriesIDs, reader.GetSeriesIDs())
+		var tombstones Tombstones
+		if idx < len(f.tombstones) {
+			tombstones = f.tombstones[idx]
+		}
+		if len(tombstones) > 0 {
+			// remove series which all data deleted
+			slotRange := reader.GetTimeRange()
+			it := roaring.FastAnd(matchSeriesIDs, tombstones.SeriesIDs()).Iterator()
+			for it.HasNext() {
+				seriesID := it.Next()
+				if covers(tombstones.deletedRanges(seriesID), slotRange) {
+					matchSeriesIDs.Remove(seriesID)
+				}
+			}
+		}
 		if matchSeriesIDs.IsEmpty() {
 			// series ids not found
 			continue
 		}
-		rs = append(rs, newFileFilterResultSet(f.familyTime, fields, matchSeriesIDs, reader))
+		rs = append(rs, newFileFilterResultSet(f.familyTime, fields, matchSeriesIDs, reader, tombstones))
 	}
 	// not founds
 	if len(rs) == 0 {
@@ -84,6 +102,7 @@ type fileFilterResultSet struct {
 	familyTime int64
 	fields     field.Metas
 	seriesIDs  *roaring.Bitmap
+	tombstones Tombstones
 }
 
 // newFileFilterResultSet creates the file filter result set
@@ -92,12 +111,14 @@ func newFileFilterResultSet(
 	fields field.Metas,
 	seriesIDs *roaring.Bitmap,
 	reader MetricReader,
+	tombstones Tombstones,
 ) flow.FilterResultSet {
 	return &fileFilterResultSet{
 		familyTime: familyTime,
 		reader:     reader,
 		fields:     fields,
 		seriesIDs:  seriesIDs,
+		tombstones: tombstones,
 	}
 }
 
@@ -123,5 +144,9 @@ func (f *fileFilterResultSet) SlotRange() timeutil.SlotRange {
 
 // Load reads data from sst files, then returns the data file scanner.
 func (f *fileFilterResultSet) Load(highKey uint16, seriesID roaring.Container) flow.DataLoader {
-	return f.reader.Load(highKey, seriesID, f.fields)
+	loader := f.reader.Load(highKey, seriesID, f.fields)
+	if loader == nil || len(f.tombstones) == 0 {
+		return loader
+	}
+	return &tombstoneDataLoader{loader: loader, highKey: highKey, tombstones: f.tombstones}
 }
@@ -0,0 +1,89 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package metricsdata
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"math"
+
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/series/field"
+)
+
+// VerifyChecksum verifies the crc32 checksum of metric block, tombstone block has no checksum.
+func VerifyChecksum(metricBlock []byte) error {
+	if IsTombstones(metricBlock) {
+		return nil
+	}
+	if len(metricBlock) <= dataFooterSize {
+		return fmt.Errorf("metric block's length too small: %d <= %d", len(metricBlock), dataFooterSize)
+	}
+	footerPos := len(metricBlock) - dataFooterSize
+	expect := binary.LittleEndian.Uint32(metricBlock[footerPos+16 : footerPos+20])
+	if actual := crc32.ChecksumIEEE(metricBlock[:footerPos]); actual != expect {
+		return fmt.Errorf("crc32 checksum mismatch, expect: %d, actual: %d", expect, actual)
+	}
+	return nil
+}
+
+// DecodeMetricBlock decodes all points of each series/field from metric block,
+// used for inspecting metric data.
+func DecodeMetricBlock(
+	metricBlock []byte,
+	fn func(seriesID uint32, fieldMeta field.Meta, slot uint16, value float64),
+) error {
+	reader, err := NewReader("", metricBlock)
+	if err != nil {
+		return err
+	}
+	r := reader.(*metricReader)
+	fields := r.fields
+	decoder := encoding.GetTSDDecoder()
+	defer encoding.ReleaseTSDDecoder(decoder)
+
+	for idx, highKey := range r.seriesIDs.GetHighKeys() {
+		container := r.seriesIDs.GetContainerAtIndex(idx)
+		loader := r.Load(highKey, container, fields)
+		if loader == nil {
+			// series data not readable under this high key
+			continue
+		}
+		it := container.PeekableIterator()
+		for it.HasNext() {
+			lowSeriesID := it.Next()
+			seriesID := encoding.ValueWithHighLowBits(uint32(highKey)<<16, lowSeriesID)
+			slotRange, fieldsData := loader.Load(lowSeriesID)
+			for fieldIdx, data := range fieldsData {
+				if len(data) == 0 {
+					continue
+				}
+				decoder.ResetWithTimeRange(data, slotRange.Start, slotRange.End)
+				for slot := decoder.StartTime(); slot <= decoder.EndTime(); slot++ {
+					if decoder.HasValueWithSlot(slot) {
+						fn(seriesID, fields[fieldIdx], slot, math.Float64frombits(decoder.Value()))
+					}
+				}
+				if err := decoder.Error(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package metricsdata
+
+import (
+	"testing"
+
+	"github.com/lindb/roaring"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/series/field"
+)
+
+func TestVerifyChecksum(t *testing.T) {
+	block := mockMetricBlock()
+	assert.NoError(t, VerifyChecksum(block))
+	tombstone, err := EncodeTombstones(Tombstones{{SeriesIDs: roaring.BitmapOf(1), SlotRange: timeutil.SlotRange{Start: 1, End: 2}}})
+	assert.NoError(t, err)
+	assert.NoError(t, VerifyChecksum(tombstone))
+	// block too short
+	assert.Error(t, VerifyChecksum([]byte{1, 2, 3}))
+	// corrupted block
+	block[0]++
+	assert.Error(t, VerifyChecksum(block))
+}
+
+func TestDecodeMetricBlock(t *testing.T) {
+	type point struct {
+		seriesID uint32
+		fieldID  field.ID
+		slot     uint16
+		value    float64
+	}
+	var points []point
+	err := DecodeMetricBlock(mockMetricBlockForOneField(), func(seriesID uint32, fieldMeta field.Meta, slot uint16, value float64) {
+		points = append(points, point{seriesID: seriesID, fieldID: fieldMeta.ID, slot: slot, value: value})
+	})
+	assert.NoError(t, err)
+	// one point each series(time range is [5,5])
+	assert.Len(t, points, 10)
+	assert.Equal(t, point{seriesID: 0, fieldID: 2, slot: 5, value: 0}, points[0])
+	assert.Equal(t, point{seriesID: 9 * 4096, fieldID: 2, slot: 5, value: 0}, points[9])
+
+	points = points[:0]
+	err = DecodeMetricBlock(mockMetricBlock(), func(seriesID uint32, fieldMeta field.Meta, slot uint16, value float64) {
+		points = append(points, point{seriesID: seriesID, fieldID: fieldMeta.ID, slot: slot, value: value})
+	})
+	assert.NoError(t, err)
+	assert.Len(t, points, 10*4)
+	// bad block
+	assert.Error(t, DecodeMetricBlock([]byte{1, 2, 3}, nil))
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/lindb/roaring"
 
 	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/kv/table"
 	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/series/field"
@@ -39,6 +40,7 @@ type mergerContext struct {
 	scanners     []*dataScanner
 	seriesIDs    *roaring.Bitmap // target series ids
 	targetFields field.Metas     // target fields
+	tombstones   []Tombstones    // deleted data of each scanner which need purge
 
 	targetRange, sourceRange timeutil.SlotRange
 	ratio                    uint16
@@ -71,14 +73,25 @@ func (m *merger) Init(params map[string]interface{}) {
 	}
 }
 
-// Merge merges the multi metric data into one target metric data for same metric id
+// Merge merges the multi metric data into one target metric data for same metric id,
+// the data deleted by tombstones will be purged, and tombstones are dropped after merge.
 func (m *merger) Merge(key uint32, metricBlocks [][]byte) error {
-	blockCount := len(metricBlocks)
+	return m.MergeVersioned(key, nil, metricBlocks)
+}
+
+// MergeVersioned merges the multi metric data like Merge, but tombstone only purges the data
+// which comes from file whose number <= tombstone's version, if file numbers is nil, all tombstones apply.
+func (m *merger) MergeVersioned(key uint32, fileNumbers []table.FileNumber, metricBlocks [][]byte) error {
 	// 1. prepare readers and metric level data(field/time slot/series ids)
-	mergeCtx, err := m.prepare(metricBlocks)
+	mergeCtx, err := m.prepare(fileNumbers, metricBlocks)
 	if err != nil {
 		return err
 	}
+	blockCount := len(mergeCtx.scanners)
+	if blockCount == 0 {
+		// only tombstones, no data need to merge
+		return nil
+	}
 	// 2. Prepare metric
 	m.dataFlusher.PrepareMetric(key, mergeCtx.targetFields)
 	// 3. merge series data by roaring container
@@ -91,29 +104,47 @@ func (m *merger) Merge(key uint32, metricBlocks [][]byte) error {
 	}()
 	encodeStream := encoding.TSDEncodeFunc(mergeCtx.targetRange.Start)
 	fieldReaders := make([]FieldReader, blockCount)
+	readers := make([]FieldReader, blockCount) // field readers with tombstones
 	for idx, highKey := range highKeys {
 		container := mergeCtx.seriesIDs.GetContainerAtIndex(idx)
 		it := container.PeekableIterator()
 		for it.HasNext() {
 			lowSeriesID := it.Next()
+			seriesID := encoding.ValueWithHighLowBits(uint32(highKey)<<16, lowSeriesID)
+			found := false
 			// maybe series id not exist in some value block
 			for blockIdx, scanner := range mergeCtx.scanners {
+				readers[blockIdx] = fieldReaders[blockIdx]
 				seriesEntry := scanner.scan(highKey, lowSeriesID)
 				if len(seriesEntry) == 0 {
 					continue
 				}
 				timeRange := scanner.slotRange()
+				deletedRanges := mergeCtx.tombstones[blockIdx].deletedRanges(seriesID)
+				if covers(deletedRanges, timeRange) {
+					// all data of series in this block is deleted
+					continue
+				}
 				if fieldReaders[blockIdx] == nil {
 					fieldReaders[blockIdx] = newFieldReader(scanner.fieldIndexes(), seriesEntry, timeRange)
 				} else {
 					fieldReaders[blockIdx].Reset(seriesEntry, timeRange)
 				}
+				readers[blockIdx] = fieldReaders[blockIdx]
+				found = true
+				if len(deletedRanges) > 0 {
+					readers[blockIdx] = &tombstoneFieldReader{FieldReader: fieldReaders[blockIdx], ranges: deletedRanges}
+				}
+			}
+			if !found {
+				// all data of series is deleted
+				continue
 			}
-			if err := m.seriesMerger.merge(mergeCtx, decodeStreams, encodeStream, fieldReaders); err != nil {
+			if err := m.seriesMerger.merge(mergeCtx, decodeStreams, encodeStream, readers); err != nil {
 				return err
 			}
 			// flush series id
-			if err := m.dataFlusher.FlushSeries(encoding.ValueWithHighLowBits(uint32(highKey)<<16, lowSeriesID)); err != nil {
+			if err := m.dataFlusher.FlushSeries(seriesID); err != nil {
 				return err
 			}
 		}
@@ -125,14 +156,23 @@ func (m *merger) Merge(key uint32, metricBlocks [][]byte) error {
 	return nil
 }
 
-func (m *merger) prepare(metricBlocks [][]byte) (*mergerContext, error) {
+func (m *merger) prepare(fileNumbers []table.FileNumber, metricBlocks [][]byte) (*mergerContext, error) {
 	ctx := &mergerContext{
-		scanners:     make([]*dataScanner, len(metricBlocks)),
 		seriesIDs:    roaring.New(),
 		targetFields: field.Metas{},
 	}
 
+	var tombstones Tombstones
+	var scannerFiles []table.FileNumber
 	for idx, metricBlock := range metricBlocks {
+		if IsTombstones(metricBlock) {
+			rs, err := DecodeTombstones(tombstones, metricBlock)
+			if err != nil {
+				return nil, err
+			}
+			tombstones = rs
+			continue
+		}
 		reader, err := NewReader("merge_operation", metricBlock)
 		if err != nil {
 			return nil, err
@@ -159,9 +199,23 @@ func (m *merger) prepare(metricBlocks [][]byte) (*mergerContext, error) {
 			}
 		}
 		// create data scanner
-		if ctx.scanners[idx], err = newDataScanner(reader); err != nil {
+		scanner, err := newDataScanner(reader)
+		if err != nil {
 			return nil, err
 		}
+		ctx.scanners = append(ctx.scanners, scanner)
+		if fileNumbers != nil {
+			scannerFiles = append(scannerFiles, fileNumbers[idx])
+		}
+	}
+	// find the tombstones of each scanner
+	ctx.tombstones = make([]Tombstones, len(ctx.scanners))
+	for idx := range ctx.scanners {
+		if fileNumbers == nil {
+			ctx.tombstones[idx] = tombstones
+		} else {
+			ctx.tombstones[idx] = tombstones.ForFile(scannerFiles[idx])
+		}
 	}
 	// sort by field id
 	sort.Slice(ctx.targetFields, func(i, j int) bool { return ctx.targetFields[i].ID < ctx.targetFields[j].ID })
@@ -24,9 +24,11 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/lindb/roaring"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/kv/table"
 	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/series/field"
@@ -84,6 +86,77 @@ func assertMergeReentrant(t *testing.T, flusher kv.Flusher, mergerIntf kv.Merger
 	assert.Len(t, blocks, 2)
 }
 
+func TestMerger_Merge_Tombstones(t *testing.T) {
+	flusher := kv.NewNopFlusher()
+	mergerIntf, err := NewMerger(flusher)
+	assert.NoError(t, err)
+	tombstones, err := EncodeTombstones(Tombstones{
+		{SeriesIDs: roaring.BitmapOf(1, 30), SlotRange: timeutil.NewSlotRange(0, 30)},
+		{SeriesIDs: roaring.BitmapOf(2), SlotRange: timeutil.NewSlotRange(11, 20)},
+	})
+	assert.NoError(t, err)
+	// case 1: only tombstones
+	err = mergerIntf.Merge(1, [][]byte{tombstones})
+	assert.NoError(t, err)
+	assert.Nil(t, flusher.Bytes())
+	// case 2: purge deleted data
+	err = mergerIntf.Merge(1, [][]byte{
+		mockRealMetricBlock([]uint32{1, 2, 4}, 11, 15),
+		tombstones,
+		mockRealMetricBlock([]uint32{2, 30}, 5, 10),
+	})
+	assert.NoError(t, err)
+	r, err := NewReader("test", flusher.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{2, 4}, r.GetSeriesIDs().ToArray())
+	assert.Equal(t, timeutil.SlotRange{Start: 5, End: 15}, r.GetTimeRange())
+	loader := r.Load(0, r.GetSeriesIDs().GetContainer(0), r.GetFields())
+	_, blocks := loader.Load(2)
+	assert.Len(t, blocks, 2)
+	decoder := encoding.NewTSDDecoder(nil)
+	decoder.ResetWithTimeRange(blocks[0], 5, 15)
+	for slot := uint16(5); slot <= 15; slot++ {
+		assert.Equal(t, slot <= 10, decoder.HasValueWithSlot(slot))
+		if slot <= 10 {
+			assert.Equal(t, float64(slot), math.Float64frombits(decoder.Value()))
+		}
+	}
+	// case 3: bad tombstones
+	err = mergerIntf.Merge(1, [][]byte{tombstoneMagic})
+	assert.Error(t, err)
+}
+
+func TestMerger_MergeVersioned(t *testing.T) {
+	flusher := kv.NewNopFlusher()
+	mergerIntf, err := NewMerger(flusher)
+	assert.NoError(t, err)
+	tombstones, err := EncodeTombstones(Tombstones{
+		{SeriesIDs: roaring.BitmapOf(1, 2), SlotRange: timeutil.NewSlotRange(0, 30), Version: 2},
+	})
+	assert.NoError(t, err)
+	// data of file 3 is written after deleting, keep it
+	err = mergerIntf.(kv.VersionedMerger).MergeVersioned(1, []table.FileNumber{1, 3, 4}, [][]byte{
+		mockRealMetricBlock([]uint32{1, 2, 4}, 11, 15),
+		tombstones,
+		mockRealMetricBlock([]uint32{2}, 5, 10),
+	})
+	assert.NoError(t, err)
+	r, err := NewReader("test", flusher.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{2, 4}, r.GetSeriesIDs().ToArray())
+	loader := r.Load(0, r.GetSeriesIDs().GetContainer(0), r.GetFields())
+	_, blocks := loader.Load(2)
+	assert.Len(t, blocks, 2)
+	decoder := encoding.NewTSDDecoder(nil)
+	decoder.ResetWithTimeRange(blocks[0], 5, 15)
+	for slot := uint16(5); slot <= 15; slot++ {
+		assert.Equal(t, slot <= 10, decoder.HasValueWithSlot(slot))
+		if slot <= 10 {
+			assert.Equal(t, float64(slot), math.Float64frombits(decoder.Value()))
+		}
+	}
+}
+
 func mockRealMetricBlock(seriesIDs []uint32, start, end uint16) []byte {
 	nopKVFlusher := kv.NewNopFlusher()
 	flusher, _ := NewFlusher(nopKVFlusher)
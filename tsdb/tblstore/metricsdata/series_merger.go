@@ -20,6 +20,7 @@ package metricsdata
 import (
 	"github.com/lindb/lindb/aggregation"
 	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/timeutil"
 )
 
 //go:generate mockgen -source ./series_merger.go -destination=./series_merger_mock.go -package metricsdata
@@ -53,6 +54,12 @@ func (sm *seriesMerger) merge(
 	encodeStream *encoding.TSDEncoder,
 	fieldReaders []FieldReader,
 ) error {
+	// down sampling calculates target position by source slot/ratio, so target range need be based on source slot,
+	// because source family time maybe not same as target family time, e.g. source family 10:00(10s) =>
+	// target family 00:00(5min), source range[5,182] => target range[120,126] => based on source slot[0,6].
+	// NOTICE: family time offset between source and target must be multiple of target interval.
+	targetRange := timeutil.SlotRange{Start: mergeCtx.sourceRange.Start / mergeCtx.ratio}
+	targetRange.End = targetRange.Start + mergeCtx.targetRange.End - mergeCtx.targetRange.Start
 	for _, f := range mergeCtx.targetFields {
 		fieldID := f.ID
 
@@ -76,7 +83,7 @@ func (sm *seriesMerger) merge(
 		// compact merge: source range = target range and ratio = 1
 		// rollup merge: source range[5,182]=>target range[0,6], ratio:30, source interval:10s, target interval:5min
 		aggregation.DownSamplingMultiSeriesInto(
-			mergeCtx.targetRange, mergeCtx.ratio,
+			targetRange, mergeCtx.ratio,
 			f.Type, streams,
 			encodeStream.EmitDownSamplingValue,
 		)
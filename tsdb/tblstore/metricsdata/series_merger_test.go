@@ -171,6 +171,97 @@ func TestSeriesMerger_rollup_merge(t *testing.T) {
 		}
 	}
 	assert.Equal(t, 2, c)
+	// case 3: merge success and rollup into target family with offset
+	reader1.EXPECT().GetFieldData(gomock.Any()).Return(mockField(10))
+	reader1.EXPECT().SlotRange().Return(timeutil.SlotRange{Start: 10, End: 10})
+	reader2.EXPECT().GetFieldData(gomock.Any()).Return(mockField(10))
+	reader2.EXPECT().SlotRange().Return(timeutil.SlotRange{Start: 12, End: 12})
+	flusher.EXPECT().FlushField(gomock.Any()).DoAndReturn(func(data []byte) error {
+		result = data
+		return nil
+	})
+	// source:[10,12] of hour family 01:00 => target:[12,12] of day family, interval: 10s => 5min
+	err = merger.merge(
+		&mergerContext{
+			targetFields: field.Metas{{ID: 1, Type: field.SumField}},
+			sourceRange:  timeutil.SlotRange{Start: 10, End: 12},
+			targetRange:  timeutil.SlotRange{Start: 12, End: 12},
+			ratio:        30,
+		}, decodeStreams, encodeStream, readers)
+	assert.NoError(t, err)
+	tsd = encoding.GetTSDDecoder()
+	tsd.ResetWithTimeRange(result, 12, 12)
+	assert.True(t, tsd.HasValueWithSlot(12))
+	assert.Equal(t, 20.0, math.Float64frombits(tsd.Value()))
+}
+
+func TestSeriesMerger_rollup_gaugeAggFields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	flusher := NewMockFlusher(ctrl)
+	merger := newSeriesMerger(flusher)
+	reader := NewMockFieldReader(ctrl)
+	reader.EXPECT().Close().AnyTimes()
+	reader.EXPECT().SlotRange().Return(timeutil.SlotRange{Start: 5, End: 7}).AnyTimes()
+
+	// gauge values written at slot 5,6,7 of 10s interval, hidden fields keep the aggregates of gauge
+	gaugeValues := []float64{2, 8, 3}
+	targetFields := field.Metas{{ID: 1, Type: field.GaugeField}}
+	for idx, aggField := range field.GaugeAggFields {
+		targetFields = append(targetFields, field.Meta{ID: field.ID(idx + 2), Type: aggField.Type})
+	}
+	reader.EXPECT().GetFieldData(field.ID(1)).Return(mockFieldValues(5, gaugeValues, nil))
+	for idx := range field.GaugeAggFields {
+		aggField := field.GaugeAggFields[idx]
+		reader.EXPECT().GetFieldData(field.ID(idx + 2)).Return(mockFieldValues(5, gaugeValues, aggField.Value))
+	}
+	var results [][]byte
+	flusher.EXPECT().FlushField(gomock.Any()).DoAndReturn(func(data []byte) error {
+		results = append(results, append([]byte{}, data...))
+		return nil
+	}).Times(len(targetFields))
+	// source:[5,7] target:[0,0], interval: 10s => 5min
+	err := merger.merge(
+		&mergerContext{
+			targetFields: targetFields,
+			sourceRange:  timeutil.SlotRange{Start: 5, End: 7},
+			targetRange:  timeutil.SlotRange{Start: 0, End: 0},
+			ratio:        30,
+		}, make([]*encoding.TSDDecoder, 1), encoding.NewTSDEncoder(0), []FieldReader{reader})
+	assert.NoError(t, err)
+	assert.Len(t, results, len(targetFields))
+	rollupValue := func(data []byte) float64 {
+		tsd := encoding.GetTSDDecoder()
+		defer encoding.ReleaseTSDDecoder(tsd)
+		tsd.ResetWithTimeRange(data, 0, 0)
+		assert.True(t, tsd.HasValueWithSlot(0))
+		return math.Float64frombits(tsd.Value())
+	}
+	gaugeValue := rollupValue(results[0])
+	aggValues := make(map[field.AggType]float64)
+	for idx, aggField := range field.GaugeAggFields {
+		aggValues[aggField.AggType] = rollupValue(results[idx+1])
+	}
+	// min/max/avg answered by hidden fields from rollup data
+	assert.Equal(t, 2.0, aggValues[field.Min])
+	assert.Equal(t, 8.0, aggValues[field.Max])
+	assert.Equal(t, 13.0/3, aggValues[field.Sum]/aggValues[field.Count])
+	// gauge self only keeps one value of the rollup slot, cannot answer avg
+	assert.NotEqual(t, 13.0/3, gaugeValue)
+}
+
+func mockFieldValues(start uint16, values []float64, valueFn func(value float64) float64) []byte {
+	encoder := encoding.NewTSDEncoder(start)
+	for _, value := range values {
+		if valueFn != nil {
+			value = valueFn(value)
+		}
+		encoder.AppendTime(bit.One)
+		encoder.AppendValue(math.Float64bits(value))
+	}
+	data, _ := encoder.BytesWithoutTime()
+	return data
 }
 
 func mockField(start uint16) []byte {
@@ -0,0 +1,223 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package metricsdata
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/lindb/roaring"
+
+	"github.com/lindb/lindb/flow"
+	"github.com/lindb/lindb/kv/table"
+	"github.com/lindb/lindb/pkg/bit"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/stream"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/series/field"
+)
+
+// tombstoneMagic is the tail of tombstone block, which distinguishes tombstone block from metric block,
+// metric block ends with high offsets position(4 bytes)+crc32, high offsets position cannot be 0xFFFFFFFF.
+var tombstoneMagic = []byte{0xFF, 0xFF, 0xFF, 0xFF, 'T', 'O', 'M', 'B'}
+
+// Tombstone marks the data of series in slot range[start,end] as deleted,
+// only the data in files which file number <= version is deleted,
+// so that the data written after deleting is kept.
+type Tombstone struct {
+	SeriesIDs *roaring.Bitmap
+	SlotRange timeutil.SlotRange
+	Version   table.FileNumber
+}
+
+// Tombstones represents the tombstones under metric level of data family.
+type Tombstones []Tombstone
+
+// IsTombstones checks if the block of metric is tombstone block.
+func IsTombstones(block []byte) bool {
+	return len(block) >= len(tombstoneMagic) && bytes.Equal(block[len(block)-len(tombstoneMagic):], tombstoneMagic)
+}
+
+// EncodeTombstones encodes the tombstones into binary block.
+// Layout: count(uvarint) + [version(uvarint) start(uint16) end(uint16) len(uvarint) series ids]... + magic
+func EncodeTombstones(tombstones Tombstones) ([]byte, error) {
+	writer := stream.NewBufferWriter(nil)
+	writer.PutUvarint64(uint64(len(tombstones)))
+	for idx := range tombstones {
+		t := &tombstones[idx]
+		data, err := encoding.BitmapMarshal(t.SeriesIDs)
+		if err != nil {
+			return nil, err
+		}
+		writer.PutUvarint64(uint64(t.Version))
+		writer.PutUInt16(t.SlotRange.Start)
+		writer.PutUInt16(t.SlotRange.End)
+		writer.PutUvarint64(uint64(len(data)))
+		writer.PutBytes(data)
+	}
+	writer.PutBytes(tombstoneMagic)
+	return writer.Bytes()
+}
+
+// DecodeTombstones decodes the tombstones from binary block, appends them into dst.
+func DecodeTombstones(dst Tombstones, block []byte) (Tombstones, error) {
+	if !IsTombstones(block) {
+		return dst, fmt.Errorf("decode tombstones failure: bad magic")
+	}
+	reader := stream.NewReader(block[:len(block)-len(tombstoneMagic)])
+	count := reader.ReadUvarint64()
+	for i := uint64(0); i < count && reader.Error() == nil; i++ {
+		var t Tombstone
+		t.Version = table.FileNumber(reader.ReadUvarint64())
+		t.SlotRange.Start = reader.ReadUint16()
+		t.SlotRange.End = reader.ReadUint16()
+		data := reader.ReadSlice(int(reader.ReadUvarint64()))
+		if reader.Error() != nil {
+			break
+		}
+		t.SeriesIDs = roaring.New()
+		if err := t.SeriesIDs.UnmarshalBinary(data); err != nil {
+			return dst, fmt.Errorf("decode tombstones failure: %w", err)
+		}
+		dst = append(dst, t)
+	}
+	if err := reader.Error(); err != nil {
+		return dst, fmt.Errorf("decode tombstones failure: %w", err)
+	}
+	return dst, nil
+}
+
+// SeriesIDs returns all series ids which have deleted data.
+func (ts Tombstones) SeriesIDs() *roaring.Bitmap {
+	seriesIDs := roaring.New()
+	for idx := range ts {
+		seriesIDs.Or(ts[idx].SeriesIDs)
+	}
+	return seriesIDs
+}
+
+// ForFile returns the tombstones which apply to the data of file.
+func (ts Tombstones) ForFile(fileNumber table.FileNumber) (rs Tombstones) {
+	for idx := range ts {
+		if ts[idx].Version >= fileNumber {
+			rs = append(rs, ts[idx])
+		}
+	}
+	return
+}
+
+// deletedRanges returns the deleted slot ranges of series.
+func (ts Tombstones) deletedRanges(seriesID uint32) (ranges []timeutil.SlotRange) {
+	for idx := range ts {
+		if ts[idx].SeriesIDs.Contains(seriesID) {
+			ranges = append(ranges, ts[idx].SlotRange)
+		}
+	}
+	return
+}
+
+// isDeleted checks if slot in the deleted slot ranges.
+func isDeleted(ranges []timeutil.SlotRange, slot uint16) bool {
+	for _, r := range ranges {
+		if slot >= r.Start && slot <= r.End {
+			return true
+		}
+	}
+	return false
+}
+
+// covers checks if the deleted slot ranges cover the whole slot range.
+func covers(ranges []timeutil.SlotRange, slotRange timeutil.SlotRange) bool {
+	if len(ranges) == 0 {
+		return false
+	}
+	for slot := int(slotRange.Start); slot <= int(slotRange.End); slot++ {
+		if !isDeleted(ranges, uint16(slot)) {
+			return false
+		}
+	}
+	return true
+}
+
+// purgeSlots removes the values in deleted slot ranges from field data(without time range),
+// returns nil if no value remains.
+func purgeSlots(data []byte, slotRange timeutil.SlotRange, ranges []timeutil.SlotRange) []byte {
+	if len(data) == 0 {
+		return nil
+	}
+	decoder := encoding.GetTSDDecoder()
+	defer encoding.ReleaseTSDDecoder(decoder)
+	decoder.ResetWithTimeRange(data, slotRange.Start, slotRange.End)
+
+	encoder := encoding.NewTSDEncoder(slotRange.Start)
+	found := false
+	for slot := int(slotRange.Start); slot <= int(slotRange.End); slot++ {
+		if !decoder.HasValueWithSlot(uint16(slot)) {
+			encoder.AppendTime(bit.Zero)
+			continue
+		}
+		value := decoder.Value()
+		if isDeleted(ranges, uint16(slot)) {
+			encoder.AppendTime(bit.Zero)
+			continue
+		}
+		found = true
+		encoder.AppendTime(bit.One)
+		encoder.AppendValue(value)
+	}
+	if !found {
+		return nil
+	}
+	result, err := encoder.BytesWithoutTime()
+	if err != nil {
+		return nil
+	}
+	return result
+}
+
+// tombstoneFieldReader wraps FieldReader, purges the deleted slots of field data.
+type tombstoneFieldReader struct {
+	FieldReader
+	ranges []timeutil.SlotRange
+}
+
+// GetFieldData returns the field data without deleted slots.
+func (r *tombstoneFieldReader) GetFieldData(fieldID field.ID) []byte {
+	return purgeSlots(r.FieldReader.GetFieldData(fieldID), r.SlotRange(), r.ranges)
+}
+
+// tombstoneDataLoader wraps flow.DataLoader, purges the deleted slots of series data.
+type tombstoneDataLoader struct {
+	loader     flow.DataLoader
+	highKey    uint16
+	tombstones Tombstones
+}
+
+// Load loads the series data without deleted slots.
+func (l *tombstoneDataLoader) Load(lowSeriesID uint16) (timeutil.SlotRange, [][]byte) {
+	slotRange, fields := l.loader.Load(lowSeriesID)
+	ranges := l.tombstones.deletedRanges(encoding.ValueWithHighLowBits(uint32(l.highKey)<<16, lowSeriesID))
+	if len(ranges) == 0 || len(fields) == 0 {
+		return slotRange, fields
+	}
+	rs := make([][]byte, len(fields))
+	for idx, data := range fields {
+		rs[idx] = purgeSlots(data, slotRange, ranges)
+	}
+	return slotRange, rs
+}
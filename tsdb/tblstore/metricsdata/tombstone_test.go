@@ -0,0 +1,142 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package metricsdata
+
+import (
+	"math"
+	"testing"
+
+	"github.com/lindb/roaring"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/kv/table"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/series/field"
+)
+
+func TestTombstones_Encode_Decode(t *testing.T) {
+	block, err := EncodeTombstones(Tombstones{
+		{SeriesIDs: roaring.BitmapOf(1, 2), SlotRange: timeutil.NewSlotRange(5, 10), Version: 10},
+		{SeriesIDs: roaring.BitmapOf(3), SlotRange: timeutil.NewSlotRange(1, 1), Version: 5},
+	})
+	assert.NoError(t, err)
+	assert.True(t, IsTombstones(block))
+	assert.False(t, IsTombstones(mockMetricBlock()))
+	assert.False(t, IsTombstones(nil))
+
+	tombstones, err := DecodeTombstones(nil, block)
+	assert.NoError(t, err)
+	assert.Len(t, tombstones, 2)
+	assert.Equal(t, timeutil.NewSlotRange(5, 10), tombstones[0].SlotRange)
+	assert.Equal(t, table.FileNumber(10), tombstones[0].Version)
+	assert.Equal(t, table.FileNumber(5), tombstones[1].Version)
+	assert.Equal(t, roaring.BitmapOf(1, 2, 3).ToArray(), tombstones.SeriesIDs().ToArray())
+	assert.Equal(t, []timeutil.SlotRange{{Start: 5, End: 10}}, tombstones.deletedRanges(1))
+	assert.Empty(t, tombstones.deletedRanges(100))
+
+	// bad block
+	_, err = DecodeTombstones(nil, []byte{1, 2, 3})
+	assert.Error(t, err)
+	_, err = DecodeTombstones(nil, append([]byte{1, 1, 1, 0, 2, 0, 100}, tombstoneMagic...))
+	assert.Error(t, err)
+}
+
+func TestTombstones_ForFile(t *testing.T) {
+	tombstones := Tombstones{
+		{SeriesIDs: roaring.BitmapOf(1), SlotRange: timeutil.NewSlotRange(5, 10), Version: 10},
+		{SeriesIDs: roaring.BitmapOf(2), SlotRange: timeutil.NewSlotRange(1, 1), Version: 5},
+	}
+	assert.Len(t, tombstones.ForFile(1), 2)
+	assert.Len(t, tombstones.ForFile(5), 2)
+	rs := tombstones.ForFile(6)
+	assert.Len(t, rs, 1)
+	assert.Equal(t, []uint32{1}, rs.SeriesIDs().ToArray())
+	assert.Empty(t, tombstones.ForFile(11))
+}
+
+func TestTombstones_covers(t *testing.T) {
+	assert.False(t, covers(nil, timeutil.NewSlotRange(1, 2)))
+	assert.True(t, covers([]timeutil.SlotRange{{Start: 1, End: 5}}, timeutil.NewSlotRange(1, 2)))
+	assert.True(t, covers([]timeutil.SlotRange{{Start: 1, End: 2}, {Start: 3, End: 4}}, timeutil.NewSlotRange(1, 4)))
+	assert.False(t, covers([]timeutil.SlotRange{{Start: 1, End: 2}, {Start: 4, End: 4}}, timeutil.NewSlotRange(1, 4)))
+}
+
+func TestTombstones_purgeSlots(t *testing.T) {
+	assert.Nil(t, purgeSlots(nil, timeutil.NewSlotRange(1, 2), nil))
+	data := mockTSDData(5, 10)
+	assert.Nil(t, purgeSlots(data, timeutil.NewSlotRange(5, 10), []timeutil.SlotRange{{Start: 0, End: 20}}))
+
+	data = purgeSlots(data, timeutil.NewSlotRange(5, 10), []timeutil.SlotRange{{Start: 6, End: 8}})
+	decoder := encoding.NewTSDDecoder(nil)
+	decoder.ResetWithTimeRange(data, 5, 10)
+	var slots []uint16
+	for slot := uint16(5); slot <= 10; slot++ {
+		if decoder.HasValueWithSlot(slot) {
+			assert.Equal(t, float64(slot), math.Float64frombits(decoder.Value()))
+			slots = append(slots, slot)
+		}
+	}
+	assert.Equal(t, []uint16{5, 9, 10}, slots)
+}
+
+func TestTombstones_Filter(t *testing.T) {
+	block := mockRealMetricBlock([]uint32{1, 2, 3}, 5, 10)
+	reader, err := NewReader("test", block)
+	assert.NoError(t, err)
+	fields := reader.GetFields()
+	filter := NewFilter(10, nil, []MetricReader{reader}, []Tombstones{{
+		{SeriesIDs: roaring.BitmapOf(1), SlotRange: timeutil.NewSlotRange(0, 20)},
+		{SeriesIDs: roaring.BitmapOf(2), SlotRange: timeutil.NewSlotRange(5, 9)},
+	}})
+	rs, err := filter.Filter(roaring.BitmapOf(1, 2, 3), fields)
+	assert.NoError(t, err)
+	assert.Len(t, rs, 1)
+	assert.Equal(t, []uint32{2, 3}, rs[0].SeriesIDs().ToArray())
+
+	loader := rs[0].Load(0, rs[0].SeriesIDs().GetContainer(0))
+	_, data := loader.Load(2)
+	assert.Len(t, data, 2)
+	decoder := encoding.NewTSDDecoder(nil)
+	decoder.ResetWithTimeRange(data[0], 5, 10)
+	for slot := uint16(5); slot <= 9; slot++ {
+		assert.False(t, decoder.HasValueWithSlot(slot))
+	}
+	assert.True(t, decoder.HasValueWithSlot(10))
+	_, data = loader.Load(3)
+	assert.Len(t, data, 2)
+	assert.Nil(t, rs[0].Load(10, rs[0].SeriesIDs().GetContainer(0)))
+
+	// all series deleted
+	filter = NewFilter(10, nil, []MetricReader{reader}, []Tombstones{{
+		{SeriesIDs: roaring.BitmapOf(1, 2, 3), SlotRange: timeutil.NewSlotRange(0, 20)},
+	}})
+	rs, err = filter.Filter(roaring.BitmapOf(1, 2, 3), field.Metas{{ID: 2, Type: field.SumField}})
+	assert.Error(t, err)
+	assert.Nil(t, rs)
+}
+
+func mockTSDData(start, end uint16) []byte {
+	encoder := encoding.NewTSDEncoder(start)
+	for i := start; i <= end; i++ {
+		encoder.AppendTime(true)
+		encoder.AppendValue(math.Float64bits(float64(i)))
+	}
+	data, _ := encoder.BytesWithoutTime()
+	return data
+}
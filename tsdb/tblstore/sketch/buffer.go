@@ -0,0 +1,210 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package sketch
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/lindb/roaring"
+
+	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/timeutil"
+)
+
+// slotKey represents the series/slot which sketch belongs to.
+type slotKey struct {
+	seriesID  uint32
+	timestamp int64
+}
+
+// Buffer holds the written sketches of each metric in memory before flushing,
+// the sketches of same series/slot are merged into one sketch.
+type Buffer struct {
+	sketches map[uint32]map[slotKey]*encoding.Sketch // metric id => slot => sketch
+	flushing map[uint32]map[slotKey]*encoding.Sketch // sketches being flushed, visible until flush completed
+	mutex    sync.RWMutex
+
+	flushLock sync.Mutex
+}
+
+// NewBuffer creates a sketch buffer.
+func NewBuffer() *Buffer {
+	return &Buffer{
+		sketches: make(map[uint32]map[slotKey]*encoding.Sketch),
+	}
+}
+
+// Add merges the sketch of series/slot into buffer, the sketch is copied so that caller can reuse it.
+func (b *Buffer) Add(metricID, seriesID uint32, timestamp int64, s *encoding.Sketch) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.add(metricID, slotKey{seriesID: seriesID, timestamp: timestamp}, s)
+}
+
+// add merges the sketch of metric into buffer without lock.
+func (b *Buffer) add(metricID uint32, key slotKey, s *encoding.Sketch) {
+	slots, ok := b.sketches[metricID]
+	if !ok {
+		slots = make(map[slotKey]*encoding.Sketch)
+		b.sketches[metricID] = slots
+	}
+	buffered, ok := slots[key]
+	if !ok {
+		buffered = encoding.NewSketch()
+		slots[key] = buffered
+	}
+	buffered.Merge(s)
+}
+
+// Find returns the copy of buffered sketches of metric which match the series ids and time range,
+// includes the sketches being flushed.
+func (b *Buffer) Find(
+	metricID uint32,
+	seriesIDs *roaring.Bitmap,
+	timeRange timeutil.TimeRange,
+) (rs []Sketch) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	find := func(slots map[slotKey]*encoding.Sketch) {
+		for key, s := range slots {
+			if timeRange.Contains(key.timestamp) && seriesIDs.Contains(key.seriesID) {
+				data := encoding.NewSketch()
+				data.Merge(s)
+				rs = append(rs, Sketch{SeriesID: key.seriesID, Timestamp: key.timestamp, Data: data})
+			}
+		}
+	}
+	find(b.sketches[metricID])
+	find(b.flushing[metricID])
+	return rs
+}
+
+// IsEmpty checks if there is no buffered sketch.
+func (b *Buffer) IsEmpty() bool {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	return len(b.sketches) == 0
+}
+
+// FlushTo flushes all buffered sketches into kv stores, the sketches are partitioned by the partition
+// of timestamp(e.g. segment which sketch belongs to), newFlusher returns the flusher of partition,
+// the sketches of partition are dropped if newFlusher returns nil flusher(e.g. segment expired).
+// The flushing sketches are still visible for query until flush completed,
+// and the sketches not flushed are merged back into buffer if flush failure.
+func (b *Buffer) FlushTo(
+	partition func(timestamp int64) int64,
+	newFlusher func(partition int64) (kv.Flusher, error),
+) error {
+	b.flushLock.Lock()
+	defer b.flushLock.Unlock()
+
+	b.mutex.Lock()
+	flushing := b.sketches
+	b.flushing = flushing
+	b.sketches = make(map[uint32]map[slotKey]*encoding.Sketch)
+	b.mutex.Unlock()
+
+	partitions := make(map[int64]map[uint32]map[slotKey]*encoding.Sketch)
+	for metricID, slots := range flushing {
+		for key, s := range slots {
+			p := partition(key.timestamp)
+			metrics, ok := partitions[p]
+			if !ok {
+				metrics = make(map[uint32]map[slotKey]*encoding.Sketch)
+				partitions[p] = metrics
+			}
+			if _, ok := metrics[metricID]; !ok {
+				metrics[metricID] = make(map[slotKey]*encoding.Sketch)
+			}
+			metrics[metricID][key] = s
+		}
+	}
+	var err error
+	for p, buffered := range partitions {
+		var flusher kv.Flusher
+		if flusher, err = newFlusher(p); err != nil {
+			break
+		}
+		if flusher != nil {
+			if err = flush(flusher, buffered); err != nil {
+				break
+			}
+		}
+		delete(partitions, p)
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.flushing = nil
+	// merge back the sketches not flushed, retry in next flush
+	for _, buffered := range partitions {
+		for metricID, slots := range buffered {
+			for key, s := range slots {
+				b.add(metricID, key, s)
+			}
+		}
+	}
+	return err
+}
+
+// flush writes the sketches of each metric into kv store.
+func flush(flusher kv.Flusher, buffered map[uint32]map[slotKey]*encoding.Sketch) error {
+	if len(buffered) == 0 {
+		return nil
+	}
+	metricIDs := make([]uint32, 0, len(buffered))
+	for metricID := range buffered {
+		metricIDs = append(metricIDs, metricID)
+	}
+	// kv store requires keys in order
+	sort.Slice(metricIDs, func(i, j int) bool { return metricIDs[i] < metricIDs[j] })
+	var list []Sketch
+	for _, metricID := range metricIDs {
+		list = list[:0]
+		for key, s := range buffered[metricID] {
+			list = append(list, Sketch{SeriesID: key.seriesID, Timestamp: key.timestamp, Data: s})
+		}
+		block, err := Encode(Merge(list))
+		if err != nil {
+			return err
+		}
+		if err := flusher.Add(metricID, block); err != nil {
+			return err
+		}
+	}
+	return flusher.Commit()
+}
+
+// filter appends the sketches which match the series ids and time range into dst.
+func filter(
+	dst, list []Sketch,
+	seriesIDs *roaring.Bitmap,
+	timeRange timeutil.TimeRange,
+) []Sketch {
+	for idx := range list {
+		if timeRange.Contains(list[idx].Timestamp) && seriesIDs.Contains(list[idx].SeriesID) {
+			dst = append(dst, list[idx])
+		}
+	}
+	return dst
+}
@@ -0,0 +1,122 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package sketch
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lindb/roaring"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/pkg/timeutil"
+)
+
+func TestBuffer_Add_Find(t *testing.T) {
+	buf := NewBuffer()
+	s := newSketch(1, 2)
+	buf.Add(1, 1, 10, s)
+	// sketch is copied
+	s.Add(3)
+	buf.Add(1, 1, 10, newSketch(4))
+	buf.Add(1, 2, 20, newSketch(5))
+
+	rs := buf.Find(1, roaring.BitmapOf(1), timeutil.TimeRange{Start: 0, End: 100})
+	assert.Len(t, rs, 1)
+	assert.Equal(t, newSketch(1, 2, 4), rs[0].Data)
+	// found sketch is copied
+	rs[0].Data.Add(100)
+	assert.Equal(t, newSketch(1, 2, 4), buf.Find(1, roaring.BitmapOf(1), timeutil.TimeRange{Start: 0, End: 100})[0].Data)
+	assert.Len(t, buf.Find(1, roaring.BitmapOf(1, 2), timeutil.TimeRange{Start: 0, End: 100}), 2)
+	assert.Len(t, buf.Find(1, roaring.BitmapOf(1, 2), timeutil.TimeRange{Start: 15, End: 100}), 1)
+	assert.Empty(t, buf.Find(2, roaring.BitmapOf(1, 2), timeutil.TimeRange{Start: 0, End: 100}))
+}
+
+func TestBuffer_FlushTo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buf := NewBuffer()
+	flusher := kv.NewMockFlusher(ctrl)
+	// partition by 100ms
+	partition := func(timestamp int64) int64 { return timestamp / 100 * 100 }
+	flushers := func(p int64) (kv.Flusher, error) {
+		assert.Equal(t, int64(0), p)
+		return flusher, nil
+	}
+	// empty buffer
+	assert.True(t, buf.IsEmpty())
+	assert.NoError(t, buf.FlushTo(partition, flushers))
+
+	buf.Add(2, 1, 10, newSketch(1))
+	buf.Add(1, 1, 10, newSketch(1))
+	assert.False(t, buf.IsEmpty())
+	gomock.InOrder(
+		flusher.EXPECT().Add(uint32(1), gomock.Any()).DoAndReturn(func(_ uint32, block []byte) error {
+			rs, err := Decode(nil, block)
+			assert.NoError(t, err)
+			assert.Len(t, rs, 1)
+			return nil
+		}),
+		flusher.EXPECT().Add(uint32(2), gomock.Any()).Return(nil),
+		flusher.EXPECT().Commit().DoAndReturn(func() error {
+			// sketches are visible during flushing
+			assert.Len(t, buf.Find(1, roaring.BitmapOf(1), timeutil.TimeRange{Start: 0, End: 100}), 1)
+			return nil
+		}),
+	)
+	assert.NoError(t, buf.FlushTo(partition, flushers))
+	assert.Empty(t, buf.Find(1, roaring.BitmapOf(1), timeutil.TimeRange{Start: 0, End: 100}))
+
+	// add failure
+	buf.Add(1, 1, 10, newSketch(1))
+	flusher.EXPECT().Add(gomock.Any(), gomock.Any()).Return(fmt.Errorf("err"))
+	assert.Error(t, buf.FlushTo(partition, flushers))
+	// commit failure
+	flusher.EXPECT().Add(gomock.Any(), gomock.Any()).Return(nil)
+	flusher.EXPECT().Commit().Return(fmt.Errorf("err"))
+	assert.Error(t, buf.FlushTo(partition, flushers))
+	// sketches are merged back after flush failure
+	buf.Add(1, 1, 10, newSketch(2))
+	assert.False(t, buf.IsEmpty())
+	rs := buf.Find(1, roaring.BitmapOf(1), timeutil.TimeRange{Start: 0, End: 100})
+	assert.Len(t, rs, 1)
+	assert.Equal(t, newSketch(1, 2), rs[0].Data)
+	// new flusher failure
+	assert.Error(t, buf.FlushTo(partition, func(_ int64) (kv.Flusher, error) {
+		return nil, fmt.Errorf("err")
+	}))
+	assert.False(t, buf.IsEmpty())
+
+	// flush each partition into its flusher, drop the sketches of partition without flusher
+	buf.Add(1, 1, 110, newSketch(1))
+	buf.Add(1, 1, 210, newSketch(1))
+	flusher.EXPECT().Add(uint32(1), gomock.Any()).Return(nil).Times(2)
+	flusher.EXPECT().Commit().Return(nil).Times(2)
+	var partitions []int64
+	assert.NoError(t, buf.FlushTo(partition, func(p int64) (kv.Flusher, error) {
+		partitions = append(partitions, p)
+		if p == 200 {
+			return nil, nil
+		}
+		return flusher, nil
+	}))
+	assert.ElementsMatch(t, []int64{0, 100, 200}, partitions)
+	assert.True(t, buf.IsEmpty())
+}
@@ -0,0 +1,119 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package sketch
+
+import (
+	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/kv/table"
+	"github.com/lindb/lindb/tsdb/tblstore/exemplar"
+)
+
+// MergerName represents the merger name of sketch family.
+var MergerName kv.MergerType = "SketchMerger"
+
+// init registers sketch merger create function
+func init() {
+	kv.RegisterMerger(MergerName, NewMerger)
+}
+
+// merger implements kv.Merger for merging sketches of each metric.
+type merger struct {
+	kvFlusher kv.Flusher
+	rollup    kv.Rollup
+}
+
+// NewMerger creates a merger for compacting sketches.
+func NewMerger(kvFlusher kv.Flusher) (kv.Merger, error) {
+	return &merger{
+		kvFlusher: kvFlusher,
+	}, nil
+}
+
+// Init initializes the merger, sets the rollup relation if merger is used by rollup job.
+func (m *merger) Init(params map[string]interface{}) {
+	rollupCtx, ok := params[kv.RollupContext]
+	if ok {
+		m.rollup = rollupCtx.(kv.Rollup)
+	}
+}
+
+// Merge merges the sketches of same metric, the sketches of same series/slot are merged into one sketch,
+// the sketches deleted by tombstones are purged, and tombstones are dropped after merge.
+func (m *merger) Merge(metricID uint32, dataBlocks [][]byte) error {
+	return m.MergeVersioned(metricID, nil, dataBlocks)
+}
+
+// MergeVersioned merges the sketches like Merge, but tombstone only purges the sketches
+// which come from file whose number <= tombstone's version, if file numbers is nil, all tombstones apply.
+// If rollup, the timestamp of sketch is truncated to the slot of target interval, so that the sketches
+// in same target slot are merged into one sketch.
+func (m *merger) MergeVersioned(metricID uint32, fileNumbers []table.FileNumber, dataBlocks [][]byte) error {
+	var (
+		tombstones exemplar.Tombstones
+		err        error
+	)
+	for _, block := range dataBlocks {
+		if exemplar.IsTombstones(block) {
+			if tombstones, err = exemplar.DecodeTombstones(tombstones, block); err != nil {
+				return err
+			}
+		}
+	}
+	var list, rs []Sketch
+	for idx, block := range dataBlocks {
+		if exemplar.IsTombstones(block) {
+			continue
+		}
+		if list, err = Decode(list[:0], block); err != nil {
+			return err
+		}
+		if fileNumbers == nil {
+			rs = append(rs, purge(tombstones, list)...)
+		} else {
+			rs = append(rs, purge(tombstones.ForFile(fileNumbers[idx]), list)...)
+		}
+	}
+	if len(rs) == 0 {
+		// all sketches are deleted
+		return nil
+	}
+	if m.rollup != nil {
+		for idx := range rs {
+			rs[idx].Timestamp = m.rollup.TruncateTimestamp(rs[idx].Timestamp)
+		}
+	}
+	block, err := Encode(Merge(rs))
+	if err != nil {
+		return err
+	}
+	return m.kvFlusher.Add(metricID, block)
+}
+
+// purge removes the sketches deleted by tombstones from list in place,
+// sketch family shares the tombstone format with exemplar family.
+func purge(tombstones exemplar.Tombstones, list []Sketch) []Sketch {
+	if len(tombstones) == 0 {
+		return list
+	}
+	result := list[:0]
+	for idx := range list {
+		if !tombstones.IsDeleted(list[idx].SeriesID, list[idx].Timestamp) {
+			result = append(result, list[idx])
+		}
+	}
+	return result
+}
@@ -0,0 +1,121 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package sketch
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lindb/roaring"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/kv/table"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/tsdb/tblstore/exemplar"
+)
+
+func TestMerger_Merge(t *testing.T) {
+	nopFlusher := kv.NewNopFlusher()
+	merger, err := NewMerger(nopFlusher)
+	assert.NoError(t, err)
+	merger.Init(nil)
+
+	var blocks [][]byte
+	for i := 1; i <= 5; i++ {
+		block, err := Encode([]Sketch{{SeriesID: 1, Timestamp: 10, Data: newSketch(float64(i))}})
+		assert.NoError(t, err)
+		blocks = append(blocks, block)
+	}
+	assert.NoError(t, merger.Merge(1, blocks))
+	rs, err := Decode(nil, nopFlusher.Bytes())
+	assert.NoError(t, err)
+	assert.Len(t, rs, 1)
+	assert.Equal(t, newSketch(1, 2, 3, 4, 5), rs[0].Data)
+
+	// bad block
+	assert.Error(t, merger.Merge(1, [][]byte{{1, 2, 3}}))
+}
+
+func TestMerger_Rollup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	nopFlusher := kv.NewNopFlusher()
+	merger, err := NewMerger(nopFlusher)
+	assert.NoError(t, err)
+	rollup := kv.NewMockRollup(ctrl)
+	merger.Init(map[string]interface{}{kv.RollupContext: rollup})
+	// 10s => 5min
+	rollup.EXPECT().TruncateTimestamp(gomock.Any()).DoAndReturn(func(timestamp int64) int64 {
+		return timestamp / timeutil.OneMinute / 5 * 5 * timeutil.OneMinute
+	}).AnyTimes()
+
+	block1, err := Encode([]Sketch{
+		{SeriesID: 1, Timestamp: 10 * timeutil.OneSecond, Data: newSketch(1)},
+		{SeriesID: 1, Timestamp: 5 * timeutil.OneMinute, Data: newSketch(2)},
+	})
+	assert.NoError(t, err)
+	block2, err := Encode([]Sketch{{SeriesID: 1, Timestamp: 20 * timeutil.OneSecond, Data: newSketch(3)}})
+	assert.NoError(t, err)
+	assert.NoError(t, merger.Merge(1, [][]byte{block1, block2}))
+	rs, err := Decode(nil, nopFlusher.Bytes())
+	assert.NoError(t, err)
+	assert.Len(t, rs, 2)
+	assert.Equal(t, int64(0), rs[0].Timestamp)
+	assert.Equal(t, newSketch(1, 3), rs[0].Data)
+	assert.Equal(t, 5*timeutil.OneMinute, rs[1].Timestamp)
+	assert.Equal(t, newSketch(2), rs[1].Data)
+}
+
+func TestMerger_MergeVersioned(t *testing.T) {
+	nopFlusher := kv.NewNopFlusher()
+	m, err := NewMerger(nopFlusher)
+	assert.NoError(t, err)
+
+	oldBlock, err := Encode([]Sketch{
+		{SeriesID: 1, Timestamp: 10, Data: newSketch(1)},
+		{SeriesID: 2, Timestamp: 10, Data: newSketch(2)},
+	})
+	assert.NoError(t, err)
+	newBlock, err := Encode([]Sketch{{SeriesID: 1, Timestamp: 10, Data: newSketch(3)}})
+	assert.NoError(t, err)
+	tombstone, err := exemplar.EncodeTombstones(exemplar.Tombstones{{
+		SeriesIDs: roaring.BitmapOf(1), TimeRange: timeutil.TimeRange{Start: 0, End: 100}, Version: 1,
+	}})
+	assert.NoError(t, err)
+
+	// tombstone only purges the sketches of file written before deleting
+	merger := m.(kv.VersionedMerger)
+	assert.NoError(t, merger.MergeVersioned(1, []table.FileNumber{1, 2, 3},
+		[][]byte{oldBlock, tombstone, newBlock}))
+	rs, err := Decode(nil, nopFlusher.Bytes())
+	assert.NoError(t, err)
+	assert.Len(t, rs, 2)
+	assert.Equal(t, newSketch(3), rs[0].Data)
+	assert.Equal(t, newSketch(2), rs[1].Data)
+	// all sketches are deleted, tombstones are dropped
+	nopFlusher = kv.NewNopFlusher()
+	m, err = NewMerger(nopFlusher)
+	assert.NoError(t, err)
+	assert.NoError(t, m.Merge(1, [][]byte{tombstone, newBlock}))
+	assert.Empty(t, nopFlusher.Bytes())
+	// bad tombstone
+	badTombstone, err := exemplar.EncodeTombstones(nil)
+	assert.NoError(t, err)
+	assert.Error(t, m.Merge(1, [][]byte{badTombstone[:len(badTombstone)-1]}))
+}
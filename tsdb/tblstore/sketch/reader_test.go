@@ -0,0 +1,104 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package sketch
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lindb/roaring"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/kv/table"
+	"github.com/lindb/lindb/kv/version"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/tsdb/tblstore/exemplar"
+)
+
+func TestRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	block, err := Encode([]Sketch{
+		{SeriesID: 1, Timestamp: 10, Data: newSketch(1)},
+		{SeriesID: 2, Timestamp: 10, Data: newSketch(2)},
+	})
+	assert.NoError(t, err)
+	newBlock, err := Encode([]Sketch{{SeriesID: 2, Timestamp: 20, Data: newSketch(3)}})
+	assert.NoError(t, err)
+	tombstone, err := exemplar.EncodeTombstones(exemplar.Tombstones{{
+		SeriesIDs: roaring.BitmapOf(2), TimeRange: timeutil.TimeRange{Start: 0, End: 100}, Version: 1,
+	}})
+	assert.NoError(t, err)
+	snapshot := version.NewMockSnapshot(ctrl)
+	v := version.NewMockVersion(ctrl)
+	snapshot.EXPECT().GetCurrent().Return(v).AnyTimes()
+	reader1 := table.NewMockReader(ctrl)
+	reader2 := table.NewMockReader(ctrl)
+	v.EXPECT().FindFiles(uint32(1)).Return([]*version.FileMeta{
+		version.NewFileMeta(1, 1, 1, 100),
+		version.NewFileMeta(2, 1, 1, 100),
+	}).AnyTimes()
+	snapshot.EXPECT().GetReader(table.FileNumber(1)).Return(reader1, nil).AnyTimes()
+	snapshot.EXPECT().GetReader(table.FileNumber(2)).Return(reader2, nil).AnyTimes()
+	timeRange := timeutil.TimeRange{Start: 0, End: 100}
+
+	// case 1: read ok
+	reader1.EXPECT().Get(uint32(1)).Return(block, nil)
+	reader2.EXPECT().Get(uint32(1)).Return(nil, table.ErrKeyNotExist)
+	rs, err := Read(snapshot, 1, roaring.BitmapOf(2), timeRange)
+	assert.NoError(t, err)
+	assert.Len(t, rs, 1)
+	assert.Equal(t, newSketch(2), rs[0].Data)
+	// case 2: tombstone deletes the sketches of file which written before deleting
+	reader1.EXPECT().Get(uint32(1)).Return(block, nil)
+	reader2.EXPECT().Get(uint32(1)).Return(tombstone, nil)
+	rs, err = Read(snapshot, 1, roaring.BitmapOf(1, 2), timeRange)
+	assert.NoError(t, err)
+	assert.Len(t, rs, 1)
+	assert.Equal(t, newSketch(1), rs[0].Data)
+	reader1.EXPECT().Get(uint32(1)).Return(tombstone, nil)
+	reader2.EXPECT().Get(uint32(1)).Return(newBlock, nil)
+	rs, err = Read(snapshot, 1, roaring.BitmapOf(1, 2), timeRange)
+	assert.NoError(t, err)
+	assert.Len(t, rs, 1)
+	assert.Equal(t, newSketch(3), rs[0].Data)
+	// case 3: get failure
+	reader1.EXPECT().Get(uint32(1)).Return(nil, fmt.Errorf("err"))
+	_, err = Read(snapshot, 1, roaring.BitmapOf(2), timeRange)
+	assert.Error(t, err)
+	// case 4: decode failure
+	reader1.EXPECT().Get(uint32(1)).Return([]byte{1, 2, 3}, nil)
+	reader2.EXPECT().Get(uint32(1)).Return(newBlock, nil)
+	_, err = Read(snapshot, 1, roaring.BitmapOf(2), timeRange)
+	assert.Error(t, err)
+	// case 5: decode tombstone failure
+	badTombstone, err := exemplar.EncodeTombstones(nil)
+	assert.NoError(t, err)
+	reader1.EXPECT().Get(uint32(1)).Return(badTombstone[:len(badTombstone)-1], nil)
+	_, err = Read(snapshot, 1, roaring.BitmapOf(2), timeRange)
+	assert.Error(t, err)
+	// case 6: get reader failure
+	v2 := version.NewMockVersion(ctrl)
+	snapshot2 := version.NewMockSnapshot(ctrl)
+	snapshot2.EXPECT().GetCurrent().Return(v2).AnyTimes()
+	v2.EXPECT().FindFiles(uint32(1)).Return([]*version.FileMeta{version.NewFileMeta(1, 1, 1, 100)})
+	snapshot2.EXPECT().GetReader(table.FileNumber(1)).Return(nil, fmt.Errorf("err"))
+	_, err = Read(snapshot2, 1, roaring.BitmapOf(2), timeRange)
+	assert.Error(t, err)
+}
@@ -0,0 +1,102 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package sketch
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/stream"
+)
+
+// Sketch represents the quantile sketch of compound field, which belongs to one time slot of series.
+type Sketch struct {
+	SeriesID  uint32
+	Timestamp int64 // start time of slot which sketch belongs to
+	Data      *encoding.Sketch
+}
+
+func (s *Sketch) sameSlot(o *Sketch) bool {
+	return s.SeriesID == o.SeriesID && s.Timestamp == o.Timestamp
+}
+
+// sketches implements sort.Interface, sorted by series id/timestamp.
+type sketches []Sketch
+
+func (ss sketches) Len() int      { return len(ss) }
+func (ss sketches) Swap(i, j int) { ss[i], ss[j] = ss[j], ss[i] }
+func (ss sketches) Less(i, j int) bool {
+	if ss[i].SeriesID != ss[j].SeriesID {
+		return ss[i].SeriesID < ss[j].SeriesID
+	}
+	return ss[i].Timestamp < ss[j].Timestamp
+}
+
+// Merge merges the sketches of same slot(series id/timestamp) in place, returns sketches sorted by series id/timestamp.
+func Merge(list []Sketch) []Sketch {
+	sort.Sort(sketches(list))
+	result := list[:0]
+	for idx := range list {
+		if len(result) > 0 && list[idx].sameSlot(&result[len(result)-1]) {
+			result[len(result)-1].Data.Merge(list[idx].Data)
+			continue
+		}
+		result = append(result, list[idx])
+	}
+	return result
+}
+
+// Encode encodes the sketches of metric into block.
+// format: count(uvarint) + [series id(uvarint) + timestamp(varint) + sketch len(uvarint) + sketch]...
+func Encode(list []Sketch) ([]byte, error) {
+	writer := stream.NewBufferWriter(nil)
+	writer.PutUvarint64(uint64(len(list)))
+	for idx := range list {
+		s := &list[idx]
+		data := s.Data.MarshalBinary()
+		writer.PutUvarint32(s.SeriesID)
+		writer.PutVarint64(s.Timestamp)
+		writer.PutUvarint64(uint64(len(data)))
+		writer.PutBytes(data)
+	}
+	return writer.Bytes()
+}
+
+// Decode decodes the sketches from block, appends them into dst.
+func Decode(dst []Sketch, block []byte) ([]Sketch, error) {
+	reader := stream.NewReader(block)
+	count := reader.ReadUvarint64()
+	for i := uint64(0); i < count && reader.Error() == nil; i++ {
+		var s Sketch
+		s.SeriesID = reader.ReadUvarint32()
+		s.Timestamp = reader.ReadVarint64()
+		data := reader.ReadSlice(int(reader.ReadUvarint64()))
+		if reader.Error() != nil {
+			break
+		}
+		s.Data = encoding.NewSketch()
+		if err := s.Data.UnmarshalBinary(data); err != nil {
+			return dst, fmt.Errorf("decode sketches failure: %w", err)
+		}
+		dst = append(dst, s)
+	}
+	if err := reader.Error(); err != nil {
+		return dst, fmt.Errorf("decode sketches failure: %w", err)
+	}
+	return dst, nil
+}
@@ -0,0 +1,69 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package sketch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/encoding"
+)
+
+func newSketch(values ...float64) *encoding.Sketch {
+	s := encoding.NewSketch()
+	for _, v := range values {
+		s.Add(v)
+	}
+	return s
+}
+
+func TestSketch_Encode_Decode(t *testing.T) {
+	block, err := Encode([]Sketch{
+		{SeriesID: 1, Timestamp: 10, Data: newSketch(1, 2, 3)},
+		{SeriesID: 2, Timestamp: 20, Data: newSketch(100)},
+	})
+	assert.NoError(t, err)
+	rs, err := Decode(nil, block)
+	assert.NoError(t, err)
+	assert.Len(t, rs, 2)
+	assert.Equal(t, uint32(1), rs[0].SeriesID)
+	assert.Equal(t, int64(10), rs[0].Timestamp)
+	assert.Equal(t, float64(3), rs[0].Data.Count())
+	assert.Equal(t, uint32(2), rs[1].SeriesID)
+	assert.Equal(t, float64(1), rs[1].Data.Count())
+
+	// bad block
+	_, err = Decode(nil, block[:len(block)-1])
+	assert.Error(t, err)
+	// bad sketch
+	_, err = Decode(nil, []byte{1, 1, 10, 2, 100, 100})
+	assert.Error(t, err)
+}
+
+func TestMerge(t *testing.T) {
+	rs := Merge([]Sketch{
+		{SeriesID: 2, Timestamp: 10, Data: newSketch(1)},
+		{SeriesID: 1, Timestamp: 20, Data: newSketch(1)},
+		{SeriesID: 1, Timestamp: 10, Data: newSketch(1, 2)},
+		{SeriesID: 1, Timestamp: 10, Data: newSketch(3)},
+	})
+	assert.Len(t, rs, 3)
+	assert.Equal(t, Sketch{SeriesID: 1, Timestamp: 10, Data: newSketch(1, 2, 3)}, rs[0])
+	assert.Equal(t, int64(20), rs[1].Timestamp)
+	assert.Equal(t, uint32(2), rs[2].SeriesID)
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/lindb/roaring"
 
 	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/pkg/encoding"
 )
 
 var SeriesForwardMerger kv.MergerType = "SeriesForwardMerger"
@@ -50,12 +51,22 @@ func NewForwardMerger(flusher kv.Flusher) (kv.Merger, error) {
 	}, nil
 }
 
-// Merge merges the multi forward index data into a forward index for same tag key id
+// Merge merges the multi forward index data into a forward index for same tag key id,
+// the deleted series ids are purged, and series tombstones are dropped after merge.
 func (m *forwardMerger) Merge(key uint32, values [][]byte) error {
 	var scanners []*tagForwardScanner
 	seriesIDs := roaring.New() // target merged series ids
+	deletedSeriesIDs := roaring.New()
 	// 1. prepare tag forward scanner
 	for _, value := range values {
+		if IsSeriesTombstone(value) {
+			deleted, err := DecodeSeriesTombstone(value)
+			if err != nil {
+				return err
+			}
+			deletedSeriesIDs.Or(deleted)
+			continue
+		}
 		reader, err := NewTagForwardReader(value)
 		if err != nil {
 			return err
@@ -64,6 +75,11 @@ func (m *forwardMerger) Merge(key uint32, values [][]byte) error {
 		scanners = append(scanners, newTagForwardScanner(reader))
 	}
 
+	targetSeriesIDs := roaring.AndNot(seriesIDs, deletedSeriesIDs)
+	if targetSeriesIDs.IsEmpty() {
+		// all series ids are deleted
+		return nil
+	}
 	// 2. merge forward index by roaring container
 	highKeys := seriesIDs.GetHighKeys()
 	m.forwardFlusher.PrepareTagKey(key)
@@ -74,10 +90,19 @@ func (m *forwardMerger) Merge(key uint32, values [][]byte) error {
 		var tagValueIDs []uint32
 		for it.HasNext() {
 			lowSeriesID := it.Next()
+			pos := len(tagValueIDs)
 			// scan index data then merge tag value ids, sort by series id
 			for _, scanner := range scanners {
 				tagValueIDs = scanner.scan(highKey, lowSeriesID, tagValueIDs)
 			}
+			if deletedSeriesIDs.Contains(encoding.ValueWithHighLowBits(uint32(highKey)<<16, lowSeriesID)) {
+				// drop tag value id of deleted series
+				tagValueIDs = tagValueIDs[:pos]
+			}
+		}
+		if len(tagValueIDs) == 0 {
+			// all series ids of container are deleted
+			continue
 		}
 		// flush tag value ids by one container
 		if err := m.forwardFlusher.FlushForwardIndex(tagValueIDs); err != nil {
@@ -85,5 +110,5 @@ func (m *forwardMerger) Merge(key uint32, values [][]byte) error {
 		}
 	}
 	// flush all series ids under this tag key
-	return m.forwardFlusher.CommitTagKey(seriesIDs)
+	return m.forwardFlusher.CommitTagKey(targetSeriesIDs)
 }
@@ -46,7 +46,7 @@ func NewForwardReader(readers []table.Reader) ForwardReader {
 	}
 }
 
-// GetSeriesIDsForTagKeyID get series ids for spec metric's tag key id
+// GetSeriesIDsForTagKeyID get series ids for spec metric's tag key id, excludes the deleted series ids
 func (r *forwardReader) GetSeriesIDsForTagKeyID(tagKeyID uint32) (*roaring.Bitmap, error) {
 	seriesIDs := roaring.New()
 	if err := r.findReader(tagKeyID, func(reader TagForwardReader) {
@@ -54,6 +54,11 @@ func (r *forwardReader) GetSeriesIDsForTagKeyID(tagKeyID uint32) (*roaring.Bitma
 	}); err != nil {
 		return nil, err
 	}
+	deletedSeriesIDs, err := r.findDeletedSeriesIDs(tagKeyID)
+	if err != nil {
+		return nil, err
+	}
+	seriesIDs.AndNot(deletedSeriesIDs)
 	return seriesIDs, nil
 }
 
@@ -79,7 +84,7 @@ func (r *forwardReader) GetGroupingScanner(tagKeyID uint32, seriesIDs *roaring.B
 func (r *forwardReader) findReader(tagKeyID uint32, callback func(reader TagForwardReader)) error {
 	for _, reader := range r.readers {
 		value, err := reader.Get(tagKeyID)
-		if err != nil {
+		if err != nil || IsSeriesTombstone(value) {
 			continue
 		}
 		indexReader, err := NewTagForwardReader(value)
@@ -92,6 +97,23 @@ func (r *forwardReader) findReader(tagKeyID uint32, callback func(reader TagForw
 	return nil
 }
 
+// findDeletedSeriesIDs returns the deleted series ids under tag key
+func (r *forwardReader) findDeletedSeriesIDs(tagKeyID uint32) (*roaring.Bitmap, error) {
+	deletedSeriesIDs := roaring.New()
+	for _, reader := range r.readers {
+		value, err := reader.Get(tagKeyID)
+		if err != nil || !IsSeriesTombstone(value) {
+			continue
+		}
+		seriesIDs, err := DecodeSeriesTombstone(value)
+		if err != nil {
+			return nil, err
+		}
+		deletedSeriesIDs.Or(seriesIDs)
+	}
+	return deletedSeriesIDs, nil
+}
+
 // TagForwardReader represents the forward index inverterReader for one tag(series id=>tag value id)
 type TagForwardReader interface {
 	series.GroupingScanner
@@ -0,0 +1,83 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package tagindex
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+
+	"github.com/lindb/roaring"
+
+	"github.com/lindb/lindb/pkg/encoding"
+)
+
+// VerifyChecksum verifies the crc32 checksum of index block(forward/inverted),
+// series tombstone block has no checksum.
+func VerifyChecksum(block []byte) error {
+	if IsSeriesTombstone(block) {
+		return nil
+	}
+	if len(block) <= indexFooterSize {
+		return fmt.Errorf("block length short:%d than footer size: %d", len(block), indexFooterSize)
+	}
+	footerPos := len(block) - indexFooterSize
+	expect := binary.LittleEndian.Uint32(block[footerPos+8 : footerPos+12])
+	if actual := crc32.ChecksumIEEE(block[:footerPos]); actual != expect {
+		return fmt.Errorf("crc32 checksum mismatch, expect: %d, actual: %d", expect, actual)
+	}
+	return nil
+}
+
+// DecodeForwardIndex decodes all series id => tag value id pairs from forward index block of tag key,
+// used for inspecting index data.
+func DecodeForwardIndex(block []byte, fn func(seriesID, tagValueID uint32)) error {
+	reader, err := NewTagForwardReader(block)
+	if err != nil {
+		return err
+	}
+	forwardReader := reader.(*tagForwardReader)
+	for _, highKey := range forwardReader.keys.GetHighKeys() {
+		container, tagValueIDs := forwardReader.GetSeriesAndTagValue(highKey)
+		it := container.PeekableIterator()
+		idx := 0
+		for it.HasNext() && idx < len(tagValueIDs) {
+			fn(encoding.ValueWithHighLowBits(uint32(highKey)<<16, it.Next()), tagValueIDs[idx])
+			idx++
+		}
+	}
+	return nil
+}
+
+// DecodeInvertedIndex decodes all tag value id => series ids from inverted index block of tag key,
+// used for inspecting index data.
+func DecodeInvertedIndex(block []byte, fn func(tagValueID uint32, seriesIDs *roaring.Bitmap)) error {
+	reader, err := newTagInvertedReader(block)
+	if err != nil {
+		return err
+	}
+	it := reader.keys.Iterator()
+	for it.HasNext() {
+		tagValueID := it.Next()
+		seriesIDs, err := reader.getSeriesIDsByTagValueIDs(roaring.BitmapOf(tagValueID))
+		if err != nil {
+			return err
+		}
+		fn(tagValueID, seriesIDs)
+	}
+	return nil
+}
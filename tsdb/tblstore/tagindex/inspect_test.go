@@ -0,0 +1,65 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package tagindex
+
+import (
+	"testing"
+
+	"github.com/lindb/roaring"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyChecksum(t *testing.T) {
+	block := buildForwardBlock()
+	assert.NoError(t, VerifyChecksum(block))
+	zoneBlock, _, _ := buildInvertedIndexBlock()
+	assert.NoError(t, VerifyChecksum(zoneBlock))
+	tombstone, err := EncodeSeriesTombstone(roaring.BitmapOf(1, 2))
+	assert.NoError(t, err)
+	assert.NoError(t, VerifyChecksum(tombstone))
+	// block too short
+	assert.Error(t, VerifyChecksum([]byte{1, 2, 3}))
+	// corrupted block
+	block[0]++
+	assert.Error(t, VerifyChecksum(block))
+}
+
+func TestDecodeForwardIndex(t *testing.T) {
+	result := make(map[uint32]uint32)
+	err := DecodeForwardIndex(buildForwardBlock(), func(seriesID, tagValueID uint32) {
+		result[seriesID] = tagValueID
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[uint32]uint32{
+		1: 1, 2: 2, 3: 3, 4: 4,
+		65535 + 10: 10, 65535 + 20: 20, 65535 + 30: 30, 65535 + 40: 40,
+	}, result)
+	assert.Error(t, DecodeForwardIndex([]byte{1, 2, 3}, nil))
+}
+
+func TestDecodeInvertedIndex(t *testing.T) {
+	_, ipBlock, _ := buildInvertedIndexBlock()
+	result := make(map[uint32]*roaring.Bitmap)
+	err := DecodeInvertedIndex(ipBlock, func(tagValueID uint32, seriesIDs *roaring.Bitmap) {
+		result[tagValueID] = seriesIDs
+	})
+	assert.NoError(t, err)
+	assert.Len(t, result, 10)
+	assert.Equal(t, []uint32{1, 2, 3, 4000000, 5000000, 6000000, 7000000, 8000000, 9000000}, result[0].ToArray())
+	assert.Equal(t, []uint32{4000000}, result[4000000].ToArray())
+	assert.Error(t, DecodeInvertedIndex([]byte{1, 2, 3}, nil))
+}
@@ -51,12 +51,22 @@ func NewInvertedMerger(flusher kv.Flusher) (kv.Merger, error) {
 
 func (m *invertedMerger) Init(_ map[string]interface{}) {}
 
-// Merge merges the multi inverted index data into a inverted index for same tag key id
+// Merge merges the multi inverted index data into a inverted index for same tag key id,
+// the deleted series ids are purged, and series tombstones are dropped after merge.
 func (m *invertedMerger) Merge(key uint32, values [][]byte) error {
 	var scanners []*tagInvertedScanner
 	targetTagValueIDs := roaring.New() // target merged tag value ids
+	deletedSeriesIDs := roaring.New()
 	// 1. prepare tag inverted scanner
 	for _, value := range values {
+		if IsSeriesTombstone(value) {
+			seriesIDs, err := DecodeSeriesTombstone(value)
+			if err != nil {
+				return err
+			}
+			deletedSeriesIDs.Or(seriesIDs)
+			continue
+		}
 		reader, err := newTagInvertedReader(value)
 		if err != nil {
 			return err
@@ -85,6 +95,11 @@ func (m *invertedMerger) Merge(key uint32, values [][]byte) error {
 				}
 			}
 
+			seriesIDs.AndNot(deletedSeriesIDs)
+			if seriesIDs.IsEmpty() {
+				// all series ids of tag value are deleted
+				continue
+			}
 			hk := uint32(highKey) << 16
 			// flush tag value id=>series ids mapping
 			if err := m.invertedFlusher.
@@ -58,14 +58,24 @@ func (r *inverterReader) GetSeriesIDsByTagValueIDs(tagKeyID uint32, tagValueIDs
 	return r.loadSeriesIDs(tagKeyID, fn)
 }
 
-// loadSeriesIDs loads the series ids by tag key id, function need implement condition
+// loadSeriesIDs loads the series ids by tag key id, function need implement condition,
+// the deleted series ids will be removed from result.
 func (r *inverterReader) loadSeriesIDs(tagKeyID uint32, fn func(indexReader *tagInvertedReader) (*roaring.Bitmap, error)) (*roaring.Bitmap, error) {
 	seriesIDs := roaring.New()
+	deletedSeriesIDs := roaring.New()
 	for _, reader := range r.readers {
 		value, err := reader.Get(tagKeyID)
 		if err != nil {
 			continue
 		}
+		if IsSeriesTombstone(value) {
+			deleted, err := DecodeSeriesTombstone(value)
+			if err != nil {
+				return nil, err
+			}
+			deletedSeriesIDs.Or(deleted)
+			continue
+		}
 		indexReader, err := newTagInvertedReader(value)
 		if err != nil {
 			return nil, err
@@ -76,6 +86,7 @@ func (r *inverterReader) loadSeriesIDs(tagKeyID uint32, fn func(indexReader *tag
 		}
 		seriesIDs.Or(ids)
 	}
+	seriesIDs.AndNot(deletedSeriesIDs)
 	return seriesIDs, nil
 }
 
@@ -0,0 +1,58 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tagindex
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/lindb/roaring"
+
+	"github.com/lindb/lindb/pkg/encoding"
+)
+
+// tombstoneMagic is the tail of series tombstone block, which distinguishes tombstone block from index block,
+// index block ends with offsets position(4 bytes)+crc32, offsets position cannot be 0xFFFFFFFF.
+var tombstoneMagic = []byte{0xFF, 0xFF, 0xFF, 0xFF, 'T', 'O', 'M', 'B'}
+
+// EncodeSeriesTombstone encodes the deleted series ids under tag key into binary block.
+// Layout: series ids bitmap + magic
+func EncodeSeriesTombstone(seriesIDs *roaring.Bitmap) ([]byte, error) {
+	data, err := encoding.BitmapMarshal(seriesIDs)
+	if err != nil {
+		return nil, err
+	}
+	return append(data, tombstoneMagic...), nil
+}
+
+// IsSeriesTombstone checks if the block of tag key is series tombstone block.
+func IsSeriesTombstone(block []byte) bool {
+	return len(block) >= len(tombstoneMagic) && bytes.Equal(block[len(block)-len(tombstoneMagic):], tombstoneMagic)
+}
+
+// DecodeSeriesTombstone decodes the deleted series ids from series tombstone block.
+func DecodeSeriesTombstone(block []byte) (*roaring.Bitmap, error) {
+	if !IsSeriesTombstone(block) {
+		return nil, fmt.Errorf("decode series tombstone failure: bad magic")
+	}
+	seriesIDs := roaring.New()
+	if err := seriesIDs.UnmarshalBinary(block[:len(block)-len(tombstoneMagic)]); err != nil {
+		return nil, fmt.Errorf("decode series tombstone failure: %w", err)
+	}
+	return seriesIDs, nil
+}
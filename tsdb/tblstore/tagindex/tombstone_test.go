@@ -0,0 +1,118 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tagindex
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lindb/roaring"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/kv/table"
+)
+
+func TestSeriesTombstone_Encode_Decode(t *testing.T) {
+	block, err := EncodeSeriesTombstone(roaring.BitmapOf(1, 2, 65535+10))
+	assert.NoError(t, err)
+	assert.True(t, IsSeriesTombstone(block))
+	assert.False(t, IsSeriesTombstone(buildForwardBlock()))
+	assert.False(t, IsSeriesTombstone(nil))
+	seriesIDs, err := DecodeSeriesTombstone(block)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{1, 2, 65535 + 10}, seriesIDs.ToArray())
+
+	_, err = DecodeSeriesTombstone([]byte{1, 2, 3})
+	assert.Error(t, err)
+	_, err = DecodeSeriesTombstone(append([]byte{1, 2, 3}, tombstoneMagic...))
+	assert.Error(t, err)
+}
+
+func TestSeriesTombstone_Reader(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tombstone, _ := EncodeSeriesTombstone(roaring.BitmapOf(2, 65535+10))
+	zoneBlock, _, _ := buildInvertedIndexBlock()
+	reader1 := table.NewMockReader(ctrl)
+	reader2 := table.NewMockReader(ctrl)
+	reader1.EXPECT().Get(uint32(20)).Return(zoneBlock, nil).AnyTimes()
+	reader2.EXPECT().Get(uint32(20)).Return(tombstone, nil).AnyTimes()
+	invertedReader := NewInvertedReader([]table.Reader{reader1, reader2})
+	seriesIDs, err := invertedReader.GetSeriesIDsByTagValueIDs(20, roaring.BitmapOf(1, 2))
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{1}, seriesIDs.ToArray())
+
+	reader3 := table.NewMockReader(ctrl)
+	reader3.EXPECT().Get(uint32(20)).Return(buildForwardBlock(), nil).AnyTimes()
+	forwardReader := NewForwardReader([]table.Reader{reader3, reader2})
+	seriesIDs, err = forwardReader.GetSeriesIDsForTagKeyID(20)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{1, 3, 4, 65535 + 20, 65535 + 30, 65535 + 40}, seriesIDs.ToArray())
+	scanners, err := forwardReader.GetGroupingScanner(20, roaring.BitmapOf(1))
+	assert.NoError(t, err)
+	assert.Len(t, scanners, 1)
+
+	// bad tombstone
+	reader4 := table.NewMockReader(ctrl)
+	reader4.EXPECT().Get(uint32(20)).Return(tombstoneMagic, nil).AnyTimes()
+	_, err = NewInvertedReader([]table.Reader{reader4}).GetSeriesIDsByTagValueIDs(20, roaring.BitmapOf(1))
+	assert.Error(t, err)
+	_, err = NewForwardReader([]table.Reader{reader4}).GetSeriesIDsForTagKeyID(20)
+	assert.Error(t, err)
+}
+
+func TestSeriesTombstone_Merge(t *testing.T) {
+	tombstone, _ := EncodeSeriesTombstone(roaring.BitmapOf(1, 3, 65535+10, 65535+20))
+	// forward index
+	nopFlusher := kv.NewNopFlusher()
+	merge, _ := NewForwardMerger(nopFlusher)
+	err := merge.Merge(1, append(mockMergeForwardBlock(), tombstone))
+	assert.NoError(t, err)
+	forward, err := NewTagForwardReader(nopFlusher.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{2, 4, 65535 + 30, 65535 + 40}, forward.getSeriesIDs().ToArray())
+	_, tagValueIDs := forward.GetSeriesAndTagValue(0)
+	assert.Equal(t, []uint32{2, 4}, tagValueIDs)
+	_, tagValueIDs = forward.GetSeriesAndTagValue(1)
+	assert.Equal(t, []uint32{30, 40}, tagValueIDs)
+	// all deleted
+	nopFlusher = kv.NewNopFlusher()
+	merge, _ = NewForwardMerger(nopFlusher)
+	all, _ := EncodeSeriesTombstone(roaring.BitmapOf(1, 2, 3, 4, 65535+10, 65535+20, 65535+30, 65535+40))
+	err = merge.Merge(1, append(mockMergeForwardBlock(), all))
+	assert.NoError(t, err)
+	assert.Nil(t, nopFlusher.Bytes())
+	err = merge.Merge(1, [][]byte{tombstoneMagic})
+	assert.Error(t, err)
+
+	// inverted index
+	nopFlusher = kv.NewNopFlusher()
+	merge, _ = NewInvertedMerger(nopFlusher)
+	tombstone, _ = EncodeSeriesTombstone(roaring.BitmapOf(1, 2))
+	err = merge.Merge(1, append(mockInvertedMergeData(), tombstone))
+	assert.NoError(t, err)
+	inverted, err := newTagInvertedReader(nopFlusher.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{1, 3, 4, 5, 6, 7, 8000000, 9000000}, inverted.keys.ToArray())
+	seriesIDs, _ := inverted.getSeriesIDsByTagValueIDs(roaring.BitmapOf(1))
+	assert.Equal(t, []uint32{10}, seriesIDs.ToArray())
+	err = merge.Merge(1, [][]byte{tombstoneMagic})
+	assert.Error(t, err)
+}